@@ -122,6 +122,7 @@ func init() {
 	_ = prometheus.Register(messagesDropped)
 	_ = prometheus.Register(newConnections)
 	_ = prometheus.Register(websocketErrors)
+	_ = prometheus.Register(transport.CompressionBytesSaved)
 }
 
 // GetDefaultAgentName returns the default agent name
@@ -140,6 +141,7 @@ type Agent struct {
 	api                *http.Server
 	assetGetter        asset.Getter
 	backendSelector    BackendSelector
+	backendDial        transport.DialFunc
 	config             *Config
 	connected          bool
 	connectedMu        sync.RWMutex
@@ -152,6 +154,7 @@ type Agent struct {
 	header             http.Header
 	inProgress         map[string]*corev2.CheckConfig
 	inProgressMu       *sync.Mutex
+	assetsInUse        map[string]int
 	localEntityConfig  *corev3.EntityConfig
 	statsdServer       StatsdServer
 	sendq              chan *transport.Message
@@ -159,12 +162,15 @@ type Agent struct {
 	systemInfoMu       sync.RWMutex
 	wg                 sync.WaitGroup
 	apiQueue           queue
+	spool              *spool
 	marshal            MarshalFunc
 	unmarshal          UnmarshalFunc
 	sequencesMu        sync.Mutex
 	sequences          map[string]int64
 	maxSessionLength   time.Duration
 	keepalivePipelines []*corev2.ResourceReference
+	secretsProvider    SecretsProvider
+	localEvents        *localEventWriter
 
 	// ProcessGetter gets information about local agent processes.
 	ProcessGetter process.Getter
@@ -194,6 +200,7 @@ func NewAgentContext(ctx context.Context, config *Config) (*Agent, error) {
 		entityConfigCh:   make(chan struct{}),
 		inProgress:       make(map[string]*corev2.CheckConfig),
 		inProgressMu:     &sync.Mutex{},
+		assetsInUse:      make(map[string]int),
 		sendq:            make(chan *transport.Message, 10),
 		systemInfo:       &corev2.System{},
 		unmarshal:        UnmarshalJSON,
@@ -203,6 +210,10 @@ func NewAgentContext(ctx context.Context, config *Config) (*Agent, error) {
 		maxSessionLength: config.MaxSessionLength,
 	}
 
+	if config.BackendDNSPolicy == BackendDNSPolicyRotate {
+		agent.backendDial = (&transport.RotatingDialer{}).DialContext
+	}
+
 	agent.statsdServer = NewStatsdServer(agent)
 	agent.handler.AddHandler(transport.MessageTypeEntityConfig, agent.handleEntityConfig)
 
@@ -219,6 +230,18 @@ func NewAgentContext(ctx context.Context, config *Config) (*Agent, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error creating agent: %s", err)
 	}
+	if config.EnableSpool {
+		agent.spool, err = newSpool(config.CacheDir, config.SpoolMaxSize, config.SpoolTTL)
+		if err != nil {
+			return nil, fmt.Errorf("error creating agent: %s", err)
+		}
+	}
+
+	if config.LocalEventSocket != "" {
+		agent.localEvents = newLocalEventWriter(config.LocalEventSocket)
+	} else if config.LocalEventsOnly {
+		return nil, errors.New("error creating agent: local events only requires a local event socket")
+	}
 
 	allowList, err := readAllowList(config.AllowList, ioutil.ReadFile)
 	if err != nil {
@@ -226,6 +249,11 @@ func NewAgentContext(ctx context.Context, config *Config) (*Agent, error) {
 	}
 	agent.allowList = allowList
 
+	agent.secretsProvider, err = NewSecretsProvider(config)
+	if err != nil {
+		return nil, err
+	}
+
 	if config.PrometheusBinding != "" {
 		go func() {
 			logger.WithError(http.ListenAndServe(config.PrometheusBinding, promhttp.Handler())).Error("couldn't serve prometheus metrics")
@@ -241,9 +269,39 @@ func (a *Agent) sendMessage(msg *transport.Message) {
 		"content_type": a.contentType,
 		"payload_size": len(msg.Payload),
 	}).Info("sending message")
+	if a.spool != nil && !a.Connected() {
+		a.spoolMessage(msg)
+		return
+	}
 	a.sendq <- msg
 }
 
+// spoolMessage persists a message that can't be sent to the backend, so that
+// it is replayed once the agent reconnects. The send callback of the message
+// is called once the message is spooled.
+func (a *Agent) spoolMessage(msg *transport.Message) {
+	err := a.spool.Push(msg)
+	if err != nil {
+		messagesDropped.WithLabelValues().Inc()
+		logger.WithError(err).Error("couldn't spool message")
+	} else {
+		logger.WithField("type", msg.Type).Debug("backend unreachable, message spooled")
+	}
+	if msg.SendCallback != nil {
+		msg.SendCallback(err)
+	}
+}
+
+// replaySpool sends the spooled messages with send, oldest first.
+func (a *Agent) replaySpool(send func(*transport.Message) error) error {
+	sent, err := a.spool.Replay(send)
+	if sent > 0 {
+		messagesSent.WithLabelValues().Add(float64(sent))
+		logger.WithField("count", sent).Info("replayed spooled messages")
+	}
+	return err
+}
+
 // RefreshSystemInfo refreshes system, platform, and process information.
 func (a *Agent) RefreshSystemInfo(ctx context.Context) error {
 	var info corev2.System
@@ -326,6 +384,11 @@ func (a *Agent) Run(ctx context.Context) error {
 		if err := a.apiQueue.Close(); err != nil {
 			logger.WithError(err).Error("error closing API queue")
 		}
+		if a.spool != nil {
+			if err := a.spool.Close(); err != nil {
+				logger.WithError(err).Error("error closing event spool")
+			}
+		}
 	}()
 	defer cancel()
 	a.header = a.buildTransportHeaderMap()
@@ -355,7 +418,11 @@ func (a *Agent) Run(ctx context.Context) error {
 		if u, err := url.Parse(burl); err != nil {
 			return fmt.Errorf("bad backend URL (%s): %s", burl, err)
 		} else {
-			if u.Scheme != "ws" && u.Scheme != "wss" {
+			if a.config.BackendTransport == BackendTransportHTTP {
+				if u.Scheme != "http" && u.Scheme != "https" {
+					return fmt.Errorf("backend URL (%s) must have http:// or https:// scheme", burl)
+				}
+			} else if u.Scheme != "ws" && u.Scheme != "wss" {
 				return fmt.Errorf("backend URL (%s) must have ws:// or wss:// scheme", burl)
 			}
 		}
@@ -378,6 +445,7 @@ func (a *Agent) Run(ctx context.Context) error {
 			trustedCAFile = a.config.TLS.TrustedCAFile
 		}
 		assetManager := asset.NewManager(a.config.CacheDir, trustedCAFile, a.getAgentEntity(), &a.wg)
+		assetManager.LimitCacheSize(a.config.AssetsCacheMaxSize, a.assetInUse)
 		limit := a.config.AssetsRateLimit
 		if limit == 0 {
 			limit = rate.Limit(asset.DefaultAssetsRateLimit)
@@ -406,9 +474,16 @@ func (a *Agent) Run(ctx context.Context) error {
 	// Increment the waitgroup counter here too in case none of the components
 	// above were started, and rely on the system info collector to decrement it
 	// once it exits
-	go a.connectionManager(ctx, cancel)
+	if a.config.BackendTransport == BackendTransportHTTP {
+		go a.httpSendLoop(ctx, cancel)
+	} else {
+		go a.connectionManager(ctx, cancel)
+	}
 	go a.refreshSystemInfoPeriodically(ctx)
 	go a.handleAPIQueue(ctx)
+	if a.localEvents != nil {
+		go a.localEvents.run(ctx)
+	}
 
 	// Wait for context to complete
 	<-ctx.Done()
@@ -587,6 +662,12 @@ func (a *Agent) sendLoop(ctx context.Context, cancel context.CancelFunc, conn tr
 		logger.WithError(err).Error("error sending message over websocket")
 		return err
 	}
+	if a.spool != nil {
+		if err := a.replaySpool(conn.Send); err != nil {
+			logger.WithError(err).Error("error replaying spooled messages")
+			return err
+		}
+	}
 	for {
 		select {
 		case <-ctx.Done():
@@ -597,14 +678,15 @@ func (a *Agent) sendLoop(ctx context.Context, cancel context.CancelFunc, conn tr
 			return nil
 		case msg := <-a.sendq:
 			if err := conn.Send(msg); err != nil {
-				messagesDropped.WithLabelValues().Inc()
+				a.handleSendError(msg)
 				logger.WithError(err).Error("error sending message over websocket")
 				return err
 			}
 			messagesSent.WithLabelValues().Inc()
 		case <-keepalive.C:
-			if err := conn.Send(a.newKeepalive()); err != nil {
-				messagesDropped.WithLabelValues().Inc()
+			msg := a.newKeepalive()
+			if err := conn.Send(msg); err != nil {
+				a.handleSendError(msg)
 				logger.WithError(err).Error("error sending message over websocket")
 				return err
 			}
@@ -613,6 +695,17 @@ func (a *Agent) sendLoop(ctx context.Context, cancel context.CancelFunc, conn tr
 	}
 }
 
+// handleSendError spools a message that could not be sent over the websocket,
+// or drops it if the spool is disabled. The messages that have a send callback
+// are retried by their sender, and are never spooled.
+func (a *Agent) handleSendError(msg *transport.Message) {
+	if a.spool == nil || msg.SendCallback != nil {
+		messagesDropped.WithLabelValues().Inc()
+		return
+	}
+	a.spoolMessage(msg)
+}
+
 func (a *Agent) nextSequence(check string) int64 {
 	a.sequencesMu.Lock()
 	defer a.sequencesMu.Unlock()
@@ -742,6 +835,7 @@ func (a *Agent) connectWithBackoff(ctx context.Context) (transport.Transport, er
 		InitialDelayInterval: a.config.RetryMin,
 		MaxDelayInterval:     a.config.RetryMax,
 		Multiplier:           a.config.RetryMultiplier,
+		Jitter:               a.config.RetryJitter,
 		Ctx:                  ctx,
 	}
 
@@ -751,7 +845,7 @@ func (a *Agent) connectWithBackoff(ctx context.Context) (transport.Transport, er
 		logger.Infof("connecting to backend URL %q", backendURL)
 		a.header.Set("Accept", ProtobufSerializationHeader)
 		logger.WithField("header", fmt.Sprintf("Accept: %s", ProtobufSerializationHeader)).Debug("setting header")
-		c, respHeader, err := transport.Connect(backendURL, a.config.TLS, a.header, a.config.BackendHandshakeTimeout)
+		c, respHeader, err := transport.ConnectWithDialer(backendURL, a.config.TLS, a.header, a.config.BackendHandshakeTimeout, a.backendDial)
 		if err != nil {
 			if err == transport.ErrTooManyRequests {
 				// Give the backend extra breathing room
@@ -18,6 +18,7 @@ import (
 	"github.com/sensu/sensu-go/token"
 	"github.com/sensu/sensu-go/transport"
 	"github.com/sensu/sensu-go/util/environment"
+	"github.com/sensu/sensu-go/util/metricthresholds"
 	"github.com/sirupsen/logrus"
 )
 
@@ -26,9 +27,10 @@ const (
 	allowListOnDenyOutput        = "check command denied by the agent allow list"
 	undocumentedTestCheckCommand = "!sensu_test_check!"
 
-	measureMin        = "min"
-	measureMax        = "max"
-	measureNullStatus = "null-status"
+	// DroppedMetricPointsAnnotation is the event annotation holding the number
+	// of metric points dropped because the check output exceeded the maximum
+	// number of metric points per event.
+	DroppedMetricPointsAnnotation = "sensu.io/dropped_metric_points"
 )
 
 // handleCheck is the check message handler.
@@ -101,27 +103,154 @@ func checkKey(request *corev2.CheckRequest) string {
 func (a *Agent) addInProgress(request *corev2.CheckRequest) {
 	a.inProgressMu.Lock()
 	a.inProgress[checkKey(request)] = request.Config
+	a.pinAssetsLocked(assetSHA512s(request))
 	a.inProgressMu.Unlock()
 }
 
 func (a *Agent) removeInProgress(request *corev2.CheckRequest) {
 	a.inProgressMu.Lock()
 	delete(a.inProgress, checkKey(request))
+	a.unpinAssetsLocked(assetSHA512s(request))
 	a.inProgressMu.Unlock()
 }
 
+// pinAssets marks the assets as in use until unpinAssets is called with the
+// same assets, so that they are not evicted from the asset cache meanwhile.
+func (a *Agent) pinAssets(sha512s []string) {
+	a.inProgressMu.Lock()
+	a.pinAssetsLocked(sha512s)
+	a.inProgressMu.Unlock()
+}
+
+// unpinAssets releases the assets pinned by pinAssets.
+func (a *Agent) unpinAssets(sha512s []string) {
+	a.inProgressMu.Lock()
+	a.unpinAssetsLocked(sha512s)
+	a.inProgressMu.Unlock()
+}
+
+func (a *Agent) pinAssetsLocked(sha512s []string) {
+	for _, sha512 := range sha512s {
+		a.assetsInUse[sha512]++
+	}
+}
+
+func (a *Agent) unpinAssetsLocked(sha512s []string) {
+	for _, sha512 := range sha512s {
+		if a.assetsInUse[sha512]--; a.assetsInUse[sha512] <= 0 {
+			delete(a.assetsInUse, sha512)
+		}
+	}
+}
+
+// assetInUse returns true if the asset is used by a check or a hook being
+// executed, in which case it must not be evicted from the asset cache.
+func (a *Agent) assetInUse(sha512 string) bool {
+	a.inProgressMu.Lock()
+	defer a.inProgressMu.Unlock()
+	return a.assetsInUse[sha512] > 0
+}
+
+// assetSHA512s returns the SHA512 of the assets of the check request, of its
+// hooks, and of their builds, any of which the check may use.
+func assetSHA512s(request *corev2.CheckRequest) []string {
+	return append(assetListSHA512s(request.Assets), hookAssetSHA512s(request.HookAssets)...)
+}
+
+// hookAssetSHA512s returns the SHA512 of the assets of the hooks, and of their
+// builds.
+func hookAssetSHA512s(hookAssets map[string]*corev2.AssetList) []string {
+	var sha512s []string
+	for _, assets := range hookAssets {
+		if assets != nil {
+			sha512s = append(sha512s, assetListSHA512s(assets.Assets)...)
+		}
+	}
+	return sha512s
+}
+
+func assetListSHA512s(assets []corev2.Asset) []string {
+	var sha512s []string
+	for _, a := range assets {
+		if a.Sha512 != "" {
+			sha512s = append(sha512s, a.Sha512)
+		}
+		for _, build := range a.Builds {
+			sha512s = append(sha512s, build.Sha512)
+		}
+	}
+	return sha512s
+}
+
 func (a *Agent) executeCheck(ctx context.Context, request *corev2.CheckRequest, entity *corev2.Entity) {
+	// Offset the execution of the checks spreading their executions over
+	// their interval. The check is only marked in progress once the delay is
+	// over, so that the delay does not get the next request rejected.
+	if splay := checkSplay(request.Config, entity.Name); splay > 0 {
+		logger.WithField("check", request.Config.Name).Debugf("delaying check execution by %s", splay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(splay):
+		}
+	}
+
 	a.addInProgress(request)
 	defer a.removeInProgress(request)
 
+	event, ex, err := a.prepareCheck(ctx, request, entity)
+	if err != nil {
+		a.sendFailure(event, err)
+		return
+	}
+
+	a.runCheck(ctx, request, event, ex)
+
+	logEvent(event)
+
+	a.sendCheckResult(event)
+}
+
+// sendCheckResult writes the check result to the local event socket, if any,
+// then sends it to the backend unless the check results are only written
+// locally. The local write comes first since it never blocks, while sending
+// the message blocks while the agent is disconnected.
+func (a *Agent) sendCheckResult(event *corev2.Event) {
+	if a.localEvents != nil {
+		a.localEvents.Write(event)
+	}
+	if a.config.LocalEventsOnly {
+		return
+	}
+
+	msg, err := a.marshal(event)
+	if err != nil {
+		logger.WithError(err).Error("error marshaling check result")
+		return
+	}
+
+	tm := &transport.Message{
+		Type:    transport.MessageTypeEvent,
+		Payload: msg,
+	}
+	a.sendMessage(tm)
+}
+
+// prepareCheck performs token substitution, enforces the allow list, fetches
+// the assets and prepares the environment and standard input of the check
+// command. It returns the event that will hold the check result, along with the
+// execution request of the command. If an error is returned, the event can be
+// used to report the failure.
+func (a *Agent) prepareCheck(ctx context.Context, request *corev2.CheckRequest, entity *corev2.Entity) (*corev2.Event, command.ExecutionRequest, error) {
 	checkAssets := request.Assets
 	checkConfig := request.Config
-	checkHooks := request.Hooks
-	hookAssets := request.HookAssets
 	secrets := request.Secrets
 
+	var ex command.ExecutionRequest
+
 	// Before token subsitution we retain copy of the command
 	origCommand := checkConfig.Command
+	origArgv := checkConfig.Argv
 	createEvent := func() *corev2.Event {
 		event := &corev2.Event{}
 		event.Namespace = checkConfig.Namespace
@@ -133,6 +262,7 @@ func (a *Agent) executeCheck(ctx context.Context, request *corev2.CheckRequest,
 		// To guard against publishing sensitive/redacted client attribute values
 		// the original command value is reinstated.
 		event.Check.Command = origCommand
+		event.Check.Argv = origArgv
 
 		event.Sequence = a.nextSequence(checkConfig.Name)
 
@@ -144,8 +274,7 @@ func (a *Agent) executeCheck(ctx context.Context, request *corev2.CheckRequest,
 		// we aren't doing load testing with the undocumented test check
 		// command.
 		if err := token.SubstituteCheck(checkConfig, entity); err != nil {
-			a.sendFailure(createEvent(), fmt.Errorf("error while substituting check tokens: %s", err))
-			return
+			return createEvent(), ex, fmt.Errorf("error while substituting check tokens: %s", err)
 		}
 	}
 
@@ -166,11 +295,10 @@ func (a *Agent) executeCheck(ctx context.Context, request *corev2.CheckRequest,
 	var match bool
 	if len(a.allowList) != 0 {
 		logger.WithFields(fields).Debug("matching check against agent allow list")
-		matchedEntry, match = a.matchAllowList(checkConfig.Command)
+		matchedEntry, match = a.matchAllowList(checkCommandLine(checkConfig))
 		if !match {
 			logger.WithFields(fields).Debug("check does not match agent allow list")
-			a.sendFailure(event, fmt.Errorf(allowListOnDenyOutput))
-			return
+			return event, ex, fmt.Errorf(allowListOnDenyOutput)
 		}
 		logger.WithFields(fields).Debug("check matches agent allow list")
 	}
@@ -184,8 +312,7 @@ func (a *Agent) executeCheck(ctx context.Context, request *corev2.CheckRequest,
 		var err error
 		assets, err = asset.GetAll(ctx, a.assetGetter, checkAssets)
 		if err != nil {
-			a.sendFailure(event, fmt.Errorf("error getting assets for check: %s", err))
-			return
+			return event, ex, fmt.Errorf("error getting assets for check: %s", err)
 		}
 	}
 
@@ -195,53 +322,81 @@ func (a *Agent) executeCheck(ctx context.Context, request *corev2.CheckRequest,
 		logger.WithFields(fields).Debug("disabling check env vars per the agent allow list")
 		env = environment.MergeEnvironments(os.Environ(), assets.Env(), secrets)
 	} else {
-		env = environment.MergeEnvironments(os.Environ(), assets.Env(), secrets, checkConfig.EnvVars)
+		envVars, err := resolveSecretRefs(ctx, a.secretsProvider, checkConfig.EnvVars, secretEnvVars(checkConfig.Annotations))
+		if err != nil {
+			return event, ex, err
+		}
+		env = environment.MergeEnvironments(os.Environ(), assets.Env(), secrets, envVars)
 	}
 
 	// Verify sha against the allow list
 	if matchedEntry.Sha512 != "" {
 		logger.WithFields(fields).Debug("matching check sha against agent allow list")
-		path, err := lookPath(strings.Split(checkConfig.Command, " ")[0], env)
+		path, err := lookPath(checkExecutable(checkConfig), env)
 		if err != nil {
 			logger.WithFields(fields).WithError(err).Error("unable to find the executable path")
-			a.sendFailure(event, fmt.Errorf(allowListOnDenyOutput))
-			return
+			return event, ex, fmt.Errorf(allowListOnDenyOutput)
 		}
 		file, err := os.Open(path)
 		if err != nil {
 			logger.WithFields(fields).WithError(err).Error("unable to open executable")
-			a.sendFailure(event, fmt.Errorf(allowListOnDenyOutput))
-			return
+			return event, ex, fmt.Errorf(allowListOnDenyOutput)
 		}
 		verifier := asset.Sha512Verifier{}
 		if err := verifier.Verify(file, matchedEntry.Sha512); err != nil {
 			logger.WithFields(fields).WithError(err).Error("check sha does not match agent allow list")
-			a.sendFailure(event, fmt.Errorf(allowListOnDenyOutput))
-			return
+			return event, ex, fmt.Errorf(allowListOnDenyOutput)
 		}
 	}
 
 	// Inject the dependencies into PATH, LD_LIBRARY_PATH & CPATH so that they
 	// are availabe when when the command is executed.
-	ex := command.ExecutionRequest{
+	ex = command.ExecutionRequest{
 		Env:          env,
 		Command:      checkConfig.Command,
+		Argv:         checkConfig.Argv,
 		Timeout:      int(checkConfig.Timeout),
 		InProgress:   a.inProgress,
 		InProgressMu: a.inProgressMu,
 		Name:         checkConfig.Name,
+		User:         checkConfig.User,
+		Group:        checkConfig.Group,
 	}
 
 	// If stdin is true, add JSON event data to command execution.
 	if checkConfig.Stdin {
 		input, err := json.Marshal(event)
 		if err != nil {
-			a.sendFailure(event, fmt.Errorf("error marshaling json from event: %s", err))
-			return
+			return event, ex, fmt.Errorf("error marshaling json from event: %s", err)
 		}
 		ex.Input = string(input)
 	}
 
+	return event, ex, nil
+}
+
+// checkCommandLine returns the command line of the check, made of its argv if
+// set, or its command otherwise.
+func checkCommandLine(check *corev2.CheckConfig) string {
+	if len(check.Argv) > 0 {
+		return strings.Join(check.Argv, " ")
+	}
+	return check.Command
+}
+
+// checkExecutable returns the executable the check runs.
+func checkExecutable(check *corev2.CheckConfig) string {
+	if len(check.Argv) > 0 {
+		return check.Argv[0]
+	}
+	return strings.Split(check.Command, " ")[0]
+}
+
+// runCheck executes the check command and populates the event with its
+// result, its metrics and the result of its hooks.
+func (a *Agent) runCheck(ctx context.Context, request *corev2.CheckRequest, event *corev2.Event, ex command.ExecutionRequest) {
+	check := event.Check
+
 	checkExec, err := a.executor.Execute(context.Background(), ex)
 	if err != nil {
 		event.Check.Output = err.Error()
@@ -266,10 +421,14 @@ func (a *Agent) executeCheck(ctx context.Context, request *corev2.CheckRequest,
 	}
 
 	if check.OutputMetricFormat != "" {
-		event.Metrics.Points = extractMetrics(event)
+		var dropped int
+		event.Metrics.Points, dropped = extractMetrics(event, a.config.MaxMetricPoints)
+		annotateDroppedMetricPoints(event, dropped, a.config.MaxMetricPoints)
+		applyMetricTagRules(event.Metrics.Points, check.Name, a.config.MetricTagRules)
+		injectEntityTags(event.Metrics.Points, event.Entity, a.config.MetricsEntityTags)
 
 		if event.Check.Status == 0 && len(event.Metrics.Points) > 0 && len(check.OutputMetricThresholds) > 0 {
-			event.Check.Status = evaluateOutputMetricThresholds(event)
+			event.Check.Status = metricthresholds.Evaluate(event)
 		}
 	}
 
@@ -278,8 +437,8 @@ func (a *Agent) executeCheck(ctx context.Context, request *corev2.CheckRequest,
 	}
 
 	// Execute hooks after we have a completely populated event object
-	if len(checkHooks) != 0 {
-		event.Check.Hooks = a.ExecuteHooks(ctx, request, event, hookAssets)
+	if len(request.Hooks) != 0 {
+		event.Check.Hooks = a.ExecuteHooks(ctx, request, event, request.HookAssets)
 	}
 
 	// The check requested that we discard its output before writing back
@@ -287,21 +446,6 @@ func (a *Agent) executeCheck(ctx context.Context, request *corev2.CheckRequest,
 	if event.Check.DiscardOutput {
 		event.Check.Output = ""
 	}
-
-	msg, err := a.marshal(event)
-	if err != nil {
-		logger.WithError(err).Error("error marshaling check result")
-		return
-	}
-
-	tm := &transport.Message{
-		Type:    transport.MessageTypeEvent,
-		Payload: msg,
-	}
-
-	logEvent(event)
-
-	a.sendMessage(tm)
 }
 
 func (a *Agent) sendFailure(event *corev2.Event, err error) {
@@ -323,209 +467,99 @@ func (a *Agent) sendFailure(event *corev2.Event, err error) {
 		}
 	}
 
-	if msg, err := a.marshal(event); err != nil {
-		logger.WithError(err).Error("error marshaling check failure")
-	} else {
-		tm := &transport.Message{
-			Type:    transport.MessageTypeEvent,
-			Payload: msg,
-		}
-		a.sendMessage(tm)
-	}
+	a.sendCheckResult(event)
 }
 
-func extractMetrics(event *corev2.Event) []*corev2.MetricPoint {
+// extractMetrics extracts at most max metric points from the check output of
+// the event, or all of them if max is 0, and returns them along with the
+// number of points dropped. The line based formats stop parsing the output
+// once max points are extracted; the nagios and prometheus outputs are parsed
+// in full before the extra points are dropped.
+func extractMetrics(event *corev2.Event, max int) ([]*corev2.MetricPoint, int) {
 	var transformer Transformer
+	var dropped int
 	if !event.HasCheck() {
 		logger.WithError(transformers.ErrMetricExtraction).Error("event must contain a check to parse and extract metrics")
-		return nil
+		return nil, 0
 	}
 
 	switch event.Check.OutputMetricFormat {
 	case corev2.GraphiteOutputMetricFormat:
-		transformer = transformers.ParseGraphite(event)
+		var list transformers.GraphiteList
+		list, dropped = transformers.ParseGraphiteN(event, max)
+		transformer = list
 	case corev2.InfluxDBOutputMetricFormat:
-		transformer = transformers.ParseInflux(event)
+		var list transformers.InfluxList
+		list, dropped = transformers.ParseInfluxN(event, max)
+		transformer = list
 	case corev2.NagiosOutputMetricFormat:
 		transformer = transformers.ParseNagios(event)
 	case corev2.OpenTSDBOutputMetricFormat:
-		transformer = transformers.ParseOpenTSDB(event)
+		var list transformers.OpenTSDBList
+		list, dropped = transformers.ParseOpenTSDBN(event, max)
+		transformer = list
 	case corev2.PrometheusOutputMetricFormat:
 		transformer = transformers.ParseProm(event)
 	}
 
 	if transformer == nil {
 		logger.WithField("format", event.Check.OutputMetricFormat).WithError(transformers.ErrMetricExtraction).Error("output metric format is not supported")
-		return nil
-	}
-
-	return transformer.Transform()
-}
-
-func evaluateOutputMetricThresholds(event *corev2.Event) uint32 {
-	if event.Check.Status > 0 {
-		return event.Check.Status
-	}
-
-	points := event.Metrics.Points
-	thresholds := event.Check.OutputMetricThresholds
-
-	var status uint32 = 0
-	annotationValue := ""
-	for _, thresholdRule := range thresholds {
-		ruleMatched := false
-		for _, metricPoint := range points {
-			if thresholdRule.MatchesMetricPoint(metricPoint) {
-				ruleMatched = true
-				for _, rule := range thresholdRule.Thresholds {
-					if rule.Min != "" {
-						min, err := strconv.ParseFloat(rule.Min, 64)
-						if err != nil {
-							continue
-						}
-						if metricPoint.Value < min {
-							addThresholdAnnotation(event, thresholdRule, measureMin, rule.Status, metricPoint.Value, rule.Min)
-							if status < rule.Status {
-								status = rule.Status
-								annotationValue = getAnnotationValue(thresholdRule, measureMin, metricPoint.Value, rule.Min)
-							}
-							continue
-						}
-					}
-					if rule.Max != "" {
-						max, err := strconv.ParseFloat(rule.Max, 64)
-						if err != nil {
-							continue
-						}
-						if metricPoint.Value > max {
-							addThresholdAnnotation(event, thresholdRule, measureMax, rule.Status, metricPoint.Value, rule.Max)
-							if status < rule.Status {
-								status = rule.Status
-								annotationValue = getAnnotationValue(thresholdRule, measureMax, metricPoint.Value, rule.Max)
-							}
-						}
-					}
-				}
-			}
-		}
-		if !ruleMatched {
-			if thresholdRule.NullStatus > 0 {
-				addNullStatusThresholdAnnotation(event, thresholdRule, thresholdRule.NullStatus)
-				if status < thresholdRule.NullStatus {
-					status = thresholdRule.NullStatus
-					annotationValue = getNullStatusAnnotationValue(thresholdRule)
-				}
-			}
-		}
+		return nil, 0
 	}
 
-	if annotationValue != "" {
-		event.AddAnnotation("sensu.io/notifications/"+corev2.CheckStatusToCaption(status), annotationValue)
+	points := transformer.Transform()
+	if max > 0 && len(points) > max {
+		dropped += len(points) - max
+		// Copy the points that are kept so that the dropped ones can be
+		// garbage collected along with the original slice
+		points = append([]*corev2.MetricPoint(nil), points[:max]...)
 	}
-
-	return status
-}
-
-func addThresholdAnnotation(event *corev2.Event, metricThreshold *corev2.MetricThreshold, measure string, status uint32, value float64, threshold string) {
-	event.AddAnnotation(getAnnotationKey(metricThreshold, measure, status), getAnnotationValue(metricThreshold, measure, value, threshold))
+	return points, dropped
 }
 
-func addNullStatusThresholdAnnotation(event *corev2.Event, metricThreshold *corev2.MetricThreshold, status uint32) {
-	event.AddAnnotation(getAnnotationKey(metricThreshold, measureNullStatus, status), getNullStatusAnnotationValue(metricThreshold))
-}
-
-func getAnnotationKey(metricThreshold *corev2.MetricThreshold, measure string, status uint32) string {
-	var key strings.Builder
-
-	key.WriteString("sensu.io/output_metric_thresholds/")
-	key.WriteString(metricThreshold.Name)
-	for _, tag := range metricThreshold.Tags {
-		key.WriteString(".")
-		key.WriteString(tag.Value)
+// annotateDroppedMetricPoints annotates the event with the number of metric
+// points dropped because the check output exceeded max, if any.
+func annotateDroppedMetricPoints(event *corev2.Event, dropped, max int) {
+	if dropped <= 0 {
+		return
 	}
-	key.WriteString("/")
-	key.WriteString(measure)
-	key.WriteString("/")
-	key.WriteString(corev2.CheckStatusToCaption(status))
-
-	return key.String()
-}
-
-func getAnnotationValue(metricThreshold *corev2.MetricThreshold, measure string, value float64, threshold string) string {
-	var val strings.Builder
-	var tagsKeyVal strings.Builder
-
-	for tagIdx, tag := range metricThreshold.Tags {
-		if tagIdx > 0 {
-			tagsKeyVal.WriteString(",")
-		}
-		tagsKeyVal.WriteString(tag.Name)
-		tagsKeyVal.WriteString("=")
-		tagsKeyVal.WriteString(tag.Value)
-	}
-
-	val.WriteString("The value of ")
-	val.WriteString(metricThreshold.Name)
-	if tagsKeyVal.Len() > 0 {
-		val.WriteString(" (")
-		val.WriteString(tagsKeyVal.String())
-		val.WriteString(")")
-	}
-	val.WriteString(" exceeded the configured threshold (")
-	val.WriteString(measure)
-	val.WriteString(": ")
-	val.WriteString(threshold)
-	val.WriteString(", actual: ")
-	val.WriteString(strconv.FormatFloat(value, 'f', -1, 64))
-	val.WriteString(").")
-
-	return val.String()
+	event.AddAnnotation(DroppedMetricPointsAnnotation, strconv.Itoa(dropped))
+	logger.WithFields(logrus.Fields{
+		"check":   event.Check.Name,
+		"dropped": dropped,
+		"max":     max,
+	}).Warn("check output has too many metric points, dropping the extra points")
 }
 
-func getNullStatusAnnotationValue(metricThreshold *corev2.MetricThreshold) string {
-	var val strings.Builder
-	var tagsKeyVal strings.Builder
+// injectEntityTags adds the entity labels, or annotations, with the given names
+// as tags of the metric points. Tags already present on a point take
+// precedence over the entity ones.
+func injectEntityTags(points []*corev2.MetricPoint, entity *corev2.Entity, names []string) {
+	if len(points) == 0 || entity == nil || len(names) == 0 {
+		return
+	}
 
-	for tagIdx, tag := range metricThreshold.Tags {
-		if tagIdx > 0 {
-			tagsKeyVal.WriteString(", ")
+	var tags []*corev2.MetricTag
+	for _, name := range names {
+		value, ok := entity.Labels[name]
+		if !ok {
+			value, ok = entity.Annotations[name]
 		}
-		tagsKeyVal.WriteString(tag.Name)
-		tagsKeyVal.WriteString("=\"")
-		tagsKeyVal.WriteString(tag.Value)
-		tagsKeyVal.WriteString("\"")
-	}
-
-	val.WriteString(strings.ToUpper(corev2.CheckStatusToCaption(metricThreshold.NullStatus)))
-	val.WriteString(" : no metric matching \"")
-	val.WriteString(metricThreshold.Name)
-	val.WriteString("\"")
-	if tagsKeyVal.Len() > 0 {
-		val.WriteString(" (")
-		val.WriteString(tagsKeyVal.String())
-		val.WriteString(")")
-	}
-	val.WriteString(" was found")
-
-	for _, t := range metricThreshold.Thresholds {
-		hasMin := len(t.Min) > 0
-		hasMax := len(t.Max) > 0
-		val.WriteString("; expected ")
-		if hasMin {
-			val.WriteString("min: ")
-			val.WriteString(t.Min)
+		if ok {
+			tags = append(tags, &corev2.MetricTag{Name: name, Value: value})
 		}
-		if hasMin && hasMax {
-			val.WriteString(" - ")
+	}
+
+	for _, point := range points {
+		existing := make(map[string]struct{}, len(point.Tags))
+		for _, tag := range point.Tags {
+			existing[tag.Name] = struct{}{}
 		}
-		if hasMax {
-			val.WriteString("max: ")
-			val.WriteString(t.Max)
+		for _, tag := range tags {
+			if _, ok := existing[tag.Name]; ok {
+				continue
+			}
+			point.Tags = append(point.Tags, &corev2.MetricTag{Name: tag.Name, Value: tag.Value})
 		}
-		val.WriteString(" (status: ")
-		val.WriteString(corev2.CheckStatusToCaption(t.Status))
-		val.WriteString(")")
 	}
-
-	return val.String()
 }
@@ -6,9 +6,11 @@ package agent
 import (
 	"context"
 	"encoding/json"
+	"os/user"
 	"testing"
 	"time"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/transport"
 	"github.com/sensu/sensu-go/types"
 	"github.com/stretchr/testify/assert"
@@ -38,3 +40,105 @@ func TestEnvVars(t *testing.T) {
 	assert.NotZero(t, event.Timestamp)
 	assert.Equal(t, event.Check.Output, "BAR\n")
 }
+
+func TestEnvVarsSecretRefs(t *testing.T) {
+	t.Setenv("SENSU_TEST_SECRET", "hunter2")
+
+	checkConfig := types.FixtureCheckConfig("check")
+	checkConfig.EnvVars = []string{"FOO=SENSU_TEST_SECRET"}
+	checkConfig.Annotations = map[string]string{corev2.CheckSecretEnvVarsAnnotation: "FOO"}
+	request := &types.CheckRequest{Config: checkConfig, Issued: time.Now().Unix()}
+	checkConfig.Command = "echo $FOO"
+
+	config, cleanup := FixtureConfig()
+	defer cleanup()
+	agent, err := NewAgent(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch := make(chan *transport.Message, 1)
+	agent.sendq = ch
+
+	entity := agent.getAgentEntity()
+	agent.executeCheck(context.Background(), request, entity)
+	msg := <-ch
+	event := &types.Event{}
+	assert.NoError(t, json.Unmarshal(msg.Payload, event))
+	assert.Equal(t, "hunter2\n", event.Check.Output)
+}
+
+func TestEnvVarsArgv(t *testing.T) {
+	checkConfig := types.FixtureCheckConfig("check")
+	checkConfig.EnvVars = []string{"FOO=BAR"}
+	request := &types.CheckRequest{Config: checkConfig, Issued: time.Now().Unix()}
+	checkConfig.Argv = []string{"sh", "-c", "echo $FOO $0", "$FOO"}
+
+	config, cleanup := FixtureConfig()
+	defer cleanup()
+	agent, err := NewAgent(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch := make(chan *transport.Message, 1)
+	agent.sendq = ch
+
+	entity := agent.getAgentEntity()
+	agent.executeCheck(context.Background(), request, entity)
+	msg := <-ch
+	event := &types.Event{}
+	assert.NoError(t, json.Unmarshal(msg.Payload, event))
+	assert.Equal(t, "BAR $FOO\n", event.Check.Output)
+	assert.Equal(t, checkConfig.Argv, event.Check.Argv)
+}
+
+func TestCheckUser(t *testing.T) {
+	current, err := user.Current()
+	if err != nil {
+		t.Skip(err)
+	}
+
+	tests := []struct {
+		name       string
+		user       string
+		wantStatus uint32
+		wantOutput string
+	}{
+		{
+			name:       "current user",
+			user:       current.Username,
+			wantStatus: 0,
+			wantOutput: "foo\n",
+		},
+		{
+			name:       "unknown user",
+			user:       "sensu-test-unknown-user",
+			wantStatus: 3,
+			wantOutput: `user "sensu-test-unknown-user" does not exist`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkConfig := types.FixtureCheckConfig("check")
+			checkConfig.Command = "echo foo"
+			checkConfig.User = tt.user
+			request := &types.CheckRequest{Config: checkConfig, Issued: time.Now().Unix()}
+
+			config, cleanup := FixtureConfig()
+			defer cleanup()
+			agent, err := NewAgent(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ch := make(chan *transport.Message, 1)
+			agent.sendq = ch
+
+			entity := agent.getAgentEntity()
+			agent.executeCheck(context.Background(), request, entity)
+			msg := <-ch
+			event := &types.Event{}
+			assert.NoError(t, json.Unmarshal(msg.Payload, event))
+			assert.Equal(t, tt.wantStatus, event.Check.Status)
+			assert.Contains(t, event.Check.Output, tt.wantOutput)
+		})
+	}
+}
@@ -3,7 +3,6 @@ package agent
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"testing"
 	"time"
 
@@ -118,6 +117,37 @@ func TestProxyCheckInProgress_GH2704(t *testing.T) {
 	assert.Empty(val)
 }
 
+func TestAssetsInUse(t *testing.T) {
+	checkConfig := corev2.FixtureCheckConfig("check")
+	request := &corev2.CheckRequest{
+		Config: checkConfig,
+		Assets: []corev2.Asset{{Sha512: "check-asset"}},
+		HookAssets: map[string]*corev2.AssetList{
+			"hook": {Assets: []corev2.Asset{{Builds: []*corev2.AssetBuild{{Sha512: "hook-asset"}}}}},
+		},
+	}
+
+	config, cleanup := FixtureConfig()
+	defer cleanup()
+	agent, err := NewAgent(config)
+	require.NoError(t, err)
+
+	agent.addInProgress(request)
+	assert.True(t, agent.assetInUse("check-asset"))
+	assert.True(t, agent.assetInUse("hook-asset"))
+
+	// the hook assets stay pinned while the hooks are executed, even once the
+	// check is no longer in progress
+	sha512s := hookAssetSHA512s(request.HookAssets)
+	agent.pinAssets(sha512s)
+	agent.removeInProgress(request)
+	assert.False(t, agent.assetInUse("check-asset"))
+	assert.True(t, agent.assetInUse("hook-asset"))
+
+	agent.unpinAssets(sha512s)
+	assert.False(t, agent.assetInUse("hook-asset"))
+}
+
 func TestHandleProxyCheck(t *testing.T) {
 	checkA := corev2.FixtureCheckConfig("check")
 	checkA.ProxyEntityName = "A"
@@ -487,10 +517,93 @@ func TestExtractMetrics(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.event.Check.OutputMetricFormat = tc.metricFormat
-			metrics := extractMetrics(tc.event)
+			metrics, dropped := extractMetrics(tc.event, 0)
 			assert.Equal(tc.expectedMetrics, metrics)
+			assert.Zero(dropped)
+		})
+	}
+}
+
+func TestInjectEntityTags(t *testing.T) {
+	entity := corev2.FixtureEntity("entity")
+	entity.Labels = map[string]string{"datacenter": "dc1", "region": "us-east"}
+	entity.Annotations = map[string]string{"team": "ops", "region": "eu-west"}
+
+	points := []*corev2.MetricPoint{
+		{Name: "cpu", Tags: []*corev2.MetricTag{{Name: "datacenter", Value: "dc2"}}},
+		{Name: "mem"},
+	}
+	injectEntityTags(points, entity, []string{"datacenter", "region", "team", "missing"})
+
+	assert.Equal(t, []*corev2.MetricTag{
+		{Name: "datacenter", Value: "dc2"},
+		{Name: "region", Value: "us-east"},
+		{Name: "team", Value: "ops"},
+	}, points[0].Tags)
+	assert.Equal(t, []*corev2.MetricTag{
+		{Name: "datacenter", Value: "dc1"},
+		{Name: "region", Value: "us-east"},
+		{Name: "team", Value: "ops"},
+	}, points[1].Tags)
+}
+
+func TestExtractMetricsLimit(t *testing.T) {
+	testCases := []struct {
+		format      string
+		output      string
+		wantNames   []string
+		wantDropped int
+	}{
+		{
+			format:      corev2.GraphiteOutputMetricFormat,
+			output:      "cpu 1 123456789\nmem 2 123456789\n\ndisk 3 123456789",
+			wantNames:   []string{"cpu"},
+			wantDropped: 2,
+		},
+		{
+			format:      corev2.OpenTSDBOutputMetricFormat,
+			output:      "cpu 123456789 1 host=a\nmem 123456789 2 host=a\ndisk 123456789 3 host=a",
+			wantNames:   []string{"cpu"},
+			wantDropped: 2,
+		},
+		{
+			format:      corev2.InfluxDBOutputMetricFormat,
+			output:      "sys cpu=1,mem=2 123456789\nsys disk=3 123456789",
+			wantNames:   []string{"sys.cpu"},
+			wantDropped: 2,
+		},
+		{
+			format:      corev2.NagiosOutputMetricFormat,
+			output:      "PING ok | cpu=1 mem=2 disk=3",
+			wantNames:   []string{"cpu"},
+			wantDropped: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.format, func(t *testing.T) {
+			event := corev2.FixtureEvent("entity", "check")
+			event.Check.Output = tc.output
+			event.Check.OutputMetricFormat = tc.format
+
+			points, dropped := extractMetrics(event, 0)
+			assert.Len(t, points, 3)
+			assert.Zero(t, dropped)
+
+			points, dropped = extractMetrics(event, 1)
+			var names []string
+			for _, point := range points {
+				names = append(names, point.Name)
+			}
+			assert.Equal(t, tc.wantNames, names)
+			assert.Equal(t, tc.wantDropped, dropped)
 		})
 	}
+
+	event := corev2.FixtureEvent("entity", "check")
+	annotateDroppedMetricPoints(event, 0, 1)
+	assert.NotContains(t, event.Annotations, DroppedMetricPointsAnnotation)
+	annotateDroppedMetricPoints(event, 2, 1)
+	assert.Equal(t, "2", event.Annotations[DroppedMetricPointsAnnotation])
 }
 
 func TestFailOnAssetCheckWithDisabledAssets(t *testing.T) {
@@ -555,117 +668,3 @@ func TestCheckHandlerProcessedBy(t *testing.T) {
 		t.Errorf("bad processed_by: got %q, want %q", got, want)
 	}
 }
-
-func TestEvaluateOutputMetricThresholds(t *testing.T) {
-	now := time.Now().UnixMilli()
-
-	metric1 := &corev2.MetricPoint{Name: "disk_rate", Value: 99999.0, Timestamp: now, Tags: nil}
-	metric2 := &corev2.MetricPoint{Name: "network_rate", Value: 100001.0, Timestamp: now, Tags: []*corev2.MetricTag{{Name: "device", Value: "eth0"}}}
-
-	statusWarningAnnotation := "sensu.io/notifications/warning"
-	statusUnknownAnnotation := "sensu.io/notifications/unknown"
-	statusCriticalAnnotation := "sensu.io/notifications/critical"
-	diskCriticalMinAnnotation := "sensu.io/output_metric_thresholds/disk_rate/min/critical"
-	diskCriticalMaxAnnotation := "sensu.io/output_metric_thresholds/disk_rate/max/critical"
-	diskWarningMinAnnotation := "sensu.io/output_metric_thresholds/disk_rate/min/warning"
-	netUnknownMaxAnnotation := "sensu.io/output_metric_thresholds/network_rate/max/unknown"
-	notDiskWarningNullAnnotation := "sensu.io/output_metric_thresholds/not_a_disk_rate/null-status/warning"
-
-	testCases := []struct {
-		name                string
-		event               *corev2.Event
-		metrics             []*corev2.MetricPoint
-		thresholds          []*corev2.MetricThreshold
-		expectedStatus      uint32
-		expectedAnnotations []string
-	}{
-		{
-			name:                "minimum rule match",
-			event:               &corev2.Event{Check: &corev2.Check{Status: 0}},
-			metrics:             []*corev2.MetricPoint{metric1},
-			thresholds:          []*corev2.MetricThreshold{{Name: "disk_rate", Thresholds: []*corev2.MetricThresholdRule{{Min: "200000.0", Status: 2}}}},
-			expectedStatus:      2,
-			expectedAnnotations: []string{statusCriticalAnnotation, diskCriticalMinAnnotation},
-		}, {
-			name:                "maximum rule match",
-			event:               &corev2.Event{Check: &corev2.Check{Status: 0}},
-			metrics:             []*corev2.MetricPoint{metric1},
-			thresholds:          []*corev2.MetricThreshold{{Name: "disk_rate", Thresholds: []*corev2.MetricThresholdRule{{Max: "50000.0", Status: 2}}}},
-			expectedStatus:      2,
-			expectedAnnotations: []string{statusCriticalAnnotation, diskCriticalMaxAnnotation},
-		}, {
-			name:                "no min rule match",
-			event:               &corev2.Event{Check: &corev2.Check{Status: 0}},
-			metrics:             []*corev2.MetricPoint{metric1},
-			thresholds:          []*corev2.MetricThreshold{{Name: "disk_rate", Thresholds: []*corev2.MetricThresholdRule{{Min: "50000.0", Status: 2}}}},
-			expectedStatus:      0,
-			expectedAnnotations: []string{},
-		}, {
-			name:                "no max rule match",
-			event:               &corev2.Event{Check: &corev2.Check{Status: 0}},
-			metrics:             []*corev2.MetricPoint{metric1},
-			thresholds:          []*corev2.MetricThreshold{{Name: "disk_rate", Thresholds: []*corev2.MetricThresholdRule{{Max: "200000.0", Status: 2}}}},
-			expectedStatus:      0,
-			expectedAnnotations: []string{},
-		}, {
-			name:                "min and max rule match",
-			event:               &corev2.Event{Check: &corev2.Check{Status: 0}},
-			metrics:             []*corev2.MetricPoint{metric1},
-			thresholds:          []*corev2.MetricThreshold{{Name: "disk_rate", Thresholds: []*corev2.MetricThresholdRule{{Min: "200000.0", Status: 1}, {Max: "75000.0", Status: 2}}}},
-			expectedStatus:      2,
-			expectedAnnotations: []string{statusCriticalAnnotation, diskWarningMinAnnotation, diskCriticalMaxAnnotation},
-		}, {
-			name:                "only one rule match",
-			event:               &corev2.Event{Check: &corev2.Check{Status: 0}},
-			metrics:             []*corev2.MetricPoint{metric1},
-			thresholds:          []*corev2.MetricThreshold{{Name: "disk_rate", Thresholds: []*corev2.MetricThresholdRule{{Min: "200000.0", Status: 1}, {Max: "200000.0", Status: 2}}}},
-			expectedStatus:      1,
-			expectedAnnotations: []string{statusWarningAnnotation, diskWarningMinAnnotation},
-		}, {
-			name:                "no filter match - null status",
-			event:               &corev2.Event{Check: &corev2.Check{Status: 0}},
-			metrics:             []*corev2.MetricPoint{metric1},
-			thresholds:          []*corev2.MetricThreshold{{Name: "not_a_disk_rate", NullStatus: 1, Thresholds: []*corev2.MetricThresholdRule{{Max: "200000.0", Status: 2}}}},
-			expectedStatus:      1,
-			expectedAnnotations: []string{statusWarningAnnotation, notDiskWarningNullAnnotation},
-		}, {
-			name:                "multi metric and filter match, no rule match",
-			event:               &corev2.Event{Check: &corev2.Check{Status: 0}},
-			metrics:             []*corev2.MetricPoint{metric1, metric2},
-			thresholds:          []*corev2.MetricThreshold{{Name: "disk_rate", NullStatus: 1, Thresholds: []*corev2.MetricThresholdRule{{Max: "200000.0", Status: 2}}}},
-			expectedStatus:      0,
-			expectedAnnotations: []string{},
-		}, {
-			name:                "multi metric and filter and rule match",
-			event:               &corev2.Event{Check: &corev2.Check{Status: 0}},
-			metrics:             []*corev2.MetricPoint{metric1, metric2},
-			thresholds:          []*corev2.MetricThreshold{{Name: "disk_rate", NullStatus: 1, Thresholds: []*corev2.MetricThresholdRule{{Max: "50000.0", Status: 2}}}},
-			expectedStatus:      2,
-			expectedAnnotations: []string{statusCriticalAnnotation, diskCriticalMaxAnnotation},
-		}, {
-			name:    "multi metric and multi rule match",
-			event:   &corev2.Event{Check: &corev2.Check{Status: 0}},
-			metrics: []*corev2.MetricPoint{metric1, metric2},
-			thresholds: []*corev2.MetricThreshold{{Name: "disk_rate", NullStatus: 1, Thresholds: []*corev2.MetricThresholdRule{{Max: "50000.0", Status: 2}}},
-				{Name: "network_rate", Thresholds: []*corev2.MetricThresholdRule{{Max: "40000", Status: 3}}}},
-			expectedStatus:      3,
-			expectedAnnotations: []string{statusUnknownAnnotation, diskCriticalMaxAnnotation, netUnknownMaxAnnotation},
-		},
-	}
-
-	for _, test := range testCases {
-		t.Run(test.name, func(t *testing.T) {
-			event := test.event
-			test.event.Metrics = &corev2.Metrics{Points: test.metrics}
-			test.event.Check.OutputMetricThresholds = test.thresholds
-			status := evaluateOutputMetricThresholds(event)
-			assert.Equal(t, test.expectedStatus, status)
-
-			assert.Equal(t, len(test.expectedAnnotations), len(event.Annotations), "wrong annotation count")
-			for _, expectedKey := range test.expectedAnnotations {
-				_, ok := event.Annotations[expectedKey]
-				assert.True(t, ok, fmt.Sprintf("missing annotation %s", expectedKey))
-			}
-		})
-	}
-}
@@ -0,0 +1,31 @@
+package agent
+
+import (
+	"hash/fnv"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// checkSplay returns the delay before the agent of the entity executes the
+// interval check, when the check spreads its executions over a part of its
+// interval. The delay is derived from the entity and check names, so that it
+// remains the same across agent restarts while differing between agents.
+func checkSplay(check *corev2.CheckConfig, entityName string) time.Duration {
+	if check.Interval == 0 || check.Cron != "" {
+		return 0
+	}
+	coverage, err := corev2.CheckSplayCoverage(check.Annotations)
+	if err != nil || coverage == 0 {
+		return 0
+	}
+	window := time.Duration(check.Interval) * time.Second * time.Duration(coverage) / 100
+	if window <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(entityName))
+	_, _ = h.Write([]byte{'/'})
+	_, _ = h.Write([]byte(check.Name))
+	return time.Duration(h.Sum64() % uint64(window))
+}
@@ -0,0 +1,41 @@
+package agent
+
+import (
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+func TestCheckSplay(t *testing.T) {
+	check := corev2.FixtureCheckConfig("check-cpu")
+	check.Interval = 60
+	if got := checkSplay(check, "foo"); got != 0 {
+		t.Fatalf("expected no splay without annotation, got %s", got)
+	}
+
+	check.Annotations = map[string]string{corev2.CheckSplayCoverageAnnotation: "50"}
+	splay := checkSplay(check, "foo")
+	if splay < 0 || splay >= 30*time.Second {
+		t.Fatalf("splay %s is not within the first half of the interval", splay)
+	}
+	if got := checkSplay(check, "foo"); got != splay {
+		t.Fatalf("splay is not deterministic: got %s, then %s", splay, got)
+	}
+
+	// Agents are spread over the interval
+	offsets := make(map[time.Duration]struct{})
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		offsets[checkSplay(check, name)] = struct{}{}
+	}
+	if len(offsets) < 2 {
+		t.Fatal("expected the agents to be spread over the interval")
+	}
+
+	// Cron checks are not splayed
+	check.Interval = 0
+	check.Cron = "* * * * *"
+	if got := checkSplay(check, "foo"); got != 0 {
+		t.Fatalf("expected no splay for cron checks, got %s", got)
+	}
+}
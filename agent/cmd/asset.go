@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ghodss/yaml"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/asset"
+	"github.com/sensu/sensu-go/system"
+	"github.com/sensu/sensu-go/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/time/rate"
+)
+
+const (
+	flagAssetFile = "asset-file"
+)
+
+// InstallAssetCommand creates a new cobra command that installs an asset the
+// same way checks do, and prints the paths it adds to the check environment.
+// The agent configuration must have been loaded, e.g. by creating the start
+// command first.
+func InstallAssetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "install-asset NAME",
+		Short:         "install an asset and show the PATH additions and bin contents checks would get",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Args:          cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := viper.BindPFlags(cmd.Flags()); err != nil {
+				return err
+			}
+			path, _ := cmd.Flags().GetString(flagAssetFile)
+			if path == "" {
+				return fmt.Errorf("--%s is required", flagAssetFile)
+			}
+			assetDef, err := readAssetDefinition(path, args[0], viper.GetString(flagNamespace))
+			if err != nil {
+				return err
+			}
+
+			info, err := system.Info()
+			if err != nil {
+				return err
+			}
+			entity := &corev2.Entity{
+				ObjectMeta:  corev2.NewObjectMeta(viper.GetString(flagAgentName), viper.GetString(flagNamespace)),
+				EntityClass: corev2.EntityAgentClass,
+				System:      info,
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			var wg sync.WaitGroup
+			defer wg.Wait()
+			defer cancel()
+
+			manager := asset.NewManager(viper.GetString(flagCacheDir), viper.GetString(flagTrustedCAFile), entity, &wg)
+			limit := rate.Limit(viper.GetFloat64(flagAssetsRateLimit))
+			if limit == 0 {
+				limit = rate.Limit(asset.DefaultAssetsRateLimit)
+			}
+			getter, err := manager.StartAssetManager(ctx, rate.NewLimiter(limit, viper.GetInt(flagAssetsBurstLimit)))
+			if err != nil {
+				return err
+			}
+			runtimeAsset, err := getter.Get(ctx, assetDef)
+			if err != nil {
+				return err
+			}
+			if runtimeAsset == nil {
+				return fmt.Errorf("asset %s is not installed, its filters do not match this agent", assetDef.Name)
+			}
+			return printRuntimeAsset(cmd.OutOrStdout(), runtimeAsset)
+		},
+	}
+
+	cmd.Flags().String(flagAssetFile, "", "path to a file with the asset definition, in JSON or YAML, as written by sensuctl dump")
+	cmd.Flags().String(flagCacheDir, "", "path to store cached data, defaults to the agent cache directory")
+	cmd.Flags().String(flagTrustedCAFile, "", "TLS CA certificate bundle in PEM format")
+	cmd.Flags().Float64(flagAssetsRateLimit, 0, "maximum number of assets fetched per second")
+	cmd.Flags().Int(flagAssetsBurstLimit, 0, "asset fetch burst limit")
+
+	return cmd
+}
+
+// readAssetDefinition returns the asset with the given name, among the
+// resources of the file at path. Assets without a namespace are put in the
+// given namespace.
+func readAssetDefinition(path, name, namespace string) (*corev2.Asset, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	docs, err := splitDocuments(b)
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range docs {
+		jsonBytes, err := yaml.YAMLToJSON(doc)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %s", path, err)
+		}
+		dec := json.NewDecoder(bytes.NewReader(jsonBytes))
+		for dec.More() {
+			var w types.Wrapper
+			if err := dec.Decode(&w); err != nil {
+				return nil, fmt.Errorf("error parsing %s: %s", path, err)
+			}
+			if a, ok := w.Value.(*corev2.Asset); ok && a.Name == name {
+				if a.Namespace == "" {
+					a.Namespace = namespace
+				}
+				if err := a.Validate(); err != nil {
+					return nil, fmt.Errorf("invalid asset %s: %s", name, err)
+				}
+				return a, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("asset %s not found in %s", name, path)
+}
+
+// splitDocuments splits YAML documents on the lines starting with "---".
+func splitDocuments(b []byte) ([][]byte, error) {
+	var docs [][]byte
+	var current bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "---") {
+			if strings.TrimSpace(current.String()) != "" {
+				docs = append(docs, append([]byte(nil), current.Bytes()...))
+			}
+			current.Reset()
+			continue
+		}
+		current.WriteString(line)
+		current.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(current.String()) != "" {
+		docs = append(docs, current.Bytes())
+	}
+	if len(docs) == 0 {
+		return nil, errors.New("no resources found")
+	}
+	return docs, nil
+}
+
+// printRuntimeAsset writes the path of an installed asset, its additions to
+// the environment of checks and the contents of its bin directory.
+func printRuntimeAsset(w io.Writer, runtimeAsset *asset.RuntimeAsset) error {
+	fmt.Fprintf(w, "Asset: %s\n", runtimeAsset.Name)
+	fmt.Fprintf(w, "Path: %s\n", runtimeAsset.Path)
+	fmt.Fprintf(w, "PATH additions: %s\n", runtimeAsset.BinDir())
+	fmt.Fprintf(w, "LD_LIBRARY_PATH additions: %s\n", runtimeAsset.LibDir())
+	fmt.Fprintf(w, "CPATH additions: %s\n", runtimeAsset.IncludeDir())
+
+	entries, err := ioutil.ReadDir(runtimeAsset.BinDir())
+	if os.IsNotExist(err) {
+		fmt.Fprintln(w, "Bin contents: none, the asset has no bin directory")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(w, "Bin contents:")
+	for _, entry := range entries {
+		fmt.Fprintf(w, "  %s\n", filepath.Join(runtimeAsset.BinDir(), entry.Name()))
+	}
+	return nil
+}
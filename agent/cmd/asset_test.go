@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sensu/sensu-go/asset"
+)
+
+const assetDefinitions = `type: Asset
+api_version: core/v2
+metadata:
+  name: other
+  namespace: default
+spec:
+  url: https://example.com/other.tar.gz
+  sha512: 4f926bf4328fbad2b9cac873d117f771914f4b837c9c85584c38ccf55a3ef3c2e8d154812246e5dda4a87450576b2c58ad9ab40c9e2edc31b288d066b195b21b
+---
+type: Asset
+api_version: core/v2
+metadata:
+  name: check-cpu
+spec:
+  url: https://example.com/check-cpu.tar.gz
+  sha512: 4f926bf4328fbad2b9cac873d117f771914f4b837c9c85584c38ccf55a3ef3c2e8d154812246e5dda4a87450576b2c58ad9ab40c9e2edc31b288d066b195b21b
+`
+
+func TestReadAssetDefinition(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sensu-agent-asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "assets.yml")
+	if err := ioutil.WriteFile(path, []byte(assetDefinitions), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := readAssetDefinition(path, "check-cpu", "dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := a.URL, "https://example.com/check-cpu.tar.gz"; got != want {
+		t.Errorf("bad url: got %q, want %q", got, want)
+	}
+	if got, want := a.Namespace, "dev"; got != want {
+		t.Errorf("bad namespace: got %q, want %q", got, want)
+	}
+
+	if _, err := readAssetDefinition(path, "missing", "dev"); err == nil {
+		t.Error("expected an error for a missing asset")
+	}
+}
+
+func TestPrintRuntimeAsset(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sensu-agent-asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	runtimeAsset := &asset.RuntimeAsset{Name: "check-cpu", Path: dir}
+
+	var buf bytes.Buffer
+	if err := printRuntimeAsset(&buf, runtimeAsset); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "no bin directory") {
+		t.Errorf("expected a missing bin directory, got %q", buf.String())
+	}
+
+	if err := os.Mkdir(runtimeAsset.BinDir(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(runtimeAsset.BinDir(), "check-cpu"), nil, 0755); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	if err := printRuntimeAsset(&buf, runtimeAsset); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "PATH additions: "+runtimeAsset.BinDir()) {
+		t.Errorf("expected the bin directory in the PATH additions, got %q", buf.String())
+	}
+	if !strings.Contains(buf.String(), filepath.Join(runtimeAsset.BinDir(), "check-cpu")) {
+		t.Errorf("expected the bin contents, got %q", buf.String())
+	}
+}
@@ -34,6 +34,10 @@ const (
 	// specified in backend urls
 	DefaultBackendPort = "8081"
 
+	// DefaultBackendAPIPort specifies the default port to use when a port is
+	// not specified in backend urls and events are sent over HTTP
+	DefaultBackendAPIPort = "8080"
+
 	environmentPrefix = "sensu"
 
 	flagAgentName                 = "name"
@@ -41,6 +45,7 @@ const (
 	flagAPIPort                   = "api-port"
 	flagAssetsRateLimit           = "assets-rate-limit"
 	flagAssetsBurstLimit          = "assets-burst-limit"
+	flagAssetsCacheMaxSize        = "assets-cache-max-size"
 	flagBackendURL                = "backend-url"
 	flagCacheDir                  = "cache-dir"
 	flagConfigFile                = "config-file"
@@ -56,6 +61,11 @@ const (
 	flagKeepaliveCheckLabels      = "keepalive-check-labels"
 	flagKeepaliveCheckAnnotations = "keepalive-check-annotations"
 	flagKeepalivePipelines        = "keepalive-pipelines"
+	flagMetricsEntityTags         = "metrics-entity-tags"
+	flagMaxMetricPoints           = "max-metric-points"
+	flagLocalEventSocket          = "local-event-socket"
+	flagLocalEventsOnly           = "local-events-only"
+	flagMetricTagRules            = "metric-tag-rules"
 	flagNamespace                 = "namespace"
 	flagPassword                  = "password"
 	flagRedact                    = "redact"
@@ -82,10 +92,21 @@ const (
 	flagRetryMin                  = "retry-min"
 	flagRetryMax                  = "retry-max"
 	flagRetryMultiplier           = "retry-multiplier"
+	flagRetryJitter               = "retry-jitter"
+	flagBackendTransport          = "backend-transport"
+	flagBackendDNSPolicy          = "backend-dns-policy"
+	flagHTTPSendInterval          = "http-send-interval"
 	flagMaxSessionLength          = "max-session-length"
+	flagEnableSpool               = "enable-spool"
+	flagSpoolMaxSize              = "spool-max-size"
+	flagSpoolTTL                  = "spool-ttl"
+	flagSecretsProvider           = "secrets-provider"
+	flagVaultAddress              = "vault-address"
+	flagVaultToken                = "vault-token"
 
 	// TLS flags
 	flagTrustedCAFile         = "trusted-ca-file"
+	flagTrustedCADir          = "trusted-ca-dir"
 	flagInsecureSkipTLSVerify = "insecure-skip-tls-verify"
 	flagCertFile              = "cert-file"
 	flagKeyFile               = "key-file"
@@ -116,6 +137,7 @@ func NewAgentConfig(cmd *cobra.Command) (*agent.Config, error) {
 	cfg.API.Port = viper.GetInt(flagAPIPort)
 	cfg.AssetsRateLimit = rate.Limit(viper.GetFloat64(flagAssetsRateLimit))
 	cfg.AssetsBurstLimit = viper.GetInt(flagAssetsBurstLimit)
+	cfg.AssetsCacheMaxSize = viper.GetInt64(flagAssetsCacheMaxSize)
 	cfg.CacheDir = viper.GetString(flagCacheDir)
 	cfg.Deregister = viper.GetBool(flagDeregister)
 	cfg.DeregistrationHandler = viper.GetString(flagDeregistrationHandler)
@@ -147,7 +169,17 @@ func NewAgentConfig(cmd *cobra.Command) (*agent.Config, error) {
 	cfg.RetryMin = viper.GetDuration(flagRetryMin)
 	cfg.RetryMax = viper.GetDuration(flagRetryMax)
 	cfg.RetryMultiplier = viper.GetFloat64(flagRetryMultiplier)
+	cfg.RetryJitter = viper.GetFloat64(flagRetryJitter)
+	cfg.BackendTransport = viper.GetString(flagBackendTransport)
+	cfg.BackendDNSPolicy = viper.GetString(flagBackendDNSPolicy)
+	cfg.HTTPSendInterval = viper.GetDuration(flagHTTPSendInterval)
 	cfg.MaxSessionLength = viper.GetDuration(flagMaxSessionLength)
+	cfg.EnableSpool = viper.GetBool(flagEnableSpool)
+	cfg.SpoolMaxSize = viper.GetInt64(flagSpoolMaxSize)
+	cfg.SpoolTTL = viper.GetDuration(flagSpoolTTL)
+	cfg.SecretsProvider = viper.GetString(flagSecretsProvider)
+	cfg.VaultAddress = viper.GetString(flagVaultAddress)
+	cfg.VaultToken = viper.GetString(flagVaultToken)
 
 	// Set the labels & annotations using values defined configuration files
 	// and/or environment variables for now
@@ -157,10 +189,37 @@ func NewAgentConfig(cmd *cobra.Command) (*agent.Config, error) {
 	// TLS configuration
 	cfg.TLS = &corev2.TLSOptions{}
 	cfg.TLS.TrustedCAFile = viper.GetString(flagTrustedCAFile)
+	cfg.TLS.TrustedCADir = viper.GetString(flagTrustedCADir)
 	cfg.TLS.InsecureSkipVerify = viper.GetBool(flagInsecureSkipTLSVerify)
 	cfg.TLS.CertFile = viper.GetString(flagCertFile)
 	cfg.TLS.KeyFile = viper.GetString(flagKeyFile)
 
+	if cfg.RetryJitter < 0 || cfg.RetryJitter > 1 {
+		return nil, fmt.Errorf("--%s must be between 0 and 1", flagRetryJitter)
+	}
+
+	if cfg.BackendDNSPolicy != agent.BackendDNSPolicyResolve && cfg.BackendDNSPolicy != agent.BackendDNSPolicyRotate {
+		return nil, fmt.Errorf("--%s must be either %q or %q",
+			flagBackendDNSPolicy, agent.BackendDNSPolicyResolve, agent.BackendDNSPolicyRotate)
+	}
+
+	if cfg.BackendTransport != agent.BackendTransportWebSocket && cfg.BackendTransport != agent.BackendTransportHTTP {
+		return nil, fmt.Errorf("--%s must be either %q or %q",
+			flagBackendTransport, agent.BackendTransportWebSocket, agent.BackendTransportHTTP)
+	}
+
+	if cfg.HTTPSendInterval <= 0 {
+		return nil, fmt.Errorf("--%s must be greater than 0", flagHTTPSendInterval)
+	}
+
+	if cfg.EnableSpool && cfg.SpoolMaxSize <= 0 {
+		return nil, fmt.Errorf("--%s must be greater than 0", flagSpoolMaxSize)
+	}
+
+	if cfg.AssetsCacheMaxSize < 0 {
+		return nil, fmt.Errorf("--%s must not be negative", flagAssetsCacheMaxSize)
+	}
+
 	if cfg.KeepaliveCriticalTimeout != 0 && cfg.KeepaliveCriticalTimeout < cfg.KeepaliveWarningTimeout {
 		return nil, fmt.Errorf("if set, --%s must be greater than --%s",
 			flagKeepaliveCriticalTimeout, flagKeepaliveWarningTimeout)
@@ -171,8 +230,12 @@ func NewAgentConfig(cmd *cobra.Command) (*agent.Config, error) {
 		cfg.AgentName = agentName
 	}
 
+	backendPort := DefaultBackendPort
+	if cfg.BackendTransport == agent.BackendTransportHTTP {
+		backendPort = DefaultBackendAPIPort
+	}
 	for _, backendURL := range viper.GetStringSlice(flagBackendURL) {
-		newURL, err := url.AppendPortIfMissing(backendURL, DefaultBackendPort)
+		newURL, err := url.AppendPortIfMissing(backendURL, backendPort)
 		if err != nil {
 			return nil, err
 		}
@@ -181,6 +244,17 @@ func NewAgentConfig(cmd *cobra.Command) (*agent.Config, error) {
 
 	cfg.Redact = viper.GetStringSlice(flagRedact)
 	cfg.Subscriptions = viper.GetStringSlice(flagSubscriptions)
+	cfg.MetricsEntityTags = viper.GetStringSlice(flagMetricsEntityTags)
+	cfg.MaxMetricPoints = viper.GetInt(flagMaxMetricPoints)
+	cfg.LocalEventSocket = viper.GetString(flagLocalEventSocket)
+	cfg.LocalEventsOnly = viper.GetBool(flagLocalEventsOnly)
+	for _, s := range viper.GetStringSlice(flagMetricTagRules) {
+		rule, err := agent.ParseMetricTagRule(s)
+		if err != nil {
+			return nil, fmt.Errorf("--%s: %s", flagMetricTagRules, err)
+		}
+		cfg.MetricTagRules = append(cfg.MetricTagRules, rule)
+	}
 
 	// Workaround for https://github.com/sensu/sensu-go/issues/2357. Detect if
 	// the flags for labels and annotations were changed. If so, use their
@@ -304,11 +378,17 @@ func handleConfig(cmd *cobra.Command, arguments []string) error {
 	viper.SetDefault(flagDisableAssets, false)
 	viper.SetDefault(flagAssetsRateLimit, asset.DefaultAssetsRateLimit)
 	viper.SetDefault(flagAssetsBurstLimit, asset.DefaultAssetsBurstLimit)
+	viper.SetDefault(flagAssetsCacheMaxSize, 0)
 	viper.SetDefault(flagEventsRateLimit, agent.DefaultEventsAPIRateLimit)
 	viper.SetDefault(flagEventsBurstLimit, agent.DefaultEventsAPIBurstLimit)
 	viper.SetDefault(flagKeepaliveInterval, agent.DefaultKeepaliveInterval)
 	viper.SetDefault(flagKeepaliveWarningTimeout, corev2.DefaultKeepaliveTimeout)
 	viper.SetDefault(flagKeepaliveCriticalTimeout, 0)
+	viper.SetDefault(flagMetricsEntityTags, []string{})
+	viper.SetDefault(flagMaxMetricPoints, 0)
+	viper.SetDefault(flagLocalEventSocket, "")
+	viper.SetDefault(flagLocalEventsOnly, false)
+	viper.SetDefault(flagMetricTagRules, []string{})
 	viper.SetDefault(flagNamespace, agent.DefaultNamespace)
 	viper.SetDefault(flagPassword, agent.DefaultPassword)
 	viper.SetDefault(flagRedact, corev2.DefaultRedactFields)
@@ -322,6 +402,7 @@ func handleConfig(cmd *cobra.Command, arguments []string) error {
 	viper.SetDefault(flagSubscriptions, []string{})
 	viper.SetDefault(flagUser, agent.DefaultUser)
 	viper.SetDefault(flagTrustedCAFile, "")
+	viper.SetDefault(flagTrustedCADir, "")
 	viper.SetDefault(flagInsecureSkipTLSVerify, false)
 	viper.SetDefault(flagLogLevel, "info")
 	viper.SetDefault(flagBackendHandshakeTimeout, 15)
@@ -330,7 +411,17 @@ func handleConfig(cmd *cobra.Command, arguments []string) error {
 	viper.SetDefault(flagRetryMin, time.Second)
 	viper.SetDefault(flagRetryMax, 120*time.Second)
 	viper.SetDefault(flagRetryMultiplier, 2.0)
+	viper.SetDefault(flagRetryJitter, 0.5)
+	viper.SetDefault(flagBackendTransport, agent.BackendTransportWebSocket)
+	viper.SetDefault(flagBackendDNSPolicy, agent.BackendDNSPolicyResolve)
+	viper.SetDefault(flagHTTPSendInterval, agent.DefaultHTTPSendInterval)
 	viper.SetDefault(flagMaxSessionLength, 0*time.Second)
+	viper.SetDefault(flagEnableSpool, false)
+	viper.SetDefault(flagSpoolMaxSize, agent.DefaultSpoolMaxSize)
+	viper.SetDefault(flagSpoolTTL, agent.DefaultSpoolTTL)
+	viper.SetDefault(flagSecretsProvider, agent.DefaultSecretsProvider)
+	viper.SetDefault(flagVaultAddress, "")
+	viper.SetDefault(flagVaultToken, "")
 
 	// Merge in flag set so that it appears in command usage
 	flags := flagSet()
@@ -416,8 +507,14 @@ func flagSet() *pflag.FlagSet {
 	flagSet.Bool(flagDetectCloudProvider, viper.GetBool(flagDetectCloudProvider), "enable cloud provider detection")
 	flagSet.Float64(flagAssetsRateLimit, viper.GetFloat64(flagAssetsRateLimit), "maximum number of assets fetched per second")
 	flagSet.Int(flagAssetsBurstLimit, viper.GetInt(flagAssetsBurstLimit), "asset fetch burst limit")
+	flagSet.Int64(flagAssetsCacheMaxSize, viper.GetInt64(flagAssetsCacheMaxSize), "maximum size in bytes of the assets installed in the cache directory, beyond which the least recently used ones are removed, except those of the checks being executed (unlimited when 0)")
 	flagSet.Float64(flagEventsRateLimit, viper.GetFloat64(flagEventsRateLimit), "maximum number of events transmitted to the backend through the /events api")
 	flagSet.Int(flagEventsBurstLimit, viper.GetInt(flagEventsBurstLimit), "/events api burst limit")
+	flagSet.StringSlice(flagMetricsEntityTags, viper.GetStringSlice(flagMetricsEntityTags), "comma-delimited list of entity labels or annotations to add as tags to the metrics extracted from check output. This flag can also be invoked multiple times")
+	flagSet.Int(flagMaxMetricPoints, viper.GetInt(flagMaxMetricPoints), "maximum number of metric points extracted from the output of a check, beyond which the extra points are dropped (unlimited when 0)")
+	flagSet.String(flagLocalEventSocket, viper.GetString(flagLocalEventSocket), "path of a unix socket the check results are also written to, as newline delimited JSON")
+	flagSet.Bool(flagLocalEventsOnly, viper.GetBool(flagLocalEventsOnly), "write the check results to the local event socket only, instead of also sending them to the backend")
+	flagSet.StringSlice(flagMetricTagRules, viper.GetStringSlice(flagMetricTagRules), "ordered list of rules renaming (rename:TAG:NEW_NAME) or dropping (drop:TAG) the tags of the metrics extracted from check output, optionally for a single check (CHECK/drop:TAG). This flag can also be invoked multiple times")
 	flagSet.String(flagNamespace, viper.GetString(flagNamespace), "agent namespace")
 	flagSet.String(flagPassword, viper.GetString(flagPassword), "agent password")
 	flagSet.StringSlice(flagRedact, viper.GetStringSlice(flagRedact), "comma-delimited list of fields to redact, overwrites the default fields. This flag can also be invoked multiple times")
@@ -429,7 +526,7 @@ func flagSet() *pflag.FlagSet {
 	flagSet.Int(flagStatsdMetricsPort, viper.GetInt(flagStatsdMetricsPort), "port used for the statsd metrics server")
 	flagSet.StringSlice(flagSubscriptions, viper.GetStringSlice(flagSubscriptions), "comma-delimited list of agent subscriptions. This flag can also be invoked multiple times")
 	flagSet.String(flagUser, viper.GetString(flagUser), "agent user")
-	flagSet.StringSlice(flagBackendURL, viper.GetStringSlice(flagBackendURL), "comma-delimited list of ws/wss URLs of Sensu backend servers. This flag can also be invoked multiple times")
+	flagSet.StringSlice(flagBackendURL, viper.GetStringSlice(flagBackendURL), "comma-delimited list of ws/wss URLs of Sensu backend servers, or of http/https URLs of their API with --backend-transport http. This flag can also be invoked multiple times")
 	flagSet.StringSlice(flagKeepaliveHandlers, viper.GetStringSlice(flagKeepaliveHandlers), "comma-delimited list of keepalive handlers for this entity. This flag can also be invoked multiple times")
 	flagSet.Int(flagKeepaliveInterval, viper.GetInt(flagKeepaliveInterval), "number of seconds to send between keepalive events")
 	flagSet.Uint32(flagKeepaliveWarningTimeout, uint32(viper.GetInt(flagKeepaliveWarningTimeout)), "number of seconds until agent is considered dead by backend to create a warning event")
@@ -441,6 +538,7 @@ func flagSet() *pflag.FlagSet {
 	flagSet.Bool(flagDisableAssets, viper.GetBool(flagDisableAssets), "disable check assets on this agent")
 	flagSet.Bool(flagDisableSockets, viper.GetBool(flagDisableSockets), "disable the Agent TCP and UDP event sockets")
 	flagSet.String(flagTrustedCAFile, viper.GetString(flagTrustedCAFile), "TLS CA certificate bundle in PEM format")
+	flagSet.String(flagTrustedCADir, viper.GetString(flagTrustedCADir), "directory of TLS CA certificates in PEM format")
 	flagSet.Bool(flagInsecureSkipTLSVerify, viper.GetBool(flagInsecureSkipTLSVerify), "skip TLS verification (not recommended!)")
 	flagSet.String(flagCertFile, viper.GetString(flagCertFile), "certificate for TLS authentication")
 	flagSet.String(flagKeyFile, viper.GetString(flagKeyFile), "key for TLS authentication")
@@ -455,7 +553,17 @@ func flagSet() *pflag.FlagSet {
 	flagSet.Duration(flagRetryMin, viper.GetDuration(flagRetryMin), "minimum amount of time to wait before retrying an agent connection to the backend")
 	flagSet.Duration(flagRetryMax, viper.GetDuration(flagRetryMax), "maximum amount of time to wait before retrying an agent connection to the backend")
 	flagSet.Float64(flagRetryMultiplier, viper.GetFloat64(flagRetryMultiplier), "value multiplied with the current retry delay to produce a longer retry delay (bounded by --retry-max)")
+	flagSet.Float64(flagRetryJitter, viper.GetFloat64(flagRetryJitter), "fraction of each retry delay that is randomized, between 0 and 1, to spread agent reconnections")
+	flagSet.String(flagBackendTransport, viper.GetString(flagBackendTransport), "transport used to send events to the backend [websocket, http]. With http, events and keepalives are sent to the backend API and check requests are not received")
+	flagSet.String(flagBackendDNSPolicy, viper.GetString(flagBackendDNSPolicy), "policy used to resolve the backend URL hosts on every connection attempt [resolve, rotate]. With rotate, the agent connects to the addresses of a host in turn instead of preferring the first one")
+	flagSet.Duration(flagHTTPSendInterval, viper.GetDuration(flagHTTPSendInterval), "interval at which events are sent to the backend API when --backend-transport is http")
 	flagSet.Duration(flagMaxSessionLength, viper.GetDuration(flagMaxSessionLength), "maximum amount of time after which the agent will reconnect to one of the configured backends (no maximum by default)")
+	flagSet.Bool(flagEnableSpool, viper.GetBool(flagEnableSpool), "persist in the cache directory the events and keepalives that can't be sent while the backend is unreachable, and replay them once reconnected")
+	flagSet.Int64(flagSpoolMaxSize, viper.GetInt64(flagSpoolMaxSize), "maximum size in bytes of the event spool, beyond which the oldest messages are dropped")
+	flagSet.Duration(flagSpoolTTL, viper.GetDuration(flagSpoolTTL), "age after which spooled messages are dropped instead of being replayed (never when 0)")
+	flagSet.String(flagSecretsProvider, viper.GetString(flagSecretsProvider), "provider used to resolve secret references in check environment variables [env, vault]")
+	flagSet.String(flagVaultAddress, viper.GetString(flagVaultAddress), "address of the Vault server, used by the vault secrets provider")
+	flagSet.String(flagVaultToken, viper.GetString(flagVaultToken), "token used to authenticate against Vault, used by the vault secrets provider")
 
 	flagSet.SetOutput(ioutil.Discard)
 
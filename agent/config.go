@@ -28,6 +28,36 @@ const (
 	// DefaultBackendURL specifies the default backend URL
 	DefaultBackendURL = "ws://127.0.0.1:8081"
 
+	// BackendTransportWebSocket specifies that the agent connects to the
+	// backend over a websocket
+	BackendTransportWebSocket = "websocket"
+
+	// BackendTransportHTTP specifies that the agent sends its events and
+	// keepalives to the backend API over HTTP(S)
+	BackendTransportHTTP = "http"
+
+	// BackendDNSPolicyResolve specifies that the agent resolves the host of
+	// the backend URL on every connection attempt, and connects to the first
+	// of its addresses that can be reached
+	BackendDNSPolicyResolve = "resolve"
+
+	// BackendDNSPolicyRotate specifies that the agent resolves the host of
+	// the backend URL on every connection attempt, and connects to its
+	// addresses in turn, starting after the address of the last connection
+	BackendDNSPolicyRotate = "rotate"
+
+	// DefaultHTTPSendInterval specifies the default interval at which events
+	// are sent to the backend API when using the HTTP backend transport
+	DefaultHTTPSendInterval = 5 * time.Second
+
+	// DefaultSpoolMaxSize specifies the default maximum size, in bytes, of the
+	// event spool
+	DefaultSpoolMaxSize = 100 * 1024 * 1024
+
+	// DefaultSpoolTTL specifies the default age after which spooled messages
+	// are dropped instead of being replayed
+	DefaultSpoolTTL = 24 * time.Hour
+
 	// DefaultEventsAPIRateLimit defines the rate limit, in events per second,
 	// for outgoing events.
 	DefaultEventsAPIRateLimit rate.Limit = 10.0
@@ -89,13 +119,47 @@ type Config struct {
 	// AssetsBurstLimit is the maximum amount of burst allowed in a rate interval.
 	AssetsBurstLimit int
 
+	// AssetsCacheMaxSize is the maximum size, in bytes, of the assets
+	// installed in CacheDir. The least recently used assets that are not used
+	// by the checks being executed are uninstalled beyond that. The size of
+	// the cache is not limited if it is 0.
+	AssetsCacheMaxSize int64
+
 	// BackendURLs is a list of URLs for the Sensu Backend. Default:
 	// ws://127.0.0.1:8081
 	BackendURLs []string
 
+	// BackendTransport is the transport used to communicate with the backend,
+	// either BackendTransportWebSocket (the default) or BackendTransportHTTP.
+	// With the HTTP transport, BackendURLs are the URLs of the backend API,
+	// and the agent does not receive check requests.
+	BackendTransport string
+
+	// BackendDNSPolicy is the policy used to resolve the host of the backend
+	// URLs, either BackendDNSPolicyResolve (the default) or
+	// BackendDNSPolicyRotate.
+	BackendDNSPolicy string
+
+	// HTTPSendInterval is the interval at which events are sent to the
+	// backend API when using the HTTP backend transport.
+	HTTPSendInterval time.Duration
+
 	// CacheDir path where cached data is stored
 	CacheDir string
 
+	// EnableSpool persists in CacheDir the events and keepalives that could
+	// not be sent while the agent is disconnected from the backend, and
+	// replays them, oldest first, once it reconnects.
+	EnableSpool bool
+
+	// SpoolMaxSize is the maximum size, in bytes, of the event spool. The
+	// oldest messages are dropped beyond that.
+	SpoolMaxSize int64
+
+	// SpoolTTL is the age after which spooled messages are dropped instead
+	// of being replayed.
+	SpoolTTL time.Duration
+
 	// Deregister indicates whether the entity is ephemeral
 	Deregister bool
 
@@ -157,6 +221,29 @@ type Config struct {
 	// Annotations are key-value pairs that users can provide to agent entities
 	Annotations map[string]string
 
+	// MetricsEntityTags contains the names of the entity labels, or
+	// annotations, added as tags to the metric points extracted from check
+	// output
+	MetricsEntityTags []string
+
+	// MaxMetricPoints is the maximum number of metric points extracted from
+	// the output of a check. The extra points are dropped, and the event is
+	// annotated with their number. The points are not limited when 0.
+	MaxMetricPoints int
+
+	// LocalEventSocket is the path of a unix socket the check results are
+	// also written to, as newline delimited JSON. They are only sent to the
+	// backend if empty.
+	LocalEventSocket string
+
+	// LocalEventsOnly writes the check results to LocalEventSocket instead of
+	// sending them to the backend.
+	LocalEventsOnly bool
+
+	// MetricTagRules are applied in order to the tags of the metric points
+	// extracted from check output, to rename or drop them.
+	MetricTagRules []MetricTagRule
+
 	// Namespace sets the Agent's RBAC namespace identifier
 	Namespace string
 
@@ -220,9 +307,24 @@ type Config struct {
 	// a longer retry delay. It is bounded by RetryMax.
 	RetryMultiplier float64
 
+	// RetryJitter is the fraction of each retry delay that is randomized, so
+	// that agents spread their reconnection attempts after a backend restart.
+	RetryJitter float64
+
 	// MaxSessionLength is the maximum duration after which the agent will
 	// reconnect to one of the backends.
 	MaxSessionLength time.Duration
+
+	// SecretsProvider is the provider used to resolve the secrets referenced
+	// by check environment variables (env or vault).
+	SecretsProvider string
+
+	// VaultAddress is the address of the Vault server used by the vault
+	// secrets provider.
+	VaultAddress string
+
+	// VaultToken is the token used by the vault secrets provider.
+	VaultToken string
 }
 
 // StatsdServerConfig contains the statsd server configuration
@@ -257,7 +359,12 @@ func FixtureConfig() (*Config, func()) {
 		AssetsRateLimit:         asset.DefaultAssetsRateLimit,
 		AssetsBurstLimit:        asset.DefaultAssetsBurstLimit,
 		BackendURLs:             []string{},
+		BackendTransport:        BackendTransportWebSocket,
+		BackendDNSPolicy:        BackendDNSPolicyResolve,
+		HTTPSendInterval:        DefaultHTTPSendInterval,
 		CacheDir:                cacheDir,
+		SpoolMaxSize:            DefaultSpoolMaxSize,
+		SpoolTTL:                DefaultSpoolTTL,
 		EventsAPIRateLimit:      DefaultEventsAPIRateLimit,
 		EventsAPIBurstLimit:     DefaultEventsAPIBurstLimit,
 		KeepaliveInterval:       DefaultKeepaliveInterval,
@@ -0,0 +1,65 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// DryRunCheck executes the check request the same way the agent would, but
+// without connecting to a backend: the command, its environment and its
+// standard input are written to w, followed by the resulting event, which is
+// returned instead of being published. It is meant to help plugin developers
+// iterate on a check locally. Checks that depend on assets are not supported,
+// since assets are fetched from the backend.
+func DryRunCheck(config *Config, request *corev2.CheckRequest, w io.Writer) (*corev2.Event, error) {
+	if request == nil || request.Config == nil {
+		return nil, errors.New("a check request with a check configuration is required")
+	}
+	if len(request.Assets) > 0 || len(request.HookAssets) > 0 {
+		return nil, errors.New("assets are not supported in dry-run mode")
+	}
+
+	agent, err := NewAgent(config)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	event, ex, err := agent.prepareCheck(ctx, request, agent.getAgentEntity())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ex.Argv) > 0 {
+		fmt.Fprintf(w, "argv:\n")
+		for _, arg := range ex.Argv {
+			fmt.Fprintf(w, "  %q\n", arg)
+		}
+	} else {
+		fmt.Fprintf(w, "command: %s\n", ex.Command)
+	}
+	fmt.Fprintf(w, "env:\n")
+	for _, kv := range ex.Env {
+		fmt.Fprintf(w, "  %s\n", kv)
+	}
+	fmt.Fprintf(w, "stdin: %d bytes\n", len(ex.Input))
+	if ex.Input != "" {
+		fmt.Fprintf(w, "%s\n", strings.TrimSpace(ex.Input))
+	}
+
+	agent.runCheck(ctx, request, event, ex)
+
+	b, err := json.MarshalIndent(event, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling check result: %s", err)
+	}
+	fmt.Fprintf(w, "event:\n%s\n", b)
+
+	return event, nil
+}
@@ -0,0 +1,66 @@
+//go:build !windows
+// +build !windows
+
+package agent
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/sensu/sensu-go/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunCheck(t *testing.T) {
+	checkConfig := types.FixtureCheckConfig("check")
+	checkConfig.EnvVars = []string{"FOO=BAR"}
+	checkConfig.Stdin = true
+	checkConfig.Command = "echo $FOO"
+	request := &types.CheckRequest{Config: checkConfig, Issued: time.Now().Unix()}
+
+	config, cleanup := FixtureConfig()
+	defer cleanup()
+
+	var buf bytes.Buffer
+	event, err := DryRunCheck(config, request, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, "BAR\n", event.Check.Output)
+	assert.Contains(t, buf.String(), "command: echo $FOO")
+	assert.Contains(t, buf.String(), "FOO=BAR")
+	assert.Contains(t, buf.String(), "event:")
+}
+
+func TestDryRunCheckArgv(t *testing.T) {
+	checkConfig := types.FixtureCheckConfig("check")
+	checkConfig.Command = ""
+	checkConfig.Argv = []string{"echo", "it's $FOO"}
+	request := &types.CheckRequest{Config: checkConfig, Issued: time.Now().Unix()}
+
+	config, cleanup := FixtureConfig()
+	defer cleanup()
+
+	var buf bytes.Buffer
+	event, err := DryRunCheck(config, request, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, "it's $FOO\n", event.Check.Output)
+	assert.Contains(t, buf.String(), "argv:\n  \"echo\"\n  \"it's $FOO\"\n")
+	assert.NotContains(t, buf.String(), "command:")
+}
+
+func TestDryRunCheckAssets(t *testing.T) {
+	checkConfig := types.FixtureCheckConfig("check")
+	request := &types.CheckRequest{
+		Config: checkConfig,
+		Assets: []types.Asset{*types.FixtureAsset("asset")},
+	}
+
+	config, cleanup := FixtureConfig()
+	defer cleanup()
+
+	var buf bytes.Buffer
+	_, err := DryRunCheck(config, request, &buf)
+	assert.Error(t, err)
+	assert.Empty(t, buf.String())
+}
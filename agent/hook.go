@@ -21,6 +21,12 @@ import (
 // ExecuteHooks executes all hooks contained in a check request based on
 // the check status code of the check request
 func (a *Agent) ExecuteHooks(ctx context.Context, request *corev2.CheckRequest, event *corev2.Event, assets map[string]*corev2.AssetList) []*corev2.Hook {
+	// The assets of the hooks are pinned until all of them are executed, so
+	// that fetching the assets of a hook can't evict those of another one.
+	sha512s := hookAssetSHA512s(assets)
+	a.pinAssets(sha512s)
+	defer a.unpinAssets(sha512s)
+
 	executedHooks := []*corev2.Hook{}
 	for _, hookList := range request.Config.CheckHooks {
 		// find the hookList with the corresponding type
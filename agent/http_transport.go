@@ -0,0 +1,229 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/transport"
+)
+
+// maxPendingHTTPMessages is the maximum number of messages waiting to be sent
+// to the backend API. The oldest messages are dropped beyond that.
+const maxPendingHTTPMessages = 1000
+
+var errHTTPUnauthorized = errors.New("unauthorized")
+
+// httpSender sends events to the backend API, authenticating with the agent
+// user and password.
+type httpSender struct {
+	client    *http.Client
+	backends  BackendSelector
+	namespace string
+	user      string
+	password  string
+
+	mu    sync.Mutex
+	token string
+}
+
+func newHTTPSender(config *Config, backends BackendSelector) (*httpSender, error) {
+	client := &http.Client{Timeout: time.Duration(config.BackendHandshakeTimeout) * time.Second}
+	if config.TLS != nil {
+		tlsConfig, err := config.TLS.ToClientTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		client.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		}
+	}
+	return &httpSender{
+		client:    client,
+		backends:  backends,
+		namespace: config.Namespace,
+		user:      config.User,
+		password:  config.Password,
+	}, nil
+}
+
+// Send posts the event serialized in the message to the events API of one of
+// the backends, logging in first if needed.
+func (s *httpSender) Send(ctx context.Context, msg *transport.Message) error {
+	backendURL := strings.TrimSuffix(s.backends.Select(), "/")
+	err := s.post(ctx, backendURL, msg.Payload)
+	if err == errHTTPUnauthorized {
+		// The access token expired, log in again
+		s.setToken("")
+		err = s.post(ctx, backendURL, msg.Payload)
+	}
+	return err
+}
+
+func (s *httpSender) post(ctx context.Context, backendURL string, payload []byte) error {
+	token := s.getToken()
+	if token == "" {
+		var err error
+		if token, err = s.login(ctx, backendURL); err != nil {
+			return err
+		}
+		s.setToken(token)
+	}
+
+	eventsURL := backendURL + path.Join("/api/core/v2/namespaces", url.PathEscape(s.namespace), "events")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, eventsURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		return errHTTPUnauthorized
+	}
+	if resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("error sending event to %s: %s: %s", eventsURL, resp.Status, strings.TrimSpace(string(body)))
+	}
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	return nil
+}
+
+// login retrieves an access token from the backend API.
+func (s *httpSender) login(ctx context.Context, backendURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, backendURL+"/auth", nil)
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(s.user, s.password)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		return "", fmt.Errorf("error logging in to %s as %q: %s", backendURL, s.user, resp.Status)
+	}
+	var tokens corev2.Tokens
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return "", fmt.Errorf("error decoding access token: %s", err)
+	}
+	return tokens.Access, nil
+}
+
+func (s *httpSender) getToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token
+}
+
+func (s *httpSender) setToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+}
+
+// httpSendLoop sends the events and keepalives of the agent to the backend
+// API at every HTTPSendInterval, in place of the websocket connection. Messages
+// that could not be sent are retried at the next interval.
+func (a *Agent) httpSendLoop(ctx context.Context, cancel context.CancelFunc) {
+	defer logger.Info("shutting down http sender")
+	sender, err := newHTTPSender(a.config, a.backendSelector)
+	if err != nil {
+		logger.WithError(err).Error("couldn't create the http sender")
+		cancel()
+		return
+	}
+
+	keepalive := time.NewTicker(time.Duration(a.config.KeepaliveInterval) * time.Second)
+	defer keepalive.Stop()
+	interval := a.config.HTTPSendInterval
+	if interval <= 0 {
+		interval = DefaultHTTPSendInterval
+	}
+	flush := time.NewTicker(interval)
+	defer flush.Stop()
+
+	pending := a.sendHTTPMessages(ctx, sender, []*transport.Message{a.newKeepalive()})
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-a.sendq:
+			pending = appendPendingMessage(pending, msg)
+		case <-keepalive.C:
+			pending = appendPendingMessage(pending, a.newKeepalive())
+		case <-flush.C:
+			pending = a.sendHTTPMessages(ctx, sender, pending)
+		}
+	}
+}
+
+// appendPendingMessage queues a message to be sent, dropping the oldest one
+// if too many are queued.
+func appendPendingMessage(pending []*transport.Message, msg *transport.Message) []*transport.Message {
+	if len(pending) >= maxPendingHTTPMessages {
+		messagesDropped.WithLabelValues().Inc()
+		logger.Warn("too many messages waiting to be sent, dropping the oldest one")
+		if dropped := pending[0]; dropped.SendCallback != nil {
+			dropped.SendCallback(errors.New("message dropped"))
+		}
+		pending = pending[1:]
+	}
+	return append(pending, msg)
+}
+
+// sendHTTPMessages sends the messages in order, and returns the ones that
+// could not be sent. Once all of them are sent, the messages spooled while the
+// backend was unreachable are replayed.
+func (a *Agent) sendHTTPMessages(ctx context.Context, sender *httpSender, messages []*transport.Message) []*transport.Message {
+	for i, msg := range messages {
+		err := sender.Send(ctx, msg)
+		if msg.SendCallback != nil {
+			msg.SendCallback(err)
+		}
+		if err != nil {
+			websocketErrors.WithLabelValues().Inc()
+			logger.WithError(err).Error("error sending message over http")
+			a.setConnected(false)
+			return messages[i:]
+		}
+		messagesSent.WithLabelValues().Inc()
+		a.setConnected(true)
+	}
+	if a.spool != nil {
+		send := func(msg *transport.Message) error {
+			return sender.Send(ctx, msg)
+		}
+		if err := a.replaySpool(send); err != nil {
+			logger.WithError(err).Error("error replaying spooled messages")
+		}
+	}
+	return messages[:0]
+}
+
+func (a *Agent) setConnected(connected bool) {
+	a.connectedMu.Lock()
+	defer a.connectedMu.Unlock()
+	a.connected = connected
+}
@@ -0,0 +1,138 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSenderSend(t *testing.T) {
+	var mu sync.Mutex
+	var logins int
+	var events [][]byte
+	token := "token-1"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/auth":
+			user, password, ok := r.BasicAuth()
+			if !ok || user != "agent" || password != "P@ssw0rd!" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			logins++
+			_ = json.NewEncoder(w).Encode(corev2.Tokens{Access: token})
+		case "/api/core/v2/namespaces/dev/events":
+			assert.Equal(t, http.MethodPost, r.Method)
+			if r.Header.Get("Authorization") != "Bearer "+token {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			events = append(events, body)
+			// expire the token once an event was sent
+			token = "token-2"
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	cfg, cleanup := FixtureConfig()
+	defer cleanup()
+	cfg.Namespace = "dev"
+	cfg.TLS = nil
+
+	sender, err := newHTTPSender(cfg, &RandomBackendSelector{Backends: []string{ts.URL}})
+	require.NoError(t, err)
+
+	require.NoError(t, sender.Send(context.Background(), &transport.Message{Payload: []byte(`{"a":1}`)}))
+	require.NoError(t, sender.Send(context.Background(), &transport.Message{Payload: []byte(`{"b":2}`)}))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, [][]byte{[]byte(`{"a":1}`), []byte(`{"b":2}`)}, events)
+	assert.Equal(t, 2, logins, "the agent should log in again once its token expired")
+}
+
+func TestHTTPSenderLoginError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	cfg, cleanup := FixtureConfig()
+	defer cleanup()
+	cfg.TLS = nil
+
+	sender, err := newHTTPSender(cfg, &RandomBackendSelector{Backends: []string{ts.URL}})
+	require.NoError(t, err)
+	assert.Error(t, sender.Send(context.Background(), &transport.Message{Payload: []byte(`{}`)}))
+}
+
+func TestAppendPendingMessage(t *testing.T) {
+	var dropped error
+	pending := []*transport.Message{{SendCallback: func(err error) { dropped = err }}}
+	for i := 1; i < maxPendingHTTPMessages; i++ {
+		pending = appendPendingMessage(pending, &transport.Message{})
+	}
+	require.Len(t, pending, maxPendingHTTPMessages)
+	assert.NoError(t, dropped)
+
+	last := &transport.Message{}
+	pending = appendPendingMessage(pending, last)
+	assert.Len(t, pending, maxPendingHTTPMessages)
+	assert.Equal(t, errors.New("message dropped"), dropped)
+	assert.Equal(t, last, pending[len(pending)-1])
+}
+
+func TestSendHTTPMessagesReplaysSpool(t *testing.T) {
+	var mu sync.Mutex
+	var events []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth" {
+			_ = json.NewEncoder(w).Encode(corev2.Tokens{Access: "token"})
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		mu.Lock()
+		events = append(events, string(body))
+		mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+
+	cfg, cleanup := FixtureConfig()
+	defer cleanup()
+	cfg.TLS = nil
+
+	sender, err := newHTTPSender(cfg, &RandomBackendSelector{Backends: []string{ts.URL}})
+	require.NoError(t, err)
+
+	agent := &Agent{spool: newTestSpool(t, DefaultSpoolMaxSize, DefaultSpoolTTL)}
+	require.NoError(t, agent.spool.Push(&transport.Message{Payload: []byte(`{"spooled":1}`)}))
+	require.NoError(t, agent.spool.Push(&transport.Message{Payload: []byte(`{"spooled":2}`)}))
+
+	pending := agent.sendHTTPMessages(context.Background(), sender, []*transport.Message{{Payload: []byte(`{"pending":1}`)}})
+	assert.Empty(t, pending)
+	assert.True(t, agent.Connected())
+	assert.Equal(t, 0, agent.spool.Len())
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{`{"pending":1}`, `{"spooled":1}`, `{"spooled":2}`}, events)
+}
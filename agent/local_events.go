@@ -0,0 +1,101 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+const (
+	// localEventQueueSize is the number of events waiting to be written to
+	// the local event socket, beyond which new events are dropped.
+	localEventQueueSize = 100
+
+	// localEventWriteTimeout bounds the time spent writing an event to the
+	// local event socket.
+	localEventWriteTimeout = 5 * time.Second
+)
+
+// localEventWriter writes events to a unix socket as newline delimited JSON,
+// for a colocated process to consume. Events are written asynchronously and
+// dropped when the socket can't keep up, so that they never delay the delivery
+// of events to the backend.
+type localEventWriter struct {
+	path  string
+	queue chan []byte
+	conn  net.Conn
+	dial  func(path string) (net.Conn, error)
+}
+
+func newLocalEventWriter(path string) *localEventWriter {
+	return &localEventWriter{
+		path:  path,
+		queue: make(chan []byte, localEventQueueSize),
+		dial: func(path string) (net.Conn, error) {
+			return net.DialTimeout("unix", path, localEventWriteTimeout)
+		},
+	}
+}
+
+// Write queues the event to be written to the socket. The event is dropped if
+// the queue is full.
+func (w *localEventWriter) Write(event *corev2.Event) {
+	line, err := json.Marshal(event)
+	if err != nil {
+		logger.WithError(err).Error("error marshaling event for the local event socket")
+		return
+	}
+	select {
+	case w.queue <- append(line, '\n'):
+	default:
+		logger.WithField("socket", w.path).Warn("local event socket is not keeping up, dropping event")
+	}
+}
+
+// run writes the queued events to the socket until the context is done. The
+// socket is dialed again after a failed write; the event is then dropped.
+func (w *localEventWriter) run(ctx context.Context) {
+	defer func() {
+		if w.conn != nil {
+			_ = w.conn.Close()
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case line := <-w.queue:
+			if err := w.write(line); err != nil {
+				logger.WithError(err).WithField("socket", w.path).Warn("couldn't write event to the local event socket")
+			}
+		}
+	}
+}
+
+func (w *localEventWriter) write(line []byte) error {
+	if w.conn == nil {
+		conn, err := w.dial(w.path)
+		if err != nil {
+			return err
+		}
+		w.conn = conn
+	}
+	if err := w.conn.SetWriteDeadline(time.Now().Add(localEventWriteTimeout)); err != nil {
+		return w.reset(err)
+	}
+	if _, err := w.conn.Write(line); err != nil {
+		return w.reset(err)
+	}
+	return nil
+}
+
+// reset closes the connection after an error, so that the socket is dialed
+// again for the next event.
+func (w *localEventWriter) reset(err error) error {
+	_ = w.conn.Close()
+	w.conn = nil
+	return err
+}
@@ -0,0 +1,82 @@
+package agent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/transport"
+)
+
+func TestLocalEventWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.sock")
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skip(err)
+	}
+	defer ln.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w := newLocalEventWriter(path)
+	go w.run(ctx)
+
+	w.Write(corev2.FixtureEvent("entity", "check-1"))
+	w.Write(corev2.FixtureEvent("entity", "check-2"))
+
+	conn, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	for _, want := range []string{"check-1", "check-2"} {
+		if !scanner.Scan() {
+			t.Fatalf("expected event %s: %v", want, scanner.Err())
+		}
+		var event corev2.Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatal(err)
+		}
+		if got := event.Check.Name; got != want {
+			t.Errorf("bad event: got check %q, want %q", got, want)
+		}
+	}
+}
+
+func TestLocalEventWriterFullQueue(t *testing.T) {
+	w := newLocalEventWriter("unused")
+	// nothing reads the queue, the extra events are dropped without blocking
+	for i := 0; i < localEventQueueSize+1; i++ {
+		w.Write(corev2.FixtureEvent("entity", "check"))
+	}
+	if got := len(w.queue); got != localEventQueueSize {
+		t.Errorf("bad queue length: got %d, want %d", got, localEventQueueSize)
+	}
+}
+
+func TestSendCheckResultLocalEventsOnly(t *testing.T) {
+	for _, localOnly := range []bool{false, true} {
+		a := &Agent{
+			config:      &Config{LocalEventsOnly: localOnly},
+			localEvents: newLocalEventWriter("unused"),
+			marshal:     MarshalJSON,
+			sendq:       make(chan *transport.Message, 1),
+		}
+		a.sendCheckResult(corev2.FixtureEvent("entity", "check"))
+		if got := len(a.localEvents.queue); got != 1 {
+			t.Errorf("local events only %v: bad local queue length: got %d, want 1", localOnly, got)
+		}
+		want := 1
+		if localOnly {
+			want = 0
+		}
+		if got := len(a.sendq); got != want {
+			t.Errorf("local events only %v: bad send queue length: got %d, want %d", localOnly, got, want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+const (
+	// MetricTagRename is the operation of the rules renaming a metric tag.
+	MetricTagRename = "rename"
+
+	// MetricTagDrop is the operation of the rules dropping a metric tag.
+	MetricTagDrop = "drop"
+)
+
+// MetricTagRule renames or drops a tag of the metric points extracted from
+// check output.
+type MetricTagRule struct {
+	// Check is the name of the check the rule applies to. The rule applies to
+	// all checks when empty.
+	Check string
+
+	// Operation is either MetricTagRename or MetricTagDrop.
+	Operation string
+
+	// Tag is the name of the tag the rule applies to.
+	Tag string
+
+	// NewName is the name the tag is renamed to.
+	NewName string
+}
+
+// ParseMetricTagRule parses a rule in one of the forms "rename:TAG:NEW_NAME"
+// or "drop:TAG", optionally prefixed by "CHECK/" to only apply it to the
+// metrics of the given check.
+func ParseMetricTagRule(s string) (MetricTagRule, error) {
+	var rule MetricTagRule
+	spec := s
+	if i := strings.Index(spec, "/"); i >= 0 {
+		rule.Check = spec[:i]
+		spec = spec[i+1:]
+		if rule.Check == "" {
+			return rule, fmt.Errorf("invalid metric tag rule %q: empty check name", s)
+		}
+	}
+	parts := strings.Split(spec, ":")
+	rule.Operation = parts[0]
+	switch rule.Operation {
+	case MetricTagRename:
+		if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+			return rule, fmt.Errorf("invalid metric tag rule %q: expected %s:TAG:NEW_NAME", s, MetricTagRename)
+		}
+		rule.Tag, rule.NewName = parts[1], parts[2]
+		if rule.Tag == rule.NewName {
+			return rule, fmt.Errorf("invalid metric tag rule %q: the tag is renamed to itself", s)
+		}
+	case MetricTagDrop:
+		if len(parts) != 2 || parts[1] == "" {
+			return rule, fmt.Errorf("invalid metric tag rule %q: expected %s:TAG", s, MetricTagDrop)
+		}
+		rule.Tag = parts[1]
+	default:
+		return rule, fmt.Errorf("invalid metric tag rule %q: unknown operation %q, expected %q or %q", s, rule.Operation, MetricTagRename, MetricTagDrop)
+	}
+	return rule, nil
+}
+
+// applyMetricTagRules applies, in order, the rules of the given check to the
+// tags of the metric points. A renamed tag replaces the tag that already had
+// the new name.
+func applyMetricTagRules(points []*corev2.MetricPoint, check string, rules []MetricTagRule) {
+	for _, rule := range rules {
+		if rule.Check != "" && rule.Check != check {
+			continue
+		}
+		for _, point := range points {
+			if !hasMetricTag(point, rule.Tag) {
+				continue
+			}
+			tags := point.Tags[:0]
+			for _, tag := range point.Tags {
+				switch {
+				case tag.Name == rule.Tag && rule.Operation == MetricTagDrop:
+					continue
+				case tag.Name == rule.NewName && rule.Operation == MetricTagRename:
+					continue
+				case tag.Name == rule.Tag:
+					tag.Name = rule.NewName
+				}
+				tags = append(tags, tag)
+			}
+			point.Tags = tags
+		}
+	}
+}
+
+func hasMetricTag(point *corev2.MetricPoint, name string) bool {
+	for _, tag := range point.Tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
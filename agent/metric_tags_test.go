@@ -0,0 +1,74 @@
+package agent
+
+import (
+	"reflect"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+func TestParseMetricTagRule(t *testing.T) {
+	tests := []struct {
+		rule    string
+		want    MetricTagRule
+		wantErr bool
+	}{
+		{rule: "rename:host:hostname", want: MetricTagRule{Operation: MetricTagRename, Tag: "host", NewName: "hostname"}},
+		{rule: "drop:pid", want: MetricTagRule{Operation: MetricTagDrop, Tag: "pid"}},
+		{rule: "disk/drop:pid", want: MetricTagRule{Check: "disk", Operation: MetricTagDrop, Tag: "pid"}},
+		{rule: "rename:host", wantErr: true},
+		{rule: "rename:host:host", wantErr: true},
+		{rule: "drop:", wantErr: true},
+		{rule: "/drop:pid", wantErr: true},
+		{rule: "keep:pid", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			got, err := ParseMetricTagRule(tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMetricTagRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseMetricTagRule() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyMetricTagRules(t *testing.T) {
+	points := []*corev2.MetricPoint{
+		{
+			Name: "cpu",
+			Tags: []*corev2.MetricTag{
+				{Name: "host", Value: "web-01"},
+				{Name: "hostname", Value: "stale"},
+				{Name: "pid", Value: "42"},
+			},
+		},
+		{
+			Name: "mem",
+			Tags: []*corev2.MetricTag{
+				{Name: "pid", Value: "43"},
+			},
+		},
+	}
+	rules := []MetricTagRule{
+		{Operation: MetricTagRename, Tag: "host", NewName: "hostname"},
+		{Check: "other", Operation: MetricTagDrop, Tag: "hostname"},
+		{Check: "cpu-check", Operation: MetricTagDrop, Tag: "pid"},
+	}
+	applyMetricTagRules(points, "cpu-check", rules)
+
+	want := [][]*corev2.MetricTag{
+		{{Name: "hostname", Value: "web-01"}},
+		nil,
+	}
+	for i, point := range points {
+		if len(point.Tags) == 0 && len(want[i]) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(point.Tags, want[i]) {
+			t.Errorf("bad tags for point %s: got %v, want %v", point.Name, point.Tags, want[i])
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+const (
+	// SecretsProviderEnv resolves secrets from the environment of the agent.
+	SecretsProviderEnv = "env"
+
+	// SecretsProviderVault resolves secrets from a Vault server.
+	SecretsProviderVault = "vault"
+
+	// DefaultSecretsProvider specifies the default secrets provider
+	DefaultSecretsProvider = SecretsProviderEnv
+)
+
+// SecretsProvider resolves the secrets referenced by check environment
+// variables, right before the check is executed.
+type SecretsProvider interface {
+	// Get returns the value of the secret with the given ID.
+	Get(ctx context.Context, id string) (string, error)
+}
+
+// NewSecretsProvider returns the secrets provider selected by the agent
+// configuration.
+func NewSecretsProvider(config *Config) (SecretsProvider, error) {
+	switch config.SecretsProvider {
+	case "", SecretsProviderEnv:
+		return EnvSecretsProvider{}, nil
+	case SecretsProviderVault:
+		if config.VaultAddress == "" {
+			return nil, fmt.Errorf("the %s secrets provider requires a vault address", SecretsProviderVault)
+		}
+		return &VaultSecretsProvider{
+			Address: config.VaultAddress,
+			Token:   config.VaultToken,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown secrets provider %q", config.SecretsProvider)
+	}
+}
+
+// EnvSecretsProvider resolves secrets from the environment variables of the
+// agent process; the secret ID is the name of the environment variable.
+type EnvSecretsProvider struct{}
+
+// Get implements SecretsProvider.
+func (EnvSecretsProvider) Get(ctx context.Context, id string) (string, error) {
+	value, ok := os.LookupEnv(id)
+	if !ok {
+		return "", fmt.Errorf("secret %q not found in the agent environment", id)
+	}
+	return value, nil
+}
+
+// VaultSecretsProvider resolves secrets from the KV secrets engine of a Vault
+// server. Secret IDs take the form <path>#<key>, where path is relative to the
+// /v1/ API prefix, e.g. secret/data/database#password.
+type VaultSecretsProvider struct {
+	// Address is the URL of the Vault server.
+	Address string
+
+	// Token is used to authenticate against the Vault server.
+	Token string
+
+	// Client is the HTTP client used to query Vault. A client with a 10
+	// seconds timeout is used if nil.
+	Client *http.Client
+}
+
+// Get implements SecretsProvider.
+func (v *VaultSecretsProvider) Get(ctx context.Context, id string) (string, error) {
+	path, key := id, ""
+	if i := strings.LastIndex(id, "#"); i >= 0 {
+		path, key = id[:i], id[i+1:]
+	}
+	if path == "" || key == "" {
+		return "", fmt.Errorf("invalid vault secret %q, expected <path>#<key>", id)
+	}
+
+	url := fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(v.Address, "/"), strings.TrimPrefix(path, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", v.Token)
+
+	client := v.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error querying vault: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error reading vault secret %q: %s", path, resp.Status)
+	}
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("error decoding vault secret %q: %s", path, err)
+	}
+	data := body.Data
+	// The version 2 of the KV secrets engine nests the secret under data.data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+	value, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in vault secret %q", key, path)
+	}
+	return fmt.Sprint(value), nil
+}
+
+// secretEnvVars returns the names of the check environment variables that
+// refer to a secret, as listed by the corev2.CheckSecretEnvVarsAnnotation
+// annotation of the check.
+func secretEnvVars(annotations map[string]string) map[string]bool {
+	names := make(map[string]bool)
+	for _, name := range strings.Split(annotations[corev2.CheckSecretEnvVarsAnnotation], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// resolveSecretRefs returns a copy of the given environment variables in
+// which the values of the variables named in secrets, which are secret IDs,
+// are replaced with the value of the secret, as returned by the provider.
+func resolveSecretRefs(ctx context.Context, provider SecretsProvider, env []string, secrets map[string]bool) ([]string, error) {
+	if len(secrets) == 0 {
+		return env, nil
+	}
+	if provider == nil {
+		provider = EnvSecretsProvider{}
+	}
+	result := make([]string, 0, len(env))
+	for _, kv := range env {
+		pair := strings.SplitN(kv, "=", 2)
+		if len(pair) != 2 || !secrets[pair[0]] {
+			result = append(result, kv)
+			continue
+		}
+		value, err := provider.Get(ctx, pair[1])
+		if err != nil {
+			return nil, fmt.Errorf("error resolving secret for %s: %s", pair[0], err)
+		}
+		result = append(result, fmt.Sprintf("%s=%s", pair[0], value))
+	}
+	return result, nil
+}
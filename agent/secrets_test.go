@@ -0,0 +1,126 @@
+package agent
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSecretsProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		address  string
+		want     SecretsProvider
+		wantErr  bool
+	}{
+		{
+			name: "default",
+			want: EnvSecretsProvider{},
+		},
+		{
+			name:     "env",
+			provider: SecretsProviderEnv,
+			want:     EnvSecretsProvider{},
+		},
+		{
+			name:     "vault",
+			provider: SecretsProviderVault,
+			address:  "http://127.0.0.1:8200",
+			want:     &VaultSecretsProvider{Address: "http://127.0.0.1:8200"},
+		},
+		{
+			name:     "vault without address",
+			provider: SecretsProviderVault,
+			wantErr:  true,
+		},
+		{
+			name:     "unknown",
+			provider: "keepass",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, cleanup := FixtureConfig()
+			defer cleanup()
+			config.SecretsProvider = tt.provider
+			config.VaultAddress = tt.address
+			got, err := NewSecretsProvider(config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewSecretsProvider() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVaultSecretsProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/database":
+			_, _ = w.Write([]byte(`{"data":{"data":{"password":"kv2"},"metadata":{"version":1}}}`))
+		case "/v1/kv/database":
+			_, _ = w.Write([]byte(`{"data":{"password":"kv1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider := &VaultSecretsProvider{Address: server.URL, Token: "s.token"}
+	ctx := context.Background()
+
+	value, err := provider.Get(ctx, "secret/data/database#password")
+	require.NoError(t, err)
+	assert.Equal(t, "kv2", value)
+
+	value, err = provider.Get(ctx, "kv/database#password")
+	require.NoError(t, err)
+	assert.Equal(t, "kv1", value)
+
+	_, err = provider.Get(ctx, "kv/database#username")
+	assert.Error(t, err)
+
+	_, err = provider.Get(ctx, "kv/missing#password")
+	assert.Error(t, err)
+
+	_, err = provider.Get(ctx, "kv/database")
+	assert.Error(t, err)
+
+	provider.Token = "s.wrong"
+	_, err = provider.Get(ctx, "kv/database#password")
+	assert.Error(t, err)
+}
+
+func TestResolveSecretRefs(t *testing.T) {
+	t.Setenv("SENSU_TEST_SECRET", "hunter2")
+
+	secrets := secretEnvVars(map[string]string{
+		corev2.CheckSecretEnvVarsAnnotation: "PASSWORD, TOKEN",
+	})
+	assert.Equal(t, map[string]bool{"PASSWORD": true, "TOKEN": true}, secrets)
+
+	// the values of the variables not listed are kept as is
+	env, err := resolveSecretRefs(context.Background(), EnvSecretsProvider{}, []string{
+		"FOO=secret:SENSU_TEST_SECRET",
+		"PASSWORD=SENSU_TEST_SECRET",
+		"EMPTY=",
+	}, secrets)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"FOO=secret:SENSU_TEST_SECRET", "PASSWORD=hunter2", "EMPTY="}, env)
+
+	_, err = resolveSecretRefs(context.Background(), EnvSecretsProvider{}, []string{
+		"PASSWORD=SENSU_TEST_MISSING_SECRET",
+	}, secrets)
+	assert.Error(t, err)
+}
@@ -0,0 +1,189 @@
+package agent
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sensu/sensu-go/transport"
+	bolt "go.etcd.io/bbolt"
+)
+
+var spoolBucket = []byte("spool")
+
+// spool persists on disk the messages that could not be sent to the backend,
+// so that they are replayed once the agent reconnects. The oldest messages are
+// dropped once the spool exceeds its maximum size, and messages older than its
+// TTL are dropped when they are replayed.
+type spool struct {
+	db      *bolt.DB
+	maxSize int64
+	ttl     time.Duration
+
+	mu   sync.Mutex
+	size int64
+}
+
+// spooledMessage is a message of the spool, along with the time at which it
+// was spooled.
+type spooledMessage struct {
+	Type      string `json:"type"`
+	Payload   []byte `json:"payload"`
+	SpooledAt int64  `json:"spooled_at"`
+}
+
+func newSpool(path string, maxSize int64, ttl time.Duration) (*spool, error) {
+	if path == os.DevNull {
+		return nil, errors.New("the event spool requires a cache directory")
+	}
+	if err := os.MkdirAll(path, 0744|os.ModeDir); err != nil {
+		return nil, fmt.Errorf("could not create directory for event spool (%s): %s", path, err)
+	}
+	spoolPath := filepath.Join(path, "spool.db")
+	db, err := bolt.Open(spoolPath, 0600, &bolt.Options{Timeout: 60 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("could not open event spool (%s): %s (is sensu-agent already running?)", spoolPath, err)
+	}
+	s := &spool{db: db, maxSize: maxSize, ttl: ttl}
+	err = db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(spoolBucket)
+		if err != nil {
+			return err
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			s.size += int64(len(v))
+			return nil
+		})
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("error creating event spool: %s", err)
+	}
+	return s, nil
+}
+
+// Close closes the spool.
+func (s *spool) Close() error {
+	return s.db.Close()
+}
+
+// Push adds a message to the spool, dropping the oldest messages if the spool
+// grows beyond its maximum size.
+func (s *spool) Push(msg *transport.Message) error {
+	value, err := json.Marshal(spooledMessage{
+		Type:      msg.Type,
+		Payload:   msg.Payload,
+		SpooledAt: time.Now().UnixNano(),
+	})
+	if err != nil {
+		return err
+	}
+	if s.maxSize > 0 && int64(len(value)) > s.maxSize {
+		return fmt.Errorf("message of %d bytes is larger than the event spool", len(value))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(spoolBucket)
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		if err := bucket.Put(spoolKey(seq), value); err != nil {
+			return err
+		}
+		s.size += int64(len(value))
+
+		for s.maxSize > 0 && s.size > s.maxSize {
+			k, v := bucket.Cursor().First()
+			if k == nil {
+				break
+			}
+			size := int64(len(v))
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+			s.size -= size
+			messagesDropped.WithLabelValues().Inc()
+			logger.Warn("event spool is full, dropping the oldest message")
+		}
+		return nil
+	})
+}
+
+// Replay sends the messages of the spool with send, oldest first. Each message
+// is removed from the spool once sent, and Replay stops at the first message
+// that can't be sent, leaving it in the spool. It returns the number of
+// messages sent.
+func (s *spool) Replay(send func(*transport.Message) error) (int, error) {
+	sent := 0
+	for {
+		key, value, err := s.first()
+		if err != nil || key == nil {
+			return sent, err
+		}
+		var msg spooledMessage
+		if err := json.Unmarshal(value, &msg); err != nil {
+			logger.WithError(err).Error("dropping invalid message from the event spool")
+		} else if s.ttl > 0 && time.Since(time.Unix(0, msg.SpooledAt)) > s.ttl {
+			messagesDropped.WithLabelValues().Inc()
+			logger.WithField("type", msg.Type).Warn("dropping expired message from the event spool")
+		} else {
+			if err := send(&transport.Message{Type: msg.Type, Payload: msg.Payload}); err != nil {
+				return sent, err
+			}
+			sent++
+		}
+		if err := s.delete(key); err != nil {
+			return sent, err
+		}
+	}
+}
+
+// first returns the oldest message of the spool, or a nil key if the spool is
+// empty.
+func (s *spool) first() (key, value []byte, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		k, v := tx.Bucket(spoolBucket).Cursor().First()
+		if k != nil {
+			key = append([]byte(nil), k...)
+			value = append([]byte(nil), v...)
+		}
+		return nil
+	})
+	return key, value, err
+}
+
+func (s *spool) delete(key []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(spoolBucket)
+		if v := bucket.Get(key); v != nil {
+			s.size -= int64(len(v))
+		}
+		return bucket.Delete(key)
+	})
+}
+
+// Len returns the number of messages in the spool.
+func (s *spool) Len() int {
+	n := 0
+	_ = s.db.View(func(tx *bolt.Tx) error {
+		n = tx.Bucket(spoolBucket).Stats().KeyN
+		return nil
+	})
+	return n
+}
+
+func spoolKey(seq uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, seq)
+	return key
+}
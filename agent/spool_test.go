@@ -0,0 +1,145 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sensu/sensu-go/transport"
+)
+
+func newTestSpool(t *testing.T, maxSize int64, ttl time.Duration) *spool {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "spool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	s, err := newSpool(dir, maxSize, ttl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func spoolPayloads(t *testing.T, s *spool) []string {
+	t.Helper()
+	var payloads []string
+	_, err := s.Replay(func(msg *transport.Message) error {
+		payloads = append(payloads, string(msg.Payload))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return payloads
+}
+
+func TestSpoolReplayOldestFirst(t *testing.T) {
+	s := newTestSpool(t, DefaultSpoolMaxSize, DefaultSpoolTTL)
+	for i := 0; i < 3; i++ {
+		if err := s.Push(&transport.Message{Type: transport.MessageTypeEvent, Payload: []byte(fmt.Sprint(i))}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := fmt.Sprint(spoolPayloads(t, s)), "[0 1 2]"; got != want {
+		t.Errorf("replayed %s, want %s", got, want)
+	}
+	if got := s.Len(); got != 0 {
+		t.Errorf("spool has %d messages after replay, want 0", got)
+	}
+}
+
+func TestSpoolReplayStopsOnError(t *testing.T) {
+	s := newTestSpool(t, DefaultSpoolMaxSize, DefaultSpoolTTL)
+	for i := 0; i < 3; i++ {
+		if err := s.Push(&transport.Message{Payload: []byte(fmt.Sprint(i))}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sent, err := s.Replay(func(msg *transport.Message) error {
+		if string(msg.Payload) == "1" {
+			return errors.New("connection closed")
+		}
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if sent != 1 {
+		t.Errorf("sent %d messages, want 1", sent)
+	}
+	if got, want := fmt.Sprint(spoolPayloads(t, s)), "[1 2]"; got != want {
+		t.Errorf("replayed %s, want %s", got, want)
+	}
+}
+
+func TestSpoolMaxSize(t *testing.T) {
+	msg := &transport.Message{Payload: []byte("0")}
+	value := int64(len(`{"type":"","payload":"MA==","spooled_at":0000000000000000000}`))
+	s := newTestSpool(t, 2*value, DefaultSpoolTTL)
+	for i := 0; i < 3; i++ {
+		msg.Payload = []byte(fmt.Sprint(i))
+		if err := s.Push(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := fmt.Sprint(spoolPayloads(t, s)), "[1 2]"; got != want {
+		t.Errorf("replayed %s, want %s", got, want)
+	}
+
+	msg.Payload = make([]byte, 2*value)
+	if err := s.Push(msg); err == nil {
+		t.Error("expected an error for a message larger than the spool")
+	}
+}
+
+func TestSpoolTTL(t *testing.T) {
+	s := newTestSpool(t, DefaultSpoolMaxSize, time.Millisecond)
+	if err := s.Push(&transport.Message{Payload: []byte("expired")}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if got := spoolPayloads(t, s); len(got) != 0 {
+		t.Errorf("replayed %v, want nothing", got)
+	}
+	if got := s.Len(); got != 0 {
+		t.Errorf("spool has %d messages after replay, want 0", got)
+	}
+}
+
+func TestSpoolReopen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s, err := newSpool(dir, DefaultSpoolMaxSize, DefaultSpoolTTL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Push(&transport.Message{Payload: []byte("persisted")}); err != nil {
+		t.Fatal(err)
+	}
+	size := s.size
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = newSpool(dir, DefaultSpoolMaxSize, DefaultSpoolTTL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if s.size != size {
+		t.Errorf("spool size is %d after reopening, want %d", s.size, size)
+	}
+	if got, want := fmt.Sprint(spoolPayloads(t, s)), "[persisted]"; got != want {
+		t.Errorf("replayed %s, want %s", got, want)
+	}
+}
@@ -43,12 +43,23 @@ func (g GraphiteList) Transform() []*types.MetricPoint {
 
 // ParseGraphite parses a graphite plain text string into a Graphite struct
 func ParseGraphite(event *types.Event) GraphiteList {
+	graphiteList, _ := ParseGraphiteN(event, 0)
+	return graphiteList
+}
+
+// ParseGraphiteN parses at most n metrics of a graphite plain text string, or
+// all of them if n is 0. The lines beyond the n-th metric are counted but not
+// parsed, their number is returned as the number of metrics dropped.
+func ParseGraphiteN(event *types.Event, n int) (GraphiteList, int) {
 	var graphiteList GraphiteList
+	var dropped int
 	fields := logrus.Fields{
 		"namespace": event.Check.Namespace,
 		"check":     event.Check.Name,
 	}
 
+	unit := metricTimestampUnit(event, fields)
+
 	metric := strings.TrimSpace(event.Check.Output)
 	s := bufio.NewScanner(strings.NewReader(metric))
 	l := 0
@@ -57,8 +68,17 @@ func ParseGraphite(event *types.Event) GraphiteList {
 		line := s.Text()
 		fields["line"] = l
 		l++
+		// The fields of a line can be separated by any amount of whitespace,
+		// and blank lines are not metrics
+		args := strings.Fields(line)
+		if len(args) == 0 {
+			continue
+		}
+		if n > 0 && len(graphiteList) >= n {
+			dropped++
+			continue
+		}
 		g := Graphite{}
-		args := strings.Split(line, " ")
 		if len(args) != 3 {
 			logger.WithFields(fields).WithError(ErrMetricExtraction).Error("graphite plain text format requires exactly 3 arguments")
 			continue
@@ -78,7 +98,7 @@ func ParseGraphite(event *types.Event) GraphiteList {
 			logger.WithFields(fields).WithError(ErrMetricExtraction).Errorf("metric timestamp is invalid, third argument must be an int: %s", args[2])
 			continue
 		}
-		g.Timestamp = i
+		g.Timestamp = toSeconds(i, unit)
 		g.Tags = event.Check.OutputMetricTags
 		graphiteList = append(graphiteList, g)
 	}
@@ -86,5 +106,5 @@ func ParseGraphite(event *types.Event) GraphiteList {
 		logger.WithFields(fields).WithError(ErrMetricExtraction).Error(err)
 	}
 
-	return graphiteList
+	return graphiteList, dropped
 }
@@ -59,6 +59,21 @@ func TestParseGraphite(t *testing.T) {
 				},
 			},
 		},
+		{
+			metric: "metric.value\t1   123456789\n\nmetric.value 0 0",
+			expectedFormat: GraphiteList{
+				{
+					Path:      "metric.value",
+					Value:     1,
+					Timestamp: 123456789,
+				},
+				{
+					Path:      "metric.value",
+					Value:     0,
+					Timestamp: 0,
+				},
+			},
+		},
 		{
 			metric:         "",
 			expectedFormat: GraphiteList(nil),
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/types"
 	"github.com/sirupsen/logrus"
 )
@@ -41,12 +42,24 @@ func (i InfluxList) Transform() []*types.MetricPoint {
 
 // ParseInflux parses an influx db line protocol string into an Influx struct
 func ParseInflux(event *types.Event) InfluxList {
+	influxList, _ := ParseInfluxN(event, 0)
+	return influxList
+}
+
+// ParseInfluxN parses at most n metrics, i.e. fields, of an influx db line
+// protocol string, or all of them if n is 0. The lines beyond the n-th metric
+// are not parsed, their fields are only counted; their number is returned
+// as the number of metrics dropped.
+func ParseInfluxN(event *types.Event, n int) (InfluxList, int) {
 	var influxList InfluxList
+	var points, dropped int
 	fields := logrus.Fields{
 		"namespace": event.Check.Namespace,
 		"check":     event.Check.Name,
 	}
 
+	unit := metricTimestampUnit(event, fields)
+
 	metric := strings.TrimSpace(event.Check.Output)
 	s := bufio.NewScanner(strings.NewReader(metric))
 	l := 0
@@ -58,6 +71,12 @@ OUTER:
 		l++
 		i := Influx{}
 		args := strings.Split(line, " ")
+		if n > 0 && points >= n {
+			if len(args) > 1 {
+				dropped += strings.Count(args[1], ",") + 1
+			}
+			continue
+		}
 		if len(args) != 3 && len(args) != 2 {
 			logger.WithFields(fields).WithError(ErrMetricExtraction).Error("influxdb line format requires 2 arguments with a 3rd (optional) timestamp")
 			continue
@@ -109,11 +128,16 @@ OUTER:
 			}
 			fieldList = append(fieldList, field)
 		}
+		if n > 0 && points+len(fieldList) > n {
+			dropped += points + len(fieldList) - n
+			fieldList = fieldList[:n-points]
+		}
 		i.FieldSet = fieldList
 
 		if len(args) == 3 {
 			timestamp := args[2]
-			if len(timestamp) > 10 {
+			// Without a unit, the timestamp is truncated to its seconds
+			if unit == corev2.MetricTimestampUnitAuto && len(timestamp) > 10 {
 				timestamp = timestamp[:10]
 			}
 			t, err := strconv.ParseInt(timestamp, 10, 64)
@@ -121,15 +145,16 @@ OUTER:
 				logger.WithFields(fields).WithError(ErrMetricExtraction).Errorf("metric timestamp is invalid, third argument must be an int: %s", timestamp)
 				continue
 			}
-			i.Timestamp = t
+			i.Timestamp = toSeconds(t, unit)
 		} else {
 			i.Timestamp = time.Now().UTC().Unix()
 		}
 		influxList = append(influxList, i)
+		points += len(i.FieldSet)
 	}
 	if err := s.Err(); err != nil {
 		logger.WithFields(fields).WithError(ErrMetricExtraction).Error(err)
 	}
 
-	return influxList
+	return influxList, dropped
 }
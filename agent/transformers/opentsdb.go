@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/types"
 	"github.com/sirupsen/logrus"
 )
@@ -37,12 +38,23 @@ func (o OpenTSDBList) Transform() []*types.MetricPoint {
 
 // ParseOpenTSDB parses OpenTSDB metrics into a list of OpenTSDB structs
 func ParseOpenTSDB(event *types.Event) OpenTSDBList {
+	openTSDBList, _ := ParseOpenTSDBN(event, 0)
+	return openTSDBList
+}
+
+// ParseOpenTSDBN parses at most n OpenTSDB metrics, or all of them if n is 0.
+// The lines beyond the n-th metric are counted but not parsed, their number is
+// returned as the number of metrics dropped.
+func ParseOpenTSDBN(event *types.Event, n int) (OpenTSDBList, int) {
 	var openTSDBList OpenTSDBList
+	var dropped int
 	fields := logrus.Fields{
 		"namespace": event.Check.Namespace,
 		"check":     event.Check.Name,
 	}
 
+	unit := metricTimestampUnit(event, fields)
+
 	// Split each line of the output into its own metric
 	output := strings.TrimSpace(event.Check.Output)
 	s := bufio.NewScanner(strings.NewReader(output))
@@ -53,6 +65,12 @@ OUTER:
 		metric := s.Text()
 		fields["line"] = l
 		l++
+		if n > 0 && len(openTSDBList) >= n {
+			if strings.TrimSpace(metric) != "" {
+				dropped++
+			}
+			continue
+		}
 		parts := strings.Split(metric, " ")
 
 		// Ensure we have all the required components. A single metric requires a
@@ -70,7 +88,9 @@ OUTER:
 			logger.WithFields(fields).WithError(ErrMetricExtraction).Errorf("invalid opentsdb metric timestamp, must be an integer: %s", parts[1])
 			continue
 		}
-		if len(parts[1]) == 13 {
+		if unit != corev2.MetricTimestampUnitAuto {
+			timestamp = toSeconds(timestamp, unit)
+		} else if len(parts[1]) == 13 {
 			timestamp = timestamp / 1000
 		}
 
@@ -118,5 +138,5 @@ OUTER:
 		logger.WithFields(fields).WithError(ErrMetricExtraction).Error(err)
 	}
 
-	return openTSDBList
+	return openTSDBList, dropped
 }
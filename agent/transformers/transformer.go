@@ -1,6 +1,12 @@
 package transformers
 
-import "errors"
+import (
+	"errors"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/types"
+	"github.com/sirupsen/logrus"
+)
 
 // ErrMetricExtraction is a blanket error for when transformer fails to extract metrics from a single line protocol
 var ErrMetricExtraction = errors.New("unable to extract metric from check output")
@@ -10,3 +16,30 @@ type Field struct {
 	Key   string
 	Value float64
 }
+
+// metricTimestampUnit returns the unit of the output metric timestamps of the
+// check of the event. An invalid unit is logged and ignored in favor of the
+// auto unit.
+func metricTimestampUnit(event *types.Event, fields logrus.Fields) string {
+	unit, err := corev2.CheckMetricTimestampUnit(event.Check.Annotations)
+	if err != nil {
+		logger.WithFields(fields).WithError(err).Warn("ignoring invalid output metric timestamp unit")
+		return corev2.MetricTimestampUnitAuto
+	}
+	return unit
+}
+
+// toSeconds converts a timestamp in the given unit to a unix timestamp with
+// second resolution. Timestamps in the auto unit are returned as is, the
+// transformers guessing their unit from the output metric format.
+func toSeconds(timestamp int64, unit string) int64 {
+	switch unit {
+	case corev2.MetricTimestampUnitMilliseconds:
+		return timestamp / 1e3
+	case corev2.MetricTimestampUnitMicroseconds:
+		return timestamp / 1e6
+	case corev2.MetricTimestampUnitNanoseconds:
+		return timestamp / 1e9
+	}
+	return timestamp
+}
@@ -0,0 +1,96 @@
+package transformers
+
+import (
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricTimestampUnit(t *testing.T) {
+	testCases := []struct {
+		name     string
+		unit     string
+		parse    func(*types.Event) []*types.MetricPoint
+		output   string
+		expected int64
+	}{
+		{
+			name:     "graphite auto",
+			parse:    func(e *types.Event) []*types.MetricPoint { return ParseGraphite(e).Transform() },
+			output:   "metric.value 1 1465839830",
+			expected: 1465839830,
+		},
+		{
+			name:     "graphite ms",
+			unit:     corev2.MetricTimestampUnitMilliseconds,
+			parse:    func(e *types.Event) []*types.MetricPoint { return ParseGraphite(e).Transform() },
+			output:   "metric.value 1 1465839830100",
+			expected: 1465839830,
+		},
+		{
+			name:     "graphite invalid unit",
+			unit:     "minutes",
+			parse:    func(e *types.Event) []*types.MetricPoint { return ParseGraphite(e).Transform() },
+			output:   "metric.value 1 1465839830",
+			expected: 1465839830,
+		},
+		{
+			name:     "influx auto",
+			parse:    func(e *types.Event) []*types.MetricPoint { return ParseInflux(e).Transform() },
+			output:   "weather temperature=82 1465839830100400200",
+			expected: 1465839830,
+		},
+		{
+			name:     "influx s",
+			unit:     corev2.MetricTimestampUnitSeconds,
+			parse:    func(e *types.Event) []*types.MetricPoint { return ParseInflux(e).Transform() },
+			output:   "weather temperature=82 946684800",
+			expected: 946684800,
+		},
+		{
+			name:     "influx us",
+			unit:     corev2.MetricTimestampUnitMicroseconds,
+			parse:    func(e *types.Event) []*types.MetricPoint { return ParseInflux(e).Transform() },
+			output:   "weather temperature=82 946684800100400",
+			expected: 946684800,
+		},
+		{
+			name:     "influx ns",
+			unit:     corev2.MetricTimestampUnitNanoseconds,
+			parse:    func(e *types.Event) []*types.MetricPoint { return ParseInflux(e).Transform() },
+			output:   "weather temperature=82 1465839830100400200",
+			expected: 1465839830,
+		},
+		{
+			name:     "opentsdb auto",
+			parse:    func(e *types.Event) []*types.MetricPoint { return ParseOpenTSDB(e).Transform() },
+			output:   "sys.cpu.user 1356998400500 42.5 host=webserver01",
+			expected: 1356998400,
+		},
+		{
+			name:     "opentsdb ms",
+			unit:     corev2.MetricTimestampUnitMilliseconds,
+			parse:    func(e *types.Event) []*types.MetricPoint { return ParseOpenTSDB(e).Transform() },
+			output:   "sys.cpu.user 946684800500 42.5 host=webserver01",
+			expected: 946684800,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			event := types.FixtureEvent("test", "test")
+			event.Check.Output = tc.output
+			if tc.unit != "" {
+				event.Check.Annotations = map[string]string{
+					corev2.CheckMetricTimestampUnitAnnotation: tc.unit,
+				}
+			}
+			points := tc.parse(event)
+			if assert.Len(t, points, 1) {
+				assert.Equal(t, tc.expected, points[0].Timestamp)
+			}
+		})
+	}
+}
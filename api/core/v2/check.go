@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"strings"
 	"time"
 
 	cron "github.com/robfig/cron/v3"
@@ -22,6 +23,48 @@ const (
 	// DefaultSplayCoverage is the default splay coverage for proxy check requests
 	DefaultSplayCoverage = 90.0
 
+	// CheckSplayCoverageAnnotation is the annotation of the checks whose
+	// executions are spread by the agents over the given percentage of their
+	// interval.
+	CheckSplayCoverageAnnotation = "sensu.io/check_splay_coverage"
+
+	// CheckSkipDefaultHandlersAnnotation is the annotation of the checks
+	// whose events are not handled by the default handlers of their
+	// namespace, when set to "true".
+	CheckSkipDefaultHandlersAnnotation = "sensu.io/skip_default_handlers"
+
+	// CheckMetricTimestampUnitAnnotation is the annotation of the checks
+	// whose output metric timestamps are in the given unit, one of "s", "ms",
+	// "us", "ns" or "auto". With "auto", the default, the unit is guessed from
+	// the output metric format.
+	CheckMetricTimestampUnitAnnotation = "sensu.io/output_metric_timestamp_unit"
+
+	// CheckSecretEnvVarsAnnotation is the annotation of the checks whose
+	// environment variables named in its comma-separated value hold the ID of
+	// a secret, resolved by the agent secrets provider before execution.
+	CheckSecretEnvVarsAnnotation = "sensu.io/secret_env_vars"
+
+	// Units of the output metric timestamps, set with the
+	// CheckMetricTimestampUnitAnnotation annotation.
+	MetricTimestampUnitAuto         = "auto"
+	MetricTimestampUnitSeconds      = "s"
+	MetricTimestampUnitMilliseconds = "ms"
+	MetricTimestampUnitMicroseconds = "us"
+	MetricTimestampUnitNanoseconds  = "ns"
+
+	// DefaultMaxCheckHistory is the default number of check results retained
+	// in the history of the events.
+	DefaultMaxCheckHistory = 21
+
+	// MinCheckHistory is the minimum number of check results retained in the
+	// history of the events, the current and the previous ones.
+	MinCheckHistory = 2
+
+	// flapDetectionHistory is the number of check results the flap detection
+	// is computed over. The checks with flap thresholds retain at least as
+	// many results in their history.
+	flapDetectionHistory = 21
+
 	// NagiosOutputMetricFormat is the accepted string to represent the output metric format of
 	// Nagios Perf Data
 	NagiosOutputMetricFormat = "nagios_perfdata"
@@ -118,6 +161,10 @@ func NewCheck(c *CheckConfig) *Check {
 		Stdin:                  c.Stdin,
 		Subdue:                 c.Subdue,
 		Cron:                   c.Cron,
+		CronTimezone:           c.CronTimezone,
+		User:                   c.User,
+		Group:                  c.Group,
+		Argv:                   c.Argv,
 		Ttl:                    c.Ttl,
 		Timeout:                c.Timeout,
 		ProxyRequests:          c.ProxyRequests,
@@ -190,6 +237,10 @@ func (c *Check) Validate() error {
 		}
 	}
 
+	if err := ValidateCronTimezone(c.Cron, c.CronTimezone); err != nil {
+		return err
+	}
+
 	if c.Ttl > 0 && c.Ttl <= int64(c.Interval) {
 		return errors.New("ttl must be greater than check interval")
 	}
@@ -279,6 +330,30 @@ func (c *Check) MarshalJSON() ([]byte, error) {
 // MergeWith updates the current Check with the history of the check given as
 // an argument, updating the current check's history appropriately.
 func (c *Check) MergeWith(prevCheck *Check) {
+	c.MergeWithMaxHistory(prevCheck, DefaultMaxCheckHistory)
+}
+
+// MergeWithMaxHistory is like MergeWith, but retains at most maxHistory
+// results in the history, trimming the oldest ones. The checks with flap
+// thresholds retain enough results for flap detection regardless.
+func (c *Check) MergeWithMaxHistory(prevCheck *Check, maxHistory int) {
+	if maxHistory < MinCheckHistory {
+		maxHistory = MinCheckHistory
+	}
+	if c.LowFlapThreshold != 0 && c.HighFlapThreshold != 0 && maxHistory < flapDetectionHistory {
+		maxHistory = flapDetectionHistory
+	}
+
+	// Record the previous occurrence, unless the check is merged with itself
+	// because it is the first one
+	c.Previous = nil
+	if prevCheck != c {
+		c.Previous = &CheckHistory{
+			Status:   prevCheck.Status,
+			Executed: prevCheck.Executed,
+		}
+	}
+
 	history := prevCheck.History
 	histEntry := CheckHistory{
 		Status:   c.Status,
@@ -286,8 +361,8 @@ func (c *Check) MergeWith(prevCheck *Check) {
 	}
 
 	history = append(history, histEntry)
-	if len(history) > 21 {
-		history = history[1:]
+	if len(history) > maxHistory {
+		history = history[len(history)-maxHistory:]
 	}
 
 	c.History = history
@@ -313,6 +388,25 @@ func ValidateOutputMetricFormat(format string) error {
 	return errors.New("output metric format is not valid")
 }
 
+// ValidateCronTimezone returns an error if the timezone is not a valid IANA
+// time zone for the cron string. The cron string must not set its own time
+// zone with a CRON_TZ or TZ prefix.
+func ValidateCronTimezone(cronStr, timezone string) error {
+	if timezone == "" {
+		return nil
+	}
+	if cronStr == "" {
+		return errors.New("cron timezone requires a cron schedule")
+	}
+	if strings.HasPrefix(cronStr, "CRON_TZ=") || strings.HasPrefix(cronStr, "TZ=") {
+		return errors.New("cron timezone cannot be used with a cron string that sets its own time zone")
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("cron timezone is invalid: %w", err)
+	}
+	return nil
+}
+
 func ValidateSubdues(subdues []*TimeWindowRepeated) error {
 	for i, subdue := range subdues {
 		if err := subdue.Validate(); err != nil {
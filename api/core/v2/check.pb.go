@@ -179,7 +179,10 @@ type ProxyRequests struct {
 	Splay bool `protobuf:"varint,2,opt,name=splay,proto3" json:"splay"`
 	// SplayCoverage is the percentage used for proxy check request splay
 	// calculation.
-	SplayCoverage        uint32   `protobuf:"varint,3,opt,name=splay_coverage,json=splayCoverage,proto3" json:"splay_coverage"`
+	SplayCoverage uint32 `protobuf:"varint,3,opt,name=splay_coverage,json=splayCoverage,proto3" json:"splay_coverage"`
+	// BatchSize is the number of proxy check requests published together at
+	// each step of the splay. Requests are published one at a time when 0.
+	BatchSize            uint32   `protobuf:"varint,4,opt,name=batch_size,json=batchSize,proto3" json:"batch_size"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -239,6 +242,13 @@ func (m *ProxyRequests) GetSplayCoverage() uint32 {
 	return 0
 }
 
+func (m *ProxyRequests) GetBatchSize() uint32 {
+	if m != nil {
+		return m.BatchSize
+	}
+	return 0
+}
+
 // CheckConfig is the specification of a check.
 type CheckConfig struct {
 	// Command is the command to be executed.
@@ -316,9 +326,24 @@ type CheckConfig struct {
 	Pipelines              []*ResourceReference  `protobuf:"bytes,32,rep,name=pipelines,proto3" json:"pipelines"`
 	OutputMetricThresholds []*MetricThreshold    `protobuf:"bytes,33,rep,name=output_metric_thresholds,json=outputMetricThresholds,proto3" json:"output_metric_thresholds,omitempty" yaml: "output_metric_thresholds,omitempty"`
 	Subdues                []*TimeWindowRepeated `protobuf:"bytes,34,rep,name=subdues,proto3" json:"subdues,omitempty"`
-	XXX_NoUnkeyedLiteral   struct{}              `json:"-"`
-	XXX_unrecognized       []byte                `json:"-"`
-	XXX_sizecache          int32                 `json:"-"`
+	// CronTimezone is the IANA time zone in which the cron string is
+	// evaluated. When empty, the cron string is evaluated in the time zone of
+	// the backend, unless it sets its own with a CRON_TZ prefix.
+	CronTimezone string `protobuf:"bytes,35,opt,name=cron_timezone,json=cronTimezone,proto3" json:"cron_timezone,omitempty"`
+	// User is the name or ID of the user the agent runs the check command as,
+	// instead of its own user. Only supported by unix agents.
+	User string `protobuf:"bytes,36,opt,name=user,proto3" json:"user,omitempty"`
+	// Group is the name or ID of the group the agent runs the check command as.
+	// When empty, the primary group of User is used. Only supported by unix
+	// agents.
+	Group string `protobuf:"bytes,37,opt,name=group,proto3" json:"group,omitempty"`
+	// Argv is the command to execute as an array of the executable and its
+	// arguments. When set, it is executed directly, without a shell, and
+	// Command is ignored.
+	Argv                 []string `protobuf:"bytes,38,rep,name=argv,proto3" json:"argv,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CheckConfig) Reset()         { *m = CheckConfig{} }
@@ -468,6 +493,25 @@ type Check struct {
 	// the check status.
 	OutputMetricThresholds []*MetricThreshold    `protobuf:"bytes,47,rep,name=output_metric_thresholds,json=outputMetricThresholds,proto3" json:"output_metric_thresholds,omitempty" yaml: "output_metric_thresholds,omitempty"`
 	Subdues                []*TimeWindowRepeated `protobuf:"bytes,48,rep,name=subdues,proto3" json:"subdues,omitempty"`
+	// CronTimezone is the IANA time zone in which the cron string is
+	// evaluated. When empty, the cron string is evaluated in the time zone of
+	// the backend, unless it sets its own with a CRON_TZ prefix.
+	CronTimezone string `protobuf:"bytes,49,opt,name=cron_timezone,json=cronTimezone,proto3" json:"cron_timezone,omitempty"`
+	// User is the name or ID of the user the agent runs the check command as,
+	// instead of its own user. Only supported by unix agents.
+	User string `protobuf:"bytes,50,opt,name=user,proto3" json:"user,omitempty"`
+	// Group is the name or ID of the group the agent runs the check command as.
+	// When empty, the primary group of User is used. Only supported by unix
+	// agents.
+	Group string `protobuf:"bytes,51,opt,name=group,proto3" json:"group,omitempty"`
+	// Argv is the command to execute as an array of the executable and its
+	// arguments. When set, it is executed directly, without a shell, and
+	// Command is ignored.
+	Argv []string `protobuf:"bytes,52,rep,name=argv,proto3" json:"argv,omitempty"`
+	// Previous is the status and execution time of the previous occurrence of
+	// the check, set by the backend when the event is stored. It is nil for
+	// the first occurrence.
+	Previous *CheckHistory `protobuf:"bytes,53,opt,name=previous,proto3" json:"previous,omitempty"`
 	// ExtendedAttributes store serialized arbitrary JSON-encoded data
 	ExtendedAttributes   []byte   `protobuf:"bytes,99,opt,name=ExtendedAttributes,proto3" json:"-"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -593,121 +637,125 @@ func init() {
 }
 
 var fileDescriptor_6b843265b29f5373 = []byte{
-	// 1812 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x58, 0xcf, 0x73, 0xdb, 0xc6,
-	0xf5, 0x37, 0x24, 0x8b, 0x12, 0x97, 0xa6, 0x7e, 0xac, 0x25, 0x7b, 0xad, 0xd8, 0x04, 0xcd, 0x6f,
-	0x9c, 0xf0, 0x1b, 0xc7, 0x94, 0x4d, 0x37, 0x93, 0xd4, 0xe3, 0xe9, 0xd4, 0x50, 0xed, 0x2a, 0x6d,
-	0x1c, 0x7b, 0xd6, 0x6a, 0x3d, 0xd3, 0x99, 0x0e, 0x66, 0x09, 0xac, 0x48, 0x54, 0x24, 0xc0, 0x62,
-	0x17, 0x94, 0x98, 0x4b, 0x7b, 0xec, 0xb1, 0xc7, 0x1e, 0x73, 0x4c, 0x2f, 0xed, 0xb5, 0x7f, 0x42,
-	0x8e, 0xb9, 0xf6, 0x82, 0x69, 0xd5, 0x1b, 0x8e, 0x39, 0xf5, 0xd8, 0xd9, 0x87, 0x05, 0x08, 0x52,
-	0x94, 0x23, 0xcf, 0x38, 0xd3, 0x4c, 0x27, 0x17, 0x62, 0xf7, 0xb3, 0xef, 0xb3, 0x3f, 0xde, 0x7e,
-	0x76, 0xdf, 0x5b, 0xa2, 0x7b, 0x5d, 0x4f, 0xf6, 0xa2, 0x4e, 0xcb, 0x09, 0x06, 0x3b, 0x82, 0xfb,
-	0x22, 0x4a, 0x7f, 0xef, 0x74, 0x83, 0x1d, 0x36, 0xf4, 0x76, 0x9c, 0x20, 0xe4, 0x3b, 0xa3, 0xf6,
-	0x8e, 0xd3, 0xe3, 0xce, 0x61, 0x6b, 0x18, 0x06, 0x32, 0xc0, 0x55, 0xb0, 0x68, 0xa9, 0xa6, 0xd6,
-	0xa8, 0xbd, 0xfd, 0x83, 0x42, 0x0f, 0xdd, 0xa0, 0x1b, 0xec, 0x80, 0x55, 0x27, 0x3a, 0xf8, 0xf1,
-	0xe8, 0x5e, 0xeb, 0x7e, 0xeb, 0x1e, 0x80, 0x80, 0x41, 0x29, 0xed, 0x64, 0xfb, 0x9c, 0xe3, 0x32,
-	0x21, 0xb8, 0xd4, 0x94, 0xbb, 0xe7, 0xa3, 0xf4, 0x82, 0xe0, 0xf0, 0xf5, 0x18, 0x03, 0x2e, 0x99,
-	0x66, 0x3c, 0x3c, 0x37, 0x23, 0xf4, 0x1c, 0x5b, 0xf6, 0x42, 0x2e, 0x7a, 0x41, 0xdf, 0xd5, 0xec,
-	0xfb, 0xaf, 0xc3, 0x16, 0x9a, 0xf4, 0xa3, 0xf3, 0x91, 0x42, 0x2e, 0x82, 0x28, 0x74, 0xb8, 0x1d,
-	0xf2, 0x03, 0x1e, 0x72, 0xdf, 0xe1, 0x9a, 0xdf, 0x3e, 0x1f, 0x5f, 0x70, 0x27, 0xcc, 0x5d, 0xf9,
-	0xe1, 0xf9, 0x38, 0xd2, 0x1b, 0x70, 0xfb, 0xc8, 0xf3, 0xdd, 0xe0, 0x28, 0x25, 0x36, 0xfe, 0xbc,
-	0x88, 0x2e, 0xed, 0x2a, 0x2d, 0x50, 0xfe, 0xdb, 0x88, 0x0b, 0x89, 0x3f, 0x42, 0x25, 0x27, 0xf0,
-	0x0f, 0xbc, 0x2e, 0x31, 0xea, 0x46, 0xb3, 0xd2, 0xde, 0x6e, 0x4d, 0xa9, 0xa3, 0x05, 0xc6, 0xbb,
-	0x60, 0x61, 0x5d, 0xfc, 0x32, 0x36, 0x0d, 0xaa, 0xed, 0x71, 0x1b, 0x95, 0x60, 0x77, 0x05, 0x59,
-	0xa8, 0x2f, 0x36, 0x2b, 0xed, 0xcd, 0x19, 0xe6, 0x23, 0xd5, 0x08, 0x9c, 0x0b, 0x54, 0x5b, 0xe2,
-	0x0f, 0xd0, 0x92, 0xda, 0x5e, 0x41, 0x16, 0x81, 0x72, 0x6d, 0x86, 0xb2, 0x17, 0x04, 0xc5, 0xb1,
-	0x2e, 0xd0, 0xd4, 0x1a, 0x37, 0x50, 0xe9, 0x63, 0x21, 0x22, 0xee, 0x92, 0x8b, 0x75, 0xa3, 0xb9,
-	0x68, 0xa1, 0x24, 0x36, 0x4b, 0x1e, 0x20, 0x54, 0xb7, 0xe0, 0x5f, 0xa3, 0x8a, 0x32, 0xb6, 0xf5,
-	0x9c, 0x96, 0x60, 0x80, 0xdb, 0xf3, 0x56, 0xa3, 0x97, 0x0e, 0xa3, 0xc1, 0x24, 0xc5, 0x63, 0x5f,
-	0x86, 0x63, 0x6b, 0x2d, 0x89, 0xcd, 0x62, 0x1f, 0x14, 0xf5, 0x72, 0x0b, 0x4c, 0xd0, 0x72, 0xba,
-	0x03, 0x82, 0x94, 0xea, 0x8b, 0xcd, 0x32, 0xcd, 0xaa, 0xdb, 0x2f, 0xd1, 0xda, 0x4c, 0x4f, 0x78,
-	0x1d, 0x2d, 0x1e, 0xf2, 0x31, 0x78, 0xb4, 0x4c, 0x55, 0x11, 0xb7, 0xd0, 0xd2, 0x88, 0xf5, 0x23,
-	0x4e, 0x16, 0xc0, 0xcb, 0x64, 0x9e, 0xaf, 0x3e, 0xf1, 0x84, 0xa4, 0xa9, 0xd9, 0x83, 0x85, 0x8f,
-	0x8c, 0xc6, 0xc7, 0xa8, 0x9c, 0xe3, 0xf8, 0x61, 0xee, 0x6d, 0xe3, 0x15, 0xde, 0x5e, 0x55, 0x5e,
-	0x53, 0xce, 0xd1, 0x2b, 0xd0, 0xdf, 0xc6, 0x5f, 0x0d, 0x54, 0x7d, 0x1e, 0x06, 0xc7, 0x63, 0xbd,
-	0x76, 0x81, 0x2d, 0xb4, 0xc1, 0x7d, 0xe9, 0xc9, 0xb1, 0xcd, 0xa4, 0x0c, 0xbd, 0x4e, 0x24, 0x79,
-	0xda, 0x75, 0xd9, 0xda, 0x4a, 0x62, 0xf3, 0x74, 0x23, 0x5d, 0x4f, 0xa1, 0x47, 0x39, 0x82, 0x4d,
-	0xb4, 0x24, 0x86, 0x7d, 0x36, 0x86, 0x45, 0xad, 0x58, 0xe5, 0x24, 0x36, 0x53, 0x80, 0xa6, 0x1f,
-	0xfc, 0x43, 0xb4, 0x0a, 0x05, 0xdb, 0x09, 0x46, 0x3c, 0x64, 0x5d, 0x4e, 0x16, 0xeb, 0x46, 0xb3,
-	0x6a, 0xe1, 0x24, 0x36, 0x67, 0x5a, 0x68, 0x15, 0xea, 0xbb, 0xba, 0xda, 0xf8, 0xfd, 0x1a, 0xaa,
-	0x14, 0xb4, 0xa7, 0xfc, 0xef, 0x04, 0x83, 0x01, 0xf3, 0x5d, 0xed, 0xd6, 0xac, 0x8a, 0x9b, 0x68,
-	0xa5, 0xc7, 0x7c, 0xb7, 0xcf, 0xc3, 0x54, 0x56, 0x65, 0xeb, 0x52, 0x12, 0x9b, 0x39, 0x46, 0xf3,
-	0x12, 0xfe, 0x29, 0xba, 0xdc, 0xf3, 0xba, 0x3d, 0xfb, 0xa0, 0xcf, 0x86, 0x93, 0xb3, 0x0f, 0x9a,
-	0xaa, 0x5a, 0x57, 0x93, 0xd8, 0x9c, 0xd7, 0x4c, 0x37, 0x14, 0xf8, 0xa4, 0xcf, 0x86, 0xfb, 0x19,
-	0xa4, 0x86, 0xf4, 0x7c, 0xc9, 0xc3, 0x11, 0xeb, 0x93, 0x25, 0x60, 0xc3, 0x90, 0x19, 0x46, 0xf3,
-	0x12, 0xfe, 0x09, 0xc2, 0xfd, 0xe0, 0x68, 0x76, 0xc4, 0x12, 0x70, 0xae, 0x24, 0xb1, 0x39, 0xa7,
-	0x95, 0xae, 0xf7, 0x83, 0xa3, 0xe9, 0xf1, 0x6e, 0xa1, 0xe5, 0x61, 0xd4, 0xe9, 0x7b, 0xa2, 0x47,
-	0xca, 0xe0, 0xea, 0x4a, 0x12, 0x9b, 0x19, 0x44, 0xb3, 0x82, 0x72, 0x77, 0x18, 0xf9, 0x70, 0xe8,
-	0xb5, 0x56, 0x10, 0xf8, 0x03, 0xdc, 0x3d, 0xdd, 0x42, 0xab, 0xba, 0xae, 0xe5, 0xfd, 0x21, 0xaa,
-	0x8a, 0xa8, 0x23, 0x9c, 0xd0, 0x1b, 0x4a, 0x2f, 0xf0, 0x05, 0xa9, 0x00, 0x73, 0x23, 0x89, 0xcd,
-	0xe9, 0x06, 0x3a, 0x5d, 0xc5, 0x1f, 0x20, 0xfc, 0xf8, 0x58, 0x72, 0xdf, 0xe5, 0xee, 0x44, 0x19,
-	0xe4, 0x52, 0xdd, 0x68, 0x5e, 0xb2, 0x96, 0x92, 0xd8, 0x34, 0xee, 0xd0, 0x39, 0x06, 0x78, 0x1f,
-	0x6d, 0x0c, 0x95, 0x1e, 0x6d, 0xad, 0x33, 0x9f, 0x0d, 0x38, 0xa9, 0xaa, 0x8d, 0xb5, 0x9a, 0x27,
-	0xb1, 0xb9, 0x06, 0x62, 0x7d, 0x0c, 0x6d, 0x9f, 0xb2, 0x01, 0x57, 0x8a, 0x3c, 0x65, 0x4f, 0xd7,
-	0x86, 0xd3, 0x56, 0xf8, 0x29, 0xaa, 0x40, 0xa0, 0xb3, 0xd3, 0x4b, 0x66, 0x15, 0x4e, 0xca, 0xd5,
-	0x39, 0x97, 0x8c, 0x3a, 0x52, 0xd6, 0x65, 0x7d, 0x58, 0x8a, 0x1c, 0x8a, 0xa0, 0xb2, 0x07, 0xd7,
-	0x8e, 0xd2, 0xb7, 0x74, 0x3d, 0x9f, 0xac, 0x15, 0xf4, 0xad, 0x00, 0x9a, 0x7e, 0xf0, 0x23, 0x54,
-	0x12, 0x51, 0xc7, 0x8d, 0x38, 0x59, 0x87, 0x63, 0x7d, 0x63, 0x66, 0xa8, 0x7d, 0x6f, 0xc0, 0x5f,
-	0xc2, 0xf5, 0xfb, 0xb2, 0xc7, 0xfd, 0xf4, 0xda, 0x4a, 0x09, 0x54, 0x7f, 0x31, 0x46, 0x17, 0x9d,
-	0x30, 0xf0, 0xc9, 0x06, 0x88, 0x1a, 0xca, 0xf8, 0x1a, 0x5a, 0x94, 0xb2, 0x4f, 0x30, 0xdc, 0x75,
-	0xcb, 0x49, 0x6c, 0xaa, 0x2a, 0x55, 0x3f, 0x4a, 0x09, 0x6a, 0xd7, 0x82, 0x48, 0x92, 0xcb, 0x20,
-	0x22, 0x50, 0x82, 0x86, 0x68, 0x56, 0xc0, 0xbb, 0x68, 0x35, 0x75, 0x57, 0xa8, 0xcf, 0x3b, 0xd9,
-	0x84, 0x09, 0x5e, 0x9f, 0x99, 0xe0, 0xd4, 0x9d, 0x40, 0xab, 0xc3, 0xa9, 0x2b, 0xe2, 0x2e, 0xaa,
-	0x84, 0x41, 0xe4, 0xbb, 0x76, 0x18, 0x74, 0x3c, 0x9f, 0x6c, 0x81, 0x13, 0xe0, 0x92, 0x2c, 0xc0,
-	0x14, 0x41, 0x85, 0xaa, 0x32, 0xfe, 0x19, 0xda, 0x0c, 0x22, 0x39, 0x8c, 0xa4, 0xad, 0x03, 0xec,
-	0x41, 0x10, 0x0e, 0x98, 0x24, 0x57, 0x60, 0x63, 0x49, 0x12, 0x9b, 0x73, 0xdb, 0x29, 0x4e, 0xd1,
-	0xa7, 0x00, 0x3e, 0x01, 0x0c, 0x3f, 0x47, 0x57, 0xa6, 0x6d, 0xf3, 0x43, 0x7e, 0x15, 0xa4, 0xb9,
-	0x9d, 0xc4, 0xe6, 0x19, 0x16, 0x74, 0xb3, 0xd8, 0xdf, 0x5e, 0x76, 0xfc, 0xdf, 0x45, 0x2b, 0xdc,
-	0x1f, 0xd9, 0x23, 0x16, 0x0a, 0x42, 0x26, 0x17, 0x45, 0x86, 0xd1, 0x65, 0xee, 0x8f, 0x7e, 0xc9,
-	0x42, 0x81, 0x7f, 0x81, 0x56, 0x54, 0x4a, 0xe1, 0x32, 0xc9, 0xc8, 0x36, 0xf8, 0x6d, 0x36, 0x50,
-	0x3d, 0xeb, 0xfc, 0x86, 0x3b, 0xaa, 0x7f, 0x66, 0xd5, 0x94, 0x8a, 0xbe, 0x8a, 0x4d, 0x43, 0x9d,
-	0xe6, 0x8c, 0xf6, 0x7e, 0x30, 0xf0, 0x24, 0x1f, 0x0c, 0xe5, 0x98, 0xe6, 0x5d, 0xe1, 0x77, 0xd0,
-	0xda, 0x80, 0x1d, 0xdb, 0x7a, 0xce, 0xc2, 0xfb, 0x8c, 0x93, 0xb7, 0xd4, 0x16, 0xd3, 0xea, 0x80,
-	0x1d, 0x3f, 0x03, 0xf4, 0x85, 0xf7, 0x19, 0xc7, 0xb7, 0xd0, 0xaa, 0xeb, 0x09, 0x87, 0x85, 0xae,
-	0xb6, 0x25, 0xd7, 0x95, 0xeb, 0x69, 0x55, 0xa3, 0xa9, 0x29, 0x7e, 0x38, 0x89, 0x48, 0x37, 0x40,
-	0xe8, 0x5b, 0x33, 0x93, 0x7c, 0x01, 0xad, 0xa9, 0x42, 0xb4, 0x65, 0x1e, 0xb5, 0xf0, 0x1f, 0x0d,
-	0x84, 0xa7, 0xbd, 0x27, 0x59, 0x57, 0x90, 0x1a, 0xf4, 0x34, 0x1b, 0x9e, 0x52, 0x47, 0xee, 0xb3,
-	0xae, 0xb5, 0x97, 0xc4, 0xe6, 0xf5, 0xd3, 0xbc, 0xc9, 0x7a, 0xbf, 0x8e, 0xcd, 0xb7, 0xc7, 0x6c,
-	0xd0, 0x7f, 0x50, 0x6f, 0xbc, 0xca, 0xac, 0x41, 0xd7, 0x8b, 0x7b, 0xb4, 0xcf, 0xba, 0x4a, 0x6f,
-	0x65, 0xe1, 0xf4, 0xb8, 0x1b, 0xf5, 0x79, 0x48, 0x4c, 0x90, 0x0c, 0x86, 0x1b, 0xe4, 0xeb, 0xd8,
-	0x2c, 0xeb, 0x3e, 0xef, 0x34, 0xe8, 0xc4, 0x08, 0x3f, 0x45, 0xe5, 0xa1, 0x37, 0xe4, 0x7d, 0xcf,
-	0xe7, 0x82, 0xd4, 0x61, 0xea, 0xf5, 0x99, 0xa9, 0x53, 0x9d, 0x76, 0xd1, 0x2c, 0xeb, 0xb2, 0xaa,
-	0x49, 0x6c, 0x4e, 0x68, 0x74, 0x52, 0xc4, 0x7f, 0x31, 0x10, 0x99, 0x99, 0x74, 0x76, 0x05, 0x0b,
-	0x72, 0x13, 0xba, 0xaf, 0xcd, 0xf7, 0x4c, 0x66, 0x66, 0xed, 0x27, 0xb1, 0xd9, 0x38, 0xab, 0x8f,
-	0x29, 0x2f, 0xbd, 0x37, 0xdf, 0x4b, 0x73, 0x8c, 0x1b, 0xf4, 0xca, 0x94, 0xaf, 0x72, 0x13, 0x4c,
-	0xd1, 0x72, 0x7a, 0x8d, 0x08, 0xd2, 0x80, 0xe9, 0xdd, 0x3c, 0xf3, 0x02, 0xa2, 0x7c, 0xc8, 0x99,
-	0xe4, 0x6e, 0x1a, 0xdd, 0x35, 0xab, 0x20, 0xd3, 0xac, 0xa3, 0x07, 0x2b, 0x7f, 0xf8, 0xdc, 0xbc,
-	0xf0, 0xc5, 0xe7, 0xa6, 0xd1, 0xf8, 0xfb, 0x26, 0x5a, 0x82, 0x10, 0xfc, 0x7d, 0xf0, 0xfd, 0x8e,
-	0x06, 0xdf, 0xef, 0xa3, 0xe8, 0xff, 0x62, 0x14, 0xdd, 0x46, 0x2b, 0x6e, 0x14, 0x32, 0xb5, 0xc5,
-	0x10, 0x39, 0x0d, 0x9a, 0xd7, 0x95, 0xf8, 0xf9, 0x31, 0x77, 0x22, 0xc9, 0x5d, 0x72, 0x15, 0x56,
-	0x96, 0xc6, 0x30, 0x8d, 0xd1, 0xbc, 0x84, 0x9f, 0xa0, 0xe5, 0x9e, 0x27, 0x64, 0x10, 0x8e, 0x21,
-	0xd8, 0x55, 0xda, 0x6f, 0xcd, 0x7b, 0x0b, 0xed, 0xa5, 0x26, 0xd6, 0x9a, 0xde, 0xc5, 0x8c, 0x43,
-	0xb3, 0x82, 0x7a, 0x7b, 0xa5, 0x2f, 0x2d, 0x72, 0xed, 0xf4, 0xdb, 0x2b, 0xfd, 0x2a, 0x1b, 0x1d,
-	0xa9, 0xb6, 0x41, 0x7c, 0x60, 0x93, 0x22, 0x54, 0x7f, 0xf1, 0xa6, 0x92, 0x01, 0x93, 0x69, 0xcc,
-	0x2b, 0xd3, 0xb4, 0xa2, 0x98, 0xaa, 0x10, 0x09, 0x88, 0x71, 0x55, 0xbd, 0xb9, 0x80, 0x50, 0xfd,
-	0x55, 0xc7, 0x58, 0x06, 0x92, 0xf5, 0x6d, 0xa0, 0xd8, 0x4e, 0x8f, 0xf9, 0x5d, 0x4e, 0x6e, 0x4c,
-	0x8e, 0xf1, 0xe9, 0x56, 0xba, 0x0e, 0xd8, 0x0b, 0x05, 0xed, 0x02, 0x82, 0x5b, 0x68, 0xb9, 0xcf,
-	0x84, 0xb4, 0x83, 0x43, 0x52, 0x83, 0x85, 0x6c, 0x9d, 0xc4, 0x66, 0xe9, 0x13, 0x26, 0xe4, 0xb3,
-	0x9f, 0xab, 0x85, 0xeb, 0x46, 0x5a, 0x52, 0x85, 0x67, 0x87, 0xf8, 0x1e, 0xaa, 0x04, 0x8e, 0x13,
-	0x85, 0x10, 0x34, 0x04, 0xc4, 0xa3, 0xc5, 0x74, 0xdf, 0x0a, 0x30, 0x2d, 0x56, 0xf0, 0xa7, 0x68,
-	0xab, 0x50, 0xb5, 0x8f, 0x98, 0xe4, 0xe1, 0x80, 0x85, 0x87, 0xa4, 0x0e, 0xe4, 0x6b, 0x49, 0x6c,
-	0xce, 0x37, 0xa0, 0x9b, 0x05, 0xf8, 0x65, 0x86, 0xe2, 0x3a, 0x5a, 0x11, 0x5e, 0x5f, 0x81, 0x2e,
-	0x84, 0x9f, 0xb2, 0x7e, 0x81, 0xe7, 0x28, 0xde, 0xc9, 0xde, 0xd3, 0xe9, 0xf5, 0x7f, 0x79, 0xce,
-	0x21, 0xd5, 0x1c, 0xfd, 0x92, 0x3e, 0x2b, 0x43, 0xfb, 0xbf, 0x37, 0x9a, 0xa1, 0xbd, 0xfd, 0x06,
-	0x32, 0xb4, 0x5b, 0xe7, 0xcd, 0xd0, 0xde, 0xf9, 0x56, 0x33, 0xb4, 0x77, 0xcf, 0x97, 0xa1, 0x35,
-	0xbf, 0x21, 0x43, 0xfb, 0xff, 0xd7, 0xcf, 0xd0, 0xee, 0xa2, 0x8a, 0x27, 0xec, 0x5c, 0x00, 0xef,
-	0x4d, 0x2e, 0x8e, 0x02, 0x4c, 0x91, 0x27, 0x5e, 0x64, 0x6a, 0x38, 0x23, 0xa7, 0xbb, 0xfd, 0x5f,
-	0xcc, 0xe9, 0x6e, 0x17, 0x73, 0xba, 0xf7, 0x41, 0x64, 0x90, 0x7f, 0xe5, 0x60, 0x31, 0x9d, 0xdb,
-	0x47, 0x95, 0xe7, 0x61, 0xe0, 0x70, 0x21, 0xb8, 0x6b, 0x8d, 0xc9, 0x1d, 0x30, 0x6f, 0x2b, 0x15,
-	0x0d, 0x33, 0xd8, 0xee, 0x8c, 0xa7, 0xe6, 0xb5, 0xa9, 0xe7, 0x55, 0x34, 0x68, 0xd0, 0x62, 0x37,
-	0xd3, 0x49, 0x62, 0xeb, 0xdb, 0x4d, 0x12, 0x77, 0xbe, 0xdb, 0x49, 0xe2, 0xdd, 0x37, 0x94, 0x24,
-	0x9e, 0xf1, 0xea, 0x77, 0xbe, 0xe1, 0xd5, 0x5f, 0xc8, 0x2d, 0x7f, 0xa7, 0xff, 0x86, 0xdc, 0x9b,
-	0x44, 0x19, 0x1d, 0x07, 0x8c, 0x33, 0xe3, 0x40, 0x31, 0xf6, 0x2d, 0xbc, 0x32, 0xf6, 0xdd, 0x44,
-	0x2b, 0x2a, 0xad, 0x1b, 0x7a, 0x7e, 0x17, 0xfe, 0x71, 0x5a, 0xc9, 0x26, 0x95, 0xc3, 0x56, 0xfd,
-	0xdf, 0xff, 0xac, 0x19, 0x5f, 0x9c, 0xd4, 0x8c, 0xbf, 0x9d, 0xd4, 0x8c, 0x2f, 0x4f, 0x6a, 0xc6,
-	0x57, 0x27, 0x35, 0xe3, 0x1f, 0x27, 0x35, 0xe3, 0x4f, 0xff, 0xaa, 0x5d, 0xf8, 0xd5, 0xc2, 0xa8,
-	0xdd, 0x29, 0xc1, 0x3f, 0xa6, 0xf7, 0xff, 0x13, 0x00, 0x00, 0xff, 0xff, 0x42, 0x79, 0x0e, 0x39,
-	0x62, 0x17, 0x00, 0x00,
+	// 1884 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xed, 0x58, 0xcd, 0x73, 0xd3, 0x46,
+	0x14, 0xc7, 0x84, 0x38, 0xf6, 0x3a, 0xce, 0xc7, 0x92, 0x80, 0x08, 0x90, 0x04, 0xf3, 0x95, 0x02,
+	0x71, 0x88, 0x81, 0x29, 0x65, 0x3a, 0x9d, 0xa2, 0x14, 0x1a, 0x5a, 0x5a, 0x98, 0x25, 0x2d, 0x33,
+	0x9d, 0xe9, 0x68, 0x64, 0x69, 0x63, 0xab, 0xb1, 0x25, 0x55, 0x2b, 0x19, 0xdc, 0x4b, 0xaf, 0x3d,
+	0xf6, 0xd8, 0x23, 0x47, 0x7a, 0xe9, 0xb9, 0x7f, 0x42, 0x8f, 0xfc, 0x05, 0x99, 0x16, 0x6e, 0x3d,
+	0x76, 0xa6, 0x33, 0x3d, 0xf6, 0xed, 0xd3, 0x4a, 0x96, 0x1d, 0x27, 0x84, 0x19, 0x98, 0x76, 0x3a,
+	0x3d, 0xd8, 0xda, 0xfd, 0xed, 0xfb, 0x3d, 0xad, 0xde, 0x7b, 0xfb, 0xde, 0x93, 0xc8, 0x6a, 0xc3,
+	0x09, 0x9b, 0x51, 0xbd, 0x6a, 0x79, 0xed, 0x15, 0xc1, 0x5d, 0x11, 0xc5, 0xff, 0xcb, 0x0d, 0x6f,
+	0xc5, 0xf4, 0x9d, 0x15, 0xcb, 0x0b, 0xf8, 0x4a, 0xa7, 0xb6, 0x62, 0x35, 0xb9, 0xb5, 0x55, 0xf5,
+	0x03, 0x2f, 0xf4, 0x68, 0x19, 0x25, 0xaa, 0x72, 0xa9, 0xda, 0xa9, 0xcd, 0x5d, 0xcd, 0x68, 0x68,
+	0x78, 0xc0, 0x43, 0xa9, 0x7a, 0xb4, 0xf9, 0x7e, 0x67, 0xb5, 0x7a, 0xa5, 0xba, 0x8a, 0x20, 0x62,
+	0x38, 0x8a, 0x95, 0xcc, 0xed, 0xf3, 0xbe, 0xa6, 0x10, 0x3c, 0x54, 0x94, 0xcb, 0xfb, 0xa3, 0x34,
+	0x3d, 0x6f, 0xeb, 0xd5, 0x18, 0x6d, 0x1e, 0x9a, 0x8a, 0xf1, 0xee, 0xbe, 0x19, 0x81, 0x63, 0x19,
+	0x61, 0x33, 0xe0, 0xa2, 0xe9, 0xb5, 0x6c, 0xc5, 0xbe, 0xf2, 0x2a, 0x6c, 0xa1, 0x48, 0xef, 0xed,
+	0x8f, 0x04, 0x77, 0xf2, 0xa2, 0xc0, 0xe2, 0x46, 0xc0, 0x37, 0x79, 0xc0, 0x5d, 0x8b, 0x2b, 0x7e,
+	0x6d, 0x7f, 0x7c, 0xc1, 0xad, 0x20, 0x35, 0xe5, 0xdb, 0xfb, 0xe3, 0x84, 0x4e, 0x9b, 0x1b, 0x8f,
+	0x1c, 0xd7, 0xf6, 0x1e, 0xc5, 0xc4, 0xca, 0x8f, 0x23, 0x64, 0x7c, 0x4d, 0xc6, 0x02, 0xe3, 0x5f,
+	0x47, 0x5c, 0x84, 0xf4, 0x3a, 0xc9, 0x5b, 0x9e, 0xbb, 0xe9, 0x34, 0xb4, 0xdc, 0x62, 0x6e, 0xa9,
+	0x54, 0x9b, 0xab, 0xf6, 0x45, 0x47, 0x15, 0x85, 0xd7, 0x50, 0x42, 0x3f, 0xf4, 0xcb, 0xf6, 0x42,
+	0x8e, 0x29, 0x79, 0x5a, 0x23, 0x79, 0xf4, 0xae, 0xd0, 0x0e, 0x2e, 0x8e, 0x00, 0x73, 0x66, 0x80,
+	0x79, 0x53, 0x2e, 0x22, 0xe7, 0x00, 0x53, 0x92, 0xf4, 0x1a, 0x19, 0x95, 0xee, 0x15, 0xda, 0x08,
+	0x52, 0x8e, 0x0d, 0x50, 0xd6, 0x61, 0x2d, 0x73, 0xaf, 0x03, 0x2c, 0x96, 0xa6, 0x15, 0x92, 0xbf,
+	0x23, 0x44, 0xc4, 0x6d, 0xed, 0x10, 0x6c, 0x72, 0x44, 0x27, 0xbf, 0x6f, 0x2f, 0xe4, 0x1d, 0x44,
+	0x98, 0x5a, 0xa1, 0x5f, 0x92, 0x92, 0x14, 0x36, 0xd4, 0x9e, 0x46, 0xf1, 0x06, 0x17, 0x87, 0x3d,
+	0x8d, 0x7a, 0x74, 0xbc, 0x1b, 0x6e, 0x52, 0xdc, 0x72, 0xc3, 0xa0, 0xab, 0x4f, 0x82, 0xd6, 0xac,
+	0x0e, 0x46, 0x9a, 0xa9, 0x04, 0xd5, 0xc8, 0x58, 0xec, 0x01, 0xa1, 0xe5, 0x41, 0x75, 0x91, 0x25,
+	0xd3, 0xb9, 0x87, 0x64, 0x72, 0x40, 0x13, 0x9d, 0x22, 0x23, 0x5b, 0xbc, 0x8b, 0x16, 0x2d, 0x32,
+	0x39, 0xa4, 0x55, 0x32, 0xda, 0x31, 0x5b, 0x11, 0x07, 0x5b, 0x49, 0x2b, 0x6b, 0xc3, 0x6c, 0x75,
+	0xd7, 0x11, 0x21, 0x8b, 0xc5, 0x6e, 0x1c, 0xbc, 0x9e, 0xab, 0xdc, 0x21, 0xc5, 0x14, 0xa7, 0xef,
+	0xa6, 0xd6, 0xce, 0xed, 0x61, 0xed, 0x09, 0x69, 0x35, 0x69, 0x1c, 0xf5, 0x04, 0xea, 0x5a, 0xd9,
+	0xce, 0x91, 0xf2, 0xfd, 0xc0, 0x7b, 0xdc, 0x55, 0xcf, 0x2e, 0xa8, 0x4e, 0xa6, 0xb9, 0x1b, 0x3a,
+	0x61, 0xd7, 0x30, 0x43, 0x88, 0xe6, 0x7a, 0x14, 0xf2, 0x58, 0x75, 0x51, 0x9f, 0x05, 0x05, 0x3b,
+	0x17, 0xd9, 0x54, 0x0c, 0xdd, 0x4c, 0x11, 0xba, 0x40, 0x46, 0x85, 0xdf, 0x32, 0xbb, 0xf8, 0x50,
+	0x05, 0xbd, 0x08, 0xbc, 0x18, 0x60, 0xf1, 0x85, 0xbe, 0x43, 0x26, 0x70, 0x60, 0x58, 0x5e, 0x87,
+	0x07, 0x66, 0x83, 0x83, 0xdf, 0x73, 0x4b, 0x65, 0x9d, 0x82, 0xe4, 0xc0, 0x0a, 0x2b, 0xe3, 0x7c,
+	0x4d, 0x4d, 0xe9, 0x32, 0x21, 0x75, 0x33, 0xb4, 0x9a, 0x86, 0x70, 0xbe, 0xe1, 0xe8, 0xf6, 0xb2,
+	0x3e, 0x01, 0xb4, 0x0c, 0xca, 0x8a, 0x38, 0x7e, 0x00, 0xc3, 0xca, 0x8b, 0x49, 0x52, 0xca, 0x84,
+	0xaa, 0x74, 0x17, 0x9c, 0x8d, 0xb6, 0xe9, 0xda, 0xca, 0x0b, 0xc9, 0x94, 0x2e, 0x91, 0x42, 0x13,
+	0xae, 0x2d, 0x1e, 0xc4, 0x51, 0x58, 0xd4, 0xc7, 0x41, 0x6d, 0x8a, 0xb1, 0x74, 0x44, 0x3f, 0x24,
+	0x87, 0x9b, 0x4e, 0xa3, 0x69, 0x6c, 0xb6, 0x4c, 0xbf, 0x97, 0x2a, 0xd4, 0x5e, 0x8e, 0x02, 0x69,
+	0xd8, 0x32, 0x9b, 0x96, 0xe0, 0x6d, 0xc0, 0x36, 0x12, 0x48, 0xde, 0xd2, 0x71, 0x43, 0x1e, 0x80,
+	0x6b, 0x21, 0x2e, 0x25, 0x1b, 0x6f, 0x99, 0x60, 0x2c, 0x1d, 0xd1, 0x0f, 0x08, 0x6d, 0x79, 0x8f,
+	0x06, 0xef, 0x98, 0x47, 0xce, 0x11, 0xe0, 0x0c, 0x59, 0x65, 0x53, 0x80, 0xf5, 0xdf, 0xef, 0x2c,
+	0x19, 0xf3, 0xa3, 0x7a, 0xcb, 0x11, 0x4d, 0xad, 0x88, 0x9e, 0x29, 0x01, 0x35, 0x81, 0x58, 0x32,
+	0x90, 0xde, 0x09, 0x22, 0x17, 0x73, 0x84, 0x0a, 0x2d, 0x82, 0xf6, 0x40, 0xef, 0xf4, 0xaf, 0xb0,
+	0xb2, 0x9a, 0xab, 0xd3, 0xf0, 0x36, 0x29, 0x8b, 0xa8, 0x2e, 0xac, 0xc0, 0xf1, 0x43, 0xc7, 0x73,
+	0x85, 0x56, 0x42, 0xe6, 0x34, 0x30, 0xfb, 0x17, 0x58, 0xff, 0x14, 0x12, 0x00, 0xbd, 0xf5, 0x38,
+	0xe4, 0xae, 0xcd, 0xed, 0x5e, 0x20, 0x69, 0xe3, 0xb0, 0xcb, 0x71, 0x7d, 0x14, 0xd8, 0xb9, 0x65,
+	0x36, 0x44, 0x80, 0x6e, 0x90, 0x69, 0x5f, 0x86, 0xaf, 0xa1, 0xc2, 0xd2, 0x35, 0xdb, 0x5c, 0x2b,
+	0x4b, 0xc7, 0xea, 0x4b, 0xcf, 0xb7, 0x17, 0x26, 0x31, 0xb6, 0x6f, 0xe1, 0xda, 0xa7, 0xb0, 0x24,
+	0x03, 0x78, 0x87, 0x3c, 0x9b, 0xf4, 0xfb, 0xa5, 0xe8, 0x27, 0xa4, 0x84, 0x75, 0xd1, 0x88, 0x73,
+	0xd2, 0x04, 0x1e, 0xac, 0xa3, 0x43, 0x72, 0x92, 0x3c, 0x81, 0xfa, 0x61, 0x75, 0xb6, 0xb2, 0x1c,
+	0x46, 0x70, 0xb2, 0x8e, 0x59, 0x4a, 0x1e, 0x87, 0xd0, 0x76, 0x5c, 0x6d, 0x32, 0x73, 0x1c, 0x24,
+	0xc0, 0xe2, 0x0b, 0xbd, 0x49, 0xf2, 0x60, 0x0d, 0x1b, 0xb2, 0xc0, 0x14, 0x66, 0x81, 0x93, 0x03,
+	0xb7, 0xda, 0x00, 0x03, 0x3f, 0xc4, 0x6c, 0xfd, 0xb0, 0xc9, 0xdd, 0x38, 0xcb, 0xc5, 0x04, 0xa6,
+	0xae, 0x94, 0x92, 0x43, 0x56, 0xe0, 0xb9, 0xda, 0x34, 0x06, 0x35, 0x8e, 0xe9, 0x31, 0x32, 0x12,
+	0x86, 0x2d, 0x8d, 0x62, 0x6a, 0x1c, 0x03, 0x92, 0x9c, 0x32, 0xf9, 0x27, 0x23, 0x41, 0x7a, 0xcd,
+	0x8b, 0x42, 0xed, 0x30, 0x06, 0x11, 0x46, 0x82, 0x82, 0x58, 0x32, 0xa0, 0x6b, 0x64, 0x22, 0x36,
+	0x57, 0xa0, 0xd2, 0x83, 0x36, 0x83, 0x1b, 0x3c, 0x31, 0xb0, 0xc1, 0xbe, 0x14, 0xc2, 0xca, 0x7e,
+	0x5f, 0x46, 0xb9, 0x4c, 0x4a, 0x81, 0x17, 0xb9, 0xb6, 0x11, 0x78, 0x75, 0x30, 0xc2, 0x2c, 0x1a,
+	0x01, 0x73, 0x6a, 0x06, 0x66, 0x04, 0x27, 0x4c, 0x8e, 0xe9, 0x47, 0x64, 0x06, 0xee, 0xee, 0x47,
+	0xa1, 0xa1, 0xea, 0xf1, 0xa6, 0x17, 0xb4, 0xcd, 0x50, 0x3b, 0x82, 0x8e, 0xd5, 0x80, 0x3a, 0x74,
+	0x9d, 0xd1, 0x18, 0xfd, 0x04, 0xc1, 0xdb, 0x88, 0xd1, 0xfb, 0xe4, 0x48, 0xbf, 0x6c, 0x7a, 0xc8,
+	0x8f, 0x62, 0x68, 0xce, 0x81, 0xb6, 0x5d, 0x24, 0xd8, 0x4c, 0x56, 0xdf, 0x7a, 0x72, 0xfc, 0xcf,
+	0x93, 0x02, 0x77, 0x3b, 0x46, 0xc7, 0x04, 0x1d, 0x5a, 0x2f, 0x51, 0x24, 0x18, 0x1b, 0x83, 0xd1,
+	0xe7, 0x30, 0xa0, 0x9f, 0x91, 0x82, 0xec, 0x40, 0x6c, 0x33, 0x34, 0xb5, 0x39, 0xb4, 0xdb, 0x60,
+	0x5d, 0xbb, 0x57, 0xff, 0x8a, 0x5b, 0x52, 0xbf, 0xa9, 0xcf, 0xcb, 0x28, 0x7a, 0x06, 0x81, 0x2e,
+	0x4f, 0x73, 0x42, 0xbb, 0xe4, 0xb5, 0x9d, 0x90, 0xb7, 0xfd, 0xb0, 0xcb, 0x52, 0x55, 0xf4, 0x1c,
+	0x99, 0x6c, 0x9b, 0x8f, 0x0d, 0xb5, 0x67, 0x4c, 0x83, 0xc7, 0xa5, 0x8b, 0x59, 0x19, 0xe0, 0x7b,
+	0x88, 0xca, 0xd4, 0x07, 0x3e, 0x9e, 0xb0, 0x1d, 0x61, 0x99, 0x81, 0xad, 0x64, 0xb5, 0x13, 0xd2,
+	0xf4, 0xac, 0xac, 0xd0, 0x58, 0x14, 0x0a, 0x48, 0x5a, 0xc0, 0x4e, 0x62, 0xa0, 0xcf, 0x0e, 0x6c,
+	0xf2, 0x01, 0xae, 0xc6, 0x11, 0xa2, 0x24, 0xd3, 0x22, 0x47, 0xbf, 0xcf, 0x11, 0xda, 0x6f, 0xbd,
+	0xd0, 0x6c, 0x08, 0x6d, 0x1e, 0x35, 0x0d, 0x56, 0xb3, 0xd8, 0x90, 0x1b, 0x66, 0x43, 0x5f, 0x07,
+	0x65, 0x27, 0x76, 0xf2, 0x7a, 0xcf, 0xfb, 0xc7, 0xf6, 0xc2, 0x99, 0xae, 0xd9, 0x6e, 0xdd, 0x58,
+	0xac, 0xec, 0x25, 0x56, 0x61, 0x53, 0x59, 0x1f, 0x81, 0x6a, 0x19, 0x6f, 0x45, 0x01, 0xa7, 0xcf,
+	0x8e, 0xc0, 0x5b, 0xda, 0x02, 0x86, 0x0c, 0xc5, 0x0c, 0x02, 0x3a, 0x8b, 0x4a, 0xe7, 0x72, 0x85,
+	0xf5, 0x84, 0xe0, 0xbc, 0x17, 0x7d, 0xc7, 0xe7, 0x2d, 0xc7, 0x85, 0x9c, 0xb3, 0x88, 0x5b, 0x5f,
+	0x1c, 0xd8, 0x3a, 0x53, 0x5d, 0x1a, 0x4b, 0x9a, 0x34, 0xbd, 0x0c, 0x3a, 0x7b, 0x34, 0xd6, 0x1b,
+	0xd2, 0x9f, 0x72, 0x44, 0x1b, 0xd8, 0x74, 0x92, 0x82, 0x85, 0x76, 0x0a, 0xd5, 0xcf, 0x0f, 0xb7,
+	0x4c, 0x22, 0xa6, 0x6f, 0x80, 0xf2, 0xca, 0x6e, 0x3a, 0xfa, 0xac, 0x74, 0x61, 0xb8, 0x95, 0x86,
+	0x08, 0x57, 0xd8, 0x91, 0x3e, 0x5b, 0xa5, 0x22, 0x94, 0x41, 0x08, 0x60, 0x1a, 0x11, 0x5a, 0x05,
+	0xb7, 0x77, 0x6a, 0xd7, 0x04, 0xc4, 0xb8, 0xcf, 0xcd, 0x90, 0xdb, 0x71, 0x33, 0xa0, 0x58, 0x99,
+	0x30, 0x4d, 0x14, 0xd1, 0xd3, 0xa4, 0x2c, 0x93, 0x90, 0x21, 0x53, 0xc9, 0x37, 0x9e, 0xcb, 0xb5,
+	0xd3, 0x98, 0x99, 0xc6, 0x25, 0xb8, 0xa1, 0x30, 0x99, 0xb5, 0x22, 0x01, 0x5e, 0x3a, 0x13, 0x67,
+	0x2d, 0x39, 0xa6, 0x33, 0x64, 0xb4, 0x01, 0xb9, 0xc0, 0xd7, 0xce, 0x22, 0x18, 0x4f, 0xa4, 0xa4,
+	0x19, 0x34, 0x3a, 0xda, 0x39, 0xec, 0xb1, 0x70, 0x7c, 0xa3, 0xf0, 0xdd, 0x93, 0x85, 0x03, 0x4f,
+	0x9f, 0x2c, 0xe4, 0x2a, 0x7f, 0xce, 0x92, 0x51, 0xac, 0xf2, 0xff, 0xd7, 0xf7, 0x7f, 0x69, 0x7d,
+	0xff, 0xbf, 0x50, 0xff, 0x17, 0x0b, 0xf5, 0x1c, 0x29, 0xd8, 0x51, 0x60, 0x4a, 0x17, 0x63, 0x71,
+	0xce, 0xb1, 0x74, 0x2e, 0x83, 0x9f, 0x3f, 0xe6, 0x16, 0xb4, 0x69, 0x36, 0x94, 0x5a, 0xf9, 0x64,
+	0x71, 0x99, 0x54, 0x18, 0x4b, 0x47, 0xf4, 0x36, 0x19, 0x6b, 0x82, 0x7f, 0xbc, 0xa0, 0x8b, 0xf5,
+	0xb4, 0x54, 0x3b, 0x3e, 0xec, 0xed, 0x6c, 0x3d, 0x16, 0xd1, 0x27, 0x95, 0x17, 0x13, 0x0e, 0x4b,
+	0x06, 0xf2, 0x6d, 0x30, 0x7e, 0xf7, 0xd3, 0x8e, 0xed, 0x7c, 0x1b, 0x8c, 0xaf, 0x52, 0x46, 0x15,
+	0xc3, 0x39, 0x0c, 0x3e, 0x94, 0x89, 0x11, 0xa6, 0xae, 0x32, 0x03, 0x89, 0x10, 0x92, 0x1c, 0x96,
+	0xd5, 0x22, 0x8b, 0x27, 0x92, 0x29, 0x07, 0x91, 0xc0, 0x32, 0x5a, 0x56, 0xce, 0x45, 0x84, 0xa9,
+	0xab, 0x3c, 0xc6, 0xa1, 0x17, 0x9a, 0x2d, 0x03, 0x29, 0x86, 0x05, 0x29, 0x05, 0xde, 0x6d, 0x4e,
+	0xf6, 0x8e, 0xf1, 0xce, 0x55, 0x36, 0x85, 0xd8, 0x03, 0x09, 0xad, 0x21, 0x02, 0xef, 0x84, 0x63,
+	0x2d, 0x53, 0x84, 0x86, 0xb7, 0x05, 0x75, 0x54, 0x3e, 0xc8, 0x2c, 0x9c, 0x90, 0xfc, 0x5d, 0x80,
+	0xee, 0x7d, 0x2c, 0x1f, 0x5c, 0x2d, 0xb2, 0xbc, 0x1c, 0xdc, 0xdb, 0xa2, 0xab, 0xa4, 0xe4, 0x59,
+	0x56, 0x14, 0x60, 0x5d, 0x12, 0x58, 0xf2, 0x46, 0x62, 0xbf, 0x65, 0x60, 0x96, 0x9d, 0xd0, 0x4f,
+	0xc9, 0x6c, 0x66, 0x6a, 0x3c, 0x82, 0x9b, 0x43, 0xb7, 0x14, 0x6c, 0x41, 0xf5, 0x93, 0xe4, 0x63,
+	0x40, 0x1e, 0x2e, 0x00, 0x3d, 0x51, 0x0f, 0x7e, 0x98, 0xa0, 0x74, 0x91, 0x14, 0x84, 0xd3, 0x92,
+	0xa0, 0x8d, 0x15, 0xae, 0xa8, 0xbe, 0x09, 0xa4, 0x28, 0x5d, 0x49, 0xde, 0xf0, 0xe3, 0x0a, 0x73,
+	0x78, 0xc8, 0x21, 0x55, 0x1c, 0xf5, 0x6e, 0xbf, 0x5b, 0x13, 0x78, 0xfa, 0xb5, 0x36, 0x81, 0x67,
+	0x5e, 0x43, 0x13, 0x78, 0x76, 0xbf, 0x4d, 0xe0, 0xb9, 0x37, 0xda, 0x04, 0x9e, 0xdf, 0x5f, 0x13,
+	0xb8, 0xf4, 0x92, 0x26, 0xf0, 0xad, 0x57, 0x6f, 0x02, 0x21, 0x71, 0x38, 0xc2, 0x48, 0x03, 0xe0,
+	0x42, 0x2f, 0x71, 0x64, 0x60, 0x46, 0x1c, 0xf1, 0x20, 0x89, 0x86, 0x5d, 0xda, 0xc6, 0x8b, 0xff,
+	0x60, 0xdb, 0x78, 0x31, 0xdb, 0x36, 0x5e, 0xc2, 0x20, 0xc3, 0x16, 0x2f, 0x05, 0xb3, 0x1d, 0xe3,
+	0x06, 0x29, 0x41, 0x2a, 0x85, 0x23, 0x20, 0xa0, 0x15, 0xea, 0x6a, 0xcb, 0x28, 0x5e, 0x93, 0x51,
+	0xe4, 0x27, 0xb0, 0x51, 0xef, 0xf6, 0xed, 0x6b, 0x46, 0xed, 0x2b, 0x2b, 0x50, 0x61, 0x59, 0x35,
+	0xfd, 0x7d, 0x68, 0xf5, 0xcd, 0xf6, 0xa1, 0x2b, 0xff, 0xee, 0x3e, 0xf4, 0xf2, 0x1b, 0xeb, 0x43,
+	0x57, 0xf7, 0xe8, 0x43, 0x6b, 0xc3, 0xfa, 0xd0, 0x2b, 0xc3, 0xfa, 0xd0, 0xab, 0xbd, 0x3e, 0x14,
+	0x9a, 0xa2, 0x82, 0x1f, 0xf0, 0x8e, 0xe3, 0x41, 0x6d, 0xb8, 0x86, 0x47, 0x7c, 0xaf, 0x02, 0xc6,
+	0x52, 0xe1, 0x5d, 0x3e, 0x7a, 0x58, 0x2f, 0xf9, 0xe8, 0x91, 0xe9, 0x7b, 0xbf, 0x55, 0x1f, 0x6d,
+	0xd7, 0x7b, 0x15, 0x50, 0xd5, 0xa8, 0xdc, 0xae, 0x35, 0x2a, 0x5b, 0x97, 0x0f, 0xee, 0x59, 0x97,
+	0x4f, 0x91, 0x82, 0x6c, 0x39, 0x7d, 0xc7, 0x6d, 0xe0, 0xf7, 0xb9, 0x42, 0xb2, 0xa9, 0x14, 0xd6,
+	0x17, 0xff, 0xfa, 0x6d, 0x3e, 0xf7, 0xf4, 0xf9, 0x7c, 0xee, 0x67, 0xf8, 0xfd, 0x02, 0xbf, 0x67,
+	0xf0, 0xfb, 0x15, 0x7e, 0x3f, 0xbc, 0x98, 0x3f, 0xf0, 0xc5, 0xc1, 0x4e, 0xad, 0x9e, 0xc7, 0xef,
+	0xcb, 0x57, 0xfe, 0x06, 0x68, 0x99, 0x5f, 0x8a, 0x90, 0x18, 0x00, 0x00,
 }
 
 func (this *CheckRequest) Equal(that interface{}) bool {
@@ -837,6 +885,9 @@ func (this *ProxyRequests) Equal(that interface{}) bool {
 	if this.SplayCoverage != that1.SplayCoverage {
 		return false
 	}
+	if this.BatchSize != that1.BatchSize {
+		return false
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -1006,6 +1057,23 @@ func (this *CheckConfig) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.CronTimezone != that1.CronTimezone {
+		return false
+	}
+	if this.User != that1.User {
+		return false
+	}
+	if this.Group != that1.Group {
+		return false
+	}
+	if len(this.Argv) != len(that1.Argv) {
+		return false
+	}
+	for i := range this.Argv {
+		if this.Argv[i] != that1.Argv[i] {
+			return false
+		}
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -1232,6 +1300,26 @@ func (this *Check) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.CronTimezone != that1.CronTimezone {
+		return false
+	}
+	if this.User != that1.User {
+		return false
+	}
+	if this.Group != that1.Group {
+		return false
+	}
+	if len(this.Argv) != len(that1.Argv) {
+		return false
+	}
+	for i := range this.Argv {
+		if this.Argv[i] != that1.Argv[i] {
+			return false
+		}
+	}
+	if !this.Previous.Equal(that1.Previous) {
+		return false
+	}
 	if !bytes.Equal(this.ExtendedAttributes, that1.ExtendedAttributes) {
 		return false
 	}
@@ -1306,6 +1394,10 @@ type CheckConfigFace interface {
 	GetPipelines() []*ResourceReference
 	GetOutputMetricThresholds() []*MetricThreshold
 	GetSubdues() []*TimeWindowRepeated
+	GetCronTimezone() string
+	GetUser() string
+	GetGroup() string
+	GetArgv() []string
 }
 
 func (this *CheckConfig) Proto() github_com_golang_protobuf_proto.Message {
@@ -1436,6 +1528,22 @@ func (this *CheckConfig) GetSubdues() []*TimeWindowRepeated {
 	return this.Subdues
 }
 
+func (this *CheckConfig) GetCronTimezone() string {
+	return this.CronTimezone
+}
+
+func (this *CheckConfig) GetUser() string {
+	return this.User
+}
+
+func (this *CheckConfig) GetGroup() string {
+	return this.Group
+}
+
+func (this *CheckConfig) GetArgv() []string {
+	return this.Argv
+}
+
 func NewCheckConfigFromFace(that CheckConfigFace) *CheckConfig {
 	this := &CheckConfig{}
 	this.Command = that.GetCommand()
@@ -1468,6 +1576,10 @@ func NewCheckConfigFromFace(that CheckConfigFace) *CheckConfig {
 	this.Pipelines = that.GetPipelines()
 	this.OutputMetricThresholds = that.GetOutputMetricThresholds()
 	this.Subdues = that.GetSubdues()
+	this.CronTimezone = that.GetCronTimezone()
+	this.User = that.GetUser()
+	this.Group = that.GetGroup()
+	this.Argv = that.GetArgv()
 	return this
 }
 
@@ -1517,6 +1629,11 @@ type CheckFace interface {
 	GetPipelines() []*ResourceReference
 	GetOutputMetricThresholds() []*MetricThreshold
 	GetSubdues() []*TimeWindowRepeated
+	GetCronTimezone() string
+	GetUser() string
+	GetGroup() string
+	GetArgv() []string
+	GetPrevious() *CheckHistory
 	GetExtendedAttributes() []byte
 }
 
@@ -1704,6 +1821,26 @@ func (this *Check) GetSubdues() []*TimeWindowRepeated {
 	return this.Subdues
 }
 
+func (this *Check) GetCronTimezone() string {
+	return this.CronTimezone
+}
+
+func (this *Check) GetUser() string {
+	return this.User
+}
+
+func (this *Check) GetGroup() string {
+	return this.Group
+}
+
+func (this *Check) GetArgv() []string {
+	return this.Argv
+}
+
+func (this *Check) GetPrevious() *CheckHistory {
+	return this.Previous
+}
+
 func (this *Check) GetExtendedAttributes() []byte {
 	return this.ExtendedAttributes
 }
@@ -1754,6 +1891,11 @@ func NewCheckFromFace(that CheckFace) *Check {
 	this.Pipelines = that.GetPipelines()
 	this.OutputMetricThresholds = that.GetOutputMetricThresholds()
 	this.Subdues = that.GetSubdues()
+	this.CronTimezone = that.GetCronTimezone()
+	this.User = that.GetUser()
+	this.Group = that.GetGroup()
+	this.Argv = that.GetArgv()
+	this.Previous = that.GetPrevious()
 	this.ExtendedAttributes = that.GetExtendedAttributes()
 	return this
 }
@@ -1930,6 +2072,11 @@ func (m *ProxyRequests) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.BatchSize != 0 {
+		i = encodeVarintCheck(dAtA, i, uint64(m.BatchSize))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.SplayCoverage != 0 {
 		i = encodeVarintCheck(dAtA, i, uint64(m.SplayCoverage))
 		i--
@@ -1981,6 +2128,44 @@ func (m *CheckConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Argv) > 0 {
+		for iNdEx := len(m.Argv) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Argv[iNdEx])
+			copy(dAtA[i:], m.Argv[iNdEx])
+			i = encodeVarintCheck(dAtA, i, uint64(len(m.Argv[iNdEx])))
+			i--
+			dAtA[i] = 0x2
+			i--
+			dAtA[i] = 0xb2
+		}
+	}
+	if len(m.Group) > 0 {
+		i -= len(m.Group)
+		copy(dAtA[i:], m.Group)
+		i = encodeVarintCheck(dAtA, i, uint64(len(m.Group)))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xaa
+	}
+	if len(m.User) > 0 {
+		i -= len(m.User)
+		copy(dAtA[i:], m.User)
+		i = encodeVarintCheck(dAtA, i, uint64(len(m.User)))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xa2
+	}
+	if len(m.CronTimezone) > 0 {
+		i -= len(m.CronTimezone)
+		copy(dAtA[i:], m.CronTimezone)
+		i = encodeVarintCheck(dAtA, i, uint64(len(m.CronTimezone)))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x9a
+	}
 	if len(m.Subdues) > 0 {
 		for iNdEx := len(m.Subdues) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -2328,6 +2513,58 @@ func (m *Check) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x9a
 	}
+	if m.Previous != nil {
+		{
+			size, err := m.Previous.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCheck(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xaa
+	}
+	if len(m.Argv) > 0 {
+		for iNdEx := len(m.Argv) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Argv[iNdEx])
+			copy(dAtA[i:], m.Argv[iNdEx])
+			i = encodeVarintCheck(dAtA, i, uint64(len(m.Argv[iNdEx])))
+			i--
+			dAtA[i] = 0x3
+			i--
+			dAtA[i] = 0xa2
+		}
+	}
+	if len(m.Group) > 0 {
+		i -= len(m.Group)
+		copy(dAtA[i:], m.Group)
+		i = encodeVarintCheck(dAtA, i, uint64(len(m.Group)))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x9a
+	}
+	if len(m.User) > 0 {
+		i -= len(m.User)
+		copy(dAtA[i:], m.User)
+		i = encodeVarintCheck(dAtA, i, uint64(len(m.User)))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x92
+	}
+	if len(m.CronTimezone) > 0 {
+		i -= len(m.CronTimezone)
+		copy(dAtA[i:], m.CronTimezone)
+		i = encodeVarintCheck(dAtA, i, uint64(len(m.CronTimezone)))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x8a
+	}
 	if len(m.Subdues) > 0 {
 		for iNdEx := len(m.Subdues) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -2900,8 +3137,9 @@ func NewPopulatedProxyRequests(r randyCheck, easy bool) *ProxyRequests {
 	}
 	this.Splay = bool(bool(r.Intn(2) == 0))
 	this.SplayCoverage = uint32(r.Uint32())
+	this.BatchSize = uint32(r.Uint32())
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedCheck(r, 4)
+		this.XXX_unrecognized = randUnrecognizedCheck(r, 5)
 	}
 	return this
 }
@@ -3010,8 +3248,16 @@ func NewPopulatedCheckConfig(r randyCheck, easy bool) *CheckConfig {
 			this.Subdues[i] = NewPopulatedTimeWindowRepeated(r, easy)
 		}
 	}
+	this.CronTimezone = string(randStringCheck(r))
+	this.User = string(randStringCheck(r))
+	this.Group = string(randStringCheck(r))
+	v24 := r.Intn(10)
+	this.Argv = make([]string, v24)
+	for i := 0; i < v24; i++ {
+		this.Argv[i] = string(randStringCheck(r))
+	}
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedCheck(r, 35)
+		this.XXX_unrecognized = randUnrecognizedCheck(r, 39)
 	}
 	return this
 }
@@ -3019,32 +3265,32 @@ func NewPopulatedCheckConfig(r randyCheck, easy bool) *CheckConfig {
 func NewPopulatedCheck(r randyCheck, easy bool) *Check {
 	this := &Check{}
 	this.Command = string(randStringCheck(r))
-	v24 := r.Intn(10)
-	this.Handlers = make([]string, v24)
-	for i := 0; i < v24; i++ {
+	v25 := r.Intn(10)
+	this.Handlers = make([]string, v25)
+	for i := 0; i < v25; i++ {
 		this.Handlers[i] = string(randStringCheck(r))
 	}
 	this.HighFlapThreshold = uint32(r.Uint32())
 	this.Interval = uint32(r.Uint32())
 	this.LowFlapThreshold = uint32(r.Uint32())
 	this.Publish = bool(bool(r.Intn(2) == 0))
-	v25 := r.Intn(10)
-	this.RuntimeAssets = make([]string, v25)
-	for i := 0; i < v25; i++ {
-		this.RuntimeAssets[i] = string(randStringCheck(r))
-	}
 	v26 := r.Intn(10)
-	this.Subscriptions = make([]string, v26)
+	this.RuntimeAssets = make([]string, v26)
 	for i := 0; i < v26; i++ {
+		this.RuntimeAssets[i] = string(randStringCheck(r))
+	}
+	v27 := r.Intn(10)
+	this.Subscriptions = make([]string, v27)
+	for i := 0; i < v27; i++ {
 		this.Subscriptions[i] = string(randStringCheck(r))
 	}
 	this.ProxyEntityName = string(randStringCheck(r))
 	if r.Intn(5) != 0 {
-		v27 := r.Intn(5)
-		this.CheckHooks = make([]HookList, v27)
-		for i := 0; i < v27; i++ {
-			v28 := NewPopulatedHookList(r, easy)
-			this.CheckHooks[i] = *v28
+		v28 := r.Intn(5)
+		this.CheckHooks = make([]HookList, v28)
+		for i := 0; i < v28; i++ {
+			v29 := NewPopulatedHookList(r, easy)
+			this.CheckHooks[i] = *v29
 		}
 	}
 	this.Stdin = bool(bool(r.Intn(2) == 0))
@@ -3070,11 +3316,11 @@ func NewPopulatedCheck(r randyCheck, easy bool) *Check {
 		this.Executed *= -1
 	}
 	if r.Intn(5) != 0 {
-		v29 := r.Intn(5)
-		this.History = make([]CheckHistory, v29)
-		for i := 0; i < v29; i++ {
-			v30 := NewPopulatedCheckHistory(r, easy)
-			this.History[i] = *v30
+		v30 := r.Intn(5)
+		this.History = make([]CheckHistory, v30)
+		for i := 0; i < v30; i++ {
+			v31 := NewPopulatedCheckHistory(r, easy)
+			this.History[i] = *v31
 		}
 	}
 	this.Issued = int64(r.Int63())
@@ -3097,77 +3343,88 @@ func NewPopulatedCheck(r randyCheck, easy bool) *Check {
 	if r.Intn(2) == 0 {
 		this.OccurrencesWatermark *= -1
 	}
-	v31 := r.Intn(10)
-	this.Silenced = make([]string, v31)
-	for i := 0; i < v31; i++ {
+	v32 := r.Intn(10)
+	this.Silenced = make([]string, v32)
+	for i := 0; i < v32; i++ {
 		this.Silenced[i] = string(randStringCheck(r))
 	}
 	if r.Intn(5) != 0 {
-		v32 := r.Intn(5)
-		this.Hooks = make([]*Hook, v32)
-		for i := 0; i < v32; i++ {
+		v33 := r.Intn(5)
+		this.Hooks = make([]*Hook, v33)
+		for i := 0; i < v33; i++ {
 			this.Hooks[i] = NewPopulatedHook(r, easy)
 		}
 	}
 	this.OutputMetricFormat = string(randStringCheck(r))
-	v33 := r.Intn(10)
-	this.OutputMetricHandlers = make([]string, v33)
-	for i := 0; i < v33; i++ {
-		this.OutputMetricHandlers[i] = string(randStringCheck(r))
-	}
 	v34 := r.Intn(10)
-	this.EnvVars = make([]string, v34)
+	this.OutputMetricHandlers = make([]string, v34)
 	for i := 0; i < v34; i++ {
+		this.OutputMetricHandlers[i] = string(randStringCheck(r))
+	}
+	v35 := r.Intn(10)
+	this.EnvVars = make([]string, v35)
+	for i := 0; i < v35; i++ {
 		this.EnvVars[i] = string(randStringCheck(r))
 	}
-	v35 := NewPopulatedObjectMeta(r, easy)
-	this.ObjectMeta = *v35
+	v36 := NewPopulatedObjectMeta(r, easy)
+	this.ObjectMeta = *v36
 	this.MaxOutputSize = int64(r.Int63())
 	if r.Intn(2) == 0 {
 		this.MaxOutputSize *= -1
 	}
 	this.DiscardOutput = bool(bool(r.Intn(2) == 0))
 	if r.Intn(5) != 0 {
-		v36 := r.Intn(5)
-		this.Secrets = make([]*Secret, v36)
-		for i := 0; i < v36; i++ {
+		v37 := r.Intn(5)
+		this.Secrets = make([]*Secret, v37)
+		for i := 0; i < v37; i++ {
 			this.Secrets[i] = NewPopulatedSecret(r, easy)
 		}
 	}
 	this.IsSilenced = bool(bool(r.Intn(2) == 0))
 	if r.Intn(5) != 0 {
-		v37 := r.Intn(5)
-		this.OutputMetricTags = make([]*MetricTag, v37)
-		for i := 0; i < v37; i++ {
+		v38 := r.Intn(5)
+		this.OutputMetricTags = make([]*MetricTag, v38)
+		for i := 0; i < v38; i++ {
 			this.OutputMetricTags[i] = NewPopulatedMetricTag(r, easy)
 		}
 	}
 	this.Scheduler = string(randStringCheck(r))
 	this.ProcessedBy = string(randStringCheck(r))
 	if r.Intn(5) != 0 {
-		v38 := r.Intn(5)
-		this.Pipelines = make([]*ResourceReference, v38)
-		for i := 0; i < v38; i++ {
+		v39 := r.Intn(5)
+		this.Pipelines = make([]*ResourceReference, v39)
+		for i := 0; i < v39; i++ {
 			this.Pipelines[i] = NewPopulatedResourceReference(r, easy)
 		}
 	}
 	if r.Intn(5) != 0 {
-		v39 := r.Intn(5)
-		this.OutputMetricThresholds = make([]*MetricThreshold, v39)
-		for i := 0; i < v39; i++ {
+		v40 := r.Intn(5)
+		this.OutputMetricThresholds = make([]*MetricThreshold, v40)
+		for i := 0; i < v40; i++ {
 			this.OutputMetricThresholds[i] = NewPopulatedMetricThreshold(r, easy)
 		}
 	}
 	if r.Intn(5) != 0 {
-		v40 := r.Intn(5)
-		this.Subdues = make([]*TimeWindowRepeated, v40)
-		for i := 0; i < v40; i++ {
+		v41 := r.Intn(5)
+		this.Subdues = make([]*TimeWindowRepeated, v41)
+		for i := 0; i < v41; i++ {
 			this.Subdues[i] = NewPopulatedTimeWindowRepeated(r, easy)
 		}
 	}
-	v41 := r.Intn(100)
-	this.ExtendedAttributes = make([]byte, v41)
-	for i := 0; i < v41; i++ {
+	this.CronTimezone = string(randStringCheck(r))
+	this.User = string(randStringCheck(r))
+	this.Group = string(randStringCheck(r))
+	v42 := r.Intn(10)
+	this.Argv = make([]string, v42)
+	for i := 0; i < v42; i++ {
+		this.Argv[i] = string(randStringCheck(r))
+	}
+	if r.Intn(5) != 0 {
+		this.Previous = NewPopulatedCheckHistory(r, easy)
+	}
+	v43 := r.Intn(100)
+	this.ExtendedAttributes = make([]byte, v43)
+	for i := 0; i < v43; i++ {
 		this.ExtendedAttributes[i] = byte(r.Intn(256))
 	}
 	if !easy && r.Intn(10) != 0 {
@@ -3209,9 +3466,9 @@ func randUTF8RuneCheck(r randyCheck) rune {
 	return rune(ru + 61)
 }
 func randStringCheck(r randyCheck) string {
-	v42 := r.Intn(100)
-	tmps := make([]rune, v42)
-	for i := 0; i < v42; i++ {
+	v44 := r.Intn(100)
+	tmps := make([]rune, v44)
+	for i := 0; i < v44; i++ {
 		tmps[i] = randUTF8RuneCheck(r)
 	}
 	return string(tmps)
@@ -3233,11 +3490,11 @@ func randFieldCheck(dAtA []byte, r randyCheck, fieldNumber int, wire int) []byte
 	switch wire {
 	case 0:
 		dAtA = encodeVarintPopulateCheck(dAtA, uint64(key))
-		v43 := r.Int63()
+		v45 := r.Int63()
 		if r.Intn(2) == 0 {
-			v43 *= -1
+			v45 *= -1
 		}
-		dAtA = encodeVarintPopulateCheck(dAtA, uint64(v43))
+		dAtA = encodeVarintPopulateCheck(dAtA, uint64(v45))
 	case 1:
 		dAtA = encodeVarintPopulateCheck(dAtA, uint64(key))
 		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
@@ -3348,6 +3605,9 @@ func (m *ProxyRequests) Size() (n int) {
 	if m.SplayCoverage != 0 {
 		n += 1 + sovCheck(uint64(m.SplayCoverage))
 	}
+	if m.BatchSize != 0 {
+		n += 1 + sovCheck(uint64(m.BatchSize))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -3490,6 +3750,24 @@ func (m *CheckConfig) Size() (n int) {
 			n += 2 + l + sovCheck(uint64(l))
 		}
 	}
+	l = len(m.CronTimezone)
+	if l > 0 {
+		n += 2 + l + sovCheck(uint64(l))
+	}
+	l = len(m.User)
+	if l > 0 {
+		n += 2 + l + sovCheck(uint64(l))
+	}
+	l = len(m.Group)
+	if l > 0 {
+		n += 2 + l + sovCheck(uint64(l))
+	}
+	if len(m.Argv) > 0 {
+		for _, s := range m.Argv {
+			l = len(s)
+			n += 2 + l + sovCheck(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -3685,6 +3963,28 @@ func (m *Check) Size() (n int) {
 			n += 2 + l + sovCheck(uint64(l))
 		}
 	}
+	l = len(m.CronTimezone)
+	if l > 0 {
+		n += 2 + l + sovCheck(uint64(l))
+	}
+	l = len(m.User)
+	if l > 0 {
+		n += 2 + l + sovCheck(uint64(l))
+	}
+	l = len(m.Group)
+	if l > 0 {
+		n += 2 + l + sovCheck(uint64(l))
+	}
+	if len(m.Argv) > 0 {
+		for _, s := range m.Argv {
+			l = len(s)
+			n += 2 + l + sovCheck(uint64(l))
+		}
+	}
+	if m.Previous != nil {
+		l = m.Previous.Size()
+		n += 2 + l + sovCheck(uint64(l))
+	}
 	l = len(m.ExtendedAttributes)
 	if l > 0 {
 		n += 2 + l + sovCheck(uint64(l))
@@ -4242,6 +4542,25 @@ func (m *ProxyRequests) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchSize", wireType)
+			}
+			m.BatchSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BatchSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCheck(dAtA[iNdEx:])
@@ -5150,6 +5469,134 @@ func (m *CheckConfig) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 35:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CronTimezone", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCheck
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheck
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CronTimezone = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 36:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCheck
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheck
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.User = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 37:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCheck
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheck
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Group = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 38:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Argv", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCheck
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheck
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Argv = append(m.Argv, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCheck(dAtA[iNdEx:])
@@ -6384,6 +6831,170 @@ func (m *Check) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 49:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CronTimezone", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCheck
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheck
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CronTimezone = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 50:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCheck
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheck
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.User = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 51:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCheck
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheck
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Group = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 52:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Argv", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCheck
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheck
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Argv = append(m.Argv, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 53:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Previous", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheck
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheck
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Previous == nil {
+				m.Previous = &CheckHistory{}
+			}
+			if err := m.Previous.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 99:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field ExtendedAttributes", wireType)
@@ -117,6 +117,10 @@ func (c *CheckConfig) Validate() error {
 		return errors.New("check interval must be greater than 0 or a valid cron schedule must be provided")
 	}
 
+	if err := ValidateCronTimezone(c.Cron, c.CronTimezone); err != nil {
+		return err
+	}
+
 	if c.Namespace == "" {
 		return errors.New("namespace must be set")
 	}
@@ -125,6 +129,10 @@ func (c *CheckConfig) Validate() error {
 		return errors.New("ttl must be greater than check interval")
 	}
 
+	if len(c.Argv) > 0 && c.Argv[0] == "" {
+		return errors.New("the first element of argv must be the executable")
+	}
+
 	for _, assetName := range c.RuntimeAssets {
 		if err := ValidateAssetName(assetName); err != nil {
 			return fmt.Errorf("asset's %s", err)
@@ -169,14 +177,61 @@ func (c *CheckConfig) Validate() error {
 		return err
 	}
 
+	if _, err := CheckSplayCoverage(c.Annotations); err != nil {
+		return err
+	}
+
+	if _, err := CheckMetricTimestampUnit(c.Annotations); err != nil {
+		return err
+	}
+
 	return c.Subdue.Validate()
 }
 
+// CheckSplayCoverage returns the percentage of the interval set in the
+// annotations of a check, over which the agents spread its executions, or 0 if
+// none is set. It returns an error if the percentage is not an integer between
+// 1 and 100.
+func CheckSplayCoverage(annotations map[string]string) (uint32, error) {
+	value, ok := annotations[CheckSplayCoverageAnnotation]
+	if !ok {
+		return 0, nil
+	}
+	coverage, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || coverage < 1 || coverage > 100 {
+		return 0, fmt.Errorf("%s annotation must be an integer between 1 and 100, got %q", CheckSplayCoverageAnnotation, value)
+	}
+	return uint32(coverage), nil
+}
+
+// CheckMetricTimestampUnit returns the unit of the output metric timestamps
+// set in the annotations of a check, or MetricTimestampUnitAuto if none is set.
+// It returns an error if the unit is not one of "s", "ms", "us", "ns" or
+// "auto".
+func CheckMetricTimestampUnit(annotations map[string]string) (string, error) {
+	value, ok := annotations[CheckMetricTimestampUnitAnnotation]
+	if !ok {
+		return MetricTimestampUnitAuto, nil
+	}
+	switch value {
+	case MetricTimestampUnitAuto, MetricTimestampUnitSeconds, MetricTimestampUnitMilliseconds,
+		MetricTimestampUnitMicroseconds, MetricTimestampUnitNanoseconds:
+		return value, nil
+	}
+	return "", fmt.Errorf("%s annotation must be one of s, ms, us, ns or auto, got %q", CheckMetricTimestampUnitAnnotation, value)
+}
+
 // IsSubdued returns true if the check is subdued at the current time.
 // It returns false otherwise.
 func (c *CheckConfig) IsSubdued() bool {
+	return c.IsSubduedAt(time.Now())
+}
+
+// IsSubduedAt returns true if the check is subdued at the given time.
+// It returns false otherwise.
+func (c *CheckConfig) IsSubduedAt(t time.Time) bool {
 	for _, subdue := range c.Subdues {
-		subdued := subdue.InWindows(time.Now())
+		subdued := subdue.InWindows(t)
 		if subdued {
 			return true
 		}
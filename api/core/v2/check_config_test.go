@@ -107,6 +107,74 @@ func TestCheckConfigFlapThresholdValidation(t *testing.T) {
 	assert.Error(t, c.Validate())
 }
 
+func TestCheckConfigArgvValidation(t *testing.T) {
+	c := FixtureCheckConfig("foo")
+	c.Argv = []string{"check-http", "--url", "http://localhost/$PATH"}
+	assert.NoError(t, c.Validate())
+
+	// empty arguments are valid
+	c.Argv = []string{"check-http", ""}
+	assert.NoError(t, c.Validate())
+
+	// the executable cannot be empty
+	c.Argv = []string{"", "--url"}
+	assert.Error(t, c.Validate())
+}
+
+func TestCheckConfigCronTimezoneValidation(t *testing.T) {
+	c := FixtureCheckConfig("foo")
+	c.Interval = 0
+	c.Cron = "0 2 * * *"
+	c.CronTimezone = "America/Toronto"
+	assert.NoError(t, c.Validate())
+
+	// unknown time zones are invalid
+	c.CronTimezone = "America/Nowhere"
+	assert.Error(t, c.Validate())
+
+	// a cron string setting its own time zone is invalid
+	c.CronTimezone = "America/Toronto"
+	c.Cron = "CRON_TZ=Asia/Tokyo 0 2 * * *"
+	assert.Error(t, c.Validate())
+
+	// a cron timezone requires a cron schedule
+	c.Cron = ""
+	c.Interval = 60
+	assert.Error(t, c.Validate())
+}
+
+func TestCheckConfigSplayCoverageValidation(t *testing.T) {
+	c := FixtureCheckConfig("foo")
+	c.Annotations = map[string]string{CheckSplayCoverageAnnotation: "50"}
+	assert.NoError(t, c.Validate())
+	coverage, err := CheckSplayCoverage(c.Annotations)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(50), coverage)
+
+	for _, value := range []string{"0", "101", "-1", "half"} {
+		c.Annotations[CheckSplayCoverageAnnotation] = value
+		assert.Error(t, c.Validate(), value)
+	}
+}
+
+func TestCheckConfigMetricTimestampUnitValidation(t *testing.T) {
+	c := FixtureCheckConfig("foo")
+	unit, err := CheckMetricTimestampUnit(c.Annotations)
+	require.NoError(t, err)
+	assert.Equal(t, MetricTimestampUnitAuto, unit)
+
+	c.Annotations = map[string]string{CheckMetricTimestampUnitAnnotation: "ms"}
+	assert.NoError(t, c.Validate())
+	unit, err = CheckMetricTimestampUnit(c.Annotations)
+	require.NoError(t, err)
+	assert.Equal(t, MetricTimestampUnitMilliseconds, unit)
+
+	for _, value := range []string{"", "sec", "MS", "1000"} {
+		c.Annotations[CheckMetricTimestampUnitAnnotation] = value
+		assert.Error(t, c.Validate(), value)
+	}
+}
+
 func TestSortCheckConfigsByName(t *testing.T) {
 	a := FixtureCheckConfig("Abernathy")
 	b := FixtureCheckConfig("Bernard")
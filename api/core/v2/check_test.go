@@ -66,6 +66,37 @@ func TestMergeWith(t *testing.T) {
 	assert.NotEmpty(t, newCheck.History)
 	assert.Equal(t, newCheck.Status, newCheck.History[20].Status)
 	assert.False(t, newCheck.History[20].Flapping)
+	assert.Equal(t, &CheckHistory{Status: 1, Executed: originalCheck.Executed}, newCheck.Previous)
+
+	// the first occurrence of a check has no previous occurrence
+	firstCheck := FixtureCheck("check")
+	firstCheck.MergeWith(firstCheck)
+	assert.Nil(t, firstCheck.Previous)
+}
+
+func TestMergeWithMaxHistory(t *testing.T) {
+	originalCheck := FixtureCheck("check")
+
+	// checks without flap thresholds are trimmed to the maximum
+	newCheck := FixtureCheck("check")
+	newCheck.LowFlapThreshold, newCheck.HighFlapThreshold = 0, 0
+	newCheck.MergeWithMaxHistory(originalCheck, 5)
+	assert.Len(t, newCheck.History, 5)
+	assert.Equal(t, newCheck.Executed, newCheck.History[4].Executed)
+
+	// the current and previous results are always retained
+	newCheck.MergeWithMaxHistory(originalCheck, 1)
+	assert.Len(t, newCheck.History, MinCheckHistory)
+
+	// checks with flap thresholds retain enough results for flap detection
+	newCheck = FixtureCheck("check")
+	newCheck.MergeWithMaxHistory(originalCheck, 5)
+	assert.Len(t, newCheck.History, flapDetectionHistory)
+
+	// more results can be retained
+	newCheck = FixtureCheck("check")
+	newCheck.MergeWithMaxHistory(originalCheck, 50)
+	assert.Len(t, newCheck.History, 22)
 }
 
 func TestCheckHasNoEmptyStringsInSub(t *testing.T) {
@@ -30,6 +30,16 @@ const (
 
 	// Redacted is filled in for fields that contain sensitive information
 	Redacted = "REDACTED"
+
+	// RoundRobinWeightAnnotation is the annotation of the agent entities
+	// setting their share of the proxy check requests of round-robin checks,
+	// relative to the other agents. Entities without it have a weight of 1.
+	RoundRobinWeightAnnotation = "sensu.io/round_robin_weight"
+
+	// BackendAnnotation is the annotation of the agent entities recording the
+	// backend their agent is connected to, set by the backend when the session
+	// of the agent is established.
+	BackendAnnotation = "sensu.io/backend"
 )
 
 // DefaultRedactFields contains the default fields to redact
@@ -63,9 +73,28 @@ func (e *Entity) Validate() error {
 		return errors.New("namespace must be set")
 	}
 
+	if _, err := RoundRobinWeight(e.Annotations); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// RoundRobinWeight returns the round-robin weight set in the annotations of an
+// entity, or 1 if none is set. It returns an error if the weight is not a
+// positive integer.
+func RoundRobinWeight(annotations map[string]string) (int, error) {
+	value, ok := annotations[RoundRobinWeightAnnotation]
+	if !ok {
+		return 1, nil
+	}
+	weight, err := strconv.Atoi(value)
+	if err != nil || weight < 1 {
+		return 0, fmt.Errorf("%s annotation must be a positive integer, got %q", RoundRobinWeightAnnotation, value)
+	}
+	return weight, nil
+}
+
 // NewEntity creates a new Entity.
 func NewEntity(meta ObjectMeta) *Entity {
 	return &Entity{ObjectMeta: meta}
@@ -26,6 +26,42 @@ func TestEntityValidate(t *testing.T) {
 
 	// Valid entity
 	assert.NoError(t, e.Validate())
+
+	// Invalid round-robin weight
+	e.Annotations = map[string]string{RoundRobinWeightAnnotation: "0"}
+	assert.Error(t, e.Validate())
+	e.Annotations[RoundRobinWeightAnnotation] = "3"
+	assert.NoError(t, e.Validate())
+}
+
+func TestRoundRobinWeight(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{value: "1", want: 1},
+		{value: "10", want: 10},
+		{value: "0", wantErr: true},
+		{value: "-2", wantErr: true},
+		{value: "1.5", wantErr: true},
+		{value: "heavy", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := RoundRobinWeight(map[string]string{RoundRobinWeightAnnotation: tt.value})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RoundRobinWeight() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RoundRobinWeight() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	weight, err := RoundRobinWeight(nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, weight)
 }
 
 func TestFixtureEntityIsValid(t *testing.T) {
@@ -123,6 +123,12 @@ func (e *Event) HasHandlers() bool {
 	return false
 }
 
+// IsMetricsOnly determines if an event only carries metrics, i.e. it has
+// metrics and its check, if any, is passing and did not change status.
+func (e *Event) IsMetricsOnly() bool {
+	return e.HasMetrics() && !e.IsIncident() && !e.IsResolution()
+}
+
 // IsIncident determines if an event indicates an incident.
 func (e *Event) IsIncident() bool {
 	return e.HasCheck() && e.Check.Status != 0
@@ -151,6 +157,16 @@ func (e *Event) IsSilenced() bool {
 	return len(e.Check.Silenced) > 0
 }
 
+// IsFlapping determines if the check of an event is flapping, according to the
+// flap thresholds of the check.
+func (e *Event) IsFlapping() bool {
+	if !e.HasCheck() {
+		return false
+	}
+
+	return e.Check.State == EventFlappingState
+}
+
 // IsFlappingStart determines if an event started flapping on this occurrence.
 func (e *Event) IsFlappingStart() bool {
 	if !e.HasCheck() {
@@ -179,6 +195,22 @@ func (e *Event) previousOccurrence() *CheckHistory {
 	return e.Check.previousOccurrence()
 }
 
+// IdempotencyKey identifies the execution of a check carried by an event, by
+// its entity, or proxy entity, its check and the times the check was issued
+// and executed, so that the same result delivered twice, such as when an agent
+// replays its spooled events after a reconnection, can be detected. It is empty
+// for the events without a check or whose check was not executed.
+func (e *Event) IdempotencyKey() string {
+	if !e.HasCheck() || e.Entity == nil || e.Check.Executed == 0 {
+		return ""
+	}
+	entityName := e.Entity.Name
+	if e.Check.ProxyEntityName != "" {
+		entityName = e.Check.ProxyEntityName
+	}
+	return fmt.Sprintf("%s/%s/%s/%d/%d", e.Entity.Namespace, entityName, e.Check.Name, e.Check.Issued, e.Check.Executed)
+}
+
 // SynthesizeExtras implements dynamic.SynthesizeExtras
 func (e *Event) SynthesizeExtras() map[string]interface{} {
 	return map[string]interface{}{
@@ -187,6 +219,7 @@ func (e *Event) SynthesizeExtras() map[string]interface{} {
 		"is_incident":       e.IsIncident(),
 		"is_resolution":     e.IsResolution(),
 		"is_silenced":       e.IsSilenced(),
+		"is_flapping":       e.IsFlapping(),
 		"is_flapping_start": e.IsFlappingStart(),
 		"is_flapping_end":   e.IsFlappingEnd(),
 	}
@@ -164,6 +164,54 @@ func TestEventIsResolution(t *testing.T) {
 	}
 }
 
+func TestEventIsMetricsOnly(t *testing.T) {
+	testCases := []struct {
+		name     string
+		metrics  *Metrics
+		history  []CheckHistory
+		status   uint32
+		expected bool
+	}{
+		{
+			name:     "no metrics",
+			history:  []CheckHistory{{Status: 0}, {Status: 0}},
+			expected: false,
+		},
+		{
+			name:     "passing check with metrics",
+			metrics:  &Metrics{},
+			history:  []CheckHistory{{Status: 0}, {Status: 0}},
+			expected: true,
+		},
+		{
+			name:     "resolution with metrics",
+			metrics:  &Metrics{},
+			history:  []CheckHistory{{Status: 1}, {Status: 0}},
+			expected: false,
+		},
+		{
+			name:     "incident with metrics",
+			metrics:  &Metrics{},
+			history:  []CheckHistory{{Status: 0}, {Status: 2}},
+			status:   2,
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			event := &Event{
+				Check: &Check{
+					History: tc.history,
+					Status:  tc.status,
+				},
+				Metrics: tc.metrics,
+			}
+			assert.Equal(t, tc.expected, event.IsMetricsOnly())
+		})
+	}
+}
+
 func TestEventIsSilenced(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -202,6 +250,16 @@ func TestEventIsSilenced(t *testing.T) {
 	}
 }
 
+func TestEventIsFlapping(t *testing.T) {
+	event := FixtureEvent("entity1", "check1")
+	assert.False(t, event.IsFlapping())
+
+	event.Check.State = EventFlappingState
+	assert.True(t, event.IsFlapping())
+
+	assert.False(t, (&Event{}).IsFlapping())
+}
+
 func TestEventIsFlappingStart(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -865,3 +923,29 @@ func TestEventURIPath(t *testing.T) {
 		t.Errorf("bad URIPath; got %q, want %q", got, want)
 	}
 }
+
+func TestEventIdempotencyKey(t *testing.T) {
+	event := FixtureEvent("entity", "check")
+	event.Check.Issued = 1600000000
+	event.Check.Executed = 1600000001
+	assert.Equal(t, "default/entity/check/1600000000/1600000001", event.IdempotencyKey())
+
+	// A new execution of the check is a different result
+	other := FixtureEvent("entity", "check")
+	other.Check.Issued = 1600000000
+	other.Check.Executed = 1600000002
+	assert.NotEqual(t, event.IdempotencyKey(), other.IdempotencyKey())
+
+	// So is the execution of the check for another proxy entity
+	proxy := FixtureEvent("entity", "check")
+	proxy.Check.Issued = 1600000000
+	proxy.Check.Executed = 1600000001
+	proxy.Check.ProxyEntityName = "router"
+	assert.Equal(t, "default/router/check/1600000000/1600000001", proxy.IdempotencyKey())
+
+	event.Check.Executed = 0
+	assert.Empty(t, event.IdempotencyKey())
+
+	event.Check = nil
+	assert.Empty(t, event.IdempotencyKey())
+}
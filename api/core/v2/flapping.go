@@ -41,21 +41,24 @@ func updateCheckState(check *Check) {
 // totalStateChange calculates the total state change percentage for the
 // history, which is later used for check state flap detection.
 func totalStateChange(check *Check) uint32 {
-	if check == nil || len(check.History) < 21 {
+	if check == nil || len(check.History) < flapDetectionHistory {
 		return 0
 	}
 
+	// Only the most recent results count when more are retained
+	history := check.History[len(check.History)-flapDetectionHistory:]
+
 	stateChanges := 0.00
 	changeWeight := 0.80
-	previousStatus := check.History[0].Status
+	previousStatus := history[0].Status
 
-	for i := 1; i <= len(check.History)-1; i++ {
-		if check.History[i].Status != previousStatus {
+	for i := 1; i <= len(history)-1; i++ {
+		if history[i].Status != previousStatus {
 			stateChanges += changeWeight
 		}
 
 		changeWeight += 0.02
-		previousStatus = check.History[i].Status
+		previousStatus = history[i].Status
 	}
 
 	return uint32(float32(stateChanges) / 20 * 100)
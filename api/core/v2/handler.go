@@ -6,7 +6,9 @@ import (
 	"net/url"
 	"path"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	stringsutil "github.com/sensu/sensu-go/api/core/v2/internal/stringutil"
 )
@@ -39,8 +41,69 @@ const (
 	// RegistrationHandlerName is the name of the handler that is executed when
 	// a registration event is passed to pipelined.
 	RegistrationHandlerName = "registration"
+
+	// HandlerConcurrencyAnnotation is the annotation of the handlers limiting
+	// their concurrent executions. The executions beyond the limit are queued.
+	HandlerConcurrencyAnnotation = "sensu.io/handler_concurrency"
+
+	// HandlerShortCircuitAnnotation is the annotation of the handlers whose
+	// filters, when they deny an event, also skip the handlers of the event
+	// with a lower priority.
+	HandlerShortCircuitAnnotation = "sensu.io/handler_short_circuit"
+
+	// HandlerRetriesAnnotation is the annotation of the handlers setting the
+	// number of times their failed executions are retried. The pipe handlers
+	// exiting with a non-zero status are retried too.
+	HandlerRetriesAnnotation = "sensu.io/handler_retries"
+
+	// HandlerRetryBackoffAnnotation is the annotation of the handlers setting
+	// the delay before their first retry, as a duration such as "5s". The
+	// delay is doubled after every retry.
+	HandlerRetryBackoffAnnotation = "sensu.io/handler_retry_backoff"
+
+	// HandlerDeadLetterAnnotation is the annotation of the handlers naming the
+	// handler the events are routed to once their execution and its retries
+	// failed.
+	HandlerDeadLetterAnnotation = "sensu.io/handler_dead_letter"
+
+	// DeadLetterHandlerAnnotation, DeadLetterErrorAnnotation and
+	// DeadLetterAttemptsAnnotation are the annotations of the events routed to
+	// a dead-letter handler, recording the handler that failed, its last
+	// error and the number of times it was executed.
+	DeadLetterHandlerAnnotation  = "sensu.io/dead_letter_handler"
+	DeadLetterErrorAnnotation    = "sensu.io/dead_letter_error"
+	DeadLetterAttemptsAnnotation = "sensu.io/dead_letter_attempts"
+
+	// HandlerFailureCheckPrefix prefixes the name of the handler in the check
+	// of the events recording the failures of the handlers with a retry
+	// policy, once their retries are exhausted.
+	HandlerFailureCheckPrefix = "handler-failure-"
+
+	// DefaultHandlerRetryBackoff is the delay before the first retry of the
+	// handlers that do not set HandlerRetryBackoffAnnotation.
+	DefaultHandlerRetryBackoff = time.Second
 )
 
+// HandlerRetryPolicy is the retry policy of a handler, set with its
+// annotations.
+type HandlerRetryPolicy struct {
+	// Retries is the number of times a failed execution is retried.
+	Retries int
+
+	// Backoff is the delay before the first retry, doubled after every
+	// retry.
+	Backoff time.Duration
+
+	// DeadLetter is the name of the handler the event is routed to once the
+	// execution and its retries failed, if any.
+	DeadLetter string
+}
+
+// Enabled returns true if the handler is retried or has a dead-letter handler.
+func (p HandlerRetryPolicy) Enabled() bool {
+	return p.Retries > 0 || p.DeadLetter != ""
+}
+
 // StorePrefix returns the path prefix to this resource in the store
 func (h *Handler) StorePrefix() string {
 	return HandlersResource
@@ -64,13 +127,91 @@ func (h *Handler) Validate() error {
 		return err
 	}
 
+	if h.MetricsFastPath && h.Type != HandlerSetType {
+		return errors.New("metrics_fast_path is only supported by set handlers")
+	}
+
 	if h.Namespace == "" {
 		return errors.New("namespace must be set")
 	}
 
+	if _, err := HandlerConcurrency(h.Annotations); err != nil {
+		return err
+	}
+
+	if _, err := HandlerShortCircuit(h.Annotations); err != nil {
+		return err
+	}
+
+	policy, err := HandlerRetry(h.Annotations)
+	if err != nil {
+		return err
+	}
+	if policy.DeadLetter == h.Name {
+		return fmt.Errorf("%s annotation cannot name the handler itself", HandlerDeadLetterAnnotation)
+	}
+
 	return nil
 }
 
+// HandlerConcurrency returns the concurrency limit set in the annotations of a
+// handler, or 0 if none is set. It returns an error if the limit is not a
+// positive integer.
+func HandlerConcurrency(annotations map[string]string) (int, error) {
+	value, ok := annotations[HandlerConcurrencyAnnotation]
+	if !ok {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 {
+		return 0, fmt.Errorf("%s annotation must be a positive integer, got %q", HandlerConcurrencyAnnotation, value)
+	}
+	return limit, nil
+}
+
+// HandlerShortCircuit returns true if the annotations of a handler enable its
+// short-circuit. It returns an error if the annotation is not a boolean.
+func HandlerShortCircuit(annotations map[string]string) (bool, error) {
+	value, ok := annotations[HandlerShortCircuitAnnotation]
+	if !ok {
+		return false, nil
+	}
+	shortCircuit, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s annotation must be a boolean, got %q", HandlerShortCircuitAnnotation, value)
+	}
+	return shortCircuit, nil
+}
+
+// HandlerRetry returns the retry policy set in the annotations of a handler,
+// which retries nothing if none is set. It returns an error if the number of
+// retries is not a positive integer, the backoff not a positive duration, or
+// the dead-letter handler not a valid name.
+func HandlerRetry(annotations map[string]string) (HandlerRetryPolicy, error) {
+	policy := HandlerRetryPolicy{Backoff: DefaultHandlerRetryBackoff}
+	if value, ok := annotations[HandlerRetriesAnnotation]; ok {
+		retries, err := strconv.Atoi(value)
+		if err != nil || retries < 1 {
+			return HandlerRetryPolicy{}, fmt.Errorf("%s annotation must be a positive integer, got %q", HandlerRetriesAnnotation, value)
+		}
+		policy.Retries = retries
+	}
+	if value, ok := annotations[HandlerRetryBackoffAnnotation]; ok {
+		backoff, err := time.ParseDuration(value)
+		if err != nil || backoff <= 0 {
+			return HandlerRetryPolicy{}, fmt.Errorf("%s annotation must be a positive duration, got %q", HandlerRetryBackoffAnnotation, value)
+		}
+		policy.Backoff = backoff
+	}
+	if value, ok := annotations[HandlerDeadLetterAnnotation]; ok {
+		if err := ValidateName(value); err != nil {
+			return HandlerRetryPolicy{}, fmt.Errorf("%s annotation %s", HandlerDeadLetterAnnotation, err)
+		}
+		policy.DeadLetter = value
+	}
+	return policy, nil
+}
+
 func (h *Handler) validateType() error {
 	if h.Type == "" {
 		return errors.New("empty handler type")
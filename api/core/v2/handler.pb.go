@@ -51,10 +51,20 @@ type Handler struct {
 	RuntimeAssets []string `protobuf:"bytes,13,rep,name=runtime_assets,json=runtimeAssets,proto3" json:"runtime_assets"`
 	// Secrets is the list of Sensu secrets to set for the handler's
 	// execution environment.
-	Secrets              []*Secret `protobuf:"bytes,14,rep,name=secrets,proto3" json:"secrets"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Secrets []*Secret `protobuf:"bytes,14,rep,name=secrets,proto3" json:"secrets"`
+	// MetricsFastPath routes the events of a handler set that only carry
+	// metrics, with a passing status that did not change, to its metric
+	// handlers only, skipping its status handlers and their filters.
+	MetricsFastPath bool `protobuf:"varint,15,opt,name=metrics_fast_path,json=metricsFastPath,proto3" json:"metrics_fast_path,omitempty"`
+	// Priority orders the handlers of the events, the handlers with a higher
+	// priority being executed first. Handlers with the same priority are
+	// executed in the order of their names. The handlers with a concurrency
+	// limit run asynchronously, so only the start of their executions is
+	// ordered.
+	Priority             int32    `protobuf:"varint,16,opt,name=priority,proto3" json:"priority,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Handler) Reset()         { *m = Handler{} }
@@ -158,39 +168,41 @@ func init() {
 }
 
 var fileDescriptor_a415b3439792b693 = []byte{
-	// 501 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0xc1, 0x6e, 0xd3, 0x40,
-	0x10, 0xcd, 0x36, 0x21, 0x76, 0x36, 0xb8, 0x87, 0x95, 0x90, 0x96, 0xaa, 0xb2, 0xad, 0x4a, 0x08,
-	0x1f, 0xc0, 0xa6, 0x0e, 0x17, 0x2a, 0x0e, 0xd4, 0x27, 0x2e, 0x08, 0x69, 0x2b, 0x38, 0x70, 0xa9,
-	0x36, 0xce, 0x36, 0x09, 0xd4, 0xde, 0xc8, 0xbb, 0xb6, 0xd4, 0x3f, 0xe8, 0x27, 0x70, 0xec, 0xb1,
-	0x9f, 0xc0, 0x27, 0xe4, 0xd8, 0x2f, 0xb0, 0x20, 0xdc, 0xfc, 0x05, 0x1c, 0x91, 0xc7, 0x76, 0xa0,
-	0x39, 0xf5, 0xb2, 0x7a, 0xf3, 0xe6, 0xcd, 0x78, 0x9e, 0x67, 0xf0, 0x64, 0xbe, 0xd4, 0x8b, 0x7c,
-	0xea, 0xc7, 0x32, 0x09, 0x94, 0x48, 0x55, 0xde, 0xbc, 0x2f, 0xe7, 0x32, 0xe0, 0xab, 0x65, 0x10,
-	0xcb, 0x4c, 0x04, 0x45, 0x18, 0x2c, 0x78, 0x3a, 0xbb, 0x14, 0x99, 0xbf, 0xca, 0xa4, 0x96, 0xc4,
-	0x02, 0x8d, 0x5f, 0x27, 0xfd, 0x22, 0x3c, 0x78, 0xfd, 0x5f, 0x8f, 0xb9, 0x9c, 0xcb, 0x00, 0x54,
-	0xd3, 0xfc, 0xe2, 0x5d, 0x71, 0xec, 0x4f, 0xfc, 0x63, 0x20, 0x81, 0x03, 0xd4, 0x34, 0x39, 0x78,
-	0xf5, 0xb0, 0x2f, 0x27, 0x42, 0xf3, 0xb6, 0x22, 0x7c, 0x58, 0x85, 0x12, 0x71, 0x26, 0x74, 0x53,
-	0x73, 0x74, 0x3d, 0xc0, 0xc6, 0xfb, 0x66, 0x78, 0xf2, 0x09, 0x9b, 0x75, 0xb7, 0x19, 0xd7, 0x9c,
-	0x22, 0x17, 0x79, 0xe3, 0xf0, 0xa9, 0x7f, 0xcf, 0x89, 0xff, 0x71, 0xfa, 0x55, 0xc4, 0xfa, 0x83,
-	0xd0, 0x3c, 0xb2, 0xd7, 0xa5, 0xd3, 0xbb, 0x2b, 0x1d, 0x54, 0x95, 0x0e, 0xe9, 0xca, 0x5e, 0xc8,
-	0x64, 0xa9, 0x45, 0xb2, 0xd2, 0x57, 0x6c, 0xdb, 0x8a, 0x10, 0x3c, 0xd0, 0x57, 0x2b, 0x41, 0xf7,
-	0x5c, 0xe4, 0x8d, 0x18, 0x60, 0x42, 0xb1, 0x91, 0xe4, 0x9a, 0x6b, 0x99, 0xd1, 0x3e, 0xd0, 0x5d,
-	0x58, 0x67, 0x62, 0x99, 0x24, 0x3c, 0x9d, 0xd1, 0x41, 0x93, 0x69, 0x43, 0xf2, 0x0c, 0x1b, 0x7a,
-	0x99, 0x08, 0x99, 0x6b, 0xfa, 0xc8, 0x45, 0x9e, 0x15, 0x8d, 0xab, 0xd2, 0xe9, 0x28, 0xd6, 0x01,
-	0x72, 0x82, 0x87, 0x4a, 0xc6, 0xdf, 0x84, 0xa6, 0x43, 0xf0, 0x70, 0xb8, 0xe3, 0xa1, 0x75, 0x7b,
-	0x06, 0x9a, 0x68, 0xb0, 0x2e, 0x1d, 0xc4, 0xda, 0x0a, 0xe2, 0x61, 0xb3, 0xdd, 0xa4, 0xa2, 0x86,
-	0xdb, 0xf7, 0x46, 0xd1, 0xe3, 0xaa, 0x74, 0xb6, 0x1c, 0xdb, 0xa2, 0x7a, 0x98, 0x8b, 0xe5, 0xa5,
-	0xae, 0x85, 0x26, 0x08, 0x61, 0x98, 0x96, 0x62, 0x1d, 0x20, 0xcf, 0xb1, 0x29, 0xd2, 0xe2, 0xbc,
-	0xe0, 0x99, 0xa2, 0xa3, 0x7f, 0x0d, 0x3b, 0x8e, 0x19, 0x22, 0x2d, 0x3e, 0xf3, 0x4c, 0x91, 0x37,
-	0x78, 0x3f, 0xcb, 0xd3, 0xda, 0xc3, 0x39, 0x57, 0x4a, 0x68, 0x45, 0x2d, 0x90, 0x93, 0xaa, 0x74,
-	0x76, 0x32, 0xcc, 0x6a, 0xe3, 0x53, 0x08, 0xc9, 0x5b, 0x6c, 0x34, 0x2b, 0x55, 0x74, 0xdf, 0xed,
-	0x7b, 0xe3, 0xf0, 0xc9, 0x8e, 0xe3, 0x33, 0xc8, 0x36, 0x13, 0xb6, 0x4a, 0xd6, 0x81, 0x13, 0xf3,
-	0xfa, 0xc6, 0xe9, 0xdd, 0xde, 0x38, 0xe8, 0xe8, 0x14, 0x5b, 0xf7, 0xfe, 0x4d, 0xbd, 0xb8, 0x85,
-	0x54, 0x1a, 0x6e, 0x61, 0xc4, 0x00, 0x93, 0x43, 0x3c, 0x58, 0xc9, 0x4c, 0xc3, 0x32, 0xad, 0xc8,
-	0xac, 0x4a, 0x07, 0x62, 0x06, 0x6f, 0xe4, 0xfe, 0xf9, 0x65, 0xa3, 0xdb, 0x8d, 0x8d, 0x7e, 0x6c,
-	0x6c, 0xb4, 0xde, 0xd8, 0xe8, 0x6e, 0x63, 0xa3, 0x9f, 0x1b, 0x1b, 0x7d, 0xff, 0x6d, 0xf7, 0xbe,
-	0xec, 0x15, 0xe1, 0x74, 0x08, 0x67, 0x37, 0xf9, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x72, 0x2a, 0x50,
-	0xb5, 0x58, 0x03, 0x00, 0x00,
+	// 542 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8d, 0x52, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0x8e, 0xdb, 0x34, 0x76, 0x36, 0xb8, 0x85, 0x95, 0x90, 0x96, 0x50, 0xc5, 0x51, 0x25, 0x44,
+	0x0e, 0xc5, 0xa6, 0x0e, 0x17, 0x2a, 0x0e, 0xd4, 0x07, 0x84, 0x84, 0x10, 0x68, 0x2b, 0x38, 0x70,
+	0x89, 0x1c, 0x77, 0x93, 0x18, 0xea, 0xac, 0xb5, 0xbb, 0xb6, 0x94, 0x37, 0xe0, 0x11, 0x38, 0xf6,
+	0xd8, 0x47, 0xe0, 0x11, 0x7a, 0xec, 0x13, 0x44, 0xfc, 0xdc, 0xb8, 0x71, 0xe3, 0xc8, 0x78, 0x6d,
+	0x87, 0x36, 0x5c, 0x7a, 0x18, 0xeb, 0x9b, 0xf9, 0xbe, 0x99, 0x9d, 0xf1, 0x0c, 0x1a, 0x4e, 0x63,
+	0x35, 0xcb, 0xc6, 0x6e, 0xc4, 0x13, 0x4f, 0xb2, 0xb9, 0xcc, 0xca, 0xef, 0xa3, 0x29, 0xf7, 0xc2,
+	0x34, 0xf6, 0x22, 0x2e, 0x98, 0x97, 0xfb, 0xde, 0x2c, 0x9c, 0x9f, 0x9c, 0x32, 0xe1, 0xa6, 0x82,
+	0x2b, 0x8e, 0x6d, 0xad, 0x71, 0x0b, 0xd2, 0xcd, 0xfd, 0xee, 0x93, 0x2b, 0x35, 0xa6, 0x1c, 0x32,
+	0xb5, 0x6a, 0x9c, 0x4d, 0x9e, 0xe7, 0x07, 0xee, 0xd0, 0x3d, 0xd0, 0x41, 0x1d, 0xd3, 0xa8, 0x2c,
+	0xd2, 0x7d, 0x7c, 0xb3, 0x97, 0x13, 0xa6, 0xc2, 0x2a, 0xc3, 0xbf, 0x59, 0x86, 0x64, 0x91, 0x60,
+	0xaa, 0xcc, 0xd9, 0xfb, 0xdd, 0x44, 0xe6, 0xcb, 0xb2, 0x79, 0xfc, 0x0e, 0x59, 0x45, 0xb5, 0x93,
+	0x50, 0x85, 0xc4, 0xe8, 0x1b, 0x83, 0x8e, 0x7f, 0xcf, 0xbd, 0x36, 0x89, 0xfb, 0x66, 0xfc, 0x91,
+	0x45, 0xea, 0x35, 0x88, 0x82, 0xde, 0xc5, 0xd2, 0x69, 0x5c, 0x2e, 0x1d, 0xe3, 0xd7, 0xd2, 0xc1,
+	0x75, 0xda, 0x3e, 0x4f, 0x62, 0xc5, 0x92, 0x54, 0x2d, 0xe8, 0xaa, 0x14, 0xc6, 0xa8, 0xa9, 0x16,
+	0x29, 0x23, 0x1b, 0x50, 0xb2, 0x4d, 0x35, 0xc6, 0x04, 0x99, 0x49, 0xa6, 0x42, 0xc5, 0x05, 0xd9,
+	0xd4, 0xe1, 0xda, 0x2d, 0x18, 0xe8, 0x3f, 0x81, 0x96, 0x48, 0xb3, 0x64, 0x2a, 0x17, 0x3f, 0x40,
+	0xa6, 0x8a, 0x13, 0xc6, 0x33, 0x45, 0xb6, 0x80, 0xb1, 0x83, 0x0e, 0x3c, 0x5d, 0x87, 0x68, 0x0d,
+	0xf0, 0x21, 0x6a, 0x49, 0x1e, 0x7d, 0x62, 0x8a, 0xb4, 0xf4, 0x0c, 0xbb, 0x6b, 0x33, 0x54, 0xd3,
+	0x1e, 0x6b, 0x4d, 0xd0, 0x84, 0x31, 0x0c, 0x5a, 0x65, 0xe0, 0x01, 0xb2, 0xaa, 0x4d, 0x4a, 0x62,
+	0xf6, 0x37, 0x07, 0xed, 0xe0, 0x16, 0xbc, 0xb1, 0x8a, 0xd1, 0x15, 0x2a, 0x9a, 0x99, 0xc4, 0xa7,
+	0xaa, 0x10, 0x5a, 0x5a, 0xa8, 0x9b, 0xa9, 0x42, 0xb4, 0x06, 0xf8, 0x21, 0xb2, 0xd8, 0x3c, 0x1f,
+	0xe5, 0x21, 0xe8, 0xda, 0xff, 0x0a, 0xd6, 0x31, 0x6a, 0x02, 0x7a, 0x0f, 0x00, 0x3f, 0x45, 0xdb,
+	0x22, 0x9b, 0x17, 0x33, 0x8c, 0x42, 0x29, 0x99, 0x92, 0xc4, 0xd6, 0x72, 0x0c, 0xf2, 0x35, 0x86,
+	0xda, 0x95, 0x7f, 0xa4, 0x5d, 0xfc, 0x0c, 0x99, 0xe5, 0x4a, 0x25, 0xd9, 0x86, 0x9c, 0x8e, 0x7f,
+	0x77, 0x6d, 0xe2, 0x63, 0xcd, 0x96, 0x1d, 0x56, 0x4a, 0x5a, 0x03, 0xfc, 0x0a, 0xdd, 0x81, 0x4d,
+	0x89, 0x38, 0x92, 0xa3, 0x49, 0x28, 0xd5, 0x28, 0x0d, 0xd5, 0x8c, 0xec, 0xc0, 0x9f, 0xb3, 0x02,
+	0x07, 0x12, 0xee, 0xff, 0x47, 0x5e, 0xd9, 0xf1, 0x4e, 0x45, 0xbe, 0x00, 0xee, 0x2d, 0x50, 0xb8,
+	0x8b, 0xac, 0x54, 0xc4, 0x5c, 0xc4, 0x6a, 0x41, 0x6e, 0x43, 0x8d, 0x2d, 0xba, 0xf2, 0x0f, 0xad,
+	0xcf, 0x67, 0x4e, 0xe3, 0xfc, 0xcc, 0x31, 0xf6, 0x8e, 0x90, 0x7d, 0x6d, 0x09, 0xc5, 0x85, 0xcc,
+	0xb8, 0x54, 0xfa, 0xe8, 0xe0, 0x42, 0x0a, 0x8c, 0x77, 0x51, 0x33, 0xe5, 0x42, 0xe9, 0xab, 0xb1,
+	0x03, 0x0b, 0x5a, 0xd1, 0x3e, 0xd5, 0xdf, 0xa0, 0xff, 0xe7, 0x7b, 0xcf, 0x38, 0xff, 0xd1, 0x33,
+	0xbe, 0x82, 0x5d, 0x80, 0x5d, 0x82, 0x7d, 0x03, 0xfb, 0xf2, 0xb3, 0xd7, 0xf8, 0xb0, 0x91, 0xfb,
+	0xe3, 0x96, 0xbe, 0xef, 0xe1, 0x5f, 0x5f, 0x0c, 0x89, 0x67, 0xc1, 0x03, 0x00, 0x00,
 }
 
 func (this *Handler) Equal(that interface{}) bool {
@@ -270,6 +282,12 @@ func (this *Handler) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.MetricsFastPath != that1.MetricsFastPath {
+		return false
+	}
+	if this.Priority != that1.Priority {
+		return false
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -319,6 +337,8 @@ type HandlerFace interface {
 	GetEnvVars() []string
 	GetRuntimeAssets() []string
 	GetSecrets() []*Secret
+	GetMetricsFastPath() bool
+	GetPriority() int32
 }
 
 func (this *Handler) Proto() github_com_golang_protobuf_proto.Message {
@@ -373,6 +393,14 @@ func (this *Handler) GetSecrets() []*Secret {
 	return this.Secrets
 }
 
+func (this *Handler) GetMetricsFastPath() bool {
+	return this.MetricsFastPath
+}
+
+func (this *Handler) GetPriority() int32 {
+	return this.Priority
+}
+
 func NewHandlerFromFace(that HandlerFace) *Handler {
 	this := &Handler{}
 	this.ObjectMeta = that.GetObjectMeta()
@@ -386,6 +414,8 @@ func NewHandlerFromFace(that HandlerFace) *Handler {
 	this.EnvVars = that.GetEnvVars()
 	this.RuntimeAssets = that.GetRuntimeAssets()
 	this.Secrets = that.GetSecrets()
+	this.MetricsFastPath = that.GetMetricsFastPath()
+	this.Priority = that.GetPriority()
 	return this
 }
 
@@ -413,6 +443,23 @@ func (m *Handler) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Priority != 0 {
+		i = encodeVarintHandler(dAtA, i, uint64(m.Priority))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.MetricsFastPath {
+		i--
+		if m.MetricsFastPath {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x78
+	}
 	if len(m.Secrets) > 0 {
 		for iNdEx := len(m.Secrets) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -602,8 +649,13 @@ func NewPopulatedHandler(r randyHandler, easy bool) *Handler {
 			this.Secrets[i] = NewPopulatedSecret(r, easy)
 		}
 	}
+	this.MetricsFastPath = bool(bool(r.Intn(2) == 0))
+	this.Priority = int32(r.Int31())
+	if r.Intn(2) == 0 {
+		this.Priority *= -1
+	}
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedHandler(r, 15)
+		this.XXX_unrecognized = randUnrecognizedHandler(r, 17)
 	}
 	return this
 }
@@ -747,6 +799,12 @@ func (m *Handler) Size() (n int) {
 			n += 1 + l + sovHandler(uint64(l))
 		}
 	}
+	if m.MetricsFastPath {
+		n += 2
+	}
+	if m.Priority != 0 {
+		n += 2 + sovHandler(uint64(m.Priority))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1153,6 +1211,45 @@ func (m *Handler) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MetricsFastPath", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.MetricsFastPath = bool(v != 0)
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHandler(dAtA[iNdEx:])
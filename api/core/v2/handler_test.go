@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -77,6 +78,28 @@ func TestHandlerValidate(t *testing.T) {
 				Type: "set",
 			},
 		},
+		{
+			Handler: Handler{
+				ObjectMeta: ObjectMeta{
+					Name:      "foo",
+					Namespace: "default",
+				},
+				Type:            "set",
+				MetricsFastPath: true,
+			},
+		},
+		{
+			Handler: Handler{
+				ObjectMeta: ObjectMeta{
+					Name:      "foo",
+					Namespace: "default",
+				},
+				Type:            "pipe",
+				Command:         "sl",
+				MetricsFastPath: true,
+			},
+			Error: "metrics_fast_path is only supported by set handlers",
+		},
 		{
 			Handler: Handler{
 				ObjectMeta: ObjectMeta{
@@ -184,6 +207,30 @@ func TestHandlerValidate(t *testing.T) {
 			},
 			Error: "missing command",
 		},
+		{
+			Handler: Handler{
+				ObjectMeta: ObjectMeta{
+					Name:        "foo",
+					Namespace:   "default",
+					Annotations: map[string]string{HandlerConcurrencyAnnotation: "none"},
+				},
+				Type:    "pipe",
+				Command: "sl",
+			},
+			Error: `sensu.io/handler_concurrency annotation must be a positive integer, got "none"`,
+		},
+		{
+			Handler: Handler{
+				ObjectMeta: ObjectMeta{
+					Name:        "foo",
+					Namespace:   "default",
+					Annotations: map[string]string{HandlerShortCircuitAnnotation: "maybe"},
+				},
+				Type:    "pipe",
+				Command: "sl",
+			},
+			Error: `sensu.io/handler_short_circuit annotation must be a boolean, got "maybe"`,
+		},
 	}
 
 	for i, test := range tests {
@@ -201,6 +248,88 @@ func TestHandlerValidate(t *testing.T) {
 	}
 }
 
+func TestHandlerConcurrency(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{value: "1", want: 1},
+		{value: "8", want: 8},
+		{value: "0", wantErr: true},
+		{value: "-1", wantErr: true},
+		{value: "many", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := HandlerConcurrency(map[string]string{HandlerConcurrencyAnnotation: tt.value})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("HandlerConcurrency() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("HandlerConcurrency() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	limit, err := HandlerConcurrency(nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, limit)
+}
+
+func TestHandlerRetry(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        HandlerRetryPolicy
+		wantErr     bool
+	}{
+		{
+			name: "no policy",
+			want: HandlerRetryPolicy{Backoff: DefaultHandlerRetryBackoff},
+		},
+		{
+			name: "full policy",
+			annotations: map[string]string{
+				HandlerRetriesAnnotation:      "3",
+				HandlerRetryBackoffAnnotation: "5s",
+				HandlerDeadLetterAnnotation:   "dead-letters",
+			},
+			want: HandlerRetryPolicy{Retries: 3, Backoff: 5 * time.Second, DeadLetter: "dead-letters"},
+		},
+		{
+			name:        "invalid retries",
+			annotations: map[string]string{HandlerRetriesAnnotation: "0"},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid backoff",
+			annotations: map[string]string{HandlerRetryBackoffAnnotation: "soon"},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid dead-letter handler",
+			annotations: map[string]string{HandlerDeadLetterAnnotation: ""},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HandlerRetry(tt.annotations)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("HandlerRetry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+
+	handler := FixtureHandler("handler1")
+	handler.Annotations = map[string]string{HandlerDeadLetterAnnotation: "handler1"}
+	assert.Error(t, handler.Validate())
+}
+
 func TestSortHandlersByName(t *testing.T) {
 	a := FixtureHandler("Abernathy")
 	b := FixtureHandler("Bernard")
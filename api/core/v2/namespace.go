@@ -30,6 +30,11 @@ func (n *Namespace) Validate() error {
 	if err := ValidateName(n.Name); err != nil {
 		return fmt.Errorf("namespace name %s", err)
 	}
+	for _, handler := range n.DefaultHandlers {
+		if err := ValidateName(handler); err != nil {
+			return fmt.Errorf("default handler name %s", err)
+		}
+	}
 
 	return nil
 }
@@ -27,7 +27,10 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 // Namespace represents a virtual cluster
 type Namespace struct {
 	// Name is the unique identifier for a namespace.
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// DefaultHandlers are the handlers applied to every event of the
+	// namespace, in addition to the handlers of their check.
+	DefaultHandlers      []string `protobuf:"bytes,2,rep,name=default_handlers,json=defaultHandlers,proto3" json:"default_handlers,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -73,6 +76,13 @@ func (m *Namespace) GetName() string {
 	return ""
 }
 
+func (m *Namespace) GetDefaultHandlers() []string {
+	if m != nil {
+		return m.DefaultHandlers
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Namespace)(nil), "sensu.core.v2.Namespace")
 }
@@ -82,18 +92,20 @@ func init() {
 }
 
 var fileDescriptor_0a0fa14fb06c2a7b = []byte{
-	// 176 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x32, 0x4d, 0xcf, 0x2c, 0xc9,
+	// 196 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe3, 0x32, 0x4d, 0xcf, 0x2c, 0xc9,
 	0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0xd5, 0x2f, 0x4e, 0xcd, 0x2b, 0x2e, 0x85, 0x90, 0xba, 0xe9,
 	0xf9, 0xfa, 0x89, 0x05, 0x99, 0xfa, 0xc9, 0xf9, 0x45, 0xa9, 0xfa, 0x65, 0x46, 0xfa, 0x79, 0x89,
 	0xb9, 0xa9, 0xc5, 0x05, 0x89, 0xc9, 0xa9, 0x7a, 0x05, 0x45, 0xf9, 0x25, 0xf9, 0x42, 0xbc, 0x60,
-	0x55, 0x7a, 0x20, 0x69, 0xbd, 0x32, 0x23, 0x29, 0x13, 0x24, 0x53, 0xd2, 0xf3, 0xd3, 0xf3, 0xf5,
-	0xc1, 0xaa, 0x92, 0x4a, 0xd3, 0x1c, 0xca, 0x0c, 0xf5, 0x8c, 0xf5, 0x0c, 0xc1, 0x82, 0x60, 0x31,
-	0x30, 0x0b, 0x62, 0x88, 0x92, 0x3c, 0x17, 0xa7, 0x1f, 0xcc, 0x5c, 0x21, 0x21, 0x2e, 0x16, 0x90,
-	0x25, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x9c, 0x41, 0x60, 0xb6, 0x93, 0xc2, 0x8f, 0x87, 0x72, 0x8c,
-	0x2b, 0x1e, 0xc9, 0x31, 0xee, 0x78, 0x24, 0xc7, 0x78, 0xe2, 0x91, 0x1c, 0xe3, 0x85, 0x47, 0x72,
-	0x8c, 0x0f, 0x1e, 0xc9, 0x31, 0xce, 0x78, 0x2c, 0xc7, 0x10, 0xc5, 0x54, 0x66, 0x94, 0xc4, 0x06,
-	0x36, 0xc9, 0x18, 0x10, 0x00, 0x00, 0xff, 0xff, 0xc1, 0xe7, 0x76, 0x13, 0xc7, 0x00, 0x00, 0x00,
+	0x55, 0x7a, 0x20, 0x69, 0xbd, 0x32, 0x23, 0x29, 0x13, 0x24, 0x53, 0xd2, 0xf3, 0x81, 0x7a, 0xc1,
+	0xaa, 0x92, 0x4a, 0xd3, 0x1c, 0xca, 0x0c, 0xf5, 0x8c, 0xf5, 0x0c, 0xc1, 0x82, 0x60, 0x31, 0x30,
+	0x0b, 0x62, 0x88, 0x92, 0x17, 0x17, 0xa7, 0x1f, 0xcc, 0x5c, 0x21, 0x21, 0x2e, 0x16, 0x90, 0x25,
+	0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x9c, 0x41, 0x60, 0xb6, 0x90, 0x26, 0x97, 0x40, 0x4a, 0x6a, 0x5a,
+	0x62, 0x69, 0x4e, 0x49, 0x7c, 0x46, 0x62, 0x5e, 0x4a, 0x4e, 0x6a, 0x51, 0xb1, 0x04, 0x93, 0x02,
+	0x33, 0x50, 0x9e, 0x1f, 0x2a, 0xee, 0x01, 0x15, 0x76, 0x52, 0xf8, 0xf1, 0x50, 0x8e, 0x71, 0xc5,
+	0x23, 0x39, 0xc6, 0x1d, 0x40, 0x7c, 0x02, 0x88, 0x2f, 0x00, 0xf1, 0x03, 0x20, 0x9e, 0xf1, 0x58,
+	0x8e, 0x21, 0x8a, 0xa9, 0xcc, 0x28, 0x89, 0x0d, 0x6c, 0xa9, 0x31, 0x00, 0x4c, 0x80, 0x54, 0x87,
+	0xf2, 0x00, 0x00, 0x00,
 }
 
 func (this *Namespace) Equal(that interface{}) bool {
@@ -118,6 +130,14 @@ func (this *Namespace) Equal(that interface{}) bool {
 	if this.Name != that1.Name {
 		return false
 	}
+	if len(this.DefaultHandlers) != len(that1.DefaultHandlers) {
+		return false
+	}
+	for i := range this.DefaultHandlers {
+		if this.DefaultHandlers[i] != that1.DefaultHandlers[i] {
+			return false
+		}
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -147,6 +167,15 @@ func (m *Namespace) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.DefaultHandlers) > 0 {
+		for iNdEx := len(m.DefaultHandlers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.DefaultHandlers[iNdEx])
+			copy(dAtA[i:], m.DefaultHandlers[iNdEx])
+			i = encodeVarintNamespace(dAtA, i, uint64(len(m.DefaultHandlers[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
 	if len(m.Name) > 0 {
 		i -= len(m.Name)
 		copy(dAtA[i:], m.Name)
@@ -171,8 +200,13 @@ func encodeVarintNamespace(dAtA []byte, offset int, v uint64) int {
 func NewPopulatedNamespace(r randyNamespace, easy bool) *Namespace {
 	this := &Namespace{}
 	this.Name = string(randStringNamespace(r))
+	v1 := r.Intn(10)
+	this.DefaultHandlers = make([]string, v1)
+	for i := 0; i < v1; i++ {
+		this.DefaultHandlers[i] = string(randStringNamespace(r))
+	}
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedNamespace(r, 2)
+		this.XXX_unrecognized = randUnrecognizedNamespace(r, 3)
 	}
 	return this
 }
@@ -259,6 +293,12 @@ func (m *Namespace) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovNamespace(uint64(l))
 	}
+	if len(m.DefaultHandlers) > 0 {
+		for _, s := range m.DefaultHandlers {
+			l = len(s)
+			n += 1 + l + sovNamespace(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -332,6 +372,38 @@ func (m *Namespace) Unmarshal(dAtA []byte) error {
 			}
 			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DefaultHandlers", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DefaultHandlers = append(m.DefaultHandlers, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNamespace(dAtA[iNdEx:])
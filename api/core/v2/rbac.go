@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"regexp"
 	"strings"
+	"sync"
 
 	stringsutil "github.com/sensu/sensu-go/api/core/v2/internal/stringutil"
 )
@@ -161,6 +163,11 @@ func (r *ClusterRole) Validate() error {
 		if err := validateVerbs(r.Rules[i].Verbs); err != nil {
 			return err
 		}
+
+		// Validate the resource name patterns
+		if err := validateResourceNames(r.Rules[i]); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -232,6 +239,11 @@ func (r *Role) Validate() error {
 		if err := validateVerbs(r.Rules[i].Verbs); err != nil {
 			return err
 		}
+
+		// Validate the resource name patterns
+		if err := validateResourceNames(r.Rules[i]); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -329,14 +341,26 @@ func (r Rule) ResourceMatches(requestedResource string) bool {
 }
 
 // ResourceNameMatches returns whether the specified requestedResourceName
-// matches any of the rule resources
+// matches any of the rule resources. When ResourceNamesRegex is set, the
+// resource names are patterns that must match the whole requested name.
 func (r Rule) ResourceNameMatches(requestedResourceName string) bool {
 	if len(r.ResourceNames) == 0 {
 		return true
 	}
 
 	for _, name := range r.ResourceNames {
-		if name == requestedResourceName {
+		if !r.ResourceNamesRegex {
+			if name == requestedResourceName {
+				return true
+			}
+			continue
+		}
+		re, err := resourceNameRegexp(name)
+		if err != nil {
+			// Invalid patterns are rejected by Validate, and never match
+			continue
+		}
+		if re.MatchString(requestedResourceName) {
 			return true
 		}
 	}
@@ -344,6 +368,38 @@ func (r Rule) ResourceNameMatches(requestedResourceName string) bool {
 	return false
 }
 
+// resourceNameRegexps caches the compiled resource name patterns, since rules
+// are evaluated for every authorized request.
+var resourceNameRegexps sync.Map
+
+// resourceNameRegexp compiles a resource name pattern, anchored so that it
+// must match the whole resource name.
+func resourceNameRegexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := resourceNameRegexps.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, err
+	}
+	resourceNameRegexps.Store(pattern, re)
+	return re, nil
+}
+
+// validateResourceNames checks that the resource names of a rule are valid
+// patterns, when the rule uses patterns.
+func validateResourceNames(rule Rule) error {
+	if !rule.ResourceNamesRegex {
+		return nil
+	}
+	for _, name := range rule.ResourceNames {
+		if _, err := resourceNameRegexp(name); err != nil {
+			return fmt.Errorf("invalid resource name pattern %q: %s", name, err)
+		}
+	}
+	return nil
+}
+
 // VerbMatches returns whether the specified requestedVerb matches any of the
 // rule verbs
 func (r Rule) VerbMatches(requestedVerb string) bool {
@@ -38,7 +38,10 @@ type Rule struct {
 	Resources []string `protobuf:"bytes,2,rep,name=resources,proto3" json:"resources"`
 	// ResourceNames is an optional list of resource names that the rule applies
 	// to.
-	ResourceNames        []string `protobuf:"bytes,3,rep,name=resource_names,json=resourceNames,proto3" json:"resource_names"`
+	ResourceNames []string `protobuf:"bytes,3,rep,name=resource_names,json=resourceNames,proto3" json:"resource_names"`
+	// ResourceNamesRegex, when true, makes the resource names regular
+	// expressions that must match the whole resource name.
+	ResourceNamesRegex   bool     `protobuf:"varint,4,opt,name=resource_names_regex,json=resourceNamesRegex,proto3" json:"resource_names_regex,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -98,6 +101,13 @@ func (m *Rule) GetResourceNames() []string {
 	return nil
 }
 
+func (m *Rule) GetResourceNamesRegex() bool {
+	if m != nil {
+		return m.ResourceNamesRegex
+	}
+	return false
+}
+
 // ClusterRole applies to all namespaces within a cluster.
 type ClusterRole struct {
 	Rules []Rule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules"`
@@ -408,38 +418,40 @@ func init() {
 }
 
 var fileDescriptor_69cb4f8fc3d151bb = []byte{
-	// 495 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x54, 0xbd, 0x8e, 0xd3, 0x40,
-	0x10, 0xce, 0xe6, 0x87, 0x4b, 0x36, 0x0a, 0x42, 0x8b, 0x84, 0xcc, 0x09, 0x79, 0xa3, 0x54, 0x91,
-	0x00, 0x9b, 0xf3, 0x51, 0x00, 0x15, 0xf2, 0x41, 0x09, 0x48, 0x8b, 0x68, 0x68, 0x4e, 0xb6, 0x6f,
-	0x62, 0x8c, 0xec, 0x6c, 0xb4, 0x5e, 0x5b, 0xba, 0x8e, 0x92, 0x8e, 0x96, 0xf2, 0xe8, 0xf2, 0x08,
-	0x3c, 0xc2, 0x95, 0xf7, 0x04, 0x16, 0x98, 0xce, 0x4f, 0x00, 0x1d, 0xda, 0x75, 0x9c, 0x1c, 0xa1,
-	0x41, 0x82, 0x14, 0x34, 0xeb, 0x9d, 0x99, 0x6f, 0xbe, 0xfd, 0xe6, 0x1b, 0xc9, 0xf8, 0x5e, 0x18,
-	0xc9, 0x37, 0x99, 0x6f, 0x05, 0x3c, 0xb1, 0x53, 0x98, 0xa7, 0x59, 0x7d, 0xde, 0x0d, 0xb9, 0xed,
-	0x2d, 0x22, 0x3b, 0xe0, 0x02, 0xec, 0xdc, 0xb1, 0x85, 0xef, 0x05, 0xd6, 0x42, 0x70, 0xc9, 0xc9,
-	0x48, 0x03, 0x2c, 0x55, 0xb1, 0x72, 0x67, 0xff, 0xfe, 0x25, 0x82, 0x90, 0x87, 0xdc, 0xd6, 0x28,
-	0x3f, 0x9b, 0x3d, 0xce, 0x0f, 0xac, 0x43, 0xeb, 0x40, 0x27, 0x75, 0x4e, 0xdf, 0x6a, 0x92, 0xfd,
-	0x3f, 0x7c, 0x36, 0x01, 0xe9, 0xd5, 0x1d, 0x93, 0x0f, 0x08, 0x77, 0x59, 0x16, 0x03, 0xa1, 0xb8,
-	0x97, 0x83, 0xf0, 0x53, 0x03, 0x8d, 0x3b, 0xd3, 0x81, 0x3b, 0xa8, 0x0a, 0x5a, 0x27, 0x58, 0xfd,
-	0x21, 0xb7, 0xf1, 0x40, 0x40, 0xca, 0x33, 0x11, 0x40, 0x6a, 0xb4, 0x35, 0x68, 0x54, 0x15, 0x74,
-	0x93, 0x64, 0x9b, 0x2b, 0x79, 0x88, 0xaf, 0x36, 0xc1, 0xf1, 0xdc, 0x4b, 0x20, 0x35, 0x3a, 0xba,
-	0x83, 0x54, 0x05, 0xdd, 0xaa, 0xb0, 0x51, 0x13, 0x3f, 0x57, 0xe1, 0x64, 0x89, 0xf0, 0xf0, 0x28,
-	0xce, 0x52, 0x09, 0x82, 0xf1, 0x18, 0xc8, 0x03, 0xdc, 0x13, 0x59, 0x0c, 0xb5, 0xb0, 0xa1, 0x73,
-	0xdd, 0xfa, 0xc5, 0x28, 0x4b, 0x89, 0x77, 0x47, 0xe7, 0x05, 0x6d, 0x29, 0xc5, 0x1a, 0xc9, 0xea,
-	0x0f, 0x79, 0x85, 0xfb, 0x6a, 0xd2, 0x13, 0x4f, 0x7a, 0x46, 0x67, 0x8c, 0xa6, 0x43, 0xe7, 0xe6,
-	0x56, 0xf3, 0x0b, 0xff, 0x2d, 0x04, 0xf2, 0x19, 0x48, 0xcf, 0x35, 0x15, 0xc5, 0x45, 0x41, 0x51,
-	0x55, 0x50, 0xd2, 0xb4, 0xdd, 0xe1, 0x49, 0x24, 0x21, 0x59, 0xc8, 0x53, 0xb6, 0xa6, 0x7a, 0xd4,
-	0x7f, 0x7f, 0x46, 0x5b, 0xcb, 0x33, 0x8a, 0x26, 0x9f, 0x94, 0x79, 0xff, 0x4e, 0x63, 0x77, 0x17,
-	0x1a, 0x9f, 0xe2, 0x3d, 0x25, 0x91, 0xc1, 0x8c, 0xdc, 0xc2, 0x5d, 0x79, 0xba, 0x00, 0x03, 0x8d,
-	0xd1, 0x74, 0xe0, 0xf6, 0xab, 0x82, 0xea, 0x98, 0xe9, 0x53, 0x55, 0xd5, 0x3e, 0x8c, 0xf6, 0xa6,
-	0xaa, 0x62, 0xa6, 0x4f, 0x45, 0xf3, 0x32, 0xd3, 0x4a, 0xfe, 0x8a, 0xe6, 0x5d, 0x1b, 0x93, 0x4b,
-	0xcb, 0x75, 0xa3, 0xf9, 0x49, 0x34, 0x0f, 0xc9, 0x13, 0xdc, 0x4f, 0x6b, 0xf6, 0xc6, 0xc2, 0x1b,
-	0x5b, 0x2e, 0xac, 0x1e, 0x77, 0xaf, 0xad, 0x5c, 0x5c, 0xe3, 0xd9, 0xfa, 0x46, 0x8e, 0x70, 0x5f,
-	0xf0, 0x18, 0x8e, 0x05, 0xcc, 0xf4, 0xf3, 0xbf, 0xb3, 0xac, 0x9c, 0xd8, 0xb0, 0x34, 0x78, 0xb6,
-	0x27, 0x56, 0x26, 0xed, 0x7c, 0x21, 0x3f, 0x10, 0x1e, 0xfe, 0x07, 0xb3, 0xf7, 0x76, 0x30, 0xbb,
-	0x3b, 0xfe, 0xfe, 0xd5, 0x44, 0xcb, 0xd2, 0x44, 0x9f, 0x4b, 0x13, 0x9d, 0x97, 0x26, 0xba, 0x28,
-	0x4d, 0xf4, 0xa5, 0x34, 0xd1, 0xc7, 0x6f, 0x66, 0xeb, 0x75, 0x3b, 0x77, 0xfc, 0x2b, 0xfa, 0xb7,
-	0x74, 0xf8, 0x33, 0x00, 0x00, 0xff, 0xff, 0x88, 0xee, 0x60, 0x1d, 0x41, 0x05, 0x00, 0x00,
+	// 514 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd5, 0x54, 0xcd, 0x4e, 0xdb, 0x40,
+	0x10, 0xce, 0x86, 0xa4, 0x38, 0x8b, 0x52, 0x55, 0x5b, 0x54, 0xa5, 0x08, 0xd9, 0x51, 0x4e, 0x48,
+	0xb4, 0x76, 0x09, 0x1c, 0xda, 0x9e, 0x2a, 0xd3, 0x1e, 0xa1, 0xd2, 0x16, 0x2e, 0x5c, 0x90, 0x6d,
+	0x36, 0xae, 0x11, 0xce, 0x46, 0xeb, 0xb5, 0x05, 0x37, 0x8e, 0x7d, 0x84, 0x1e, 0xe9, 0x2d, 0x8f,
+	0xd0, 0x47, 0xe0, 0xc8, 0x13, 0x44, 0xfd, 0x51, 0x2f, 0x7d, 0x82, 0xf6, 0xc6, 0xec, 0x3a, 0xce,
+	0x5f, 0x7b, 0x40, 0x82, 0x1c, 0x38, 0xcc, 0x7a, 0x67, 0xe7, 0x9b, 0x6f, 0x67, 0xbe, 0x59, 0x19,
+	0xbf, 0x08, 0x23, 0xf9, 0x31, 0xf5, 0xed, 0x80, 0xc7, 0x4e, 0xc2, 0xba, 0x49, 0x9a, 0xaf, 0xcf,
+	0x43, 0xee, 0x78, 0xbd, 0xc8, 0x09, 0xb8, 0x60, 0x4e, 0xd6, 0x76, 0x84, 0xef, 0x05, 0x76, 0x4f,
+	0x70, 0xc9, 0x49, 0x5d, 0x03, 0x6c, 0x15, 0xb1, 0xb3, 0xf6, 0xca, 0xd6, 0x04, 0x41, 0xc8, 0x21,
+	0x4d, 0xa3, 0xfc, 0xb4, 0xf3, 0x26, 0xdb, 0xb0, 0x37, 0xed, 0x0d, 0x7d, 0xa8, 0xcf, 0xf4, 0x2e,
+	0x27, 0x59, 0xb9, 0xe1, 0xb5, 0x31, 0x93, 0x5e, 0x9e, 0xd1, 0xfa, 0x85, 0x70, 0x85, 0xa6, 0x27,
+	0x8c, 0x58, 0xb8, 0x9a, 0x31, 0xe1, 0x27, 0x0d, 0xd4, 0x5c, 0x58, 0xab, 0xb9, 0xb5, 0xdf, 0x03,
+	0x2b, 0x3f, 0xa0, 0xf9, 0x87, 0xac, 0xe3, 0x9a, 0x60, 0x09, 0x4f, 0x45, 0xc0, 0x92, 0x46, 0x59,
+	0x83, 0xea, 0x00, 0x1a, 0x1f, 0xd2, 0xf1, 0x96, 0xbc, 0xc2, 0x0f, 0x0b, 0xe7, 0xb0, 0xeb, 0xc5,
+	0x90, 0xb1, 0xa0, 0x33, 0x08, 0x64, 0xcc, 0x44, 0x68, 0xbd, 0xf0, 0x77, 0x95, 0x4b, 0xf6, 0xf0,
+	0xf2, 0x34, 0xe0, 0x50, 0xb0, 0x90, 0x9d, 0x36, 0x2a, 0x4d, 0xb4, 0x66, 0xb8, 0x2d, 0x20, 0x30,
+	0xff, 0x17, 0x7f, 0xc6, 0xe3, 0x48, 0xb2, 0xb8, 0x27, 0xcf, 0x28, 0x99, 0x22, 0xa4, 0x2a, 0xda,
+	0xea, 0x23, 0xbc, 0xb4, 0x7d, 0x92, 0x26, 0x92, 0x09, 0xca, 0xa1, 0xdd, 0x97, 0xb8, 0x2a, 0xa0,
+	0xed, 0xbc, 0xdd, 0xa5, 0xf6, 0x63, 0x7b, 0x4a, 0x7e, 0x5b, 0x49, 0xe2, 0xd6, 0x2f, 0x07, 0x56,
+	0x49, 0xe9, 0xa0, 0x91, 0x34, 0xff, 0x90, 0x7d, 0x6c, 0x28, 0xfd, 0x8e, 0x3c, 0xe9, 0x41, 0x53,
+	0x08, 0x92, 0x9f, 0xce, 0x24, 0xbf, 0xf7, 0x8f, 0x59, 0x20, 0x77, 0x00, 0xe4, 0x9a, 0x8a, 0xe2,
+	0x6a, 0x60, 0x21, 0xa0, 0x21, 0x45, 0xda, 0x44, 0xa9, 0x23, 0xaa, 0xd7, 0xc6, 0xa7, 0x0b, 0xab,
+	0xd4, 0xbf, 0xb0, 0x50, 0xeb, 0x8b, 0x1a, 0xc9, 0xdd, 0xd5, 0x58, 0x99, 0x47, 0x8d, 0xef, 0xf0,
+	0xa2, 0x2a, 0x91, 0xb2, 0x0e, 0x59, 0xc5, 0x15, 0x79, 0xd6, 0x63, 0x50, 0x24, 0x82, 0x01, 0x1b,
+	0x40, 0xa2, 0x7d, 0xaa, 0x57, 0x15, 0x55, 0x43, 0x82, 0x07, 0x33, 0x8a, 0x2a, 0x9f, 0xea, 0x55,
+	0xd1, 0x7c, 0x48, 0x75, 0x25, 0xb7, 0xa2, 0x39, 0x2f, 0x63, 0x32, 0x31, 0x5c, 0x37, 0xea, 0x1e,
+	0x45, 0xdd, 0x90, 0xbc, 0xc5, 0x46, 0x92, 0xb3, 0x17, 0x12, 0x3e, 0x99, 0x51, 0x61, 0x78, 0xb9,
+	0xfb, 0x68, 0xa8, 0xe2, 0x08, 0x4f, 0x47, 0x3b, 0xb2, 0x8d, 0x0d, 0x01, 0xa4, 0xf0, 0xca, 0x3a,
+	0xfa, 0xfa, 0x7f, 0x59, 0x86, 0x4a, 0x8c, 0x59, 0x0a, 0x3c, 0x5d, 0x14, 0x43, 0x91, 0xe6, 0x3e,
+	0x90, 0xbf, 0xf0, 0xbe, 0xef, 0x41, 0xef, 0xd5, 0x39, 0xf4, 0xee, 0x36, 0xff, 0x7c, 0x37, 0x51,
+	0xff, 0x87, 0x89, 0xbe, 0x82, 0x5d, 0x82, 0x5d, 0x81, 0x7d, 0x03, 0xfb, 0xfc, 0xd3, 0x2c, 0x1d,
+	0x94, 0xb3, 0xb6, 0xff, 0x40, 0xff, 0xec, 0x36, 0xaf, 0x01, 0xc6, 0x33, 0x9d, 0x66, 0x97, 0x05,
+	0x00, 0x00,
 }
 
 func (this *Rule) Equal(that interface{}) bool {
@@ -485,6 +497,9 @@ func (this *Rule) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.ResourceNamesRegex != that1.ResourceNamesRegex {
+		return false
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -849,6 +864,16 @@ func (m *Rule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.ResourceNamesRegex {
+		i--
+		if m.ResourceNamesRegex {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.ResourceNames) > 0 {
 		for iNdEx := len(m.ResourceNames) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.ResourceNames[iNdEx])
@@ -1213,8 +1238,9 @@ func NewPopulatedRule(r randyRbac, easy bool) *Rule {
 	for i := 0; i < v3; i++ {
 		this.ResourceNames[i] = string(randStringRbac(r))
 	}
+	this.ResourceNamesRegex = bool(bool(r.Intn(2) == 0))
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedRbac(r, 4)
+		this.XXX_unrecognized = randUnrecognizedRbac(r, 5)
 	}
 	return this
 }
@@ -1411,6 +1437,9 @@ func (m *Rule) Size() (n int) {
 			n += 1 + l + sovRbac(uint64(l))
 		}
 	}
+	if m.ResourceNamesRegex {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1672,6 +1701,26 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 			}
 			m.ResourceNames = append(m.ResourceNames, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceNamesRegex", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRbac
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ResourceNamesRegex = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRbac(dAtA[iNdEx:])
@@ -52,6 +52,7 @@ func TestRuleResourceNameMatches(t *testing.T) {
 	tests := []struct {
 		name                  string
 		resourceNames         []string
+		regex                 bool
 		requestedResourceName string
 		want                  bool
 	}{
@@ -77,11 +78,39 @@ func TestRuleResourceNameMatches(t *testing.T) {
 			requestedResourceName: "bar",
 			want:                  true,
 		},
+		{
+			name:                  "pattern is exact without regex",
+			resourceNames:         []string{"web-.*"},
+			requestedResourceName: "web-01",
+			want:                  false,
+		},
+		{
+			name:                  "regex matches",
+			resourceNames:         []string{"db-.*", "web-.*"},
+			regex:                 true,
+			requestedResourceName: "web-01",
+			want:                  true,
+		},
+		{
+			name:                  "regex must match the whole name",
+			resourceNames:         []string{"web-.*"},
+			regex:                 true,
+			requestedResourceName: "old-web-01",
+			want:                  false,
+		},
+		{
+			name:                  "invalid regex does not match",
+			resourceNames:         []string{"web-("},
+			regex:                 true,
+			requestedResourceName: "web-(",
+			want:                  false,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			r := Rule{
-				ResourceNames: tc.resourceNames,
+				ResourceNames:      tc.resourceNames,
+				ResourceNamesRegex: tc.regex,
 			}
 			if got := r.ResourceNameMatches(tc.requestedResourceName); got != tc.want {
 				t.Errorf("Rule.ResourceNameMatches() = %v, want %v", got, tc.want)
@@ -90,6 +119,22 @@ func TestRuleResourceNameMatches(t *testing.T) {
 	}
 }
 
+func TestRoleValidateResourceNamesRegex(t *testing.T) {
+	role := FixtureRole("web", "default")
+	role.Rules[0].ResourceNames = []string{"web-("}
+	if err := role.Validate(); err != nil {
+		t.Fatalf("exact resource names must not be parsed as patterns: %s", err)
+	}
+	role.Rules[0].ResourceNamesRegex = true
+	if err := role.Validate(); err == nil {
+		t.Fatal("expected an error for an invalid pattern")
+	}
+	role.Rules[0].ResourceNames = []string{"web-.*"}
+	if err := role.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestRuleVerbMatches(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -214,3 +214,102 @@ func (s *Silenced) SetObjectMeta(meta ObjectMeta) {
 func (*Silenced) RBACName() string {
 	return "silenced"
 }
+
+// SilencedSelector selects the subscriptions and checks to silence in bulk.
+// It is expanded into one silenced entry per subscription, per check carrying
+// all of CheckLabels, or per pair of both when both are given.
+type SilencedSelector struct {
+	// Subscriptions are the subscriptions to silence.
+	Subscriptions []string `json:"subscriptions,omitempty"`
+
+	// CheckLabels selects the checks to silence by their labels.
+	CheckLabels map[string]string `json:"check_labels,omitempty"`
+
+	// Expire, ExpireOnResolve, Reason and Begin are set on every silenced
+	// entry of the selector.
+	Expire          int64  `json:"expire"`
+	ExpireOnResolve bool   `json:"expire_on_resolve"`
+	Reason          string `json:"reason,omitempty"`
+	Begin           int64  `json:"begin"`
+}
+
+// Validate returns an error if the selector selects nothing, or selects
+// invalid subscriptions.
+func (s *SilencedSelector) Validate() error {
+	if len(s.Subscriptions) == 0 && len(s.CheckLabels) == 0 {
+		return errors.New("must provide subscriptions or check labels")
+	}
+	for _, sub := range s.Subscriptions {
+		if err := ValidateSubscriptionName(sub); err != nil {
+			return fmt.Errorf("Subscription %s", err)
+		}
+	}
+	for key := range s.CheckLabels {
+		if key == "" {
+			return errors.New("check label keys must not be empty")
+		}
+	}
+	return nil
+}
+
+// MatchesCheck returns true if the check carries all of the check labels of
+// the selector and, when subscriptions are selected, is subscribed to one of
+// them.
+func (s *SilencedSelector) MatchesCheck(check *CheckConfig) bool {
+	for key, value := range s.CheckLabels {
+		if v, ok := check.Labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	if len(s.Subscriptions) == 0 {
+		return true
+	}
+	for _, sub := range s.Subscriptions {
+		if stringsutil.InArray(sub, check.Subscriptions) {
+			return true
+		}
+	}
+	return false
+}
+
+// Expand returns the silenced entries of the selector in the given namespace.
+// The checks are only considered when the selector has check labels, in which
+// case no entry is returned if none of them match.
+func (s *SilencedSelector) Expand(namespace string, checks []*CheckConfig) []*Silenced {
+	var entries []*Silenced
+	newEntry := func(subscription, check string) *Silenced {
+		entry := &Silenced{
+			ObjectMeta:      NewObjectMeta("", namespace),
+			Subscription:    subscription,
+			Check:           check,
+			Expire:          s.Expire,
+			ExpireOnResolve: s.ExpireOnResolve,
+			Reason:          s.Reason,
+			Begin:           s.Begin,
+		}
+		entry.Name, _ = SilencedName(subscription, check)
+		return entry
+	}
+
+	if len(s.CheckLabels) == 0 {
+		for _, sub := range s.Subscriptions {
+			entries = append(entries, newEntry(sub, "*"))
+		}
+		return entries
+	}
+	for _, check := range checks {
+		if !s.MatchesCheck(check) {
+			continue
+		}
+		if len(s.Subscriptions) == 0 {
+			entries = append(entries, newEntry("*", check.Name))
+			continue
+		}
+		for _, sub := range s.Subscriptions {
+			if stringsutil.InArray(sub, check.Subscriptions) {
+				entries = append(entries, newEntry(sub, check.Name))
+			}
+		}
+	}
+	return entries
+}
@@ -201,3 +201,69 @@ func TestSilencedFields(t *testing.T) {
 		})
 	}
 }
+
+func TestSilencedSelectorValidate(t *testing.T) {
+	assert.Error(t, (&SilencedSelector{}).Validate())
+	assert.Error(t, (&SilencedSelector{Subscriptions: []string{"not valid"}}).Validate())
+	assert.Error(t, (&SilencedSelector{CheckLabels: map[string]string{"": "true"}}).Validate())
+	assert.NoError(t, (&SilencedSelector{Subscriptions: []string{"linux"}}).Validate())
+	assert.NoError(t, (&SilencedSelector{CheckLabels: map[string]string{"maintenance": "true"}}).Validate())
+}
+
+func TestSilencedSelectorExpand(t *testing.T) {
+	cpu := FixtureCheckConfig("cpu")
+	cpu.Subscriptions = []string{"linux", "windows"}
+	cpu.Labels = map[string]string{"maintenance": "true"}
+	disk := FixtureCheckConfig("disk")
+	disk.Subscriptions = []string{"linux"}
+	disk.Labels = map[string]string{"maintenance": "true"}
+	mem := FixtureCheckConfig("mem")
+	mem.Subscriptions = []string{"linux"}
+	checks := []*CheckConfig{cpu, disk, mem}
+
+	names := func(entries []*Silenced) []string {
+		result := []string{}
+		for _, entry := range entries {
+			assert.Equal(t, "default", entry.Namespace)
+			assert.Equal(t, int64(60), entry.Expire)
+			result = append(result, entry.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		selector SilencedSelector
+		want     []string
+	}{
+		{
+			name:     "subscriptions",
+			selector: SilencedSelector{Subscriptions: []string{"linux", "windows"}},
+			want:     []string{"linux:*", "windows:*"},
+		},
+		{
+			name:     "check labels",
+			selector: SilencedSelector{CheckLabels: map[string]string{"maintenance": "true"}},
+			want:     []string{"*:cpu", "*:disk"},
+		},
+		{
+			name: "subscriptions and check labels",
+			selector: SilencedSelector{
+				Subscriptions: []string{"windows"},
+				CheckLabels:   map[string]string{"maintenance": "true"},
+			},
+			want: []string{"windows:cpu"},
+		},
+		{
+			name:     "no match",
+			selector: SilencedSelector{CheckLabels: map[string]string{"maintenance": "false"}},
+			want:     []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.selector.Expire = 60
+			assert.Equal(t, tt.want, names(tt.selector.Expand("default", checks)))
+		})
+	}
+}
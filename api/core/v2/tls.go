@@ -5,6 +5,8 @@ import (
 	"crypto/x509"
 	"fmt"
 	"io/ioutil"
+	"path/filepath"
+	"strings"
 )
 
 var (
@@ -39,8 +41,8 @@ var (
 func (t *TLSOptions) ToServerTLSConfig() (*tls.Config, error) {
 	cfg := tls.Config{}
 
-	if t.GetTrustedCAFile() != "" {
-		caCertPool, err := LoadCACerts(t.TrustedCAFile)
+	if t.GetTrustedCAFile() != "" || t.GetTrustedCADir() != "" {
+		caCertPool, err := t.loadCACerts()
 		if err != nil {
 			return nil, err
 		}
@@ -81,8 +83,8 @@ func (t *TLSOptions) ToClientTLSConfig() (*tls.Config, error) {
 	cfg := tls.Config{}
 	cfg.InsecureSkipVerify = t.GetInsecureSkipVerify()
 
-	if t.GetTrustedCAFile() != "" {
-		caCertPool, err := LoadCACerts(t.TrustedCAFile)
+	if t.GetTrustedCAFile() != "" || t.GetTrustedCADir() != "" {
+		caCertPool, err := t.loadCACerts()
 		if err != nil {
 			return nil, err
 		}
@@ -121,3 +123,52 @@ func LoadCACerts(path string) (*x509.CertPool, error) {
 
 	return caCertPool, nil
 }
+
+// loadCACerts returns the pool of the CA certificates of TrustedCAFile and of
+// the files of TrustedCADir.
+func (t *TLSOptions) loadCACerts() (*x509.CertPool, error) {
+	caCertPool := x509.NewCertPool()
+	if t.GetTrustedCAFile() != "" {
+		var err error
+		caCertPool, err = LoadCACerts(t.TrustedCAFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if t.GetTrustedCADir() != "" {
+		if err := AppendCACertsFromDir(caCertPool, t.TrustedCADir); err != nil {
+			return nil, err
+		}
+	}
+	return caCertPool, nil
+}
+
+// AppendCACertsFromDir adds to the pool the certificates of the PEM files,
+// with the .pem or .crt extension, of a directory. It returns an error naming
+// the file that could not be parsed, or if the directory has no PEM file.
+func AppendCACertsFromDir(pool *x509.CertPool, dir string) error {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("Error reading CA directory: %s", err)
+	}
+	found := false
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".pem" && ext != ".crt") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		caCerts, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("Error reading CA file: %s", err)
+		}
+		if !pool.AppendCertsFromPEM(caCerts) {
+			return fmt.Errorf("No certificates could be parsed out of %s", path)
+		}
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("No CA certificate file (.pem or .crt) found in %s", dir)
+	}
+	return nil
+}
@@ -32,6 +32,7 @@ type TLSOptions struct {
 	TrustedCAFile        string   `protobuf:"bytes,3,opt,name=trusted_ca_file,json=trustedCaFile,proto3" json:"trusted_ca_file,omitempty"`
 	InsecureSkipVerify   bool     `protobuf:"varint,4,opt,name=insecure_skip_verify,json=insecureSkipVerify,proto3" json:"insecure_skip_verify"`
 	ClientAuthType       bool     `protobuf:"varint,5,opt,name=client_auth_type,json=clientAuthType,proto3" json:"client_auth_type,omitempty"`
+	TrustedCADir         string   `protobuf:"bytes,6,opt,name=trusted_ca_dir,json=trustedCaDir,proto3" json:"trusted_ca_dir,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -105,6 +106,13 @@ func (m *TLSOptions) GetClientAuthType() bool {
 	return false
 }
 
+func (m *TLSOptions) GetTrustedCADir() string {
+	if m != nil {
+		return m.TrustedCADir
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*TLSOptions)(nil), "sensu.core.v2.TLSOptions")
 }
@@ -114,28 +122,29 @@ func init() {
 }
 
 var fileDescriptor_132ffabeafc49c65 = []byte{
-	// 327 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x90, 0xbd, 0x4e, 0xf3, 0x30,
-	0x14, 0x40, 0x3f, 0xf7, 0xe3, 0xa7, 0xb5, 0x54, 0x7e, 0x22, 0x86, 0x00, 0x92, 0x5b, 0x31, 0x75,
-	0x21, 0x56, 0x5b, 0x16, 0x36, 0xda, 0x4a, 0x0c, 0x08, 0x09, 0x29, 0xad, 0x18, 0x58, 0xa2, 0x34,
-	0xdc, 0xa6, 0x56, 0x42, 0x6c, 0xc5, 0x76, 0xa4, 0xbc, 0x09, 0x8f, 0xc0, 0x23, 0xf0, 0x08, 0x8c,
-	0x3c, 0x41, 0x05, 0x61, 0x63, 0x47, 0x62, 0x44, 0xb1, 0x41, 0x62, 0x60, 0xb1, 0xac, 0x73, 0xce,
-	0xbd, 0xc3, 0xc5, 0x34, 0x66, 0x6a, 0xa9, 0xe7, 0x5e, 0xc4, 0xef, 0xa8, 0x84, 0x4c, 0x6a, 0xfb,
-	0x1e, 0xc7, 0x9c, 0x86, 0x82, 0xd1, 0x88, 0xe7, 0x40, 0x8b, 0x01, 0x55, 0xa9, 0xf4, 0x44, 0xce,
-	0x15, 0x77, 0xda, 0xc6, 0x7b, 0xb5, 0xf0, 0x8a, 0xc1, 0xc1, 0xc9, 0xaf, 0xf9, 0x98, 0xc7, 0x9c,
-	0x9a, 0x6a, 0xae, 0x17, 0x67, 0x45, 0xdf, 0x1b, 0x7a, 0x7d, 0x03, 0x0d, 0x33, 0x3f, 0xbb, 0xe4,
-	0xe8, 0x03, 0x61, 0x3c, 0xbb, 0x9c, 0x5e, 0x09, 0xc5, 0x78, 0x26, 0x9d, 0x43, 0xdc, 0x8a, 0x20,
-	0x57, 0xc1, 0x82, 0xa5, 0xe0, 0xa2, 0x2e, 0xea, 0xb5, 0xfc, 0x66, 0x0d, 0xce, 0x59, 0x0a, 0xce,
-	0x3e, 0x6e, 0x26, 0x50, 0x5a, 0xd7, 0x30, 0x6e, 0x33, 0x81, 0xd2, 0xa8, 0x53, 0xbc, 0xad, 0x72,
-	0x2d, 0x15, 0xdc, 0x06, 0x51, 0x68, 0x8b, 0xff, 0x75, 0x31, 0xde, 0xad, 0x56, 0x9d, 0xf6, 0xcc,
-	0xaa, 0xc9, 0xa8, 0x6e, 0xfd, 0xf6, 0x77, 0x39, 0x09, 0xcd, 0xe8, 0x05, 0xde, 0x63, 0x99, 0x84,
-	0x48, 0xe7, 0x10, 0xc8, 0x84, 0x89, 0xa0, 0x80, 0x9c, 0x2d, 0x4a, 0x77, 0xad, 0x8b, 0x7a, 0xcd,
-	0xb1, 0xfb, 0xbe, 0xea, 0xfc, 0xe9, 0x7d, 0xe7, 0x87, 0x4e, 0x13, 0x26, 0xae, 0x0d, 0x73, 0x7a,
-	0x78, 0x27, 0x4a, 0x19, 0x64, 0x2a, 0x08, 0xb5, 0x5a, 0x06, 0xaa, 0x14, 0xe0, 0xae, 0xd7, 0x7b,
-	0xfc, 0x2d, 0xcb, 0x47, 0x5a, 0x2d, 0x67, 0xa5, 0x80, 0x71, 0xf7, 0xf3, 0x95, 0xa0, 0x87, 0x8a,
-	0xa0, 0xc7, 0x8a, 0xa0, 0xa7, 0x8a, 0xa0, 0xe7, 0x8a, 0xa0, 0x97, 0x8a, 0xa0, 0xfb, 0x37, 0xf2,
-	0xef, 0xa6, 0x51, 0x0c, 0xe6, 0x1b, 0xe6, 0x40, 0xc3, 0xaf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x65,
-	0x79, 0x45, 0x58, 0x98, 0x01, 0x00, 0x00,
+	// 342 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe3, 0xd2, 0x4f, 0xcf, 0x2c, 0xc9,
+	0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0xd5, 0x2f, 0x4e, 0xcd, 0x2b, 0x2e, 0x85, 0x90, 0xba, 0xe9,
+	0xf9, 0xfa, 0x89, 0x05, 0x99, 0xfa, 0xc9, 0xf9, 0x45, 0xa9, 0xfa, 0x65, 0x46, 0xfa, 0x25, 0x39,
+	0xc5, 0x7a, 0x05, 0x45, 0xf9, 0x25, 0xf9, 0x42, 0xbc, 0x60, 0x79, 0x3d, 0x90, 0x84, 0x5e, 0x99,
+	0x91, 0x94, 0x09, 0x92, 0xfe, 0xf4, 0x7c, 0xa0, 0x2e, 0xb0, 0xaa, 0xa4, 0xd2, 0x34, 0x87, 0x32,
+	0x43, 0x3d, 0x63, 0x3d, 0x43, 0xb0, 0x20, 0x58, 0x0c, 0xcc, 0x82, 0x18, 0xa2, 0xb4, 0x96, 0x89,
+	0x8b, 0x2b, 0xc4, 0x27, 0xd8, 0xbf, 0xa0, 0x24, 0x33, 0x3f, 0xaf, 0x58, 0x48, 0x9a, 0x8b, 0x33,
+	0x39, 0xb5, 0xa8, 0x24, 0x3e, 0x2d, 0x33, 0x27, 0x55, 0x82, 0x51, 0x81, 0x51, 0x83, 0x33, 0x88,
+	0x03, 0x24, 0xe0, 0x06, 0xe4, 0x0b, 0x49, 0x72, 0x71, 0x64, 0xa7, 0x56, 0x42, 0xe4, 0x98, 0xc0,
+	0x72, 0xec, 0x40, 0x3e, 0x58, 0xca, 0x92, 0x8b, 0xbf, 0xa4, 0xa8, 0xb4, 0xb8, 0x24, 0x35, 0x25,
+	0x3e, 0x39, 0x11, 0xa2, 0x82, 0x19, 0xa4, 0xc2, 0x49, 0xf0, 0xd1, 0x3d, 0x79, 0xde, 0x10, 0x88,
+	0x94, 0xb3, 0x23, 0x48, 0x6d, 0x10, 0x2f, 0x54, 0xa5, 0x73, 0x22, 0x58, 0xab, 0x17, 0x97, 0x48,
+	0x66, 0x5e, 0x71, 0x6a, 0x72, 0x69, 0x51, 0x6a, 0x7c, 0x71, 0x76, 0x66, 0x41, 0x7c, 0x59, 0x6a,
+	0x51, 0x66, 0x5a, 0xa5, 0x04, 0x0b, 0x50, 0x3f, 0x87, 0x93, 0xc4, 0xab, 0x7b, 0xf2, 0x58, 0xe5,
+	0x83, 0x84, 0x60, 0xa2, 0xc1, 0x40, 0xc1, 0x30, 0xb0, 0x98, 0x90, 0x06, 0x97, 0x40, 0x72, 0x4e,
+	0x66, 0x6a, 0x5e, 0x49, 0x7c, 0x62, 0x69, 0x49, 0x46, 0x7c, 0x49, 0x65, 0x41, 0xaa, 0x04, 0x2b,
+	0xc8, 0x9c, 0x20, 0x3e, 0x88, 0xb8, 0x23, 0x50, 0x38, 0x04, 0x28, 0x2a, 0x64, 0xc6, 0xc5, 0x87,
+	0xe4, 0xe0, 0x94, 0xcc, 0x22, 0x09, 0x36, 0xb0, 0x7b, 0x05, 0x4e, 0xdd, 0x93, 0xe7, 0x81, 0xbb,
+	0xd7, 0x25, 0xb3, 0x28, 0x88, 0x07, 0xee, 0x5c, 0x20, 0xcf, 0x49, 0xe1, 0xc7, 0x43, 0x39, 0xc6,
+	0x15, 0x8f, 0xe4, 0x18, 0x77, 0x00, 0xf1, 0x09, 0x20, 0xbe, 0x00, 0xc4, 0x0f, 0x80, 0x78, 0xc6,
+	0x63, 0x39, 0x86, 0x28, 0xa6, 0x32, 0xa3, 0x24, 0x36, 0x70, 0xc0, 0x1a, 0x03, 0x00, 0xf7, 0x8b,
+	0xbf, 0x46, 0xd0, 0x01, 0x00, 0x00,
 }
 
 func (this *TLSOptions) Equal(that interface{}) bool {
@@ -172,6 +181,9 @@ func (this *TLSOptions) Equal(that interface{}) bool {
 	if this.ClientAuthType != that1.ClientAuthType {
 		return false
 	}
+	if this.TrustedCADir != that1.TrustedCADir {
+		return false
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -201,6 +213,13 @@ func (m *TLSOptions) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.TrustedCADir) > 0 {
+		i -= len(m.TrustedCADir)
+		copy(dAtA[i:], m.TrustedCADir)
+		i = encodeVarintTls(dAtA, i, uint64(len(m.TrustedCADir)))
+		i--
+		dAtA[i] = 0x32
+	}
 	if m.ClientAuthType {
 		i--
 		if m.ClientAuthType {
@@ -263,8 +282,9 @@ func NewPopulatedTLSOptions(r randyTls, easy bool) *TLSOptions {
 	this.TrustedCAFile = string(randStringTls(r))
 	this.InsecureSkipVerify = bool(bool(r.Intn(2) == 0))
 	this.ClientAuthType = bool(bool(r.Intn(2) == 0))
+	this.TrustedCADir = string(randStringTls(r))
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTls(r, 6)
+		this.XXX_unrecognized = randUnrecognizedTls(r, 7)
 	}
 	return this
 }
@@ -365,6 +385,10 @@ func (m *TLSOptions) Size() (n int) {
 	if m.ClientAuthType {
 		n += 2
 	}
+	l = len(m.TrustedCADir)
+	if l > 0 {
+		n += 1 + l + sovTls(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -542,6 +566,38 @@ func (m *TLSOptions) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.ClientAuthType = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TrustedCADir", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTls
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTls
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTls
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TrustedCADir = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTls(dAtA[iNdEx:])
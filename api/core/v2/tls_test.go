@@ -0,0 +1,99 @@
+package v2
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeTestCACert(t *testing.T, path, name string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAppendCACertsFromDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	pool := x509.NewCertPool()
+	if err := AppendCACertsFromDir(pool, dir); err == nil {
+		t.Error("expected an error for a directory without certificates")
+	}
+
+	writeTestCACert(t, filepath.Join(dir, "root1.pem"), "root1")
+	writeTestCACert(t, filepath.Join(dir, "root2.crt"), "root2")
+	if err := ioutil.WriteFile(filepath.Join(dir, "README"), []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendCACertsFromDir(pool, dir); err != nil {
+		t.Fatal(err)
+	}
+	//nolint:staticcheck // Subjects is only used to count the certificates
+	if got := len(pool.Subjects()); got != 2 {
+		t.Errorf("pool has %d certificates, want 2", got)
+	}
+
+	invalid := filepath.Join(dir, "invalid.pem")
+	if err := ioutil.WriteFile(invalid, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	err = AppendCACertsFromDir(x509.NewCertPool(), dir)
+	if err == nil || !strings.Contains(err.Error(), invalid) {
+		t.Errorf("expected an error naming %s, got %v", invalid, err)
+	}
+}
+
+func TestToClientTLSConfigTrustedCADir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeTestCACert(t, filepath.Join(dir, "root1.pem"), "root1")
+	writeTestCACert(t, filepath.Join(dir, "root2.pem"), "root2")
+	caFile := filepath.Join(dir, "bundle")
+	writeTestCACert(t, caFile, "root3")
+
+	opts := &TLSOptions{TrustedCAFile: caFile, TrustedCADir: dir}
+	cfg, err := opts.ToClientTLSConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	//nolint:staticcheck // Subjects is only used to count the certificates
+	if got := len(cfg.RootCAs.Subjects()); got != 3 {
+		t.Errorf("root CAs have %d certificates, want 3", got)
+	}
+}
@@ -175,8 +175,8 @@ func FixtureEntityState(name string) *EntityState {
 	}
 }
 
-// validate ensures that the entity's class is valid, and that the entity
-// is within a namespace.
+// validate ensures that the entity's class is valid, that the entity is
+// within a namespace, and that its round-robin weight is valid.
 func (e *EntityConfig) validate() error {
 	if err := corev2.ValidateName(e.EntityClass); err != nil {
 		return errors.New("entity class " + err.Error())
@@ -186,6 +186,10 @@ func (e *EntityConfig) validate() error {
 		return errors.New("namespace must be set")
 	}
 
+	if _, err := corev2.RoundRobinWeight(e.Metadata.Annotations); err != nil {
+		return err
+	}
+
 	return nil
 }
 
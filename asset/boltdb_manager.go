@@ -54,6 +54,9 @@ func init() {
 	if err := prometheus.Register(expandDuration); err != nil {
 		panic(metricspkg.FormatRegistrationErr(ExpandDuration, err))
 	}
+	if err := prometheus.Register(cacheSize); err != nil {
+		panic(metricspkg.FormatRegistrationErr(CacheSize, err))
+	}
 }
 
 // NewBoltDBGetter returns a new default asset Getter. If fetcher, verifier, or
@@ -66,6 +69,17 @@ func NewBoltDBGetter(db *bolt.DB,
 	expander Expander,
 	limiter *rate.Limiter) Getter {
 
+	return newBoltDBAssetManager(db, localStorage, trustedCAFile, fetcher, verifier, expander, limiter)
+}
+
+func newBoltDBAssetManager(db *bolt.DB,
+	localStorage string,
+	trustedCAFile string,
+	fetcher Fetcher,
+	verifier Verifier,
+	expander Expander,
+	limiter *rate.Limiter) *boltDBAssetManager {
+
 	if fetcher == nil {
 		fetcher = &httpFetcher{
 			Limiter:       limiter,
@@ -101,6 +115,7 @@ type boltDBAssetManager struct {
 	fetcher      Fetcher
 	expander     Expander
 	verifier     Verifier
+	cache        *assetCache
 }
 
 // Get opens a transaction to BoltDB, causing subsequent calls to
@@ -139,8 +154,10 @@ func (b *boltDBAssetManager) Get(ctx context.Context, asset *corev2.Asset) (*Run
 		return nil, err
 	}
 
-	// Check to see if the view was successful.
-	if localAsset != nil {
+	// Check to see if the view was successful, and that the asset was not
+	// evicted from the cache since.
+	if localAsset != nil && b.cache.use(asset.Sha512) {
+		b.cache.evict(asset.Sha512)
 		localAsset.Name = asset.Name
 		localAsset.SHA512 = asset.Sha512
 		return localAsset, nil
@@ -156,10 +173,14 @@ func (b *boltDBAssetManager) Get(ctx context.Context, asset *corev2.Asset) (*Run
 		// call completed installation of the asset while this transaction
 		// was blocked on serialization. Re-attempt to get the key in case that is
 		// what happened.
+		localAsset = nil
 		value := bucket.Get(key)
 		if value != nil {
 			// deserialize asset
 			if err := json.Unmarshal(value, &localAsset); err == nil {
+				if !b.cache.use(asset.Sha512) {
+					b.cache.add(asset.Sha512, localAsset.Path)
+				}
 				return nil
 			}
 		}
@@ -201,10 +222,15 @@ func (b *boltDBAssetManager) Get(ctx context.Context, asset *corev2.Asset) (*Run
 			panic(err)
 		}
 
-		return bucket.Put(key, assetJSON)
+		if err := bucket.Put(key, assetJSON); err != nil {
+			return err
+		}
+		b.cache.add(asset.Sha512, assetPath)
+		return nil
 	}); err != nil {
 		return nil, err
 	}
+	b.cache.evict(asset.Sha512)
 
 	if localAsset != nil {
 		localAsset.Name = asset.Name
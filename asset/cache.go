@@ -0,0 +1,209 @@
+package asset
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// CacheSize is the name of the prometheus gauge used to track the size of
+	// the installed assets, in bytes.
+	CacheSize = "sensu_go_asset_cache_size_bytes"
+)
+
+var cacheSize = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: CacheSize,
+		Help: "size of the installed assets in the asset cache, in bytes",
+	},
+)
+
+// cacheEntry is an installed asset, identified by its SHA512.
+type cacheEntry struct {
+	sha512   string
+	path     string
+	size     int64
+	lastUsed time.Time
+}
+
+// assetCache tracks the size of the installed assets and, if it has a maximum
+// size, uninstalls the least recently used assets once it is exceeded. The
+// assets that inUse reports are never uninstalled.
+//
+// The entries of the cache are added and removed in BoltDB write transactions,
+// so that an asset is never uninstalled while it is being installed.
+type assetCache struct {
+	mu      sync.Mutex
+	db      *bolt.DB
+	maxSize int64
+	size    int64
+	entries map[string]*cacheEntry
+	inUse   func(sha512 string) bool
+	now     func() time.Time
+}
+
+// newAssetCache returns the cache of the assets installed in db, which are not
+// limited in size if maxSize is 0.
+func newAssetCache(db *bolt.DB, maxSize int64, inUse func(sha512 string) bool) (*assetCache, error) {
+	if inUse == nil {
+		inUse = func(string) bool { return false }
+	}
+	c := &assetCache{
+		db:      db,
+		maxSize: maxSize,
+		entries: make(map[string]*cacheEntry),
+		inUse:   inUse,
+		now:     time.Now,
+	}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// load adds the installed assets to the cache. Their installation time is
+// used as the time they were last used.
+func (c *assetCache) load() error {
+	return c.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(assetBucketName)
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(key, value []byte) error {
+			var localAsset RuntimeAsset
+			if err := json.Unmarshal(value, &localAsset); err != nil {
+				return nil
+			}
+			c.add(string(key), localAsset.Path)
+			return nil
+		})
+	})
+}
+
+// add adds the asset installed at path to the cache, as used now.
+func (c *assetCache) add(sha512, path string) {
+	if c == nil {
+		return
+	}
+	entry := &cacheEntry{
+		sha512:   sha512,
+		path:     path,
+		size:     dirSize(path),
+		lastUsed: c.now(),
+	}
+	if info, err := os.Stat(path); err == nil && info.ModTime().Before(entry.lastUsed) {
+		entry.lastUsed = info.ModTime()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if previous, ok := c.entries[sha512]; ok {
+		c.size -= previous.size
+	}
+	c.entries[sha512] = entry
+	c.size += entry.size
+	cacheSize.Set(float64(c.size))
+}
+
+// use marks the asset as used now, and returns false if it is not in the
+// cache, i.e. it was uninstalled.
+func (c *assetCache) use(sha512 string) bool {
+	if c == nil {
+		return true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[sha512]
+	if ok {
+		entry.lastUsed = c.now()
+	}
+	return ok
+}
+
+// evict uninstalls the least recently used assets until the cache fits its
+// maximum size. The asset keep, which was just returned to a caller, and the
+// assets in use are not uninstalled.
+func (c *assetCache) evict(keep string) {
+	if c == nil || c.maxSize <= 0 {
+		return
+	}
+	c.mu.Lock()
+	exceeded := c.size > c.maxSize
+	c.mu.Unlock()
+	if !exceeded {
+		return
+	}
+
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(assetBucketName)
+		if bucket == nil {
+			return nil
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		defer func() { cacheSize.Set(float64(c.size)) }()
+		for c.size > c.maxSize {
+			entry := c.leastRecentlyUsed(keep)
+			if entry == nil {
+				logger.WithFields(logrus.Fields{
+					"size":     c.size,
+					"max_size": c.maxSize,
+				}).Warn("the asset cache exceeds its maximum size, but its assets are in use")
+				return nil
+			}
+			if err := bucket.Delete([]byte(entry.sha512)); err != nil {
+				return err
+			}
+			// the asset is reinstalled the next time it is used, even if its
+			// files could not all be removed
+			if err := os.RemoveAll(entry.path); err != nil {
+				logger.WithError(err).WithField("path", entry.path).Error("error removing evicted asset")
+			}
+			delete(c.entries, entry.sha512)
+			c.size -= entry.size
+			logger.WithFields(logrus.Fields{
+				"sha512": entry.sha512,
+				"size":   entry.size,
+			}).Info("evicted asset from the asset cache")
+		}
+		return nil
+	})
+	if err != nil {
+		logger.WithError(err).Error("error evicting assets from the asset cache")
+	}
+}
+
+// leastRecentlyUsed returns the least recently used asset that can be
+// uninstalled, or nil if there is none. c.mu must be held.
+func (c *assetCache) leastRecentlyUsed(keep string) *cacheEntry {
+	var lru *cacheEntry
+	for sha512, entry := range c.entries {
+		if sha512 == keep || c.inUse(sha512) {
+			continue
+		}
+		if lru == nil || entry.lastUsed.Before(lru.lastUsed) {
+			lru = entry
+		}
+	}
+	return lru
+}
+
+// dirSize returns the total size of the regular files under path.
+func dirSize(path string) int64 {
+	var size int64
+	_ = filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
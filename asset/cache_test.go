@@ -0,0 +1,109 @@
+package asset
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestAssetCacheEvict(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	db, err := bolt.Open(filepath.Join(dir, dbName), 0600, &bolt.Options{})
+	if err != nil {
+		t.Fatalf("unable to open boltdb in test: %v", err)
+	}
+	defer db.Close()
+
+	// install three assets of 10 bytes each
+	shas := []string{"a", "b", "c"}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(assetBucketName)
+		if err != nil {
+			return err
+		}
+		for _, sha := range shas {
+			path := filepath.Join(dir, sha)
+			if err := os.MkdirAll(filepath.Join(path, binDir), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(filepath.Join(path, binDir, "check"), make([]byte, 10), 0755); err != nil {
+				return err
+			}
+			value, err := json.Marshal(&RuntimeAsset{Path: path})
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put([]byte(sha), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("unable to install assets in test: %v", err)
+	}
+
+	inUse := map[string]bool{"b": true}
+	cache, err := newAssetCache(db, 25, func(sha string) bool { return inUse[sha] })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := cache.size, int64(30); got != want {
+		t.Fatalf("bad size: got %d, want %d", got, want)
+	}
+
+	// use the assets in order, a being the least recently used
+	now := time.Now()
+	for _, sha := range shas {
+		now = now.Add(time.Second)
+		cache.now = func() time.Time { return now }
+		if !cache.use(sha) {
+			t.Fatalf("asset %s is not in the cache", sha)
+		}
+	}
+
+	installed := func(sha string) bool {
+		var value []byte
+		_ = db.View(func(tx *bolt.Tx) error {
+			value = tx.Bucket(assetBucketName).Get([]byte(sha))
+			return nil
+		})
+		_, err := os.Stat(filepath.Join(dir, sha))
+		if (value != nil) != (err == nil) {
+			t.Fatalf("asset %s is in boltdb: %v, but its files exist: %v", sha, value != nil, err == nil)
+		}
+		return value != nil
+	}
+
+	cache.evict("")
+	if installed("a") || !installed("b") || !installed("c") {
+		t.Fatal("expected a to be evicted")
+	}
+	if got, want := cache.size, int64(20); got != want {
+		t.Fatalf("bad size: got %d, want %d", got, want)
+	}
+	if cache.use("a") {
+		t.Fatal("expected a to be out of the cache")
+	}
+
+	// b is in use and c is kept, nothing can be evicted
+	cache.maxSize = 5
+	cache.evict("c")
+	if !installed("b") || !installed("c") {
+		t.Fatal("expected b and c to be kept")
+	}
+
+	delete(inUse, "b")
+	cache.evict("")
+	if installed("b") || installed("c") {
+		t.Fatal("expected b and c to be evicted")
+	}
+	if got, want := cache.size, int64(0); got != want {
+		t.Fatalf("bad size: got %d, want %d", got, want)
+	}
+}
@@ -22,6 +22,8 @@ type Manager struct {
 	entity        *types.Entity
 	wg            *sync.WaitGroup
 	trustedCAFile string
+	maxCacheSize  int64
+	inUse         func(sha512 string) bool
 }
 
 // NewManager ...
@@ -34,6 +36,15 @@ func NewManager(cacheDir, trustedCAFile string, entity *types.Entity, wg *sync.W
 	}
 }
 
+// LimitCacheSize sets the maximum size, in bytes, of the assets installed in
+// the cache directory. Beyond it, the least recently used assets are
+// uninstalled, except those inUse reports, such as the assets of the checks
+// being executed. The size of the cache is not limited if maxSize is 0.
+func (m *Manager) LimitCacheSize(maxSize int64, inUse func(sha512 string) bool) {
+	m.maxCacheSize = maxSize
+	m.inUse = inUse
+}
+
 // StartAssetManager starts the asset manager for a backend or agent.
 func (m *Manager) StartAssetManager(ctx context.Context, limiter *rate.Limiter) (Getter, error) {
 	// create agent cache directory if it doesn't already exist
@@ -56,8 +67,12 @@ func (m *Manager) StartAssetManager(ctx context.Context, limiter *rate.Limiter)
 			logger.Debug(err)
 		}
 	}()
-	boltDBGetter := NewBoltDBGetter(
+	boltDBGetter := newBoltDBAssetManager(
 		db, m.cacheDir, m.trustedCAFile, nil, nil, nil, limiter)
+	boltDBGetter.cache, err = newAssetCache(db, m.maxCacheSize, m.inUse)
+	if err != nil {
+		return nil, err
+	}
 
 	return NewFilteredManager(boltDBGetter, m.entity), nil
 }
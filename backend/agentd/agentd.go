@@ -48,6 +48,10 @@ var (
 
 const (
 	WebsocketUpgradeDuration = "sensu_go_websocket_upgrade_duration"
+
+	// DuplicateAgentCounterName is the name of the prometheus counter of the
+	// agents connecting with the name of an agent connected from another host.
+	DuplicateAgentCounterName = "sensu_go_agentd_duplicate_agents"
 )
 
 var (
@@ -59,6 +63,14 @@ var (
 		},
 		[]string{},
 	)
+
+	duplicateAgentCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: DuplicateAgentCounterName,
+			Help: "The total number of agents connecting with the name of an agent connected from another host",
+		},
+		[]string{"namespace"},
+	)
 )
 
 func init() {
@@ -74,6 +86,12 @@ func init() {
 	if err := prometheus.Register(eventBytesSummary); err != nil {
 		metrics.LogError(logger, EventBytesSummaryName, err)
 	}
+	if err := prometheus.Register(firstMessageHistogram); err != nil {
+		metrics.LogError(logger, FirstMessageHistogramName, err)
+	}
+	if err := prometheus.Register(duplicateAgentCounter); err != nil {
+		metrics.LogError(logger, DuplicateAgentCounterName, err)
+	}
 }
 
 // Agentd is the backend HTTP API.
@@ -84,24 +102,33 @@ type Agentd struct {
 	// Port is the port Agentd is running on.
 	Port int
 
-	stopping            chan struct{}
-	running             *atomic.Value
-	wg                  *sync.WaitGroup
-	errChan             chan error
-	httpServer          *http.Server
-	store               store.Store
-	storev2             storev2.Interface
-	bus                 messaging.MessageBus
-	tls                 *corev2.TLSOptions
-	ringPool            *ringv2.RingPool
-	ctx                 context.Context
-	cancel              context.CancelFunc
-	writeTimeout        int
-	namespaceCache      *cache.Resource
-	watcher             <-chan store.WatchEventEntityConfig
-	client              *clientv3.Client
-	etcdClientTLSConfig *tls.Config
-	healthRouter        *routers.HealthRouter
+	stopping             chan struct{}
+	running              *atomic.Value
+	wg                   *sync.WaitGroup
+	errChan              chan error
+	httpServer           *http.Server
+	store                store.Store
+	storev2              storev2.Interface
+	bus                  messaging.MessageBus
+	tls                  *corev2.TLSOptions
+	ringPool             *ringv2.RingPool
+	ctx                  context.Context
+	cancel               context.CancelFunc
+	writeTimeout         int
+	namespaceCache       *cache.Resource
+	watcher              <-chan store.WatchEventEntityConfig
+	client               *clientv3.Client
+	etcdClientTLSConfig  *tls.Config
+	healthRouter         *routers.HealthRouter
+	allowedNetworks      []*net.IPNet
+	upgrader             *websocket.Upgrader
+	agents               agentRegistry
+	rejectDuplicates     bool
+	subscriptionsWarning int
+	maxSubscriptions     int
+	backendName          string
+	maxEventSize         int
+	metadataSizeLimit    int
 }
 
 // Config configures an Agentd.
@@ -116,6 +143,41 @@ type Config struct {
 	Client              *clientv3.Client
 	EtcdClientTLSConfig *tls.Config
 	Watcher             <-chan store.WatchEventEntityConfig
+
+	// AllowedNetworks restricts the networks agents can connect from. Agents
+	// can connect from any network if empty.
+	AllowedNetworks []*net.IPNet
+
+	// Compression negotiates per-message compression of the websocket
+	// traffic with the agents that support it.
+	Compression bool
+
+	// RejectDuplicates rejects the sessions of agents connecting from a host
+	// while an agent with the same name is connected from another host.
+	RejectDuplicates bool
+
+	// SubscriptionsWarning is the number of subscriptions above which the
+	// agents are logged and their entity annotated with a warning when they
+	// connect. There is no warning if zero.
+	SubscriptionsWarning int
+
+	// MaxSubscriptions rejects the sessions of agents connecting with more
+	// subscriptions. There is no limit if zero.
+	MaxSubscriptions int
+
+	// BackendName identifies the backend in the entities of the agents
+	// connected to it, with the corev2.BackendAnnotation annotation.
+	BackendName string
+
+	// MaxEventSize is the maximum size, in bytes, of the event messages
+	// received from the agents. Larger events are dropped before being
+	// decoded. Events are not limited if 0.
+	MaxEventSize int
+
+	// MetadataSizeLimit is the maximum total size, in bytes, of the labels and
+	// annotations of the entities registered by the agents. It is disabled
+	// when 0.
+	MetadataSizeLimit int
 }
 
 // Option is a functional option.
@@ -125,23 +187,36 @@ type Option func(*Agentd) error
 func New(c Config, opts ...Option) (*Agentd, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	a := &Agentd{
-		Host:                c.Host,
-		Port:                c.Port,
-		bus:                 c.Bus,
-		store:               c.Store,
-		tls:                 c.TLS,
-		stopping:            make(chan struct{}, 1),
-		running:             &atomic.Value{},
-		wg:                  &sync.WaitGroup{},
-		errChan:             make(chan error, 1),
-		ringPool:            c.RingPool,
-		ctx:                 ctx,
-		cancel:              cancel,
-		writeTimeout:        c.WriteTimeout,
-		storev2:             etcdstore.NewStore(c.Client),
-		watcher:             c.Watcher,
-		client:              c.Client,
-		etcdClientTLSConfig: c.EtcdClientTLSConfig,
+		Host:                 c.Host,
+		Port:                 c.Port,
+		bus:                  c.Bus,
+		store:                c.Store,
+		tls:                  c.TLS,
+		stopping:             make(chan struct{}, 1),
+		running:              &atomic.Value{},
+		wg:                   &sync.WaitGroup{},
+		errChan:              make(chan error, 1),
+		ringPool:             c.RingPool,
+		ctx:                  ctx,
+		cancel:               cancel,
+		writeTimeout:         c.WriteTimeout,
+		storev2:              etcdstore.NewStore(c.Client),
+		watcher:              c.Watcher,
+		client:               c.Client,
+		etcdClientTLSConfig:  c.EtcdClientTLSConfig,
+		allowedNetworks:      c.AllowedNetworks,
+		upgrader:             upgrader,
+		rejectDuplicates:     c.RejectDuplicates,
+		subscriptionsWarning: c.SubscriptionsWarning,
+		maxSubscriptions:     c.MaxSubscriptions,
+		backendName:          c.BackendName,
+		maxEventSize:         c.MaxEventSize,
+		metadataSizeLimit:    c.MetadataSizeLimit,
+	}
+	if c.Compression {
+		compressing := *upgrader
+		compressing.EnableCompression = true
+		a.upgrader = &compressing
 	}
 
 	// prepare server TLS config
@@ -210,6 +285,9 @@ func (a *Agentd) Start() error {
 	if err != nil {
 		return fmt.Errorf("failed to start agentd: %s", err)
 	}
+	if len(a.allowedNetworks) > 0 {
+		ln = &allowListener{Listener: ln, allowed: a.allowedNetworks}
+	}
 
 	a.wg.Add(1)
 
@@ -354,8 +432,39 @@ func (a *Agentd) webSocketHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	conn, err := upgrader.Upgrade(w, r, responseHeader)
+	// Detect the hosts misconfigured with the name of an agent connected from
+	// another host, which would otherwise overwrite each other's entity
+	release, duplicate := a.agents.register(namespace, r.Header.Get(transport.HeaderKeyAgentName), r.RemoteAddr)
+	if duplicate != "" {
+		duplicateAgentCounter.WithLabelValues(namespace).Inc()
+		lager = lager.WithField("duplicate_host", duplicate)
+		if a.rejectDuplicates {
+			release()
+			lager.Warning("rejecting agent, an agent with the same name is connected from another host")
+			http.Error(w, "an agent with the same name is connected from another host", http.StatusConflict)
+			return
+		}
+		lager.Warning("an agent with the same name is connected from another host")
+	}
+
+	// Warn about, or reject, the agents with runaway subscriptions
+	agentName := r.Header.Get(transport.HeaderKeyAgentName)
+	subscriptions := strings.Split(r.Header.Get(transport.HeaderKeySubscriptions), ",")
+	subscriptionsCount := countSubscriptions(agentName, subscriptions)
+	subscriptionsWarning, err := a.checkSubscriptions(subscriptionsCount)
+	if err != nil {
+		release()
+		lager.WithField("subscriptions", subscriptionsCount).WithError(err).Warning("rejecting agent")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if subscriptionsWarning != "" {
+		lager.WithField("subscriptions", subscriptionsCount).Warning(subscriptionsWarning)
+	}
+
+	conn, err := a.upgrader.Upgrade(w, r, responseHeader)
 	if err != nil {
+		release()
 		lager.WithError(err).Error("transport error on websocket upgrade")
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -363,10 +472,10 @@ func (a *Agentd) webSocketHandler(w http.ResponseWriter, r *http.Request) {
 
 	cfg := SessionConfig{
 		AgentAddr:      r.RemoteAddr,
-		AgentName:      r.Header.Get(transport.HeaderKeyAgentName),
+		AgentName:      agentName,
 		Namespace:      r.Header.Get(transport.HeaderKeyNamespace),
 		User:           r.Header.Get(transport.HeaderKeyUser),
-		Subscriptions:  strings.Split(r.Header.Get(transport.HeaderKeySubscriptions), ","),
+		Subscriptions:  subscriptions,
 		RingPool:       a.ringPool,
 		ContentType:    contentType,
 		WriteTimeout:   a.writeTimeout,
@@ -377,12 +486,18 @@ func (a *Agentd) webSocketHandler(w http.ResponseWriter, r *http.Request) {
 		Marshal:        marshal,
 		Unmarshal:      unmarshal,
 		BurialReceiver: NewBurialReceiver(),
+
+		SubscriptionsWarning: subscriptionsWarning,
+		BackendName:          a.backendName,
+		MaxEventSize:         a.maxEventSize,
+		MetadataSizeLimit:    a.metadataSizeLimit,
 	}
 
 	cfg.Subscriptions = corev2.AddEntitySubscription(cfg.AgentName, cfg.Subscriptions)
 
 	session, err := NewSession(a.ctx, cfg)
 	if err != nil {
+		release()
 		lager.WithError(err).Error("failed to create session")
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		// There was an error retrieving the namespace from
@@ -396,6 +511,10 @@ func (a *Agentd) webSocketHandler(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
+	go func() {
+		<-session.ctx.Done()
+		release()
+	}()
 
 	if err := session.Start(); err != nil {
 		lager.WithError(err).Error("failed to start session")
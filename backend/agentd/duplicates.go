@@ -0,0 +1,67 @@
+package agentd
+
+import (
+	"net"
+	"path"
+	"sync"
+)
+
+// agentRegistry tracks the hosts the live agent sessions of this backend are
+// connected from, by namespace and agent name, to detect the hosts sharing an
+// agent name.
+type agentRegistry struct {
+	mu       sync.Mutex
+	nextID   uint64
+	sessions map[string]map[uint64]string
+}
+
+// register records a session of the agent connected from addr. It returns the
+// function releasing the session, and the host of a live session of the same
+// agent connected from another host, if any. An agent reconnecting from the
+// same host before its previous session ended is not a duplicate.
+func (r *agentRegistry) register(namespace, name, addr string) (release func(), duplicate string) {
+	key := path.Join(namespace, name)
+	host := agentHost(addr)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.sessions == nil {
+		r.sessions = make(map[string]map[uint64]string)
+	}
+	hosts, ok := r.sessions[key]
+	if !ok {
+		hosts = make(map[uint64]string)
+		r.sessions[key] = hosts
+	}
+	for _, h := range hosts {
+		if h != host {
+			duplicate = h
+			break
+		}
+	}
+	r.nextID++
+	id := r.nextID
+	hosts[id] = host
+
+	var once sync.Once
+	release = func() {
+		once.Do(func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			delete(hosts, id)
+			if len(hosts) == 0 {
+				delete(r.sessions, key)
+			}
+		})
+	}
+	return release, duplicate
+}
+
+// agentHost returns the host of the address, without its port.
+func agentHost(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
@@ -0,0 +1,39 @@
+package agentd
+
+import (
+	"testing"
+)
+
+func TestAgentRegistry(t *testing.T) {
+	var registry agentRegistry
+
+	releaseA, duplicate := registry.register("default", "foo", "10.0.0.1:5000")
+	if duplicate != "" {
+		t.Fatalf("unexpected duplicate %q", duplicate)
+	}
+
+	// An agent reconnecting from the same host is not a duplicate
+	releaseB, duplicate := registry.register("default", "foo", "10.0.0.1:5001")
+	if duplicate != "" {
+		t.Fatalf("unexpected duplicate %q", duplicate)
+	}
+
+	// Agents of other namespaces or names are not duplicates
+	releaseC, duplicate := registry.register("acme", "foo", "10.0.0.2:5000")
+	if duplicate != "" {
+		t.Fatalf("unexpected duplicate %q", duplicate)
+	}
+	releaseC()
+
+	_, duplicate = registry.register("default", "foo", "10.0.0.2:5000")
+	if got, want := duplicate, "10.0.0.1"; got != want {
+		t.Fatalf("bad duplicate: got %q, want %q", got, want)
+	}
+
+	releaseA()
+	releaseB()
+	releaseB()
+	if got, want := len(registry.sessions["default/foo"]), 1; got != want {
+		t.Fatalf("bad number of sessions: got %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,70 @@
+package agentd
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ParseCIDRs parses the networks agents are allowed to connect from.
+func ParseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid agent CIDR %q: %s", cidr, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// allowListener is a net.Listener that only accepts the connections coming
+// from the allowed networks, closing the other ones before any data is read
+// from them.
+type allowListener struct {
+	net.Listener
+	allowed []*net.IPNet
+}
+
+// Accept waits for and returns the next connection from an allowed network.
+func (l *allowListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		if l.allows(conn.RemoteAddr()) {
+			return conn, nil
+		}
+		logger.WithField("source", conn.RemoteAddr().String()).Warn("rejecting agent connection from a network that is not allowed")
+		_ = conn.Close()
+	}
+}
+
+func (l *allowListener) allows(addr net.Addr) bool {
+	var ip net.IP
+	switch addr := addr.(type) {
+	case *net.TCPAddr:
+		ip = addr.IP
+	default:
+		host, _, err := net.SplitHostPort(addr.String())
+		if err != nil {
+			return false
+		}
+		ip = net.ParseIP(host)
+	}
+	if ip == nil {
+		return false
+	}
+	for _, network := range l.allowed {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
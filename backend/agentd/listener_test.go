@@ -0,0 +1,75 @@
+package agentd
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCIDRs(t *testing.T) {
+	networks, err := ParseCIDRs([]string{"10.0.0.0/8", " 2001:db8::/32", ""})
+	require.NoError(t, err)
+	require.Len(t, networks, 2)
+	assert.Equal(t, "10.0.0.0/8", networks[0].String())
+	assert.Equal(t, "2001:db8::/32", networks[1].String())
+
+	_, err = ParseCIDRs([]string{"10.0.0.1"})
+	assert.Error(t, err)
+}
+
+func TestAllowListenerAllows(t *testing.T) {
+	networks, err := ParseCIDRs([]string{"10.0.0.0/8", "2001:db8::/32"})
+	require.NoError(t, err)
+	l := &allowListener{allowed: networks}
+
+	tests := []struct {
+		addr net.Addr
+		want bool
+	}{
+		{addr: &net.TCPAddr{IP: net.ParseIP("10.1.2.3"), Port: 1234}, want: true},
+		{addr: &net.TCPAddr{IP: net.ParseIP("192.168.1.1"), Port: 1234}, want: false},
+		{addr: &net.TCPAddr{IP: net.ParseIP("::ffff:10.1.2.3"), Port: 1234}, want: true},
+		{addr: &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 1234}, want: true},
+		{addr: &net.UnixAddr{Name: "/tmp/agentd.sock", Net: "unix"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, l.allows(tt.addr))
+		})
+	}
+}
+
+func TestAllowListenerAccept(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	networks, err := ParseCIDRs([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	l := &allowListener{Listener: ln, allowed: networks}
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err == nil {
+			accepted <- conn
+		}
+	}()
+
+	// The connection from the loopback network is closed by the listener
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = conn.Read(make([]byte, 1))
+	assert.Error(t, err)
+
+	select {
+	case <-accepted:
+		t.Fatal("connection should have been rejected")
+	default:
+	}
+}
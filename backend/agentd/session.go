@@ -14,6 +14,8 @@ import (
 	"github.com/sensu/sensu-go/agent"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/apid/handlers"
+	"github.com/sensu/sensu-go/backend/eventd"
 	"github.com/sensu/sensu-go/backend/messaging"
 	"github.com/sensu/sensu-go/backend/metrics"
 	"github.com/sensu/sensu-go/backend/ringv2"
@@ -46,6 +48,15 @@ const (
 	// EventBytesSummaryHelp is the help message for EventBytesSummary
 	// Prometheus metrics.
 	EventBytesSummaryHelp = "Distribution of event sizes, in bytes, received by agentd on this backend"
+
+	// FirstMessageHistogramName is the name of the prometheus histogram vec
+	// used to track the time from the start of agent sessions to their first
+	// event or keepalive.
+	FirstMessageHistogramName = "sensu_go_agent_session_first_message_seconds"
+
+	// messageTypeLabelName is the name of the label for the type of the first
+	// message of a session, either event or keepalive.
+	messageTypeLabelName = "type"
 )
 
 var (
@@ -65,6 +76,14 @@ var (
 		},
 		[]string{"op", "error"},
 	)
+	firstMessageHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    FirstMessageHistogramName,
+			Help:    "Time, in seconds, from the start of agent sessions on this backend to their first event or keepalive",
+			Buckets: []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+		},
+		[]string{messageTypeLabelName},
+	)
 	sessionErrorCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: sessionErrorCounterName,
@@ -97,6 +116,11 @@ type Session struct {
 	entityConfig     *entityConfig
 	mu               sync.Mutex
 	subscriptionsMap map[string]subscription
+
+	// connectedAt is the time the session was established, and
+	// firstMessage records the delay until its first event or keepalive.
+	connectedAt  time.Time
+	firstMessage sync.Once
 }
 
 // subscription is used to abstract a message.Subscription and therefore allow
@@ -149,6 +173,22 @@ type SessionConfig struct {
 	// with the session has been buried. Necessary when running parallel keepalived
 	// workers.
 	BurialReceiver *BurialReceiver
+
+	// SubscriptionsWarning is added as an annotation to the entity of the
+	// agent when set, if it registered with too many subscriptions.
+	SubscriptionsWarning string
+
+	// BackendName is added as an annotation to the entity of the agent when
+	// set, to record the backend handling the session.
+	BackendName string
+
+	// MaxEventSize is the maximum size, in bytes, of the event messages
+	// received from the agent. There is no limit if zero.
+	MaxEventSize int
+
+	// MetadataSizeLimit is the maximum total size, in bytes, of the labels and
+	// annotations of the entity of the agent. There is no limit if zero.
+	MetadataSizeLimit int
 }
 
 type BurialReceiver struct {
@@ -188,6 +228,7 @@ func NewSession(ctx context.Context, cfg SessionConfig) (*Session, error) {
 		storev2:          cfg.Storev2,
 		bus:              cfg.Bus,
 		subscriptionsMap: map[string]subscription{},
+		connectedAt:      time.Now(),
 		ctx:              ctx,
 		cancel:           cancel,
 		ringPool:         cfg.RingPool,
@@ -524,6 +565,15 @@ func (s *Session) Start() (err error) {
 			return err
 		}
 
+		// Keep the annotations of agentd up to date in the stored entity
+		// config, before it gets modified for the agent
+		if s.annotateEntityConfig(&storedEntityConfig) {
+			if err := s.updateEntityConfig(&storedEntityConfig); err != nil {
+				sessionErrorCounter.WithLabelValues(err.Error()).Inc()
+				lager.WithError(err).Error("could not update the entity config annotations")
+			}
+		}
+
 		// Remove the managed_by label if the value is sensu-agent, in case the
 		// entity is no longer managed by its agent
 		if storedEntityConfig.Metadata.Labels[corev2.ManagedByLabel] == "sensu-agent" {
@@ -561,6 +611,16 @@ func (s *Session) Start() (err error) {
 	return nil
 }
 
+// updateEntityConfig writes the entity config of the agent to the store.
+func (s *Session) updateEntityConfig(config *corev3.EntityConfig) error {
+	req := storev2.NewResourceRequestFromResource(s.ctx, config)
+	wrapper, err := storev2.WrapResource(config)
+	if err != nil {
+		return err
+	}
+	return s.storev2.CreateOrUpdate(req, wrapper)
+}
+
 // Stop a running session. This will cause the send and receive loops to
 // shutdown. Blocks until the session has shutdown.
 func (s *Session) Stop() {
@@ -623,11 +683,26 @@ func (s *Session) handleKeepalive(_ context.Context, payload []byte) error {
 		return errors.New("keepalive contains invalid timestamp")
 	}
 
+	// The entity of the agent is registered from its keepalives
+	if err := handlers.ValidateMetadataSize(keepalive.Entity.ObjectMeta, s.cfg.MetadataSizeLimit); err != nil {
+		return err
+	}
+
 	keepalive.Entity.Subscriptions = corev2.AddEntitySubscription(keepalive.Entity.Name, keepalive.Entity.Subscriptions)
+	s.annotateEntity(keepalive.Entity)
+	s.observeFirstMessage("keepalive")
 
 	return s.bus.Publish(messaging.TopicKeepalive, keepalive)
 }
 
+// observeFirstMessage records the time elapsed since the session started, if
+// the message of type msgType is the first one received.
+func (s *Session) observeFirstMessage(msgType string) {
+	s.firstMessage.Do(func() {
+		firstMessageHistogram.WithLabelValues(msgType).Observe(time.Since(s.connectedAt).Seconds())
+	})
+}
+
 // handleEvent is the event message handler.
 func (s *Session) handleEvent(_ context.Context, payload []byte) error {
 	// Decode the payload to an event
@@ -636,6 +711,20 @@ func (s *Session) handleEvent(_ context.Context, payload []byte) error {
 		return err
 	}
 
+	// Drop the events too large to be processed, measured like eventd and the
+	// API do. The error is not returned, so that the event does not get logged.
+	if s.cfg.MaxEventSize > 0 {
+		if size := event.Size(); size > s.cfg.MaxEventSize {
+			eventd.EventsRejected.WithLabelValues(eventd.EventsRejectedReasonTooLarge).Inc()
+			logger.WithFields(logrus.Fields{
+				"agent":     s.cfg.AgentName,
+				"namespace": s.cfg.Namespace,
+				"size":      size,
+			}).Errorf("dropping event exceeding the maximum event size of %d bytes", s.cfg.MaxEventSize)
+			return nil
+		}
+	}
+
 	// Validate the received event
 	if err := event.Validate(); err != nil {
 		return err
@@ -644,6 +733,12 @@ func (s *Session) handleEvent(_ context.Context, payload []byte) error {
 	// Add the entity subscription to the subscriptions of this entity
 	event.Entity.Subscriptions = corev2.AddEntitySubscription(event.Entity.Name, event.Entity.Subscriptions)
 
+	msgType := "event"
+	if event.HasCheck() && event.Check.Name == corev2.KeepaliveCheckName {
+		msgType = "keepalive"
+	}
+	s.observeFirstMessage(msgType)
+
 	if event.HasCheck() {
 		if event.HasMetrics() {
 			eventBytesSummary.WithLabelValues(metrics.EventTypeLabelCheckAndMetrics).Observe(float64(len(payload)))
@@ -651,13 +746,14 @@ func (s *Session) handleEvent(_ context.Context, payload []byte) error {
 			eventBytesSummary.WithLabelValues(metrics.EventTypeLabelCheck).Observe(float64(len(payload)))
 		}
 		if event.Check.Name == corev2.KeepaliveCheckName {
+			s.annotateEntity(event.Entity)
 			return s.bus.Publish(messaging.TopicKeepaliveRaw, event)
 		}
 	} else if event.HasMetrics() {
 		eventBytesSummary.WithLabelValues(metrics.EventTypeLabelMetrics).Observe(float64(len(payload)))
 	}
 
-	return s.bus.Publish(messaging.TopicEventRaw, event)
+	return s.bus.Publish(messaging.TopicEventRaw, messaging.AgentEvent{Event: event})
 }
 
 // subscribe adds a subscription to the session for every check subscriptions
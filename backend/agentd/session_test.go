@@ -10,9 +10,12 @@ import (
 	"time"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/sensu/sensu-go/agent"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/eventd"
 	"github.com/sensu/sensu-go/backend/messaging"
 	"github.com/sensu/sensu-go/backend/store"
 	storev2 "github.com/sensu/sensu-go/backend/store/v2"
@@ -332,6 +335,7 @@ func TestSession_Start(t *testing.T) {
 		name      string
 		connFunc  connFunc
 		storeFunc storeFunc
+		warning   string
 		wantErr   bool
 	}{
 		{
@@ -381,6 +385,66 @@ func TestSession_Start(t *testing.T) {
 				s.On("Get", mock.Anything).Return(wrappedConfig, nil)
 			},
 		},
+		{
+			name: "a stale subscriptions warning is removed from the stored entity config",
+			connFunc: func(conn *mocktransport.MockTransport, wg *sync.WaitGroup) {
+				conn.On("Receive").After(100*time.Millisecond).Return(&transport.Message{}, nil)
+				conn.On("Closed").Return(true)
+				conn.On("Send", mock.Anything).Return(nil)
+				conn.On("Close").Return(nil)
+			},
+			storeFunc: func(s *storetest.Store, wg *sync.WaitGroup) {
+				cfg := corev3.FixtureEntityConfig("testing")
+				cfg.Metadata.Annotations = map[string]string{SubscriptionsWarningAnnotation: "too many subscriptions"}
+				wrappedConfig, err := storev2.WrapResource(cfg)
+				if err != nil {
+					t.Fatal(err)
+				}
+				s.On("Get", mock.Anything).Return(wrappedConfig, nil)
+				wg.Add(1)
+				s.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					defer wg.Done()
+					var stored corev3.EntityConfig
+					if err := args.Get(1).(*wrap.Wrapper).UnwrapInto(&stored); err != nil {
+						t.Error(err)
+						return
+					}
+					if _, ok := stored.Metadata.Annotations[SubscriptionsWarningAnnotation]; ok {
+						t.Error("stale subscriptions warning not removed")
+					}
+				}).Return(nil).Once()
+			},
+		},
+		{
+			name: "a new subscriptions warning is added to the stored entity config",
+			connFunc: func(conn *mocktransport.MockTransport, wg *sync.WaitGroup) {
+				conn.On("Receive").After(100*time.Millisecond).Return(&transport.Message{}, nil)
+				conn.On("Closed").Return(true)
+				conn.On("Send", mock.Anything).Return(nil)
+				conn.On("Close").Return(nil)
+			},
+			storeFunc: func(s *storetest.Store, wg *sync.WaitGroup) {
+				cfg := corev3.FixtureEntityConfig("testing")
+				wrappedConfig, err := storev2.WrapResource(cfg)
+				if err != nil {
+					t.Fatal(err)
+				}
+				s.On("Get", mock.Anything).Return(wrappedConfig, nil)
+				wg.Add(1)
+				s.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					defer wg.Done()
+					var stored corev3.EntityConfig
+					if err := args.Get(1).(*wrap.Wrapper).UnwrapInto(&stored); err != nil {
+						t.Error(err)
+						return
+					}
+					if got, want := stored.Metadata.Annotations[SubscriptionsWarningAnnotation], "too many subscriptions"; got != want {
+						t.Errorf("bad subscriptions warning: got %q, want %q", got, want)
+					}
+				}).Return(nil).Once()
+			},
+			warning: "too many subscriptions",
+		},
 		{
 			name: "store err is handled",
 			connFunc: func(conn *mocktransport.MockTransport, wg *sync.WaitGroup) {
@@ -429,6 +493,8 @@ func TestSession_Start(t *testing.T) {
 				Storev2:   storev2,
 				Unmarshal: agent.UnmarshalJSON,
 				Marshal:   agent.MarshalJSON,
+
+				SubscriptionsWarning: tt.warning,
 			}
 			session, err := NewSession(context.Background(), cfg)
 			if err != nil {
@@ -808,3 +874,66 @@ func TestSession_receiver(t *testing.T) {
 		})
 	}
 }
+
+func TestSession_observeFirstMessage(t *testing.T) {
+	count := func() uint64 {
+		var m dto.Metric
+		observer := firstMessageHistogram.WithLabelValues("keepalive")
+		require.NoError(t, observer.(prometheus.Histogram).Write(&m))
+		return m.GetHistogram().GetSampleCount()
+	}
+	before := count()
+
+	s := &Session{connectedAt: time.Now().Add(-time.Second)}
+	s.observeFirstMessage("keepalive")
+	s.observeFirstMessage("keepalive")
+	s.observeFirstMessage("event")
+
+	// Only the first message of the session is observed
+	assert.Equal(t, before+1, count())
+}
+
+func TestSession_handleEventMaxEventSize(t *testing.T) {
+	event := corev2.FixtureEvent("foo", "check-cpu")
+	payload, err := proto.Marshal(event)
+	require.NoError(t, err)
+
+	bus := &mockbus.MockBus{}
+	bus.On("Publish", messaging.TopicEventRaw, mock.Anything).Return(nil)
+	s := &Session{
+		cfg:       SessionConfig{MaxEventSize: event.Size()},
+		bus:       bus,
+		unmarshal: proto.Unmarshal,
+	}
+	require.NoError(t, s.handleEvent(context.Background(), payload))
+
+	rejected := eventd.EventsRejected.WithLabelValues(eventd.EventsRejectedReasonTooLarge)
+	var before, after dto.Metric
+	require.NoError(t, rejected.Write(&before))
+	s.cfg.MaxEventSize = event.Size() - 1
+	require.NoError(t, s.handleEvent(context.Background(), payload))
+	bus.AssertNumberOfCalls(t, "Publish", 1)
+	require.NoError(t, rejected.Write(&after))
+	assert.Equal(t, before.GetCounter().GetValue()+1, after.GetCounter().GetValue())
+}
+
+func TestSession_handleKeepaliveMetadataSizeLimit(t *testing.T) {
+	keepalive := corev2.FixtureEvent("foo", "keepalive")
+	keepalive.Timestamp = time.Now().Unix()
+	keepalive.Entity.Labels = map[string]string{"region": "us-west-1"}
+	payload, err := proto.Marshal(keepalive)
+	require.NoError(t, err)
+
+	bus := &mockbus.MockBus{}
+	bus.On("Publish", messaging.TopicKeepalive, mock.Anything).Return(nil)
+	s := &Session{
+		cfg:       SessionConfig{MetadataSizeLimit: 100},
+		bus:       bus,
+		unmarshal: proto.Unmarshal,
+	}
+	require.NoError(t, s.handleKeepalive(context.Background(), payload))
+
+	s.cfg.MetadataSizeLimit = 10
+	require.Error(t, s.handleKeepalive(context.Background(), payload))
+	bus.AssertNumberOfCalls(t, "Publish", 1)
+}
@@ -0,0 +1,97 @@
+package agentd
+
+import (
+	"fmt"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+)
+
+const (
+	// SubscriptionsWarningAnnotation is the annotation added to the entities
+	// of the agents registering with more subscriptions than the warning
+	// threshold of agentd.
+	SubscriptionsWarningAnnotation = "sensu.io/subscriptions_warning"
+
+	// DefaultSubscriptionsWarning is the default number of subscriptions
+	// above which agentd warns about an agent.
+	DefaultSubscriptionsWarning = 100
+)
+
+// countSubscriptions returns the number of distinct subscriptions an agent
+// registers with, ignoring the empty ones and its entity subscription.
+func countSubscriptions(agentName string, subscriptions []string) int {
+	entitySubscription := corev2.GetEntitySubscription(agentName)
+	seen := make(map[string]struct{}, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if subscription == "" || subscription == entitySubscription {
+			continue
+		}
+		seen[subscription] = struct{}{}
+	}
+	return len(seen)
+}
+
+// checkSubscriptions verifies the number of subscriptions of an agent against
+// the warning threshold and the limit of agentd, which are disabled when zero.
+// It returns a warning if the count exceeds the threshold, and an error if it
+// exceeds the limit.
+func (a *Agentd) checkSubscriptions(count int) (string, error) {
+	if a.maxSubscriptions > 0 && count > a.maxSubscriptions {
+		return "", fmt.Errorf("the agent has %d subscriptions, more than the limit of %d", count, a.maxSubscriptions)
+	}
+	if a.subscriptionsWarning > 0 && count > a.subscriptionsWarning {
+		return fmt.Sprintf("the agent has %d subscriptions, more than the warning threshold of %d", count, a.subscriptionsWarning), nil
+	}
+	return "", nil
+}
+
+// annotateEntity adds the subscriptions warning of the session, if any, and
+// the backend handling the session to the entity of the agent.
+func (s *Session) annotateEntity(entity *corev2.Entity) {
+	if entity == nil || (s.cfg.SubscriptionsWarning == "" && s.cfg.BackendName == "") {
+		return
+	}
+	if entity.Annotations == nil {
+		entity.Annotations = make(map[string]string)
+	}
+	if s.cfg.SubscriptionsWarning != "" {
+		entity.Annotations[SubscriptionsWarningAnnotation] = s.cfg.SubscriptionsWarning
+	}
+	if s.cfg.BackendName != "" {
+		entity.Annotations[corev2.BackendAnnotation] = s.cfg.BackendName
+	}
+}
+
+// annotateEntityConfig updates the subscriptions warning and the backend
+// handling the session in the stored entity config of the agent, which is not
+// updated by keepalived once it exists. The annotations of the session are
+// added, or removed if the session has none, such as when the agent is back
+// under the subscriptions warning threshold. It returns true if the entity
+// config was modified.
+func (s *Session) annotateEntityConfig(config *corev3.EntityConfig) bool {
+	if config.Metadata == nil {
+		return false
+	}
+	warningChanged := setAnnotation(config.Metadata, SubscriptionsWarningAnnotation, s.cfg.SubscriptionsWarning)
+	backendChanged := setAnnotation(config.Metadata, corev2.BackendAnnotation, s.cfg.BackendName)
+	return warningChanged || backendChanged
+}
+
+// setAnnotation sets the annotation key of meta to value, or removes it if
+// value is empty. It returns true if the annotations were modified.
+func setAnnotation(meta *corev2.ObjectMeta, key, value string) bool {
+	current, ok := meta.Annotations[key]
+	if value == "" {
+		delete(meta.Annotations, key)
+		return ok
+	}
+	if ok && current == value {
+		return false
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = make(map[string]string)
+	}
+	meta.Annotations[key] = value
+	return true
+}
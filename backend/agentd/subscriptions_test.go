@@ -0,0 +1,114 @@
+package agentd
+
+import (
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+)
+
+func TestCountSubscriptions(t *testing.T) {
+	subscriptions := []string{"linux", "", "linux", "web", "entity:foo"}
+	if got, want := countSubscriptions("foo", subscriptions), 2; got != want {
+		t.Errorf("bad count: got %d, want %d", got, want)
+	}
+	if got, want := countSubscriptions("foo", []string{""}), 0; got != want {
+		t.Errorf("bad count: got %d, want %d", got, want)
+	}
+}
+
+func TestCheckSubscriptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		warning     int
+		limit       int
+		count       int
+		wantWarning bool
+		wantErr     bool
+	}{
+		{name: "below the threshold", warning: 10, limit: 20, count: 10},
+		{name: "above the threshold", warning: 10, limit: 20, count: 11, wantWarning: true},
+		{name: "above the limit", warning: 10, limit: 20, count: 21, wantErr: true},
+		{name: "disabled", count: 1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Agentd{subscriptionsWarning: tt.warning, maxSubscriptions: tt.limit}
+			warning, err := a.checkSubscriptions(tt.count)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (warning != "") != tt.wantWarning {
+				t.Fatalf("unexpected warning: %q", warning)
+			}
+		})
+	}
+}
+
+func TestSessionAnnotateEntity(t *testing.T) {
+	entity := corev2.FixtureEntity("foo")
+	entity.Annotations = nil
+
+	s := &Session{}
+	s.annotateEntity(entity)
+	if _, ok := entity.Annotations[SubscriptionsWarningAnnotation]; ok {
+		t.Fatal("entity annotated without a warning")
+	}
+
+	s.cfg.SubscriptionsWarning = "too many subscriptions"
+	s.annotateEntity(entity)
+	if got, want := entity.Annotations[SubscriptionsWarningAnnotation], "too many subscriptions"; got != want {
+		t.Fatalf("bad annotation: got %q, want %q", got, want)
+	}
+	if _, ok := entity.Annotations[corev2.BackendAnnotation]; ok {
+		t.Fatal("entity annotated without a backend")
+	}
+
+	s.cfg.BackendName = "backend-1"
+	s.annotateEntity(entity)
+	if got, want := entity.Annotations[corev2.BackendAnnotation], "backend-1"; got != want {
+		t.Fatalf("bad annotation: got %q, want %q", got, want)
+	}
+}
+
+func TestSessionAnnotateEntityConfig(t *testing.T) {
+	config := corev3.FixtureEntityConfig("foo")
+	config.Metadata.Annotations = nil
+
+	s := &Session{}
+	if s.annotateEntityConfig(config) {
+		t.Fatal("entity config modified without a warning")
+	}
+
+	s.cfg.SubscriptionsWarning = "too many subscriptions"
+	if !s.annotateEntityConfig(config) {
+		t.Fatal("entity config not modified with a new warning")
+	}
+	if got, want := config.Metadata.Annotations[SubscriptionsWarningAnnotation], "too many subscriptions"; got != want {
+		t.Fatalf("bad annotation: got %q, want %q", got, want)
+	}
+	if s.annotateEntityConfig(config) {
+		t.Fatal("entity config modified with the same warning")
+	}
+
+	s.cfg.SubscriptionsWarning = ""
+	if !s.annotateEntityConfig(config) {
+		t.Fatal("entity config not modified when the warning is cleared")
+	}
+	if _, ok := config.Metadata.Annotations[SubscriptionsWarningAnnotation]; ok {
+		t.Fatal("stale warning not removed")
+	}
+
+	// the backend of a previous session is replaced by the current one
+	config.Metadata.Annotations[corev2.BackendAnnotation] = "backend-1"
+	s.cfg.BackendName = "backend-2"
+	if !s.annotateEntityConfig(config) {
+		t.Fatal("entity config not modified with a new backend")
+	}
+	if got, want := config.Metadata.Annotations[corev2.BackendAnnotation], "backend-2"; got != want {
+		t.Fatalf("bad backend annotation: got %q, want %q", got, want)
+	}
+	if s.annotateEntityConfig(config) {
+		t.Fatal("entity config modified with the same backend")
+	}
+}
@@ -39,5 +39,6 @@ func authorize(ctx context.Context, auth authorization.Authorizer, attrs *author
 	if !authorized {
 		return authorization.ErrUnauthorized
 	}
+	authorization.Record(ctx, attrs)
 	return nil
 }
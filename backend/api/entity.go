@@ -2,7 +2,9 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -35,13 +37,16 @@ func NewEntityClient(store store.EntityStore, storev2 storev2.Interface, eventSt
 // DeleteEntity deletes an Entity, if authorized. In doing so, it will also
 // delete all of the events associated with the entity. The operation is not
 // transactional; partial data may remain if it fails.
+//
+// Authorization and lookup failures are reported as *ErrUnauthorized and
+// *ErrNotFound respectively.
 func (e *EntityClient) DeleteEntity(ctx context.Context, name string) error {
 	attrs := entityAuthAttributes(ctx, "delete", name)
 	if err := authorize(ctx, e.auth, attrs); err != nil {
-		return err
+		return wrapError(err)
 	}
 	if err := e.entityStore.DeleteEntityByName(ctx, name); err != nil {
-		return err
+		return wrapError(err)
 	}
 	// if the client delete succeeded, we have sufficient permissions to also
 	// delete the associated events.
@@ -71,20 +76,32 @@ func (e *EntityClient) DeleteEntity(ctx context.Context, name string) error {
 func (e *EntityClient) CreateEntity(ctx context.Context, entity *corev2.Entity) error {
 	attrs := entityAuthAttributes(ctx, "create", entity.Name)
 	if err := authorize(ctx, e.auth, attrs); err != nil {
-		return err
+		return wrapError(err)
 	}
 	setCreatedBy(ctx, entity)
 	if err := e.entityStore.UpdateEntity(ctx, entity); err != nil {
-		return err
+		return wrapError(err)
 	}
 	return nil
 }
 
+// ErrEntityVersionRequired is returned by UpdateEntity when the update is
+// neither forced nor based on a version of the entity.
+var ErrEntityVersionRequired = errors.New("entity version is required unless the update is forced")
+
 // UpdateEntity updates an entity, if authorized.
-func (e *EntityClient) UpdateEntity(ctx context.Context, entity *corev2.Entity) error {
+//
+// Unless force is true, the entity is only updated if the configuration of
+// the stored entity still has the given version, as returned by
+// FetchEntityWithVersion when the entity was read. Otherwise the update fails
+// with *ErrConflict, rather than overwriting the changes made in the meantime.
+func (e *EntityClient) UpdateEntity(ctx context.Context, entity *corev2.Entity, version string, force bool) error {
 	attrs := entityAuthAttributes(ctx, "update", entity.Name)
 	if err := authorize(ctx, e.auth, attrs); err != nil {
-		return err
+		return wrapError(err)
+	}
+	if !force && version == "" {
+		return ErrEntityVersionRequired
 	}
 	setCreatedBy(ctx, entity)
 
@@ -97,43 +114,93 @@ func (e *EntityClient) UpdateEntity(ctx context.Context, entity *corev2.Entity)
 	// something we don't really want unless that entity is a proxy entity.
 	//
 	// See sensu-go#3896.
-	if entity.EntityClass == corev2.EntityProxyClass {
+	if force && entity.EntityClass == corev2.EntityProxyClass {
 		if err := e.entityStore.UpdateEntity(ctx, entity); err != nil {
-			return err
+			return wrapError(err)
 		}
+		return nil
+	}
+
+	config, state := corev3.V2EntityToV3(entity)
+	// Ensure per-entity subscription does not get removed
+	config.Subscriptions = corev2.AddEntitySubscription(config.Metadata.Name, config.Subscriptions)
+	req := storev2.NewResourceRequestFromResource(ctx, config)
+
+	wConfig, err := storev2.WrapResource(config)
+	if err != nil {
+		return err
+	}
+
+	if force {
+		err = e.storev2.CreateOrUpdate(req, wConfig)
 	} else {
-		config, _ := corev3.V2EntityToV3(entity)
-		// Ensure per-entity subscription does not get removed
-		config.Subscriptions = corev2.AddEntitySubscription(config.Metadata.Name, config.Subscriptions)
-		req := storev2.NewResourceRequestFromResource(ctx, config)
+		err = e.storev2.UpdateIfMatch(req, wConfig, &store.ETagCondition{IfMatch: version})
+	}
+	if err != nil {
+		return wrapError(err)
+	}
 
-		wConfig, err := storev2.WrapResource(config)
+	if entity.EntityClass == corev2.EntityProxyClass {
+		// The state of proxy entities is only written once their
+		// configuration was updated, so that a conflicting update leaves
+		// both untouched.
+		wState, err := storev2.WrapResource(state)
 		if err != nil {
 			return err
 		}
-
-		if err := e.storev2.CreateOrUpdate(req, wConfig); err != nil {
-			return err
+		stateReq := storev2.NewResourceRequestFromResource(ctx, state)
+		if err := e.storev2.CreateOrUpdate(stateReq, wState); err != nil {
+			return wrapError(err)
 		}
 	}
 
 	return nil
 }
 
-// FetchEntity gets an entity, if authorized.
+// FetchEntityWithVersion gets an entity along with the version of its stored
+// configuration, if authorized. The version is to be passed to UpdateEntity
+// to update the entity based on it. Authorization and lookup failures are
+// reported as *ErrUnauthorized and *ErrNotFound respectively.
+func (e *EntityClient) FetchEntityWithVersion(ctx context.Context, name string) (*corev2.Entity, string, error) {
+	entity, err := e.FetchEntity(ctx, name)
+	if err != nil {
+		return nil, "", err
+	}
+	req := storev2.NewResourceRequest(ctx, entity.Namespace, name, (&corev3.EntityConfig{}).StoreName())
+	wrapper, err := e.storev2.Get(req)
+	if err != nil {
+		return nil, "", wrapError(err)
+	}
+	stored, err := wrapper.Unwrap()
+	if err != nil {
+		return nil, "", err
+	}
+	version, err := store.ETag(stored)
+	if err != nil {
+		return nil, "", err
+	}
+	return entity, version, nil
+}
+
+// FetchEntity gets an entity, if authorized. Authorization and lookup failures
+// are reported as *ErrUnauthorized and *ErrNotFound respectively.
 func (e *EntityClient) FetchEntity(ctx context.Context, name string) (*corev2.Entity, error) {
 	attrs := entityAuthAttributes(ctx, "get", name)
 	if err := authorize(ctx, e.auth, attrs); err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 	entity, err := e.entityStore.GetEntityByName(ctx, name)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
+	}
+	if entity == nil {
+		return nil, &ErrNotFound{Err: &store.ErrNotFound{Key: name}}
 	}
 	return entity, nil
 }
 
-// ListEntities lists all entities in a namespace, if authorized.
+// ListEntities lists all entities in a namespace, if authorized. The fields
+// populated on the entities can be restricted with pred.Fields.
 func (e *EntityClient) ListEntities(ctx context.Context, pred *store.SelectionPredicate) ([]*corev2.Entity, error) {
 	attrs := entityAuthAttributes(ctx, "list", "")
 	if err := authorize(ctx, e.auth, attrs); err != nil {
@@ -146,6 +213,69 @@ func (e *EntityClient) ListEntities(ctx context.Context, pred *store.SelectionPr
 	return slice, nil
 }
 
+// EntityPage is a page of entities, as returned by ListEntitiesPage.
+type EntityPage struct {
+	// Entities are the entities of the page.
+	Entities []*corev2.Entity
+
+	// Continue is the token to set in the selection predicate to fetch the
+	// next page. It is empty when there are no more entities.
+	Continue string
+
+	// TotalCount is the approximate number of entities in the namespace, or
+	// -1 if it is unknown. It is only counted for the first page.
+	TotalCount int64
+}
+
+// ListEntitiesPage lists a page of entities in a namespace, if authorized.
+// Unlike ListEntities, pred is not modified; the token to fetch the next page
+// is returned along with the entities.
+func (e *EntityClient) ListEntitiesPage(ctx context.Context, pred *store.SelectionPredicate) (*EntityPage, error) {
+	var pagePred store.SelectionPredicate
+	if pred != nil {
+		pagePred = *pred
+	}
+	entities, err := e.ListEntities(ctx, &pagePred)
+	if err != nil {
+		return nil, err
+	}
+	page := &EntityPage{
+		Entities:   entities,
+		Continue:   pagePred.Continue,
+		TotalCount: -1,
+	}
+	if pred != nil && pred.Continue != "" {
+		// Counting reads the whole keyspace, the callers keep the count of
+		// the first page
+		return page, nil
+	}
+	if page.Continue == "" {
+		// The whole namespace fits in a single page
+		page.TotalCount = int64(len(entities))
+	} else if counter, ok := e.entityStore.(store.EntityCounter); ok {
+		if count, err := counter.CountEntities(ctx, &pagePred); err == nil {
+			page.TotalCount = count
+		}
+	}
+	return page, nil
+}
+
+// ListStaleEntities lists the entities in a namespace that were not seen for
+// longer than olderThan, if authorized. The entities that were never seen are
+// not listed. Unlike ListEntities, it relies on the store to only read the
+// stale entities.
+func (e *EntityClient) ListStaleEntities(ctx context.Context, olderThan time.Duration) ([]*corev2.Entity, error) {
+	attrs := entityAuthAttributes(ctx, "list", "")
+	if err := authorize(ctx, e.auth, attrs); err != nil {
+		return nil, err
+	}
+	lister, ok := e.entityStore.(store.StaleEntityLister)
+	if !ok {
+		return nil, fmt.Errorf("%T does not support listing stale entities", e.entityStore)
+	}
+	return lister.ListStaleEntities(ctx, time.Now().Add(-olderThan).Unix())
+}
+
 func entityAuthAttributes(ctx context.Context, verb, name string) *authorization.Attributes {
 	return &authorization.Attributes{
 		APIGroup:     "core",
@@ -5,10 +5,12 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
 	"github.com/sensu/sensu-go/backend/authorization"
 	"github.com/sensu/sensu-go/backend/authorization/rbac"
 	"github.com/sensu/sensu-go/backend/store"
@@ -576,7 +578,7 @@ func TestUpdateEntity(t *testing.T) {
 			client := NewEntityClient(store, storev2, eventStore, auth)
 
 			defaultEntity.EntityClass = corev2.EntityAgentClass
-			err := client.UpdateEntity(ctx, defaultEntity)
+			err := client.UpdateEntity(ctx, defaultEntity, "", true)
 			if err != nil && !test.ExpErr {
 				t.Fatal(err)
 			}
@@ -596,7 +598,7 @@ func TestUpdateEntity(t *testing.T) {
 			client := NewEntityClient(store, storev2, eventStore, auth)
 
 			defaultEntity.EntityClass = corev2.EntityProxyClass
-			err := client.UpdateEntity(ctx, defaultEntity)
+			err := client.UpdateEntity(ctx, defaultEntity, "", true)
 			if err != nil && !test.ExpErr {
 				t.Fatal(err)
 			}
@@ -610,6 +612,121 @@ func TestUpdateEntity(t *testing.T) {
 	}
 }
 
+func TestUpdateEntityVersion(t *testing.T) {
+	updateAuth := func() authorization.Authorizer {
+		return &mockAuth{
+			attrs: map[authorization.AttributesKey]bool{
+				{
+					APIGroup:     "core",
+					APIVersion:   "v2",
+					Namespace:    "default",
+					Resource:     "entities",
+					ResourceName: "default",
+					UserName:     "legit",
+					Verb:         "update",
+				}: true,
+			},
+		}
+	}
+	cond := &store.ETagCondition{IfMatch: "v1"}
+
+	t.Run("version required", func(t *testing.T) {
+		ctx := contextWithUser(defaultContext(), "legit", nil)
+		client := NewEntityClient(new(mockstore.MockStore), new(storetest.Store), new(mockstore.MockStore), updateAuth())
+		err := client.UpdateEntity(ctx, corev2.FixtureEntity("default"), "", false)
+		if err != ErrEntityVersionRequired {
+			t.Fatalf("expected ErrEntityVersionRequired, got %v", err)
+		}
+	})
+
+	t.Run("agent entity", func(t *testing.T) {
+		ctx := contextWithUser(defaultContext(), "legit", nil)
+		s := new(storetest.Store)
+		s.On("UpdateIfMatch", mock.Anything, mock.Anything, cond).Return(nil)
+		client := NewEntityClient(new(mockstore.MockStore), s, new(mockstore.MockStore), updateAuth())
+		entity := corev2.FixtureEntity("default")
+		entity.EntityClass = corev2.EntityAgentClass
+		if err := client.UpdateEntity(ctx, entity, "v1", false); err != nil {
+			t.Fatal(err)
+		}
+		s.AssertExpectations(t)
+	})
+
+	t.Run("proxy entity", func(t *testing.T) {
+		ctx := contextWithUser(defaultContext(), "legit", nil)
+		s := new(storetest.Store)
+		s.On("UpdateIfMatch", mock.Anything, mock.Anything, cond).Return(nil)
+		s.On("CreateOrUpdate", mock.MatchedBy(func(req storev2.ResourceRequest) bool {
+			return req.StoreName == (&corev3.EntityState{}).StoreName()
+		}), mock.Anything).Return(nil)
+		client := NewEntityClient(new(mockstore.MockStore), s, new(mockstore.MockStore), updateAuth())
+		entity := corev2.FixtureEntity("default")
+		entity.EntityClass = corev2.EntityProxyClass
+		if err := client.UpdateEntity(ctx, entity, "v1", false); err != nil {
+			t.Fatal(err)
+		}
+		s.AssertExpectations(t)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		ctx := contextWithUser(defaultContext(), "legit", nil)
+		s := new(storetest.Store)
+		s.On("UpdateIfMatch", mock.Anything, mock.Anything, cond).Return(&store.ErrPreconditionFailed{Key: "default"})
+		client := NewEntityClient(new(mockstore.MockStore), s, new(mockstore.MockStore), updateAuth())
+		entity := corev2.FixtureEntity("default")
+		entity.EntityClass = corev2.EntityProxyClass
+		err := client.UpdateEntity(ctx, entity, "v1", false)
+		var conflict *ErrConflict
+		if !errors.As(err, &conflict) {
+			t.Fatalf("expected ErrConflict, got %v", err)
+		}
+		// The state of the proxy entity must not be written
+		s.AssertExpectations(t)
+	})
+}
+
+func TestFetchEntityWithVersion(t *testing.T) {
+	ctx := contextWithUser(defaultContext(), "legit", nil)
+	auth := &mockAuth{
+		attrs: map[authorization.AttributesKey]bool{
+			{
+				APIGroup:     "core",
+				APIVersion:   "v2",
+				Namespace:    "default",
+				Resource:     "entities",
+				ResourceName: "default",
+				UserName:     "legit",
+				Verb:         "get",
+			}: true,
+		},
+	}
+	entityStore := new(mockstore.MockStore)
+	entityStore.On("GetEntityByName", mock.Anything, "default").Return(corev2.FixtureEntity("default"), nil)
+	config := corev3.FixtureEntityConfig("default")
+	wrapper, err := storev2.WrapResource(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := new(storetest.Store)
+	s.On("Get", mock.Anything).Return(wrapper, nil)
+	client := NewEntityClient(entityStore, s, new(mockstore.MockStore), auth)
+
+	entity, version, err := client.FetchEntityWithVersion(ctx, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := entity.Name, "default"; got != want {
+		t.Errorf("bad entity: got %q, want %q", got, want)
+	}
+	want, err := store.ETag(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != want {
+		t.Errorf("bad version: got %q, want %q", version, want)
+	}
+}
+
 func TestDeleteEntity(t *testing.T) {
 	tests := []struct {
 		Name       string
@@ -847,3 +964,111 @@ func TestDeleteEntity(t *testing.T) {
 		})
 	}
 }
+
+func TestListEntitiesPage(t *testing.T) {
+	ctx := contextWithUser(defaultContext(), "legit", nil)
+	auth := &mockAuth{
+		attrs: map[authorization.AttributesKey]bool{
+			authorization.AttributesKey{
+				APIGroup:   "core",
+				APIVersion: "v2",
+				Namespace:  "default",
+				Resource:   "entities",
+				UserName:   "legit",
+				Verb:       "list",
+			}: true,
+		},
+	}
+
+	t.Run("single page", func(t *testing.T) {
+		st := new(mockstore.MockStore)
+		st.On("GetEntities", mock.Anything, mock.Anything).Return([]*corev2.Entity{defaultEntity}, nil)
+		client := NewEntityClient(st, &storetest.Store{}, st, auth)
+		pred := &store.SelectionPredicate{Limit: 10}
+		page, err := client.ListEntitiesPage(ctx, pred)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := page.Entities, []*corev2.Entity{defaultEntity}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("bad entities: got %v, want %v", got, want)
+		}
+		if page.Continue != "" {
+			t.Errorf("bad continue token: %q", page.Continue)
+		}
+		if got, want := page.TotalCount, int64(1); got != want {
+			t.Errorf("bad total count: got %d, want %d", got, want)
+		}
+	})
+
+	t.Run("more pages", func(t *testing.T) {
+		st := new(mockstore.MockStore)
+		st.On("GetEntities", mock.Anything, mock.Anything).Return([]*corev2.Entity{defaultEntity}, nil).Run(func(args mock.Arguments) {
+			args.Get(1).(*store.SelectionPredicate).Continue = "next"
+		})
+		client := NewEntityClient(st, &storetest.Store{}, st, auth)
+		pred := &store.SelectionPredicate{Limit: 1}
+		page, err := client.ListEntitiesPage(ctx, pred)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := page.Continue, "next"; got != want {
+			t.Errorf("bad continue token: got %q, want %q", got, want)
+		}
+		if got, want := page.TotalCount, int64(-1); got != want {
+			t.Errorf("bad total count: got %d, want %d", got, want)
+		}
+		if pred.Continue != "" {
+			t.Errorf("the predicate should not be modified, got continue token %q", pred.Continue)
+		}
+	})
+
+	t.Run("next page", func(t *testing.T) {
+		st := new(mockstore.MockStore)
+		st.On("GetEntities", mock.Anything, mock.Anything).Return([]*corev2.Entity{defaultEntity}, nil)
+		client := NewEntityClient(st, &storetest.Store{}, st, auth)
+		pred := &store.SelectionPredicate{Limit: 1, Continue: "next"}
+		page, err := client.ListEntitiesPage(ctx, pred)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := page.TotalCount, int64(-1); got != want {
+			t.Errorf("bad total count: got %d, want %d", got, want)
+		}
+	})
+}
+
+func TestListStaleEntities(t *testing.T) {
+	ctx := contextWithUser(defaultContext(), "legit", nil)
+	auth := &mockAuth{
+		attrs: map[authorization.AttributesKey]bool{
+			authorization.AttributesKey{
+				APIGroup:   "core",
+				APIVersion: "v2",
+				Namespace:  "default",
+				Resource:   "entities",
+				UserName:   "legit",
+				Verb:       "list",
+			}: true,
+		},
+	}
+
+	st := new(mockstore.MockStore)
+	before := time.Now().Add(-time.Hour).Unix()
+	st.On("ListStaleEntities", mock.Anything, mock.MatchedBy(func(lastSeenBefore int64) bool {
+		return lastSeenBefore >= before && lastSeenBefore <= before+1
+	})).Return([]*corev2.Entity{defaultEntity}, nil)
+	client := NewEntityClient(st, &storetest.Store{}, st, auth)
+
+	entities, err := client.ListStaleEntities(ctx, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := entities, []*corev2.Entity{defaultEntity}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("bad entities: got %v, want %v", got, want)
+	}
+
+	ctx = contextWithUser(defaultContext(), "haxor", nil)
+	if _, err := client.ListStaleEntities(ctx, time.Hour); err == nil {
+		t.Fatal("expected an authorization error")
+	}
+}
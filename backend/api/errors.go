@@ -0,0 +1,74 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/sensu/sensu-go/backend/authorization"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// ErrNotFound is returned when the requested resource does not exist.
+type ErrNotFound struct {
+	Err error
+}
+
+func (e *ErrNotFound) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ErrNotFound) Unwrap() error {
+	return e.Err
+}
+
+// ErrUnauthorized is returned when the request is not authorized to access
+// the resource, or when the request carries no claims at all.
+type ErrUnauthorized struct {
+	Err error
+}
+
+func (e *ErrUnauthorized) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ErrUnauthorized) Unwrap() error {
+	return e.Err
+}
+
+// ErrConflict is returned when the request conflicts with the current state
+// of the resource, for instance when it already exists.
+type ErrConflict struct {
+	Err error
+}
+
+func (e *ErrConflict) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ErrConflict) Unwrap() error {
+	return e.Err
+}
+
+// wrapError classifies errors returned by the authorizer and the store into
+// one of the error types above. Errors that do not fall into any category are
+// returned as is. The original error can always be recovered with errors.Unwrap.
+func wrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, authorization.ErrUnauthorized) || errors.Is(err, authorization.ErrNoClaims) {
+		return &ErrUnauthorized{Err: err}
+	}
+	var notFound *store.ErrNotFound
+	if errors.As(err, &notFound) {
+		return &ErrNotFound{Err: err}
+	}
+	var alreadyExists *store.ErrAlreadyExists
+	if errors.As(err, &alreadyExists) {
+		return &ErrConflict{Err: err}
+	}
+	var preconditionFailed *store.ErrPreconditionFailed
+	if errors.As(err, &preconditionFailed) {
+		return &ErrConflict{Err: err}
+	}
+	return err
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sensu/sensu-go/backend/authorization"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+func TestWrapError(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		check func(error) bool
+	}{
+		{
+			name: "nil",
+			err:  nil,
+			check: func(err error) bool {
+				return err == nil
+			},
+		},
+		{
+			name: "unauthorized",
+			err:  authorization.ErrUnauthorized,
+			check: func(err error) bool {
+				var e *ErrUnauthorized
+				return errors.As(err, &e) && errors.Is(err, authorization.ErrUnauthorized)
+			},
+		},
+		{
+			name: "no claims",
+			err:  authorization.ErrNoClaims,
+			check: func(err error) bool {
+				var e *ErrUnauthorized
+				return errors.As(err, &e)
+			},
+		},
+		{
+			name: "not found",
+			err:  fmt.Errorf("fetching: %w", &store.ErrNotFound{Key: "foo"}),
+			check: func(err error) bool {
+				var e *ErrNotFound
+				var storeErr *store.ErrNotFound
+				return errors.As(err, &e) && errors.As(err, &storeErr)
+			},
+		},
+		{
+			name: "already exists",
+			err:  &store.ErrAlreadyExists{Key: "foo"},
+			check: func(err error) bool {
+				var e *ErrConflict
+				return errors.As(err, &e)
+			},
+		},
+		{
+			name: "precondition failed",
+			err:  &store.ErrPreconditionFailed{Key: "foo"},
+			check: func(err error) bool {
+				var e *ErrConflict
+				return errors.As(err, &e)
+			},
+		},
+		{
+			name: "other",
+			err:  errors.New("oh no"),
+			check: func(err error) bool {
+				var e *ErrNotFound
+				return err != nil && !errors.As(err, &e) && err.Error() == "oh no"
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapError(tt.err); !tt.check(got) {
+				t.Errorf("wrapError() = %#v", got)
+			}
+		})
+	}
+}
@@ -77,7 +77,7 @@ func (e *EventClient) DeleteEvent(ctx context.Context, entity, check string) err
 		return err
 	}
 	if err := e.store.DeleteEventByEntityCheck(ctx, entity, check); err != nil {
-		return fmt.Errorf("couldn't delete event: %s", err)
+		return fmt.Errorf("couldn't delete event: %w", err)
 	}
 	return nil
 }
@@ -2,7 +2,9 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	corev3 "github.com/sensu/sensu-go/api/core/v3"
@@ -28,6 +30,7 @@ type NamespaceClient struct {
 	bindingClient  GenericClient
 	storev2        storev2.Interface
 	auth           authorization.Authorizer
+	cache          *NamespaceCache
 }
 
 // NewNamespaceClient creates a new NamespaceClient, given a store and authorizer.
@@ -60,11 +63,18 @@ func NewNamespaceClient(store store.ResourceStore, namespaceStore store.Namespac
 	}
 }
 
+// WithCache makes the client list namespaces from the given cache when
+// possible, and invalidate it when namespaces are modified.
+func (a *NamespaceClient) WithCache(cache *NamespaceCache) *NamespaceClient {
+	a.cache = cache
+	return a
+}
+
 // ListNamespaces fetches a list of the namespace resources that are authorized
 // by the supplied credentials. This may include implicit access via resources
 // that are in a namespace that the credentials are authorized to get.
 func (a *NamespaceClient) ListNamespaces(ctx context.Context, pred *store.SelectionPredicate) ([]*corev2.Namespace, error) {
-	var resources, namespaces []*corev2.Namespace
+	var namespaces []*corev2.Namespace
 
 	visitor, ok := a.auth.(ruleVisitor)
 	if !ok {
@@ -73,7 +83,8 @@ func (a *NamespaceClient) ListNamespaces(ctx context.Context, pred *store.Select
 		}
 		return namespaces, nil
 	}
-	if err := a.client.Store.ListResources(ctx, a.client.Kind.StorePrefix(), &resources, pred); err != nil {
+	resources, err := a.listNamespaceResources(ctx, pred)
+	if err != nil {
 		return nil, err
 	}
 	namespaceMap := make(map[string]*corev2.Namespace, len(resources))
@@ -177,6 +188,28 @@ func (a *NamespaceClient) ListNamespaces(ctx context.Context, pred *store.Select
 	return namespaces, nil
 }
 
+// listNamespaceResources lists the namespaces from the cache, if any and if
+// the whole list is requested, or from the store.
+func (a *NamespaceClient) listNamespaceResources(ctx context.Context, pred *store.SelectionPredicate) ([]*corev2.Namespace, error) {
+	if a.cache != nil && (pred == nil || (pred.Limit == 0 && pred.Continue == "")) {
+		if namespaces, ok := a.cache.Get(); ok {
+			return namespaces, nil
+		}
+	}
+	var resources []*corev2.Namespace
+	if err := a.client.Store.ListResources(ctx, a.client.Kind.StorePrefix(), &resources, pred); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// invalidateCache invalidates the namespace cache, if any.
+func (a *NamespaceClient) invalidateCache() {
+	if a.cache != nil {
+		a.cache.Invalidate()
+	}
+}
+
 // FetchNamespace fetches a namespace resource from the backend, if authorized.
 func (a *NamespaceClient) FetchNamespace(ctx context.Context, name string) (*corev2.Namespace, error) {
 	var namespace corev2.Namespace
@@ -322,6 +355,7 @@ func (a *NamespaceClient) CreateNamespace(ctx context.Context, namespace *corev2
 	if err := a.client.Create(ctx, namespace); err != nil {
 		return err
 	}
+	a.invalidateCache()
 	if err := a.createResourceTemplates(ctx, namespace.Name); err != nil {
 		return err
 	}
@@ -363,6 +397,7 @@ func (a *NamespaceClient) UpdateNamespace(ctx context.Context, namespace *corev2
 	if err := a.client.Update(ctx, namespace); err != nil {
 		return err
 	}
+	a.invalidateCache()
 	if err := a.createResourceTemplates(ctx, namespace.Name); err != nil {
 		return err
 	}
@@ -395,6 +430,7 @@ func (a *NamespaceClient) DeleteNamespace(ctx context.Context, name string) erro
 	if err := a.namespaceStore.DeleteNamespace(ctx, name); err != nil {
 		return err
 	}
+	a.invalidateCache()
 
 	if err := a.roleClient.Delete(namespacedCtx, pipelineRoleName); err != nil {
 		logger.Warnf("could not delete implicit %s role in namespace %s: %s", pipelineRoleName, name, err)
@@ -407,6 +443,167 @@ func (a *NamespaceClient) DeleteNamespace(ctx context.Context, name string) erro
 	return nil
 }
 
+// cascadeKinds are the kinds of core/v2 resources, besides entities and
+// events, that DeleteNamespaceCascade removes from a namespace. They are listed
+// so that resources are deleted before the ones they may reference: pipelines
+// before their handlers, filters and mutators, and role bindings before their
+// roles.
+var cascadeKinds = []corev2.Resource{
+	&corev2.CheckConfig{},
+	&corev2.HookConfig{},
+	&corev2.Silenced{},
+	&corev2.Pipeline{},
+	&corev2.Handler{},
+	&corev2.Mutator{},
+	&corev2.EventFilter{},
+	&corev2.Asset{},
+	&corev2.RoleBinding{},
+	&corev2.Role{},
+}
+
+// cascadeKindsV3 returns the kinds of core/v3 resources that
+// DeleteNamespaceCascade removes from a namespace. They are taken from the
+// core/v3 resource registry, except for entity configs and states, which are
+// deleted along with their entities.
+func cascadeKindsV3() []corev3.Resource {
+	var kinds []corev3.Resource
+	for _, kind := range corev3.ListResources() {
+		switch kind.(type) {
+		case *corev3.EntityConfig, *corev3.EntityState:
+			continue
+		}
+		kinds = append(kinds, kind)
+	}
+	return kinds
+}
+
+// DeleteNamespaceCascade deletes a namespace along with all the resources it
+// contains, if authorized. Events are deleted first, then entities, then the
+// core/v2 resources in the order of cascadeKinds, and finally the core/v3
+// resources returned by cascadeKindsV3. Each deletion is
+// authorized on its own, and the first failure stops the operation, leaving
+// the namespace and its remaining resources in place.
+//
+// The number of resources deleted by type, keyed by their RBAC name, is
+// returned even if an error occurs.
+func (a *NamespaceClient) DeleteNamespaceCascade(ctx context.Context, name string, entities *EntityClient, events *EventClient) (map[string]int, error) {
+	attrs := namespaceDeleteAttributes(ctx, name)
+	if err := authorize(ctx, a.auth, attrs); err != nil {
+		return nil, err
+	}
+	namespace, err := a.namespaceStore.GetNamespace(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == nil {
+		return nil, &store.ErrNotFound{Key: name}
+	}
+
+	namespacedCtx := context.WithValue(ctx, corev2.NamespaceKey, name)
+	deleted := map[string]int{}
+
+	eventList, err := events.ListEvents(namespacedCtx, &store.SelectionPredicate{})
+	if err != nil {
+		return deleted, err
+	}
+	for _, event := range eventList {
+		if !event.HasCheck() {
+			continue
+		}
+		err := events.DeleteEvent(namespacedCtx, event.Entity.Name, event.Check.Name)
+		if err := cascadeError(err); err != nil {
+			return deleted, err
+		}
+		deleted[event.RBACName()]++
+	}
+
+	entityList, err := entities.ListEntities(namespacedCtx, &store.SelectionPredicate{})
+	if err != nil {
+		return deleted, err
+	}
+	for _, entity := range entityList {
+		err := entities.DeleteEntity(namespacedCtx, entity.Name)
+		if err := cascadeError(err); err != nil {
+			return deleted, err
+		}
+		deleted[entity.RBACName()]++
+	}
+
+	for _, kind := range cascadeKinds {
+		client := GenericClient{
+			Kind:       kind,
+			Store:      a.client.Store,
+			Auth:       a.auth,
+			APIGroup:   "core",
+			APIVersion: "v2",
+		}
+		list := reflect.New(reflect.SliceOf(reflect.TypeOf(kind)))
+		if err := client.List(namespacedCtx, list.Interface(), &store.SelectionPredicate{}); err != nil {
+			return deleted, err
+		}
+		resources := list.Elem()
+		for i := 0; i < resources.Len(); i++ {
+			resource := resources.Index(i).Interface().(corev2.Resource)
+			if isImplicitPipelineRBAC(resource) {
+				// DeleteNamespace removes it along with the namespace
+				continue
+			}
+			err := client.Delete(namespacedCtx, resource.GetObjectMeta().Name)
+			if err := cascadeError(err); err != nil {
+				return deleted, err
+			}
+			deleted[kind.RBACName()]++
+		}
+	}
+
+	for _, kind := range cascadeKindsV3() {
+		client := GenericClient{
+			Kind:       corev3.V3ToV2Resource(kind),
+			StoreV2:    a.storev2,
+			Auth:       a.auth,
+			APIGroup:   "core",
+			APIVersion: "v3",
+		}
+		var resources []corev2.Resource
+		if err := client.List(namespacedCtx, &resources, &store.SelectionPredicate{}); err != nil {
+			return deleted, err
+		}
+		for _, resource := range resources {
+			err := client.Delete(namespacedCtx, resource.GetObjectMeta().Name)
+			if err := cascadeError(err); err != nil {
+				return deleted, err
+			}
+			deleted[kind.RBACName()]++
+		}
+	}
+
+	return deleted, a.DeleteNamespace(ctx, name)
+}
+
+// isImplicitPipelineRBAC reports whether the resource is the role or role
+// binding created implicitly with each namespace for pipelines.
+func isImplicitPipelineRBAC(resource corev2.Resource) bool {
+	switch resource.(type) {
+	case *corev2.Role, *corev2.RoleBinding:
+		return resource.GetObjectMeta().Name == pipelineRoleName
+	}
+	return false
+}
+
+// cascadeError ignores the errors caused by resources deleted concurrently
+// with DeleteNamespaceCascade.
+func cascadeError(err error) error {
+	var notFound *store.ErrNotFound
+	if errors.As(err, &notFound) {
+		return nil
+	}
+	var apiNotFound *ErrNotFound
+	if errors.As(err, &apiNotFound) {
+		return nil
+	}
+	return err
+}
+
 func namespaceDeleteAttributes(ctx context.Context, name string) *authorization.Attributes {
 	return &authorization.Attributes{
 		APIGroup:     "core",
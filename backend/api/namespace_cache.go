@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// NamespaceCacheMaxSize is the maximum number of namespaces held by a
+// NamespaceCache. Namespaces are read from the store when there are more.
+const NamespaceCacheMaxSize = 10_000
+
+// NamespaceCache is a cache of all the namespaces of the store, shared by the
+// namespace clients so that listing namespaces does not read them from the
+// store for every request. It is refreshed periodically, and must be
+// invalidated when namespaces are created or deleted.
+type NamespaceCache struct {
+	store    store.ResourceStore
+	interval time.Duration
+	maxSize  int
+
+	mu         sync.RWMutex
+	namespaces []*corev2.Namespace
+	valid      bool
+	generation uint64
+
+	refresh chan struct{}
+}
+
+// NewNamespaceCache creates a new NamespaceCache, refreshed from the store at
+// the given interval once started.
+func NewNamespaceCache(store store.ResourceStore, interval time.Duration) *NamespaceCache {
+	return &NamespaceCache{
+		store:    store,
+		interval: interval,
+		maxSize:  NamespaceCacheMaxSize,
+		refresh:  make(chan struct{}, 1),
+	}
+}
+
+// Start loads the namespaces in the background, and reloads them at every
+// interval or as soon as the cache is invalidated, until ctx is canceled.
+func (c *NamespaceCache) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			if err := c.rebuild(ctx); err != nil {
+				logger.WithError(err).Error("couldn't refresh the namespace cache")
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-c.refresh:
+			}
+		}
+	}()
+}
+
+// Get returns the cached namespaces, and whether they are available. They are
+// not available until loaded, after an invalidation, or if there are more
+// namespaces than the cache can hold.
+func (c *NamespaceCache) Get() ([]*corev2.Namespace, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.valid {
+		return nil, false
+	}
+	namespaces := make([]*corev2.Namespace, len(c.namespaces))
+	copy(namespaces, c.namespaces)
+	return namespaces, true
+}
+
+// Invalidate discards the cached namespaces and schedules their reload.
+func (c *NamespaceCache) Invalidate() {
+	c.mu.Lock()
+	c.generation++
+	c.valid = false
+	c.namespaces = nil
+	c.mu.Unlock()
+
+	select {
+	case c.refresh <- struct{}{}:
+	default:
+		// a reload is already scheduled
+	}
+}
+
+func (c *NamespaceCache) rebuild(ctx context.Context) error {
+	c.mu.RLock()
+	generation := c.generation
+	c.mu.RUnlock()
+
+	var namespaces []*corev2.Namespace
+	pred := &store.SelectionPredicate{Limit: int64(c.maxSize)}
+	err := c.store.ListResources(ctx, (&corev2.Namespace{}).StorePrefix(), &namespaces, pred)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		// The cache was invalidated while the namespaces were read, the
+		// pending reload takes care of it.
+		return err
+	}
+	if err != nil {
+		c.valid = false
+		c.namespaces = nil
+		return err
+	}
+	if pred.Continue != "" {
+		logger.Warnf("more than %d namespaces, they will not be cached", c.maxSize)
+		c.valid = false
+		c.namespaces = nil
+		return nil
+	}
+	c.valid = true
+	c.namespaces = namespaces
+	return nil
+}
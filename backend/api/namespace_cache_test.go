@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func mockListNamespaces(s *mockstore.MockStore, namespaces []*corev2.Namespace, cont string, err error) *mock.Call {
+	return s.On("ListResources", mock.Anything, corev2.NamespacesResource, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		resources := args[2].(*[]*corev2.Namespace)
+		*resources = append(*resources, namespaces...)
+		args[3].(*store.SelectionPredicate).Continue = cont
+	}).Return(err)
+}
+
+func TestNamespaceCache(t *testing.T) {
+	namespaces := []*corev2.Namespace{
+		corev2.FixtureNamespace("default"),
+		corev2.FixtureNamespace("dev"),
+	}
+	s := new(mockstore.MockStore)
+	mockListNamespaces(s, namespaces, "", nil).Once()
+
+	cache := NewNamespaceCache(s, time.Minute)
+	_, ok := cache.Get()
+	assert.False(t, ok, "namespaces should not be available before they are loaded")
+
+	require.NoError(t, cache.rebuild(context.Background()))
+	got, ok := cache.Get()
+	require.True(t, ok)
+	assert.Equal(t, namespaces, got)
+
+	cache.Invalidate()
+	_, ok = cache.Get()
+	assert.False(t, ok, "namespaces should not be available once invalidated")
+
+	mockListNamespaces(s, namespaces[:1], "", nil).Once()
+	require.NoError(t, cache.rebuild(context.Background()))
+	got, ok = cache.Get()
+	require.True(t, ok)
+	assert.Equal(t, namespaces[:1], got)
+	s.AssertExpectations(t)
+}
+
+func TestNamespaceCacheOverflow(t *testing.T) {
+	s := new(mockstore.MockStore)
+	mockListNamespaces(s, []*corev2.Namespace{corev2.FixtureNamespace("default")}, "dev", nil)
+
+	cache := NewNamespaceCache(s, time.Minute)
+	cache.maxSize = 1
+	require.NoError(t, cache.rebuild(context.Background()))
+	_, ok := cache.Get()
+	assert.False(t, ok, "namespaces should not be cached when there are too many")
+}
+
+func TestNamespaceCacheError(t *testing.T) {
+	s := new(mockstore.MockStore)
+	mockListNamespaces(s, nil, "", errors.New("error"))
+
+	cache := NewNamespaceCache(s, time.Minute)
+	assert.Error(t, cache.rebuild(context.Background()))
+	_, ok := cache.Get()
+	assert.False(t, ok)
+}
+
+func TestNamespaceClientListFromCache(t *testing.T) {
+	cached := []*corev2.Namespace{corev2.FixtureNamespace("default")}
+	s := new(mockstore.MockStore)
+	mockListNamespaces(s, cached, "", nil).Once()
+
+	cache := NewNamespaceCache(s, time.Minute)
+	require.NoError(t, cache.rebuild(context.Background()))
+
+	client := NewNamespaceClient(s, s, nil, nil).WithCache(cache)
+
+	// The whole list is read from the cache
+	got, err := client.listNamespaceResources(context.Background(), &store.SelectionPredicate{})
+	require.NoError(t, err)
+	assert.Equal(t, cached, got)
+
+	// Pages are read from the store
+	paged := []*corev2.Namespace{corev2.FixtureNamespace("dev")}
+	mockListNamespaces(s, paged, "", nil).Once()
+	got, err = client.listNamespaceResources(context.Background(), &store.SelectionPredicate{Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, paged, got)
+	s.AssertExpectations(t)
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"reflect"
 	"sort"
 	"testing"
@@ -1104,3 +1105,137 @@ func TestNamespaceUpdateSideEffects(t *testing.T) {
 	s2.AssertCalled(t, "List", mock.Anything, mock.Anything)
 	s2.AssertCalled(t, "CreateOrUpdate", mock.Anything, mock.Anything)
 }
+
+func TestNamespaceDeleteCascade(t *testing.T) {
+	clusterRoles := []*corev2.ClusterRole{
+		{
+			ObjectMeta: corev2.NewObjectMeta("cluster-admin", ""),
+			Rules: []corev2.Rule{
+				{
+					Verbs:     []string{corev2.VerbAll},
+					Resources: []string{corev2.ResourceAll},
+				},
+			},
+		},
+	}
+	clusterRoleBindings := []*corev2.ClusterRoleBinding{
+		{
+			Subjects: []corev2.Subject{
+				{
+					Type: corev2.GroupType,
+					Name: "cluster-admins",
+				},
+			},
+			RoleRef: corev2.RoleRef{
+				Type: "ClusterRole",
+				Name: "cluster-admin",
+			},
+			ObjectMeta: corev2.NewObjectMeta("cluster-admin", ""),
+		},
+	}
+
+	tests := []struct {
+		name         string
+		deleteErr    error
+		eventErr     error
+		wantDeleted  map[string]int
+		wantErr      bool
+		wantNsDelete bool
+	}{
+		{
+			name:         "all resources are deleted before the namespace",
+			wantDeleted:  map[string]int{"events": 1, "entities": 1, "checks": 1, "resource_templates": 1},
+			wantNsDelete: true,
+		},
+		{
+			name:        "the first error stops the deletion",
+			deleteErr:   errors.New("boom"),
+			wantDeleted: map[string]int{"events": 1, "entities": 1},
+			wantErr:     true,
+		},
+		{
+			name:         "resources deleted concurrently are ignored",
+			deleteErr:    &store.ErrNotFound{Key: "check1"},
+			wantDeleted:  map[string]int{"events": 1, "entities": 1, "resource_templates": 1},
+			wantNsDelete: true,
+		},
+		{
+			name:         "events deleted concurrently are ignored",
+			eventErr:     &store.ErrNotFound{Key: "entity1/check1"},
+			wantDeleted:  map[string]int{"events": 1, "entities": 1, "checks": 1, "resource_templates": 1},
+			wantNsDelete: true,
+		},
+	}
+	resourceTemplate := &corev3.ResourceTemplate{
+		Metadata:   &corev2.ObjectMeta{Namespace: "acme", Name: "tmpl1"},
+		APIVersion: "core/v2",
+		Type:       "CheckConfig",
+	}
+	wrappedResourceTemplate, err := storev2.WrapResource(resourceTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pipelineRole := &corev2.Role{ObjectMeta: corev2.NewObjectMeta(pipelineRoleName, "acme")}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := new(mockstore.MockStore)
+			s.On("ListClusterRoleBindings", mock.Anything, mock.Anything).Return(clusterRoleBindings, nil)
+			s.On("ListRoleBindings", mock.Anything, mock.Anything).Return(([]*corev2.RoleBinding)(nil), nil)
+			setupGetClusterRoleAndGetRole(s, clusterRoles, nil)
+
+			s.On("GetNamespace", mock.Anything, "acme").Return(corev2.FixtureNamespace("acme"), nil)
+			s.On("GetEvents", mock.Anything, mock.Anything).Return([]*corev2.Event{corev2.FixtureEvent("entity1", "check1")}, nil)
+			s.On("DeleteEventByEntityCheck", mock.Anything, "entity1", "check1").Return(tt.eventErr)
+			s.On("GetEntities", mock.Anything, mock.Anything).Return([]*corev2.Entity{corev2.FixtureEntity("entity1")}, nil)
+			s.On("DeleteEntityByName", mock.Anything, "entity1").Return(nil)
+			s.On("GetEventsByEntity", mock.Anything, "entity1", mock.Anything).Return([]*corev2.Event{}, nil)
+			s.On("ListResources", mock.Anything, new(corev2.CheckConfig).StorePrefix(), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				checks := args[2].(*[]*corev2.CheckConfig)
+				*checks = append(*checks, corev2.FixtureCheckConfig("check1"))
+			}).Return(nil)
+			s.On("ListResources", mock.Anything, new(corev2.Role).StorePrefix(), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				roles := args[2].(*[]*corev2.Role)
+				*roles = append(*roles, pipelineRole)
+			}).Return(nil)
+			s.On("ListResources", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			s.On("DeleteResource", mock.Anything, new(corev2.CheckConfig).StorePrefix(), "check1").Return(tt.deleteErr)
+			implicitDeletes := 0
+			s.On("DeleteResource", mock.Anything, mock.Anything, pipelineRoleName).Run(func(mock.Arguments) {
+				implicitDeletes++
+			}).Return(nil)
+			s.On("DeleteNamespace", mock.Anything, "acme").Return(nil)
+
+			ctx := contextWithUser(context.Background(), "cluster-admin", []string{"cluster-admins"})
+			auth := &rbac.Authorizer{Store: s}
+			s2 := new(mockstore.V2MockStore)
+			s2.On("List", mock.Anything, mock.Anything).Return(wrap.List{wrappedResourceTemplate.(*wrap.Wrapper)}, nil)
+			s2.On("Delete", mock.Anything).Return(nil)
+			client := NewNamespaceClient(s, s, auth, s2)
+			entities := NewEntityClient(s, nil, s, auth)
+			events := NewEventClient(s, auth, nil)
+
+			deleted, err := client.DeleteNamespaceCascade(ctx, "acme", entities, events)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DeleteNamespaceCascade() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(deleted, tt.wantDeleted) {
+				t.Errorf("DeleteNamespaceCascade() = %v, want %v", deleted, tt.wantDeleted)
+			}
+			if tt.wantNsDelete {
+				s.AssertCalled(t, "DeleteNamespace", mock.Anything, "acme")
+			} else {
+				s.AssertNotCalled(t, "DeleteNamespace", mock.Anything, "acme")
+			}
+			// The implicit pipeline role and binding are only deleted along
+			// with the namespace
+			wantImplicit := 0
+			if tt.wantNsDelete {
+				wantImplicit = 2
+			}
+			if implicitDeletes != wantImplicit {
+				t.Errorf("implicit pipeline RBAC deleted %d times, want %d", implicitDeletes, wantImplicit)
+			}
+		})
+	}
+}
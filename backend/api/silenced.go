@@ -11,15 +11,17 @@ import (
 
 // SilencedClient is an API client for silencing checks.
 type SilencedClient struct {
-	store store.SilencedStore
-	auth  authorization.Authorizer
+	store      store.SilencedStore
+	checkStore store.CheckConfigStore
+	auth       authorization.Authorizer
 }
 
 // NewSilencedClient creates a new SilencedClient, given a store and authorizer.
-func NewSilencedClient(store store.SilencedStore, auth authorization.Authorizer) *SilencedClient {
+func NewSilencedClient(store store.Store, auth authorization.Authorizer) *SilencedClient {
 	return &SilencedClient{
-		store: store,
-		auth:  auth,
+		store:      store,
+		checkStore: store,
+		auth:       auth,
 	}
 }
 
@@ -40,6 +42,50 @@ func (s *SilencedClient) UpdateSilenced(ctx context.Context, silenced *corev2.Si
 	return nil
 }
 
+// CreateSilencedFromSelector creates or replaces the silenced entries that the
+// selector expands to, if authorized. The checks of the namespace are only
+// listed when the selector has check labels. The entries are all created at
+// once if the store supports it.
+func (s *SilencedClient) CreateSilencedFromSelector(ctx context.Context, selector *corev2.SilencedSelector) ([]*corev2.Silenced, error) {
+	if err := selector.Validate(); err != nil {
+		return nil, &store.ErrNotValid{Err: fmt.Errorf("couldn't create silenced entries: %s", err)}
+	}
+	var checks []*corev2.CheckConfig
+	if len(selector.CheckLabels) > 0 {
+		if err := authorize(ctx, s.auth, checkListAttributes(ctx)); err != nil {
+			return nil, err
+		}
+		var err error
+		checks, err = s.checkStore.GetCheckConfigs(ctx, &store.SelectionPredicate{})
+		if err != nil {
+			return nil, fmt.Errorf("couldn't list checks: %s", err)
+		}
+	}
+	entries := selector.Expand(corev2.ContextNamespace(ctx), checks)
+	for _, entry := range entries {
+		if err := authorize(ctx, s.auth, silencedUpdateAttrs(ctx, entry.Name)); err != nil {
+			return nil, err
+		}
+		entry.Prepare(ctx)
+		if err := entry.Validate(); err != nil {
+			return nil, &store.ErrNotValid{Err: fmt.Errorf("couldn't create silenced entries: %s", err)}
+		}
+		setCreatedBy(ctx, entry)
+	}
+	if updater, ok := s.store.(store.SilencedBatchUpdater); ok {
+		if err := updater.UpdateSilencedEntries(ctx, entries...); err != nil {
+			return nil, fmt.Errorf("couldn't create silenced entries: %w", err)
+		}
+		return entries, nil
+	}
+	for _, entry := range entries {
+		if err := s.store.UpdateSilencedEntry(ctx, entry); err != nil {
+			return nil, fmt.Errorf("couldn't create silenced entries: %w", err)
+		}
+	}
+	return entries, nil
+}
+
 // GetSilencedByName gets a silenced entry by name, if authorized.
 func (s *SilencedClient) GetSilencedByName(ctx context.Context, name string) (*corev2.Silenced, error) {
 	attrs := silencedFetchAttrs(ctx, name)
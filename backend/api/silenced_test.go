@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -734,3 +735,92 @@ func TestGetSilencedBySubscription(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateSilencedFromSelector(t *testing.T) {
+	cpu := corev2.FixtureCheckConfig("cpu")
+	cpu.Labels = map[string]string{"maintenance": "true"}
+	mem := corev2.FixtureCheckConfig("mem")
+	selector := &corev2.SilencedSelector{CheckLabels: map[string]string{"maintenance": "true"}}
+
+	listChecks := authorization.AttributesKey{
+		APIGroup:   "core",
+		APIVersion: "v2",
+		Namespace:  "default",
+		Resource:   "checks",
+		UserName:   "legit",
+		Verb:       "list",
+	}
+	updateSilenced := authorization.AttributesKey{
+		APIGroup:     "core",
+		APIVersion:   "v2",
+		Namespace:    "default",
+		Resource:     "silenced",
+		ResourceName: "*:cpu",
+		UserName:     "legit",
+		Verb:         "update",
+	}
+
+	tests := []struct {
+		Name   string
+		Attrs  []authorization.AttributesKey
+		Exp    []string
+		ExpErr bool
+	}{
+		{
+			Name:   "can't list checks",
+			Attrs:  []authorization.AttributesKey{updateSilenced},
+			ExpErr: true,
+		},
+		{
+			Name:   "can't update silenced",
+			Attrs:  []authorization.AttributesKey{listChecks},
+			ExpErr: true,
+		},
+		{
+			Name:  "good auth",
+			Attrs: []authorization.AttributesKey{listChecks, updateSilenced},
+			Exp:   []string{"*:cpu"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctx := contextWithUser(defaultContext(), "legit", nil)
+			store := new(mockstore.MockStore)
+			store.On("GetCheckConfigs", mock.Anything, mock.Anything).Return([]*corev2.CheckConfig{cpu, mem}, nil)
+			store.On("UpdateSilencedEntry", mock.Anything, mock.Anything).Return(nil)
+			auth := &mockAuth{attrs: map[authorization.AttributesKey]bool{}}
+			for _, attrs := range test.Attrs {
+				auth.attrs[attrs] = true
+			}
+			client := NewSilencedClient(store, auth)
+			entries, err := client.CreateSilencedFromSelector(ctx, selector)
+			if err != nil && !test.ExpErr {
+				t.Fatal(err)
+			}
+			if err == nil && test.ExpErr {
+				t.Fatal("expected non-nil error")
+			}
+			if test.ExpErr {
+				store.AssertNotCalled(t, "UpdateSilencedEntry", mock.Anything, mock.Anything)
+				return
+			}
+			var names []string
+			for _, entry := range entries {
+				names = append(names, entry.Name)
+			}
+			if got, want := names, test.Exp; !reflect.DeepEqual(got, want) {
+				t.Fatalf("bad silenceds: got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCreateSilencedFromSelectorInvalid(t *testing.T) {
+	ctx := contextWithUser(defaultContext(), "legit", nil)
+	client := NewSilencedClient(new(mockstore.MockStore), &mockAuth{})
+	_, err := client.CreateSilencedFromSelector(ctx, &corev2.SilencedSelector{})
+	var notValid *store.ErrNotValid
+	if !errors.As(err, &notValid) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+}
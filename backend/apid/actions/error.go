@@ -52,6 +52,10 @@ const (
 	// the operation has completed successfully. For example, a successful
 	// response from a server could have been delayed long
 	DeadlineExceeded
+
+	// PayloadTooLarge means that the resource submitted exceeds the maximum
+	// size accepted for it.
+	PayloadTooLarge
 )
 
 // Default error messages if not message is provided.
@@ -65,6 +69,7 @@ var standardErrorMessages = map[ErrCode]string{
 	PaymentRequired:    "license required",
 	PreconditionFailed: "precondition failed",
 	DeadlineExceeded:   "deadline exceeded",
+	PayloadTooLarge:    "payload too large",
 }
 
 // Error describes an issue that ocurred while performing the action.
@@ -0,0 +1,16 @@
+package actions
+
+// FilterTestResult is the outcome of the evaluation of an event against a
+// filter, without handling the event.
+type FilterTestResult struct {
+	// Filter is the name of the filter the event was evaluated against.
+	Filter string `json:"filter"`
+
+	// Denied is true when the filter would prevent the event from being
+	// handled.
+	Denied bool `json:"denied"`
+
+	// Errors are the errors that occurred while evaluating the filter, such
+	// as invalid expressions. They are only logged by the pipeline.
+	Errors []string `json:"errors,omitempty"`
+}
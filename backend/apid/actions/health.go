@@ -29,3 +29,8 @@ func NewHealthController(store store.HealthStore, cluster clientv3.Cluster, etcd
 func (h HealthController) GetClusterHealth(ctx context.Context) *corev2.HealthResponse {
 	return h.store.GetClusterHealth(ctx, h.cluster, h.etcdClientTLSConfig)
 }
+
+// CheckWriteHealth returns an error if the store does not accept writes
+func (h HealthController) CheckWriteHealth(ctx context.Context) error {
+	return h.store.CheckWriteHealth(ctx)
+}
@@ -91,6 +91,7 @@ func (c SilencedController) CreateOrReplace(ctx context.Context, entry *corev2.S
 	return nil
 }
 
+// Get returns the silenced entry with the given name.
 func (c SilencedController) Get(ctx context.Context, name string) (*corev2.Silenced, error) {
 	entry, err := c.Store.GetSilencedEntryByName(ctx, name)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -14,6 +15,8 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 
 	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/apid/graphql"
@@ -49,6 +52,7 @@ type APId struct {
 	cluster             clientv3.Cluster
 	etcdClientTLSConfig *tls.Config
 	clusterVersion      string
+	auditLog            *AuditLog
 }
 
 // Option is a functional option.
@@ -59,6 +63,7 @@ type Config struct {
 	ListenAddress       string
 	RequestLimit        int64
 	WriteTimeout        time.Duration
+	EnableH2C           bool
 	URL                 string
 	Bus                 messaging.MessageBus
 	Store               store.Store
@@ -72,6 +77,47 @@ type Config struct {
 	ClusterVersion      string
 	GraphQLService      *graphql.Service
 	HealthRouter        *routers.HealthRouter
+
+	// PlatformMetricsHandler serves the platform metrics as JSON. The
+	// endpoint is not mounted when nil.
+	PlatformMetricsHandler http.Handler
+
+	// QueueDepthHandler serves the depth of the event pipeline queues as
+	// JSON. The /health/pipeline endpoint is not mounted when nil.
+	QueueDepthHandler http.Handler
+
+	// FilterTester evaluates events against filters for the
+	// /filters/{id}/test endpoint. The endpoint is not mounted when nil.
+	FilterTester routers.FilterTester
+
+	// AuditLogFile is the path of the file the mutating API requests are
+	// recorded to. They are not recorded when empty.
+	AuditLogFile string
+
+	// Auditor records the mutating API requests. It is set by New when
+	// AuditLogFile is configured.
+	Auditor middlewares.Auditor
+
+	// StrictRoundRobinChecks rejects the round robin checks that no agent
+	// entity is subscribed to, instead of only warning about them.
+	StrictRoundRobinChecks bool
+
+	// SecretsValidator rejects the checks referencing secrets that do not
+	// exist. The secrets of the checks are not validated when nil.
+	SecretsValidator routers.SecretsValidator
+
+	// StrictHandlerReferences rejects the handlers that reference filters,
+	// mutators or handlers that do not exist, instead of only warning about
+	// them.
+	StrictHandlerReferences bool
+
+	// MetadataSizeLimit is the maximum total size, in bytes, of the labels and
+	// annotations of the resources written through the API.
+	MetadataSizeLimit int
+
+	// MaxEventSize is the maximum serialized size, in bytes, of the events
+	// created through the API. Events are not limited if 0.
+	MaxEventSize int
 }
 
 // New creates a new APId.
@@ -104,6 +150,11 @@ func New(c Config, opts ...Option) (*APId, error) {
 		}
 	}
 
+	if c.AuditLogFile != "" {
+		a.auditLog = &AuditLog{Path: c.AuditLogFile, Bus: c.Bus}
+		c.Auditor = a.auditLog
+	}
+
 	router := NewRouter()
 	_ = PublicSubrouter(router, c)
 	a.GraphQLSubrouter = GraphQLSubrouter(router, c)
@@ -111,9 +162,19 @@ func New(c Config, opts ...Option) (*APId, error) {
 	a.CoreSubrouter = CoreSubrouter(router, c)
 	a.EntityLimitedCoreSubrouter = EntityLimitedCoreSubrouter(router, c)
 
+	var handler http.Handler = router
+	if c.EnableH2C {
+		if c.TLS != nil {
+			return nil, errors.New("h2c cannot be enabled on a TLS listener")
+		}
+		// Accept HTTP/2 requests over cleartext, for deployments where TLS is
+		// terminated upstream (e.g. by a service mesh sidecar)
+		handler = h2c.NewHandler(router, &http2.Server{})
+	}
+
 	a.HTTPServer = &http.Server{
 		Addr:         c.ListenAddress,
-		Handler:      router,
+		Handler:      handler,
 		WriteTimeout: c.WriteTimeout,
 		ReadTimeout:  15 * time.Second,
 		TLSConfig:    tlsServerConfig,
@@ -159,33 +220,40 @@ func AuthenticationSubrouter(router *mux.Router, cfg Config) *mux.Router {
 // CoreSubrouter initializes a subrouter that handles all requests coming to
 // /api/core/v2
 func CoreSubrouter(router *mux.Router, cfg Config) *mux.Router {
+	checksRouter := routers.NewChecksRouter(cfg.Store, cfg.QueueGetter)
+	checksRouter.StrictRoundRobin = cfg.StrictRoundRobinChecks
+	checksRouter.Secrets = cfg.SecretsValidator
+	handlersRouter := routers.NewHandlersRouter(cfg.Store, &rbac.Authorizer{Store: cfg.Store})
+	handlersRouter.StrictReferences = cfg.StrictHandlerReferences
+
 	subrouter := NewSubrouter(
 		router.PathPrefix("/api/{group:core}/{version:v2}/"),
 		middlewares.Namespace{},
 		middlewares.Authentication{Store: cfg.Store},
 		middlewares.SimpleLogger{},
 		middlewares.AuthorizationAttributes{},
-		middlewares.Authorization{Authorizer: &rbac.Authorizer{Store: cfg.Store}},
+		middlewares.Authorization{Authorizer: &rbac.Authorizer{Store: cfg.Store}, Auditor: cfg.Auditor},
 		middlewares.LimitRequest{Limit: cfg.RequestLimit},
+		middlewares.MetadataSizeLimit{Limit: cfg.MetadataSizeLimit},
 		middlewares.Pagination{},
 	)
 	mountRouters(
 		subrouter,
 		routers.NewAssetRouter(cfg.Store),
 		routers.NewAPIKeysRouter(cfg.Store),
-		routers.NewChecksRouter(cfg.Store, cfg.QueueGetter),
+		checksRouter,
 		routers.NewClusterRolesRouter(cfg.Store),
 		routers.NewClusterRoleBindingsRouter(cfg.Store),
 		routers.NewClusterRouter(actions.NewClusterController(cfg.Cluster, cfg.Store)),
-		routers.NewEventFiltersRouter(cfg.Store),
-		routers.NewHandlersRouter(cfg.Store),
+		routers.NewEventFiltersRouter(cfg.Store).WithTester(cfg.FilterTester),
+		handlersRouter,
 		routers.NewHooksRouter(cfg.Store),
 		routers.NewMutatorsRouter(cfg.Store),
-		routers.NewNamespacesRouter(cfg.Store, cfg.Store, &rbac.Authorizer{Store: cfg.Store}, cfg.Storev2),
+		routers.NewNamespacesRouter(cfg.Store, cfg.Store, &rbac.Authorizer{Store: cfg.Store}, cfg.Storev2).WithCascade(cfg.Store, cfg.EventStore),
 		routers.NewPipelinesRouter(cfg.Store),
 		routers.NewRolesRouter(cfg.Store),
 		routers.NewRoleBindingsRouter(cfg.Store),
-		routers.NewSilencedRouter(cfg.Store),
+		routers.NewSilencedRouter(cfg.Store, &rbac.Authorizer{Store: cfg.Store}),
 		routers.NewTessenRouter(actions.NewTessenController(cfg.Store, cfg.Bus)),
 		routers.NewUsersRouter(cfg.Store),
 	)
@@ -202,14 +270,21 @@ func EntityLimitedCoreSubrouter(router *mux.Router, cfg Config) *mux.Router {
 		middlewares.Authentication{Store: cfg.Store},
 		middlewares.SimpleLogger{},
 		middlewares.AuthorizationAttributes{},
-		middlewares.Authorization{Authorizer: &rbac.Authorizer{Store: cfg.Store}},
+		middlewares.Authorization{Authorizer: &rbac.Authorizer{Store: cfg.Store}, Auditor: cfg.Auditor},
 		middlewares.LimitRequest{Limit: cfg.RequestLimit},
+		middlewares.MetadataSizeLimit{Limit: cfg.MetadataSizeLimit},
 		middlewares.Pagination{},
 	)
+	// Event streams end before the write timeout hangs them up, letting
+	// clients watch again
+	eventsRouter := routers.NewEventsRouter(cfg.EventStore, cfg.Bus)
+	eventsRouter.WatchTimeout = cfg.WriteTimeout * 9 / 10
+	eventsRouter.MaxEventSize = cfg.MaxEventSize
+
 	mountRouters(
 		subrouter,
-		routers.NewEntitiesRouter(cfg.Store, cfg.Storev2, cfg.EventStore),
-		routers.NewEventsRouter(cfg.EventStore, cfg.Bus),
+		routers.NewEntitiesRouter(cfg.Store, cfg.Storev2, cfg.EventStore, &rbac.Authorizer{Store: cfg.Store}),
+		eventsRouter,
 	)
 
 	return subrouter
@@ -245,6 +320,7 @@ func GraphQLSubrouter(router *mux.Router, cfg Config) *mux.Router {
 		&routers.GraphQLRouter{
 			Service: cfg.GraphQLService,
 			Timeout: timeout,
+			Auditor: cfg.Auditor,
 		},
 	)
 
@@ -266,7 +342,14 @@ func PublicSubrouter(router *mux.Router, cfg Config) *mux.Router {
 		routers.NewTessenMetricRouter(actions.NewTessenMetricController(cfg.Bus)),
 	)
 
+	if cfg.QueueDepthHandler != nil {
+		subrouter.Handle("/health/pipeline", cfg.QueueDepthHandler)
+	}
+
 	subrouter.Handle("/metrics", promhttp.Handler())
+	if cfg.PlatformMetricsHandler != nil {
+		subrouter.Handle("/metrics/platform", cfg.PlatformMetricsHandler)
+	}
 
 	return subrouter
 }
@@ -287,6 +370,13 @@ func (a *APId) Start() error {
 		return fmt.Errorf("failed to start apid: %s", err)
 	}
 
+	if a.auditLog != nil {
+		if err := a.auditLog.Start(); err != nil {
+			_ = ln.Close()
+			return fmt.Errorf("failed to start apid: %s", err)
+		}
+	}
+
 	a.wg.Add(1)
 
 	go func() {
@@ -319,6 +409,9 @@ func (a *APId) Stop() error {
 	a.running.Store(false)
 	close(a.stopping)
 	a.wg.Wait()
+	if a.auditLog != nil {
+		a.auditLog.Stop()
+	}
 	close(a.errChan)
 
 	return nil
@@ -0,0 +1,70 @@
+package apid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"syscall"
+
+	"github.com/sensu/sensu-go/backend/apid/middlewares"
+	"github.com/sensu/sensu-go/backend/logging"
+	"github.com/sensu/sensu-go/backend/messaging"
+)
+
+// AuditLog writes the audit records of the API to a file, as newline
+// delimited JSON. Like the event log, the file is reopened on SIGHUP so it can
+// be rotated.
+type AuditLog struct {
+	Path string
+	Bus  messaging.MessageBus
+
+	writer       *logging.RotateWriter
+	sighup       messaging.ChanSubscriber
+	subscription messaging.Subscription
+}
+
+// Start opens the audit log file.
+func (l *AuditLog) Start() error {
+	l.sighup = make(messaging.ChanSubscriber, 1)
+	writer, err := logging.NewRotateWriter(l.Path, l.sighup)
+	if err != nil {
+		close(l.sighup)
+		return fmt.Errorf("could not open the audit log: %v", err)
+	}
+	l.writer = writer
+
+	consumerName := fmt.Sprintf("auditlog://%s", l.Path)
+	subscription, err := l.Bus.Subscribe(messaging.SignalTopic(syscall.SIGHUP), consumerName, l.sighup)
+	if err != nil {
+		_ = l.writer.Close()
+		close(l.sighup)
+		return fmt.Errorf("failed to subscribe audit log to SIGHUP: %v", err)
+	}
+	l.subscription = subscription
+	return nil
+}
+
+// Stop closes the audit log file.
+func (l *AuditLog) Stop() {
+	if l.writer == nil {
+		return
+	}
+	_ = l.subscription.Cancel()
+	close(l.sighup)
+	_ = l.writer.Close()
+}
+
+// Audit implements middlewares.Auditor
+func (l *AuditLog) Audit(ctx context.Context, record middlewares.AuditRecord) {
+	if l.writer == nil {
+		return
+	}
+	b, err := json.Marshal(record)
+	if err != nil {
+		logger.WithError(err).Error("could not encode audit record")
+		return
+	}
+	if _, err := l.writer.Write(append(b, '\n')); err != nil {
+		logger.WithError(err).Error("could not write audit record")
+	}
+}
@@ -31,21 +31,13 @@ func (r *checkCfgImpl) ID(p graphql.ResolveParams) (string, error) {
 // Handlers implements response to request for 'handlers' field.
 func (r *checkCfgImpl) Handlers(p graphql.ResolveParams) (interface{}, error) {
 	src := p.Source.(*corev2.CheckConfig)
-	results, err := loadHandlers(p.Context, src.Namespace)
-	records := filterHandlers(results, func(obj *corev2.Handler) bool {
-		return strings.InArray(obj.Name, src.Handlers)
-	})
-	return records, err
+	return loadHandlersByName(p.Context, src.Namespace, src.Handlers)
 }
 
 // OutputMetricHandlers implements response to request for 'outputMetricHandlers' field.
 func (r *checkCfgImpl) OutputMetricHandlers(p graphql.ResolveParams) (interface{}, error) {
 	src := p.Source.(*corev2.CheckConfig)
-	results, err := loadHandlers(p.Context, src.Namespace)
-	records := filterHandlers(results, func(obj *corev2.Handler) bool {
-		return strings.InArray(obj.Name, src.OutputMetricHandlers)
-	})
-	return records, err
+	return loadHandlersByName(p.Context, src.Namespace, src.OutputMetricHandlers)
 }
 
 // IsSilenced implements response to request for 'isSilenced' field.
@@ -96,11 +88,7 @@ func (r *checkCfgImpl) ToJSON(p graphql.ResolveParams) (interface{}, error) {
 // RuntimeAssets implements response to request for 'runtimeAssets' field.
 func (r *checkCfgImpl) RuntimeAssets(p graphql.ResolveParams) (interface{}, error) {
 	src := p.Source.(*corev2.CheckConfig)
-	records, err := loadAssets(p.Context, src.Namespace)
-	results := filterAssets(records, func(obj *corev2.Asset) bool {
-		return strings.InArray(obj.Name, src.RuntimeAssets)
-	})
-	return results, err
+	return loadAssetsByName(p.Context, src.Namespace, src.RuntimeAssets)
 }
 
 // IsTypeOf is used to determine if a given value is associated with the Check type
@@ -165,11 +153,7 @@ func (r *checkImpl) History(p schema.CheckHistoryFieldResolverParams) (interface
 // Handlers implements response to request for 'handlers' field.
 func (r *checkImpl) Handlers(p graphql.ResolveParams) (interface{}, error) {
 	src := p.Source.(*corev2.Check)
-	results, err := loadHandlers(p.Context, src.Namespace)
-	records := filterHandlers(results, func(obj *corev2.Handler) bool {
-		return strings.InArray(obj.Name, src.Handlers)
-	})
-	return records, err
+	return loadHandlersByName(p.Context, src.Namespace, src.Handlers)
 }
 
 // IsSilenced implements response to request for 'isSilenced' field.
@@ -206,21 +190,13 @@ func (r *checkImpl) Output(p schema.CheckOutputFieldResolverParams) (string, err
 // OutputMetricHandlers implements response to request for 'outputMetricHandlers' field.
 func (r *checkImpl) OutputMetricHandlers(p graphql.ResolveParams) (interface{}, error) {
 	src := p.Source.(*corev2.Check)
-	results, err := loadHandlers(p.Context, src.Namespace)
-	records := filterHandlers(results, func(obj *corev2.Handler) bool {
-		return strings.InArray(obj.Name, src.OutputMetricHandlers)
-	})
-	return records, err
+	return loadHandlersByName(p.Context, src.Namespace, src.OutputMetricHandlers)
 }
 
 // RuntimeAssets implements response to request for 'runtimeAssets' field.
 func (r *checkImpl) RuntimeAssets(p graphql.ResolveParams) (interface{}, error) {
 	src := p.Source.(*corev2.Check)
-	records, err := loadAssets(p.Context, src.Namespace)
-	results := filterAssets(records, func(obj *corev2.Asset) bool {
-		return strings.InArray(obj.Name, src.RuntimeAssets)
-	})
-	return results, err
+	return loadAssetsByName(p.Context, src.Namespace, src.RuntimeAssets)
 }
 
 // ToJSON implements response to request for 'toJSON' field.
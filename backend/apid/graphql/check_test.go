@@ -8,6 +8,7 @@ import (
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/graphql/schema"
+	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/graphql"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -128,11 +129,8 @@ func TestCheckTypeRuntimeAssetsField(t *testing.T) {
 	check.RuntimeAssets = []string{"one", "two"}
 
 	assetClient := new(MockAssetClient)
-	assetClient.On("ListAssets", mock.Anything).Return([]*corev2.Asset{
-		corev2.FixtureAsset("one"),
-		corev2.FixtureAsset("two"),
-		corev2.FixtureAsset("three"),
-	}, nil).Once()
+	assetClient.On("FetchAsset", mock.Anything, "one").Return(corev2.FixtureAsset("one"), nil).Once()
+	assetClient.On("FetchAsset", mock.Anything, "two").Return(corev2.FixtureAsset("two"), nil).Once()
 
 	// return associated silence
 	impl := &checkImpl{}
@@ -196,11 +194,8 @@ func TestCheckConfigTypeRuntimeAssetsField(t *testing.T) {
 	check.RuntimeAssets = []string{"one", "two"}
 
 	assetClient := new(MockAssetClient)
-	assetClient.On("ListAssets", mock.Anything).Return([]*corev2.Asset{
-		corev2.FixtureAsset("one"),
-		corev2.FixtureAsset("two"),
-		corev2.FixtureAsset("three"),
-	}, nil).Once()
+	assetClient.On("FetchAsset", mock.Anything, "one").Return(corev2.FixtureAsset("one"), nil).Once()
+	assetClient.On("FetchAsset", mock.Anything, "two").Return(corev2.FixtureAsset("two"), nil).Once()
 
 	// return associated silence
 	impl := &checkCfgImpl{}
@@ -220,14 +215,11 @@ func TestCheckConfigTypeHandlersField(t *testing.T) {
 	params := graphql.ResolveParams{}
 	client := new(MockHandlerClient)
 
-	// return associated silence
-	client.On("ListHandlers", mock.Anything).Return([]*corev2.Handler{
-		corev2.FixtureHandler("one"),
-		corev2.FixtureHandler("two"),
-		corev2.FixtureHandler("three"),
-		corev2.FixtureHandler("four:five"),
-		corev2.FixtureHandler("six:seven"),
-	}, nil).Once()
+	// return associated handlers
+	client.On("FetchHandler", mock.Anything, "one").Return(corev2.FixtureHandler("one"), nil).Once()
+	client.On("FetchHandler", mock.Anything, "two").Return(corev2.FixtureHandler("two"), nil).Once()
+	client.On("FetchHandler", mock.Anything, "four").Return((*corev2.Handler)(nil), &store.ErrNotFound{Key: "four"}).Once()
+	client.On("FetchHandler", mock.Anything, "six:seven").Return(corev2.FixtureHandler("six:seven"), nil).Once()
 
 	cfg := ServiceConfig{HandlerClient: client}
 	params.Context = contextWithLoadersNoCache(context.Background(), cfg)
@@ -250,14 +242,11 @@ func TestCheckTypeHandlersField(t *testing.T) {
 	params.Context = contextWithLoadersNoCache(context.Background(), cfg)
 	params.Source = check
 
-	// return associated silence
-	client.On("ListHandlers", mock.Anything).Return([]*corev2.Handler{
-		corev2.FixtureHandler("one"),
-		corev2.FixtureHandler("two"),
-		corev2.FixtureHandler("three"),
-		corev2.FixtureHandler("four:five"),
-		corev2.FixtureHandler("six:seven"),
-	}, nil).Once()
+	// return associated handlers
+	client.On("FetchHandler", mock.Anything, "one").Return(corev2.FixtureHandler("one"), nil).Once()
+	client.On("FetchHandler", mock.Anything, "two").Return(corev2.FixtureHandler("two"), nil).Once()
+	client.On("FetchHandler", mock.Anything, "four").Return((*corev2.Handler)(nil), &store.ErrNotFound{Key: "four"}).Once()
+	client.On("FetchHandler", mock.Anything, "six:seven").Return(corev2.FixtureHandler("six:seven"), nil).Once()
 
 	res, err := impl.Handlers(params)
 	require.NoError(t, err)
@@ -276,14 +265,11 @@ func TestCheckConfigTypeOutputMetricHandlersField(t *testing.T) {
 	params.Context = contextWithLoadersNoCache(context.Background(), cfg)
 	params.Source = check
 
-	// return associated silence
-	client.On("ListHandlers", mock.Anything).Return([]*corev2.Handler{
-		corev2.FixtureHandler("one"),
-		corev2.FixtureHandler("two"),
-		corev2.FixtureHandler("three"),
-		corev2.FixtureHandler("four:five"),
-		corev2.FixtureHandler("six:seven"),
-	}, nil).Once()
+	// return associated handlers
+	client.On("FetchHandler", mock.Anything, "one").Return(corev2.FixtureHandler("one"), nil).Once()
+	client.On("FetchHandler", mock.Anything, "two").Return(corev2.FixtureHandler("two"), nil).Once()
+	client.On("FetchHandler", mock.Anything, "four").Return((*corev2.Handler)(nil), &store.ErrNotFound{Key: "four"}).Once()
+	client.On("FetchHandler", mock.Anything, "six:seven").Return(corev2.FixtureHandler("six:seven"), nil).Once()
 
 	res, err := impl.OutputMetricHandlers(params)
 	require.NoError(t, err)
@@ -302,14 +288,11 @@ func TestCheckTypeOutputMetricHandlersField(t *testing.T) {
 	params.Context = contextWithLoadersNoCache(context.Background(), cfg)
 	params.Source = check
 
-	// return associated silence
-	client.On("ListHandlers", mock.Anything).Return([]*corev2.Handler{
-		corev2.FixtureHandler("one"),
-		corev2.FixtureHandler("two"),
-		corev2.FixtureHandler("three"),
-		corev2.FixtureHandler("four:five"),
-		corev2.FixtureHandler("six:seven"),
-	}, nil).Once()
+	// return associated handlers
+	client.On("FetchHandler", mock.Anything, "one").Return(corev2.FixtureHandler("one"), nil).Once()
+	client.On("FetchHandler", mock.Anything, "two").Return(corev2.FixtureHandler("two"), nil).Once()
+	client.On("FetchHandler", mock.Anything, "four").Return((*corev2.Handler)(nil), &store.ErrNotFound{Key: "four"}).Once()
+	client.On("FetchHandler", mock.Anything, "six:seven").Return(corev2.FixtureHandler("six:seven"), nil).Once()
 
 	res, err := impl.OutputMetricHandlers(params)
 	require.NoError(t, err)
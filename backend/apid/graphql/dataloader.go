@@ -4,10 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/graph-gophers/dataloader"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/api"
 	"github.com/sensu/sensu-go/backend/authorization"
 	"github.com/sensu/sensu-go/backend/store"
 )
@@ -17,11 +21,13 @@ type key int
 const (
 	loadersKey key = iota
 	assetsLoaderKey
+	assetLoaderKey
 	checkConfigsLoaderKey
 	entitiesLoaderKey
 	eventsLoaderKey
 	eventFiltersLoaderKey
 	handlersLoaderKey
+	handlerLoaderKey
 	mutatorsLoaderKey
 	namespacesLoaderKey
 	silencedsLoaderKey
@@ -40,6 +46,7 @@ var (
 	errLoadersNotFound        = errors.New("loaders was not found inside context")
 	errLoaderNotFound         = errors.New("loader was not found")
 	errUnexpectedLoaderResult = errors.New("loader returned unexpected result")
+	errLoaderTimeout          = errors.New("load timed out")
 )
 
 // assets
@@ -73,6 +80,81 @@ func loadAssets(ctx context.Context, ns string) ([]*corev2.Asset, error) {
 	return records, err
 }
 
+// asset by name
+
+type assetCacheKey struct {
+	namespace string
+	name      string
+}
+
+func newAssetCacheKey(key string) *assetCacheKey {
+	els := strings.SplitN(key, "\n", 2)
+	return &assetCacheKey{namespace: els[0], name: els[1]}
+}
+
+func (k *assetCacheKey) String() string {
+	return strings.Join([]string{k.namespace, k.name}, "\n")
+}
+
+func (k *assetCacheKey) Raw() interface{} {
+	return k
+}
+
+func loadAssetBatchFn(c AssetClient) dataloader.BatchFunc {
+	return func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		results := make([]*dataloader.Result, 0, len(keys))
+		for _, key := range keys {
+			key := newAssetCacheKey(key.String())
+			ctx := store.NamespaceContext(ctx, key.namespace)
+			record, err := c.FetchAsset(ctx, key.name)
+			data, err := handleFetchResult(record, err)
+			results = append(results, &dataloader.Result{Data: data, Error: err})
+		}
+		return results
+	}
+}
+
+// loadAssetsByName fetches the named assets from the given namespace, rather
+// than the whole catalog of the namespace. Names that are repeated, or that do
+// not refer to an existing asset, are omitted from the result.
+func loadAssetsByName(ctx context.Context, ns string, names []string) ([]*corev2.Asset, error) {
+	records := []*corev2.Asset{}
+	loader, err := getLoader(ctx, assetLoaderKey)
+	if err != nil {
+		return records, err
+	}
+
+	seen := make(map[string]struct{}, len(names))
+	thunks := make([]dataloader.Thunk, 0, len(names))
+	for _, name := range names {
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		key := &assetCacheKey{namespace: ns, name: name}
+		thunks = append(thunks, loader.Load(ctx, key))
+	}
+
+	for _, thunk := range thunks {
+		result, err := thunk()
+		if err != nil {
+			return records, err
+		}
+		if result == nil {
+			continue
+		}
+		record, ok := result.(*corev2.Asset)
+		if !ok {
+			return records, fmt.Errorf("asset loader: %s", errUnexpectedLoaderResult)
+		}
+		if record == nil {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
 // checks
 
 func loadCheckConfigsBatchFn(c CheckClient) dataloader.BatchFunc {
@@ -106,17 +188,21 @@ func loadCheckConfigs(ctx context.Context, ns string) ([]*corev2.CheckConfig, er
 
 // entities
 
+// listEntities reads up to maxSize entities from the store. The entities are
+// fetched in full, since the loader is shared by resolvers that may need any
+// of their fields.
 func listEntities(ctx context.Context, c EntityClient, maxSize int) (records []*corev2.Entity, err error) {
 	pred := &store.SelectionPredicate{Continue: "", Limit: int64(loaderPageSize)}
 	for {
-		r, err := c.ListEntities(ctx, pred)
+		page, err := c.ListEntitiesPage(ctx, pred)
 		if err != nil {
 			return records, err
 		}
-		records = append(records, r...)
-		if pred.Continue == "" || len(r) < loaderPageSize || len(records) >= maxSize {
+		records = append(records, page.Entities...)
+		if page.Continue == "" || len(page.Entities) < loaderPageSize || len(records) >= maxSize {
 			break
 		}
+		pred.Continue = page.Continue
 	}
 	return
 }
@@ -169,8 +255,11 @@ func (k *eventCacheKey) Raw() interface{} {
 	return k
 }
 
-func listEvents(ctx context.Context, c EventClient, entity string, maxSize int) ([]*corev2.Event, error) {
-	pred := &store.SelectionPredicate{Continue: "", Limit: int64(loaderPageSize)}
+// listEvents reads up to maxSize events from the store. The events are
+// restricted to the ones whose check has the subscription, if not empty. The
+// pages of the stores filtering the events by subscription can be short.
+func listEvents(ctx context.Context, c EventClient, entity, subscription string, maxSize int) ([]*corev2.Event, error) {
+	pred := &store.SelectionPredicate{Continue: "", Limit: int64(loaderPageSize), Subscription: subscription}
 	list := func(ctx context.Context, entity string, pred *store.SelectionPredicate) ([]*corev2.Event, error) {
 		if entity == "" {
 			return c.ListEvents(ctx, pred)
@@ -184,7 +273,8 @@ func listEvents(ctx context.Context, c EventClient, entity string, maxSize int)
 			return results, err
 		}
 		results = append(results, r...)
-		if pred.Continue == "" || len(r) < loaderPageSize || len(results) >= maxSize {
+		shortPage := subscription == "" && len(r) < loaderPageSize
+		if pred.Continue == "" || shortPage || len(results) >= maxSize {
 			break
 		}
 	}
@@ -197,7 +287,7 @@ func loadEventsBatchFn(c EventClient) dataloader.BatchFunc {
 		for _, key := range keys {
 			key := newEventCacheKey(key.String())
 			ctx := store.NamespaceContext(ctx, key.namespace)
-			records, err := listEvents(ctx, c, key.entity, maxLengthEventDataloader)
+			records, err := listEvents(ctx, c, key.entity, "", maxLengthEventDataloader)
 			result := &dataloader.Result{Data: records, Error: handleListErr(err)}
 			results = append(results, result)
 		}
@@ -221,6 +311,27 @@ func loadEvents(ctx context.Context, ns, entity string) ([]*corev2.Event, error)
 	return records, err
 }
 
+// loadEntityHealth returns the status of the keepalive of the entity, or -1 if
+// it has none. The keepalive is taken from the events of the entity, so that
+// its health and its events are fetched by the same batched load.
+func loadEntityHealth(ctx context.Context, ns, entity string) (int, error) {
+	records, err := loadEvents(ctx, ns, entity)
+	if err != nil {
+		return -1, err
+	}
+	for _, record := range records {
+		if !record.HasCheck() || record.Check.Name != corev2.KeepaliveCheckName {
+			continue
+		}
+		// web browsers handle values > math.MaxInt32 inconsistently
+		if record.Check.Status > math.MaxInt32 {
+			return math.MaxInt32, nil
+		}
+		return int(record.Check.Status), nil
+	}
+	return -1, nil
+}
+
 // event filters
 
 func loadEventFiltersBatchFn(c EventFilterClient) dataloader.BatchFunc {
@@ -283,6 +394,81 @@ func loadHandlers(ctx context.Context, ns string) ([]*corev2.Handler, error) {
 	return records, err
 }
 
+// handler by name
+
+type handlerCacheKey struct {
+	namespace string
+	name      string
+}
+
+func newHandlerCacheKey(key string) *handlerCacheKey {
+	els := strings.SplitN(key, "\n", 2)
+	return &handlerCacheKey{namespace: els[0], name: els[1]}
+}
+
+func (k *handlerCacheKey) String() string {
+	return strings.Join([]string{k.namespace, k.name}, "\n")
+}
+
+func (k *handlerCacheKey) Raw() interface{} {
+	return k
+}
+
+func loadHandlerBatchFn(c HandlerClient) dataloader.BatchFunc {
+	return func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		results := make([]*dataloader.Result, 0, len(keys))
+		for _, key := range keys {
+			key := newHandlerCacheKey(key.String())
+			ctx := store.NamespaceContext(ctx, key.namespace)
+			record, err := c.FetchHandler(ctx, key.name)
+			data, err := handleFetchResult(record, err)
+			results = append(results, &dataloader.Result{Data: data, Error: err})
+		}
+		return results
+	}
+}
+
+// loadHandlersByName fetches the named handlers from the given namespace.
+// Names that are repeated, or that do not refer to an existing handler, are
+// omitted from the result.
+func loadHandlersByName(ctx context.Context, ns string, names []string) ([]*corev2.Handler, error) {
+	records := []*corev2.Handler{}
+	loader, err := getLoader(ctx, handlerLoaderKey)
+	if err != nil {
+		return records, err
+	}
+
+	seen := make(map[string]struct{}, len(names))
+	thunks := make([]dataloader.Thunk, 0, len(names))
+	for _, name := range names {
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		key := &handlerCacheKey{namespace: ns, name: name}
+		thunks = append(thunks, loader.Load(ctx, key))
+	}
+
+	for _, thunk := range thunks {
+		result, err := thunk()
+		if err != nil {
+			return records, err
+		}
+		if result == nil {
+			continue
+		}
+		record, ok := result.(*corev2.Handler)
+		if !ok {
+			return records, fmt.Errorf("handler loader: %s", errUnexpectedLoaderResult)
+		}
+		if record == nil {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
 // mutators
 
 func loadMutatorsBatchFn(c MutatorClient) dataloader.BatchFunc {
@@ -380,19 +566,141 @@ func contextWithLoaders(ctx context.Context, cfg ServiceConfig, opts ...dataload
 	// rely only on dataloader's cache.
 	opts = append([]dataloader.Option{dataloader.WithBatchCapacity(1)}, opts...)
 
+	newLoader := func(name string, fn dataloader.BatchFunc) *dataloader.Loader {
+		loaderOpts := opts
+		if cfg.LoaderCacheTTL > 0 {
+			// Each loader needs its own cache, since their keys overlap
+			cache := newTTLCache(cfg.LoaderCacheTTL)
+			loaderOpts = append([]dataloader.Option{dataloader.WithCache(cache)}, opts...)
+		}
+		return dataloader.NewBatchedLoader(withLoadTimeout(name, cfg.LoaderTimeout, fn), loaderOpts...)
+	}
+
 	loaders := map[key]*dataloader.Loader{}
-	loaders[assetsLoaderKey] = dataloader.NewBatchedLoader(loadAssetsBatchFn(cfg.AssetClient), opts...)
-	loaders[checkConfigsLoaderKey] = dataloader.NewBatchedLoader(loadCheckConfigsBatchFn(cfg.CheckClient), opts...)
-	loaders[entitiesLoaderKey] = dataloader.NewBatchedLoader(loadEntitiesBatchFn(cfg.EntityClient), opts...)
-	loaders[eventsLoaderKey] = dataloader.NewBatchedLoader(loadEventsBatchFn(cfg.EventClient), opts...)
-	loaders[eventFiltersLoaderKey] = dataloader.NewBatchedLoader(loadEventFiltersBatchFn(cfg.EventFilterClient), opts...)
-	loaders[handlersLoaderKey] = dataloader.NewBatchedLoader(loadHandlersBatchFn(cfg.HandlerClient), opts...)
-	loaders[mutatorsLoaderKey] = dataloader.NewBatchedLoader(loadMutatorsBatchFn(cfg.MutatorClient), opts...)
-	loaders[namespacesLoaderKey] = dataloader.NewBatchedLoader(loadNamespacesBatchFn(cfg.NamespaceClient), opts...)
-	loaders[silencedsLoaderKey] = dataloader.NewBatchedLoader(loadSilencedsBatchFn(cfg.SilencedClient), opts...)
+	loaders[assetsLoaderKey] = newLoader("asset", loadAssetsBatchFn(cfg.AssetClient))
+	loaders[assetLoaderKey] = newLoader("asset", loadAssetBatchFn(cfg.AssetClient))
+	loaders[checkConfigsLoaderKey] = newLoader("check", loadCheckConfigsBatchFn(cfg.CheckClient))
+	loaders[entitiesLoaderKey] = newLoader("entity", loadEntitiesBatchFn(cfg.EntityClient))
+	loaders[eventsLoaderKey] = newLoader("event", loadEventsBatchFn(cfg.EventClient))
+	loaders[eventFiltersLoaderKey] = newLoader("event filter", loadEventFiltersBatchFn(cfg.EventFilterClient))
+	loaders[handlersLoaderKey] = newLoader("handler", loadHandlersBatchFn(cfg.HandlerClient))
+	loaders[handlerLoaderKey] = newLoader("handler", loadHandlerBatchFn(cfg.HandlerClient))
+	loaders[mutatorsLoaderKey] = newLoader("mutator", loadMutatorsBatchFn(cfg.MutatorClient))
+	loaders[namespacesLoaderKey] = newLoader("namespace", loadNamespacesBatchFn(cfg.NamespaceClient))
+	loaders[silencedsLoaderKey] = newLoader("silenced", loadSilencedsBatchFn(cfg.SilencedClient))
 	return context.WithValue(ctx, loadersKey, loaders)
 }
 
+// withLoadTimeout bounds the batch function to the given timeout. The batch
+// function is given a context that is cancelled once the timeout expires; if
+// it has not returned by then, every key of the batch is resolved with a
+// timeout error instead of blocking the request. A timeout of 0 disables the
+// bound.
+func withLoadTimeout(name string, timeout time.Duration, fn dataloader.BatchFunc) dataloader.BatchFunc {
+	if timeout <= 0 {
+		return fn
+	}
+	return func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		done := make(chan []*dataloader.Result, 1)
+		go func() {
+			done <- fn(ctx, keys)
+		}()
+
+		select {
+		case results := <-done:
+			for _, result := range results {
+				if result != nil && errors.Is(result.Error, context.DeadlineExceeded) {
+					result.Error = loadTimeoutErr(name, timeout)
+				}
+			}
+			return results
+		case <-ctx.Done():
+			if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				// the request itself was cancelled
+				return loadErrResults(keys, ctx.Err())
+			}
+			return loadErrResults(keys, loadTimeoutErr(name, timeout))
+		}
+	}
+}
+
+func loadTimeoutErr(name string, timeout time.Duration) error {
+	return fmt.Errorf("%s loader: %w after %s", name, errLoaderTimeout, timeout)
+}
+
+func loadErrResults(keys dataloader.Keys, err error) []*dataloader.Result {
+	results := make([]*dataloader.Result, 0, len(keys))
+	for range keys {
+		results = append(results, &dataloader.Result{Error: err})
+	}
+	return results
+}
+
+// ttlCache is a dataloader cache whose entries expire after a TTL, so that the
+// resources loaded by a long request are eventually loaded again.
+type ttlCache struct {
+	ttl   time.Duration
+	now   func() time.Time
+	mu    sync.Mutex
+	items map[string]ttlCacheItem
+}
+
+type ttlCacheItem struct {
+	thunk   dataloader.Thunk
+	expires time.Time
+}
+
+func newTTLCache(ttl time.Duration) *ttlCache {
+	return &ttlCache{
+		ttl:   ttl,
+		now:   time.Now,
+		items: map[string]ttlCacheItem{},
+	}
+}
+
+// Get returns the thunk cached for the key, unless it expired.
+func (c *ttlCache) Get(_ context.Context, key dataloader.Key) (dataloader.Thunk, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	item, ok := c.items[key.String()]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(item.expires) {
+		delete(c.items, key.String())
+		return nil, false
+	}
+	return item.thunk, true
+}
+
+// Set caches the thunk for the key until the TTL expires.
+func (c *ttlCache) Set(_ context.Context, key dataloader.Key, thunk dataloader.Thunk) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[key.String()] = ttlCacheItem{thunk: thunk, expires: c.now().Add(c.ttl)}
+}
+
+// Delete removes the thunk cached for the key, if any.
+func (c *ttlCache) Delete(_ context.Context, key dataloader.Key) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.items[key.String()]; ok {
+		delete(c.items, key.String())
+		return true
+	}
+	return false
+}
+
+// Clear removes every cached thunk.
+func (c *ttlCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = map[string]ttlCacheItem{}
+}
+
 func getLoader(ctx context.Context, loaderKey key) (*dataloader.Loader, error) {
 	loaders, ok := ctx.Value(loadersKey).(map[key]*dataloader.Loader)
 	if !ok {
@@ -420,11 +728,7 @@ func handleListErr(err error) error {
 // error; as such we omit the error when the API client returns NotFound or
 // Permission denied.
 func handleFetchResult(resource interface{}, err error) (interface{}, error) {
-	if err == authorization.ErrUnauthorized || err == authorization.ErrNoClaims {
-		logger.WithError(err).Warn("couldn't access resource")
-		return nil, nil
-	}
-	if _, ok := err.(*store.ErrNotFound); ok {
+	if isUnauthorizedErr(err) || isNotFoundErr(err) {
 		logger.WithError(err).Warn("couldn't access resource")
 		return nil, nil
 	}
@@ -433,3 +737,21 @@ func handleFetchResult(resource interface{}, err error) (interface{}, error) {
 	}
 	return resource, err
 }
+
+// isUnauthorizedErr reports whether the given error signals that the request
+// was denied. Not all API clients return typed errors yet, so the sentinel
+// errors of the authorization package are also considered.
+func isUnauthorizedErr(err error) bool {
+	var apiErr *api.ErrUnauthorized
+	return errors.As(err, &apiErr) ||
+		errors.Is(err, authorization.ErrUnauthorized) ||
+		errors.Is(err, authorization.ErrNoClaims)
+}
+
+// isNotFoundErr reports whether the given error signals that the requested
+// resource does not exist.
+func isNotFoundErr(err error) bool {
+	var apiErr *api.ErrNotFound
+	var storeErr *store.ErrNotFound
+	return errors.As(err, &apiErr) || errors.As(err, &storeErr)
+}
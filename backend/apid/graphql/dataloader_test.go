@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/graph-gophers/dataloader"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/api"
+	"github.com/sensu/sensu-go/backend/authorization"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/stretchr/testify/mock"
 )
@@ -24,13 +27,21 @@ func Test_listEvents(t *testing.T) {
 		}
 		return result
 	}
+	mkSubscribedEvents := func(num int, subscription string) []*corev2.Event {
+		result := mkEvents(num)
+		for _, event := range result {
+			event.Check.Subscriptions = []string{subscription}
+		}
+		return result
+	}
 	tests := []struct {
-		name    string
-		entity  string
-		maxSize int
-		setup   func(*MockEventClient)
-		wantLen int
-		wantErr bool
+		name         string
+		entity       string
+		subscription string
+		maxSize      int
+		setup        func(*MockEventClient)
+		wantLen      int
+		wantErr      bool
 	}{
 		{
 			name: "single page",
@@ -65,6 +76,27 @@ func Test_listEvents(t *testing.T) {
 			wantLen: 500,
 			wantErr: false,
 		},
+		{
+			name:         "subscription filtered by store",
+			subscription: "unix",
+			setup: func(c *MockEventClient) {
+				isUnix := mock.MatchedBy(func(pred *store.SelectionPredicate) bool {
+					return pred.Subscription == "unix"
+				})
+				// the pages of the filtered events can be short
+				c.On("ListEvents", mock.Anything, isUnix).Return(mkSubscribedEvents(20, "unix"), nil).Run(func(args mock.Arguments) {
+					arg := args.Get(1).(*store.SelectionPredicate)
+					arg.Continue = "test"
+				}).Once()
+				c.On("ListEvents", mock.Anything, isUnix).Return(mkSubscribedEvents(5, "unix"), nil).Run(func(args mock.Arguments) {
+					arg := args.Get(1).(*store.SelectionPredicate)
+					arg.Continue = ""
+				}).Once()
+			},
+			maxSize: 5_000,
+			wantLen: 25,
+			wantErr: false,
+		},
 		{
 			name: "fetch err",
 			setup: func(c *MockEventClient) {
@@ -79,7 +111,7 @@ func Test_listEvents(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			client := new(MockEventClient)
 			tt.setup(client)
-			got, err := listEvents(context.Background(), client, tt.entity, tt.maxSize)
+			got, err := listEvents(context.Background(), client, tt.entity, tt.subscription, tt.maxSize)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("listAllEvents() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -109,7 +141,7 @@ func Test_listEntities(t *testing.T) {
 		{
 			name: "single page",
 			setup: func(c *MockEntityClient) {
-				c.On("ListEntities", mock.Anything, mock.Anything).Return(mkEntities(500), nil).Once()
+				c.On("ListEntitiesPage", mock.Anything, mock.Anything).Return(&api.EntityPage{Entities: mkEntities(500)}, nil).Once()
 			},
 			maxLen:  10_000,
 			wantLen: 500,
@@ -118,11 +150,8 @@ func Test_listEntities(t *testing.T) {
 		{
 			name: "many pages",
 			setup: func(c *MockEntityClient) {
-				c.On("ListEntities", mock.Anything, mock.Anything).Return(mkEntities(2000), nil).Run(func(args mock.Arguments) {
-					arg := args.Get(1).(*store.SelectionPredicate)
-					arg.Continue = "test"
-				}).Once()
-				c.On("ListEntities", mock.Anything, mock.Anything).Return(mkEntities(20), nil).Once()
+				c.On("ListEntitiesPage", mock.Anything, mock.Anything).Return(&api.EntityPage{Entities: mkEntities(2000), Continue: "test"}, nil).Once()
+				c.On("ListEntitiesPage", mock.Anything, mock.Anything).Return(&api.EntityPage{Entities: mkEntities(20)}, nil).Once()
 			},
 			maxLen:  10_000,
 			wantLen: 2020,
@@ -131,10 +160,7 @@ func Test_listEntities(t *testing.T) {
 		{
 			name: "hit upper bounds",
 			setup: func(c *MockEntityClient) {
-				c.On("ListEntities", mock.Anything, mock.Anything).Return(mkEntities(1000), nil).Run(func(args mock.Arguments) {
-					arg := args.Get(1).(*store.SelectionPredicate)
-					arg.Continue = "test"
-				})
+				c.On("ListEntitiesPage", mock.Anything, mock.Anything).Return(&api.EntityPage{Entities: mkEntities(1000), Continue: "test"}, nil)
 			},
 			maxLen:  2500,
 			wantLen: 3000,
@@ -143,7 +169,7 @@ func Test_listEntities(t *testing.T) {
 		{
 			name: "fetch err",
 			setup: func(c *MockEntityClient) {
-				c.On("ListEntities", mock.Anything, mock.Anything).Return(mkEntities(2), errors.New("err")).Once()
+				c.On("ListEntitiesPage", mock.Anything, mock.Anything).Return((*api.EntityPage)(nil), errors.New("err")).Once()
 			},
 			maxLen:  10_000,
 			wantLen: 0,
@@ -165,3 +191,165 @@ func Test_listEntities(t *testing.T) {
 		})
 	}
 }
+
+func Test_loadHandlersByName(t *testing.T) {
+	client := new(MockHandlerClient)
+	client.On("FetchHandler", mock.Anything, "one").Return(corev2.FixtureHandler("one"), nil).Once()
+	client.On("FetchHandler", mock.Anything, "two").Return((*corev2.Handler)(nil), &store.ErrNotFound{Key: "two"}).Once()
+	client.On("FetchHandler", mock.Anything, "three").Return(corev2.FixtureHandler("three"), nil).Once()
+
+	ctx := contextWithLoaders(context.Background(), ServiceConfig{HandlerClient: client})
+
+	// names repeated within and across calls are only fetched once
+	got, err := loadHandlersByName(ctx, "default", []string{"one", "two", "one"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Errorf("loadHandlersByName() = %v, want %v", len(got), 1)
+	}
+	got, err = loadHandlersByName(ctx, "default", []string{"three", "one"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Errorf("loadHandlersByName() = %v, want %v", len(got), 2)
+	}
+	client.AssertExpectations(t)
+}
+
+func Test_loadAssetsByName(t *testing.T) {
+	client := new(MockAssetClient)
+	client.On("FetchAsset", mock.Anything, "one").Return(corev2.FixtureAsset("one"), nil).Once()
+	client.On("FetchAsset", mock.Anything, "two").Return((*corev2.Asset)(nil), &store.ErrNotFound{Key: "two"}).Once()
+	client.On("FetchAsset", mock.Anything, "three").Return(corev2.FixtureAsset("three"), nil).Once()
+
+	ctx := contextWithLoaders(context.Background(), ServiceConfig{AssetClient: client})
+
+	// names repeated within and across calls are only fetched once, and the
+	// catalog of the namespace is never listed
+	got, err := loadAssetsByName(ctx, "default", []string{"one", "two", "one"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Errorf("loadAssetsByName() = %v, want %v", len(got), 1)
+	}
+	got, err = loadAssetsByName(ctx, "default", []string{"three", "one"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Errorf("loadAssetsByName() = %v, want %v", len(got), 2)
+	}
+	client.AssertExpectations(t)
+	client.AssertNotCalled(t, "ListAssets", mock.Anything)
+}
+
+func Test_handleFetchResult(t *testing.T) {
+	entity := corev2.FixtureEntity("sensu")
+	tests := []struct {
+		name    string
+		err     error
+		wantNil bool
+		wantErr bool
+	}{
+		{name: "ok", err: nil},
+		{name: "api not found", err: &api.ErrNotFound{Err: &store.ErrNotFound{Key: "sensu"}}, wantNil: true},
+		{name: "api unauthorized", err: &api.ErrUnauthorized{Err: authorization.ErrUnauthorized}, wantNil: true},
+		{name: "store not found", err: &store.ErrNotFound{Key: "sensu"}, wantNil: true},
+		{name: "unauthorized", err: authorization.ErrNoClaims, wantNil: true},
+		{name: "conflict", err: &api.ErrConflict{Err: &store.ErrAlreadyExists{Key: "sensu"}}, wantNil: true, wantErr: true},
+		{name: "other", err: errors.New("err"), wantNil: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := handleFetchResult(entity, tt.err)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("handleFetchResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (got == nil) != tt.wantNil {
+				t.Errorf("handleFetchResult() = %v, wantNil %v", got, tt.wantNil)
+			}
+		})
+	}
+}
+
+func Test_loaderTimeout(t *testing.T) {
+	client := new(MockAssetClient)
+	client.On("ListAssets", mock.Anything).Run(func(args mock.Arguments) {
+		ctx := args.Get(0).(context.Context)
+		<-ctx.Done()
+	}).Return([]*corev2.Asset(nil), context.DeadlineExceeded).Once()
+
+	cfg := ServiceConfig{AssetClient: client, LoaderTimeout: 10 * time.Millisecond}
+	ctx := contextWithLoaders(context.Background(), cfg)
+	_, err := loadAssets(ctx, "default")
+	if !errors.Is(err, errLoaderTimeout) {
+		t.Fatalf("loadAssets() error = %v, want %v", err, errLoaderTimeout)
+	}
+	if got, want := err.Error(), "asset loader: load timed out after 10ms"; got != want {
+		t.Errorf("loadAssets() error = %q, want %q", got, want)
+	}
+}
+
+func Test_withLoadTimeoutBlocking(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	fn := func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		// ignores the cancellation of its context
+		<-release
+		return nil
+	}
+
+	results := withLoadTimeout("test", 10*time.Millisecond, fn)(context.Background(), dataloader.Keys{dataloader.StringKey("a"), dataloader.StringKey("b")})
+	if len(results) != 2 {
+		t.Fatalf("len(results) = %d, want 2", len(results))
+	}
+	for _, result := range results {
+		if !errors.Is(result.Error, errLoaderTimeout) {
+			t.Errorf("result error = %v, want %v", result.Error, errLoaderTimeout)
+		}
+	}
+}
+
+func Test_loaderCacheTTL(t *testing.T) {
+	client := new(MockAssetClient)
+	client.On("ListAssets", mock.Anything).Return([]*corev2.Asset{corev2.FixtureAsset("one")}, nil).Twice()
+
+	ctx := contextWithLoaders(context.Background(), ServiceConfig{AssetClient: client, LoaderCacheTTL: 20 * time.Millisecond})
+
+	// the assets are cached until the ttl expires
+	for i := 0; i < 2; i++ {
+		if _, err := loadAssets(ctx, "default"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, err := loadAssets(ctx, "default"); err != nil {
+		t.Fatal(err)
+	}
+	client.AssertExpectations(t)
+}
+
+func Test_ttlCache(t *testing.T) {
+	now := time.Now()
+	cache := newTTLCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	key := dataloader.StringKey("a")
+	thunk := func() (interface{}, error) { return "a", nil }
+	cache.Set(ctx, key, thunk)
+
+	if _, ok := cache.Get(ctx, key); !ok {
+		t.Fatal("expected the thunk to be cached")
+	}
+	now = now.Add(time.Minute)
+	if _, ok := cache.Get(ctx, key); ok {
+		t.Fatal("expected the thunk to expire")
+	}
+	if cache.Delete(ctx, key) {
+		t.Error("expected the expired thunk to be removed")
+	}
+}
@@ -136,6 +136,12 @@ func (r *entityImpl) Status(p graphql.ResolveParams) (int, error) {
 	return st, nil
 }
 
+// Health implements response to request for 'health' field.
+func (r *entityImpl) Health(p graphql.ResolveParams) (int, error) {
+	src := p.Source.(*corev2.Entity)
+	return loadEntityHealth(p.Context, src.Namespace, src.Name)
+}
+
 // IsSilenced implements response to request for 'isSilenced' field.
 func (r *entityImpl) IsSilenced(p graphql.ResolveParams) (bool, error) {
 	src := p.Source.(*corev2.Entity)
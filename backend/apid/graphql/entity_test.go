@@ -7,6 +7,7 @@ import (
 	"time"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/api"
 	"github.com/sensu/sensu-go/backend/apid/graphql/schema"
 	"github.com/sensu/sensu-go/graphql"
 	"github.com/stretchr/testify/assert"
@@ -28,11 +29,11 @@ func TestEntityTypeRelatedField(t *testing.T) {
 	source := corev2.FixtureEntity("c")
 
 	client := new(MockEntityClient)
-	client.On("ListEntities", mock.Anything, mock.Anything).Return([]*corev2.Entity{
+	client.On("ListEntitiesPage", mock.Anything, mock.Anything).Return(&api.EntityPage{Entities: []*corev2.Entity{
 		source,
 		corev2.FixtureEntity("a"),
 		corev2.FixtureEntity("b"),
-	}, nil).Once()
+	}}, nil).Once()
 
 	cfg := ServiceConfig{EntityClient: client}
 	params := schema.EntityRelatedFieldResolverParams{ResolveParams: graphql.ResolveParams{Context: context.Background()}}
@@ -97,6 +98,47 @@ func TestEntityTypeStatusField(t *testing.T) {
 	assert.EqualValues(t, math.MaxInt32, st)
 }
 
+func TestEntityTypeHealthField(t *testing.T) {
+	entity := corev2.FixtureEntity("en")
+	entity.Namespace = "sensu"
+
+	keepalive := corev2.FixtureEvent(entity.Name, corev2.KeepaliveCheckName)
+	keepalive.Check.Status = 1
+	failing := corev2.FixtureEvent(entity.Name, "bad")
+	failing.Check.Status = 2
+
+	// the health and the events of the entity share a single load
+	client := new(MockEventClient)
+	client.On("ListEventsByEntity", mock.Anything, entity.Name, mock.Anything).Return([]*corev2.Event{
+		failing,
+		keepalive,
+	}, nil).Once()
+
+	params := graphql.ResolveParams{}
+	cfg := ServiceConfig{EventClient: client}
+	params.Context = contextWithLoaders(context.Background(), cfg)
+	params.Source = entity
+
+	impl := &entityImpl{}
+	health, err := impl.Health(params)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, health)
+
+	events, err := impl.Events(schema.EntityEventsFieldResolverParams{ResolveParams: params})
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+	client.AssertExpectations(t)
+
+	// no keepalive event: -1
+	client.On("ListEventsByEntity", mock.Anything, entity.Name, mock.Anything).Return([]*corev2.Event{
+		failing,
+	}, nil).Once()
+	params.Context = contextWithLoadersNoCache(context.Background(), cfg)
+	health, err = impl.Health(params)
+	require.NoError(t, err)
+	assert.EqualValues(t, -1, health)
+}
+
 func TestEntityTypeLastSeenField(t *testing.T) {
 	now := time.Now()
 
@@ -1,8 +1,10 @@
 package graphql
 
 import (
+	"errors"
+
+	"github.com/sensu/sensu-go/backend/api"
 	"github.com/sensu/sensu-go/backend/apid/graphql/schema"
-	"github.com/sensu/sensu-go/backend/authorization"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/graphql"
 )
@@ -22,14 +24,15 @@ func newStdErr(input string, err error) stdErr {
 		input:   input,
 		message: err.Error(),
 	}
-	if err == authorization.ErrUnauthorized || err == authorization.ErrNoClaims {
+	var conflictErr *api.ErrConflict
+	var existsErr *store.ErrAlreadyExists
+	switch {
+	case isUnauthorizedErr(err):
 		out.code = schema.ErrCodes.ERR_PERMISSION_DENIED
-	}
-	switch err.(type) {
-	case (*store.ErrAlreadyExists):
-		out.code = schema.ErrCodes.ERR_ALREADY_EXISTS
-	case (*store.ErrNotFound):
+	case isNotFoundErr(err):
 		out.code = schema.ErrCodes.ERR_NOT_FOUND
+	case errors.As(err, &conflictErr), errors.As(err, &existsErr):
+		out.code = schema.ErrCodes.ERR_ALREADY_EXISTS
 	}
 	return out
 }
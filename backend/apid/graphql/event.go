@@ -73,6 +73,12 @@ func (r *eventImpl) WasSilenced(p graphql.ResolveParams) (bool, error) {
 	return event.IsSilenced(), nil
 }
 
+// IsFlapping implements response to request for 'isFlapping' field.
+func (r *eventImpl) IsFlapping(p graphql.ResolveParams) (bool, error) {
+	event := p.Source.(*corev2.Event)
+	return event.IsFlapping(), nil
+}
+
 // IsSilenced implements response to request for 'isSilenced' field.
 func (r *eventImpl) IsSilenced(p graphql.ResolveParams) (bool, error) {
 	src := p.Source.(*corev2.Event)
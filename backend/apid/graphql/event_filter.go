@@ -9,7 +9,6 @@ import (
 	"github.com/sensu/sensu-go/backend/apid/graphql/schema"
 	"github.com/sensu/sensu-go/graphql"
 	"github.com/sensu/sensu-go/types"
-	"github.com/sensu/sensu-go/util/strings"
 )
 
 var _ schema.EventFilterFieldResolvers = (*eventFilterImpl)(nil)
@@ -51,9 +50,5 @@ func (*eventFilterImpl) ToJSON(p graphql.ResolveParams) (interface{}, error) {
 // RuntimeAssets implements response to request for 'runtimeAssets' field.
 func (r *eventFilterImpl) RuntimeAssets(p graphql.ResolveParams) (interface{}, error) {
 	src := p.Source.(*corev2.EventFilter)
-	records, err := loadAssets(p.Context, src.Namespace)
-	results := filterAssets(records, func(obj *corev2.Asset) bool {
-		return strings.InArray(obj.Name, src.RuntimeAssets)
-	})
-	return results, err
+	return loadAssetsByName(p.Context, src.Namespace, src.RuntimeAssets)
 }
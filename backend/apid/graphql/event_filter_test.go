@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/graphql"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -16,13 +17,10 @@ func TestEventFilterTypeRuntimeAssetsField(t *testing.T) {
 	filter.RuntimeAssets = []string{"one", "two", "four", "six:seven"}
 
 	assetClient := new(MockAssetClient)
-	assetClient.On("ListAssets", mock.Anything, mock.Anything).Return([]*corev2.Asset{
-		corev2.FixtureAsset("one"),
-		corev2.FixtureAsset("two"),
-		corev2.FixtureAsset("three"),
-		corev2.FixtureAsset("four:five"),
-		corev2.FixtureAsset("six:seven"),
-	}, nil).Once()
+	assetClient.On("FetchAsset", mock.Anything, "one").Return(corev2.FixtureAsset("one"), nil).Once()
+	assetClient.On("FetchAsset", mock.Anything, "two").Return(corev2.FixtureAsset("two"), nil).Once()
+	assetClient.On("FetchAsset", mock.Anything, "four").Return((*corev2.Asset)(nil), &store.ErrNotFound{Key: "four"}).Once()
+	assetClient.On("FetchAsset", mock.Anything, "six:seven").Return(corev2.FixtureAsset("six:seven"), nil).Once()
 
 	// return associated silence
 	impl := &eventFilterImpl{}
@@ -3,6 +3,7 @@ package graphql
 import (
 	v2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/graphql/filter"
+	"github.com/sensu/sensu-go/util/strings"
 )
 
 // EventFilters returns collection of filters used for matching resources.
@@ -38,6 +39,11 @@ func EventFilters() map[string]filter.Filter {
 		"silenced": filter.Boolean(func(res v2.Resource, v bool) bool {
 			return (len(res.(*v2.Event).Check.Silenced) > 0) == v
 		}),
+		// subscription:unix | subscription:db
+		"subscription": filter.String(func(res v2.Resource, v string) bool {
+			event := res.(*v2.Event)
+			return event.HasCheck() && strings.InArray(v, event.Check.Subscriptions)
+		}),
 	}
 
 	// merge global filters
@@ -83,6 +83,24 @@ func TestEventFilters(t *testing.T) {
 				return ev
 			},
 		},
+		{
+			statement: "subscription:unix",
+			expect:    true,
+			setupRecord: func() *v2.Event {
+				ev := v2.FixtureEvent("a", "b")
+				ev.Check.Subscriptions = []string{"unix", "db"}
+				return ev
+			},
+		},
+		{
+			statement: "subscription:windows",
+			expect:    false,
+			setupRecord: func() *v2.Event {
+				ev := v2.FixtureEvent("a", "b")
+				ev.Check.Subscriptions = []string{"unix"}
+				return ev
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -75,6 +75,16 @@ func TestEventTypeIsSilencedField(t *testing.T) {
 	assert.True(t, res)
 }
 
+func TestEventTypeIsFlappingField(t *testing.T) {
+	event := corev2.FixtureEvent("my-entity", "my-check")
+	event.Check.State = corev2.EventFlappingState
+
+	impl := &eventImpl{}
+	res, err := impl.IsFlapping(graphql.ResolveParams{Source: event})
+	require.NoError(t, err)
+	assert.True(t, res)
+}
+
 func TestEventTypeSilencesField(t *testing.T) {
 	event := corev2.FixtureEvent("my-entity", "my-check")
 	event.Check.Subscriptions = []string{"unix"}
@@ -4,23 +4,6 @@ import corev2 "github.com/sensu/sensu-go/api/core/v2"
 
 // TODO: It would be more ideal to generate the functions in this package
 
-// assets
-
-type assetPredicate func(*corev2.Asset) bool
-
-func filterAssets(records []*corev2.Asset, filterFn assetPredicate) []*corev2.Asset {
-	relevant := make([]*corev2.Asset, 0, len(records))
-
-	for i := range records {
-		record := records[i]
-		if filterFn(record) {
-			relevant = append(relevant, record)
-		}
-	}
-
-	return relevant
-}
-
 // entities
 
 type entityPredicate func(*corev2.Entity) bool
@@ -28,9 +28,10 @@ type CheckClient interface {
 type EntityClient interface {
 	DeleteEntity(context.Context, string) error
 	CreateEntity(context.Context, *corev2.Entity) error
-	UpdateEntity(context.Context, *corev2.Entity) error
+	UpdateEntity(context.Context, *corev2.Entity, string, bool) error
 	FetchEntity(context.Context, string) (*corev2.Entity, error)
 	ListEntities(ctx context.Context, pred *store.SelectionPredicate) ([]*corev2.Entity, error)
+	ListEntitiesPage(ctx context.Context, pred *store.SelectionPredicate) (*api.EntityPage, error)
 }
 
 type EventClient interface {
@@ -74,6 +75,7 @@ type SilencedClient interface {
 	ListSilenced(ctx context.Context) ([]*corev2.Silenced, error)
 	GetSilencedByCheckName(ctx context.Context, check string) ([]*corev2.Silenced, error)
 	GetSilencedBySubscription(ctx context.Context, subs ...string) ([]*corev2.Silenced, error)
+	CreateSilencedFromSelector(ctx context.Context, selector *corev2.SilencedSelector) ([]*corev2.Silenced, error)
 }
 
 type NamespaceClient interface {
@@ -88,6 +88,10 @@ func (c *MockSilencedClient) GetSilencedBySubscription(ctx context.Context, subs
 	args := c.Called(ctx, subs)
 	return args.Get(0).([]*corev2.Silenced), args.Error(1)
 }
+func (c *MockSilencedClient) CreateSilencedFromSelector(ctx context.Context, selector *corev2.SilencedSelector) ([]*corev2.Silenced, error) {
+	args := c.Called(ctx, selector)
+	return args.Get(0).([]*corev2.Silenced), args.Error(1)
+}
 
 type MockHandlerClient struct {
 	mock.Mock
@@ -127,8 +131,8 @@ func (c *MockEntityClient) CreateEntity(ctx context.Context, entity *corev2.Enti
 	return c.Called(ctx, entity).Error(0)
 }
 
-func (c *MockEntityClient) UpdateEntity(ctx context.Context, entity *corev2.Entity) error {
-	return c.Called(ctx, entity).Error(0)
+func (c *MockEntityClient) UpdateEntity(ctx context.Context, entity *corev2.Entity, version string, force bool) error {
+	return c.Called(ctx, entity, version, force).Error(0)
 }
 
 func (c *MockEntityClient) FetchEntity(ctx context.Context, name string) (*corev2.Entity, error) {
@@ -141,6 +145,11 @@ func (c *MockEntityClient) ListEntities(ctx context.Context, pred *store.Selecti
 	return args.Get(0).([]*corev2.Entity), args.Error(1)
 }
 
+func (c *MockEntityClient) ListEntitiesPage(ctx context.Context, pred *store.SelectionPredicate) (*api.EntityPage, error) {
+	args := c.Called(ctx, pred)
+	return args.Get(0).(*api.EntityPage), args.Error(1)
+}
+
 type MockEventClient struct {
 	mock.Mock
 }
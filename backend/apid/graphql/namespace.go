@@ -2,6 +2,7 @@ package graphql
 
 import (
 	"sort"
+	"strings"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/graphql/filter"
@@ -266,13 +267,19 @@ func (r *namespaceImpl) Entities(p schema.NamespaceEntitiesFieldResolverParams)
 	}
 
 	matches := 0
+	storeCount := int64(-1)
 	records := make([]*corev2.Entity, 0, p.Args.Limit)
 
 CONTINUE:
-	queryResult, err := r.entityClient.ListEntities(ctx, pred)
+	page, err := r.entityClient.ListEntitiesPage(ctx, pred)
 	if err != nil {
 		return res, err
 	}
+	queryResult := page.Entities
+	pred.Continue = page.Continue
+	if storeCount < 0 {
+		storeCount = page.TotalCount
+	}
 
 	// filter
 	matchFn, err := filter.Compile(p.Args.Filters, EntityFilters(), corev2.EntityFields)
@@ -295,8 +302,9 @@ CONTINUE:
 		if (matches - p.Args.Offset) < p.Args.Limit {
 			goto CONTINUE
 		}
-		// ...or, if we are still determining the total count.
-		if matches < maxCountNamespaceListEntities {
+		// ...or, if we are still determining the total count and the store
+		// could not count the entities for us.
+		if matches < maxCountNamespaceListEntities && (len(p.Args.Filters) > 0 || storeCount < 0) {
 			goto CONTINUE
 		}
 	}
@@ -321,6 +329,12 @@ CONTINUE:
 		logger.Debug("Namespace.Entities: metric store is not present")
 	}
 
+	// Otherwise fallback to the count provided by the store, if any.
+	if !hasTotalCount && len(p.Args.Filters) == 0 && storeCount >= 0 {
+		hasTotalCount = true
+		matches = int(storeCount)
+	}
+
 	// In the case where we ended up scanning the entire keyspace we can also
 	// confidently convey that the total count is complete.
 	if !hasTotalCount && pred.Continue == "" {
@@ -352,6 +366,17 @@ func listEventsOrdering(order schema.EventsListOrder) (string, bool) {
 	}
 }
 
+// subscriptionFilter returns the subscription of the first subscription
+// filter statement, so that the selection can be pushed to the store.
+func subscriptionFilter(statements []string) string {
+	for _, s := range statements {
+		if ss := strings.SplitN(s, ":", 2); len(ss) == 2 && ss[0] == "subscription" {
+			return ss[1]
+		}
+	}
+	return ""
+}
+
 func (r *namespaceImpl) eventsWithInStoreFiltering(p schema.NamespaceEventsFieldResolverParams) (interface{}, error) {
 	res := newOffsetContainer(p.Args.Offset, p.Args.Limit)
 	nsp := p.Source.(*corev2.Namespace)
@@ -360,17 +385,22 @@ func (r *namespaceImpl) eventsWithInStoreFiltering(p schema.NamespaceEventsField
 
 	ordering, direction := listEventsOrdering(p.Args.OrderBy)
 	pred := &store.SelectionPredicate{
-		Limit:      int64(p.Args.Limit),
-		Offset:     int64(p.Args.Offset),
-		Ordering:   ordering,
-		Descending: direction,
+		Limit:        int64(p.Args.Limit),
+		Offset:       int64(p.Args.Offset),
+		Ordering:     ordering,
+		Descending:   direction,
+		Subscription: subscriptionFilter(p.Args.Filters),
 	}
 	events, err := r.eventClient.ListEvents(ctx, pred)
 	if err != nil {
 		return res, err
 	}
-	// No predicate for all events in namespace
-	totalResultCount, err := r.eventClient.CountEvents(ctx, nil)
+	// No predicate for all events in namespace, unless scoped to a subscription
+	var countPred *store.SelectionPredicate
+	if pred.Subscription != "" {
+		countPred = &store.SelectionPredicate{Subscription: pred.Subscription}
+	}
+	totalResultCount, err := r.eventClient.CountEvents(ctx, countPred)
 	if err != nil {
 		return res, err
 	}
@@ -391,7 +421,7 @@ func (r *namespaceImpl) Events(p schema.NamespaceEventsFieldResolverParams) (int
 
 	// fetch
 	ctx := store.NamespaceContext(p.Context, nsp.Name)
-	results, err := listEvents(ctx, r.eventClient, "", maxSizeNamespaceListEvents)
+	results, err := listEvents(ctx, r.eventClient, "", subscriptionFilter(p.Args.Filters), maxSizeNamespaceListEvents)
 	if err != nil {
 		return res, err
 	}
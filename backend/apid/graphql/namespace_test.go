@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/api"
 	"github.com/sensu/sensu-go/backend/apid/graphql/schema"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/graphql"
@@ -43,11 +44,11 @@ func TestNamespaceTypeCheckConfigsField(t *testing.T) {
 
 func TestNamespaceTypeEntitiesField(t *testing.T) {
 	client := new(MockEntityClient)
-	client.On("ListEntities", mock.Anything, mock.Anything).Return([]*corev2.Entity{
+	client.On("ListEntitiesPage", mock.Anything, mock.Anything).Return(&api.EntityPage{Entities: []*corev2.Entity{
 		corev2.FixtureEntity("a"),
 		corev2.FixtureEntity("b"),
 		corev2.FixtureEntity("c"),
-	}, nil).Times(2)
+	}, TotalCount: -1}, nil).Times(2)
 
 	params := schema.NamespaceEntitiesFieldResolverParams{ResolveParams: graphql.ResolveParams{Context: context.Background()}}
 	params.Context = context.Background()
@@ -71,16 +72,13 @@ func TestNamespaceTypeEntitiesField(t *testing.T) {
 	assert.False(t, got.(offsetContainer).PageInfo.partialCount)
 
 	// Multiple chunks
-	client.On("ListEntities", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
-		pred := args[1].(*store.SelectionPredicate)
-		pred.Continue = "next"
-	}).Return([]*corev2.Entity{
+	client.On("ListEntitiesPage", mock.Anything, mock.Anything).Return(&api.EntityPage{Entities: []*corev2.Entity{
 		corev2.FixtureEntity("a"),
 		corev2.FixtureEntity("b"),
 		corev2.FixtureEntity("c"),
 		corev2.FixtureEntity("d"),
 		corev2.FixtureEntity("e"),
-	}, nil).Times(100)
+	}, Continue: "next", TotalCount: -1}, nil).Times(100)
 	params.Args.Limit = 20
 	resolver = &namespaceImpl{entityClient: client}
 	got, err = resolver.Entities(params)
@@ -90,26 +88,20 @@ func TestNamespaceTypeEntitiesField(t *testing.T) {
 	assert.Equal(t, true, got.(offsetContainer).PageInfo.partialCount)
 
 	// Finite chunks
-	client.On("ListEntities", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
-		pred := args[1].(*store.SelectionPredicate)
-		pred.Continue = "next"
-	}).Return([]*corev2.Entity{
+	client.On("ListEntitiesPage", mock.Anything, mock.Anything).Return(&api.EntityPage{Entities: []*corev2.Entity{
 		corev2.FixtureEntity("a"),
 		corev2.FixtureEntity("b"),
 		corev2.FixtureEntity("c"),
 		corev2.FixtureEntity("d"),
 		corev2.FixtureEntity("e"),
-	}, nil).Times(50)
-	client.On("ListEntities", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
-		pred := args[1].(*store.SelectionPredicate)
-		pred.Continue = ""
-	}).Return([]*corev2.Entity{
+	}, Continue: "next", TotalCount: -1}, nil).Times(50)
+	client.On("ListEntitiesPage", mock.Anything, mock.Anything).Return(&api.EntityPage{Entities: []*corev2.Entity{
 		corev2.FixtureEntity("a"),
 		corev2.FixtureEntity("b"),
 		corev2.FixtureEntity("c"),
 		corev2.FixtureEntity("d"),
 		corev2.FixtureEntity("e"),
-	}, nil).Once()
+	}, TotalCount: -1}, nil).Once()
 	params.Args.Limit = 20
 	resolver = &namespaceImpl{entityClient: client}
 	got, err = resolver.Entities(params)
@@ -119,26 +111,20 @@ func TestNamespaceTypeEntitiesField(t *testing.T) {
 	assert.Equal(t, false, got.(offsetContainer).PageInfo.partialCount)
 
 	// w/ offset
-	client.On("ListEntities", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
-		pred := args[1].(*store.SelectionPredicate)
-		pred.Continue = "next"
-	}).Return([]*corev2.Entity{
+	client.On("ListEntitiesPage", mock.Anything, mock.Anything).Return(&api.EntityPage{Entities: []*corev2.Entity{
 		corev2.FixtureEntity("a"),
 		corev2.FixtureEntity("b"),
 		corev2.FixtureEntity("c"),
 		corev2.FixtureEntity("d"),
 		corev2.FixtureEntity("e"),
-	}, nil).Times(50)
-	client.On("ListEntities", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
-		pred := args[1].(*store.SelectionPredicate)
-		pred.Continue = ""
-	}).Return([]*corev2.Entity{
+	}, Continue: "next", TotalCount: -1}, nil).Times(50)
+	client.On("ListEntitiesPage", mock.Anything, mock.Anything).Return(&api.EntityPage{Entities: []*corev2.Entity{
 		corev2.FixtureEntity("a"),
 		corev2.FixtureEntity("b"),
 		corev2.FixtureEntity("c"),
 		corev2.FixtureEntity("d"),
 		corev2.FixtureEntity("e"),
-	}, nil).Once()
+	}, TotalCount: -1}, nil).Once()
 	params.Args.Limit = 20
 	params.Args.Offset = 250
 	resolver = &namespaceImpl{entityClient: client}
@@ -149,10 +135,27 @@ func TestNamespaceTypeEntitiesField(t *testing.T) {
 	assert.Equal(t, false, got.(offsetContainer).PageInfo.partialCount)
 
 	// Store err
-	client.On("ListEntities", mock.Anything, mock.Anything).Return([]*corev2.Entity{}, errors.New("abc")).Once()
+	client.On("ListEntitiesPage", mock.Anything, mock.Anything).Return((*api.EntityPage)(nil), errors.New("abc")).Once()
 	got, err = resolver.Entities(params)
 	assert.Empty(t, got.(offsetContainer).Nodes)
 	assert.Error(t, err)
+
+	// Total count provided by the store
+	client = new(MockEntityClient)
+	client.On("ListEntitiesPage", mock.Anything, mock.Anything).Return(&api.EntityPage{Entities: []*corev2.Entity{
+		corev2.FixtureEntity("a"),
+		corev2.FixtureEntity("b"),
+		corev2.FixtureEntity("c"),
+	}, Continue: "next", TotalCount: 1000}, nil).Once()
+	params.Args.Offset = 0
+	params.Args.Limit = 2
+	resolver = &namespaceImpl{entityClient: client}
+	got, err = resolver.Entities(params)
+	assert.NoError(t, err)
+	assert.Len(t, got.(offsetContainer).Nodes, 2)
+	assert.Equal(t, 1000, got.(offsetContainer).PageInfo.totalCount)
+	assert.False(t, got.(offsetContainer).PageInfo.partialCount)
+	client.AssertExpectations(t)
 }
 
 func TestNamespaceTypeEventsField(t *testing.T) {
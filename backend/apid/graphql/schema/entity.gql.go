@@ -92,6 +92,9 @@ type EntityFieldResolvers interface {
 	// Status implements response to request for 'status' field.
 	Status(p graphql.ResolveParams) (int, error)
 
+	// Health implements response to request for 'health' field.
+	Health(p graphql.ResolveParams) (int, error)
+
 	// Related implements response to request for 'related' field.
 	Related(p EntityRelatedFieldResolverParams) (interface{}, error)
 
@@ -275,6 +278,19 @@ func (_ EntityAliases) Status(p graphql.ResolveParams) (int, error) {
 	return ret, err
 }
 
+// Health implements response to request for 'health' field.
+func (_ EntityAliases) Health(p graphql.ResolveParams) (int, error) {
+	val, err := graphql.DefaultResolver(p.Source, p.Info.FieldName)
+	ret, ok := graphql1.Int.ParseValue(val).(int)
+	if err != nil {
+		return ret, err
+	}
+	if !ok {
+		return ret, errors.New("unable to coerce value for field 'health'")
+	}
+	return ret, err
+}
+
 // Related implements response to request for 'related' field.
 func (_ EntityAliases) Related(p EntityRelatedFieldResolverParams) (interface{}, error) {
 	val, err := graphql.DefaultResolver(p.Source, p.Info.FieldName)
@@ -448,6 +464,15 @@ func _ObjTypeEntityStatusHandler(impl interface{}) graphql1.FieldResolveFn {
 	}
 }
 
+func _ObjTypeEntityHealthHandler(impl interface{}) graphql1.FieldResolveFn {
+	resolver := impl.(interface {
+		Health(p graphql.ResolveParams) (int, error)
+	})
+	return func(frp graphql1.ResolveParams) (interface{}, error) {
+		return resolver.Health(frp)
+	}
+}
+
 func _ObjTypeEntityRelatedHandler(impl interface{}) graphql1.FieldResolveFn {
 	resolver := impl.(interface {
 		Related(p EntityRelatedFieldResolverParams) (interface{}, error)
@@ -548,6 +573,13 @@ func _ObjectTypeEntityConfigFn() graphql1.ObjectConfig {
 				Name:              "events",
 				Type:              graphql1.NewNonNull(graphql1.NewList(graphql1.NewNonNull(graphql.OutputType("Event")))),
 			},
+			"health": &graphql1.Field{
+				Args:              graphql1.FieldConfigArgument{},
+				DeprecationReason: "",
+				Description:       "Health represents the status of the latest keepalive of the entity. If the\nentity has no keepalive event value is -1.",
+				Name:              "health",
+				Type:              graphql1.NewNonNull(graphql1.Int),
+			},
 			"id": &graphql1.Field{
 				Args:              graphql1.FieldConfigArgument{},
 				DeprecationReason: "",
@@ -683,6 +715,7 @@ var _ObjectTypeEntityDesc = graphql.ObjectDesc{
 		"deregistration":    _ObjTypeEntityDeregistrationHandler,
 		"entityClass":       _ObjTypeEntityEntityClassHandler,
 		"events":            _ObjTypeEntityEventsHandler,
+		"health":            _ObjTypeEntityHealthHandler,
 		"id":                _ObjTypeEntityIDHandler,
 		"isSilenced":        _ObjTypeEntityIsSilencedHandler,
 		"lastSeen":          _ObjTypeEntityLastSeenHandler,
@@ -49,6 +49,9 @@ type EventFieldResolvers interface {
 	// IsSilenced implements response to request for 'isSilenced' field.
 	IsSilenced(p graphql.ResolveParams) (bool, error)
 
+	// IsFlapping implements response to request for 'isFlapping' field.
+	IsFlapping(p graphql.ResolveParams) (bool, error)
+
 	// Silences implements response to request for 'silences' field.
 	Silences(p graphql.ResolveParams) (interface{}, error)
 
@@ -193,6 +196,19 @@ func (_ EventAliases) IsSilenced(p graphql.ResolveParams) (bool, error) {
 	return ret, err
 }
 
+// IsFlapping implements response to request for 'isFlapping' field.
+func (_ EventAliases) IsFlapping(p graphql.ResolveParams) (bool, error) {
+	val, err := graphql.DefaultResolver(p.Source, p.Info.FieldName)
+	ret, ok := val.(bool)
+	if err != nil {
+		return ret, err
+	}
+	if !ok {
+		return ret, errors.New("unable to coerce value for field 'isFlapping'")
+	}
+	return ret, err
+}
+
 // Silences implements response to request for 'silences' field.
 func (_ EventAliases) Silences(p graphql.ResolveParams) (interface{}, error) {
 	val, err := graphql.DefaultResolver(p.Source, p.Info.FieldName)
@@ -333,6 +349,15 @@ func _ObjTypeEventIsSilencedHandler(impl interface{}) graphql1.FieldResolveFn {
 	}
 }
 
+func _ObjTypeEventIsFlappingHandler(impl interface{}) graphql1.FieldResolveFn {
+	resolver := impl.(interface {
+		IsFlapping(p graphql.ResolveParams) (bool, error)
+	})
+	return func(frp graphql1.ResolveParams) (interface{}, error) {
+		return resolver.IsFlapping(frp)
+	}
+}
+
 func _ObjTypeEventSilencesHandler(impl interface{}) graphql1.FieldResolveFn {
 	resolver := impl.(interface {
 		Silences(p graphql.ResolveParams) (interface{}, error)
@@ -392,6 +417,13 @@ func _ObjectTypeEventConfigFn() graphql1.ObjectConfig {
 				Name:              "id",
 				Type:              graphql1.NewNonNull(graphql1.ID),
 			},
+			"isFlapping": &graphql1.Field{
+				Args:              graphql1.FieldConfigArgument{},
+				DeprecationReason: "",
+				Description:       "isFlapping returns true if the check of the event is flapping, according to\nits flap thresholds.",
+				Name:              "isFlapping",
+				Type:              graphql1.NewNonNull(graphql1.Boolean),
+			},
 			"isIncident": &graphql1.Field{
 				Args:              graphql1.FieldConfigArgument{},
 				DeprecationReason: "",
@@ -495,6 +527,7 @@ var _ObjectTypeEventDesc = graphql.ObjectDesc{
 		"entity":        _ObjTypeEventEntityHandler,
 		"hooks":         _ObjTypeEventHooksHandler,
 		"id":            _ObjTypeEventIDHandler,
+		"isFlapping":    _ObjTypeEventIsFlappingHandler,
 		"isIncident":    _ObjTypeEventIsIncidentHandler,
 		"isNewIncident": _ObjTypeEventIsNewIncidentHandler,
 		"isResolution":  _ObjTypeEventIsResolutionHandler,
@@ -98,7 +98,7 @@ type NamespaceEventsFieldResolverArgs struct {
 	Filters []string        /*
 	Filters reduces the set using given arbitrary expression[s]; expressions
 	take on the form KEY: VALUE. The accepted key(s) are: status, check, entity,
-	& silenced.
+	silenced & subscription.
 
 	Eg.
 
@@ -108,6 +108,7 @@ type NamespaceEventsFieldResolverArgs struct {
 	check:check-disk
 	entity:venice
 	silenced:true
+	subscription:unix
 	*/
 }
 
@@ -547,7 +548,7 @@ func _ObjectTypeNamespaceConfigFn() graphql1.ObjectConfig {
 					},
 					"filters": &graphql1.ArgumentConfig{
 						DefaultValue: []interface{}{},
-						Description:  "Filters reduces the set using given arbitrary expression[s]; expressions\ntake on the form KEY: VALUE. The accepted key(s) are: status, check, entity,\n& silenced.\n\nEg.\n\nstatus:passing\nstatus:warning\nstatus:incident\ncheck:check-disk\nentity:venice\nsilenced:true",
+						Description:  "Filters reduces the set using given arbitrary expression[s]; expressions\ntake on the form KEY: VALUE. The accepted key(s) are: status, check, entity,\nsilenced & subscription.\n\nEg.\n\nstatus:passing\nstatus:warning\nstatus:incident\ncheck:check-disk\nentity:venice\nsilenced:true\nsubscription:unix",
 						Type:         graphql1.NewList(graphql1.NewNonNull(graphql1.String)),
 					},
 					"limit": &graphql1.ArgumentConfig{
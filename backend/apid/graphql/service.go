@@ -2,6 +2,7 @@ package graphql
 
 import (
 	"context"
+	"time"
 
 	"github.com/sensu/sensu-go/backend/apid/graphql/relay"
 	"github.com/sensu/sensu-go/backend/apid/graphql/schema"
@@ -40,6 +41,25 @@ type ServiceConfig struct {
 	GenericClient      GenericClient
 	MetricGatherer     MetricGatherer
 	ClusterMetricStore ClusterMetricStore
+
+	// MaxQueryDepth and MaxQueryComplexity reject the queries that are too
+	// expensive to execute, before any resource is loaded. They are unlimited
+	// when 0.
+	MaxQueryDepth      int
+	MaxQueryComplexity int
+
+	// LoaderTimeout bounds each load of resources from the store made while
+	// resolving a query. Loads are unbounded when 0.
+	LoaderTimeout time.Duration
+
+	// LoaderCacheTTL is the duration after which the resources cached while
+	// resolving a query are loaded again, bounding their staleness within
+	// long requests. They are cached for the whole request when 0.
+	LoaderCacheTTL time.Duration
+
+	// DisableIntrospection rejects the __schema and __type introspection
+	// queries.
+	DisableIntrospection bool
 }
 
 // Service describes the Sensu GraphQL service capable of handling queries.
@@ -52,6 +72,11 @@ type Service struct {
 // NewService instantiates new GraphQL service
 func NewService(cfg ServiceConfig) (*Service, error) {
 	svc := graphql.NewService()
+	svc.Limits = graphql.QueryLimits{
+		MaxDepth:      cfg.MaxQueryDepth,
+		MaxComplexity: cfg.MaxQueryComplexity,
+	}
+	svc.DisableIntrospection = cfg.DisableIntrospection
 
 	nodeRegister := relay.NodeRegister{}
 	nodeResolver := relay.Resolver{Register: &nodeRegister}
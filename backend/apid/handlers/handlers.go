@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/url"
@@ -11,15 +12,68 @@ import (
 	storev2 "github.com/sensu/sensu-go/backend/store/v2"
 )
 
+// DefaultMetadataSizeLimit is the default maximum total size, in bytes, of the
+// labels and annotations of a resource.
+const DefaultMetadataSizeLimit = 256 * 1024
+
+type metadataSizeLimitKey struct{}
+
+// ContextWithMetadataSizeLimit returns a copy of ctx in which the labels and
+// annotations of the resources checked by CheckMeta are limited to limit bytes
+// in total.
+func ContextWithMetadataSizeLimit(ctx context.Context, limit int) context.Context {
+	return context.WithValue(ctx, metadataSizeLimitKey{}, limit)
+}
+
+// MetadataSizeLimitFromContext returns the metadata size limit stored in ctx,
+// or 0, disabling the limit, if there is none.
+func MetadataSizeLimitFromContext(ctx context.Context) int {
+	if limit, ok := ctx.Value(metadataSizeLimitKey{}).(int); ok {
+		return limit
+	}
+	return 0
+}
+
 // Handlers represents the HTTP handlers for CRUD operations on resources
 type Handlers struct {
 	Resource   corev2.Resource
 	V3Resource corev3.Resource
 	Store      store.ResourceStore
 	StoreV2    storev2.Interface
+
+	// PatchValidator, if set, validates the patched core/v2 resources before
+	// they are stored, as the routers validate the resources they create.
+	PatchValidator func(context.Context, corev2.Resource) error
 }
 
-func checkMeta(meta corev2.ObjectMeta, vars map[string]string, idVar string) error {
+// ValidateMetadataSize returns an error if the labels and annotations of the
+// metadata exceed limit bytes in total. The limit is disabled when 0.
+func ValidateMetadataSize(meta corev2.ObjectMeta, limit int) error {
+	if limit <= 0 {
+		return nil
+	}
+	size := 0
+	for k, v := range meta.Labels {
+		size += len(k) + len(v)
+	}
+	for k, v := range meta.Annotations {
+		size += len(k) + len(v)
+	}
+	if size > limit {
+		return fmt.Errorf(
+			"the labels and annotations of the resource are %d bytes in total, exceeding the limit of %d bytes",
+			size,
+			limit,
+		)
+	}
+	return nil
+}
+
+func checkMeta(ctx context.Context, meta corev2.ObjectMeta, vars map[string]string, idVar string) error {
+	if err := ValidateMetadataSize(meta, MetadataSizeLimitFromContext(ctx)); err != nil {
+		return err
+	}
+
 	namespace, err := url.PathUnescape(vars["namespace"])
 	if err != nil {
 		return err
@@ -57,7 +111,7 @@ func checkMeta(meta corev2.ObjectMeta, vars map[string]string, idVar string) err
 
 // V3CheckMeta inspects the resource metadata and ensures it matches what was
 // specified in the request URL. Unlike CheckMeta it operates on v3 resources.
-func CheckV3Meta(resource interface{}, vars map[string]string, idVar string) error {
+func CheckV3Meta(ctx context.Context, resource interface{}, vars map[string]string, idVar string) error {
 	v, ok := resource.(interface{ GetMetadata() *corev2.ObjectMeta })
 	if !ok {
 		// We are not dealing with a corev3.Resource interface
@@ -67,19 +121,20 @@ func CheckV3Meta(resource interface{}, vars map[string]string, idVar string) err
 	if meta == nil {
 		return errors.New("nil metadata")
 	}
-	return checkMeta(*meta, vars, idVar)
+	return checkMeta(ctx, *meta, vars, idVar)
 }
 
 // CheckMeta inspects the resource metadata and ensures it matches what was
-// specified in the request URL
-func CheckMeta(resource interface{}, vars map[string]string, idVar string) error {
+// specified in the request URL, and that its labels and annotations do not
+// exceed the metadata size limit of ctx
+func CheckMeta(ctx context.Context, resource interface{}, vars map[string]string, idVar string) error {
 	v, ok := resource.(interface{ GetObjectMeta() corev2.ObjectMeta })
 	if !ok {
 		// We are not dealing with a corev2.Resource interface
 		return nil
 	}
 	meta := v.GetObjectMeta()
-	return checkMeta(meta, vars, idVar)
+	return checkMeta(ctx, meta, vars, idVar)
 }
 
 // Resource is used to set metadata values, e.g. in MetaPathValues()
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -13,6 +14,7 @@ func TestCheckMeta(t *testing.T) {
 		name     string
 		resource corev2.Resource
 		vars     map[string]string
+		limit    int
 		wantErr  bool
 	}{
 		{
@@ -32,16 +34,67 @@ func TestCheckMeta(t *testing.T) {
 			resource: &fixture.Resource{ObjectMeta: corev2.ObjectMeta{Name: "baz", Namespace: "foo"}},
 			vars:     map[string]string{"namespace": "foo", "id": "baz"},
 		},
+		{
+			name:     "metadata over the size limit",
+			resource: &fixture.Resource{ObjectMeta: corev2.ObjectMeta{Name: "baz", Namespace: "foo", Labels: map[string]string{"region": "us-west-1"}}},
+			vars:     map[string]string{"namespace": "foo", "id": "baz"},
+			limit:    10,
+			wantErr:  true,
+		},
+		{
+			name:     "metadata under the size limit",
+			resource: &fixture.Resource{ObjectMeta: corev2.ObjectMeta{Name: "baz", Namespace: "foo", Labels: map[string]string{"region": "us-west-1"}}},
+			vars:     map[string]string{"namespace": "foo", "id": "baz"},
+			limit:    100,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := CheckMeta(tt.resource, tt.vars, "id"); (err != nil) != tt.wantErr {
+			ctx := ContextWithMetadataSizeLimit(context.Background(), tt.limit)
+			if err := CheckMeta(ctx, tt.resource, tt.vars, "id"); (err != nil) != tt.wantErr {
 				t.Errorf("checkMeta() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
+func TestValidateMetadataSize(t *testing.T) {
+	meta := corev2.ObjectMeta{
+		Labels:      map[string]string{"region": "us-west-1"},
+		Annotations: map[string]string{"runbook": "http://example.com"},
+	}
+	tests := []struct {
+		name    string
+		limit   int
+		wantErr bool
+	}{
+		{
+			name:  "under the limit",
+			limit: 100,
+		},
+		{
+			name:  "at the limit",
+			limit: len("region") + len("us-west-1") + len("runbook") + len("http://example.com"),
+		},
+		{
+			name:    "over the limit",
+			limit:   10,
+			wantErr: true,
+		},
+		{
+			name:  "disabled",
+			limit: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateMetadataSize(meta, tt.limit); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMetadataSize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func marshal(t *testing.T, v interface{}) []byte {
 	t.Helper()
 	bytes, err := json.Marshal(v)
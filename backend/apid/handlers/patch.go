@@ -103,6 +103,15 @@ func (h Handlers) patchV2Resource(ctx context.Context, body []byte, name string,
 		return nil, actions.NewErrorf(actions.InvalidArgument)
 	}
 
+	if h.PatchValidator != nil {
+		patcher = &validatingPatcher{
+			Patcher:  patcher,
+			ctx:      ctx,
+			resource: payload.Type().Elem(),
+			validate: h.PatchValidator,
+		}
+	}
+
 	if err := h.Store.PatchResource(ctx, resource, name, patcher, conditions); err != nil {
 		switch err := err.(type) {
 		case *store.ErrNotFound:
@@ -198,3 +207,30 @@ func validatePatch(data []byte, vars map[string]string) error {
 
 	return nil
 }
+
+// validatingPatcher validates the patched document, decoded into a new
+// resource, before the store saves it.
+type validatingPatcher struct {
+	patch.Patcher
+	ctx      context.Context
+	resource reflect.Type
+	validate func(context.Context, corev2.Resource) error
+}
+
+func (p *validatingPatcher) Patch(document []byte) ([]byte, error) {
+	patched, err := p.Patcher.Patch(document)
+	if err != nil {
+		return nil, err
+	}
+	resource, ok := reflect.New(p.resource).Interface().(corev2.Resource)
+	if !ok {
+		return patched, nil
+	}
+	if err := json.Unmarshal(patched, resource); err != nil {
+		return nil, &store.ErrNotValid{Err: err}
+	}
+	if err := p.validate(p.ctx, resource); err != nil {
+		return nil, &store.ErrNotValid{Err: err}
+	}
+	return patched, nil
+}
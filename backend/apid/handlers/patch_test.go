@@ -1,6 +1,16 @@
 package handlers
 
-import "testing"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/patch"
+)
 
 func TestValidatePatch(t *testing.T) {
 	tests := []struct {
@@ -61,3 +71,49 @@ func TestValidatePatch(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatingPatcher(t *testing.T) {
+	document, err := json.Marshal(corev2.FixtureCheckConfig("check"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	validate := func(_ context.Context, resource corev2.Resource) error {
+		if resource.(*corev2.CheckConfig).Command == "invalid" {
+			return errors.New("invalid command")
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name    string
+		patch   string
+		wantErr bool
+	}{
+		{
+			name:  "valid patched resource",
+			patch: `{"command":"valid"}`,
+		},
+		{
+			name:    "invalid patched resource",
+			patch:   `{"command":"invalid"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &validatingPatcher{
+				Patcher:  &patch.Merge{MergePatch: []byte(tt.patch)},
+				ctx:      context.Background(),
+				resource: reflect.TypeOf(corev2.CheckConfig{}),
+				validate: validate,
+			}
+			_, err := p.Patch(document)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validatingPatcher.Patch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, ok := err.(*store.ErrNotValid); tt.wantErr && !ok {
+				t.Errorf("validatingPatcher.Patch() error = %T, want *store.ErrNotValid", err)
+			}
+		})
+	}
+}
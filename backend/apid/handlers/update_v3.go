@@ -21,7 +21,7 @@ func (h Handlers) CreateOrUpdateV3Resource(r *http.Request) (interface{}, error)
 		return nil, actions.NewError(actions.InvalidArgument, err)
 	}
 
-	if err := CheckV3Meta(payload.Interface(), mux.Vars(r), "id"); err != nil {
+	if err := CheckV3Meta(r.Context(), payload.Interface(), mux.Vars(r), "id"); err != nil {
 		return nil, actions.NewError(actions.InvalidArgument, err)
 	}
 
@@ -0,0 +1,93 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/sensu/sensu-go/backend/authorization"
+)
+
+// Auditor records the mutating requests allowed by the Authorization
+// middleware.
+type Auditor interface {
+	Audit(ctx context.Context, record AuditRecord)
+}
+
+// AuditRecord describes who changed what, and when.
+type AuditRecord struct {
+	Timestamp  time.Time `json:"timestamp"`
+	User       string    `json:"user"`
+	Verb       string    `json:"verb"`
+	APIGroup   string    `json:"api_group"`
+	APIVersion string    `json:"api_version"`
+	Resource   string    `json:"resource"`
+	Namespace  string    `json:"namespace,omitempty"`
+	Name       string    `json:"name,omitempty"`
+
+	// Status is the HTTP status of the response to the request.
+	Status int `json:"status"`
+
+	// Errors are the error messages of a GraphQL operation.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// NewAuditRecord returns the audit record of a request from its authorization
+// attributes.
+func NewAuditRecord(attrs *authorization.Attributes, timestamp time.Time) AuditRecord {
+	return AuditRecord{
+		Timestamp:  timestamp.UTC(),
+		User:       attrs.User.Username,
+		Verb:       attrs.Verb,
+		APIGroup:   attrs.APIGroup,
+		APIVersion: attrs.APIVersion,
+		Resource:   attrs.Resource,
+		Namespace:  attrs.Namespace,
+		Name:       attrs.ResourceName,
+	}
+}
+
+// IsMutating returns whether requests with the given verb modify resources.
+func IsMutating(verb string) bool {
+	switch verb {
+	case "create", "update", "delete":
+		return true
+	}
+	return false
+}
+
+// statusRecorder is an http.ResponseWriter that keeps track of the status of
+// the response, for the audit record of the request.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Status returns the status of the response, http.StatusOK if the handler
+// wrote none.
+func (r *statusRecorder) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
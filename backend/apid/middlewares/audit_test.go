@@ -0,0 +1,94 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/authorization"
+)
+
+type allowAuthorizer struct {
+	authorized bool
+}
+
+func (a allowAuthorizer) Authorize(context.Context, *authorization.Attributes) (bool, error) {
+	return a.authorized, nil
+}
+
+type recordingAuditor struct {
+	records []AuditRecord
+}
+
+func (a *recordingAuditor) Audit(_ context.Context, record AuditRecord) {
+	a.records = append(a.records, record)
+}
+
+func TestAuthorizationAudit(t *testing.T) {
+	tests := []struct {
+		name       string
+		verb       string
+		authorized bool
+		audited    bool
+		status     int
+	}{
+		{name: "create", verb: "create", authorized: true, audited: true},
+		{name: "failed create", verb: "create", authorized: true, audited: true, status: http.StatusConflict},
+		{name: "update", verb: "update", authorized: true, audited: true},
+		{name: "delete", verb: "delete", authorized: true, audited: true},
+		{name: "get", verb: "get", authorized: true, audited: false},
+		{name: "list", verb: "list", authorized: true, audited: false},
+		{name: "unauthorized", verb: "delete", authorized: false, audited: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditor := &recordingAuditor{}
+			middleware := Authorization{
+				Authorizer: allowAuthorizer{authorized: tt.authorized},
+				Auditor:    auditor,
+			}
+			handler := middleware.Then(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+				}
+			}))
+
+			attrs := &authorization.Attributes{
+				APIGroup:     "core",
+				APIVersion:   "v2",
+				Namespace:    "default",
+				Resource:     "checks",
+				ResourceName: "check-cpu",
+				User:         corev2.User{Username: "alice"},
+				Verb:         tt.verb,
+			}
+			r := httptest.NewRequest(http.MethodPut, "/api/core/v2/namespaces/default/checks/check-cpu", nil)
+			r = r.WithContext(authorization.SetAttributes(r.Context(), attrs))
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+
+			if !tt.audited {
+				assert.Empty(t, auditor.records)
+				return
+			}
+			require.Len(t, auditor.records, 1)
+			record := auditor.records[0]
+			assert.Equal(t, "alice", record.User)
+			assert.Equal(t, tt.verb, record.Verb)
+			assert.Equal(t, "checks", record.Resource)
+			assert.Equal(t, "default", record.Namespace)
+			assert.Equal(t, "check-cpu", record.Name)
+			assert.WithinDuration(t, time.Now(), record.Timestamp, time.Minute)
+			wantStatus := tt.status
+			if wantStatus == 0 {
+				wantStatus = http.StatusOK
+			}
+			assert.Equal(t, wantStatus, record.Status)
+		})
+	}
+}
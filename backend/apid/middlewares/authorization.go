@@ -2,6 +2,7 @@ package middlewares
 
 import (
 	"net/http"
+	"time"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/actions"
@@ -12,6 +13,10 @@ import (
 // Authorization is an HTTP middleware that enforces authorization
 type Authorization struct {
 	Authorizer authorization.Authorizer
+
+	// Auditor, if set, records the mutating requests that are authorized,
+	// once they are handled
+	Auditor Auditor
 }
 
 func namespaceGetAttrs(attrs *authorization.Attributes) bool {
@@ -63,6 +68,15 @@ func (a Authorization) Then(next http.Handler) http.Handler {
 			return
 		}
 
+		if a.Auditor != nil && IsMutating(attrs.Verb) {
+			record := NewAuditRecord(attrs, time.Now())
+			recorder := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(recorder, r.WithContext(ctx))
+			record.Status = recorder.Status()
+			a.Auditor.Audit(ctx, record)
+			return
+		}
+
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
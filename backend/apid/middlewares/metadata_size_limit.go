@@ -0,0 +1,22 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/sensu/sensu-go/backend/apid/handlers"
+)
+
+// MetadataSizeLimit is an HTTP middleware that limits the total size, in
+// bytes, of the labels and annotations of the resources written by the
+// request. The limit is disabled when 0.
+type MetadataSizeLimit struct {
+	Limit int
+}
+
+// Then middleware
+func (m MetadataSizeLimit) Then(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := handlers.ContextWithMetadataSizeLimit(r.Context(), m.Limit)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -1,19 +1,26 @@
 package routers
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/apid/handlers"
+	"github.com/sensu/sensu-go/backend/secrets"
 	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/token"
 	"github.com/sensu/sensu-go/types"
+	stringsutil "github.com/sensu/sensu-go/util/strings"
 )
 
 // checkController represents the controller needs of the ChecksRouter.
@@ -23,21 +30,48 @@ type checkController interface {
 	QueueAdhocRequest(context.Context, string, *corev2.AdhocRequest) error
 }
 
+// entityLister represents the store needs of the round robin validation of
+// the ChecksRouter.
+type entityLister interface {
+	GetEntities(context.Context, *store.SelectionPredicate) ([]*corev2.Entity, error)
+}
+
+// SecretsValidator verifies that the secrets referenced by checks exist.
+type SecretsValidator interface {
+	ValidateSecrets(context.Context, []*corev2.Secret) error
+}
+
+// roundRobinEntitiesPageSize is the number of entities read at once when
+// looking for the entities subscribed to a round robin check.
+const roundRobinEntitiesPageSize = 500
+
 // ChecksRouter handles requests for /checks
 type ChecksRouter struct {
 	controller checkController
 	handlers   handlers.Handlers
+	entities   entityLister
+
+	// StrictRoundRobin rejects the round robin checks that no agent entity is
+	// subscribed to, instead of only warning about them.
+	StrictRoundRobin bool
+
+	// Secrets rejects the checks referencing secrets that do not exist. The
+	// secrets are not validated if nil.
+	Secrets SecretsValidator
 }
 
 // NewChecksRouter instantiates new router for controlling check resources
 func NewChecksRouter(store store.Store, getter types.QueueGetter) *ChecksRouter {
-	return &ChecksRouter{
+	r := &ChecksRouter{
 		controller: actions.NewCheckController(store, getter),
 		handlers: handlers.Handlers{
 			Resource: &corev2.CheckConfig{},
 			Store:    store,
 		},
+		entities: store,
 	}
+	r.handlers.PatchValidator = r.validatePatchedCheck
+	return r
 }
 
 // Mount the ChecksRouter to a parent Router
@@ -52,8 +86,8 @@ func (r *ChecksRouter) Mount(parent *mux.Router) {
 	routes.List(r.handlers.ListResources, corev2.CheckConfigFields)
 	routes.ListAllNamespaces(r.handlers.ListResources, "/{resource:checks}", corev2.CheckConfigFields)
 	routes.Patch(r.handlers.PatchResource)
-	routes.Post(r.handlers.CreateResource)
-	routes.Put(r.handlers.CreateOrUpdateResource)
+	parent.Handle(routes.PathPrefix, r.validateTemplates(r.validateSecrets(r.validateRoundRobin(actionHandler(r.handlers.CreateResource))))).Methods(http.MethodPost)
+	parent.Handle(path.Join(routes.PathPrefix, "{id}"), r.validateTemplates(r.validateSecrets(r.validateRoundRobin(actionHandler(r.handlers.CreateOrUpdateResource))))).Methods(http.MethodPut)
 
 	// Custom
 	routes.Path("{id}/hooks/{type}", r.addCheckHook).Methods(http.MethodPut)
@@ -127,3 +161,163 @@ func (r *ChecksRouter) adhocRequest(w http.ResponseWriter, req *http.Request) {
 		WriteError(w, err)
 	}
 }
+
+// validateRoundRobin warns, with a Warning header, when the round robin check
+// given in the request body would never be executed because no agent entity
+// is subscribed to it. The request is rejected instead with StrictRoundRobin.
+func (r *ChecksRouter) validateRoundRobin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if r.entities == nil {
+			next.ServeHTTP(w, req)
+			return
+		}
+		check, err := decodeCheck(req)
+		if err != nil {
+			WriteError(w, actions.NewError(actions.InvalidArgument, err))
+			return
+		}
+		if check == nil || !check.RoundRobin {
+			// Invalid checks are rejected by the next handler
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		subscribed, err := r.hasSubscribedEntity(req.Context(), check.Subscriptions)
+		if err != nil {
+			logger.WithError(err).Warn("could not validate the subscriptions of a round robin check")
+		} else if !subscribed {
+			msg := fmt.Sprintf("round robin check %q will not be executed: no agent entity is subscribed to %s",
+				check.Name, strings.Join(check.Subscriptions, ", "))
+			if r.StrictRoundRobin {
+				WriteError(w, actions.NewErrorf(actions.InvalidArgument, msg))
+				return
+			}
+			logger.Warn(msg)
+			w.Header().Add("Warning", fmt.Sprintf("199 sensu-backend %q", msg))
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// validateSecrets rejects the check given in the request body if it
+// references secrets that do not exist, so that they are not only reported
+// when the check is executed.
+func (r *ChecksRouter) validateSecrets(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if r.Secrets == nil {
+			next.ServeHTTP(w, req)
+			return
+		}
+		check, err := decodeCheck(req)
+		if err != nil {
+			WriteError(w, actions.NewError(actions.InvalidArgument, err))
+			return
+		}
+		if check == nil {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		if err := r.checkSecrets(req.Context(), check); err != nil {
+			WriteError(w, actions.NewError(actions.InvalidArgument, err))
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// checkSecrets returns an error if the check references secrets that do not
+// exist. The secrets are not checked if the secrets provider can't validate
+// them.
+func (r *ChecksRouter) checkSecrets(ctx context.Context, check *corev2.CheckConfig) error {
+	if r.Secrets == nil || len(check.Secrets) == 0 {
+		return nil
+	}
+	if err := r.Secrets.ValidateSecrets(ctx, check.Secrets); err != nil {
+		if err == secrets.ErrSecretsNotSupported {
+			logger.WithError(err).Debug("could not validate the secrets of a check")
+			return nil
+		}
+		return fmt.Errorf("check %q: %s", check.Name, err)
+	}
+	return nil
+}
+
+// validateTemplates rejects the check given in the request body if its
+// tokens, substituted with the attributes of the entities executing the check
+// or, for proxy checks, of the proxy entities, are not valid templates, so
+// that they are not only reported when the check is executed.
+func (r *ChecksRouter) validateTemplates(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		check, err := decodeCheck(req)
+		if err != nil {
+			WriteError(w, actions.NewError(actions.InvalidArgument, err))
+			return
+		}
+		if check == nil {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		if err := token.Validate(check); err != nil {
+			WriteError(w, actions.NewErrorf(actions.InvalidArgument, "check %q: %s", check.Name, err))
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// validatePatchedCheck validates the check resulting from a PATCH request, as
+// validateTemplates and validateSecrets validate the checks of the POST and
+// PUT requests.
+func (r *ChecksRouter) validatePatchedCheck(ctx context.Context, resource corev2.Resource) error {
+	check, ok := resource.(*corev2.CheckConfig)
+	if !ok {
+		return nil
+	}
+	if err := token.Validate(check); err != nil {
+		return fmt.Errorf("check %q: %s", check.Name, err)
+	}
+	return r.checkSecrets(ctx, check)
+}
+
+// decodeCheck decodes the check given in the request body, leaving the body
+// readable by the next handlers. A nil check is returned if the body is not a
+// valid check, which the next handlers reject.
+func decodeCheck(req *http.Request) (*corev2.CheckConfig, error) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	var check corev2.CheckConfig
+	if err := json.Unmarshal(body, &check); err != nil {
+		return nil, nil
+	}
+	return &check, nil
+}
+
+// hasSubscribedEntity returns whether an agent entity of the namespace of the
+// request is subscribed to one of the given subscriptions.
+func (r *ChecksRouter) hasSubscribedEntity(ctx context.Context, subscriptions []string) (bool, error) {
+	pred := &store.SelectionPredicate{Limit: roundRobinEntitiesPageSize}
+	for {
+		entities, err := r.entities.GetEntities(ctx, pred)
+		if err != nil {
+			return false, err
+		}
+		for _, entity := range entities {
+			if entity.EntityClass == corev2.EntityProxyClass {
+				// proxy entities do not execute checks
+				continue
+			}
+			if len(stringsutil.Intersect(entity.Subscriptions, subscriptions)) > 0 {
+				return true, nil
+			}
+		}
+		if pred.Continue == "" {
+			return false, nil
+		}
+	}
+}
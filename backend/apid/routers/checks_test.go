@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -13,6 +14,7 @@ import (
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/apid/handlers"
+	"github.com/sensu/sensu-go/backend/secrets"
 	"github.com/sensu/sensu-go/testing/mockqueue"
 	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/sensu/sensu-go/testing/testutil"
@@ -163,3 +165,275 @@ func TestChecksRouterCustomRoutes(t *testing.T) {
 		})
 	}
 }
+
+func TestChecksRouterValidateRoundRobin(t *testing.T) {
+	agent := corev2.FixtureEntity("agent")
+	agent.Subscriptions = []string{"linux"}
+	proxy := corev2.FixtureEntity("proxy")
+	proxy.EntityClass = corev2.EntityProxyClass
+	proxy.Subscriptions = []string{"windows"}
+
+	tests := []struct {
+		name        string
+		check       func() *corev2.CheckConfig
+		strict      bool
+		wantStatus  int
+		wantWarning bool
+	}{
+		{
+			name: "check without round robin",
+			check: func() *corev2.CheckConfig {
+				check := corev2.FixtureCheckConfig("check")
+				check.Subscriptions = []string{"windows"}
+				return check
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "round robin check with a subscribed agent",
+			check: func() *corev2.CheckConfig {
+				check := corev2.FixtureCheckConfig("check")
+				check.RoundRobin = true
+				check.Subscriptions = []string{"linux"}
+				return check
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "round robin check without subscribed agent",
+			check: func() *corev2.CheckConfig {
+				check := corev2.FixtureCheckConfig("check")
+				check.RoundRobin = true
+				check.Subscriptions = []string{"windows"}
+				return check
+			},
+			wantStatus:  http.StatusCreated,
+			wantWarning: true,
+		},
+		{
+			name: "strict round robin check without subscribed agent",
+			check: func() *corev2.CheckConfig {
+				check := corev2.FixtureCheckConfig("check")
+				check.RoundRobin = true
+				check.Subscriptions = []string{"windows"}
+				return check
+			},
+			strict:     true,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &mockstore.MockStore{}
+			s.On("GetEntities", mock.Anything, mock.Anything).Return([]*corev2.Entity{agent, proxy}, nil)
+			router := &ChecksRouter{entities: s, StrictRoundRobin: tt.strict}
+
+			var received corev2.CheckConfig
+			next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				// the body must still be readable by the next handler
+				if err := json.NewDecoder(req.Body).Decode(&received); err != nil {
+					t.Fatal(err)
+				}
+				w.WriteHeader(http.StatusCreated)
+			})
+
+			check := tt.check()
+			req := httptest.NewRequest(http.MethodPost, "/namespaces/default/checks", bytes.NewReader(marshal(check)))
+			rr := httptest.NewRecorder()
+			router.validateRoundRobin(next).ServeHTTP(rr, req)
+
+			if got := rr.Code; got != tt.wantStatus {
+				t.Fatalf("bad status: got %d, want %d", got, tt.wantStatus)
+			}
+			if got := rr.Header().Get("Warning") != ""; got != tt.wantWarning {
+				t.Errorf("bad warning header: %q", rr.Header().Get("Warning"))
+			}
+			if tt.wantStatus == http.StatusCreated && received.Name != check.Name {
+				t.Errorf("bad check received by the next handler: %q", received.Name)
+			}
+		})
+	}
+}
+
+type secretsValidatorFunc func(context.Context, []*corev2.Secret) error
+
+func (f secretsValidatorFunc) ValidateSecrets(ctx context.Context, secrets []*corev2.Secret) error {
+	return f(ctx, secrets)
+}
+
+func TestChecksRouterValidateSecrets(t *testing.T) {
+	validator := secretsValidatorFunc(func(_ context.Context, s []*corev2.Secret) error {
+		for _, secret := range s {
+			switch secret.Secret {
+			case "unsupported":
+				return secrets.ErrSecretsNotSupported
+			case "missing":
+				return errors.New("secret not found")
+			}
+		}
+		return nil
+	})
+
+	tests := []struct {
+		name       string
+		secrets    []*corev2.Secret
+		wantStatus int
+	}{
+		{
+			name:       "check without secrets",
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "check with existing secrets",
+			secrets:    []*corev2.Secret{{Name: "TOKEN", Secret: "token"}},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "check with missing secrets",
+			secrets:    []*corev2.Secret{{Name: "TOKEN", Secret: "token"}, {Name: "PASSWORD", Secret: "missing"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "secrets not supported",
+			secrets:    []*corev2.Secret{{Name: "TOKEN", Secret: "unsupported"}},
+			wantStatus: http.StatusCreated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := &ChecksRouter{Secrets: validator}
+
+			var received corev2.CheckConfig
+			next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				// the body must still be readable by the next handler
+				if err := json.NewDecoder(req.Body).Decode(&received); err != nil {
+					t.Fatal(err)
+				}
+				w.WriteHeader(http.StatusCreated)
+			})
+
+			check := corev2.FixtureCheckConfig("check")
+			check.Secrets = tt.secrets
+			req := httptest.NewRequest(http.MethodPost, "/namespaces/default/checks", bytes.NewReader(marshal(check)))
+			rr := httptest.NewRecorder()
+			router.validateSecrets(next).ServeHTTP(rr, req)
+
+			if got := rr.Code; got != tt.wantStatus {
+				t.Fatalf("bad status: got %d, want %d", got, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusCreated && received.Name != check.Name {
+				t.Errorf("bad check received by the next handler: %q", received.Name)
+			}
+		})
+	}
+}
+
+func TestChecksRouterValidateTemplates(t *testing.T) {
+	tests := []struct {
+		name       string
+		command    string
+		labels     map[string]string
+		wantStatus int
+	}{
+		{
+			name:       "check without tokens",
+			command:    "check-http -u http://localhost",
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "check with valid tokens",
+			command:    `check-http -u {{ .labels.url | default "http://localhost" }}`,
+			labels:     map[string]string{"target": "{{ .name }}"},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "command with an invalid token",
+			command:    "check-http -u {{ .labels.url",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "label with an invalid token",
+			command:    "check-http",
+			labels:     map[string]string{"target": "{{ .name }"},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := &ChecksRouter{}
+
+			var received corev2.CheckConfig
+			next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				// the body must still be readable by the next handler
+				if err := json.NewDecoder(req.Body).Decode(&received); err != nil {
+					t.Fatal(err)
+				}
+				w.WriteHeader(http.StatusCreated)
+			})
+
+			check := corev2.FixtureCheckConfig("check")
+			check.Command = tt.command
+			check.Labels = tt.labels
+			check.ProxyRequests = corev2.FixtureProxyRequests(true)
+			req := httptest.NewRequest(http.MethodPost, "/namespaces/default/checks", bytes.NewReader(marshal(check)))
+			rr := httptest.NewRecorder()
+			router.validateTemplates(next).ServeHTTP(rr, req)
+
+			if got := rr.Code; got != tt.wantStatus {
+				t.Fatalf("bad status: got %d, want %d", got, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusCreated && received.Command != check.Command {
+				t.Errorf("bad check received by the next handler: %q", received.Command)
+			}
+		})
+	}
+}
+
+func TestChecksRouterValidatePatchedCheck(t *testing.T) {
+	validator := secretsValidatorFunc(func(_ context.Context, s []*corev2.Secret) error {
+		for _, secret := range s {
+			if secret.Secret == "missing" {
+				return errors.New("secret not found")
+			}
+		}
+		return nil
+	})
+
+	tests := []struct {
+		name    string
+		command string
+		secrets []*corev2.Secret
+		wantErr bool
+	}{
+		{
+			name:    "valid check",
+			command: "check-http -u {{ .labels.url }}",
+			secrets: []*corev2.Secret{{Name: "TOKEN", Secret: "token"}},
+		},
+		{
+			name:    "check with an invalid token",
+			command: "check-http -u {{ .labels.url",
+			wantErr: true,
+		},
+		{
+			name:    "check with missing secrets",
+			command: "check-http",
+			secrets: []*corev2.Secret{{Name: "PASSWORD", Secret: "missing"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewChecksRouter(&mockstore.MockStore{}, &mockqueue.Getter{})
+			router.Secrets = validator
+
+			check := corev2.FixtureCheckConfig("check")
+			check.Command = tt.command
+			check.Secrets = tt.secrets
+			err := router.handlers.PatchValidator(context.Background(), check)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validatePatchedCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
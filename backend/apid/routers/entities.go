@@ -3,14 +3,19 @@ package routers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
+	"path"
+	"time"
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/api"
 	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/apid/handlers"
+	"github.com/sensu/sensu-go/backend/authorization"
 	"github.com/sensu/sensu-go/backend/store"
 	storev2 "github.com/sensu/sensu-go/backend/store/v2"
 )
@@ -18,6 +23,8 @@ import (
 // EntitiesRouter handles requests for /entities
 type EntitiesRouter struct {
 	controller      EntityController
+	versions        entityVersionClient
+	stale           staleEntityClient
 	store           store.Store
 	eventStore      store.EventStore
 	configSubrouter EntityConfigRouter
@@ -28,16 +35,31 @@ type EntityConfigRouter struct {
 }
 
 type EntityController interface {
-	Find(ctx context.Context, id string) (*corev2.Entity, error)
 	List(ctx context.Context, pred *store.SelectionPredicate) ([]corev2.Resource, error)
 	Create(ctx context.Context, entity corev2.Entity) error
 	CreateOrReplace(ctx context.Context, entity corev2.Entity) error
 }
 
+// entityVersionClient represents the needs of the EntitiesRouter to read and
+// update entities based on the version of their configuration.
+type entityVersionClient interface {
+	FetchEntityWithVersion(ctx context.Context, name string) (*corev2.Entity, string, error)
+	UpdateEntity(ctx context.Context, entity *corev2.Entity, version string, force bool) error
+}
+
+// staleEntityClient represents the needs of the EntitiesRouter to list the
+// entities that were not seen for a while.
+type staleEntityClient interface {
+	ListStaleEntities(ctx context.Context, olderThan time.Duration) ([]*corev2.Entity, error)
+}
+
 // NewEntitiesRouter instantiates new router for controlling entities resources
-func NewEntitiesRouter(store store.Store, storev2 storev2.Interface, events store.EventStore) *EntitiesRouter {
+func NewEntitiesRouter(store store.Store, storev2 storev2.Interface, events store.EventStore, auth authorization.Authorizer) *EntitiesRouter {
+	client := api.NewEntityClient(store, storev2, events, auth)
 	return &EntitiesRouter{
 		controller: actions.NewEntityController(store, storev2),
+		versions:   client,
+		stale:      client,
 		store:      store,
 		eventStore: events,
 		configSubrouter: EntityConfigRouter{
@@ -63,7 +85,12 @@ func (r *EntitiesRouter) Mount(parent *mux.Router) {
 	}
 
 	routes.Del(deleter.Delete)
-	routes.Get(r.find)
+	// GET /entities/:id responds with the version of the entity as its
+	// ETag, which PUT /entities/:id accepts as If-Match.
+	routes.Router.HandleFunc(path.Join(routes.PathPrefix, "{id}"), r.find).Methods(http.MethodGet)
+	// GET /entities?stale=<duration> lists the entities not seen for longer
+	// than the duration, it must be mounted before the regular list.
+	handleAction(parent, routes.PathPrefix, r.listStale).Methods(http.MethodGet).Queries("stale", "{stale}")
 	routes.List(r.controller.List, corev2.EntityFields)
 	routes.ListAllNamespaces(r.controller.List, "/{resource:entities}", corev2.EntityFields)
 	routes.Patch(r.configSubrouter.handlers.PatchResource)
@@ -71,13 +98,34 @@ func (r *EntitiesRouter) Mount(parent *mux.Router) {
 	routes.Put(r.createOrReplace)
 }
 
-func (r *EntitiesRouter) find(req *http.Request) (interface{}, error) {
-	params := mux.Vars(req)
-	id, err := url.PathUnescape(params["id"])
+func (r *EntitiesRouter) find(w http.ResponseWriter, req *http.Request) {
+	id, err := url.PathUnescape(mux.Vars(req)["id"])
 	if err != nil {
-		return nil, err
+		WriteError(w, err)
+		return
+	}
+	entity, version, err := r.versions.FetchEntityWithVersion(req.Context(), id)
+	if err != nil {
+		WriteError(w, entityError(err))
+		return
 	}
-	return r.controller.Find(req.Context(), id)
+	w.Header().Set("ETag", version)
+	RespondWith(w, req, entity)
+}
+
+func (r *EntitiesRouter) listStale(req *http.Request) (interface{}, error) {
+	olderThan, err := time.ParseDuration(req.URL.Query().Get("stale"))
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	if olderThan <= 0 {
+		return nil, actions.NewError(actions.InvalidArgument, fmt.Errorf("stale duration must be positive, got %s", olderThan))
+	}
+	entities, err := r.stale.ListStaleEntities(req.Context(), olderThan)
+	if err != nil {
+		return nil, entityError(err)
+	}
+	return entities, nil
 }
 
 func (r *EntitiesRouter) create(req *http.Request) (interface{}, error) {
@@ -85,6 +133,9 @@ func (r *EntitiesRouter) create(req *http.Request) (interface{}, error) {
 	if err := UnmarshalBody(req, &entity); err != nil {
 		return nil, err
 	}
+	if err := handlers.CheckMeta(req.Context(), &entity, mux.Vars(req), "id"); err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
 	err := r.controller.Create(req.Context(), entity)
 	return entity, err
 }
@@ -99,5 +150,39 @@ func (r *EntitiesRouter) createOrReplace(req *http.Request) (interface{}, error)
 		return nil, actions.NewError(actions.AlreadyExistsErr, errors.New("entity is managed by its agent"))
 	}
 
-	return entity, r.controller.CreateOrReplace(req.Context(), entity)
+	if err := handlers.CheckMeta(req.Context(), &entity, mux.Vars(req), "id"); err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+
+	// Without If-Match, the entity is replaced regardless of its version
+	version := req.Header.Get("If-Match")
+	if version == "" {
+		return entity, r.controller.CreateOrReplace(req.Context(), entity)
+	}
+	if err := entity.Validate(); err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	if err := r.versions.UpdateEntity(req.Context(), &entity, version, false); err != nil {
+		return nil, entityError(err)
+	}
+	return entity, nil
+}
+
+// entityError maps the errors of the entity client to the errors of the API.
+// An update conflicting with the version of the entity fails its
+// precondition.
+func entityError(err error) error {
+	var notFound *api.ErrNotFound
+	var preconditionFailed *store.ErrPreconditionFailed
+	switch {
+	case errors.Is(err, authorization.ErrUnauthorized):
+		return actions.NewError(actions.PermissionDenied, err)
+	case errors.Is(err, authorization.ErrNoClaims):
+		return actions.NewError(actions.Unauthenticated, err)
+	case errors.As(err, &notFound):
+		return actions.NewError(actions.NotFound, err)
+	case errors.As(err, &preconditionFailed):
+		return actions.NewError(actions.PreconditionFailed, err)
+	}
+	return actions.NewError(actions.InternalErr, err)
 }
@@ -1,11 +1,19 @@
 package routers
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/api"
+	"github.com/sensu/sensu-go/backend/authorization"
+	"github.com/sensu/sensu-go/backend/authorization/rbac"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/backend/store/v2/storetest"
 	"github.com/sensu/sensu-go/testing/mockstore"
@@ -16,11 +24,6 @@ type mockEntitiesController struct {
 	mock.Mock
 }
 
-func (m *mockEntitiesController) Find(ctx context.Context, id string) (*corev2.Entity, error) {
-	args := m.Called(ctx, id)
-	return args.Get(0).(*corev2.Entity), args.Error(1)
-}
-
 func (m *mockEntitiesController) List(ctx context.Context, pred *store.SelectionPredicate) ([]corev2.Resource, error) {
 	args := m.Called(ctx, pred)
 	return args.Get(0).([]corev2.Resource), args.Error(1)
@@ -39,7 +42,6 @@ func (m *mockEntitiesController) CreateOrReplace(ctx context.Context, entity cor
 func TestEntitiesRouter(t *testing.T) {
 	// Setup the router
 	controller := new(mockEntitiesController)
-	controller.On("Find", mock.Anything, mock.Anything).Return(corev2.FixtureEntity("foo"), nil)
 	controller.On("List", mock.Anything, mock.Anything).Return([]corev2.Resource{corev2.FixtureEntity("foo")}, nil)
 	controller.On("Create", mock.Anything, mock.Anything).Return(nil)
 	controller.On("CreateOrReplace", mock.Anything, mock.Anything).Return(nil)
@@ -49,7 +51,7 @@ func TestEntitiesRouter(t *testing.T) {
 	s.On("DeleteEntityByName", mock.Anything, "foo").Return(nil)
 	s.On("GetEntityByName", mock.Anything, "foo").Return(corev2.FixtureEntity("foo"), nil)
 	s2 := new(storetest.Store)
-	router := NewEntitiesRouter(s, s2, s)
+	router := NewEntitiesRouter(s, s2, s, &rbac.Authorizer{Store: s})
 	router.controller = controller
 	parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
 	router.Mount(parentRouter)
@@ -72,3 +74,167 @@ func TestEntitiesRouter(t *testing.T) {
 		run(t, tt, parentRouter, s)
 	}
 }
+
+type mockStaleEntityClient struct {
+	mock.Mock
+}
+
+func (m *mockStaleEntityClient) ListStaleEntities(ctx context.Context, olderThan time.Duration) ([]*corev2.Entity, error) {
+	args := m.Called(ctx, olderThan)
+	entities, _ := args.Get(0).([]*corev2.Entity)
+	return entities, args.Error(1)
+}
+
+func TestEntitiesRouterListStale(t *testing.T) {
+	stale := new(mockStaleEntityClient)
+	stale.On("ListStaleEntities", mock.Anything, time.Hour).Return([]*corev2.Entity{corev2.FixtureEntity("foo")}, nil)
+	stale.On("ListStaleEntities", mock.Anything, 2*time.Hour).Return(nil, authorization.ErrUnauthorized)
+	s := new(mockstore.MockStore)
+	router := NewEntitiesRouter(s, new(storetest.Store), s, &rbac.Authorizer{Store: s})
+	router.stale = stale
+	parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+	router.Mount(parentRouter)
+
+	tests := []routerTestCase{
+		{
+			name:           "stale entities",
+			method:         http.MethodGet,
+			path:           corev2.URLPrefix + "/namespaces/default/entities?stale=1h",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "invalid duration",
+			method:         http.MethodGet,
+			path:           corev2.URLPrefix + "/namespaces/default/entities?stale=forever",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "negative duration",
+			method:         http.MethodGet,
+			path:           corev2.URLPrefix + "/namespaces/default/entities?stale=-1h",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "unauthorized",
+			method:         http.MethodGet,
+			path:           corev2.URLPrefix + "/namespaces/default/entities?stale=2h",
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		run(t, tt, parentRouter, s)
+	}
+	stale.AssertNumberOfCalls(t, "ListStaleEntities", 2)
+}
+
+type mockEntityVersionClient struct {
+	mock.Mock
+}
+
+func (m *mockEntityVersionClient) FetchEntityWithVersion(ctx context.Context, name string) (*corev2.Entity, string, error) {
+	args := m.Called(ctx, name)
+	entity, _ := args.Get(0).(*corev2.Entity)
+	return entity, args.String(1), args.Error(2)
+}
+
+func (m *mockEntityVersionClient) UpdateEntity(ctx context.Context, entity *corev2.Entity, version string, force bool) error {
+	args := m.Called(ctx, entity, version, force)
+	return args.Error(0)
+}
+
+func TestEntitiesRouterVersions(t *testing.T) {
+	controller := new(mockEntitiesController)
+	controller.On("CreateOrReplace", mock.Anything, mock.Anything).Return(nil)
+	versions := new(mockEntityVersionClient)
+	versions.On("FetchEntityWithVersion", mock.Anything, "foo").Return(corev2.FixtureEntity("foo"), `"v1"`, nil)
+	versions.On("FetchEntityWithVersion", mock.Anything, "bar").Return(nil, "", &api.ErrNotFound{Err: &store.ErrNotFound{Key: "bar"}})
+	versions.On("UpdateEntity", mock.Anything, mock.Anything, `"v1"`, false).Return(nil)
+	versions.On("UpdateEntity", mock.Anything, mock.Anything, `"v0"`, false).Return(&api.ErrConflict{Err: &store.ErrPreconditionFailed{Key: "foo"}})
+	s := new(mockstore.MockStore)
+	router := NewEntitiesRouter(s, new(storetest.Store), s, &rbac.Authorizer{Store: s})
+	router.controller = controller
+	router.versions = versions
+	parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+	router.Mount(parentRouter)
+	server := httptest.NewServer(parentRouter)
+	defer server.Close()
+
+	path := server.URL + corev2.URLPrefix + "/namespaces/default/entities/"
+	body, err := json.Marshal(corev2.FixtureEntity("foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		id             string
+		ifMatch        string
+		wantStatusCode int
+		wantETag       string
+	}{
+		{
+			name:           "get responds with the version",
+			method:         http.MethodGet,
+			id:             "foo",
+			wantStatusCode: http.StatusOK,
+			wantETag:       `"v1"`,
+		},
+		{
+			name:           "get missing entity",
+			method:         http.MethodGet,
+			id:             "bar",
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "put without version",
+			method:         http.MethodPut,
+			id:             "foo",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "put with current version",
+			method:         http.MethodPut,
+			id:             "foo",
+			ifMatch:        `"v1"`,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "put with outdated version",
+			method:         http.MethodPut,
+			id:             "foo",
+			ifMatch:        `"v0"`,
+			wantStatusCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "put with mismatched name",
+			method:         http.MethodPut,
+			id:             "bar",
+			ifMatch:        `"v1"`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, path+tt.id, bytes.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+			if res.StatusCode != tt.wantStatusCode {
+				t.Errorf("StatusCode = %v, wantStatusCode %v", res.StatusCode, tt.wantStatusCode)
+			}
+			if tt.wantETag != "" && res.Header.Get("ETag") != tt.wantETag {
+				t.Errorf("ETag = %q, want %q", res.Header.Get("ETag"), tt.wantETag)
+			}
+		})
+	}
+	controller.AssertNumberOfCalls(t, "CreateOrReplace", 1)
+}
@@ -2,9 +2,11 @@ package routers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 	"path"
+	"time"
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
@@ -17,6 +19,14 @@ import (
 // EventsRouter handles requests for /events
 type EventsRouter struct {
 	controller eventController
+	bus        messaging.MessageBus
+
+	// WatchTimeout, if set, bounds the duration of the event streams.
+	WatchTimeout time.Duration
+
+	// MaxEventSize, if set, is the maximum serialized size of the events
+	// created, in bytes. Larger events are rejected before being published.
+	MaxEventSize int
 }
 
 // eventController represents the controller needs of the EventsRouter.
@@ -31,6 +41,7 @@ type eventController interface {
 func NewEventsRouter(store store.EventStore, bus messaging.MessageBus) *EventsRouter {
 	return &EventsRouter{
 		controller: actions.NewEventController(store, bus),
+		bus:        bus,
 	}
 }
 
@@ -42,6 +53,9 @@ func (r *EventsRouter) Mount(parent *mux.Router) {
 	}
 
 	routes.Post(r.create)
+	// The watch route must precede the list route, which it would otherwise
+	// be shadowed by
+	parent.HandleFunc(routes.PathPrefix, r.watch).Methods(http.MethodGet).Queries("watch", "true")
 	routes.List(r.controller.List, corev2.EventFields)
 	routes.ListAllNamespaces(r.controller.List, "/{resource:events}", corev2.EventFields)
 	routes.Path("{entity}/{check}", r.get).Methods(http.MethodGet)
@@ -76,7 +90,10 @@ func (r *EventsRouter) create(req *http.Request) (interface{}, error) {
 	}
 
 	vars := mux.Vars(req)
-	if err := validateEventPayload(event, vars); err != nil {
+	if err := validateEventPayload(req.Context(), event, vars); err != nil {
+		return nil, err
+	}
+	if err := r.checkEventSize(event); err != nil {
 		return nil, err
 	}
 
@@ -91,7 +108,10 @@ func (r *EventsRouter) createOrReplace(req *http.Request) (interface{}, error) {
 	}
 
 	vars := mux.Vars(req)
-	if err := validateEventPayload(event, vars); err != nil {
+	if err := validateEventPayload(req.Context(), event, vars); err != nil {
+		return nil, err
+	}
+	if err := r.checkEventSize(event); err != nil {
 		return nil, err
 	}
 
@@ -99,8 +119,20 @@ func (r *EventsRouter) createOrReplace(req *http.Request) (interface{}, error) {
 	return nil, err
 }
 
+// checkEventSize rejects the events larger than the maximum event size, which
+// eventd would drop.
+func (r *EventsRouter) checkEventSize(event *corev2.Event) error {
+	if r.MaxEventSize <= 0 {
+		return nil
+	}
+	if size := event.Size(); size > r.MaxEventSize {
+		return actions.NewError(actions.PayloadTooLarge, fmt.Errorf("event size of %d bytes exceeds the maximum of %d bytes", size, r.MaxEventSize))
+	}
+	return nil
+}
+
 // validateEventPayload validates the event payload against the URL path values
-func validateEventPayload(event *corev2.Event, vars map[string]string) error {
+func validateEventPayload(ctx context.Context, event *corev2.Event, vars map[string]string) error {
 	if event.Entity != nil {
 		// Fill any missing entity metadata with the URL path values
 		if err := handlers.MetaPathValues(event.Entity, vars, "entity"); err != nil {
@@ -108,7 +140,7 @@ func validateEventPayload(event *corev2.Event, vars map[string]string) error {
 		}
 
 		// Ensure the entity metadata matches the URL path values
-		if err := handlers.CheckMeta(event.Entity, vars, "entity"); err != nil {
+		if err := handlers.CheckMeta(ctx, event.Entity, vars, "entity"); err != nil {
 			return actions.NewError(actions.InvalidArgument, err)
 		}
 	}
@@ -120,7 +152,7 @@ func validateEventPayload(event *corev2.Event, vars map[string]string) error {
 		}
 
 		// Ensure the check metadata matches the URL path values
-		if err := handlers.CheckMeta(event.Check, vars, "check"); err != nil {
+		if err := handlers.CheckMeta(ctx, event.Check, vars, "check"); err != nil {
 			return actions.NewError(actions.InvalidArgument, err)
 		}
 	}
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gorilla/mux"
@@ -412,3 +413,51 @@ func TestEventsRouter(t *testing.T) {
 		})
 	}
 }
+
+func TestEventsRouterMaxEventSize(t *testing.T) {
+	controller := &mockEventController{}
+	controller.On("CreateOrReplace", mock.Anything, mock.Anything).Return(nil)
+	fixture := corev2.FixtureEvent("foo", "check-cpu")
+	router := EventsRouter{controller: controller, MaxEventSize: 2 * fixture.Size()}
+	parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+	router.Mount(parentRouter)
+	server := httptest.NewServer(parentRouter)
+	defer server.Close()
+
+	large := corev2.FixtureEvent("foo", "check-cpu")
+	large.Check.Output = strings.Repeat("x", 2*fixture.Size())
+
+	tests := []struct {
+		name           string
+		event          *corev2.Event
+		wantStatusCode int
+	}{
+		{
+			name:           "event within the limit",
+			event:          fixture,
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:           "event exceeding the limit",
+			event:          large,
+			wantStatusCode: http.StatusRequestEntityTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPut, server.URL+tt.event.URIPath(), bytes.NewReader(marshal(tt.event)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+			if res.StatusCode != tt.wantStatusCode {
+				t.Errorf("StatusCode = %v, wantStatusCode %v", res.StatusCode, tt.wantStatusCode)
+			}
+		})
+	}
+	controller.AssertNumberOfCalls(t, "CreateOrReplace", 1)
+}
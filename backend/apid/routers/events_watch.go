@@ -0,0 +1,125 @@
+package routers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/messaging"
+)
+
+const (
+	// eventWatchBufferSize is the number of events buffered for a client
+	// reading its stream slower than events are processed. Events are dropped
+	// once the buffer is full, so that slow clients never hold up the message
+	// bus.
+	eventWatchBufferSize = 100
+
+	// eventWatchPingInterval is the interval between two comments sent to
+	// keep the stream alive when no event matches.
+	eventWatchPingInterval = 10 * time.Second
+)
+
+// watch streams, as server-sent events, the events of the namespace processed
+// by this backend, optionally limited to those of an entity and a check. The
+// stream ends when the client disconnects or, if set, when the watch timeout
+// expires, in which case the client is expected to watch again.
+func (r *EventsRouter) watch(w http.ResponseWriter, req *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		WriteError(w, actions.NewErrorf(actions.InternalErr, "streaming is not supported"))
+		return
+	}
+
+	namespace := mux.Vars(req)["namespace"]
+	query := req.URL.Query()
+	entity, check := query.Get("entity"), query.Get("check")
+
+	ctx := req.Context()
+	if r.WatchTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.WatchTimeout)
+		defer cancel()
+	}
+
+	sub := make(messaging.ChanSubscriber, eventWatchBufferSize)
+	consumer := "apid-watch-" + uuid.New().String()
+	subscription, err := r.bus.Subscribe(messaging.TopicEvent, consumer, sub)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+	defer func() {
+		_ = subscription.Cancel()
+	}()
+
+	// Serialize the matching events as soon as they are received, dropping
+	// them when the client can't keep up.
+	events := make(chan []byte, eventWatchBufferSize)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-sub:
+				event, ok := msg.(*corev2.Event)
+				if !ok || !eventWatchMatches(event, namespace, entity, check) {
+					continue
+				}
+				b, err := json.Marshal(event)
+				if err != nil {
+					logger.WithError(err).Error("failed to marshal watched event")
+					continue
+				}
+				select {
+				case events <- b:
+				default:
+					logger.WithField("consumer", consumer).Warn("event watch is too slow, dropping event")
+				}
+			}
+		}
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ping := time.NewTicker(eventWatchPingInterval)
+	defer ping.Stop()
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return
+		case <-ping.C:
+			_, err = w.Write([]byte(":\n\n"))
+		case b := <-events:
+			_, err = w.Write(append(append([]byte("data: "), b...), '\n', '\n'))
+		}
+		if err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// eventWatchMatches returns whether the event belongs to the namespace and,
+// when they are not empty, to the entity and check.
+func eventWatchMatches(event *corev2.Event, namespace, entity, check string) bool {
+	if event.Entity == nil || event.Entity.Namespace != namespace {
+		return false
+	}
+	if entity != "" && event.Entity.Name != entity {
+		return false
+	}
+	if check != "" && (event.Check == nil || event.Check.Name != check) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package routers
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventsRouterWatch(t *testing.T) {
+	bus, err := messaging.NewWizardBus(messaging.WizardBusConfig{})
+	require.NoError(t, err)
+	require.NoError(t, bus.Start())
+
+	router := EventsRouter{controller: &mockEventController{}, bus: bus, WatchTimeout: 5 * time.Second}
+	parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+	router.Mount(parentRouter)
+	server := httptest.NewServer(parentRouter)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/core/v2/namespaces/default/events?watch=true&entity=foo")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	other := corev2.FixtureEvent("foo", "check-cpu")
+	other.Entity.Namespace = "acme"
+	require.NoError(t, bus.Publish(messaging.TopicEvent, other))
+	require.NoError(t, bus.Publish(messaging.TopicEvent, corev2.FixtureEvent("bar", "check-cpu")))
+	require.NoError(t, bus.Publish(messaging.TopicEvent, corev2.FixtureEvent("foo", "check-disk")))
+
+	reader := bufio.NewReader(resp.Body)
+	var line string
+	for !strings.HasPrefix(line, "data: ") {
+		line, err = reader.ReadString('\n')
+		require.NoError(t, err)
+	}
+	var event corev2.Event
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event))
+	assert.Equal(t, "default", event.Entity.Namespace)
+	assert.Equal(t, "foo", event.Entity.Name)
+	assert.Equal(t, "check-disk", event.Check.Name)
+}
+
+func TestEventWatchMatches(t *testing.T) {
+	event := corev2.FixtureEvent("foo", "check-cpu")
+	assert.True(t, eventWatchMatches(event, "default", "", ""))
+	assert.True(t, eventWatchMatches(event, "default", "foo", "check-cpu"))
+	assert.False(t, eventWatchMatches(event, "acme", "", ""))
+	assert.False(t, eventWatchMatches(event, "default", "bar", ""))
+	assert.False(t, eventWatchMatches(event, "default", "", "check-disk"))
+
+	event.Check = nil
+	assert.True(t, eventWatchMatches(event, "default", "foo", ""))
+	assert.False(t, eventWatchMatches(event, "default", "foo", "check-cpu"))
+}
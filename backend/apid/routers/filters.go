@@ -1,15 +1,28 @@
 package routers
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/apid/handlers"
 	"github.com/sensu/sensu-go/backend/store"
 )
 
+// FilterTester evaluates events against filters, as the pipeline would,
+// without handling them.
+type FilterTester interface {
+	TestFilter(ctx context.Context, name string, event *corev2.Event) (bool, []error, error)
+}
+
 // EventFiltersRouter handles /filters requests.
 type EventFiltersRouter struct {
 	handlers handlers.Handlers
+	tester   FilterTester
 }
 
 // NewEventFiltersRouter creates a new EventFiltersRouter.
@@ -22,6 +35,13 @@ func NewEventFiltersRouter(store store.ResourceStore) *EventFiltersRouter {
 	}
 }
 
+// WithTester mounts the /filters/{id}/test endpoint, which evaluates the event
+// in the request body against a filter with the given tester.
+func (r *EventFiltersRouter) WithTester(tester FilterTester) *EventFiltersRouter {
+	r.tester = tester
+	return r
+}
+
 // Mount the EventFiltersRouter to a parent Router
 func (r *EventFiltersRouter) Mount(parent *mux.Router) {
 	routes := ResourceRoute{
@@ -36,4 +56,51 @@ func (r *EventFiltersRouter) Mount(parent *mux.Router) {
 	routes.Patch(r.handlers.PatchResource)
 	routes.Post(r.handlers.CreateResource)
 	routes.Put(r.handlers.CreateOrUpdateResource)
+
+	if r.tester != nil {
+		routes.Path("{id}/test", r.test).Methods(http.MethodPost)
+	}
+}
+
+func (r *EventFiltersRouter) test(req *http.Request) (interface{}, error) {
+	ctx := req.Context()
+	name, err := url.PathUnescape(mux.Vars(req)["id"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+
+	var event corev2.Event
+	if err := UnmarshalBody(req, &event); err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	if event.Entity == nil || !event.HasCheck() {
+		return nil, actions.NewError(actions.InvalidArgument, errors.New("the event must have an entity and a check"))
+	}
+	// The filter is looked up in the namespace of the event entity
+	namespace := corev2.ContextNamespace(ctx)
+	event.Namespace = namespace
+	event.Entity.Namespace = namespace
+	event.Check.Namespace = namespace
+
+	if _, ok := builtInFilterNames[name]; !ok {
+		if err := r.handlers.Store.GetResource(ctx, name, &corev2.EventFilter{}); err != nil {
+			if _, ok := err.(*store.ErrNotFound); ok {
+				return nil, actions.NewErrorf(actions.NotFound)
+			}
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+	}
+
+	denied, evalErrs, err := r.tester.TestFilter(ctx, name, &event)
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	result := actions.FilterTestResult{
+		Filter: name,
+		Denied: denied,
+	}
+	for _, err := range evalErrs {
+		result.Errors = append(result.Errors, err.Error())
+	}
+	return result, nil
 }
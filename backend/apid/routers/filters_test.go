@@ -1,11 +1,22 @@
 package routers
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestEventFiltersRouter(t *testing.T) {
@@ -28,3 +39,91 @@ func TestEventFiltersRouter(t *testing.T) {
 		run(t, tt, parentRouter, s)
 	}
 }
+
+type fakeFilterTester struct {
+	denied bool
+	errs   []error
+}
+
+func (f fakeFilterTester) TestFilter(ctx context.Context, name string, event *corev2.Event) (bool, []error, error) {
+	if got, want := event.Entity.Namespace, corev2.ContextNamespace(ctx); got != want {
+		return false, nil, fmt.Errorf("bad entity namespace: got %q, want %q", got, want)
+	}
+	return f.denied, f.errs, nil
+}
+
+func TestEventFiltersRouterTest(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     string
+		event      *corev2.Event
+		tester     fakeFilterTester
+		wantStatus int
+		wantResult actions.FilterTestResult
+	}{
+		{
+			name:       "allowed event",
+			filter:     "foo",
+			event:      corev2.FixtureEvent("entity1", "check1"),
+			wantStatus: http.StatusOK,
+			wantResult: actions.FilterTestResult{Filter: "foo"},
+		},
+		{
+			name:       "denied event with evaluation errors",
+			filter:     "foo",
+			event:      corev2.FixtureEvent("entity1", "check1"),
+			tester:     fakeFilterTester{denied: true, errs: []error{errors.New("boom")}},
+			wantStatus: http.StatusOK,
+			wantResult: actions.FilterTestResult{Filter: "foo", Denied: true, Errors: []string{"boom"}},
+		},
+		{
+			name:       "built-in filter",
+			filter:     "is_incident",
+			event:      corev2.FixtureEvent("entity1", "check1"),
+			wantStatus: http.StatusOK,
+			wantResult: actions.FilterTestResult{Filter: "is_incident"},
+		},
+		{
+			name:       "missing filter",
+			filter:     "missing",
+			event:      corev2.FixtureEvent("entity1", "check1"),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "event without check",
+			filter:     "foo",
+			event:      &corev2.Event{Entity: corev2.FixtureEntity("entity1")},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &mockstore.MockStore{}
+			s.On("GetResource", mock.Anything, "foo", mock.Anything).Return(nil)
+			s.On("GetResource", mock.Anything, "missing", mock.Anything).Return(&store.ErrNotFound{Key: "missing"})
+			router := NewEventFiltersRouter(s).WithTester(tt.tester)
+			parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+			router.Mount(parentRouter)
+
+			path := fmt.Sprintf("%s/namespaces/acme/filters/%s/test", corev2.URLPrefix, tt.filter)
+			req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(marshal(tt.event)))
+			req = req.WithContext(context.WithValue(req.Context(), corev2.NamespaceKey, "acme"))
+			rr := httptest.NewRecorder()
+			parentRouter.ServeHTTP(rr, req)
+
+			if got := rr.Code; got != tt.wantStatus {
+				t.Fatalf("bad status: got %d, want %d: %s", got, tt.wantStatus, rr.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var result actions.FilterTestResult
+			if err := json.Unmarshal(rr.Body.Bytes(), &result); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result, tt.wantResult) {
+				t.Errorf("bad result: got %#v, want %#v", result, tt.wantResult)
+			}
+		})
+	}
+}
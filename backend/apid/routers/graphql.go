@@ -10,7 +10,9 @@ import (
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/apid/middlewares"
 	"github.com/sensu/sensu-go/backend/authentication/jwt"
+	"github.com/sensu/sensu-go/backend/authorization"
 	"github.com/sensu/sensu-go/graphql"
 )
 
@@ -26,6 +28,9 @@ type GraphQLService interface {
 type GraphQLRouter struct {
 	Service GraphQLService
 	Timeout time.Duration
+
+	// Auditor, if set, records the mutations of the operations
+	Auditor middlewares.Auditor
 }
 
 // Mount the GraphQLRouter to a parent Router
@@ -78,12 +83,22 @@ func (r *GraphQLRouter) query(req *http.Request) (interface{}, error) {
 		queryVars, _ := op["variables"].(map[string]interface{})
 		skipValidate, _ := op["skip_validation"].(bool)
 
-		// Execute given query
-		result := r.Service.Do(ctx, graphql.QueryParams{
+		// Execute given query, keeping track of the authorized requests to
+		// audit the mutations
+		opCtx := ctx
+		var authorized func() []*authorization.Attributes
+		if r.Auditor != nil {
+			opCtx, authorized = authorization.WithRecorder(ctx)
+		}
+		start := time.Now()
+		result := r.Service.Do(opCtx, graphql.QueryParams{
 			Query:          query,
 			Variables:      queryVars,
 			SkipValidation: skipValidate,
 		})
+		if r.Auditor != nil {
+			r.audit(opCtx, authorized(), start, result)
+		}
 		results = append(results, map[string]interface{}{
 			"data":   result.Data,
 			"errors": result.Errors,
@@ -113,3 +128,37 @@ func (r *GraphQLRouter) query(req *http.Request) (interface{}, error) {
 	}
 	return results[0], nil
 }
+
+// audit records the mutations authorized during a GraphQL operation, along
+// with the errors of the operation.
+func (r *GraphQLRouter) audit(ctx context.Context, authorized []*authorization.Attributes, start time.Time, result *graphql.Result) {
+	var errs []string
+	for _, err := range result.Errors {
+		errs = append(errs, err.Message)
+	}
+	// The mutations return their errors in their payload
+	data, _ := result.Data.(map[string]interface{})
+	for _, payload := range data {
+		payload, _ := payload.(map[string]interface{})
+		payloadErrs, _ := payload["errors"].([]interface{})
+		for _, err := range payloadErrs {
+			err, _ := err.(map[string]interface{})
+			if message, ok := err["message"].(string); ok {
+				errs = append(errs, message)
+			}
+		}
+	}
+	status := http.StatusOK
+	if ctx.Err() == context.DeadlineExceeded {
+		status = http.StatusGatewayTimeout
+	}
+	for _, attrs := range authorized {
+		if !middlewares.IsMutating(attrs.Verb) {
+			continue
+		}
+		record := middlewares.NewAuditRecord(attrs, start)
+		record.Status = status
+		record.Errors = errs
+		r.Auditor.Audit(ctx, record)
+	}
+}
@@ -2,12 +2,17 @@ package routers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"testing"
 
+	"github.com/graphql-go/graphql/gqlerrors"
 	"github.com/graphql-go/graphql/testutil"
 	"github.com/sensu/sensu-go/backend/apid/graphql"
+	"github.com/sensu/sensu-go/backend/apid/middlewares"
+	"github.com/sensu/sensu-go/backend/authorization"
+	sensugraphql "github.com/sensu/sensu-go/graphql"
 )
 
 func setupRequest(method string, path string, payload interface{}) (*http.Request, error) {
@@ -72,3 +77,62 @@ func TestHttpGraphQLBatchRequest(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// authorizingService authorizes the given requests, as the API clients do when
+// resolving the fields of an operation.
+type authorizingService struct {
+	attrs []*authorization.Attributes
+	errs  []gqlerrors.FormattedError
+}
+
+func (s authorizingService) Do(ctx context.Context, _ sensugraphql.QueryParams) *sensugraphql.Result {
+	for _, attrs := range s.attrs {
+		authorization.Record(ctx, attrs)
+	}
+	return &sensugraphql.Result{Errors: s.errs}
+}
+
+type recordingAuditor struct {
+	records []middlewares.AuditRecord
+}
+
+func (a *recordingAuditor) Audit(_ context.Context, record middlewares.AuditRecord) {
+	a.records = append(a.records, record)
+}
+
+func TestHttpGraphQLAuditsMutations(t *testing.T) {
+	auditor := &recordingAuditor{}
+	router := &GraphQLRouter{
+		Service: authorizingService{
+			attrs: []*authorization.Attributes{
+				{Verb: "get", Resource: "checks", ResourceName: "check-cpu"},
+				{Verb: "delete", Resource: "checks", ResourceName: "check-cpu"},
+			},
+			errs: []gqlerrors.FormattedError{{Message: "store unavailable"}},
+		},
+		Auditor: auditor,
+	}
+	req, err := setupRequest(http.MethodPost, "/graphql", map[string]interface{}{
+		"query": "mutation { deleteCheck(input: {id: \"check-cpu\"}) { deletedId } }",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := router.query(req); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := len(auditor.records), 1; got != want {
+		t.Fatalf("bad number of audit records: got %d, want %d", got, want)
+	}
+	record := auditor.records[0]
+	if got, want := record.Verb, "delete"; got != want {
+		t.Errorf("bad verb: got %q, want %q", got, want)
+	}
+	if got, want := record.Status, http.StatusOK; got != want {
+		t.Errorf("bad status: got %d, want %d", got, want)
+	}
+	if got, want := record.Errors, []string{"store unavailable"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("bad errors: got %v, want %v", got, want)
+	}
+}
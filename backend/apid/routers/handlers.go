@@ -1,24 +1,82 @@
 package routers
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/api"
+	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/apid/handlers"
+	"github.com/sensu/sensu-go/backend/authorization"
 	"github.com/sensu/sensu-go/backend/store"
 )
 
+// eventFilterLister represents the filter needs of the reference validation of
+// the HandlersRouter.
+type eventFilterLister interface {
+	ListEventFilters(context.Context) ([]*corev2.EventFilter, error)
+}
+
+// mutatorLister represents the mutator needs of the reference validation of
+// the HandlersRouter.
+type mutatorLister interface {
+	ListMutators(context.Context) ([]*corev2.Mutator, error)
+}
+
+// handlerLister represents the handler needs of the reference validation of
+// the HandlersRouter.
+type handlerLister interface {
+	ListHandlers(context.Context) ([]*corev2.Handler, error)
+}
+
+var (
+	// builtInFilterNames are the filters provided by the pipeline, which are
+	// never found in the store.
+	builtInFilterNames = map[string]struct{}{
+		"is_incident":  {},
+		"has_metrics":  {},
+		"not_silenced": {},
+		"not_flapping": {},
+	}
+
+	// builtInMutatorNames are the mutators provided by the pipeline, which are
+	// never found in the store.
+	builtInMutatorNames = map[string]struct{}{
+		"json":              {},
+		"only_check_output": {},
+	}
+)
+
 // HandlersRouter handles requests for /handlers
 type HandlersRouter struct {
 	handlers handlers.Handlers
+	filters  eventFilterLister
+	mutators mutatorLister
+	members  handlerLister
+
+	// StrictReferences rejects the handlers that reference filters, mutators
+	// or handlers that do not exist, instead of only warning about them.
+	StrictReferences bool
 }
 
 // NewHandlersRouter instantiates new router for controlling handler resources
-func NewHandlersRouter(store store.ResourceStore) *HandlersRouter {
+func NewHandlersRouter(store store.ResourceStore, auth authorization.Authorizer) *HandlersRouter {
 	return &HandlersRouter{
 		handlers: handlers.Handlers{
 			Resource: &corev2.Handler{},
 			Store:    store,
 		},
+		filters:  api.NewEventFilterClient(store, auth),
+		mutators: api.NewMutatorClient(store, auth),
+		members:  api.NewHandlerClient(store, auth),
 	}
 }
 
@@ -33,6 +91,137 @@ func (r *HandlersRouter) Mount(parent *mux.Router) {
 	routes.List(r.handlers.ListResources, corev2.HandlerFields)
 	routes.ListAllNamespaces(r.handlers.ListResources, "/{resource:handlers}", corev2.HandlerFields)
 	routes.Patch(r.handlers.PatchResource)
-	routes.Post(r.handlers.CreateResource)
-	routes.Put(r.handlers.CreateOrUpdateResource)
+	parent.Handle(routes.PathPrefix, r.validateReferences(actionHandler(r.handlers.CreateResource))).Methods(http.MethodPost)
+	parent.Handle(path.Join(routes.PathPrefix, "{id}"), r.validateReferences(actionHandler(r.handlers.CreateOrUpdateResource))).Methods(http.MethodPut)
+}
+
+// validateReferences warns, with a Warning header, when the handler given in
+// the request body references filters, a mutator or, for handler sets,
+// handlers that do not exist in its namespace. The request is rejected
+// instead with StrictReferences.
+func (r *HandlersRouter) validateReferences(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if r.filters == nil || r.mutators == nil || r.members == nil {
+			next.ServeHTTP(w, req)
+			return
+		}
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			WriteError(w, actions.NewError(actions.InvalidArgument, err))
+			return
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		var handler corev2.Handler
+		if err := json.Unmarshal(body, &handler); err != nil {
+			// Invalid handlers are rejected by the next handler
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		if err := r.validateDeadLetter(req.Context(), &handler); err != nil {
+			WriteError(w, err)
+			return
+		}
+
+		missing, err := r.missingReferences(req.Context(), &handler)
+		if err != nil {
+			logger.WithError(err).Warn("could not validate the references of a handler")
+		} else if len(missing) > 0 {
+			msg := fmt.Sprintf("handler %q references resources that do not exist: %s",
+				handler.Name, strings.Join(missing, ", "))
+			if r.StrictReferences {
+				WriteError(w, actions.NewErrorf(actions.InvalidArgument, msg))
+				return
+			}
+			logger.Warn(msg)
+			w.Header().Add("Warning", fmt.Sprintf("199 sensu-backend %q", msg))
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// validateDeadLetter rejects the handler if its dead-letter handler is a
+// handler set, which can't be executed for the events routed to it.
+func (r *HandlersRouter) validateDeadLetter(ctx context.Context, handler *corev2.Handler) error {
+	policy, err := corev2.HandlerRetry(handler.Annotations)
+	if err != nil || policy.DeadLetter == "" {
+		// Invalid policies are rejected by the validation of the handler
+		return nil
+	}
+	handlers, err := r.members.ListHandlers(ctx)
+	if err != nil {
+		logger.WithError(err).Warn("could not validate the dead-letter handler of a handler")
+		return nil
+	}
+	for _, h := range handlers {
+		if h.Name == policy.DeadLetter && h.Type == corev2.HandlerSetType {
+			return actions.NewErrorf(actions.InvalidArgument,
+				"dead-letter handler %q of handler %q is a handler set", h.Name, handler.Name)
+		}
+	}
+	return nil
+}
+
+// missingReferences returns the filters, mutator and handlers referenced by
+// the given handler that do not exist in the namespace of the request. Only
+// the kinds of resources that are referenced are listed.
+func (r *HandlersRouter) missingReferences(ctx context.Context, handler *corev2.Handler) ([]string, error) {
+	var missing []string
+
+	if len(handler.Filters) > 0 {
+		filters, err := r.filters.ListEventFilters(ctx)
+		if err != nil {
+			return nil, err
+		}
+		names := make(map[string]struct{}, len(filters))
+		for _, filter := range filters {
+			names[filter.Name] = struct{}{}
+		}
+		for _, name := range handler.Filters {
+			if _, ok := builtInFilterNames[name]; ok {
+				continue
+			}
+			if _, ok := names[name]; !ok {
+				missing = append(missing, fmt.Sprintf("filter %q", name))
+			}
+		}
+	}
+
+	if handler.Mutator != "" {
+		if _, ok := builtInMutatorNames[handler.Mutator]; !ok {
+			mutators, err := r.mutators.ListMutators(ctx)
+			if err != nil {
+				return nil, err
+			}
+			found := false
+			for _, mutator := range mutators {
+				if mutator.Name == handler.Mutator {
+					found = true
+					break
+				}
+			}
+			if !found {
+				missing = append(missing, fmt.Sprintf("mutator %q", handler.Mutator))
+			}
+		}
+	}
+
+	if handler.Type == corev2.HandlerSetType && len(handler.Handlers) > 0 {
+		handlers, err := r.members.ListHandlers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		names := make(map[string]struct{}, len(handlers))
+		for _, h := range handlers {
+			names[h.Name] = struct{}{}
+		}
+		for _, name := range handler.Handlers {
+			if _, ok := names[name]; !ok {
+				missing = append(missing, fmt.Sprintf("handler %q", name))
+			}
+		}
+	}
+
+	return missing, nil
 }
@@ -1,6 +1,11 @@
 package routers
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/gorilla/mux"
@@ -11,7 +16,7 @@ import (
 func TestHandlersRouter(t *testing.T) {
 	// Setup the router
 	s := &mockstore.MockStore{}
-	router := NewHandlersRouter(s)
+	router := NewHandlersRouter(s, nil)
 	parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
 	router.Mount(parentRouter)
 
@@ -28,3 +33,150 @@ func TestHandlersRouter(t *testing.T) {
 		run(t, tt, parentRouter, s)
 	}
 }
+
+type fakeHandlerReferences struct {
+	filters  []*corev2.EventFilter
+	mutators []*corev2.Mutator
+	handlers []*corev2.Handler
+}
+
+func (f fakeHandlerReferences) ListEventFilters(context.Context) ([]*corev2.EventFilter, error) {
+	return f.filters, nil
+}
+
+func (f fakeHandlerReferences) ListMutators(context.Context) ([]*corev2.Mutator, error) {
+	return f.mutators, nil
+}
+
+func (f fakeHandlerReferences) ListHandlers(context.Context) ([]*corev2.Handler, error) {
+	return f.handlers, nil
+}
+
+func TestHandlersRouterValidateReferences(t *testing.T) {
+	refs := fakeHandlerReferences{
+		filters:  []*corev2.EventFilter{corev2.FixtureEventFilter("filter")},
+		mutators: []*corev2.Mutator{corev2.FixtureMutator("mutator")},
+		handlers: []*corev2.Handler{
+			corev2.FixtureHandler("handler"),
+			corev2.FixtureSetHandler("set", "handler"),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		handler     func() *corev2.Handler
+		strict      bool
+		wantStatus  int
+		wantWarning bool
+	}{
+		{
+			name: "existing references",
+			handler: func() *corev2.Handler {
+				handler := corev2.FixtureHandler("foo")
+				handler.Filters = []string{"filter", "is_incident"}
+				handler.Mutator = "mutator"
+				return handler
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "built-in mutator",
+			handler: func() *corev2.Handler {
+				handler := corev2.FixtureHandler("foo")
+				handler.Mutator = "only_check_output"
+				return handler
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "missing filter",
+			handler: func() *corev2.Handler {
+				handler := corev2.FixtureHandler("foo")
+				handler.Filters = []string{"filter", "missing"}
+				return handler
+			},
+			wantStatus:  http.StatusCreated,
+			wantWarning: true,
+		},
+		{
+			name: "missing mutator",
+			handler: func() *corev2.Handler {
+				handler := corev2.FixtureHandler("foo")
+				handler.Mutator = "missing"
+				return handler
+			},
+			wantStatus:  http.StatusCreated,
+			wantWarning: true,
+		},
+		{
+			name: "handler set with a missing handler",
+			handler: func() *corev2.Handler {
+				return corev2.FixtureSetHandler("foo", "handler", "missing")
+			},
+			wantStatus:  http.StatusCreated,
+			wantWarning: true,
+		},
+		{
+			name: "dead-letter handler",
+			handler: func() *corev2.Handler {
+				handler := corev2.FixtureHandler("foo")
+				handler.Annotations = map[string]string{corev2.HandlerDeadLetterAnnotation: "handler"}
+				return handler
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "dead-letter handler set",
+			handler: func() *corev2.Handler {
+				handler := corev2.FixtureHandler("foo")
+				handler.Annotations = map[string]string{corev2.HandlerDeadLetterAnnotation: "set"}
+				return handler
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "strict missing filter",
+			handler: func() *corev2.Handler {
+				handler := corev2.FixtureHandler("foo")
+				handler.Filters = []string{"missing"}
+				return handler
+			},
+			strict:     true,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := &HandlersRouter{
+				filters:          refs,
+				mutators:         refs,
+				members:          refs,
+				StrictReferences: tt.strict,
+			}
+
+			var received corev2.Handler
+			next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				// the body must still be readable by the next handler
+				if err := json.NewDecoder(req.Body).Decode(&received); err != nil {
+					t.Fatal(err)
+				}
+				w.WriteHeader(http.StatusCreated)
+			})
+
+			handler := tt.handler()
+			req := httptest.NewRequest(http.MethodPost, "/namespaces/default/handlers", bytes.NewReader(marshal(handler)))
+			rr := httptest.NewRecorder()
+			router.validateReferences(next).ServeHTTP(rr, req)
+
+			if got := rr.Code; got != tt.wantStatus {
+				t.Fatalf("bad status: got %d, want %d", got, tt.wantStatus)
+			}
+			if got := rr.Header().Get("Warning") != ""; got != tt.wantWarning {
+				t.Errorf("bad warning header: %q", rr.Header().Get("Warning"))
+			}
+			if tt.wantStatus == http.StatusCreated && received.Name != handler.Name {
+				t.Errorf("bad handler received by the next handler: %q", received.Name)
+			}
+		})
+	}
+}
@@ -17,10 +17,31 @@ type HealthController interface {
 	GetClusterHealth(ctx context.Context) *corev2.HealthResponse
 }
 
-// HealthRouter handles requests for /health
+// WriteHealthController is implemented by the health controllers that can
+// verify that the store accepts writes.
+type WriteHealthController interface {
+	CheckWriteHealth(ctx context.Context) error
+}
+
+// writeHealthInterval is the minimum interval between two writes to the store
+// by /health/write. Requests within the interval get the last result.
+const writeHealthInterval = time.Second
+
+// WriteHealthResponse is the response of /health/write
+type WriteHealthResponse struct {
+	Healthy bool   `json:"healthy"`
+	Err     string `json:"err,omitempty"`
+}
+
+// HealthRouter handles requests for /health and /health/write
 type HealthRouter struct {
 	controller HealthController
 	mu         sync.Mutex
+
+	// writeMu serializes the write probes, and protects the last result.
+	writeMu        sync.Mutex
+	lastWriteCheck time.Time
+	lastWriteErr   error
 }
 
 // NewHealthRouter instantiates new router for controlling health info
@@ -33,6 +54,7 @@ func NewHealthRouter(ctrl HealthController) *HealthRouter {
 // Mount the HealthRouter to a parent Router
 func (r *HealthRouter) Mount(parent *mux.Router) {
 	parent.HandleFunc("/health", r.health).Methods(http.MethodGet)
+	parent.HandleFunc("/health/write", r.writeHealth).Methods(http.MethodGet)
 }
 
 func (r *HealthRouter) health(w http.ResponseWriter, req *http.Request) {
@@ -53,6 +75,45 @@ func (r *HealthRouter) health(w http.ResponseWriter, req *http.Request) {
 	_ = json.NewEncoder(w).Encode(clusterHealth)
 }
 
+// writeHealth verifies that the store accepts writes, and responds with
+// 503 Service Unavailable when it does not, so that load balancers can take
+// the backend out of rotation. The store is written to at most once per
+// writeHealthInterval.
+func (r *HealthRouter) writeHealth(w http.ResponseWriter, req *http.Request) {
+	timeout, err := parseTimeout(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.mu.Lock()
+	controller, ok := r.controller.(WriteHealthController)
+	r.mu.Unlock()
+	if !ok {
+		http.Error(w, "write health is not supported", http.StatusNotImplemented)
+		return
+	}
+
+	r.writeMu.Lock()
+	if time.Since(r.lastWriteCheck) >= writeHealthInterval {
+		ctx := req.Context()
+		if timeout > 0 {
+			ctx = context.WithValue(ctx, store.ContextKeyTimeout, time.Duration(timeout)*time.Second)
+		}
+		r.lastWriteErr = controller.CheckWriteHealth(ctx)
+		r.lastWriteCheck = time.Now()
+	}
+	checkErr := r.lastWriteErr
+	r.writeMu.Unlock()
+
+	response := WriteHealthResponse{Healthy: checkErr == nil}
+	w.Header().Set("Content-Type", "application/json")
+	if checkErr != nil {
+		response.Err = checkErr.Error()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(response)
+}
+
 // Swap swaps the health controller of the health router.
 func (r *HealthRouter) Swap(newCtl HealthController) {
 	r.mu.Lock()
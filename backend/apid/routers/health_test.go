@@ -2,6 +2,8 @@ package routers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -21,6 +23,11 @@ func (m *mockHealthController) GetClusterHealth(ctx context.Context) *types.Heal
 	return args.Get(0).(*types.HealthResponse)
 }
 
+func (m *mockHealthController) CheckWriteHealth(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
 func newHealthTest(t *testing.T) (*mockHealthController, *httptest.Server) {
 	controller := &mockHealthController{}
 	healthRouter := NewHealthRouter(controller)
@@ -88,3 +95,59 @@ func TestUnHealthyClusterStatus(t *testing.T) {
 	}
 
 }
+
+func TestWriteHealth(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantHealth bool
+	}{
+		{name: "writeable store", wantStatus: http.StatusOK, wantHealth: true},
+		{name: "read-only store", err: errors.New("etcdserver: mvcc: database space exceeded"), wantStatus: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller, server := newHealthTest(t)
+			defer server.Close()
+			controller.On("CheckWriteHealth", mock.Anything).Return(tt.err).Once()
+
+			req := newRequest(t, http.MethodGet, server.URL+"/health/write", nil)
+			resp, err := new(http.Client).Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("bad status: got %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			var response WriteHealthResponse
+			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+				t.Fatal(err)
+			}
+			if response.Healthy != tt.wantHealth {
+				t.Errorf("bad health: got %v, want %v", response.Healthy, tt.wantHealth)
+			}
+		})
+	}
+}
+
+func TestWriteHealthRateLimited(t *testing.T) {
+	controller, server := newHealthTest(t)
+	defer server.Close()
+	controller.On("CheckWriteHealth", mock.Anything).Return(nil)
+
+	client := new(http.Client)
+	for i := 0; i < 5; i++ {
+		req := newRequest(t, http.MethodGet, server.URL+"/health/write", nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("bad status: %d", resp.StatusCode)
+		}
+	}
+	controller.AssertNumberOfCalls(t, "CheckWriteHealth", 1)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
@@ -42,6 +43,12 @@ func List(list ListControllerFunc, fields FieldsFunc) http.HandlerFunc {
 			pred.Subcollection = subcollection
 		}
 
+		// ?fields=a,b restricts the fields of the resources to the ones
+		// named, when supported by their store
+		if fields := r.URL.Query().Get("fields"); fields != "" {
+			pred.Fields = strings.Split(fields, ",")
+		}
+
 		results, err := list(r.Context(), pred)
 		if err != nil {
 			WriteError(w, err)
@@ -53,6 +53,14 @@ func TestList(t *testing.T) {
 			expectedPred:   &store.SelectionPredicate{Subcollection: "bar"},
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:           "list with fields",
+			path:           "/foo?fields=entity_class,subscriptions",
+			results:        []corev2.Resource{corev2.FixtureEntity("entity1")},
+			expectedLen:    1,
+			expectedPred:   &store.SelectionPredicate{Fields: []string{"entity_class", "subscriptions"}},
+			expectedStatus: http.StatusOK,
+		},
 		{
 			name:           "controller error",
 			path:           "/foo",
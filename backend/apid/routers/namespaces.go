@@ -3,8 +3,13 @@ package routers
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
@@ -23,6 +28,8 @@ type NamespacesRouter struct {
 	store          store.ResourceStore
 	storev2        storev2.Interface
 	namespaceStore store.NamespaceStore
+	entityStore    store.EntityStore
+	eventStore     store.EventStore
 	auth           authorization.Authorizer
 }
 
@@ -40,6 +47,14 @@ func NewNamespacesRouter(store store.ResourceStore, namespaceStore store.Namespa
 	}
 }
 
+// WithCascade allows the router to delete namespaces that still contain
+// entities and events, when the cascade query parameter is set.
+func (r *NamespacesRouter) WithCascade(entityStore store.EntityStore, eventStore store.EventStore) *NamespacesRouter {
+	r.entityStore = entityStore
+	r.eventStore = eventStore
+	return r
+}
+
 // Mount the NamespacesRouter to a parent Router
 func (r *NamespacesRouter) Mount(parent *mux.Router) {
 	routes := ResourceRoute{
@@ -79,7 +94,7 @@ func (r *NamespacesRouter) create(req *http.Request) (interface{}, error) {
 		meta.CreatedBy = claims.StandardClaims.Subject
 		ns.SetObjectMeta(meta)
 	}
-	if err := handlers.CheckMeta(&ns, mux.Vars(req), "id"); err != nil {
+	if err := handlers.CheckMeta(req.Context(), &ns, mux.Vars(req), "id"); err != nil {
 		return nil, actions.NewError(actions.InvalidArgument, err)
 	}
 	if err := ns.Validate(); err != nil {
@@ -110,7 +125,7 @@ func (r *NamespacesRouter) update(req *http.Request) (interface{}, error) {
 		meta.CreatedBy = claims.StandardClaims.Subject
 		ns.SetObjectMeta(meta)
 	}
-	if err := handlers.CheckMeta(&ns, mux.Vars(req), "id"); err != nil {
+	if err := handlers.CheckMeta(req.Context(), &ns, mux.Vars(req), "id"); err != nil {
 		return nil, actions.NewError(actions.InvalidArgument, err)
 	}
 	if err := ns.Validate(); err != nil {
@@ -136,6 +151,9 @@ func (r *NamespacesRouter) delete(req *http.Request) (interface{}, error) {
 	}
 
 	client := api.NewNamespaceClient(r.store, r.namespaceStore, r.auth, r.storev2)
+	if cascade, _ := strconv.ParseBool(req.URL.Query().Get("cascade")); cascade {
+		return r.deleteCascade(req.Context(), client, name)
+	}
 	if err := client.DeleteNamespace(req.Context(), name); err != nil {
 		switch err := err.(type) {
 		case *store.ErrNotFound:
@@ -147,3 +165,53 @@ func (r *NamespacesRouter) delete(req *http.Request) (interface{}, error) {
 
 	return nil, nil
 }
+
+func (r *NamespacesRouter) deleteCascade(ctx context.Context, client *api.NamespaceClient, name string) (interface{}, error) {
+	if r.entityStore == nil || r.eventStore == nil {
+		return nil, actions.NewErrorf(actions.InvalidArgument, "cascading deletion is not supported")
+	}
+	entities := api.NewEntityClient(r.entityStore, r.storev2, r.eventStore, r.auth)
+	events := api.NewEventClient(r.eventStore, r.auth, nil)
+	deleted, err := client.DeleteNamespaceCascade(ctx, name, entities, events)
+	if err != nil {
+		if len(deleted) > 0 {
+			err = fmt.Errorf("namespace %s was not deleted, after deleting %s: %w", name, formatDeleted(deleted), err)
+		}
+		return nil, namespaceCascadeError(err)
+	}
+	return deleted, nil
+}
+
+// namespaceCascadeError returns the actions error of an error returned by a
+// cascading deletion, so that its status code is kept.
+func namespaceCascadeError(err error) error {
+	var notFound *store.ErrNotFound
+	var apiNotFound *api.ErrNotFound
+	switch {
+	case errors.Is(err, authorization.ErrUnauthorized):
+		return actions.NewError(actions.PermissionDenied, err)
+	case errors.Is(err, authorization.ErrNoClaims):
+		return actions.NewError(actions.Unauthenticated, err)
+	case errors.As(err, &notFound), errors.As(err, &apiNotFound):
+		return actions.NewError(actions.NotFound, err)
+	}
+	return actions.NewError(actions.InternalErr, err)
+}
+
+// formatDeleted describes the number of resources deleted by type, in a
+// stable order.
+func formatDeleted(deleted map[string]int) string {
+	if len(deleted) == 0 {
+		return "no resources"
+	}
+	kinds := make([]string, 0, len(deleted))
+	for kind := range deleted {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	counts := make([]string, len(kinds))
+	for i, kind := range kinds {
+		counts[i] = fmt.Sprintf("%d %s", deleted[kind], kind)
+	}
+	return strings.Join(counts, ", ")
+}
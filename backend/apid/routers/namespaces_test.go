@@ -2,11 +2,14 @@ package routers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"testing"
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/api"
+	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/authorization/rbac"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/backend/store/v2/wrap"
@@ -137,6 +140,47 @@ func TestNamespaceRouterList(t *testing.T) {
 	}
 }
 
+func TestNamespacesRouterDeleteCascadeErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		authorized bool
+		namespace  *corev2.Namespace
+		wantCode   actions.ErrCode
+	}{
+		{
+			name:       "unauthorized",
+			authorized: false,
+			namespace:  corev2.FixtureNamespace("foo"),
+			wantCode:   actions.PermissionDenied,
+		},
+		{
+			name:       "not found",
+			authorized: true,
+			wantCode:   actions.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &mockstore.MockStore{}
+			s.On("GetNamespace", mock.Anything, "foo").Return(tt.namespace, nil)
+			authorizer := &mockauthorizer.Authorizer{}
+			authorizer.On("Authorize", mock.Anything, mock.Anything).Return(tt.authorized, nil)
+			router := NewNamespacesRouter(s, s, authorizer, new(mockstore.V2MockStore)).WithCascade(s, s)
+
+			ctx := context.WithValue(context.Background(), corev2.ClaimsKey, corev2.FixtureClaims("foo", []string{"cluster-admins"}))
+			client := api.NewNamespaceClient(s, s, authorizer, new(mockstore.V2MockStore))
+			_, err := router.deleteCascade(ctx, client, "foo")
+			var actionsErr actions.Error
+			if !errors.As(err, &actionsErr) {
+				t.Fatalf("expected an actions error, got %v", err)
+			}
+			if got, want := actionsErr.Code, tt.wantCode; got != want {
+				t.Errorf("bad error code: got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
 func mockedClaims(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), corev2.ClaimsKey, corev2.FixtureClaims("foo", []string{"cluster-admins"}))
@@ -27,7 +27,9 @@ func RespondWith(w http.ResponseWriter, r *http.Request, resources interface{})
 	_, isCoreV2Resource := resources.(corev2.Resource)
 	_, isWrapper := resources.(types.Wrapper)
 	_, isV3Resource := resources.(corev3.Resource)
-	if isCoreV2Resource || isWrapper || isV3Resource {
+	// The handler may have already set the ETag, e.g. with the version of the
+	// stored resource
+	if (isCoreV2Resource || isWrapper || isV3Resource) && w.Header().Get("ETag") == "" {
 		etag, err := store.ETag(resources)
 		if err != nil {
 			logger.WithError(err).Error("failed to generate etag")
@@ -119,6 +121,8 @@ func HTTPStatusFromCode(code actions.ErrCode) int {
 		return http.StatusPreconditionFailed
 	case actions.DeadlineExceeded:
 		return http.StatusGatewayTimeout
+	case actions.PayloadTooLarge:
+		return http.StatusRequestEntityTooLarge
 	}
 
 	logger.WithField("code", code).Error("unknown error code")
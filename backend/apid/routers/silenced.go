@@ -2,19 +2,23 @@ package routers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/url"
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/api"
 	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/apid/handlers"
+	"github.com/sensu/sensu-go/backend/authorization"
 	"github.com/sensu/sensu-go/backend/store"
 )
 
 // SilencedRouter handles requests for /users
 type SilencedRouter struct {
 	controller silencedController
+	selector   silencedSelectorCreator
 	handlers   handlers.Handlers
 }
 
@@ -26,10 +30,18 @@ type silencedController interface {
 	Get(ctx context.Context, name string) (*corev2.Silenced, error)
 }
 
+// silencedSelectorCreator represents the needs of the SilencedRouter to
+// create silenced entries from a selector, which lists the checks of the
+// namespace on behalf of the user.
+type silencedSelectorCreator interface {
+	CreateSilencedFromSelector(ctx context.Context, selector *corev2.SilencedSelector) ([]*corev2.Silenced, error)
+}
+
 // NewSilencedRouter instantiates new router for controlling user resources
-func NewSilencedRouter(store store.Store) *SilencedRouter {
+func NewSilencedRouter(store store.Store, auth authorization.Authorizer) *SilencedRouter {
 	return &SilencedRouter{
 		controller: actions.NewSilencedController(store),
+		selector:   api.NewSilencedClient(store, auth),
 		handlers: handlers.Handlers{
 			Resource: &corev2.Silenced{},
 			Store:    store,
@@ -48,6 +60,7 @@ func (r *SilencedRouter) Mount(parent *mux.Router) {
 	routes.Get(r.get)
 	routes.Post(r.create)
 	routes.Put(r.createOrReplace)
+	routes.Path("bulk", r.createFromSelector).Methods(http.MethodPost)
 	routes.List(r.listr, corev2.SilencedFields)
 	routes.ListAllNamespaces(r.listr, "/{resource:silenced}", corev2.SilencedFields)
 
@@ -72,7 +85,7 @@ func (r *SilencedRouter) create(req *http.Request) (interface{}, error) {
 		return nil, actions.NewError(actions.InvalidArgument, err)
 	}
 
-	if err := handlers.CheckMeta(entry, mux.Vars(req), "id"); err != nil {
+	if err := handlers.CheckMeta(req.Context(), entry, mux.Vars(req), "id"); err != nil {
 		return nil, actions.NewError(actions.InvalidArgument, err)
 	}
 
@@ -86,7 +99,7 @@ func (r *SilencedRouter) createOrReplace(req *http.Request) (interface{}, error)
 		return nil, actions.NewError(actions.InvalidArgument, err)
 	}
 
-	if err := handlers.CheckMeta(entry, mux.Vars(req), "id"); err != nil {
+	if err := handlers.CheckMeta(req.Context(), entry, mux.Vars(req), "id"); err != nil {
 		return nil, actions.NewError(actions.InvalidArgument, err)
 	}
 
@@ -94,6 +107,30 @@ func (r *SilencedRouter) createOrReplace(req *http.Request) (interface{}, error)
 	return nil, err
 }
 
+func (r *SilencedRouter) createFromSelector(req *http.Request) (interface{}, error) {
+	selector := &corev2.SilencedSelector{}
+	if err := UnmarshalBody(req, selector); err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	if err := selector.Validate(); err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	entries, err := r.selector.CreateSilencedFromSelector(req.Context(), selector)
+	if err != nil {
+		var notValid *store.ErrNotValid
+		switch {
+		case errors.Is(err, authorization.ErrUnauthorized):
+			return nil, actions.NewError(actions.PermissionDenied, err)
+		case errors.Is(err, authorization.ErrNoClaims):
+			return nil, actions.NewError(actions.Unauthenticated, err)
+		case errors.As(err, &notValid):
+			return nil, actions.NewError(actions.InvalidArgument, err)
+		}
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	return entries, nil
+}
+
 func (r *SilencedRouter) listr(ctx context.Context, pred *store.SelectionPredicate) ([]corev2.Resource, error) {
 	entries, err := r.controller.List(ctx, "", "")
 	if err != nil {
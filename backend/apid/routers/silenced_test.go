@@ -12,6 +12,8 @@ import (
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/authorization"
+	"github.com/sensu/sensu-go/backend/authorization/rbac"
 	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/stretchr/testify/mock"
 )
@@ -19,7 +21,7 @@ import (
 func TestSilencedRouter(t *testing.T) {
 	// Setup the router
 	s := &mockstore.MockStore{}
-	router := NewSilencedRouter(s)
+	router := NewSilencedRouter(s, &rbac.Authorizer{Store: s})
 	parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
 	router.Mount(parentRouter)
 
@@ -44,6 +46,11 @@ func (m *mockSilencedController) CreateOrReplace(ctx context.Context, entry *cor
 	return m.Called(ctx, entry).Error(0)
 }
 
+func (m *mockSilencedController) CreateSilencedFromSelector(ctx context.Context, selector *corev2.SilencedSelector) ([]*corev2.Silenced, error) {
+	args := m.Called(ctx, selector)
+	return args.Get(0).([]*corev2.Silenced), args.Error(1)
+}
+
 func (m *mockSilencedController) List(ctx context.Context, sub, check string) ([]*corev2.Silenced, error) {
 	args := m.Called(ctx, sub, check)
 	return args.Get(0).([]*corev2.Silenced), args.Error(1)
@@ -59,7 +66,7 @@ func TestSilencedRouterCustomRoutes(t *testing.T) {
 
 	// Setup the router
 	controller := &mockSilencedController{}
-	router := SilencedRouter{controller: controller}
+	router := SilencedRouter{controller: controller, selector: controller}
 	parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
 	router.Mount(parentRouter)
 
@@ -113,6 +120,44 @@ func TestSilencedRouterCustomRoutes(t *testing.T) {
 			},
 			wantStatusCode: http.StatusCreated,
 		},
+		{
+			name:           "it returns 400 if the selector to create is not decodable",
+			method:         http.MethodPost,
+			path:           empty.URIPath() + "/bulk",
+			body:           []byte(`foo`),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "it returns 400 if the selector to create is invalid",
+			method:         http.MethodPost,
+			path:           empty.URIPath() + "/bulk",
+			body:           []byte(`{}`),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "it returns 404 if the checks cannot be listed",
+			method: http.MethodPost,
+			path:   empty.URIPath() + "/bulk",
+			body:   []byte(`{"check_labels": {"maintenance": "true"}}`),
+			controllerFunc: func(c *mockSilencedController) {
+				c.On("CreateSilencedFromSelector", mock.Anything, mock.Anything).
+					Return(([]*corev2.Silenced)(nil), authorization.ErrUnauthorized).
+					Once()
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:   "it returns 200 when silenced entries are created from a selector",
+			method: http.MethodPost,
+			path:   empty.URIPath() + "/bulk",
+			body:   []byte(`{"subscriptions": ["linux"]}`),
+			controllerFunc: func(c *mockSilencedController) {
+				c.On("CreateSilencedFromSelector", mock.Anything, &corev2.SilencedSelector{Subscriptions: []string{"linux"}}).
+					Return([]*corev2.Silenced{corev2.FixtureSilenced("linux:*")}, nil).
+					Once()
+			},
+			wantStatusCode: http.StatusOK,
+		},
 		{
 			name:           "it returns 400 if the payload to update is not decodable",
 			method:         http.MethodPut,
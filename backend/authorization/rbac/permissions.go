@@ -0,0 +1,93 @@
+package rbac
+
+import (
+	"context"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/authorization"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// Permission is a rule granted to a user by a cluster role binding or a role
+// binding.
+type Permission struct {
+	// Namespace is the namespace the rule applies to, or empty if the rule is
+	// granted by a cluster role binding and applies to all namespaces.
+	Namespace string
+
+	// Binding is the name of the binding granting the rule.
+	Binding string
+
+	// RoleRef is the role or cluster role the rule belongs to.
+	RoleRef corev2.RoleRef
+
+	// Rule is the granted rule.
+	Rule corev2.Rule
+}
+
+// Allows returns whether the permission allows a request based on its
+// attributes.
+func (p Permission) Allows(attrs *authorization.Attributes) bool {
+	if p.Namespace != "" && p.Namespace != attrs.Namespace {
+		return false
+	}
+	allowed, _ := ruleAllows(attrs, p.Rule)
+	return allowed
+}
+
+// EffectivePermissions returns the permissions granted to the user by the
+// cluster role bindings, and the role bindings of all namespaces, the user or
+// one of its groups is a subject of. The cluster-wide permissions come first.
+func (a *Authorizer) EffectivePermissions(ctx context.Context, user corev2.User) ([]Permission, error) {
+	ctx = store.NamespaceContext(ctx, corev2.NamespaceTypeAll)
+	var permissions []Permission
+
+	clusterRoleBindings, err := a.Store.ListClusterRoleBindings(ctx, &store.SelectionPredicate{})
+	if err != nil {
+		if _, ok := err.(*store.ErrNotFound); !ok {
+			return nil, err
+		}
+	}
+	for _, binding := range clusterRoleBindings {
+		if !matchesUser(user, binding.Subjects) {
+			continue
+		}
+		rules, err := a.getRoleReferenceRules(ctx, binding.RoleRef)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			permissions = append(permissions, Permission{
+				Binding: binding.Name,
+				RoleRef: binding.RoleRef,
+				Rule:    rule,
+			})
+		}
+	}
+
+	roleBindings, err := a.Store.ListRoleBindings(ctx, &store.SelectionPredicate{})
+	if err != nil {
+		if _, ok := err.(*store.ErrNotFound); !ok {
+			return nil, err
+		}
+	}
+	for _, binding := range roleBindings {
+		if !matchesUser(user, binding.Subjects) {
+			continue
+		}
+		rules, err := a.getRoleReferenceRules(store.NamespaceContext(ctx, binding.Namespace), binding.RoleRef)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			permissions = append(permissions, Permission{
+				Namespace: binding.Namespace,
+				Binding:   binding.Name,
+				RoleRef:   binding.RoleRef,
+				Rule:      rule,
+			})
+		}
+	}
+
+	return permissions, nil
+}
@@ -0,0 +1,114 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/authorization"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectivePermissions(t *testing.T) {
+	user := corev2.User{Username: "foo", Groups: []string{"ops"}}
+	readEntities := corev2.Rule{Verbs: []string{"get", "list"}, Resources: []string{"entities"}}
+	writeChecks := corev2.Rule{Verbs: []string{"create", "update"}, Resources: []string{"checks"}}
+
+	stor := &mockstore.MockStore{}
+	a := &Authorizer{Store: stor}
+	stor.On("ListClusterRoleBindings", mock.Anything, &store.SelectionPredicate{}).
+		Return([]*corev2.ClusterRoleBinding{
+			{
+				ObjectMeta: corev2.ObjectMeta{Name: "ops-view"},
+				RoleRef:    corev2.RoleRef{Type: "ClusterRole", Name: "view"},
+				Subjects:   []corev2.Subject{{Type: corev2.GroupType, Name: "ops"}},
+			},
+			{
+				ObjectMeta: corev2.ObjectMeta{Name: "bar-admin"},
+				RoleRef:    corev2.RoleRef{Type: "ClusterRole", Name: "admin"},
+				Subjects:   []corev2.Subject{{Type: corev2.UserType, Name: "bar"}},
+			},
+		}, nil)
+	stor.On("ListRoleBindings", mock.Anything, &store.SelectionPredicate{}).
+		Return([]*corev2.RoleBinding{
+			{
+				ObjectMeta: corev2.ObjectMeta{Name: "foo-checks", Namespace: "acme"},
+				RoleRef:    corev2.RoleRef{Type: "Role", Name: "checks"},
+				Subjects:   []corev2.Subject{{Type: corev2.UserType, Name: "foo"}},
+			},
+		}, nil)
+	stor.On("GetClusterRole", mock.Anything, "view").
+		Return(&corev2.ClusterRole{Rules: []corev2.Rule{readEntities}}, nil)
+	stor.On("GetRole", mock.Anything, "checks").
+		Return(&corev2.Role{Rules: []corev2.Rule{writeChecks}}, nil)
+
+	permissions, err := a.EffectivePermissions(context.Background(), user)
+	require.NoError(t, err)
+	require.Len(t, permissions, 2)
+
+	assert.Equal(t, Permission{
+		Binding: "ops-view",
+		RoleRef: corev2.RoleRef{Type: "ClusterRole", Name: "view"},
+		Rule:    readEntities,
+	}, permissions[0])
+	assert.Equal(t, Permission{
+		Namespace: "acme",
+		Binding:   "foo-checks",
+		RoleRef:   corev2.RoleRef{Type: "Role", Name: "checks"},
+		Rule:      writeChecks,
+	}, permissions[1])
+
+	// Cluster-wide permissions apply to all namespaces, namespaced permissions
+	// to their namespace only
+	assert.True(t, permissions[0].Allows(&authorization.Attributes{Namespace: "dev", Verb: "list", Resource: "entities"}))
+	assert.True(t, permissions[1].Allows(&authorization.Attributes{Namespace: "acme", Verb: "create", Resource: "checks"}))
+	assert.False(t, permissions[1].Allows(&authorization.Attributes{Namespace: "dev", Verb: "create", Resource: "checks"}))
+	assert.False(t, permissions[1].Allows(&authorization.Attributes{Namespace: "acme", Verb: "delete", Resource: "checks"}))
+}
+
+func TestEffectivePermissionsErrors(t *testing.T) {
+	user := corev2.User{Username: "foo"}
+	var nilRoleBindings []*corev2.RoleBinding
+
+	t.Run("bindings store err", func(t *testing.T) {
+		stor := &mockstore.MockStore{}
+		a := &Authorizer{Store: stor}
+		stor.On("ListClusterRoleBindings", mock.Anything, &store.SelectionPredicate{}).
+			Return([]*corev2.ClusterRoleBinding(nil), errors.New("error"))
+		_, err := a.EffectivePermissions(context.Background(), user)
+		assert.Error(t, err)
+	})
+
+	t.Run("no bindings found", func(t *testing.T) {
+		stor := &mockstore.MockStore{}
+		a := &Authorizer{Store: stor}
+		stor.On("ListClusterRoleBindings", mock.Anything, &store.SelectionPredicate{}).
+			Return([]*corev2.ClusterRoleBinding(nil), &store.ErrNotFound{})
+		stor.On("ListRoleBindings", mock.Anything, &store.SelectionPredicate{}).
+			Return(nilRoleBindings, nil)
+		permissions, err := a.EffectivePermissions(context.Background(), user)
+		assert.NoError(t, err)
+		assert.Empty(t, permissions)
+	})
+
+	t.Run("missing role", func(t *testing.T) {
+		stor := &mockstore.MockStore{}
+		a := &Authorizer{Store: stor}
+		stor.On("ListClusterRoleBindings", mock.Anything, &store.SelectionPredicate{}).
+			Return([]*corev2.ClusterRoleBinding(nil), nil)
+		stor.On("ListRoleBindings", mock.Anything, &store.SelectionPredicate{}).
+			Return([]*corev2.RoleBinding{{
+				ObjectMeta: corev2.ObjectMeta{Name: "foo-checks", Namespace: "acme"},
+				RoleRef:    corev2.RoleRef{Type: "Role", Name: "checks"},
+				Subjects:   []corev2.Subject{{Type: corev2.UserType, Name: "foo"}},
+			}}, nil)
+		stor.On("GetRole", mock.Anything, "checks").Return((*corev2.Role)(nil), nil)
+		_, err := a.EffectivePermissions(context.Background(), user)
+		assert.Equal(t, ErrRoleNotFound{Role: "checks"}, err)
+	})
+}
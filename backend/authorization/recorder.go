@@ -0,0 +1,37 @@
+package authorization
+
+import (
+	"context"
+	"sync"
+)
+
+type recorderKey struct{}
+
+// recorder keeps the attributes of the requests authorized with a context.
+type recorder struct {
+	mu    sync.Mutex
+	attrs []*Attributes
+}
+
+// WithRecorder returns a context in which the attributes of the authorized
+// requests are kept by Record, and a function returning them.
+func WithRecorder(ctx context.Context) (context.Context, func() []*Attributes) {
+	r := &recorder{}
+	return context.WithValue(ctx, recorderKey{}, r), func() []*Attributes {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return append([]*Attributes(nil), r.attrs...)
+	}
+}
+
+// Record keeps the attributes of an authorized request, if the context was
+// returned by WithRecorder.
+func Record(ctx context.Context, attrs *Attributes) {
+	r, ok := ctx.Value(recorderKey{}).(*recorder)
+	if !ok {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attrs = append(r.attrs, attrs)
+}
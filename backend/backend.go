@@ -14,7 +14,6 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sensu/sensu-go/backend/resource"
 	"github.com/spf13/viper"
-	"go.etcd.io/etcd/client/pkg/v3/transport"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
@@ -39,6 +38,7 @@ import (
 	"github.com/sensu/sensu-go/backend/liveness"
 	"github.com/sensu/sensu-go/backend/logging"
 	"github.com/sensu/sensu-go/backend/messaging"
+	backendmetrics "github.com/sensu/sensu-go/backend/metrics"
 	"github.com/sensu/sensu-go/backend/pipeline"
 	"github.com/sensu/sensu-go/backend/pipeline/filter"
 	"github.com/sensu/sensu-go/backend/pipeline/handler"
@@ -48,6 +48,7 @@ import (
 	"github.com/sensu/sensu-go/backend/ringv2"
 	"github.com/sensu/sensu-go/backend/schedulerd"
 	"github.com/sensu/sensu-go/backend/secrets"
+	"github.com/sensu/sensu-go/backend/seeds"
 	"github.com/sensu/sensu-go/backend/store"
 	etcdstore "github.com/sensu/sensu-go/backend/store/etcd"
 	storev2 "github.com/sensu/sensu-go/backend/store/v2"
@@ -79,6 +80,9 @@ var SelectedMetrics = []string{
 	"sensu_go_agent_sessions",
 	"sensu_go_session_errors",
 	"sensu_go_websocket_errors",
+	"sensu_go_agent_session_first_message_seconds_bucket",
+	"sensu_go_agent_session_first_message_seconds_sum",
+	"sensu_go_agent_session_first_message_seconds_count",
 	"sensu_go_agentd_event_bytes",
 	"sensu_go_agentd_event_bytes_sum",
 	"sensu_go_agentd_event_bytes_count",
@@ -119,6 +123,8 @@ var SelectedMetrics = []string{
 	"sensu_go_pipeline_handler_duration",
 	"sensu_go_pipeline_handler_duration_sum",
 	"sensu_go_pipeline_handler_duration_count",
+	"sensu_go_queue_depth",
+	"sensu_go_queue_capacity",
 	"sensu_go_asset_fetch_duration",
 	"sensu_go_asset_fetch_duration_sum",
 	"sensu_go_asset_fetch_duration_count",
@@ -169,6 +175,17 @@ type Backend struct {
 	Cfg       *Config
 }
 
+// queueMonitor reports the depth of the pipelined, eventd and keepalived
+// queues. It is registered once, and its queues are replaced every time a
+// backend is initialized.
+var queueMonitor = backendmetrics.NewQueueMonitor()
+
+func init() {
+	if err := prometheus.Register(queueMonitor); err != nil {
+		panic(err)
+	}
+}
+
 // StoreUpdater offers a way to update an event store to a different
 // implementation in-place.
 type StoreUpdater interface {
@@ -180,9 +197,9 @@ type StoreV2Updater interface {
 	UpdateStore(to storev2.Interface)
 }
 
-func devModeClient(ctx context.Context, config *Config, backend *Backend) (*clientv3.Client, error) {
-	// Initialize and start etcd, because we'll need to provide an etcd client to
-	// the Wizard bus, which requires etcd to be started.
+// NewDevModeEtcd starts the embedded etcd used by the backend in dev mode,
+// with its data stored in the state directory of the config.
+func NewDevModeEtcd(config *Config) (*etcd.Etcd, error) {
 	cfg := etcd.NewConfig()
 	cfg.DataDir = config.StateDir
 	cfg.ListenClientURLs = []string{"http://127.0.0.1:2379"}
@@ -195,11 +212,20 @@ func devModeClient(ctx context.Context, config *Config, backend *Backend) (*clie
 	cfg.LogLevel = config.LogLevel
 	cfg.ClientLogLevel = config.Store.EtcdConfigurationStore.LogLevel
 
-	// Start etcd
 	e, err := etcd.NewEtcd(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("error starting etcd: %s", err)
 	}
+	return e, nil
+}
+
+func devModeClient(ctx context.Context, config *Config, backend *Backend) (*clientv3.Client, error) {
+	// Initialize and start etcd, because we'll need to provide an etcd client to
+	// the Wizard bus, which requires etcd to be started.
+	e, err := NewDevModeEtcd(config)
+	if err != nil {
+		return nil, err
+	}
 
 	backend.Etcd = e
 
@@ -216,8 +242,7 @@ func newClient(ctx context.Context, config *Config, backend *Backend) (*clientv3
 		return devModeClient(ctx, config, backend)
 	}
 	logger.Info("dialing etcd server")
-	tlsInfo := (transport.TLSInfo)(config.Store.EtcdConfigurationStore.ClientTLSInfo)
-	tlsConfig, err := tlsInfo.ClientConfig()
+	tlsConfig, err := config.Store.EtcdConfigurationStore.ClientTLSConfig()
 	if err != nil {
 		return nil, err
 	}
@@ -285,12 +310,21 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 
 	// Create the store, which lives on top of etcd
 	stor := etcdstore.NewStore(b.Client)
+	if err := stor.SetEventStorageFormat(viper.GetString(FlagEventStorageFormat)); err != nil {
+		return nil, err
+	}
+	if err := stor.SetMaxCheckHistory(viper.GetInt(FlagMaxCheckHistory)); err != nil {
+		return nil, err
+	}
 	b.Store = stor
 	storv2 := etcdstorev2.NewStore(b.Client)
 	var storev2Proxy storev2.Proxy
 	storev2Proxy.UpdateStore(storv2)
 	b.StoreV2 = &storev2Proxy
 	b.StoreV2Updater = &storev2Proxy
+	if threshold := viper.GetDuration(FlagStoreSlowLogThreshold); threshold > 0 {
+		b.StoreV2 = storev2.NewSlowLogStore(&storev2Proxy, threshold)
+	}
 
 	// Create the ring pool for round-robin functionality
 	b.RingPool = ringv2.NewRingPool(func(path string) ringv2.Interface {
@@ -310,6 +344,9 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 	storeProxy := store.NewStoreProxy(stor)
 	b.StoreUpdater = storeProxy
 	b.Store = storeProxy
+	if threshold := viper.GetDuration(FlagStoreSlowLogThreshold); threshold > 0 {
+		b.Store = store.NewSlowLogStore(storeProxy, threshold)
+	}
 
 	logger.Debug("Registering backend...")
 
@@ -357,6 +394,13 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 		return nil, fmt.Errorf("error creating system namespace and backend entity: %s", err.Error())
 	}
 
+	// Load the dev mode seed resources
+	if config.DevMode && config.DevSeed != "" {
+		if err := seeds.SeedDevResources(ctx, b.Store, config.DevSeed); err != nil {
+			return nil, fmt.Errorf("error loading dev seed resources: %s", err)
+		}
+	}
+
 	// Initialize the secrets provider manager
 	b.SecretsProviderManager = secrets.NewProviderManager(br)
 
@@ -367,6 +411,8 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 		Bus:         bus,
 		BufferSize:  viper.GetInt(FlagPipelinedBufferSize),
 		WorkerCount: viper.GetInt(FlagPipelinedWorkers),
+		DedupWindow: viper.GetDuration(FlagPipelinedDedupWindow),
+		Client:      b.Client,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error initializing %s: %s", pipelineDaemon.Name(), err)
@@ -388,21 +434,29 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 	hasMetricsFilterAdapter := &filter.HasMetricsAdapter{}
 	isIncidentFilterAdapter := &filter.IsIncidentAdapter{}
 	notSilencedFilterAdapter := &filter.NotSilencedAdapter{}
+	notFlappingFilterAdapter := &filter.NotFlappingAdapter{}
 
 	b.PipelineAdapterV1.FilterAdapters = []pipeline.FilterAdapter{
 		legacyFilterAdapter,
 		hasMetricsFilterAdapter,
 		isIncidentFilterAdapter,
 		notSilencedFilterAdapter,
+		notFlappingFilterAdapter,
 	}
 
 	// Initialize PipelineAdapterV1 mutator adapters
+	mutatorTimeoutPolicy := viper.GetString(FlagMutatorTimeoutPolicy)
+	if err := mutator.ValidateTimeoutPolicy(mutatorTimeoutPolicy); err != nil {
+		return nil, err
+	}
 	legacyMutatorAdapter := &mutator.LegacyAdapter{
 		AssetGetter:            assetGetter,
 		Executor:               command.NewExecutor(),
 		SecretsProviderManager: b.SecretsProviderManager,
 		Store:                  b.Store,
 		StoreTimeout:           storeTimeout,
+		MaxTimeout:             viper.GetDuration(FlagMutatorMaxTimeout),
+		TimeoutPolicy:          mutatorTimeoutPolicy,
 	}
 	onlyCheckOutputMutatorAdapter := &mutator.OnlyCheckOutputAdapter{}
 	jsonMutatorAdapter := &mutator.JSONAdapter{}
@@ -421,6 +475,8 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 		SecretsProviderManager: b.SecretsProviderManager,
 		Store:                  b.Store,
 		StoreTimeout:           storeTimeout,
+		HandlerQueueSize:       viper.GetInt(FlagHandlerQueueSize),
+		HandlerRetryQueueSize:  viper.GetInt(FlagHandlerRetryQueueSize),
 	}
 
 	b.PipelineAdapterV1.HandlerAdapters = []pipeline.HandlerAdapter{
@@ -429,8 +485,13 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 
 	pipelineDaemon.AddAdapter(&b.PipelineAdapterV1)
 	b.Daemons = append(b.Daemons, pipelineDaemon)
+	queueMonitor.Set(pipelineDaemon.Name(), pipelineDaemon)
 
 	// Initialize eventd
+	namespaceEventTTLs, err := eventd.ParseNamespaceTTLs(config.EventTTLNamespaces)
+	if err != nil {
+		return nil, err
+	}
 	event, err := eventd.New(
 		b.RunContext(),
 		eventd.Config{
@@ -446,12 +507,22 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 			LogBufferSize:       b.Cfg.EventLogBufferSize,
 			LogBufferWait:       b.Cfg.EventLogBufferWait,
 			LogParallelEncoders: b.Cfg.EventLogParallelEncoders,
+			LogOverflow:         b.Cfg.EventLogOverflow,
+			LogSpillPath:        b.Cfg.EventLogSpillFile,
+			EventTTL:            viper.GetDuration(FlagEventTTL),
+			NamespaceEventTTLs:  namespaceEventTTLs,
+			PruneInterval:       viper.GetDuration(FlagEventPruneInterval),
+			PruneRate:           rate.Limit(viper.GetFloat64(FlagEventPruneRate)),
+			PruneKeepFailing:    viper.GetBool(FlagEventPruneKeepFailing),
+			MaxEventSize:        viper.GetInt(FlagMaxEventSize),
+			DedupTTL:            viper.GetDuration(FlagEventDedupTTL),
 		},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing %s: %s", event.Name(), err)
 	}
 	b.Daemons = append(b.Daemons, event)
+	queueMonitor.Set(event.Name(), event)
 
 	// Initialize schedulerd
 	scheduler, err := schedulerd.New(
@@ -479,14 +550,17 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 	entityConfigWatcher := agentd.GetEntityConfigWatcher(b.ctx, b.Client)
 
 	// Prepare the etcd client TLS config
-	etcdClientTLSInfo := (transport.TLSInfo)(config.Store.EtcdConfigurationStore.ClientTLSInfo)
-	etcdClientTLSConfig, err := etcdClientTLSInfo.ClientConfig()
+	etcdClientTLSConfig, err := config.Store.EtcdConfigurationStore.ClientTLSConfig()
 	if err != nil {
 		return nil, err
 	}
 	b.EtcdClientTLSConfig = etcdClientTLSConfig
 
 	// Initialize keepalived
+	classTimeouts, err := keepalived.ParseClassTimeouts(config.KeepalivedClassTimeouts)
+	if err != nil {
+		return nil, err
+	}
 	keepalive, err := keepalived.New(keepalived.Config{
 		Client:                b.Client,
 		DeregistrationHandler: config.DeregistrationHandler,
@@ -499,11 +573,14 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 		BufferSize:            viper.GetInt(FlagKeepalivedBufferSize),
 		WorkerCount:           viper.GetInt(FlagKeepalivedWorkers),
 		StoreTimeout:          2 * time.Minute,
+		ClassTimeouts:         classTimeouts,
+		StartupGracePeriod:    viper.GetDuration(FlagKeepalivedStartupGracePeriod),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error initializing %s: %s", keepalive.Name(), err)
 	}
 	b.Daemons = append(b.Daemons, keepalive)
+	queueMonitor.Set(keepalive.Name(), keepalive)
 
 	// Prepare the authentication providers
 	authenticator := &authentication.Authenticator{}
@@ -545,6 +622,13 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 	b.HealthRouter = routers.NewHealthRouter(actions.NewHealthController(b.Store, b.Client.Cluster, b.EtcdClientTLSConfig))
 
 	// Initialize GraphQL service
+	namespaceClient := api.NewNamespaceClient(b.Store, b.Store, auth, b.StoreV2)
+	if interval := viper.GetDuration(FlagNamespaceCacheInterval); interval > 0 {
+		namespaceCache := api.NewNamespaceCache(b.Store, interval)
+		namespaceCache.Start(b.RunContext())
+		go invalidateNamespaceCache(b.RunContext(), b.Client, namespaceCache)
+		namespaceClient.WithCache(namespaceCache)
+	}
 	b.GraphQLService, err = graphql.NewService(graphql.ServiceConfig{
 		AssetClient:       api.NewAssetClient(b.Store, auth),
 		CheckClient:       api.NewCheckClient(b.Store, actions.NewCheckController(b.Store, queueGetter), auth),
@@ -555,13 +639,20 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 		HealthController:  actions.NewHealthController(b.Store, b.Client.Cluster, etcdClientTLSConfig),
 		MutatorClient:     api.NewMutatorClient(b.Store, auth),
 		SilencedClient:    api.NewSilencedClient(b.Store, auth),
-		NamespaceClient:   api.NewNamespaceClient(b.Store, b.Store, auth, b.StoreV2),
+		NamespaceClient:   namespaceClient,
 		HookClient:        api.NewHookConfigClient(b.Store, auth),
 		UserClient:        api.NewUserClient(b.Store, auth),
 		RBACClient:        api.NewRBACClient(b.Store, auth),
 		VersionController: actions.NewVersionController(clusterVersion),
 		MetricGatherer:    prometheus.DefaultGatherer,
 		GenericClient:     &api.GenericClient{Store: b.Store, Auth: auth},
+
+		MaxQueryDepth:      viper.GetInt(FlagGraphQLMaxDepth),
+		MaxQueryComplexity: viper.GetInt(FlagGraphQLMaxComplexity),
+		LoaderTimeout:      viper.GetDuration(FlagGraphQLLoaderTimeout),
+		LoaderCacheTTL:     viper.GetDuration(FlagGraphQLLoaderCacheTTL),
+		// introspection is always available in dev mode
+		DisableIntrospection: viper.GetBool(FlagGraphQLDisableIntrospection) && !config.DevMode,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error initializing graphql.Service: %s", err)
@@ -571,7 +662,9 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 	b.APIDConfig = apid.Config{
 		ListenAddress:       config.APIListenAddress,
 		RequestLimit:        config.APIRequestLimit,
+		MetadataSizeLimit:   config.MetadataSizeLimit,
 		WriteTimeout:        config.APIWriteTimeout,
+		EnableH2C:           config.APIEnableH2C,
 		URL:                 config.APIURL,
 		Bus:                 bus,
 		Store:               b.Store,
@@ -585,12 +678,32 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 		ClusterVersion:      clusterVersion,
 		GraphQLService:      b.GraphQLService,
 		HealthRouter:        b.HealthRouter,
+		QueueDepthHandler:   queueMonitor,
+		AuditLogFile:        config.AuditLogFile,
+		FilterTester:        &b.PipelineAdapterV1,
+		SecretsValidator:    b.SecretsProviderManager,
+
+		StrictRoundRobinChecks:  viper.GetBool(FlagStrictRoundRobinChecks),
+		StrictHandlerReferences: viper.GetBool(FlagStrictHandlerReferences),
+		MaxEventSize:            viper.GetInt(FlagMaxEventSize),
 	}
-	newApi, err := apid.New(b.APIDConfig)
-	if err != nil {
-		return nil, fmt.Errorf("error initializing %s: %s", newApi.Name(), err)
+	if !config.DisablePlatformMetrics {
+		b.APIDConfig.PlatformMetricsHandler = metrics.NewJSONHandler(&metrics.InfluxBridgeConfig{
+			Gatherer:    prometheus.DefaultGatherer,
+			ErrLogger:   logger,
+			Select:      SelectedMetrics,
+			ExtraLabels: map[string]string{"backend": getDefaultBackendID()},
+		})
+	}
+	if config.DisableAPId {
+		logger.Info("apid is disabled, the API will not be served")
+	} else {
+		newApi, err := apid.New(b.APIDConfig)
+		if err != nil {
+			return nil, fmt.Errorf("error initializing %s: %s", newApi.Name(), err)
+		}
+		b.Daemons = append(b.Daemons, newApi)
 	}
-	b.Daemons = append(b.Daemons, newApi)
 
 	// Initialize tessend
 	tessen, err := tessend.New(
@@ -608,26 +721,51 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 	b.Daemons = append(b.Daemons, tessen)
 
 	// Initialize agentd
-	agent, err := agentd.New(agentd.Config{
-		Host:                config.AgentHost,
-		Port:                config.AgentPort,
-		Bus:                 bus,
-		Store:               b.Store,
-		TLS:                 config.AgentTLSOptions,
-		RingPool:            b.RingPool,
-		WriteTimeout:        config.AgentWriteTimeout,
-		Client:              b.Client,
-		Watcher:             entityConfigWatcher,
-		EtcdClientTLSConfig: b.EtcdClientTLSConfig,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error initializing %s: %s", agent.Name(), err)
+	if config.DisableAgentd {
+		logger.Info("agentd is disabled, agent connections will not be accepted")
+	} else {
+		allowedNetworks, err := agentd.ParseCIDRs(config.AgentAllowCIDRs)
+		if err != nil {
+			return nil, err
+		}
+		agent, err := agentd.New(agentd.Config{
+			Host:                 config.AgentHost,
+			Port:                 config.AgentPort,
+			Bus:                  bus,
+			Store:                b.Store,
+			TLS:                  config.AgentTLSOptions,
+			RingPool:             b.RingPool,
+			WriteTimeout:         config.AgentWriteTimeout,
+			Client:               b.Client,
+			Watcher:              entityConfigWatcher,
+			EtcdClientTLSConfig:  b.EtcdClientTLSConfig,
+			AllowedNetworks:      allowedNetworks,
+			Compression:          config.AgentCompression,
+			RejectDuplicates:     config.AgentRejectDuplicates,
+			SubscriptionsWarning: config.AgentSubscriptionsWarning,
+			MaxSubscriptions:     config.AgentMaxSubscriptions,
+			BackendName:          getDefaultBackendID(),
+			MaxEventSize:         viper.GetInt(FlagMaxEventSize),
+			MetadataSizeLimit:    config.MetadataSizeLimit,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error initializing %s: %s", agent.Name(), err)
+		}
+		b.Daemons = append(b.Daemons, agent)
 	}
-	b.Daemons = append(b.Daemons, agent)
 
 	return b, nil
 }
 
+// invalidateNamespaceCache invalidates the namespace cache whenever namespaces
+// are modified, including through other backends of the cluster.
+func invalidateNamespaceCache(ctx context.Context, client *clientv3.Client, cache *api.NamespaceCache) {
+	watcher := etcdstore.Watch(ctx, client, etcdstore.GetNamespacesPath(ctx, "")+"/", true)
+	for range watcher.Result() {
+		cache.Invalidate()
+	}
+}
+
 func (b *Backend) runOnce() error {
 	eCloser := b.StoreUpdater.(closer)
 	defer func() { _ = eCloser.Close() }()
@@ -0,0 +1,402 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/sensu/sensu-go/backend"
+	"github.com/sensu/sensu-go/backend/etcd"
+	etcdstore "github.com/sensu/sensu-go/backend/store/etcd"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	bolt "go.etcd.io/bbolt"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	flagSkipConfirm = "skip-confirm"
+
+	// maxRestoreTxnOps is the default maximum number of operations allowed in
+	// an etcd transaction.
+	maxRestoreTxnOps = 128
+)
+
+var (
+	// keyBucketName is the bucket of the etcd snapshot database holding the
+	// revisions of the keys.
+	keyBucketName = []byte("key")
+
+	// backendsKeyPrefix holds a leased key per running backend.
+	backendsKeyPrefix = path.Join(etcdstore.EtcdRoot, "backends") + "/"
+
+	// restoreMarkerKey is set while a snapshot is being restored. It is left
+	// behind by a restore that failed midway.
+	restoreMarkerKey = path.Join(etcdstore.EtcdRoot, ".restore")
+)
+
+// EtcdCommand is the 'sensu-backend etcd' subcommand.
+func EtcdCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "etcd",
+		Short: "manage the etcd datastore of sensu",
+	}
+	cmd.AddCommand(snapshotCommand())
+	return cmd
+}
+
+func snapshotCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "save and restore snapshots of the etcd datastore",
+	}
+	cmd.AddCommand(snapshotSaveCommand())
+	cmd.AddCommand(snapshotRestoreCommand())
+	return cmd
+}
+
+func snapshotSaveCommand() *cobra.Command {
+	var setupErr error
+	cmd := &cobra.Command{
+		Use:           "save <path>",
+		Short:         "save a snapshot of the etcd datastore to a file",
+		Args:          cobra.ExactArgs(1),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_ = viper.BindPFlags(cmd.Flags())
+			if setupErr != nil {
+				return setupErr
+			}
+
+			clientConfig, err := snapshotClientConfig()
+			if err != nil {
+				return err
+			}
+			client, err := clientv3.New(clientConfig)
+			if err != nil {
+				return fmt.Errorf("error connecting to etcd: %w", err)
+			}
+			defer func() { _ = client.Close() }()
+
+			if err := saveSnapshot(context.Background(), client, args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Snapshot saved to %s\n", args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool(flagDevMode, viper.GetBool(flagDevMode), "sensu-backend is running in dev mode")
+
+	setupErr = handleConfig(cmd, os.Args[1:], false)
+
+	return cmd
+}
+
+func snapshotRestoreCommand() *cobra.Command {
+	var setupErr error
+	cmd := &cobra.Command{
+		Use:   "restore <path>",
+		Short: "restore the sensu data of the etcd datastore from a snapshot",
+		Long: "Restore the sensu data of the etcd datastore from a snapshot, replacing " +
+			"all of its current sensu data. Every sensu-backend must be stopped.\n\n" +
+			"The data is restored in batches of transactions. If the restore fails " +
+			"midway, the datastore is left partially restored, with the " + restoreMarkerKey +
+			" key set: run the restore again with the same snapshot to complete it " +
+			"before starting the backends.",
+		Args:          cobra.ExactArgs(1),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_ = viper.BindPFlags(cmd.Flags())
+			if setupErr != nil {
+				return setupErr
+			}
+
+			kvs, err := readSnapshot(args[0])
+			if err != nil {
+				return err
+			}
+
+			if !viper.GetBool(flagSkipConfirm) {
+				confirmed := false
+				prompt := &survey.Confirm{
+					Message: fmt.Sprintf("This will replace all of the sensu data in etcd with the %d keys of %s. Continue?", len(kvs), args[0]),
+				}
+				if err := survey.AskOne(prompt, &confirmed); err != nil {
+					return err
+				}
+				if !confirmed {
+					fmt.Fprintln(cmd.OutOrStdout(), "Canceled")
+					return nil
+				}
+			}
+
+			var client *clientv3.Client
+			if viper.GetBool(flagDevMode) {
+				// The embedded etcd of a dev mode backend only runs with the
+				// backend, so start it on its own. This fails if the backend is
+				// still running, since the client port is already in use.
+				stateDir := viper.GetString(flagStateDir)
+				if stateDir == "" {
+					return fmt.Errorf("--%s is required to restore a dev mode backend", flagStateDir)
+				}
+				e, err := backend.NewDevModeEtcd(&backend.Config{
+					StateDir: stateDir,
+					LogLevel: "warn",
+					Store: backend.StoreConfig{
+						EtcdConfigurationStore: backend.EtcdConfig{
+							LogLevel: viper.GetString(flagEtcdConfigStoreLogLevel),
+						},
+					},
+				})
+				if err != nil {
+					return fmt.Errorf("could not start etcd, is sensu-backend stopped? %w", err)
+				}
+				defer func() { _ = e.Shutdown() }()
+				client = e.NewEmbeddedClient()
+			} else {
+				clientConfig, err := snapshotClientConfig()
+				if err != nil {
+					return err
+				}
+				client, err = clientv3.New(clientConfig)
+				if err != nil {
+					return fmt.Errorf("error connecting to etcd: %w", err)
+				}
+			}
+			defer func() { _ = client.Close() }()
+
+			if err := restoreSnapshot(context.Background(), client, kvs); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Snapshot %s restored\n", args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool(flagDevMode, viper.GetBool(flagDevMode), "sensu-backend is running in dev mode")
+	cmd.Flags().StringP(flagStateDir, "d", viper.GetString(flagStateDir), "path to sensu state storage, required in dev mode")
+	cmd.Flags().Bool(flagSkipConfirm, false, "skip interactive confirmation prompt")
+
+	setupErr = handleConfig(cmd, os.Args[1:], false)
+
+	return cmd
+}
+
+// snapshotClientConfig returns the configuration of the etcd client from the
+// etcd flags of the backend.
+func snapshotClientConfig() (clientv3.Config, error) {
+	etcdConfig := backend.EtcdConfig{
+		ClientTLSInfo: etcd.TLSInfo{
+			CertFile:       viper.GetString(flagEtcdConfigStoreCertFile),
+			KeyFile:        viper.GetString(flagEtcdConfigStoreKeyFile),
+			TrustedCAFile:  viper.GetString(flagEtcdConfigStoreCACert),
+			ClientCertAuth: viper.GetBool(flagEtcdConfigStoreClientCertAuth),
+		},
+		TrustedCADir: viper.GetString(flagTrustedCADir),
+	}
+	tlsConfig, err := etcdConfig.ClientTLSConfig()
+	if err != nil {
+		return clientv3.Config{}, err
+	}
+
+	clientURLs := viper.GetStringSlice(flagEtcdConfigStoreURLs)
+	if viper.GetBool(flagDevMode) {
+		clientURLs = []string{"http://127.0.0.1:2379"}
+	}
+
+	return clientv3.Config{
+		Endpoints:   clientURLs,
+		Username:    viper.GetString(envEtcdConfigStoreUsername),
+		Password:    viper.GetString(envEtcdConfigStorePassword),
+		TLS:         tlsConfig,
+		DialTimeout: 5 * time.Second,
+	}, nil
+}
+
+// saveSnapshot streams a snapshot of the etcd datastore to the file at
+// dbPath. The file is only created once the snapshot is complete.
+func saveSnapshot(ctx context.Context, client *clientv3.Client, dbPath string) (err error) {
+	partPath := dbPath + ".part"
+	f, err := os.OpenFile(partPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("could not create snapshot file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(partPath)
+		}
+	}()
+
+	rc, err := client.Snapshot(ctx)
+	if err != nil {
+		return fmt.Errorf("could not request snapshot: %w", err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	if _, err := io.Copy(f, rc); err != nil {
+		return fmt.Errorf("could not receive snapshot: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(partPath, dbPath)
+}
+
+// readSnapshot returns the sensu keys of the snapshot at dbPath, at the
+// revision of the snapshot. Leased keys are left out, their leases do not
+// survive the restore.
+func readSnapshot(dbPath string) ([]*mvccpb.KeyValue, error) {
+	dbFile, err := verifiedSnapshotCopy(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.Remove(dbFile) }()
+
+	db, err := bolt.Open(dbFile, 0400, &bolt.Options{ReadOnly: true, Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("could not open snapshot: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	latest := map[string]*mvccpb.KeyValue{}
+	err = db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(keyBucketName)
+		if bucket == nil {
+			return errors.New("invalid snapshot: no key bucket")
+		}
+		// Keys are revisions, in ascending order, so the last revision of a
+		// key wins. Revisions suffixed with 't' are tombstones.
+		return bucket.ForEach(func(rev, value []byte) error {
+			var kv mvccpb.KeyValue
+			if err := kv.Unmarshal(value); err != nil {
+				return fmt.Errorf("invalid snapshot: %w", err)
+			}
+			if len(rev) > 0 && rev[len(rev)-1] == 't' {
+				delete(latest, string(kv.Key))
+				return nil
+			}
+			latest[string(kv.Key)] = &kv
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	kvs := make([]*mvccpb.KeyValue, 0, len(latest))
+	for key, kv := range latest {
+		if !strings.HasPrefix(key, etcdstore.EtcdRoot+"/") || kv.Lease != 0 {
+			continue
+		}
+		kvs = append(kvs, kv)
+	}
+	return kvs, nil
+}
+
+// verifiedSnapshotCopy copies the snapshot at dbPath to a temporary file,
+// without the sha256 checksum appended by etcd, after verifying it.
+func verifiedSnapshotCopy(dbPath string) (string, error) {
+	data, err := os.ReadFile(dbPath)
+	if err != nil {
+		return "", fmt.Errorf("could not read snapshot: %w", err)
+	}
+	// etcd appends the checksum of the database to the snapshots it streams,
+	// databases are otherwise a multiple of the 512 bytes sector size.
+	if len(data)%512 == sha256.Size {
+		db, checksum := data[:len(data)-sha256.Size], data[len(data)-sha256.Size:]
+		sum := sha256.Sum256(db)
+		if !bytes.Equal(sum[:], checksum) {
+			return "", errors.New("invalid snapshot: checksum mismatch")
+		}
+		data = db
+	}
+
+	f, err := os.CreateTemp("", "sensu-snapshot")
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// restoreSnapshot replaces the sensu keys of etcd with the given ones. It
+// refuses to do so while a backend is running.
+//
+// The keys that are not in the snapshot are deleted and the others are put,
+// in transactions of at most maxRestoreTxnOps operations. The restore marker
+// key is set until the last transaction is committed; since the restore only
+// depends on the snapshot, running it again completes a failed restore.
+func restoreSnapshot(ctx context.Context, client *clientv3.Client, kvs []*mvccpb.KeyValue) error {
+	resp, err := client.Get(ctx, backendsKeyPrefix, clientv3.WithPrefix(), clientv3.WithCountOnly())
+	if err != nil {
+		return fmt.Errorf("error connecting to etcd: %w", err)
+	}
+	if resp.Count > 0 {
+		return fmt.Errorf("%d sensu-backend(s) still running, stop every backend before restoring", resp.Count)
+	}
+
+	current, err := client.Get(ctx, etcdstore.EtcdRoot+"/", clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return fmt.Errorf("could not list current sensu data: %w", err)
+	}
+
+	if _, err := client.Put(ctx, restoreMarkerKey, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("could not start restore: %w", err)
+	}
+
+	restored := make(map[string]bool, len(kvs))
+	for _, kv := range kvs {
+		restored[string(kv.Key)] = true
+	}
+	ops := make([]clientv3.Op, 0, len(current.Kvs)+len(kvs))
+	for _, kv := range current.Kvs {
+		key := string(kv.Key)
+		if !restored[key] && key != restoreMarkerKey {
+			ops = append(ops, clientv3.OpDelete(key))
+		}
+	}
+	for _, kv := range kvs {
+		if string(kv.Key) != restoreMarkerKey {
+			ops = append(ops, clientv3.OpPut(string(kv.Key), string(kv.Value)))
+		}
+	}
+	for len(ops) > 0 {
+		n := len(ops)
+		if n > maxRestoreTxnOps {
+			n = maxRestoreTxnOps
+		}
+		if _, err := client.Txn(ctx).Then(ops[:n]...).Commit(); err != nil {
+			return fmt.Errorf("could not restore sensu data, run the restore again to complete it: %w", err)
+		}
+		ops = ops[n:]
+	}
+
+	if _, err := client.Delete(ctx, restoreMarkerKey); err != nil {
+		return fmt.Errorf("could not complete restore, run the restore again to complete it: %w", err)
+	}
+	return nil
+}
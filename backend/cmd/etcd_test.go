@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/sensu/sensu-go/backend/etcd"
+	etcdstore "github.com/sensu/sensu-go/backend/store/etcd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func revisionKey(main int64, tombstone bool) []byte {
+	b := make([]byte, 17, 18)
+	binary.BigEndian.PutUint64(b, uint64(main))
+	b[8] = '_'
+	if tombstone {
+		b = append(b, 't')
+	}
+	return b
+}
+
+func writeSnapshot(t *testing.T, kvs []mvccpb.KeyValue, tombstones map[int]bool) string {
+	t.Helper()
+	dbPath := filepath.Join(t.TempDir(), "snapshot.db")
+	db, err := bolt.Open(dbPath, 0600, nil)
+	require.NoError(t, err)
+	err = db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucket(keyBucketName)
+		if err != nil {
+			return err
+		}
+		for i, kv := range kvs {
+			value, err := kv.Marshal()
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put(revisionKey(int64(i+1), tombstones[i]), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+	return dbPath
+}
+
+func TestReadSnapshot(t *testing.T) {
+	kvs := []mvccpb.KeyValue{
+		{Key: []byte("/sensu.io/checks/default/a"), Value: []byte("1")},
+		{Key: []byte("/sensu.io/checks/default/b"), Value: []byte("1")},
+		{Key: []byte("/sensu.io/checks/default/a"), Value: []byte("2")},
+		{Key: []byte("/sensu.io/checks/default/b")},
+		{Key: []byte("/sensu.io/backends/1"), Value: []byte("1"), Lease: 1},
+		{Key: []byte("/other/key"), Value: []byte("1")},
+	}
+	dbPath := writeSnapshot(t, kvs, map[int]bool{3: true})
+
+	// append the checksum like etcd does when streaming snapshots
+	data, err := os.ReadFile(dbPath)
+	require.NoError(t, err)
+	sum := sha256.Sum256(data)
+	require.NoError(t, os.WriteFile(dbPath, append(data, sum[:]...), 0600))
+
+	got, err := readSnapshot(dbPath)
+	require.NoError(t, err)
+	sort.Slice(got, func(i, j int) bool { return string(got[i].Key) < string(got[j].Key) })
+	require.Len(t, got, 1)
+	assert.Equal(t, "/sensu.io/checks/default/a", string(got[0].Key))
+	assert.Equal(t, "2", string(got[0].Value))
+}
+
+func TestReadSnapshotChecksumMismatch(t *testing.T) {
+	dbPath := writeSnapshot(t, nil, nil)
+	data, err := os.ReadFile(dbPath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(dbPath, append(data, make([]byte, sha256.Size)...), 0600))
+
+	_, err = readSnapshot(dbPath)
+	assert.EqualError(t, err, "invalid snapshot: checksum mismatch")
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	e, cleanup := etcd.NewTestEtcd(t)
+	defer cleanup()
+	client := e.NewEmbeddedClient()
+	ctx := context.Background()
+
+	_, err := client.Put(ctx, etcdstore.EtcdRoot+"/stale", "stale")
+	require.NoError(t, err)
+	_, err = client.Put(ctx, etcdstore.EtcdRoot+"/key-0", "old")
+	require.NoError(t, err)
+	_, err = client.Put(ctx, "/other/key", "kept")
+	require.NoError(t, err)
+
+	// more keys than fit in a single transaction
+	var kvs []*mvccpb.KeyValue
+	for i := 0; i < 3*maxRestoreTxnOps; i++ {
+		kvs = append(kvs, &mvccpb.KeyValue{
+			Key:   []byte(fmt.Sprintf("%s/key-%d", etcdstore.EtcdRoot, i)),
+			Value: []byte(fmt.Sprint(i)),
+		})
+	}
+	require.NoError(t, restoreSnapshot(ctx, client, kvs))
+
+	resp, err := client.Get(ctx, etcdstore.EtcdRoot+"/", clientv3.WithPrefix())
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(kvs)), resp.Count)
+	resp, err = client.Get(ctx, etcdstore.EtcdRoot+"/key-0")
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+	assert.Equal(t, "0", string(resp.Kvs[0].Value))
+	resp, err = client.Get(ctx, "/other/key")
+	require.NoError(t, err)
+	assert.Len(t, resp.Kvs, 1)
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sensu/sensu-go/backend/apid/graphql"
+	"github.com/spf13/cobra"
+)
+
+// GraphQLCommand is the 'sensu-backend graphql' subcommand.
+func GraphQLCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "graphql",
+		Short: "inspect the GraphQL API of sensu",
+	}
+	cmd.AddCommand(exportSchemaCommand())
+	return cmd
+}
+
+func exportSchemaCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "export-schema <path>",
+		Short: "write the GraphQL schema served by sensu-backend to a file",
+		Long: "Write the GraphQL schema served by sensu-backend to a file, in the " +
+			"GraphQL schema definition language, without starting the backend.",
+		Args:          cobra.ExactArgs(1),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := exportSchema(args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "GraphQL schema exported to %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+// exportSchema writes the schema of the GraphQL service to path. The clients of
+// the service are not needed to build its schema.
+func exportSchema(path string) error {
+	svc, err := graphql.NewService(graphql.ServiceConfig{})
+	if err != nil {
+		return fmt.Errorf("error building the GraphQL schema: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(svc.Target.PrintSchema()), 0644); err != nil {
+		return fmt.Errorf("error writing the GraphQL schema: %w", err)
+	}
+	return nil
+}
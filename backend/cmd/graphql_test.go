@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.graphql")
+	require.NoError(t, exportSchema(path))
+
+	schema, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(schema), "type Query {")
+	assert.Contains(t, string(schema), "type Mutation {")
+	assert.NotContains(t, string(schema), "__Schema")
+
+	assert.Error(t, exportSchema(filepath.Join(t.TempDir(), "missing", "schema.graphql")))
+}
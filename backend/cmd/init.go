@@ -15,7 +15,6 @@ import (
 	etcdstore "github.com/sensu/sensu-go/backend/store/etcd"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"go.etcd.io/etcd/client/pkg/v3/transport"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"google.golang.org/grpc"
 )
@@ -26,6 +25,8 @@ const (
 	flagIgnoreAlreadyInitialized = "ignore-already-initialized"
 	flagInitAdminUsername        = "cluster-admin-username"
 	flagInitAdminPassword        = "cluster-admin-password"
+	flagInitAdminPasswordFile    = "cluster-admin-password-file"
+	flagInitEnsure               = "ensure"
 	flagInteractive              = "interactive"
 	flagTimeout                  = "timeout"
 	flagWait                     = "wait"
@@ -38,6 +39,10 @@ type initConfig struct {
 	backend.Config
 	SeedConfig seeds.Config
 	Timeout    time.Duration
+
+	// Ensure creates the missing default resources when the cluster has
+	// already been initialized.
+	Ensure bool
 }
 
 func (c *initConfig) Validate() error {
@@ -102,14 +107,23 @@ func InitCommand() *cobra.Command {
 				return setupErr
 			}
 
+			pgConfigStoreDSN, err := readSecretFlag(flagPGConfigStoreDSN, flagPGConfigStoreDSNFile)
+			if err != nil {
+				return err
+			}
+			pgStateStoreDSN, err := readSecretFlag(flagPGStateStoreDSN, flagPGStateStoreDSNFile)
+			if err != nil {
+				return err
+			}
+
 			cfg := &backend.Config{
 				Store: backend.StoreConfig{
 					ConfigurationStore: viper.GetString(flagConfigStore),
 					PostgresConfigurationStore: backend.PostgresConfig{
-						DSN: viper.GetString(flagPGConfigStoreDSN),
+						DSN: pgConfigStoreDSN,
 					},
 					PostgresStateStore: backend.PostgresConfig{
-						DSN: viper.GetString(flagPGStateStoreDSN),
+						DSN: pgStateStoreDSN,
 					},
 					EtcdConfigurationStore: backend.EtcdConfig{
 						ClientTLSInfo: etcd.TLSInfo{
@@ -118,6 +132,7 @@ func InitCommand() *cobra.Command {
 							TrustedCAFile:  viper.GetString(flagEtcdConfigStoreCACert),
 							ClientCertAuth: viper.GetBool(flagEtcdConfigStoreClientCertAuth),
 						},
+						TrustedCADir:      viper.GetString(flagTrustedCADir),
 						URLs:              viper.GetStringSlice(flagEtcdConfigStoreURLs),
 						Username:          viper.GetString(envEtcdConfigStoreUsername),
 						Password:          viper.GetString(envEtcdConfigStorePassword),
@@ -132,6 +147,7 @@ func InitCommand() *cobra.Command {
 			keyFile := viper.GetString(flagKeyFile)
 			insecureSkipTLSVerify := viper.GetBool(flagInsecureSkipTLSVerify)
 			trustedCAFile := viper.GetString(flagTrustedCAFile)
+			trustedCADir := viper.GetString(flagTrustedCADir)
 
 			// Optional username/password auth
 			etcdClientUsername := viper.GetString(envEtcdConfigStoreUsername)
@@ -142,6 +158,7 @@ func InitCommand() *cobra.Command {
 					CertFile:           certFile,
 					KeyFile:            keyFile,
 					TrustedCAFile:      trustedCAFile,
+					TrustedCADir:       trustedCADir,
 					InsecureSkipVerify: insecureSkipTLSVerify,
 				}
 			} else if certFile != "" || keyFile != "" {
@@ -150,9 +167,12 @@ func InitCommand() *cobra.Command {
 					flagCertFile, flagKeyFile)
 			}
 
-			// Convert the TLS config into etcd's transport.TLSInfo
-			tlsInfo := (transport.TLSInfo)(cfg.Store.EtcdConfigurationStore.ClientTLSInfo)
-			tlsConfig, err := tlsInfo.ClientConfig()
+			tlsConfig, err := cfg.Store.EtcdConfigurationStore.ClientTLSConfig()
+			if err != nil {
+				return err
+			}
+
+			adminPassword, err := readSecretFlag(flagInitAdminPassword, flagInitAdminPasswordFile)
 			if err != nil {
 				return err
 			}
@@ -166,10 +186,11 @@ func InitCommand() *cobra.Command {
 				Config: *cfg,
 				SeedConfig: seeds.Config{
 					AdminUsername: viper.GetString(flagInitAdminUsername),
-					AdminPassword: viper.GetString(flagInitAdminPassword),
+					AdminPassword: adminPassword,
 					AdminAPIKey:   viper.GetString(flagInitAdminAPIKey),
 				},
 				Timeout: timeout,
+				Ensure:  viper.GetBool(flagInitEnsure),
 			}
 
 			wait := viper.GetBool(flagWait)
@@ -252,6 +273,8 @@ func InitCommand() *cobra.Command {
 	cmd.Flags().Bool(flagIgnoreAlreadyInitialized, false, "exit 0 if the cluster has already been initialized")
 	cmd.Flags().String(flagInitAdminUsername, "", "cluster admin username")
 	cmd.Flags().String(flagInitAdminPassword, "", "cluster admin password")
+	cmd.Flags().String(flagInitAdminPasswordFile, "", "path to a file containing the cluster admin password")
+	cmd.Flags().Bool(flagInitEnsure, false, "if the cluster has already been initialized, create the default namespace, cluster-admin role and binding, and admin user if they are missing, then exit 0")
 	cmd.Flags().Bool(flagInteractive, false, "interactive mode")
 	cmd.Flags().String(flagTimeout, defaultTimeout, "duration to wait before a connection attempt to etcd is considered failed (must be >= 1s)")
 	cmd.Flags().Bool(flagWait, false, "continuously retry to establish a connection to etcd until it is successful")
@@ -286,6 +309,9 @@ func initializeStore(clientConfig clientv3.Config, initConfig initConfig, endpoi
 	store := etcdstore.NewStore(client)
 	if err := seeds.SeedCluster(ctx, store, client, initConfig.SeedConfig); err != nil {
 		if errors.Is(err, seeds.ErrAlreadyInitialized) {
+			if initConfig.Ensure {
+				return seeds.EnsureCluster(ctx, store, initConfig.SeedConfig)
+			}
 			return err
 		}
 		return fmt.Errorf("error seeding cluster, is cluster healthy? %w", err)
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// postgresDialTimeout is the time allowed to reach the state store at startup.
+const postgresDialTimeout = 5 * time.Second
+
+// postgresTarget is the location of a postgresql database, without the
+// credentials of its DSN.
+type postgresTarget struct {
+	Hosts  []string
+	DBName string
+}
+
+func (t postgresTarget) String() string {
+	return fmt.Sprintf("%s/%s", strings.Join(t.Hosts, ","), t.DBName)
+}
+
+// parsePostgresDSN returns the location of the database of a DSN, in any of
+// the formats accepted by the postgresql driver.
+func parsePostgresDSN(dsn string) (postgresTarget, error) {
+	config, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		// the errors of the driver include the DSN, whose credentials are not
+		// always redacted
+		return postgresTarget{}, errors.New("invalid postgresql DSN")
+	}
+	target := postgresTarget{DBName: config.Database}
+	seen := map[string]bool{}
+	addHost := func(host string, port uint16) {
+		address := postgresAddress(host, port)
+		if !seen[address] {
+			seen[address] = true
+			target.Hosts = append(target.Hosts, address)
+		}
+	}
+	addHost(config.Host, config.Port)
+	// the fallbacks include the other hosts of the DSN, and the same hosts
+	// with other TLS settings
+	for _, fallback := range config.Fallbacks {
+		addHost(fallback.Host, fallback.Port)
+	}
+	return target, nil
+}
+
+// postgresAddress returns the address of a postgresql host, which is the
+// socket file of the server for unix socket directories.
+func postgresAddress(host string, port uint16) string {
+	if strings.HasPrefix(host, "/") {
+		return path.Join(host, ".s.PGSQL."+strconv.Itoa(int(port)))
+	}
+	return net.JoinHostPort(host, strconv.Itoa(int(port)))
+}
+
+// checkPostgresStateStore verifies that the postgresql state store of the DSN
+// can be reached. Its errors never include the credentials of the DSN.
+func checkPostgresStateStore(dsn string) error {
+	target, err := parsePostgresDSN(dsn)
+	if err != nil {
+		return fmt.Errorf("postgresql state store: %s", err)
+	}
+	var dialErr error
+	for _, host := range target.Hosts {
+		network := "tcp"
+		if strings.HasPrefix(host, "/") {
+			network = "unix"
+		}
+		conn, err := net.DialTimeout(network, host, postgresDialTimeout)
+		if err == nil {
+			_ = conn.Close()
+			return nil
+		}
+		dialErr = err
+	}
+	return fmt.Errorf("postgresql state store %s can't be reached: %s", target, dialErr)
+}
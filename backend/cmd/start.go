@@ -12,16 +12,22 @@ import (
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
 
+	"github.com/sensu/sensu-go/backend/apid/handlers"
 	"github.com/sensu/sensu-go/backend/apid/middlewares"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/asset"
 	"github.com/sensu/sensu-go/backend"
+	"github.com/sensu/sensu-go/backend/agentd"
 	"github.com/sensu/sensu-go/backend/etcd"
+	"github.com/sensu/sensu-go/backend/eventd"
+	"github.com/sensu/sensu-go/backend/pipeline/handler"
+	"github.com/sensu/sensu-go/backend/pipeline/mutator"
 	"github.com/sensu/sensu-go/util/path"
 	stringsutil "github.com/sensu/sensu-go/util/strings"
 	"github.com/sirupsen/logrus"
@@ -35,9 +41,21 @@ import (
 // as deprecated.
 var DeprecateDashboardFlags = true
 
+// deprecatedDashboardFlags maps each deprecated dashboard flag to the
+// replacement reported in its deprecation warning.
+var deprecatedDashboardFlags = map[string]string{
+	flagDashboardHost:         "the listener host of the standalone web UI",
+	flagDashboardPort:         "the listener port of the standalone web UI",
+	flagDashboardCertFile:     "the TLS certificate of the standalone web UI",
+	flagDashboardKeyFile:      "the TLS certificate key of the standalone web UI",
+	flagDashboardWriteTimeout: "the write timeout of the standalone web UI",
+}
+
 var (
 	annotations               map[string]string
 	labels                    map[string]string
+	keepalivedClassTimeouts   map[string]string
+	eventTTLNamespaces        map[string]string
 	configFileDefaultLocation = filepath.Join(path.SystemConfigDir(), "backend.yml")
 )
 
@@ -45,41 +63,62 @@ const (
 	environmentPrefix = "sensu_backend"
 
 	// Flag constants
-	flagConfigFile            = "config-file"
-	flagAgentHost             = "agent-host"
-	flagAgentPort             = "agent-port"
-	flagAPIListenAddress      = "api-listen-address"
-	flagAPIRequestLimit       = "api-request-limit"
-	flagAPIURL                = "api-url"
-	flagAPIWriteTimeout       = "api-write-timeout"
-	flagAssetsRateLimit       = "assets-rate-limit"
-	flagAssetsBurstLimit      = "assets-burst-limit"
-	flagDashboardHost         = "dashboard-host"
-	flagDashboardPort         = "dashboard-port"
-	flagDashboardCertFile     = "dashboard-cert-file"
-	flagDashboardKeyFile      = "dashboard-key-file"
-	flagDashboardWriteTimeout = "dashboard-write-timeout"
-	flagDeregistrationHandler = "deregistration-handler"
-	flagCacheDir              = "cache-dir"
-	flagStateDir              = "state-dir"
-	flagCertFile              = "cert-file"
-	flagKeyFile               = "key-file"
-	flagTrustedCAFile         = "trusted-ca-file"
-	flagInsecureSkipTLSVerify = "insecure-skip-tls-verify"
-	flagDebug                 = "debug"
-	flagLogLevel              = "log-level"
-	flagLabels                = "labels"
-	flagAnnotations           = "annotations"
-	flagDevMode               = "dev"
+	flagConfigFile                = "config-file"
+	flagStrictConfig              = "strict-config"
+	flagAgentHost                 = "agent-host"
+	flagAgentPort                 = "agent-port"
+	flagAgentAllowCIDR            = "agent-allow-cidr"
+	flagAgentCompression          = "agent-websocket-compression"
+	flagRejectDuplicateAgents     = "reject-duplicate-agents"
+	flagAgentSubscriptionsWarning = "agent-subscriptions-warning"
+	flagAgentMaxSubscriptions     = "agent-max-subscriptions"
+	flagAPIListenAddress          = "api-listen-address"
+	flagAPIRequestLimit           = "api-request-limit"
+	flagMetadataSizeLimit         = "metadata-size-limit"
+	flagAPIURL                    = "api-url"
+	flagAPIWriteTimeout           = "api-write-timeout"
+	flagAPIEnableH2C              = "api-enable-h2c"
+	flagAssetsRateLimit           = "assets-rate-limit"
+	flagAssetsBurstLimit          = "assets-burst-limit"
+	flagDashboardHost             = "dashboard-host"
+	flagDashboardPort             = "dashboard-port"
+	flagDashboardCertFile         = "dashboard-cert-file"
+	flagDashboardKeyFile          = "dashboard-key-file"
+	flagDashboardWriteTimeout     = "dashboard-write-timeout"
+	flagDeregistrationHandler     = "deregistration-handler"
+	flagCacheDir                  = "cache-dir"
+	flagStateDir                  = "state-dir"
+	flagCertFile                  = "cert-file"
+	flagKeyFile                   = "key-file"
+	flagTrustedCAFile             = "trusted-ca-file"
+	flagAgentCertFile             = "agent-cert-file"
+	flagAgentKeyFile              = "agent-key-file"
+	flagAgentTrustedCAFile        = "agent-trusted-ca-file"
+	flagTrustedCADir              = "trusted-ca-dir"
+	flagInsecureSkipTLSVerify     = "insecure-skip-tls-verify"
+	flagDebug                     = "debug"
+	flagLogLevel                  = "log-level"
+	flagLabels                    = "labels"
+	flagAnnotations               = "annotations"
+	flagDevMode                   = "dev"
+	flagDevSeed                   = "dev-seed"
+	flagDisableAgentd             = "disable-agentd"
+	flagDisableAPId               = "disable-apid"
+	flagDisableDashboard          = "disable-dashboard"
 
 	// config store selector (etcd, postgres)
 	flagConfigStore = "config-store"
 
 	// Postgres config store
-	flagPGConfigStoreDSN = "pg-config-store-dsn"
+	flagPGConfigStoreDSN     = "pg-config-store-dsn"
+	flagPGConfigStoreDSNFile = "pg-config-store-dsn-file"
 
 	// Postgres state store
-	flagPGStateStoreDSN = "pg-state-store-dsn"
+	flagPGStateStoreDSN     = "pg-state-store-dsn"
+	flagPGStateStoreDSNFile = "pg-state-store-dsn-file"
+
+	// Postgres store migrations
+	flagSkipMigrations = "skip-migrations"
 
 	// Etcd flag constants
 	flagEtcdConfigStoreURLs     = "etcd-config-store-urls"
@@ -112,6 +151,17 @@ const (
 	// flagEventLogParallelEncoders used to indicate parallel encoders should be used for event logging
 	flagEventLogParallelEncoders = "event-log-parallel-encoders"
 
+	// flagEventLogOverflow indicates the policy applied when the event log
+	// buffer is full
+	flagEventLogOverflow = "event-log-overflow"
+
+	// flagEventLogSpillFile indicates the path to the file events are spilled
+	// to when the event log buffer is full
+	flagEventLogSpillFile = "event-log-spill-file"
+
+	// flagAuditLogFile indicates the path to the audit log file
+	flagAuditLogFile = "audit-log-file"
+
 	// Default values
 
 	// Start command usage template
@@ -169,6 +219,97 @@ func anyConfig(cfg backend.EtcdConfig) bool {
 	return !reflect.DeepEqual(cfg, zero)
 }
 
+// validateCacheAndStateDirs ensures that the cache and state directories do
+// not overlap, since unpacked assets would otherwise collide with state files.
+func validateCacheAndStateDirs(cacheDir, stateDir string) error {
+	cache, err := filepath.Abs(cacheDir)
+	if err != nil {
+		return fmt.Errorf("invalid cache dir: %s", err)
+	}
+	state, err := filepath.Abs(stateDir)
+	if err != nil {
+		return fmt.Errorf("invalid state dir: %s", err)
+	}
+	if cache == state {
+		return fmt.Errorf("--%s and --%s must be different directories, both are set to %q", flagCacheDir, flagStateDir, cache)
+	}
+	if isSubdir(cache, state) || isSubdir(state, cache) {
+		return fmt.Errorf("--%s (%q) and --%s (%q) must not be nested in one another", flagCacheDir, cache, flagStateDir, state)
+	}
+	return nil
+}
+
+// isSubdir returns true if dir is located under parent. Both paths must be
+// absolute and clean.
+func isSubdir(parent, dir string) bool {
+	rel, err := filepath.Rel(parent, dir)
+	if err != nil {
+		return false
+	}
+	return rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// agentTLSOptions returns the TLS configuration of the agent listener, made of
+// the agent TLS flags when set, falling back to the shared TLS configuration
+// of the APIs otherwise. It returns nil if neither is configured.
+func agentTLSOptions(shared *corev2.TLSOptions) (*corev2.TLSOptions, error) {
+	certFile := viper.GetString(flagAgentCertFile)
+	keyFile := viper.GetString(flagAgentKeyFile)
+	trustedCAFile := viper.GetString(flagAgentTrustedCAFile)
+
+	if (certFile == "") != (keyFile == "") {
+		return nil, fmt.Errorf(
+			"agent tls configuration error, both flags --%s & --%s are required",
+			flagAgentCertFile, flagAgentKeyFile)
+	}
+	if certFile == "" && trustedCAFile == "" {
+		return shared, nil
+	}
+
+	var tls corev2.TLSOptions
+	if shared != nil {
+		tls = *shared
+	} else {
+		tls = corev2.TLSOptions{
+			TrustedCAFile:      viper.GetString(flagTrustedCAFile),
+			TrustedCADir:       viper.GetString(flagTrustedCADir),
+			InsecureSkipVerify: viper.GetBool(flagInsecureSkipTLSVerify),
+		}
+	}
+	if certFile != "" {
+		tls.CertFile = certFile
+		tls.KeyFile = keyFile
+	}
+	if tls.CertFile == "" {
+		return nil, fmt.Errorf(
+			"--%s requires a certificate, set with --%s & --%s or --%s & --%s",
+			flagAgentTrustedCAFile, flagAgentCertFile, flagAgentKeyFile, flagCertFile, flagKeyFile)
+	}
+	if trustedCAFile != "" {
+		tls.TrustedCAFile = trustedCAFile
+	}
+	return &tls, nil
+}
+
+// readSecretFlag returns the secret set with secretFlag, or read from the file
+// set with fileFlag, without its surrounding whitespace. Both flags cannot be
+// set.
+func readSecretFlag(secretFlag, fileFlag string) (string, error) {
+	secret := viper.GetString(secretFlag)
+	file := viper.GetString(fileFlag)
+	if file == "" {
+		return secret, nil
+	}
+	if secret != "" {
+		return "", fmt.Errorf("--%s and --%s cannot be used together", secretFlag, fileFlag)
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("error reading --%s: %s", fileFlag, err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
 // StartCommand ...
 func StartCommand(initialize InitializeFunc) *cobra.Command {
 	var setupErr error
@@ -190,6 +331,10 @@ func StartCommand(initialize InitializeFunc) *cobra.Command {
 			}
 			logrus.SetLevel(level)
 
+			if DeprecateDashboardFlags {
+				warnDeprecatedDashboardFlags(cmd.Flags())
+			}
+
 			devMode := viper.GetBool(flagDevMode)
 			stateStore := "postgres"
 			configStore := viper.GetString(flagConfigStore)
@@ -202,28 +347,50 @@ func StartCommand(initialize InitializeFunc) *cobra.Command {
 				return errors.New("postgres config store not supported yet")
 			}
 
+			pgConfigStoreDSN, err := readSecretFlag(flagPGConfigStoreDSN, flagPGConfigStoreDSNFile)
+			if err != nil {
+				return err
+			}
+			pgStateStoreDSN, err := readSecretFlag(flagPGStateStoreDSN, flagPGStateStoreDSNFile)
+			if err != nil {
+				return err
+			}
+
 			cfg := &backend.Config{
-				AgentHost:             viper.GetString(flagAgentHost),
-				AgentPort:             viper.GetInt(flagAgentPort),
-				AgentWriteTimeout:     viper.GetInt(backend.FlagAgentWriteTimeout),
-				APIListenAddress:      viper.GetString(flagAPIListenAddress),
-				APIRequestLimit:       viper.GetInt64(flagAPIRequestLimit),
-				APIURL:                viper.GetString(flagAPIURL),
-				APIWriteTimeout:       viper.GetDuration(flagAPIWriteTimeout),
-				AssetsRateLimit:       rate.Limit(viper.GetFloat64(flagAssetsRateLimit)),
-				AssetsBurstLimit:      viper.GetInt(flagAssetsBurstLimit),
-				DashboardHost:         viper.GetString(flagDashboardHost),
-				DashboardPort:         viper.GetInt(flagDashboardPort),
-				DashboardTLSCertFile:  viper.GetString(flagDashboardCertFile),
-				DashboardTLSKeyFile:   viper.GetString(flagDashboardKeyFile),
-				DashboardWriteTimeout: viper.GetDuration(flagDashboardWriteTimeout),
-				DeregistrationHandler: viper.GetString(flagDeregistrationHandler),
-				CacheDir:              viper.GetString(flagCacheDir),
-				StateDir:              viper.GetString(flagStateDir),
+				AgentHost:                 viper.GetString(flagAgentHost),
+				AgentPort:                 viper.GetInt(flagAgentPort),
+				AgentWriteTimeout:         viper.GetInt(backend.FlagAgentWriteTimeout),
+				AgentAllowCIDRs:           viper.GetStringSlice(flagAgentAllowCIDR),
+				AgentCompression:          viper.GetBool(flagAgentCompression),
+				AgentRejectDuplicates:     viper.GetBool(flagRejectDuplicateAgents),
+				AgentSubscriptionsWarning: viper.GetInt(flagAgentSubscriptionsWarning),
+				AgentMaxSubscriptions:     viper.GetInt(flagAgentMaxSubscriptions),
+				APIListenAddress:          viper.GetString(flagAPIListenAddress),
+				APIRequestLimit:           viper.GetInt64(flagAPIRequestLimit),
+				MetadataSizeLimit:         viper.GetInt(flagMetadataSizeLimit),
+				APIURL:                    viper.GetString(flagAPIURL),
+				APIWriteTimeout:           viper.GetDuration(flagAPIWriteTimeout),
+				APIEnableH2C:              viper.GetBool(flagAPIEnableH2C),
+				AssetsRateLimit:           rate.Limit(viper.GetFloat64(flagAssetsRateLimit)),
+				AssetsBurstLimit:          viper.GetInt(flagAssetsBurstLimit),
+				DashboardHost:             viper.GetString(flagDashboardHost),
+				DashboardPort:             viper.GetInt(flagDashboardPort),
+				DashboardTLSCertFile:      viper.GetString(flagDashboardCertFile),
+				DashboardTLSKeyFile:       viper.GetString(flagDashboardKeyFile),
+				DashboardWriteTimeout:     viper.GetDuration(flagDashboardWriteTimeout),
+				DeregistrationHandler:     viper.GetString(flagDeregistrationHandler),
+				CacheDir:                  viper.GetString(flagCacheDir),
+				StateDir:                  viper.GetString(flagStateDir),
 
 				DevMode:                        devMode,
+				DevSeed:                        viper.GetString(flagDevSeed),
 				Labels:                         viper.GetStringMapString(flagLabels),
 				Annotations:                    viper.GetStringMapString(flagAnnotations),
+				KeepalivedClassTimeouts:        viper.GetStringMapString(backend.FlagKeepalivedClassTimeouts),
+				EventTTLNamespaces:             viper.GetStringMapString(backend.FlagEventTTLNamespaces),
+				DisableAgentd:                  viper.GetBool(flagDisableAgentd),
+				DisableAPId:                    viper.GetBool(flagDisableAPId),
+				DisableDashboard:               viper.GetBool(flagDisableDashboard),
 				DisablePlatformMetrics:         viper.GetBool(flagDisablePlatformMetrics),
 				PlatformMetricsLoggingInterval: viper.GetDuration(flagPlatformMetricsLoggingInterval),
 				PlatformMetricsLogFile:         viper.GetString(flagPlatformMetricsLogFile),
@@ -231,15 +398,18 @@ func StartCommand(initialize InitializeFunc) *cobra.Command {
 				EventLogBufferWait:             viper.GetDuration(flagEventLogBufferWait),
 				EventLogFile:                   viper.GetString(flagEventLogFile),
 				EventLogParallelEncoders:       viper.GetBool(flagEventLogParallelEncoders),
+				EventLogOverflow:               viper.GetString(flagEventLogOverflow),
+				EventLogSpillFile:              viper.GetString(flagEventLogSpillFile),
+				AuditLogFile:                   viper.GetString(flagAuditLogFile),
 
 				Store: backend.StoreConfig{
 					ConfigurationStore: configStore,
 					StateStore:         stateStore,
 					PostgresConfigurationStore: backend.PostgresConfig{
-						DSN: viper.GetString(flagPGConfigStoreDSN),
+						DSN: pgConfigStoreDSN,
 					},
 					PostgresStateStore: backend.PostgresConfig{
-						DSN: viper.GetString(flagPGStateStoreDSN),
+						DSN: pgStateStoreDSN,
 					},
 					EtcdConfigurationStore: backend.EtcdConfig{
 						ClientTLSInfo: etcd.TLSInfo{
@@ -248,6 +418,7 @@ func StartCommand(initialize InitializeFunc) *cobra.Command {
 							TrustedCAFile:  viper.GetString(flagEtcdConfigStoreCACert),
 							ClientCertAuth: viper.GetBool(flagEtcdConfigStoreClientCertAuth),
 						},
+						TrustedCADir:      viper.GetString(flagTrustedCADir),
 						URLs:              viper.GetStringSlice(flagEtcdConfigStoreURLs),
 						Username:          viper.GetString(envEtcdConfigStoreUsername),
 						Password:          viper.GetString(envEtcdConfigStorePassword),
@@ -257,6 +428,11 @@ func StartCommand(initialize InitializeFunc) *cobra.Command {
 				},
 			}
 
+			if !cfg.DevMode && cfg.DevSeed != "" {
+				logger.Warnf("--%s is only supported in dev mode, ignoring it", flagDevSeed)
+				cfg.DevSeed = ""
+			}
+
 			if cfg.DevMode && cfg.CacheDir == "" {
 				var err error
 				cfg.CacheDir, err = os.MkdirTemp("", "sensu-cache")
@@ -267,25 +443,66 @@ func StartCommand(initialize InitializeFunc) *cobra.Command {
 				return errors.New("cache dir not set")
 			}
 
+			tempStateDir := false
 			if cfg.DevMode && cfg.StateDir == "" {
 				var err error
 				cfg.StateDir, err = os.MkdirTemp("", "sensu-state")
 				if err != nil {
 					return err
 				}
+				tempStateDir = true
 			} else if cfg.StateDir == "" {
 				return errors.New("state dir not set")
 			}
 
+			if err := validateCacheAndStateDirs(cfg.CacheDir, cfg.StateDir); err != nil {
+				return err
+			}
+
+			// A fresh temporary state dir has nothing to verify or migrate,
+			// and the etcd data is only kept there by the embedded etcd of
+			// dev mode
+			if !tempStateDir {
+				if err := backend.PrepareStateDir(cfg.StateDir, cfg.DevMode); err != nil {
+					return err
+				}
+			}
+
+			if cfg.DisableAgentd && cfg.DisableAPId {
+				return fmt.Errorf("--%s and --%s cannot be used together, at least one of agentd or apid must be enabled", flagDisableAgentd, flagDisableAPId)
+			}
+
 			if flag := cmd.Flags().Lookup(flagLabels); flag != nil && flag.Changed {
 				cfg.Labels = labels
 			}
 			if flag := cmd.Flags().Lookup(flagAnnotations); flag != nil && flag.Changed {
 				cfg.Annotations = annotations
 			}
+			if err := handlers.ValidateMetadataSize(corev2.ObjectMeta{Labels: cfg.Labels, Annotations: cfg.Annotations}, cfg.MetadataSizeLimit); err != nil {
+				return fmt.Errorf("invalid --%s or --%s: %s", flagLabels, flagAnnotations, err)
+			}
+			if flag := cmd.Flags().Lookup(backend.FlagKeepalivedClassTimeouts); flag != nil && flag.Changed {
+				cfg.KeepalivedClassTimeouts = keepalivedClassTimeouts
+			}
+			if flag := cmd.Flags().Lookup(backend.FlagEventTTLNamespaces); flag != nil && flag.Changed {
+				cfg.EventTTLNamespaces = eventTTLNamespaces
+			}
 			if cfg.Store.ConfigurationStore != "etcd" && anyConfig(cfg.Store.EtcdConfigurationStore) {
 				return errors.New("etcd configuration specified, but config-store is not etcd")
 			}
+			if cfg.Store.StateStore == "postgres" && cfg.Store.PostgresStateStore.DSN != "" {
+				if err := checkPostgresStateStore(cfg.Store.PostgresStateStore.DSN); err != nil {
+					return err
+				}
+			}
+			if !viper.GetBool(flagSkipMigrations) {
+				if err := migratePostgresStores(context.Background(), cfg.Store); err != nil {
+					return err
+				}
+			}
+			if err := checkPostgresSchemas(context.Background(), cfg.Store); err != nil {
+				return err
+			}
 
 			// Sensu APIs TLS config
 			certFile := viper.GetString(flagCertFile)
@@ -294,12 +511,14 @@ func StartCommand(initialize InitializeFunc) *cobra.Command {
 			// TODO(ccressent gbolo): issue #2548
 			// Eventually this should be changed: --insecure-skip-tls-verify --etcd-insecure-skip-tls-verify
 			trustedCAFile := viper.GetString(flagTrustedCAFile)
+			trustedCADir := viper.GetString(flagTrustedCADir)
 
 			if certFile != "" && keyFile != "" {
 				cfg.TLS = &corev2.TLSOptions{
 					CertFile:           certFile,
 					KeyFile:            keyFile,
 					TrustedCAFile:      trustedCAFile,
+					TrustedCADir:       trustedCADir,
 					InsecureSkipVerify: insecureSkipTLSVerify,
 				}
 			} else if certFile != "" || keyFile != "" {
@@ -308,11 +527,20 @@ func StartCommand(initialize InitializeFunc) *cobra.Command {
 					flagCertFile, flagKeyFile)
 			}
 
-			if cf, kf := len(cfg.DashboardTLSCertFile) == 0, len(cfg.DashboardTLSKeyFile) == 0; cf != kf {
+			if cfg.APIEnableH2C && cfg.TLS != nil {
 				return fmt.Errorf(
-					"dashboard tls configuration error, both flags --%s and --%s are required",
-					flagDashboardCertFile, flagDashboardKeyFile,
-				)
+					"--%s only applies to cleartext listeners and cannot be used with --%s & --%s",
+					flagAPIEnableH2C, flagCertFile, flagKeyFile)
+			}
+
+			agentTLS, err := agentTLSOptions(cfg.TLS)
+			if err != nil {
+				return err
+			}
+			cfg.AgentTLSOptions = agentTLS
+
+			if err := validateDashboardConfig(cfg); err != nil {
+				return err
 			}
 
 			ctx, cancel := context.WithCancel(context.Background())
@@ -377,10 +605,17 @@ func handleConfig(cmd *cobra.Command, arguments []string, server bool) error {
 		// Flag defaults
 		viper.SetDefault(flagAgentHost, "[::]")
 		viper.SetDefault(flagAgentPort, 8081)
+		viper.SetDefault(flagAgentSubscriptionsWarning, agentd.DefaultSubscriptionsWarning)
+		viper.SetDefault(flagAgentMaxSubscriptions, 0)
+		viper.SetDefault(flagDisableAgentd, false)
+		viper.SetDefault(flagDisableAPId, false)
+		viper.SetDefault(flagDisableDashboard, false)
 		viper.SetDefault(flagAPIListenAddress, "[::]:8080")
 		viper.SetDefault(flagAPIRequestLimit, middlewares.MaxBytesLimit)
+		viper.SetDefault(flagMetadataSizeLimit, handlers.DefaultMetadataSizeLimit)
 		viper.SetDefault(flagAPIURL, "http://localhost:8080")
 		viper.SetDefault(flagAPIWriteTimeout, "15s")
+		viper.SetDefault(flagAPIEnableH2C, false)
 		viper.SetDefault(flagAssetsRateLimit, asset.DefaultAssetsRateLimit)
 		viper.SetDefault(flagAssetsBurstLimit, asset.DefaultAssetsBurstLimit)
 		viper.SetDefault(flagDashboardHost, "[::]")
@@ -392,14 +627,41 @@ func handleConfig(cmd *cobra.Command, arguments []string, server bool) error {
 		viper.SetDefault(flagCertFile, "")
 		viper.SetDefault(flagKeyFile, "")
 		viper.SetDefault(flagTrustedCAFile, "")
+		viper.SetDefault(flagAgentCertFile, "")
+		viper.SetDefault(flagAgentKeyFile, "")
+		viper.SetDefault(flagAgentTrustedCAFile, "")
+		viper.SetDefault(flagTrustedCADir, "")
 		viper.SetDefault(flagInsecureSkipTLSVerify, false)
 		viper.SetDefault(flagLogLevel, "warn")
 		viper.SetDefault(backend.FlagEventdWorkers, 100)
 		viper.SetDefault(backend.FlagEventdBufferSize, 1000)
 		viper.SetDefault(backend.FlagKeepalivedWorkers, 100)
 		viper.SetDefault(backend.FlagKeepalivedBufferSize, 1000)
+		viper.SetDefault(backend.FlagKeepalivedStartupGracePeriod, time.Duration(0))
 		viper.SetDefault(backend.FlagPipelinedWorkers, 100)
 		viper.SetDefault(backend.FlagPipelinedBufferSize, 1000)
+		viper.SetDefault(backend.FlagPipelinedDedupWindow, time.Duration(0))
+		viper.SetDefault(backend.FlagMutatorMaxTimeout, time.Duration(0))
+		viper.SetDefault(backend.FlagMutatorTimeoutPolicy, mutator.TimeoutPolicyFail)
+		viper.SetDefault(backend.FlagHandlerQueueSize, handler.DefaultHandlerQueueSize)
+		viper.SetDefault(backend.FlagHandlerRetryQueueSize, handler.DefaultHandlerRetryQueueSize)
+		viper.SetDefault(backend.FlagNamespaceCacheInterval, time.Duration(0))
+		viper.SetDefault(backend.FlagGraphQLMaxDepth, 0)
+		viper.SetDefault(backend.FlagGraphQLMaxComplexity, 0)
+		viper.SetDefault(backend.FlagGraphQLLoaderTimeout, time.Duration(0))
+		viper.SetDefault(backend.FlagGraphQLLoaderCacheTTL, time.Duration(0))
+		viper.SetDefault(backend.FlagGraphQLDisableIntrospection, false)
+		viper.SetDefault(backend.FlagStrictRoundRobinChecks, false)
+		viper.SetDefault(backend.FlagStrictHandlerReferences, false)
+		viper.SetDefault(backend.FlagEventTTL, time.Duration(0))
+		viper.SetDefault(backend.FlagMaxEventSize, 0)
+		viper.SetDefault(backend.FlagEventDedupTTL, time.Duration(0))
+		viper.SetDefault(backend.FlagStoreSlowLogThreshold, time.Duration(0))
+		viper.SetDefault(backend.FlagEventPruneInterval, time.Minute)
+		viper.SetDefault(backend.FlagEventPruneRate, 100.0)
+		viper.SetDefault(backend.FlagEventPruneKeepFailing, false)
+		viper.SetDefault(backend.FlagEventStorageFormat, "protobuf")
+		viper.SetDefault(backend.FlagMaxCheckHistory, corev2.DefaultMaxCheckHistory)
 		viper.SetDefault(backend.FlagAgentWriteTimeout, 15)
 		viper.SetDefault(flagDisablePlatformMetrics, defaultDisablePlatformMetrics)
 		viper.SetDefault(flagPlatformMetricsLoggingInterval, defaultPlatformMetricsLoggingInterval)
@@ -408,6 +670,10 @@ func handleConfig(cmd *cobra.Command, arguments []string, server bool) error {
 		viper.SetDefault(flagEventLogBufferSize, 100000)
 		viper.SetDefault(flagEventLogFile, "")
 		viper.SetDefault(flagEventLogParallelEncoders, false)
+		viper.SetDefault(flagEventLogOverflow, eventd.OverflowDiscard)
+		viper.SetDefault(flagEventLogSpillFile, "")
+		viper.SetDefault(flagAuditLogFile, "")
+		viper.SetDefault(flagSkipMigrations, false)
 	}
 
 	// Etcd defaults
@@ -418,14 +684,24 @@ func handleConfig(cmd *cobra.Command, arguments []string, server bool) error {
 	cmd.Flags().AddFlagSet(flags)
 
 	// Load the configuration file but only error out if flagConfigFile is used
-	if err := viper.ReadInConfig(); err != nil && configFilePathIsDefined {
-		return err
+	configFileErr := viper.ReadInConfig()
+	if configFileErr != nil && configFilePathIsDefined {
+		return configFileErr
 	}
 
 	viper.SetEnvPrefix(environmentPrefix)
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	viper.AutomaticEnv()
 
+	// Reject the unknown keys of the configuration file, which are otherwise
+	// silently ignored
+	strictConfig, _ := configFlags.GetBool(flagStrictConfig)
+	if (strictConfig || viper.GetBool(flagStrictConfig)) && configFileErr == nil {
+		if err := checkConfigKeys(configFilePath); err != nil {
+			return err
+		}
+	}
+
 	// Use our custom template for the start command
 	cobra.AddTemplateFunc("categoryFlags", categoryFlags)
 	cmd.SetUsageTemplate(startUsageTemplate)
@@ -433,6 +709,81 @@ func handleConfig(cmd *cobra.Command, arguments []string, server bool) error {
 	return nil
 }
 
+// checkConfigKeys returns an error listing the top-level keys of the
+// configuration file that do not match any flag of sensu-backend.
+func checkConfigKeys(path string) error {
+	file := viper.New()
+	file.SetConfigType("yaml")
+	file.SetConfigFile(path)
+	if err := file.ReadInConfig(); err != nil {
+		return err
+	}
+
+	known := map[string]bool{
+		envEtcdConfigStoreUsername: true,
+		envEtcdConfigStorePassword: true,
+	}
+	flagSet(true).VisitAll(func(flag *pflag.Flag) {
+		known[flag.Name] = true
+	})
+
+	var unknown []string
+	for key := range file.AllSettings() {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown keys in config file %s: %s", path, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// validateDashboardConfig verifies that the dashboard TLS certificate and key
+// are set together. The dashboard configuration is cleared instead if the
+// dashboard is disabled, so that it is ignored entirely.
+func validateDashboardConfig(cfg *backend.Config) error {
+	if cfg.DisableDashboard {
+		cfg.DashboardHost = ""
+		cfg.DashboardPort = 0
+		cfg.DashboardTLSCertFile = ""
+		cfg.DashboardTLSKeyFile = ""
+		return nil
+	}
+	if cf, kf := len(cfg.DashboardTLSCertFile) == 0, len(cfg.DashboardTLSKeyFile) == 0; cf != kf {
+		return fmt.Errorf(
+			"dashboard tls configuration error, both flags --%s and --%s are required",
+			flagDashboardCertFile, flagDashboardKeyFile,
+		)
+	}
+	return nil
+}
+
+// changedDashboardFlags returns the sorted names of the deprecated dashboard
+// flags explicitly set on the command line.
+func changedDashboardFlags(flags *pflag.FlagSet) []string {
+	var changed []string
+	for name := range deprecatedDashboardFlags {
+		if flag := flags.Lookup(name); flag != nil && flag.Changed {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// warnDeprecatedDashboardFlags logs a deprecation warning for every deprecated
+// dashboard flag explicitly set on the command line.
+func warnDeprecatedDashboardFlags(flags *pflag.FlagSet) {
+	for _, name := range changedDashboardFlags(flags) {
+		logger.WithFields(logrus.Fields{
+			"flag":        "--" + name,
+			"replacement": deprecatedDashboardFlags[name],
+		}).Warn("flag is deprecated, the dashboard is no longer served by sensu-backend")
+	}
+}
+
 func categoryFlags(category string, flags *pflag.FlagSet) *pflag.FlagSet {
 	flagSet := pflag.NewFlagSet(category, pflag.ContinueOnError)
 
@@ -456,6 +807,7 @@ func flagSet(server bool) *pflag.FlagSet {
 	// Config flag
 	configFileDescription := fmt.Sprintf("path to sensu-backend config file (default %q)", configFileDefaultLocation)
 	flagSet.StringP(flagConfigFile, "c", "", configFileDescription)
+	flagSet.Bool(flagStrictConfig, false, "fail if the config file has unknown keys")
 
 	flagSet.String(flagConfigStore, viper.GetString(flagConfigStore), "configuration store type [etcd, postgres]")
 	_ = flagSet.SetAnnotation(flagConfigStore, "categories", []string{"store"})
@@ -463,9 +815,15 @@ func flagSet(server bool) *pflag.FlagSet {
 	flagSet.String(flagPGConfigStoreDSN, viper.GetString(flagPGConfigStoreDSN), "postgresql configuration store DSN")
 	_ = flagSet.SetAnnotation(flagPGConfigStoreDSN, "categories", []string{"pgconfig"})
 
+	flagSet.String(flagPGConfigStoreDSNFile, viper.GetString(flagPGConfigStoreDSNFile), "path to a file containing the postgresql configuration store DSN, instead of --"+flagPGConfigStoreDSN)
+	_ = flagSet.SetAnnotation(flagPGConfigStoreDSNFile, "categories", []string{"pgconfig"})
+
 	flagSet.String(flagPGStateStoreDSN, viper.GetString(flagPGStateStoreDSN), "postgresql state store DSN")
 	_ = flagSet.SetAnnotation(flagPGStateStoreDSN, "categories", []string{"pgstate"})
 
+	flagSet.String(flagPGStateStoreDSNFile, viper.GetString(flagPGStateStoreDSNFile), "path to a file containing the postgresql state store DSN, instead of --"+flagPGStateStoreDSN)
+	_ = flagSet.SetAnnotation(flagPGStateStoreDSNFile, "categories", []string{"pgstate"})
+
 	// Etcd client/server flags
 	flagSet.String(flagEtcdConfigStoreLogLevel, viper.GetString(flagEtcdConfigStoreLogLevel), "etcd client logging level [panic, fatal, error, warn, info, debug]")
 	_ = flagSet.SetAnnotation(flagEtcdConfigStoreLogLevel, "categories", []string{"etcdconfig"})
@@ -482,14 +840,27 @@ func flagSet(server bool) *pflag.FlagSet {
 	flagSet.String(flagEtcdConfigStoreURLs, viper.GetString(flagEtcdConfigStoreURLs), "client URLs to use when operating as an etcd client")
 	_ = flagSet.SetAnnotation(flagEtcdConfigStoreURLs, "categories", []string{"etcdconfig"})
 
+	// Shared by the API and etcd clients
+	flagSet.String(flagTrustedCADir, viper.GetString(flagTrustedCADir), "directory of TLS CA certificates in PEM format, trusted in addition to --"+flagTrustedCAFile+" and --"+flagEtcdConfigStoreCACert)
+
 	if server {
 		// Main Flags
 		flagSet.String(flagAgentHost, viper.GetString(flagAgentHost), "agent listener host")
 		flagSet.Int(flagAgentPort, viper.GetInt(flagAgentPort), "agent listener port")
+		flagSet.StringSlice(flagAgentAllowCIDR, viper.GetStringSlice(flagAgentAllowCIDR), "CIDR of a network agents are allowed to connect from, all networks are allowed if unset. This flag can be invoked multiple times")
+		flagSet.Bool(flagAgentCompression, viper.GetBool(flagAgentCompression), "negotiate per-message compression of the websocket traffic with the agents that support it")
+		flagSet.Bool(flagRejectDuplicateAgents, viper.GetBool(flagRejectDuplicateAgents), "reject the agents connecting while an agent with the same name is connected to this backend from another host, instead of only warning about them")
+		flagSet.Int(flagAgentSubscriptionsWarning, viper.GetInt(flagAgentSubscriptionsWarning), "number of subscriptions above which the agents are logged and their entity annotated with a warning when they connect (0 to disable)")
+		flagSet.Int(flagAgentMaxSubscriptions, viper.GetInt(flagAgentMaxSubscriptions), "reject the agents connecting with more subscriptions (0 for no limit)")
+		flagSet.Bool(flagDisableAgentd, viper.GetBool(flagDisableAgentd), "do not accept agent connections, for API-only backends")
+		flagSet.Bool(flagDisableAPId, viper.GetBool(flagDisableAPId), "do not serve the API, for ingest-only backends")
+		flagSet.Bool(flagDisableDashboard, viper.GetBool(flagDisableDashboard), "ignore the deprecated dashboard flags, skipping the validation of their TLS configuration")
 		flagSet.String(flagAPIListenAddress, viper.GetString(flagAPIListenAddress), "address to listen on for api traffic")
 		flagSet.Int64(flagAPIRequestLimit, viper.GetInt64(flagAPIRequestLimit), "maximum API request body size, in bytes")
+		flagSet.Int(flagMetadataSizeLimit, viper.GetInt(flagMetadataSizeLimit), "maximum total size of the labels and annotations of a resource, in bytes (default 262144, 0 to disable)")
 		flagSet.String(flagAPIURL, viper.GetString(flagAPIURL), "url of the api to connect to")
 		flagSet.Duration(flagAPIWriteTimeout, viper.GetDuration(flagAPIWriteTimeout), "maximum duration before timing out writes of responses")
+		flagSet.Bool(flagAPIEnableH2C, viper.GetBool(flagAPIEnableH2C), "serve HTTP/2 over cleartext (h2c) on the api listener, when TLS is terminated upstream")
 		flagSet.Float64(flagAssetsRateLimit, viper.GetFloat64(flagAssetsRateLimit), "maximum number of assets fetched per second")
 		flagSet.Int(flagAssetsBurstLimit, viper.GetInt(flagAssetsBurstLimit), "asset fetch burst limit")
 		flagSet.String(flagDashboardHost, viper.GetString(flagDashboardHost), "dashboard listener host")
@@ -504,14 +875,42 @@ func flagSet(server bool) *pflag.FlagSet {
 		flagSet.String(flagKeyFile, viper.GetString(flagKeyFile), "TLS certificate key in PEM format")
 		flagSet.String(flagTrustedCAFile, viper.GetString(flagTrustedCAFile), "TLS CA certificate bundle in PEM format")
 		flagSet.Bool(flagInsecureSkipTLSVerify, viper.GetBool(flagInsecureSkipTLSVerify), "skip TLS verification (not recommended!)")
+		flagSet.String(flagAgentCertFile, viper.GetString(flagAgentCertFile), "TLS certificate in PEM format of the agent listener, --"+flagCertFile+" is used if unset")
+		flagSet.String(flagAgentKeyFile, viper.GetString(flagAgentKeyFile), "TLS certificate key in PEM format of the agent listener, --"+flagKeyFile+" is used if unset")
+		flagSet.String(flagAgentTrustedCAFile, viper.GetString(flagAgentTrustedCAFile), "TLS CA certificate bundle in PEM format of the agent listener, --"+flagTrustedCAFile+" is used if unset")
 		flagSet.Bool(flagDebug, false, "enable debugging and profiling features")
 		flagSet.String(flagLogLevel, viper.GetString(flagLogLevel), "logging level [panic, fatal, error, warn, info, debug, trace]")
 		flagSet.Int(backend.FlagEventdWorkers, viper.GetInt(backend.FlagEventdWorkers), "number of workers spawned for processing incoming events")
 		flagSet.Int(backend.FlagEventdBufferSize, viper.GetInt(backend.FlagEventdBufferSize), "number of incoming events that can be buffered")
 		flagSet.Int(backend.FlagKeepalivedWorkers, viper.GetInt(backend.FlagKeepalivedWorkers), "number of workers spawned for processing incoming keepalives")
 		flagSet.Int(backend.FlagKeepalivedBufferSize, viper.GetInt(backend.FlagKeepalivedBufferSize), "number of incoming keepalives that can be buffered")
+		flagSet.StringToStringVar(&keepalivedClassTimeouts, backend.FlagKeepalivedClassTimeouts, nil, "keepalive timeouts per entity class, in seconds, as <warning>[:<critical>] (e.g. proxy=300:600)")
+		flagSet.Duration(backend.FlagKeepalivedStartupGracePeriod, viper.GetDuration(backend.FlagKeepalivedStartupGracePeriod), "period following the start of the backend during which keepalive failures are not emitted, giving agents time to reconnect (disabled when 0)")
 		flagSet.Int(backend.FlagPipelinedWorkers, viper.GetInt(backend.FlagPipelinedWorkers), "number of workers spawned for handling events through the event pipeline")
 		flagSet.Int(backend.FlagPipelinedBufferSize, viper.GetInt(backend.FlagPipelinedBufferSize), "number of events to handle that can be buffered")
+		flagSet.Duration(backend.FlagPipelinedDedupWindow, viper.GetDuration(backend.FlagPipelinedDedupWindow), "window within which the repeats of the status last handled for a check are handled only once (disabled when 0)")
+		flagSet.Duration(backend.FlagMutatorMaxTimeout, viper.GetDuration(backend.FlagMutatorMaxTimeout), "maximum execution time of pipe mutators, including those without a timeout (disabled when 0)")
+		flagSet.String(backend.FlagMutatorTimeoutPolicy, viper.GetString(backend.FlagMutatorTimeoutPolicy), fmt.Sprintf("what happens to the events whose mutator timed out [%s, %s]", mutator.TimeoutPolicyFail, mutator.TimeoutPolicyUnmutated))
+		flagSet.Int(backend.FlagHandlerQueueSize, viper.GetInt(backend.FlagHandlerQueueSize), "number of events that can wait for a handler with a concurrency limit, beyond which they are dropped for that handler")
+		flagSet.Int(backend.FlagHandlerRetryQueueSize, viper.GetInt(backend.FlagHandlerRetryQueueSize), "number of handler retries that can wait for their backoff, beyond which the events are routed to their dead-letter handler without retry")
+		flagSet.Duration(backend.FlagNamespaceCacheInterval, viper.GetDuration(backend.FlagNamespaceCacheInterval), "interval at which the namespaces cached for GraphQL requests are refreshed (disabled when 0)")
+		flagSet.Int(backend.FlagGraphQLMaxDepth, viper.GetInt(backend.FlagGraphQLMaxDepth), "maximum nesting of the fields of a GraphQL query (unlimited when 0)")
+		flagSet.Int(backend.FlagGraphQLMaxComplexity, viper.GetInt(backend.FlagGraphQLMaxComplexity), "maximum number of fields selected by a GraphQL query, fragments included (unlimited when 0)")
+		flagSet.Duration(backend.FlagGraphQLLoaderTimeout, viper.GetDuration(backend.FlagGraphQLLoaderTimeout), "maximum time allowed to load resources from the store while resolving a GraphQL query (unlimited when 0)")
+		flagSet.Duration(backend.FlagGraphQLLoaderCacheTTL, viper.GetDuration(backend.FlagGraphQLLoaderCacheTTL), "duration after which the resources cached while resolving a GraphQL query are loaded again (cached for the whole query when 0)")
+		flagSet.Bool(backend.FlagGraphQLDisableIntrospection, viper.GetBool(backend.FlagGraphQLDisableIntrospection), "reject GraphQL introspection queries (__schema and __type), ignored in dev mode")
+		flagSet.Bool(backend.FlagStrictRoundRobinChecks, viper.GetBool(backend.FlagStrictRoundRobinChecks), "reject the round robin checks that no agent entity is subscribed to, instead of only warning about them")
+		flagSet.Bool(backend.FlagStrictHandlerReferences, viper.GetBool(backend.FlagStrictHandlerReferences), "reject the handlers that reference filters, mutators or handlers that do not exist, instead of only warning about them")
+		flagSet.Int(backend.FlagMaxEventSize, viper.GetInt(backend.FlagMaxEventSize), "maximum serialized size of the events, in bytes; larger events are rejected (unlimited when 0)")
+		flagSet.Duration(backend.FlagEventDedupTTL, viper.GetDuration(backend.FlagEventDedupTTL), "duration during which the check results of agents are remembered by entity, check, issued and executed time, to drop their duplicates such as those replayed after a reconnection (disabled when 0)")
+		flagSet.Duration(backend.FlagStoreSlowLogThreshold, viper.GetDuration(backend.FlagStoreSlowLogThreshold), "duration beyond which store operations are logged, with their namespace and key or selector (disabled when 0)")
+		flagSet.Duration(backend.FlagEventTTL, viper.GetDuration(backend.FlagEventTTL), "age after which events that were not updated are pruned (disabled when 0)")
+		flagSet.StringToStringVar(&eventTTLNamespaces, backend.FlagEventTTLNamespaces, nil, "event ttl per namespace, overriding --event-ttl (e.g. dev=24h,prod=0)")
+		flagSet.Duration(backend.FlagEventPruneInterval, viper.GetDuration(backend.FlagEventPruneInterval), "interval between two sweeps of expired events")
+		flagSet.Float64(backend.FlagEventPruneRate, viper.GetFloat64(backend.FlagEventPruneRate), "maximum number of expired events deleted per second")
+		flagSet.Bool(backend.FlagEventPruneKeepFailing, viper.GetBool(backend.FlagEventPruneKeepFailing), "never prune the events of checks that are failing")
+		flagSet.String(backend.FlagEventStorageFormat, viper.GetString(backend.FlagEventStorageFormat), "format events are written to the store in, either protobuf or json (events are read in either format)")
+		flagSet.Int(backend.FlagMaxCheckHistory, viper.GetInt(backend.FlagMaxCheckHistory), "number of check results retained in the history of the events, the oldest ones being trimmed (checks with flap thresholds retain at least 21)")
 		flagSet.Int(backend.FlagAgentWriteTimeout, viper.GetInt(backend.FlagAgentWriteTimeout), "timeout in seconds for agent writes")
 		flagSet.String(backend.FlagJWTPrivateKeyFile, viper.GetString(backend.FlagJWTPrivateKeyFile), "path to the PEM-encoded private key to use to sign JWTs")
 		flagSet.String(backend.FlagJWTPublicKeyFile, viper.GetString(backend.FlagJWTPublicKeyFile), "path to the PEM-encoded public key to use to verify JWT signatures")
@@ -523,6 +922,10 @@ func flagSet(server bool) *pflag.FlagSet {
 
 		flagSet.Bool(flagDevMode, viper.GetBool(flagDevMode), "start sensu-backend in single-node developer mode, no external dependencies required")
 		_ = flagSet.SetAnnotation(flagDevMode, "categories", []string{"store"})
+		flagSet.String(flagDevSeed, viper.GetString(flagDevSeed), "path to a file or directory of resources to load at startup, in developer mode only")
+		_ = flagSet.SetAnnotation(flagDevSeed, "categories", []string{"store"})
+		flagSet.Bool(flagSkipMigrations, viper.GetBool(flagSkipMigrations), "do not apply the migrations of the schemas of the postgresql stores at startup, see sensu-backend store migrate")
+		_ = flagSet.SetAnnotation(flagSkipMigrations, "categories", []string{"store"})
 
 		_ = flagSet.String(flagEventLogFile, "", "path to the event log file")
 		_ = flagSet.Bool(flagEventLogParallelEncoders, false, "use parallel JSON encoding for the event log")
@@ -540,6 +943,14 @@ func flagSet(server bool) *pflag.FlagSet {
 		// event back-pressure could stop the backend and its agent sessions from
 		// producing and processing new events and possibly lead to a crash.
 		_ = flagSet.String(flagEventLogBufferWait, "10ms", "full buffer wait time")
+
+		// Once the full buffer wait time elapsed, the overflow policy either
+		// discards the oldest buffered event, blocks until the writer catches up,
+		// or spills the event to a secondary file.
+		_ = flagSet.String(flagEventLogOverflow, eventd.OverflowDiscard, "policy applied when the event log buffer is full, one of discard, block or spill")
+		_ = flagSet.String(flagEventLogSpillFile, "", "path to the file events are spilled to when the event log buffer is full (defaults to the event log file with a .spill extension)")
+
+		_ = flagSet.String(flagAuditLogFile, "", "path to the audit log file, recording the mutating API requests")
 	}
 
 	flagSet.SetOutput(ioutil.Discard)
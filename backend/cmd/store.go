@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/sensu/sensu-go/backend"
+	"github.com/sensu/sensu-go/backend/store/postgres"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	flagMigrateTo    = "to"
+	flagMigrateStore = "store"
+)
+
+// StoreCommand is the 'sensu-backend store' subcommand.
+func StoreCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "store",
+		Short: "manage the postgresql stores of sensu",
+	}
+	cmd.AddCommand(migrateCommand())
+	return cmd
+}
+
+func migrateCommand() *cobra.Command {
+	var setupErr error
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "apply the migrations of the schema of a postgresql store",
+		Long: "Apply the migrations of the schema of a postgresql store, in order and " +
+			"each in its own transaction, up to the version set with --to. The " +
+			"migrations are otherwise applied when sensu-backend starts, unless " +
+			"--skip-migrations is set.",
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_ = viper.BindPFlags(cmd.Flags())
+			if setupErr != nil {
+				return setupErr
+			}
+
+			to, err := cmd.Flags().GetInt(flagMigrateTo)
+			if err != nil {
+				return err
+			}
+			store, err := cmd.Flags().GetString(flagMigrateStore)
+			if err != nil {
+				return err
+			}
+
+			var dsnFlag, fileFlag string
+			var migrations []postgres.Migration
+			switch store {
+			case "state":
+				dsnFlag, fileFlag = flagPGStateStoreDSN, flagPGStateStoreDSNFile
+				migrations = postgres.StateStoreMigrations
+			case "config":
+				dsnFlag, fileFlag = flagPGConfigStoreDSN, flagPGConfigStoreDSNFile
+				migrations = postgres.ConfigStoreMigrations
+			default:
+				return fmt.Errorf("unknown store %q, expected state or config", store)
+			}
+			dsn, err := readSecretFlag(dsnFlag, fileFlag)
+			if err != nil {
+				return err
+			}
+			if dsn == "" {
+				return fmt.Errorf("the postgresql %s store is not configured, see --%s", store, dsnFlag)
+			}
+
+			version, err := migratePostgresStore(context.Background(), store, dsn, migrations, to)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "The schema of the postgresql %s store is at version %d\n", store, version)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int(flagMigrateTo, 0, "version to migrate the schema to (the latest when 0)")
+	cmd.Flags().String(flagMigrateStore, "state", "postgresql store to migrate [state, config]")
+
+	setupErr = handleConfig(cmd, os.Args[1:], false)
+
+	return cmd
+}
+
+// postgresStore is a postgresql store of sensu, along with the migrations of
+// its schema.
+type postgresStore struct {
+	name       string
+	dsn        string
+	migrations []postgres.Migration
+}
+
+// postgresStores returns the postgresql configuration and state stores of cfg,
+// those that are in use.
+func postgresStores(cfg backend.StoreConfig) []postgresStore {
+	var stores []postgresStore
+	if cfg.ConfigurationStore == "postgres" && cfg.PostgresConfigurationStore.DSN != "" {
+		stores = append(stores, postgresStore{
+			name:       "config",
+			dsn:        cfg.PostgresConfigurationStore.DSN,
+			migrations: postgres.ConfigStoreMigrations,
+		})
+	}
+	if cfg.StateStore == "postgres" && cfg.PostgresStateStore.DSN != "" {
+		stores = append(stores, postgresStore{
+			name:       "state",
+			dsn:        cfg.PostgresStateStore.DSN,
+			migrations: postgres.StateStoreMigrations,
+		})
+	}
+	return stores
+}
+
+// migratePostgresStores applies the pending migrations of the postgresql
+// configuration and state stores of cfg, those that are in use.
+func migratePostgresStores(ctx context.Context, cfg backend.StoreConfig) error {
+	for _, store := range postgresStores(cfg) {
+		if len(store.migrations) == 0 {
+			continue
+		}
+		version, err := migratePostgresStore(ctx, store.name, store.dsn, store.migrations, 0)
+		if err != nil {
+			return err
+		}
+		logger.WithField("version", version).Infof("the schema of the postgresql %s store is up to date", store.name)
+	}
+	return nil
+}
+
+// checkPostgresSchemas verifies that the schemas of the postgresql stores of
+// cfg in use are at the version expected by the backend. Its errors never
+// include the credentials of the DSNs.
+func checkPostgresSchemas(ctx context.Context, cfg backend.StoreConfig) error {
+	for _, store := range postgresStores(cfg) {
+		target, err := parsePostgresDSN(store.dsn)
+		if err != nil {
+			return fmt.Errorf("postgresql %s store: %s", store.name, err)
+		}
+		db, err := postgres.Open(store.dsn)
+		if err != nil {
+			return fmt.Errorf("postgresql %s store %s: %w", store.name, target, err)
+		}
+		err = postgres.CheckSchema(ctx, db, store.migrations)
+		_ = db.Close()
+		if err != nil {
+			return fmt.Errorf("postgresql %s store %s: %w", store.name, target, err)
+		}
+	}
+	return nil
+}
+
+// migratePostgresStore applies the migrations of the postgresql store of the
+// DSN up to the version to, and returns the resulting version of its schema.
+// Its errors never include the credentials of the DSN.
+func migratePostgresStore(ctx context.Context, store, dsn string, migrations []postgres.Migration, to int) (int, error) {
+	db, err := postgres.Open(dsn)
+	if err != nil {
+		return 0, fmt.Errorf("postgresql %s store: %w", store, err)
+	}
+	defer func() { _ = db.Close() }()
+
+	version, err := postgres.Migrate(ctx, db, migrations, to)
+	if err != nil {
+		return version, fmt.Errorf("postgresql %s store: %w", store, err)
+	}
+	return version, nil
+}
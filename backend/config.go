@@ -1,11 +1,14 @@
 package backend
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"time"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/etcd"
 	"github.com/sensu/sensu-go/backend/licensing"
+	"go.etcd.io/etcd/client/pkg/v3/transport"
 	"golang.org/x/time/rate"
 )
 
@@ -27,10 +30,82 @@ const (
 	FlagKeepalivedWorkers = "keepalived-workers"
 	// FlagKeepalivedBufferSize defines buffer size for keepalived
 	FlagKeepalivedBufferSize = "keepalived-buffer-size"
+	// FlagKeepalivedClassTimeouts defines the keepalive timeouts per entity class
+	FlagKeepalivedClassTimeouts = "keepalived-class-timeouts"
+	// FlagKeepalivedStartupGracePeriod defines the period following the start
+	// of the backend during which keepalive failures are not emitted
+	FlagKeepalivedStartupGracePeriod = "keepalived-startup-grace-period"
 	// FlagPipelinedWorkers defines the number of workers for pipelined
 	FlagPipelinedWorkers = "pipelined-workers"
 	// FlagPipelinedBufferSize defines the buffer size for pipelined
 	FlagPipelinedBufferSize = "pipelined-buffer-size"
+	// FlagPipelinedDedupWindow defines the window within which pipelined
+	// coalesces identical status transitions
+	FlagPipelinedDedupWindow = "pipelined-dedup-window"
+	// FlagMutatorMaxTimeout defines the maximum execution time of pipe
+	// mutators
+	FlagMutatorMaxTimeout = "mutator-max-timeout"
+	// FlagMutatorTimeoutPolicy defines what happens to the events whose
+	// mutator timed out
+	FlagMutatorTimeoutPolicy = "mutator-timeout-policy"
+	// FlagHandlerQueueSize defines the number of executions that can wait for
+	// a handler with a concurrency limit
+	FlagHandlerQueueSize = "handler-queue-size"
+	// FlagHandlerRetryQueueSize defines the number of handler retries that
+	// can wait for their backoff
+	FlagHandlerRetryQueueSize = "handler-retry-queue-size"
+	// FlagNamespaceCacheInterval defines the interval at which the namespaces
+	// cached for the GraphQL service are refreshed
+	FlagNamespaceCacheInterval = "namespace-cache-interval"
+	// FlagGraphQLMaxDepth defines the maximum nesting of the fields of a
+	// GraphQL query
+	FlagGraphQLMaxDepth = "graphql-max-depth"
+	// FlagGraphQLMaxComplexity defines the maximum number of fields selected
+	// by a GraphQL query
+	FlagGraphQLMaxComplexity = "graphql-max-complexity"
+	// FlagGraphQLLoaderTimeout defines the maximum time allowed to load
+	// resources from the store while resolving a GraphQL query
+	FlagGraphQLLoaderTimeout = "graphql-loader-timeout"
+	// FlagGraphQLLoaderCacheTTL defines the duration after which the resources
+	// cached while resolving a GraphQL query are loaded again
+	FlagGraphQLLoaderCacheTTL = "graphql-loader-cache-ttl"
+	// FlagGraphQLDisableIntrospection disables the GraphQL introspection
+	// queries
+	FlagGraphQLDisableIntrospection = "graphql-disable-introspection"
+	// FlagStrictRoundRobinChecks rejects the round robin checks that no agent
+	// entity is subscribed to
+	FlagStrictRoundRobinChecks = "strict-round-robin-checks"
+	// FlagStrictHandlerReferences rejects the handlers that reference filters,
+	// mutators or handlers that do not exist
+	FlagStrictHandlerReferences = "strict-handler-references"
+	// FlagEventTTL defines the age after which events that were not updated
+	// are pruned
+	FlagEventTTL = "event-ttl"
+	// FlagMaxEventSize defines the maximum serialized size of the events
+	FlagMaxEventSize = "max-event-size"
+	// FlagEventDedupTTL defines the duration during which the check results
+	// processed are remembered, to drop their duplicates
+	FlagEventDedupTTL = "event-dedup-ttl"
+	// FlagStoreSlowLogThreshold defines the duration beyond which store
+	// operations are logged as slow
+	FlagStoreSlowLogThreshold = "store-slow-log-threshold"
+	// FlagEventTTLNamespaces defines the event TTL per namespace
+	FlagEventTTLNamespaces = "event-ttl-namespaces"
+	// FlagEventPruneInterval defines the interval between two sweeps of
+	// expired events
+	FlagEventPruneInterval = "event-prune-interval"
+	// FlagEventPruneRate defines the maximum number of expired events deleted
+	// per second
+	FlagEventPruneRate = "event-prune-rate"
+	// FlagEventPruneKeepFailing prevents the events of failing checks from
+	// being pruned
+	FlagEventPruneKeepFailing = "event-prune-keep-failing"
+	// FlagEventStorageFormat defines the format events are written in, either
+	// protobuf or json
+	FlagEventStorageFormat = "event-storage-format"
+	// FlagMaxCheckHistory defines the number of check results retained in the
+	// history of the events
+	FlagMaxCheckHistory = "max-check-history"
 
 	// FlagAgentWriteTimeout specifies the time in seconds to wait before
 	// giving up on a write to an agent and disposing of the connection.
@@ -66,6 +141,7 @@ type StoreConfig struct {
 
 type EtcdConfig struct {
 	ClientTLSInfo     etcd.TLSInfo
+	TrustedCADir      string
 	URLs              []string
 	Username          string
 	Password          string
@@ -73,10 +149,47 @@ type EtcdConfig struct {
 	UseEmbeddedClient bool
 }
 
+// ClientTLSConfig returns the TLS configuration of the etcd client. The
+// certificates found in TrustedCADir, if any, are added to the trusted CAs.
+func (c EtcdConfig) ClientTLSConfig() (*tls.Config, error) {
+	tlsInfo := (transport.TLSInfo)(c.ClientTLSInfo)
+	tlsConfig, err := tlsInfo.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	if c.TrustedCADir == "" {
+		return tlsConfig, nil
+	}
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+	if tlsConfig.RootCAs == nil {
+		tlsConfig.RootCAs = x509.NewCertPool()
+	}
+	if err := corev2.AppendCACertsFromDir(tlsConfig.RootCAs, c.TrustedCADir); err != nil {
+		return nil, err
+	}
+	return tlsConfig, nil
+}
+
 type PostgresConfig struct {
 	DSN string
 }
 
+// String redacts the DSN, which usually contains credentials, so that it does
+// not leak when the configuration is printed.
+func (c PostgresConfig) String() string {
+	if c.DSN == "" {
+		return "{DSN:}"
+	}
+	return "{DSN:[redacted]}"
+}
+
+// GoString redacts the DSN like String.
+func (c PostgresConfig) GoString() string {
+	return "backend.PostgresConfig" + c.String()
+}
+
 // Config specifies a Backend configuration.
 type Config struct {
 	// Backend Configuration
@@ -88,12 +201,29 @@ type Config struct {
 	AgentPort         int
 	AgentTLSOptions   *corev2.TLSOptions
 	AgentWriteTimeout int
+	AgentAllowCIDRs   []string
+	AgentCompression  bool
+
+	// AgentRejectDuplicates rejects the agents connecting with the name of an
+	// agent connected from another host
+	AgentRejectDuplicates bool
+
+	// AgentSubscriptionsWarning is the number of subscriptions above which
+	// the agents are warned about, and AgentMaxSubscriptions the number above
+	// which they are rejected. Both are disabled when zero.
+	AgentSubscriptionsWarning int
+	AgentMaxSubscriptions     int
 
 	// Apid Configuration
 	APIListenAddress string
 	APIRequestLimit  int64
 	APIURL           string
 	APIWriteTimeout  time.Duration
+	APIEnableH2C     bool
+
+	// MetadataSizeLimit is the maximum total size, in bytes, of the labels and
+	// annotations of a resource.
+	MetadataSizeLimit int
 
 	// AssetsRateLimit is the maximum number of assets per second that will be fetched.
 	AssetsRateLimit rate.Limit
@@ -111,6 +241,14 @@ type Config struct {
 	// Pipelined Configuration
 	DeregistrationHandler string
 
+	// KeepalivedClassTimeouts maps entity classes to the keepalive timeouts,
+	// in the <warning>[:<critical>] form, applied to their entities.
+	KeepalivedClassTimeouts map[string]string
+
+	// EventTTLNamespaces maps namespaces to the age, as a duration, after
+	// which their events are pruned.
+	EventTTLNamespaces map[string]string
+
 	// Labels are key-value pairs that users can provide to backend entities
 	Labels map[string]string
 
@@ -120,12 +258,27 @@ type Config struct {
 	// DevMode starts up a single-node embedded etcd server when enabled.
 	DevMode bool
 
+	// DevSeed is the path to a file, or a directory of files, containing
+	// resources to load into the store at startup. Only used in dev mode.
+	DevSeed string
+
 	TLS *corev2.TLSOptions
 
 	LogLevel string
 
 	LicenseGetter licensing.Getter
 
+	// DisableAgentd prevents the agent listener from being started, so the
+	// backend does not accept agent connections.
+	DisableAgentd bool
+
+	// DisableAPId prevents the API, including GraphQL, from being served.
+	DisableAPId bool
+
+	// DisableDashboard clears the deprecated dashboard configuration instead
+	// of validating it.
+	DisableDashboard bool
+
 	DisablePlatformMetrics         bool
 	PlatformMetricsLoggingInterval time.Duration
 	PlatformMetricsLogFile         string
@@ -135,5 +288,17 @@ type Config struct {
 	EventLogFile             string
 	EventLogParallelEncoders bool
 
+	// EventLogOverflow is the policy applied when the event log buffer is
+	// full, either discard, block or spill
+	EventLogOverflow string
+
+	// EventLogSpillFile is the path of the file the events are written to
+	// when the event log buffer is full and EventLogOverflow is spill
+	EventLogSpillFile string
+
+	// AuditLogFile is the path of the file the mutating API requests are
+	// recorded to
+	AuditLogFile string
+
 	Store StoreConfig
 }
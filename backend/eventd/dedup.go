@@ -0,0 +1,74 @@
+package eventd
+
+import (
+	"sync"
+	"time"
+)
+
+// dedupEntry is an idempotency key and the time it expires at.
+type dedupEntry struct {
+	key     string
+	expires time.Time
+}
+
+// dedupCache remembers the idempotency keys of the events processed recently,
+// so that the duplicates of these events can be dropped. Keys expire after
+// the same ttl, in the order they were added.
+type dedupCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	keys    map[string]time.Time
+	entries []dedupEntry
+	now     func() time.Time
+}
+
+func newDedupCache(ttl time.Duration) *dedupCache {
+	return &dedupCache{
+		ttl:  ttl,
+		keys: make(map[string]time.Time),
+		now:  time.Now,
+	}
+}
+
+// add remembers key, and returns false if it was already remembered, i.e.
+// the event is a duplicate. Keys are never remembered if the cache is nil.
+func (c *dedupCache) add(key string) bool {
+	if c == nil || key == "" {
+		return true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	c.expire(now)
+	if _, ok := c.keys[key]; ok {
+		return false
+	}
+	expires := now.Add(c.ttl)
+	c.keys[key] = expires
+	c.entries = append(c.entries, dedupEntry{key: key, expires: expires})
+	return true
+}
+
+// forget removes key, so that an event that could not be processed is not
+// dropped when it is delivered again.
+func (c *dedupCache) forget(key string) {
+	if c == nil || key == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.keys, key)
+}
+
+// expire removes the keys that expired at now.
+func (c *dedupCache) expire(now time.Time) {
+	i := 0
+	for ; i < len(c.entries) && !c.entries[i].expires.After(now); i++ {
+		entry := c.entries[i]
+		// the key may have been forgotten and added again since
+		if expires, ok := c.keys[entry.key]; ok && expires.Equal(entry.expires) {
+			delete(c.keys, entry.key)
+		}
+	}
+	c.entries = c.entries[i:]
+}
@@ -0,0 +1,46 @@
+package eventd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDedupCache(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	c := newDedupCache(time.Minute)
+	c.now = func() time.Time { return now }
+
+	if !c.add("default/foo/check-cpu/1") {
+		t.Fatal("the first result was dropped")
+	}
+	if c.add("default/foo/check-cpu/1") {
+		t.Fatal("the duplicate result was not dropped")
+	}
+	if !c.add("default/foo/check-cpu/2") {
+		t.Fatal("the next result was dropped")
+	}
+
+	// Results that could not be processed can be delivered again
+	c.forget("default/foo/check-cpu/2")
+	if !c.add("default/foo/check-cpu/2") {
+		t.Fatal("the forgotten result was dropped")
+	}
+
+	// Keys are remembered until they expire
+	now = now.Add(time.Minute)
+	if !c.add("default/foo/check-cpu/1") {
+		t.Fatal("the expired result was dropped")
+	}
+	if got, want := len(c.keys), 1; got != want {
+		t.Errorf("%d keys remembered, want %d", got, want)
+	}
+
+	// Events without a key and nil caches are never dropped
+	if !c.add("") || !c.add("") {
+		t.Error("the events without a key were dropped")
+	}
+	var nilCache *dedupCache
+	if !nilCache.add("default/foo/check-cpu/1") || !nilCache.add("default/foo/check-cpu/1") {
+		t.Error("the nil cache dropped events")
+	}
+}
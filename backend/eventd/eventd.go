@@ -12,6 +12,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"golang.org/x/time/rate"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	corev3 "github.com/sensu/sensu-go/api/core/v3"
@@ -24,6 +25,7 @@ import (
 	storev2 "github.com/sensu/sensu-go/backend/store/v2"
 	metricspkg "github.com/sensu/sensu-go/metrics"
 	utillogging "github.com/sensu/sensu-go/util/logging"
+	"github.com/sensu/sensu-go/util/metricthresholds"
 )
 
 const (
@@ -34,6 +36,22 @@ const (
 	// EventsProcessedCounterVec is the name of the prometheus counter vec used to count events processed.
 	EventsProcessedCounterVec = "sensu_go_events_processed"
 
+	// EventsRejectedCounterVec is the name of the prometheus counter vec used
+	// to count the events rejected before being processed.
+	EventsRejectedCounterVec = "sensu_go_events_rejected"
+
+	// EventsRejectedReasonLabelName is the name of the label which describes
+	// why an event was rejected.
+	EventsRejectedReasonLabelName = "reason"
+
+	// EventsRejectedReasonTooLarge is the value to use for the reason label if
+	// the event exceeds the maximum event size.
+	EventsRejectedReasonTooLarge = "too_large"
+
+	// EventsRejectedReasonDuplicate is the value to use for the reason label
+	// if the event carries a check result that was already processed.
+	EventsRejectedReasonDuplicate = "duplicate"
+
 	// EventMetricPointsProcessedCounter is the name of the prometheus counter used to count metric points
 	// processed by eventd.
 	EventMetricPointsProcessedCounter = "sensu_go_event_metric_points_processed"
@@ -116,6 +134,20 @@ var (
 		[]string{EventsProcessedLabelName, EventsProcessedTypeLabelName},
 	)
 
+	// EventsRejected counts the number of sensu go events rejected before
+	// being processed.
+	EventsRejected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: EventsRejectedCounterVec,
+			Help: "The total number of events rejected before being processed",
+		},
+		[]string{EventsRejectedReasonLabelName},
+	)
+
+	// ErrEventTooLarge is returned for the events whose serialized size
+	// exceeds the maximum event size.
+	ErrEventTooLarge = errors.New("event exceeds the maximum event size")
+
 	MetricPointsProcessed = prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Name: EventMetricPointsProcessedCounter,
@@ -219,6 +251,11 @@ type Eventd struct {
 	logBufferSize       int
 	logBufferWait       time.Duration
 	logParallelEncoders bool
+	logOverflow         string
+	logSpillPath        string
+	pruner              *pruner
+	maxEventSize        int
+	dedup               *dedupCache
 }
 
 // DEPRECATED: use cache.Cache instead
@@ -244,6 +281,41 @@ type Config struct {
 	LogBufferSize       int
 	LogBufferWait       time.Duration
 	LogParallelEncoders bool
+
+	// LogOverflow is the policy applied when the event log buffer is full,
+	// one of OverflowDiscard (default), OverflowBlock or OverflowSpill.
+	LogOverflow string
+
+	// LogSpillPath is the path of the file the events are written to when
+	// LogOverflow is OverflowSpill and the event log buffer is full.
+	LogSpillPath string
+
+	// MaxEventSize is the maximum serialized size, in bytes, of the events.
+	// Larger events are rejected instead of being stored and published. Events
+	// are not limited if 0.
+	MaxEventSize int
+
+	// DedupTTL is the duration during which the idempotency keys of the
+	// events processed from agents are remembered, the events with the same
+	// key being dropped as duplicates. Events are not deduplicated if 0.
+	DedupTTL time.Duration
+
+	// EventTTL is the duration after which events that were not updated are
+	// pruned. Events are never pruned if 0.
+	EventTTL time.Duration
+
+	// NamespaceEventTTLs overrides EventTTL in the given namespaces.
+	NamespaceEventTTLs map[string]time.Duration
+
+	// PruneInterval is the interval between two sweeps of expired events.
+	PruneInterval time.Duration
+
+	// PruneRate is the maximum number of events deleted per second.
+	PruneRate rate.Limit
+
+	// PruneKeepFailing prevents the events of failing checks from being
+	// pruned.
+	PruneKeepFailing bool
 }
 
 // New creates a new Eventd.
@@ -260,6 +332,15 @@ func New(ctx context.Context, c Config, opts ...Option) (*Eventd, error) {
 		logger.Warn("StoreTimeout not configured")
 		c.StoreTimeout = defaultStoreTimeout
 	}
+	if err := ValidateOverflow(c.LogOverflow); err != nil {
+		return nil, err
+	}
+	if c.PruneInterval == 0 {
+		c.PruneInterval = DefaultPruneInterval
+	}
+	if c.PruneRate == 0 {
+		c.PruneRate = DefaultPruneRate
+	}
 
 	e := &Eventd{
 		store:               c.Store,
@@ -278,7 +359,24 @@ func New(ctx context.Context, c Config, opts ...Option) (*Eventd, error) {
 		logBufferSize:       c.LogBufferSize,
 		logBufferWait:       c.LogBufferWait,
 		logParallelEncoders: c.LogParallelEncoders,
+		logOverflow:         c.LogOverflow,
+		logSpillPath:        c.LogSpillPath,
 		Logger:              NoopLogger{},
+		maxEventSize:        c.MaxEventSize,
+		pruner: &pruner{
+			store:         c.EventStore,
+			client:        c.Client,
+			ttl:           c.EventTTL,
+			namespaceTTLs: c.NamespaceEventTTLs,
+			interval:      c.PruneInterval,
+			limiter:       rate.NewLimiter(c.PruneRate, 1),
+			keepFailing:   c.PruneKeepFailing,
+			now:           time.Now,
+		},
+	}
+
+	if c.DedupTTL > 0 {
+		e.dedup = newDedupCache(c.DedupTTL)
 	}
 
 	e.ctx, e.cancel = context.WithCancel(ctx)
@@ -299,6 +397,8 @@ func New(ctx context.Context, c Config, opts ...Option) (*Eventd, error) {
 	EventsProcessed.WithLabelValues(EventsProcessedLabelSuccess, EventsProcessedTypeLabelMetrics)
 	EventsProcessed.WithLabelValues(EventsProcessedLabelError, EventsProcessedTypeLabelUnknown)
 	EventsProcessed.WithLabelValues(EventsProcessedLabelError, EventsProcessedTypeLabelCheck)
+	EventsRejected.WithLabelValues(EventsRejectedReasonTooLarge)
+	EventsRejected.WithLabelValues(EventsRejectedReasonDuplicate)
 
 	eventHandlerDuration.WithLabelValues(metricspkg.StatusLabelSuccess, metricspkg.EventTypeLabelCheck)
 	eventHandlerDuration.WithLabelValues(metricspkg.StatusLabelSuccess, metricspkg.EventTypeLabelMetrics)
@@ -319,6 +419,7 @@ func New(ctx context.Context, c Config, opts ...Option) (*Eventd, error) {
 	busPublishDuration.WithLabelValues(metricspkg.StatusLabelError, metricspkg.EventTypeLabelMetrics)
 
 	_ = prometheus.Register(EventsProcessed)
+	_ = prometheus.Register(EventsRejected)
 	_ = prometheus.Register(MetricPointsProcessed)
 	_ = prometheus.Register(eventHandlerDuration)
 	_ = prometheus.Register(eventHandlersBusy)
@@ -328,6 +429,7 @@ func New(ctx context.Context, c Config, opts ...Option) (*Eventd, error) {
 	_ = prometheus.Register(livenessFactoryDuration)
 	_ = prometheus.Register(switchesAliveDuration)
 	_ = prometheus.Register(switchesBuryDuration)
+	_ = prometheus.Register(eventsPruned)
 
 	return e, nil
 }
@@ -337,6 +439,17 @@ func (e *Eventd) Receiver() chan<- interface{} {
 	return e.eventChan
 }
 
+// QueueLen returns the number of events waiting to be processed.
+func (e *Eventd) QueueLen() int {
+	return len(e.eventChan)
+}
+
+// QueueCap returns the maximum number of events that can wait to be
+// processed.
+func (e *Eventd) QueueCap() int {
+	return cap(e.eventChan)
+}
+
 // Start eventd.
 func (e *Eventd) Start() error {
 	e.wg.Add(e.workerCount)
@@ -352,10 +465,17 @@ func (e *Eventd) Start() error {
 
 	e.startHandlers()
 
+	if e.pruner.enabled() {
+		go e.pruner.run(e.ctx)
+	}
+
 	return nil
 }
 
 func withEventFields(e interface{}, logger *logrus.Entry) *logrus.Entry {
+	if agentEvent, ok := e.(messaging.AgentEvent); ok {
+		e = agentEvent.Event
+	}
 	event, _ := e.(*corev2.Event)
 	if event != nil {
 		fields := utillogging.EventFields(event, false)
@@ -514,12 +634,26 @@ func (e *Eventd) handleMessage(msg interface{}) (fEvent *corev2.Event, fErr erro
 			WithLabelValues(status, eventType).
 			Observe(float64(duration) / float64(time.Millisecond))
 	}()
+	// Only the events received from agents are deduplicated
+	agentEvent, fromAgent := msg.(messaging.AgentEvent)
+	if fromAgent {
+		msg = agentEvent.Event
+	}
 	event, ok := msg.(*corev2.Event)
 	if !ok {
 		EventsProcessed.WithLabelValues(EventsProcessedLabelError, EventsProcessedTypeLabelUnknown).Inc()
 		return event, fmt.Errorf("received non-Event on event channel: %v", msg)
 	}
 
+	// Reject the events too large to be stored and published before doing any
+	// work with them. The error is logged by the caller.
+	if e.maxEventSize > 0 {
+		if size := event.Size(); size > e.maxEventSize {
+			EventsRejected.WithLabelValues(EventsRejectedReasonTooLarge).Inc()
+			return event, fmt.Errorf("%w: %d bytes, maximum is %d", ErrEventTooLarge, size, e.maxEventSize)
+		}
+	}
+
 	fields := utillogging.EventFields(event, false)
 	logger.WithFields(fields).Info("eventd received event")
 
@@ -550,6 +684,31 @@ func (e *Eventd) handleMessage(msg interface{}) (fEvent *corev2.Event, fErr erro
 		return event, err
 	}
 
+	// Drop the check results of agents that were already processed, such as
+	// those replayed after a reconnection. The key is forgotten if the event
+	// cannot be processed, so that it can be delivered again.
+	var key string
+	if fromAgent {
+		key = event.IdempotencyKey()
+	}
+	if !e.dedup.add(key) {
+		EventsRejected.WithLabelValues(EventsRejectedReasonDuplicate).Inc()
+		logger.WithFields(fields).Debug("dropping duplicate event")
+		return event, nil
+	}
+	defer func() {
+		if fErr != nil {
+			e.dedup.forget(key)
+		}
+	}()
+
+	// Evaluate the output metric thresholds of the check, for the events
+	// that were not evaluated by an agent, such as the events of older agents
+	// or of the events API
+	if event.Check.Status == 0 && event.HasMetrics() && len(event.Check.OutputMetricThresholds) > 0 {
+		event.Check.Status = metricthresholds.Evaluate(event)
+	}
+
 	// Add any silenced subscriptions to the event
 	silenced.GetSilenced(ctx, event, e.silencedCache)
 	if len(event.Check.Silenced) > 0 {
@@ -853,6 +1012,8 @@ func (e Eventd) startFileLogger() Logger {
 		BufferWait:           e.logBufferWait,
 		Bus:                  e.bus,
 		ParallelJSONEncoding: e.logParallelEncoders,
+		Overflow:             e.logOverflow,
+		SpillPath:            e.logSpillPath,
 	}
 	if err := log.Start(); err != nil {
 		logger.WithError(err).Warning("event log file could not be configured. event logs will not be recorded.")
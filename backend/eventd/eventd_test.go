@@ -559,8 +559,68 @@ func TestEventd_handleMessage(t *testing.T) {
 		cacheFunc      cacheFunc
 		eventStoreFunc eventStoreFunc
 		storeFunc      storeFunc
+		maxEventSize   int
+		processed      []string
+		fromAgent      bool
 		wantErr        bool
 	}{
+		{
+			name: "events exceeding the maximum event size are rejected",
+			event: corev2.Event{
+				Entity: corev2.FixtureEntity("foo"),
+				Metrics: &corev2.Metrics{
+					Points: []*corev2.MetricPoint{{Name: "disk_used", Value: 95}},
+				},
+			},
+			maxEventSize: 10,
+			wantErr:      true,
+		},
+		{
+			name: "check results of agents that were already processed are dropped",
+			event: corev2.Event{
+				Check: func() *corev2.Check {
+					check := corev2.FixtureCheck("check-cpu")
+					check.Issued = 1600000000
+					check.Executed = 1600000001
+					return check
+				}(),
+				Entity: corev2.FixtureEntity("foo"),
+			},
+			processed: []string{"default/foo/check-cpu/1600000000/1600000001"},
+			fromAgent: true,
+		},
+		{
+			name: "check results submitted through the API are not deduplicated",
+			event: corev2.Event{
+				Check: func() *corev2.Check {
+					check := corev2.FixtureCheck("check-cpu")
+					check.Issued = 1600000000
+					check.Executed = 1600000001
+					return check
+				}(),
+				Entity: corev2.FixtureEntity("foo"),
+			},
+			processed: []string{"default/foo/check-cpu/1600000000/1600000001"},
+			busFunc: func(bus *mockbus.MockBus) {
+				bus.On("Publish", messaging.TopicEvent, mock.Anything).Once().Return(nil)
+			},
+			cacheFunc: func(c *mockcache.MockCache) {
+				c.On("Get", "default").Once().Return([]cache.Value{})
+			},
+			eventStoreFunc: func(store *mockstore.MockStore) {
+				store.On("UpdateEvent", mock.AnythingOfType("*v2.Event")).Once().Return(
+					corev2.FixtureEvent("foo", "check-cpu"), nilEvent, nil,
+				)
+			},
+			storeFunc: func(store *storetest.Store) {
+				store.On("Get", mock.Anything).Once().Return(
+					newEntityConfig(), nil,
+				)
+				store.On("Get", mock.Anything).Once().Return(
+					newEntityState(), nil,
+				)
+			},
+		},
 		{
 			name: "metrics events are published without being stored",
 			event: corev2.Event{
@@ -610,6 +670,47 @@ func TestEventd_handleMessage(t *testing.T) {
 				)
 			},
 		},
+		{
+			name: "output metric thresholds are evaluated",
+			event: corev2.Event{
+				Check: func() *corev2.Check {
+					check := corev2.FixtureCheck("check-disk")
+					check.OutputMetricThresholds = []*corev2.MetricThreshold{
+						{Name: "disk_used", Thresholds: []*corev2.MetricThresholdRule{{Max: "90", Status: 2}}},
+					}
+					return check
+				}(),
+				Entity: corev2.FixtureEntity("foo"),
+				Metrics: &corev2.Metrics{
+					Points: []*corev2.MetricPoint{{Name: "disk_used", Value: 95}},
+				},
+			},
+			busFunc: func(bus *mockbus.MockBus) {
+				bus.On("Publish", messaging.TopicEvent, mock.Anything).Once().Return(nil)
+			},
+			cacheFunc: func(c *mockcache.MockCache) {
+				c.On("Get", "default").Once().Return([]cache.Value{})
+			},
+			eventStoreFunc: func(store *mockstore.MockStore) {
+				store.On("UpdateEvent", mock.AnythingOfType("*v2.Event")).
+					Run(func(args mock.Arguments) {
+						event := args[0].(*corev2.Event)
+						if event.Check.Status != 2 {
+							t.Fatalf("expected a critical status, got %d", event.Check.Status)
+						}
+					}).Return(
+					corev2.FixtureEvent("foo", "check-disk"), nilEvent, nil,
+				)
+			},
+			storeFunc: func(store *storetest.Store) {
+				store.On("Get", mock.Anything).Once().Return(
+					newEntityConfig(), nil,
+				)
+				store.On("Get", mock.Anything).Once().Return(
+					newEntityState(), nil,
+				)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -640,10 +741,20 @@ func TestEventd_handleMessage(t *testing.T) {
 				wg:              &sync.WaitGroup{},
 				Logger:          NoopLogger{},
 				silencedCache:   cache,
+				maxEventSize:    tt.maxEventSize,
+				dedup:           newDedupCache(time.Minute),
+			}
+			for _, key := range tt.processed {
+				e.dedup.add(key)
+			}
+			var msg interface{} = &tt.event
+			if tt.fromAgent {
+				msg = messaging.AgentEvent{Event: &tt.event}
 			}
-			if _, err := e.handleMessage(&tt.event); (err != nil) != tt.wantErr {
+			if _, err := e.handleMessage(msg); (err != nil) != tt.wantErr {
 				t.Errorf("Eventd.handleMessage() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			eventStore.AssertExpectations(t)
 		})
 	}
 }
@@ -15,6 +15,32 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// The policies applied by the event logger when its buffer is still full
+// after the buffer wait time.
+const (
+	// OverflowDiscard drops the oldest buffered event to make room.
+	OverflowDiscard = "discard"
+
+	// OverflowBlock waits for the writer to consume a buffered event, which
+	// applies back-pressure to eventd.
+	OverflowBlock = "block"
+
+	// OverflowSpill writes the event to a secondary file, until the writer
+	// catches up and the buffer has room again.
+	OverflowSpill = "spill"
+)
+
+// ValidateOverflow returns an error if policy is not a valid event log
+// overflow policy. An empty policy is valid and defaults to OverflowDiscard.
+func ValidateOverflow(policy string) error {
+	switch policy {
+	case "", OverflowDiscard, OverflowBlock, OverflowSpill:
+		return nil
+	}
+	return fmt.Errorf("invalid event log overflow policy %q, must be one of %s, %s or %s",
+		policy, OverflowDiscard, OverflowBlock, OverflowSpill)
+}
+
 // FileLogger is a rotatable logger.
 type FileLogger struct {
 	Path                 string
@@ -22,9 +48,29 @@ type FileLogger struct {
 	BufferWait           time.Duration
 	Bus                  messaging.MessageBus
 	ParallelJSONEncoding bool
-	notify               chan interface{}
-	rawLogger            *rawLogger
-	subscription         messaging.Subscription
+
+	// Overflow is the policy applied when the buffer is full, OverflowDiscard
+	// if empty.
+	Overflow string
+
+	// SpillPath is the path of the file events are written to when the buffer
+	// is full and Overflow is OverflowSpill. Defaults to Path with a .spill
+	// extension.
+	SpillPath string
+
+	notify            chan interface{}
+	spillNotify       rotateSubscriber
+	rawLogger         *rawLogger
+	subscription      messaging.Subscription
+	spillSubscription *messaging.Subscription
+}
+
+// rotateSubscriber forwards the signals it receives to a RotateWriter.
+type rotateSubscriber chan interface{}
+
+// Receiver implements messaging.Subscriber
+func (r rotateSubscriber) Receiver() chan<- interface{} {
+	return r
 }
 
 // Start replaces the core event logger with the enteprise one, which logs
@@ -32,12 +78,37 @@ type FileLogger struct {
 func (f *FileLogger) Start() error {
 	f.notify = make(chan interface{}, 1)
 
+	if err := ValidateOverflow(f.Overflow); err != nil {
+		return fmt.Errorf("could not start event logging: %v", err)
+	}
+
 	rawLogger, err := newRawLogger(f.Path, f.BufferSize, f.BufferWait, f.notify)
 	if err != nil {
 		return fmt.Errorf("could not start event logging: %v", err)
 	}
+	rawLogger.overflow = f.Overflow
 	f.rawLogger = rawLogger
 
+	if f.Overflow == OverflowSpill {
+		if f.SpillPath == "" {
+			f.SpillPath = f.Path + ".spill"
+		}
+		f.spillNotify = make(rotateSubscriber, 1)
+		spill, err := logging.NewRotateWriter(f.SpillPath, f.spillNotify)
+		if err != nil {
+			return fmt.Errorf("could not open the event log spill file: %v", err)
+		}
+		rawLogger.spill = spill
+		rawLogger.spillPath = f.SpillPath
+
+		consumerName := fmt.Sprintf("filelogger://%s", f.SpillPath)
+		subscription, err := f.Bus.Subscribe(messaging.SignalTopic(syscall.SIGHUP), consumerName, f.spillNotify)
+		if err != nil {
+			return fmt.Errorf("failed to subscribe event log spill file to SIGHUP: %v", err)
+		}
+		f.spillSubscription = &subscription
+	}
+
 	consumerName := fmt.Sprintf("filelogger://%s", f.Path)
 	subscription, err := f.Bus.Subscribe(messaging.SignalTopic(syscall.SIGHUP), consumerName, f)
 	if err != nil {
@@ -78,6 +149,9 @@ func (f *FileLogger) Receiver() chan<- interface{} {
 
 func (f *FileLogger) Stop() {
 	_ = f.subscription.Cancel()
+	if f.spillSubscription != nil {
+		_ = f.spillSubscription.Cancel()
+	}
 	f.rawLogger.Stop()
 }
 
@@ -100,6 +174,14 @@ type rawLogger struct {
 	wait         time.Duration
 	metrics      *metrics
 	done         chan interface{}
+
+	// overflow is the policy applied when the buffer is full
+	overflow string
+
+	// spill receives the events that do not fit in the buffer when overflow
+	// is OverflowSpill
+	spill     LogWriter
+	spillPath string
 }
 
 // newRawLogger initializes the raw event logger
@@ -136,48 +218,99 @@ func (l *rawLogger) Stop() {
 }
 
 // ringBuffer forwards events from the input channel to the output buffered
-// channel and eliminates the oldest events when full
+// channel. When full, it applies the overflow policy, which by default
+// eliminates the oldest events
 func (l *rawLogger) ringBuffer() {
-	var eventsDropped int
+	var eventsOverflowed int
 	mu := sync.Mutex{}
 
-	// Keep an eye on the eventsDropper counter and log an error when events are
-	// dropped
+	// Keep an eye on the eventsOverflowed counter and log when the overflow
+	// policy is applied
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
 	defer close(l.encoderInput)
+	if l.spill != nil {
+		defer func() {
+			if err := l.spill.Close(); err != nil {
+				logger.WithError(err).Error("could not close the event log spill file")
+			}
+		}()
+	}
 	go func() {
 		for range ticker.C {
 			mu.Lock()
-			if eventsDropped > 0 {
-				logger.Errorf("the event buffer is full, %d event(s) lost", eventsDropped)
-				eventsDropped = 0
+			if eventsOverflowed > 0 {
+				l.logOverflow(eventsOverflowed)
+				eventsOverflowed = 0
 			}
 			mu.Unlock()
 		}
 	}()
 
+	// countOverflow increments the eventsOverflowed counter
+	countOverflow := func() {
+		mu.Lock()
+		eventsOverflowed++
+		mu.Unlock()
+	}
+
 	for v := range l.input {
 		select {
 		case l.encoderInput <- v:
 		case <-time.After(l.wait):
-			dropped := false
-			select {
-			case <-l.encoderInput:
-				dropped = true
-			case l.encoderInput <- v:
-			}
-			if dropped {
-				// Increment the eventsDropped counter
-				mu.Lock()
-				eventsDropped++
-				mu.Unlock()
+			switch l.overflow {
+			case OverflowBlock:
+				countOverflow()
 				l.encoderInput <- v
+			case OverflowSpill:
+				select {
+				case l.encoderInput <- v:
+				default:
+					if err := l.spillEvent(v); err != nil {
+						logger.WithError(err).Warning("could not spill event")
+						continue
+					}
+					countOverflow()
+				}
+			default:
+				dropped := false
+				select {
+				case <-l.encoderInput:
+					dropped = true
+				case l.encoderInput <- v:
+				}
+				if dropped {
+					countOverflow()
+					l.encoderInput <- v
+				}
 			}
 		}
 	}
 }
 
+// logOverflow reports the number of events the overflow policy was applied
+// to since the last report
+func (l *rawLogger) logOverflow(count int) {
+	switch l.overflow {
+	case OverflowBlock:
+		logger.Warningf("the event buffer is full, %d event(s) waited for the writer", count)
+	case OverflowSpill:
+		logger.Warningf("the event buffer is full, %d event(s) spilled to %s", count, l.spillPath)
+	default:
+		logger.Errorf("the event buffer is full, %d event(s) lost", count)
+	}
+}
+
+// spillEvent encodes v and writes it to the spill writer
+func (l *rawLogger) spillEvent(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = l.spill.Write(append(b, '\n'))
+	return err
+}
+
 func (l *rawLogger) encoder() {
 	defer close(l.output)
 
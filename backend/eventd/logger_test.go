@@ -4,6 +4,7 @@
 package eventd
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -311,6 +312,118 @@ func TestRawLogger_ringBuffer(t *testing.T) {
 	}
 }
 
+type bufferWriter struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (w *bufferWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(b)
+}
+
+func (w *bufferWriter) Close() error {
+	return nil
+}
+
+func (w *bufferWriter) Sync() error {
+	return nil
+}
+
+func (w *bufferWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func TestRawLogger_ringBufferOverflow(t *testing.T) {
+	tests := []struct {
+		name      string
+		overflow  string
+		want      []interface{}
+		wantSpill string
+	}{
+		{
+			name:     "block waits for the buffer to have room",
+			overflow: OverflowBlock,
+			want:     []interface{}{0, 1, 2, 3, 4},
+		},
+		{
+			name:      "spill writes the overflow to the spill writer",
+			overflow:  OverflowSpill,
+			want:      []interface{}{0, 1, 2, 3},
+			wantSpill: "4\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nullLogger, hook := test.NewNullLogger()
+			logger = nullLogger.WithField("test", tt.name)
+
+			spill := &bufferWriter{}
+			l := &rawLogger{
+				input:        make(chan interface{}),
+				encoderInput: make(chan interface{}, 4),
+				writer:       &nilWriter{},
+				wait:         10 * time.Millisecond,
+				metrics:      newMetrics(),
+				done:         make(chan interface{}),
+				overflow:     tt.overflow,
+				spill:        spill,
+				spillPath:    "event.log.spill",
+			}
+			go l.ringBuffer()
+
+			// Send messages over input channel, without consuming the buffer
+			sent := make(chan struct{})
+			go func() {
+				for i := 0; i < 5; i++ {
+					l.input <- i
+				}
+				close(sent)
+			}()
+
+			// Wait for any event to be logged
+			time.Sleep(time.Second * 2)
+
+			// Consume the buffer, which unblocks the last message if needed
+			var results []interface{}
+			for len(results) < len(tt.want) {
+				select {
+				case result := <-l.encoderInput:
+					results = append(results, result)
+				case <-time.After(5 * time.Second):
+					t.Fatalf("timed out, got %#v", results)
+				}
+			}
+			<-sent
+			l.Stop()
+
+			if !reflect.DeepEqual(results, tt.want) {
+				t.Errorf("rawLogger.ringBuffer() = %#v, want %#v", results, tt.want)
+			}
+			if got := spill.String(); got != tt.wantSpill {
+				t.Errorf("rawLogger.ringBuffer() spilled %q, want %q", got, tt.wantSpill)
+			}
+			if len(hook.AllEntries()) == 0 {
+				t.Error("rawLogger.ringBuffer() expected a log entry, got 0")
+			}
+		})
+	}
+}
+
+func TestValidateOverflow(t *testing.T) {
+	for _, policy := range []string{"", OverflowDiscard, OverflowBlock, OverflowSpill} {
+		if err := ValidateOverflow(policy); err != nil {
+			t.Errorf("ValidateOverflow(%q) = %v, want nil", policy, err)
+		}
+	}
+	if err := ValidateOverflow("retry"); err == nil {
+		t.Error("ValidateOverflow(\"retry\") = nil, want an error")
+	}
+}
+
 func TestRawLogger_encoder(t *testing.T) {
 	type Tmp struct {
 		Id int `json:"id"`
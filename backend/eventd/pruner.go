@@ -0,0 +1,212 @@
+package eventd
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+	"golang.org/x/time/rate"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+const (
+	// EventsPrunedGauge is the name of the prometheus gauge used to track the
+	// number of events pruned by the last sweep.
+	EventsPrunedGauge = "sensu_go_eventd_events_pruned"
+
+	// DefaultPruneInterval is the interval between two sweeps of expired
+	// events used if the backend did not configure one.
+	DefaultPruneInterval = time.Minute
+
+	// DefaultPruneRate is the maximum number of events deleted per second
+	// used if the backend did not configure one.
+	DefaultPruneRate = 100
+
+	// pruneBatchSize is the number of events read from the store at once.
+	pruneBatchSize = 100
+)
+
+// pruneElectionPrefix is the etcd prefix of the election of the backend that
+// prunes the events.
+var pruneElectionPrefix = path.Join(store.Root, "eventd", "pruner")
+
+var eventsPruned = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: EventsPrunedGauge,
+		Help: "The number of events pruned by the last sweep of expired events",
+	},
+)
+
+// ParseNamespaceTTLs parses event TTLs keyed by namespace, e.g. dev=24h. A
+// TTL of 0 disables pruning in the namespace.
+func ParseNamespaceTTLs(values map[string]string) (map[string]time.Duration, error) {
+	ttls := make(map[string]time.Duration, len(values))
+	for namespace, value := range values {
+		if namespace == "" {
+			return nil, fmt.Errorf("event ttl %q: missing namespace", value)
+		}
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl < 0 {
+			return nil, fmt.Errorf("event ttl for namespace %q: invalid duration %q", namespace, value)
+		}
+		ttls[namespace] = ttl
+	}
+	return ttls, nil
+}
+
+// pruner deletes the events that were not updated for longer than their TTL.
+// When it has an etcd client, only the backend elected among the pruners of
+// the cluster sweeps the events.
+type pruner struct {
+	store         store.EventStore
+	client        *clientv3.Client
+	ttl           time.Duration
+	namespaceTTLs map[string]time.Duration
+	interval      time.Duration
+	limiter       *rate.Limiter
+	keepFailing   bool
+	now           func() time.Time
+}
+
+// enabled returns whether events expire in any namespace.
+func (p *pruner) enabled() bool {
+	if p == nil {
+		return false
+	}
+	if p.ttl > 0 {
+		return true
+	}
+	for _, ttl := range p.namespaceTTLs {
+		if ttl > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// run sweeps the expired events at every interval until ctx is canceled,
+// while the pruner is the elected leader.
+func (p *pruner) run(ctx context.Context) {
+	if p.client == nil {
+		p.sweepEvery(ctx, nil)
+		return
+	}
+	for ctx.Err() == nil {
+		if err := p.lead(ctx); err != nil && ctx.Err() == nil {
+			logger.WithError(err).Error("error electing the event pruner")
+			select {
+			case <-ctx.Done():
+			case <-time.After(p.interval):
+			}
+		}
+	}
+}
+
+// lead campaigns to be the leader of the pruners, then sweeps the expired
+// events until the leadership is lost or ctx is canceled.
+func (p *pruner) lead(ctx context.Context) error {
+	session, err := concurrency.NewSession(p.client, concurrency.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+	election := concurrency.NewElection(session, pruneElectionPrefix)
+	if err := election.Campaign(ctx, ""); err != nil {
+		return err
+	}
+	logger.Info("elected to prune the expired events")
+	p.sweepEvery(ctx, session.Done())
+	return nil
+}
+
+// sweepEvery sweeps the expired events at every interval until ctx is
+// canceled or done is closed.
+func (p *pruner) sweepEvery(ctx context.Context, done <-chan struct{}) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+			cancel()
+		}
+	}()
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		pruned, err := p.sweep(ctx)
+		eventsPruned.Set(float64(pruned))
+		if err != nil && ctx.Err() == nil {
+			logger.WithError(err).Error("error pruning expired events")
+			continue
+		}
+		if pruned > 0 {
+			logger.WithField("events", pruned).Info("pruned expired events")
+		}
+	}
+}
+
+// sweep deletes the expired events of all namespaces, and returns how many
+// were deleted.
+func (p *pruner) sweep(ctx context.Context) (int, error) {
+	// List the events of all namespaces
+	listCtx := store.NamespaceContext(ctx, corev2.NamespaceTypeAll)
+	pred := &store.SelectionPredicate{Limit: pruneBatchSize}
+	pruned := 0
+	for {
+		events, err := p.store.GetEvents(listCtx, pred)
+		if err != nil {
+			return pruned, err
+		}
+		for _, event := range events {
+			if !p.expired(event) {
+				continue
+			}
+			if err := p.limiter.Wait(ctx); err != nil {
+				return pruned, err
+			}
+			deleteCtx := store.NamespaceContext(ctx, event.Entity.Namespace)
+			if err := p.store.DeleteEventByEntityCheck(deleteCtx, event.Entity.Name, event.Check.Name); err != nil {
+				return pruned, err
+			}
+			pruned++
+		}
+		if pred.Continue == "" {
+			return pruned, nil
+		}
+	}
+}
+
+// expired returns whether the event was not updated for longer than the TTL
+// of its namespace. Keepalive events never expire, keepalived is in charge of
+// them and would keep failing the keepalives of pruned events.
+func (p *pruner) expired(event *corev2.Event) bool {
+	if !event.HasCheck() || event.Entity == nil {
+		return false
+	}
+	if event.Check.Name == corev2.KeepaliveCheckName {
+		return false
+	}
+	ttl, ok := p.namespaceTTLs[event.Entity.Namespace]
+	if !ok {
+		ttl = p.ttl
+	}
+	if ttl <= 0 {
+		return false
+	}
+	if p.keepFailing && event.Check.Status != 0 {
+		return false
+	}
+	return p.now().Sub(time.Unix(event.Timestamp, 0)) > ttl
+}
@@ -0,0 +1,133 @@
+package eventd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/testing/mockstore"
+)
+
+func fixturePrunedEvent(namespace, entity, check string, status uint32, age time.Duration, now time.Time) *corev2.Event {
+	event := corev2.FixtureEvent(entity, check)
+	event.Namespace = namespace
+	event.Entity.Namespace = namespace
+	event.Check.Namespace = namespace
+	event.Check.Status = status
+	event.Timestamp = now.Add(-age).Unix()
+	return event
+}
+
+func TestParseNamespaceTTLs(t *testing.T) {
+	ttls, err := ParseNamespaceTTLs(map[string]string{"dev": "24h", "prod": "0"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"dev": 24 * time.Hour, "prod": 0}, ttls)
+
+	_, err = ParseNamespaceTTLs(map[string]string{"dev": "tomorrow"})
+	assert.Error(t, err)
+
+	_, err = ParseNamespaceTTLs(map[string]string{"dev": "-1h"})
+	assert.Error(t, err)
+
+	_, err = ParseNamespaceTTLs(map[string]string{"": "1h"})
+	assert.Error(t, err)
+}
+
+func TestPrunerExpired(t *testing.T) {
+	now := time.Now()
+	p := &pruner{
+		ttl:           time.Hour,
+		namespaceTTLs: map[string]time.Duration{"dev": 10 * time.Minute, "prod": 0},
+		keepFailing:   true,
+		now:           func() time.Time { return now },
+	}
+
+	tests := []struct {
+		name  string
+		event *corev2.Event
+		want  bool
+	}{
+		{
+			name:  "recent event",
+			event: fixturePrunedEvent("default", "entity", "check", 0, time.Minute, now),
+			want:  false,
+		},
+		{
+			name:  "expired event",
+			event: fixturePrunedEvent("default", "entity", "check", 0, 2*time.Hour, now),
+			want:  true,
+		},
+		{
+			name:  "namespace ttl",
+			event: fixturePrunedEvent("dev", "entity", "check", 0, 20*time.Minute, now),
+			want:  true,
+		},
+		{
+			name:  "pruning disabled in namespace",
+			event: fixturePrunedEvent("prod", "entity", "check", 0, 48*time.Hour, now),
+			want:  false,
+		},
+		{
+			name:  "failing check",
+			event: fixturePrunedEvent("default", "entity", "check", 2, 2*time.Hour, now),
+			want:  false,
+		},
+		{
+			name:  "keepalive",
+			event: fixturePrunedEvent("default", "entity", corev2.KeepaliveCheckName, 0, 2*time.Hour, now),
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, p.expired(tt.event))
+		})
+	}
+}
+
+func TestPrunerSweep(t *testing.T) {
+	now := time.Now()
+	s := &mockstore.MockStore{}
+	s.On("GetEvents", mock.Anything, mock.Anything).Return([]*corev2.Event{
+		fixturePrunedEvent("default", "entity1", "check", 0, 2*time.Hour, now),
+		fixturePrunedEvent("default", "entity2", "check", 0, time.Minute, now),
+	}, nil).Run(func(args mock.Arguments) {
+		args[1].(*store.SelectionPredicate).Continue = "next"
+	}).Once()
+	s.On("GetEvents", mock.Anything, mock.Anything).Return([]*corev2.Event{
+		fixturePrunedEvent("dev", "entity3", "check", 0, 2*time.Hour, now),
+	}, nil).Run(func(args mock.Arguments) {
+		args[1].(*store.SelectionPredicate).Continue = ""
+	}).Once()
+	s.On("DeleteEventByEntityCheck", mock.Anything, "entity1", "check").Return(nil).Once()
+	s.On("DeleteEventByEntityCheck", mock.MatchedBy(func(ctx context.Context) bool {
+		return corev2.ContextNamespace(ctx) == "dev"
+	}), "entity3", "check").Return(nil).Once()
+
+	p := &pruner{
+		store:   s,
+		ttl:     time.Hour,
+		limiter: rate.NewLimiter(rate.Inf, 1),
+		now:     func() time.Time { return now },
+	}
+	pruned, err := p.sweep(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, pruned)
+	s.AssertExpectations(t)
+}
+
+func TestPrunerEnabled(t *testing.T) {
+	var p *pruner
+	assert.False(t, p.enabled())
+	assert.False(t, (&pruner{}).enabled())
+	assert.False(t, (&pruner{namespaceTTLs: map[string]time.Duration{"dev": 0}}).enabled())
+	assert.True(t, (&pruner{namespaceTTLs: map[string]time.Duration{"dev": time.Hour}}).enabled())
+	assert.True(t, (&pruner{ttl: time.Hour}).enabled())
+}
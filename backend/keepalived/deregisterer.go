@@ -14,6 +14,16 @@ import (
 	"github.com/sensu/sensu-go/types"
 )
 
+const (
+	// DeregistrationReasonAnnotation is the annotation of deregistration events
+	// that explains why the entity was deregistered.
+	DeregistrationReasonAnnotation = "deregistration.reason"
+
+	// DeregistrationReasonKeepalive is the reason of the entities deregistered
+	// after their keepalive timed out.
+	DeregistrationReasonKeepalive = "keepalive timeout"
+)
+
 // A Deregisterer provides a mechanism for deregistering entities and
 // notifying the rest of the backend when a deregistration occurs.
 type Deregisterer interface {
@@ -30,6 +40,14 @@ type Deregistration struct {
 	MessageBus    messaging.MessageBus
 	SilencedCache cache.Cache
 	StoreTimeout  time.Duration
+
+	// DefaultHandler is the deregistration handler of the entities that do not
+	// specify one.
+	DefaultHandler string
+
+	// Reason is added to the deregistration event, as the
+	// DeregistrationReasonAnnotation annotation.
+	Reason string
 }
 
 // Deregister an entity and all of its associated events.
@@ -69,13 +87,17 @@ func (d *Deregistration) Deregister(entity *types.Entity) error {
 		}
 	}
 
-	if entity.Deregistration.Handler != "" {
+	handler := entity.Deregistration.Handler
+	if handler == "" {
+		handler = d.DefaultHandler
+	}
+	if handler != "" {
 		deregistrationCheck := &types.Check{
 			ObjectMeta:    corev2.NewObjectMeta("deregistration", entity.Namespace),
 			Interval:      1,
 			Subscriptions: []string{},
 			Command:       "",
-			Handlers:      []string{entity.Deregistration.Handler},
+			Handlers:      []string{handler},
 			Status:        1,
 		}
 
@@ -84,11 +106,25 @@ func (d *Deregistration) Deregister(entity *types.Entity) error {
 			return err
 		}
 
+		// Carry the labels and annotations of the entity, so that handlers
+		// can be routed with them
+		meta := corev2.NewObjectMeta("", entity.Namespace)
+		for k, v := range entity.Labels {
+			meta.Labels[k] = v
+		}
+		for k, v := range entity.Annotations {
+			meta.Annotations[k] = v
+		}
+		if d.Reason != "" {
+			meta.Annotations[DeregistrationReasonAnnotation] = d.Reason
+		}
+
 		deregistrationEvent := &types.Event{
-			Entity:    entity,
-			Check:     deregistrationCheck,
-			ID:        id[:],
-			Timestamp: time.Now().Unix(),
+			ObjectMeta: meta,
+			Entity:     entity,
+			Check:      deregistrationCheck,
+			ID:         id[:],
+			Timestamp:  time.Now().Unix(),
 		}
 
 		// Add any silenced subscriptions to the event
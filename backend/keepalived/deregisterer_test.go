@@ -90,3 +90,44 @@ func TestDeregistrationHandler(t *testing.T) {
 
 	assert.NoError(adapter.Deregister(entity))
 }
+
+func TestDeregistrationEventMetadata(t *testing.T) {
+	assert := assert.New(t)
+
+	mockCache := &mockcache.MockCache{}
+	mockStore := &mockstore.MockStore{}
+	mockBus := &mockbus.MockBus{}
+
+	adapter := &Deregistration{
+		EventStore:     mockStore,
+		EntityStore:    mockStore,
+		MessageBus:     mockBus,
+		SilencedCache:  mockCache,
+		DefaultHandler: "default-deregistration",
+		Reason:         DeregistrationReasonKeepalive,
+	}
+
+	entity := types.FixtureEntity("entity")
+	entity.Deregister = true
+	entity.Labels = map[string]string{"team": "ops"}
+	entity.Annotations = map[string]string{"runbook": "https://example.com"}
+
+	mockCache.On("Get", "default").Once().Return([]cache.Value{})
+	mockStore.On("GetEventsByEntity", mock.Anything, entity.Name, &store.SelectionPredicate{}).Return([]*types.Event{}, nil)
+	mockStore.On("DeleteEntity", mock.Anything, entity).Return(nil)
+
+	var published *types.Event
+	mockBus.On("Publish", messaging.TopicEvent, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		published = args[1].(*types.Event)
+	})
+
+	assert.NoError(adapter.Deregister(entity))
+	if !assert.NotNil(published) {
+		return
+	}
+	assert.Equal([]string{"default-deregistration"}, published.Check.Handlers)
+	assert.Equal("ops", published.Labels["team"])
+	assert.Equal("https://example.com", published.Annotations["runbook"])
+	assert.Equal(DeregistrationReasonKeepalive, published.Annotations[DeregistrationReasonAnnotation])
+	assert.NotContains(entity.Annotations, DeregistrationReasonAnnotation)
+}
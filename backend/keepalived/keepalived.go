@@ -95,6 +95,9 @@ type Keepalived struct {
 	cancel                context.CancelFunc
 	storeTimeout          time.Duration
 	silencedCache         cache.Cache
+	classTimeouts         map[string]Timeouts
+	startupGracePeriod    time.Duration
+	startedAt             time.Time
 }
 
 // Option is a functional option.
@@ -113,6 +116,15 @@ type Config struct {
 	BufferSize            int
 	WorkerCount           int
 	StoreTimeout          time.Duration
+
+	// ClassTimeouts overrides the keepalive timeouts sent by the entities of
+	// the given classes.
+	ClassTimeouts map[string]Timeouts
+
+	// StartupGracePeriod is the period following the start of keepalived
+	// during which keepalive failures are not emitted, giving the agents time
+	// to reconnect after a backend restart.
+	StartupGracePeriod time.Duration
 }
 
 // New creates a new Keepalived.
@@ -155,6 +167,8 @@ func New(c Config, opts ...Option) (*Keepalived, error) {
 		cancel:                cancel,
 		storeTimeout:          c.StoreTimeout,
 		silencedCache:         silencedCache,
+		classTimeouts:         c.ClassTimeouts,
+		startupGracePeriod:    c.StartupGracePeriod,
 	}
 	for _, o := range opts {
 		if err := o(k); err != nil {
@@ -169,9 +183,21 @@ func (k *Keepalived) Receiver() chan<- interface{} {
 	return k.keepaliveChan
 }
 
+// QueueLen returns the number of keepalives waiting to be processed.
+func (k *Keepalived) QueueLen() int {
+	return len(k.keepaliveChan)
+}
+
+// QueueCap returns the maximum number of keepalives that can wait to be
+// processed.
+func (k *Keepalived) QueueCap() int {
+	return cap(k.keepaliveChan)
+}
+
 // Start starts the daemon, returning an error if preconditions for startup
 // fail.
 func (k *Keepalived) Start() error {
+	k.startedAt = time.Now()
 	sub, err := k.bus.Subscribe(messaging.TopicKeepalive, "keepalived", k)
 	if err != nil {
 		return err
@@ -337,6 +363,8 @@ func (k *Keepalived) processKeepalives(ctx context.Context) {
 				}
 			}
 
+			k.applyClassTimeouts(event)
+
 			// Retrieve the keepalive timeout or use a default value in case an older
 			// agent version was used, since entity.KeepaliveTimeout no longer exist
 			ttl := int64(corev2.DefaultKeepaliveTimeout)
@@ -570,6 +598,13 @@ func (k *Keepalived) dead(key string, prev liveness.State, leader bool) bool {
 		return false
 	}
 
+	// The switch is notified again once its TTL expires, by which time the
+	// grace period may be over
+	if remaining := k.startupGracePeriod - time.Since(k.startedAt); remaining > 0 {
+		lager.WithField("grace_period_remaining", remaining.String()).Info("keepalive timed out during the startup grace period, not emitting a failure")
+		return false
+	}
+
 	lager.Warn("keepalive timed out")
 
 	// Now verify if we encountered an error while parsing the key
@@ -617,13 +652,22 @@ func (k *Keepalived) dead(key string, prev liveness.State, leader bool) bool {
 
 	if entityConfig.Deregister {
 		deregisterer := &Deregistration{
-			EntityStore:   k.store,
-			EventStore:    k.eventStore,
-			MessageBus:    k.bus,
-			SilencedCache: k.silencedCache,
-			StoreTimeout:  k.storeTimeout,
+			EntityStore:    k.store,
+			EventStore:     k.eventStore,
+			MessageBus:     k.bus,
+			SilencedCache:  k.silencedCache,
+			StoreTimeout:   k.storeTimeout,
+			DefaultHandler: k.deregistrationHandler,
+			Reason:         DeregistrationReasonKeepalive,
+		}
+		// The entity config holds the last known labels and annotations of
+		// the entity
+		entity := currentEvent.Entity
+		if entityConfig.Metadata != nil {
+			entity.Labels = entityConfig.Metadata.Labels
+			entity.Annotations = entityConfig.Metadata.Annotations
 		}
-		if err := deregisterer.Deregister(currentEvent.Entity); err != nil {
+		if err := deregisterer.Deregister(entity); err != nil {
 			lager.WithError(err).Error("error deregistering entity")
 		}
 		lager.Debug("deregistering entity")
@@ -719,6 +763,12 @@ func (k *Keepalived) handleUpdate(e *corev2.Event) error {
 		return err
 	}
 
+	if lister, ok := k.store.(store.StaleEntityLister); ok {
+		if err := lister.UpdateEntityLastSeen(ctx, entity.Name, entity.LastSeen); err != nil {
+			logger.WithError(err).Error("error updating entity last seen time in store")
+		}
+	}
+
 	event := createKeepaliveEvent(e)
 	event.Check.Status = 0
 	event.Check.Output = fmt.Sprintf("Keepalive last sent from %s at %s", entity.Name, time.Unix(entity.LastSeen, 0).String())
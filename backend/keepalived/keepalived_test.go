@@ -222,6 +222,7 @@ func TestEventProcessing(t *testing.T) {
 	}), mock.Anything).Return(nil)
 
 	test.Store.On("DeleteFailingKeepalive", mock.Anything, event.Entity).Return(nil)
+	test.Store.On("UpdateEntityLastSeen", mock.Anything, "entity", event.Timestamp).Return(nil)
 
 	test.Keepalived.keepaliveChan <- event
 	assert.NoError(t, test.Keepalived.Stop())
@@ -498,3 +499,50 @@ func TestDeadCallbackNoEvent(t *testing.T) {
 		t.Fatalf("got bury: %v, want bury: %v", got, want)
 	}
 }
+
+func TestDeadCallbackStartupGracePeriod(t *testing.T) {
+	messageBus, err := messaging.NewWizardBus(messaging.WizardBusConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := messageBus.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	store := &storetest.Store{}
+	client := mockclientv3.MockClientV3{}
+	getResp := &clientv3.GetResponse{}
+	client.On("Get", mock.Anything, "/sensu.io/silenced/", mock.Anything).
+		Return(getResp, nil)
+
+	keepalived, err := New(Config{
+		Client:             client,
+		StoreV2:            store,
+		Bus:                messageBus,
+		LivenessFactory:    fakeFactory,
+		WorkerCount:        1,
+		BufferSize:         1,
+		StoreTimeout:       time.Minute,
+		StartupGracePeriod: time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keepalived.startedAt = time.Now()
+
+	// The failure is not emitted, and the switch is not buried, during the
+	// grace period
+	if got, want := keepalived.dead("default/entity1", liveness.Alive, true), false; got != want {
+		t.Fatalf("got bury: %v, want bury: %v", got, want)
+	}
+	store.AssertNotCalled(t, "Get", mock.Anything)
+
+	// The keepalive is processed once the grace period is over
+	keepalived.startedAt = time.Now().Add(-2 * time.Hour)
+	store.On("Get", mock.MatchedBy(func(req storv2.ResourceRequest) bool {
+		return req.StoreName == new(corev3.EntityConfig).StoreName()
+	})).Return((storv2.Wrapper)(nil), &stor.ErrNotFound{Key: "foo"})
+	if got, want := keepalived.dead("default/entity1", liveness.Alive, true), true; got != want {
+		t.Fatalf("got bury: %v, want bury: %v", got, want)
+	}
+}
@@ -0,0 +1,63 @@
+package keepalived
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sensu/sensu-go/agent"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// Timeouts holds the keepalive thresholds, in seconds, applied to the entities
+// of a given class. A keepalive warning is emitted once Warning seconds
+// elapsed without a keepalive, and a critical one after Critical seconds.
+type Timeouts struct {
+	Warning  uint32
+	Critical uint32
+}
+
+// ParseClassTimeouts parses keepalive timeouts keyed by entity class. Values
+// take the form <warning>[:<critical>], in seconds, e.g. proxy=300:600.
+func ParseClassTimeouts(values map[string]string) (map[string]Timeouts, error) {
+	timeouts := make(map[string]Timeouts, len(values))
+	for class, value := range values {
+		if class == "" {
+			return nil, fmt.Errorf("keepalive timeouts %q: missing entity class", value)
+		}
+		parts := strings.SplitN(value, ":", 2)
+		warning, err := strconv.ParseUint(parts[0], 10, 32)
+		if err != nil || warning == 0 {
+			return nil, fmt.Errorf("keepalive timeouts for entity class %q: invalid warning timeout %q", class, parts[0])
+		}
+		t := Timeouts{Warning: uint32(warning)}
+		if len(parts) == 2 {
+			critical, err := strconv.ParseUint(parts[1], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("keepalive timeouts for entity class %q: invalid critical timeout %q", class, parts[1])
+			}
+			if critical != 0 && critical <= warning {
+				return nil, fmt.Errorf("keepalive timeouts for entity class %q: critical timeout must be greater than the warning timeout", class)
+			}
+			t.Critical = uint32(critical)
+		}
+		timeouts[class] = t
+	}
+	return timeouts, nil
+}
+
+// applyClassTimeouts overrides the keepalive thresholds sent with the event
+// with the ones configured for the class of its entity, if any.
+func (k *Keepalived) applyClassTimeouts(event *corev2.Event) {
+	t, ok := k.classTimeouts[event.Entity.EntityClass]
+	if !ok {
+		return
+	}
+	if event.Check == nil {
+		event.Check = &corev2.Check{
+			Interval: agent.DefaultKeepaliveInterval,
+		}
+	}
+	event.Check.Timeout = t.Warning
+	event.Check.Ttl = int64(t.Critical)
+}
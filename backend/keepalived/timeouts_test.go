@@ -0,0 +1,83 @@
+package keepalived
+
+import (
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClassTimeouts(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]string
+		want    map[string]Timeouts
+		wantErr bool
+	}{
+		{
+			name:   "warning only",
+			values: map[string]string{"proxy": "300"},
+			want:   map[string]Timeouts{"proxy": {Warning: 300}},
+		},
+		{
+			name:   "warning and critical",
+			values: map[string]string{"proxy": "300:600", "agent": "120:180"},
+			want: map[string]Timeouts{
+				"proxy": {Warning: 300, Critical: 600},
+				"agent": {Warning: 120, Critical: 180},
+			},
+		},
+		{
+			name:    "invalid warning",
+			values:  map[string]string{"proxy": "soon"},
+			wantErr: true,
+		},
+		{
+			name:    "zero warning",
+			values:  map[string]string{"proxy": "0"},
+			wantErr: true,
+		},
+		{
+			name:    "critical lower than warning",
+			values:  map[string]string{"proxy": "300:200"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseClassTimeouts(tt.values)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApplyClassTimeouts(t *testing.T) {
+	k := &Keepalived{
+		classTimeouts: map[string]Timeouts{
+			corev2.EntityProxyClass: {Warning: 300, Critical: 600},
+		},
+	}
+
+	event := corev2.FixtureEvent("entity1", "keepalive")
+	event.Check.Timeout = 120
+	k.applyClassTimeouts(event)
+	assert.Equal(t, uint32(120), event.Check.Timeout)
+	assert.Equal(t, int64(0), event.Check.Ttl)
+
+	event.Entity.EntityClass = corev2.EntityProxyClass
+	k.applyClassTimeouts(event)
+	assert.Equal(t, uint32(300), event.Check.Timeout)
+	assert.Equal(t, int64(600), event.Check.Ttl)
+
+	event.Check = nil
+	k.applyClassTimeouts(event)
+	require.NotNil(t, event.Check)
+	assert.Equal(t, uint32(20), event.Check.Interval)
+	assert.Equal(t, uint32(300), event.Check.Timeout)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/prometheus/client_golang/prometheus"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/daemon"
 )
 
@@ -50,6 +51,13 @@ var (
 	)
 )
 
+// AgentEvent is an event received from an agent, published by agentd on
+// TopicEventRaw so that it can be told apart from the events submitted through
+// the API or generated by the backend.
+type AgentEvent struct {
+	*corev2.Event
+}
+
 // A Subscriber receives messages via a channel.
 type Subscriber interface {
 
@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// QueueDepthGaugeName is the name of the gauge of the number of messages
+	// buffered by the queues.
+	QueueDepthGaugeName = "sensu_go_queue_depth"
+
+	// QueueCapacityGaugeName is the name of the gauge of the capacity of the
+	// queues.
+	QueueCapacityGaugeName = "sensu_go_queue_capacity"
+
+	// QueueLabelName is the name of the label for the queue name.
+	QueueLabelName = "queue"
+)
+
+var (
+	queueDepthDesc = prometheus.NewDesc(
+		QueueDepthGaugeName,
+		"the number of messages buffered by the queue",
+		[]string{QueueLabelName}, nil,
+	)
+	queueCapacityDesc = prometheus.NewDesc(
+		QueueCapacityGaugeName,
+		"the maximum number of messages the queue can buffer",
+		[]string{QueueLabelName}, nil,
+	)
+)
+
+// Queue is a buffer of messages waiting to be processed by a daemon.
+type Queue interface {
+	// QueueLen returns the number of buffered messages.
+	QueueLen() int
+
+	// QueueCap returns the maximum number of messages that can be buffered.
+	QueueCap() int
+}
+
+// QueueDepth is the number of messages buffered by a queue, relative to its
+// capacity.
+type QueueDepth struct {
+	Name     string `json:"name"`
+	Depth    int    `json:"depth"`
+	Capacity int    `json:"capacity"`
+
+	// Usage is the ratio of the depth to the capacity, between 0 and 1.
+	Usage float64 `json:"usage"`
+}
+
+// QueueDepthResponse is the response of the QueueMonitor HTTP handler.
+type QueueDepthResponse struct {
+	Queues []QueueDepth `json:"queues"`
+}
+
+// QueueMonitor reports the depth of named queues, as JSON when served over
+// HTTP, and as the sensu_go_queue_depth and sensu_go_queue_capacity gauges
+// when registered as a prometheus collector.
+type QueueMonitor struct {
+	mu     sync.Mutex
+	queues map[string]Queue
+}
+
+// NewQueueMonitor returns a QueueMonitor without any queue.
+func NewQueueMonitor() *QueueMonitor {
+	return &QueueMonitor{queues: make(map[string]Queue)}
+}
+
+// Set monitors the queue q under name, replacing any queue previously set
+// under that name.
+func (m *QueueMonitor) Set(name string, q Queue) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queues[name] = q
+}
+
+// Depths returns the depth of the monitored queues, sorted by name.
+func (m *QueueMonitor) Depths() []QueueDepth {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	depths := make([]QueueDepth, 0, len(m.queues))
+	for name, q := range m.queues {
+		depth := QueueDepth{
+			Name:     name,
+			Depth:    q.QueueLen(),
+			Capacity: q.QueueCap(),
+		}
+		if depth.Capacity > 0 {
+			depth.Usage = float64(depth.Depth) / float64(depth.Capacity)
+		}
+		depths = append(depths, depth)
+	}
+	sort.Slice(depths, func(i, j int) bool {
+		return depths[i].Name < depths[j].Name
+	})
+	return depths
+}
+
+// ServeHTTP serves the depth of the monitored queues as JSON.
+func (m *QueueMonitor) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(QueueDepthResponse{Queues: m.Depths()})
+}
+
+// Describe implements prometheus.Collector
+func (m *QueueMonitor) Describe(ch chan<- *prometheus.Desc) {
+	ch <- queueDepthDesc
+	ch <- queueCapacityDesc
+}
+
+// Collect implements prometheus.Collector
+func (m *QueueMonitor) Collect(ch chan<- prometheus.Metric) {
+	for _, depth := range m.Depths() {
+		ch <- prometheus.MustNewConstMetric(queueDepthDesc, prometheus.GaugeValue, float64(depth.Depth), depth.Name)
+		ch <- prometheus.MustNewConstMetric(queueCapacityDesc, prometheus.GaugeValue, float64(depth.Capacity), depth.Name)
+	}
+}
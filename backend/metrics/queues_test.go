@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testQueue chan interface{}
+
+func (q testQueue) QueueLen() int {
+	return len(q)
+}
+
+func (q testQueue) QueueCap() int {
+	return cap(q)
+}
+
+func TestQueueMonitor(t *testing.T) {
+	eventd := make(testQueue, 4)
+	eventd <- 1
+	pipelined := make(testQueue, 10)
+	for i := 0; i < 9; i++ {
+		pipelined <- i
+	}
+
+	monitor := NewQueueMonitor()
+	monitor.Set("pipelined", pipelined)
+	monitor.Set("eventd", eventd)
+	monitor.Set("keepalived", make(testQueue))
+
+	want := []QueueDepth{
+		{Name: "eventd", Depth: 1, Capacity: 4, Usage: 0.25},
+		{Name: "keepalived", Depth: 0, Capacity: 0, Usage: 0},
+		{Name: "pipelined", Depth: 9, Capacity: 10, Usage: 0.9},
+	}
+	if got := monitor.Depths(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Depths() = %#v, want %#v", got, want)
+	}
+
+	w := httptest.NewRecorder()
+	monitor.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/pipeline", nil))
+	var response QueueDepthResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(response.Queues, want) {
+		t.Errorf("ServeHTTP() = %#v, want %#v", response.Queues, want)
+	}
+
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(monitor); err != nil {
+		t.Fatal(err)
+	}
+	if got := testutil.CollectAndCount(monitor); got != 6 {
+		t.Errorf("Collect() returned %d metrics, want 6", got)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/store"
 	metricspkg "github.com/sensu/sensu-go/metrics"
+	utilstrings "github.com/sensu/sensu-go/util/strings"
 	"github.com/sirupsen/logrus"
 )
 
@@ -21,6 +22,10 @@ const (
 	HandlerRequests      = "sensu_go_handler_requests"
 	HandlerRequestsTotal = "sensu_go_handler_requests_total"
 
+	// MetricsFastPathSkips is the name of the prometheus counter used to track
+	// the handler sets skipped by the metrics fast path.
+	MetricsFastPathSkips = "sensu_go_pipeline_metrics_fast_path_skips"
+
 	// PipelineDuration is the name of the prometheus summary vec used to track
 	// average latencies of pipeline execution.
 	PipelineDuration = "sensu_go_pipeline_duration"
@@ -58,6 +63,13 @@ var (
 		[]string{"status", "type"},
 	)
 
+	metricsFastPathSkipsCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: MetricsFastPathSkips,
+			Help: "The number of handler sets skipped by the metrics fast path",
+		},
+	)
+
 	pipelineDuration = prometheus.NewSummaryVec(
 		prometheus.SummaryOpts{
 			Name:       PipelineDuration,
@@ -93,6 +105,9 @@ func init() {
 	if err := prometheus.Register(handlerRequestsCounter); err != nil {
 		panic(fmt.Errorf("error registering %s: %s", HandlerRequests, err))
 	}
+	if err := prometheus.Register(metricsFastPathSkipsCounter); err != nil {
+		panic(fmt.Errorf("error registering %s: %s", MetricsFastPathSkips, err))
+	}
 	if err := prometheus.Register(pipelineDuration); err != nil {
 		panic(fmt.Errorf("error registering %s: %s", PipelineDuration, err))
 	}
@@ -280,7 +295,16 @@ func (a *AdapterV1) generateLegacyPipeline(ctx context.Context, event *corev2.Ev
 		legacyHandlerNames = append(legacyHandlerNames, event.Metrics.Handlers...)
 	}
 
-	handlers, err := a.expandHandlers(ctx, legacyHandlerNames, 1)
+	handlers, err := a.getHandlers(ctx, legacyHandlerNames, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.HasCheck() && event.IsMetricsOnly() {
+		a.skipMetricsFastPathSets(ctx, handlers, event)
+	}
+
+	handlers, err = a.expandHandlerSets(ctx, handlers, 1)
 	if err != nil {
 		return nil, err
 	}
@@ -293,23 +317,94 @@ func (a *AdapterV1) generateLegacyPipeline(ctx context.Context, event *corev2.Ev
 		Workflows: []*corev2.PipelineWorkflow{},
 	}
 
-	// sort the keys of the handlers map to guarantee the ordering of the
-	// slice of handlers
+	// sort the keys of the handlers map by decreasing priority, then by name,
+	// to guarantee the ordering of the slice of handlers
 	handlerNames := make([]string, 0, len(handlers))
 	for handlerName := range handlers {
 		handlerNames = append(handlerNames, handlerName)
 	}
-	sort.Strings(handlerNames)
-
+	sort.Slice(handlerNames, func(i, j int) bool {
+		pi, pj := handlers[handlerNames[i]].Priority, handlers[handlerNames[j]].Priority
+		if pi != pj {
+			return pi > pj
+		}
+		return handlerNames[i] < handlerNames[j]
+	})
+
+	// the handlers with a lower priority than a short-circuit handler whose
+	// filters deny the event are skipped. The handlers limited with the
+	// handler concurrency annotation run asynchronously, so their priority
+	// only orders the start of their executions.
+	var shortCircuited *corev2.Handler
 	for _, handlerName := range handlerNames {
+		handler := handlers[handlerName]
+		if shortCircuited != nil && handler.Priority < shortCircuited.Priority {
+			logger.WithFields(logrus.Fields{
+				"namespace": corev2.ContextNamespace(ctx),
+				"handler":   shortCircuited.Name,
+			}).Debug("event denied by a short-circuit handler, skipping the handlers with a lower priority")
+			break
+		}
 		workflowName := fmt.Sprintf(LegacyPipelineWorkflowName, handlerName)
-		workflow := corev2.PipelineWorkflowFromHandler(ctx, workflowName, handlers[handlerName])
+		workflow := corev2.PipelineWorkflowFromHandler(ctx, workflowName, handler)
+
+		// the filters of the first short-circuit handler are evaluated here
+		// only, so its workflow is either skipped or run without them
+		if shortCircuit, _ := corev2.HandlerShortCircuit(handler.Annotations); shortCircuit && shortCircuited == nil {
+			filtered, err := a.processFilters(ctx, workflow.Filters, event)
+			if err != nil {
+				logger.WithFields(logrus.Fields{
+					"namespace": corev2.ContextNamespace(ctx),
+					"handler":   handler.Name,
+				}).WithError(err).Error("failed to filter the event of a short-circuit handler, skipping the handler")
+				continue
+			}
+			if filtered {
+				shortCircuited = handler
+				continue
+			}
+			workflow.Filters = nil
+		}
+
 		pipeline.Workflows = append(pipeline.Workflows, workflow)
 	}
 
 	return pipeline, nil
 }
 
+// skipMetricsFastPathSets removes from the handlers of a metrics-only event
+// the check handler sets that have the metrics fast path enabled and whose
+// filters, if any, let the event through, so that only the metric handlers
+// of the event are run. The handler sets whose filters fail are kept.
+func (a *AdapterV1) skipMetricsFastPathSets(ctx context.Context, handlers HandlerMap, event *corev2.Event) {
+	for handlerName, handler := range handlers {
+		if handler.Type != corev2.HandlerSetType || !handler.MetricsFastPath {
+			continue
+		}
+		if event.HasMetrics() && utilstrings.InArray(handlerName, event.Metrics.Handlers) {
+			continue
+		}
+
+		fields := logrus.Fields{
+			"namespace": corev2.ContextNamespace(ctx),
+			"handler":   handlerName,
+		}
+		workflow := corev2.PipelineWorkflowFromHandler(ctx, handler.Name, handler)
+		filtered, err := a.processFilters(ctx, workflow.Filters, event)
+		if err != nil {
+			logger.WithFields(fields).WithError(err).Error("failed to filter the event of a handler set, keeping its status handlers")
+			continue
+		}
+		if filtered {
+			continue
+		}
+
+		logger.WithFields(fields).Debug("metrics-only event, skipping the status handlers of the handler set")
+		metricsFastPathSkipsCounter.Inc()
+		delete(handlers, handlerName)
+	}
+}
+
 // expandHandlers turns a list of Sensu handler names into a list of
 // handlers, while expanding handler sets with support for some
 // nesting. Handlers are fetched from etcd.
@@ -318,7 +413,18 @@ func (a *AdapterV1) expandHandlers(ctx context.Context, handlers []string, level
 		return nil, errors.New("handler sets cannot be deeply nested")
 	}
 
-	expandedHandlers := HandlerMap{}
+	fetchedHandlers, err := a.getHandlers(ctx, handlers, level)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.expandHandlerSets(ctx, fetchedHandlers, level)
+}
+
+// getHandlers fetches the named handlers from etcd, without expanding the
+// handler sets. The handlers that do not exist are ignored.
+func (a *AdapterV1) getHandlers(ctx context.Context, handlers []string, level int) (HandlerMap, error) {
+	fetchedHandlers := HandlerMap{}
 
 	// Prepare log entry
 	namespace := corev2.ContextNamespace(ctx)
@@ -327,6 +433,10 @@ func (a *AdapterV1) expandHandlers(ctx context.Context, handlers []string, level
 	}
 
 	for _, handlerName := range handlers {
+		if _, ok := fetchedHandlers[handlerName]; ok {
+			continue
+		}
+
 		tctx, cancel := context.WithTimeout(ctx, a.StoreTimeout)
 		handler, err := a.Store.GetHandlerByName(tctx, handlerName)
 		cancel()
@@ -355,6 +465,27 @@ func (a *AdapterV1) expandHandlers(ctx context.Context, handlers []string, level
 			continue
 		}
 
+		fetchedHandlers[handlerName] = handler
+	}
+
+	return fetchedHandlers, nil
+}
+
+// expandHandlerSets replaces the handler sets of the fetched handlers with
+// the handlers they contain.
+func (a *AdapterV1) expandHandlerSets(ctx context.Context, handlers HandlerMap, level int) (HandlerMap, error) {
+	expandedHandlers := HandlerMap{}
+
+	// Prepare log entry
+	namespace := corev2.ContextNamespace(ctx)
+	fields := logrus.Fields{
+		"namespace": namespace,
+	}
+
+	for handlerName, handler := range handlers {
+		// Add handler name to log entry
+		fields["handler"] = handlerName
+
 		if handler.Type == "set" {
 			setHandlers, err := a.expandHandlers(ctx, handler.Handlers, level+1)
 			if err != nil {
@@ -842,6 +842,260 @@ func TestAdapterV1_generateLegacyPipeline(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "the metrics fast path skips the status handler sets of metrics-only events",
+			args: args{
+				ctx: context.Background(),
+				event: func() *corev2.Event {
+					event := corev2.FixtureEvent("entity1", "check1")
+					event.Check.Handlers = []string{"statusset"}
+					event.Metrics = &corev2.Metrics{
+						Handlers: []string{"metricshandler"},
+					}
+					return event
+				}(),
+			},
+			fields: fields{
+				Store: func() store.Store {
+					statusSet := corev2.FixtureSetHandler("statusset", "checkhandler")
+					statusSet.Type = corev2.HandlerSetType
+					statusSet.MetricsFastPath = true
+					metricsHandler := corev2.FixtureHandler("metricshandler")
+					stor := &mockstore.MockStore{}
+					// the handler set is only fetched once
+					stor.On("GetHandlerByName", mock.Anything, statusSet.GetName()).
+						Return(statusSet, nil).Once()
+					stor.On("GetHandlerByName", mock.Anything, metricsHandler.GetName()).
+						Return(metricsHandler, nil)
+					return stor
+				}(),
+			},
+			want: &corev2.Pipeline{
+				ObjectMeta: corev2.NewObjectMeta("legacy-pipeline", "default"),
+				Workflows: []*corev2.PipelineWorkflow{{
+					Name: "legacy-pipeline-workflow-metricshandler",
+					Handler: &corev2.ResourceReference{
+						APIVersion: "core/v2",
+						Type:       "Handler",
+						Name:       "metricshandler",
+					},
+				}},
+			},
+		},
+		{
+			name: "the metrics fast path does not apply to incidents",
+			args: args{
+				ctx: context.Background(),
+				event: func() *corev2.Event {
+					event := corev2.FixtureEvent("entity1", "check1")
+					event.Check.Status = 2
+					event.Check.Handlers = []string{"statusset"}
+					event.Metrics = &corev2.Metrics{}
+					return event
+				}(),
+			},
+			fields: fields{
+				Store: func() store.Store {
+					statusSet := corev2.FixtureSetHandler("statusset", "checkhandler")
+					statusSet.Type = corev2.HandlerSetType
+					statusSet.MetricsFastPath = true
+					checkHandler := corev2.FixtureHandler("checkhandler")
+					stor := &mockstore.MockStore{}
+					stor.On("GetHandlerByName", mock.Anything, statusSet.GetName()).
+						Return(statusSet, nil)
+					stor.On("GetHandlerByName", mock.Anything, checkHandler.GetName()).
+						Return(checkHandler, nil)
+					return stor
+				}(),
+			},
+			want: &corev2.Pipeline{
+				ObjectMeta: corev2.NewObjectMeta("legacy-pipeline", "default"),
+				Workflows: []*corev2.PipelineWorkflow{{
+					Name: "legacy-pipeline-workflow-checkhandler",
+					Handler: &corev2.ResourceReference{
+						APIVersion: "core/v2",
+						Type:       "Handler",
+						Name:       "checkhandler",
+					},
+				}},
+			},
+		},
+		{
+			name: "the handlers are ordered by decreasing priority, then by name",
+			args: args{
+				ctx: context.Background(),
+				event: func() *corev2.Event {
+					event := corev2.FixtureEvent("entity1", "check1")
+					event.Check.Handlers = []string{"enrichment", "notifications", "archive"}
+					return event
+				}(),
+			},
+			fields: fields{
+				Store: func() store.Store {
+					notifications := corev2.FixtureSetHandler("notifications", "slack", "pagerduty")
+					notifications.Type = corev2.HandlerSetType
+					slack := corev2.FixtureHandler("slack")
+					slack.Priority = 10
+					pagerduty := corev2.FixtureHandler("pagerduty")
+					pagerduty.Priority = 10
+					enrichment := corev2.FixtureHandler("enrichment")
+					archive := corev2.FixtureHandler("archive")
+					archive.Priority = -1
+					stor := &mockstore.MockStore{}
+					for _, handler := range []*corev2.Handler{notifications, slack, pagerduty, enrichment, archive} {
+						stor.On("GetHandlerByName", mock.Anything, handler.GetName()).
+							Return(handler, nil)
+					}
+					return stor
+				}(),
+			},
+			want: &corev2.Pipeline{
+				ObjectMeta: corev2.NewObjectMeta("legacy-pipeline", "default"),
+				Workflows: []*corev2.PipelineWorkflow{
+					{
+						Name: "legacy-pipeline-workflow-pagerduty",
+						Handler: &corev2.ResourceReference{
+							APIVersion: "core/v2",
+							Type:       "Handler",
+							Name:       "pagerduty",
+						},
+					},
+					{
+						Name: "legacy-pipeline-workflow-slack",
+						Handler: &corev2.ResourceReference{
+							APIVersion: "core/v2",
+							Type:       "Handler",
+							Name:       "slack",
+						},
+					},
+					{
+						Name: "legacy-pipeline-workflow-enrichment",
+						Handler: &corev2.ResourceReference{
+							APIVersion: "core/v2",
+							Type:       "Handler",
+							Name:       "enrichment",
+						},
+					},
+					{
+						Name: "legacy-pipeline-workflow-archive",
+						Handler: &corev2.ResourceReference{
+							APIVersion: "core/v2",
+							Type:       "Handler",
+							Name:       "archive",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "a short-circuit handler denying the event skips the handlers with a lower priority",
+			args: args{
+				ctx: context.Background(),
+				event: func() *corev2.Event {
+					event := corev2.FixtureEvent("entity1", "check1")
+					event.Check.Handlers = []string{"enrichment", "pagerduty", "slack"}
+					return event
+				}(),
+			},
+			fields: fields{
+				FilterAdapters: []FilterAdapter{&filter.IsIncidentAdapter{}},
+				Store:          shortCircuitStore(),
+			},
+			want: &corev2.Pipeline{
+				ObjectMeta: corev2.NewObjectMeta("legacy-pipeline", "default"),
+				Workflows: []*corev2.PipelineWorkflow{
+					{
+						Name: "legacy-pipeline-workflow-slack",
+						Handler: &corev2.ResourceReference{
+							APIVersion: "core/v2",
+							Type:       "Handler",
+							Name:       "slack",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "a short-circuit handler allowing the event runs the handlers with a lower priority",
+			args: args{
+				ctx: context.Background(),
+				event: func() *corev2.Event {
+					event := corev2.FixtureEvent("entity1", "check1")
+					event.Check.Status = 2
+					event.Check.Handlers = []string{"enrichment", "pagerduty", "slack"}
+					return event
+				}(),
+			},
+			fields: fields{
+				FilterAdapters: []FilterAdapter{&filter.IsIncidentAdapter{}},
+				Store:          shortCircuitStore(),
+			},
+			want: &corev2.Pipeline{
+				ObjectMeta: corev2.NewObjectMeta("legacy-pipeline", "default"),
+				Workflows: []*corev2.PipelineWorkflow{
+					{
+						Name: "legacy-pipeline-workflow-pagerduty",
+						Handler: &corev2.ResourceReference{
+							APIVersion: "core/v2",
+							Type:       "Handler",
+							Name:       "pagerduty",
+						},
+					},
+					{
+						Name: "legacy-pipeline-workflow-slack",
+						Handler: &corev2.ResourceReference{
+							APIVersion: "core/v2",
+							Type:       "Handler",
+							Name:       "slack",
+						},
+					},
+					{
+						Name: "legacy-pipeline-workflow-enrichment",
+						Handler: &corev2.ResourceReference{
+							APIVersion: "core/v2",
+							Type:       "Handler",
+							Name:       "enrichment",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "a short-circuit handler whose filters fail is skipped alone",
+			args: args{
+				ctx: context.Background(),
+				event: func() *corev2.Event {
+					event := corev2.FixtureEvent("entity1", "check1")
+					event.Check.Handlers = []string{"enrichment", "pagerduty", "slack"}
+					return event
+				}(),
+			},
+			fields: fields{
+				// no filter adapter can filter the event
+				Store: shortCircuitStore(),
+			},
+			want: &corev2.Pipeline{
+				ObjectMeta: corev2.NewObjectMeta("legacy-pipeline", "default"),
+				Workflows: []*corev2.PipelineWorkflow{
+					{
+						Name: "legacy-pipeline-workflow-slack",
+						Handler: &corev2.ResourceReference{
+							APIVersion: "core/v2",
+							Type:       "Handler",
+							Name:       "slack",
+						},
+					},
+					{
+						Name: "legacy-pipeline-workflow-enrichment",
+						Handler: &corev2.ResourceReference{
+							APIVersion: "core/v2",
+							Type:       "Handler",
+							Name:       "enrichment",
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -864,6 +1118,25 @@ func TestAdapterV1_generateLegacyPipeline(t *testing.T) {
 	}
 }
 
+// shortCircuitStore returns a store with a short-circuit pagerduty handler
+// denying the events that are not incidents, a slack handler of the same
+// priority and an enrichment handler of a lower priority.
+func shortCircuitStore() store.Store {
+	pagerduty := corev2.FixtureHandler("pagerduty")
+	pagerduty.Priority = 10
+	pagerduty.Filters = []string{"is_incident"}
+	pagerduty.Annotations = map[string]string{corev2.HandlerShortCircuitAnnotation: "true"}
+	slack := corev2.FixtureHandler("slack")
+	slack.Priority = 10
+	enrichment := corev2.FixtureHandler("enrichment")
+	stor := &mockstore.MockStore{}
+	for _, handler := range []*corev2.Handler{pagerduty, slack, enrichment} {
+		stor.On("GetHandlerByName", mock.Anything, handler.GetName()).
+			Return(handler, nil)
+	}
+	return stor
+}
+
 func TestAdapterV1_expandHandlers(t *testing.T) {
 	var (
 		nilHandler *corev2.Handler
@@ -79,3 +79,30 @@ func (a *AdapterV1) getFilterAdapterForResource(ctx context.Context, ref *corev2
 	}
 	return nil, fmt.Errorf("no filter adapters were found that can filter the resource: %s.%s = %s", ref.APIVersion, ref.Type, ref.Name)
 }
+
+// FilterTester is implemented by the filter adapters that can report the
+// errors encountered while evaluating a filter, besides the verdict.
+type FilterTester interface {
+	TestFilter(context.Context, *corev2.ResourceReference, *corev2.Event) (bool, []error, error)
+}
+
+// TestFilter evaluates the event against the core/v2 event filter named name,
+// with the filter adapter a pipeline would use, without handling the event. It
+// returns whether the event would be filtered, along with the errors that
+// occurred while evaluating the filter when the adapter is a FilterTester.
+func (a *AdapterV1) TestFilter(ctx context.Context, name string, event *corev2.Event) (bool, []error, error) {
+	ref := &corev2.ResourceReference{
+		APIVersion: "core/v2",
+		Type:       "EventFilter",
+		Name:       name,
+	}
+	filter, err := a.getFilterAdapterForResource(ctx, ref)
+	if err != nil {
+		return false, nil, err
+	}
+	if tester, ok := filter.(FilterTester); ok {
+		return tester.TestFilter(ctx, ref, event)
+	}
+	filtered, err := filter.Filter(ctx, ref, event)
+	return filtered, nil, err
+}
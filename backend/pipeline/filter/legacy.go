@@ -26,6 +26,7 @@ var (
 		"is_incident",
 		"has_metrics",
 		"not_silenced",
+		"not_flapping",
 	}
 
 	errCouldNotRetrieveFilter = errors.New("could not retrieve filter")
@@ -65,6 +66,17 @@ func (l *LegacyAdapter) CanFilter(ref *corev2.ResourceReference) bool {
 // Sensu pipeline. It returns whether or not the event was filtered and if any
 // error was encountered.
 func (l *LegacyAdapter) Filter(ctx context.Context, ref *corev2.ResourceReference, event *corev2.Event) (bool, error) {
+	filtered, _, err := l.filter(ctx, ref, event)
+	return filtered, err
+}
+
+// TestFilter filters a Sensu event like Filter does, but also returns the
+// errors encountered while evaluating the filter, which Filter only logs.
+func (l *LegacyAdapter) TestFilter(ctx context.Context, ref *corev2.ResourceReference, event *corev2.Event) (bool, []error, error) {
+	return l.filter(ctx, ref, event)
+}
+
+func (l *LegacyAdapter) filter(ctx context.Context, ref *corev2.ResourceReference, event *corev2.Event) (bool, []error, error) {
 	// Prepare log entry
 	fields := event.LogFields(false)
 	fields["pipeline"] = corev2.ContextPipeline(ctx)
@@ -78,11 +90,11 @@ func (l *LegacyAdapter) Filter(ctx context.Context, ref *corev2.ResourceReferenc
 	cancel()
 	if err != nil {
 		logger.WithFields(fields).WithError(err).Warning(errCouldNotRetrieveFilter.Error())
-		return false, err
+		return false, nil, err
 	}
 	if filter == nil {
 		logger.WithFields(fields).WithError(err).Warning(errCouldNotRetrieveFilter.Error())
-		return false, fmt.Errorf(errCouldNotRetrieveFilter.Error())
+		return false, nil, fmt.Errorf(errCouldNotRetrieveFilter.Error())
 	}
 
 	// Execute the filter, evaluating each of its
@@ -95,21 +107,29 @@ func (l *LegacyAdapter) Filter(ctx context.Context, ref *corev2.ResourceReferenc
 		logger.WithFields(fields).WithError(err).Error("failed to retrieve assets for filter")
 		if _, ok := err.(*store.ErrInternal); ok {
 			// Fatal error
-			return false, err
+			return false, nil, err
 		}
 	}
-	filtered := evaluateEventFilter(ctx, event, filter, assets)
+	filtered, evalErrs := evaluate(ctx, event, filter, assets)
 	if filtered {
 		logger.WithFields(fields).Debug("denying event with custom filter")
-		return true, nil
+		return true, evalErrs, nil
 	}
 
 	logger.WithFields(fields).Debug("allowing event")
-	return false, nil
+	return false, evalErrs, nil
 }
 
 // Returns true if the event should be filtered/denied.
 func evaluateEventFilter(ctx context.Context, event *corev2.Event, filter *corev2.EventFilter, assets asset.RuntimeAssetSet) bool {
+	filtered, _ := evaluate(ctx, event, filter, assets)
+	return filtered
+}
+
+// evaluate returns true if the event should be filtered/denied, along with the
+// errors that occurred while evaluating the filter. These errors are logged,
+// and don't prevent the evaluation of the rest of the filter.
+func evaluate(ctx context.Context, event *corev2.Event, filter *corev2.EventFilter, assets asset.RuntimeAssetSet) (bool, []error) {
 	// Redact the entity to avoid leaking sensitive information
 	event.Entity = event.Entity.GetRedactedEntity()
 
@@ -119,22 +139,24 @@ func evaluateEventFilter(ctx context.Context, event *corev2.Event, filter *corev
 	fields["pipeline"] = corev2.ContextPipeline(ctx)
 	fields["pipeline_workflow"] = corev2.ContextPipelineWorkflow(ctx)
 
+	var errs []error
+
 	if filter.When != nil {
 		inWindows, err := filter.When.InWindows(time.Now().UTC())
 		if err != nil {
 			logger.WithFields(fields).WithError(err).
 				Error("denying event - unable to determine if time is in specified window")
-			return false
+			return false, []error{err}
 		}
 
 		if filter.Action == corev2.EventFilterActionAllow && !inWindows {
 			logger.WithFields(fields).Debug("denying event outside of filtering window")
-			return true
+			return true, errs
 		}
 
 		if filter.Action == corev2.EventFilterActionDeny && !inWindows {
 			logger.WithFields(fields).Debug("allowing event outside of filtering window")
-			return false
+			return false, errs
 		}
 	}
 
@@ -170,39 +192,40 @@ func evaluateEventFilter(ctx context.Context, event *corev2.Event, filter *corev
 		match, err := env.Eval(ctx, expression)
 		if err != nil {
 			logger.WithFields(fields).WithError(err).Error("error evaluating javascript event filter")
+			errs = append(errs, fmt.Errorf("expression %q: %w", expression, err))
 			continue
 		}
 
 		// Allow - One of the expressions did not match, filter the event
 		if filter.Action == corev2.EventFilterActionAllow && !match {
 			logger.WithFields(fields).Debug("denying event that does not match filter")
-			return true
+			return true, errs
 		}
 
 		// Deny - One of the expressions did not match, do not filter the event
 		if filter.Action == corev2.EventFilterActionDeny && !match {
 			logger.WithFields(fields).Debug("allowing event that does not match filter")
-			return false
+			return false, errs
 		}
 	}
 
 	// Allow - All of the expressions matched, do not filter the event
 	if filter.Action == corev2.EventFilterActionAllow {
 		logger.WithFields(fields).Debug("allowing event that matches filter")
-		return false
+		return false, errs
 	}
 
 	// Deny - All of the expressions matched, filter the event
 	if filter.Action == corev2.EventFilterActionDeny {
 		logger.WithFields(fields).Debug("denying event that matches filter")
-		return true
+		return true, errs
 	}
 
 	// Something weird happened, let's not filter the event and log a warning message
 	logger.WithFields(fields).
 		Warn("not filtering event due to unhandled case")
 
-	return false
+	return false, errs
 }
 
 type FilterExecutionEnvironment struct {
@@ -2,6 +2,7 @@ package filter
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -384,6 +385,26 @@ func Test_evaluateEventFilter(t *testing.T) {
 	}
 }
 
+func Test_evaluateErrors(t *testing.T) {
+	filter := &corev2.EventFilter{
+		ObjectMeta: corev2.ObjectMeta{
+			Name: "invalid_expression",
+		},
+		Action:      corev2.EventFilterActionAllow,
+		Expressions: []string{"event.check.status ==", "event.check.status == 0"},
+	}
+	filtered, errs := evaluate(context.Background(), corev2.FixtureEvent("entity1", "check1"), filter, nil)
+	if filtered {
+		t.Error("expected the event to be allowed by the valid expression")
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 evaluation error, got %v", errs)
+	}
+	if !strings.Contains(errs[0].Error(), "event.check.status ==") {
+		t.Errorf("expected the error to mention the invalid expression, got %q", errs[0])
+	}
+}
+
 func TestJavascriptStoreAccess(t *testing.T) {
 	st := new(mockstore.MockStore)
 	pipelineRoleBinding := &corev2.RoleBinding{
@@ -523,3 +544,29 @@ func TestJavascriptStoreAccess(t *testing.T) {
 	st.AssertCalled(t, "GetEventByEntityCheck", mock.Anything, "batman", "robin")
 	st.AssertCalled(t, "GetEvents", mock.Anything, mock.Anything)
 }
+
+func Test_evaluatePreviousState(t *testing.T) {
+	filter := &corev2.EventFilter{
+		ObjectMeta: corev2.ObjectMeta{
+			Name: "state_change",
+		},
+		Action:      corev2.EventFilterActionAllow,
+		Expressions: []string{"event.check.previous && event.check.previous.status != event.check.status"},
+	}
+
+	// the first occurrence has no previous state
+	event := corev2.FixtureEvent("entity1", "check1")
+	if filtered, errs := evaluate(context.Background(), event, filter, nil); !filtered || len(errs) > 0 {
+		t.Errorf("expected the first occurrence to be filtered, got %v, %v", filtered, errs)
+	}
+
+	event.Check.Previous = &corev2.CheckHistory{Status: 2, Executed: event.Check.Executed - 60}
+	if filtered, errs := evaluate(context.Background(), event, filter, nil); filtered || len(errs) > 0 {
+		t.Errorf("expected the resolution to be allowed, got %v, %v", filtered, errs)
+	}
+
+	event.Check.Previous.Status = event.Check.Status
+	if filtered, errs := evaluate(context.Background(), event, filter, nil); !filtered || len(errs) > 0 {
+		t.Errorf("expected the repeated status to be filtered, got %v, %v", filtered, errs)
+	}
+}
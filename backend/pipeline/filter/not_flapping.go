@@ -0,0 +1,47 @@
+package filter
+
+import (
+	"context"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	utillogging "github.com/sensu/sensu-go/util/logging"
+)
+
+const (
+	// NotFlappingAdapter is the name of the filter adapter.
+	NotFlappingAdapterName = "NotFlappingAdapter"
+)
+
+// NotFlappingAdapter is a filter adapter which will filter events that are not
+// flapping.
+type NotFlappingAdapter struct{}
+
+// Name returns the name of the filter adapter.
+func (n *NotFlappingAdapter) Name() string {
+	return NotFlappingAdapterName
+}
+
+// CanFilter determines whether NotFlappingAdapter can filter the resource being
+// referenced.
+func (n *NotFlappingAdapter) CanFilter(ref *corev2.ResourceReference) bool {
+	if ref.APIVersion == "core/v2" && ref.Type == "EventFilter" && ref.Name == "not_flapping" {
+		return true
+	}
+	return false
+}
+
+// Filter will evaluate the event and determine whether or not to filter it.
+func (n *NotFlappingAdapter) Filter(ctx context.Context, ref *corev2.ResourceReference, event *corev2.Event) (bool, error) {
+	// Prepare log entry
+	fields := utillogging.EventFields(event, false)
+	fields["pipeline"] = corev2.ContextPipeline(ctx)
+	fields["pipeline_workflow"] = corev2.ContextPipelineWorkflow(ctx)
+
+	// Deny an event if its check is flapping
+	if event.IsFlapping() {
+		logger.WithFields(fields).Debug("denying event that is flapping")
+		return true, nil
+	}
+
+	return false, nil
+}
@@ -0,0 +1,124 @@
+package filter
+
+import (
+	"context"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+func TestNotFlappingAdapter_Name(t *testing.T) {
+	o := &NotFlappingAdapter{}
+	want := "NotFlappingAdapter"
+
+	if got := o.Name(); want != got {
+		t.Errorf("NotFlappingAdapter.Name() = %v, want %v", got, want)
+	}
+}
+
+func TestNotFlappingAdapter_CanFilter(t *testing.T) {
+	type args struct {
+		ref *corev2.ResourceReference
+	}
+	tests := []struct {
+		name string
+		i    *NotFlappingAdapter
+		args args
+		want bool
+	}{
+		{
+			name: "returns false when resource reference is not a core/v2.EventFilter",
+			args: args{
+				ref: &corev2.ResourceReference{
+					APIVersion: "core/v2",
+					Type:       "Handler",
+				},
+			},
+			want: false,
+		},
+		{
+			name: "returns false when resource reference is a core/v2.EventFilter and its name is not not_flapping",
+			args: args{
+				ref: &corev2.ResourceReference{
+					APIVersion: "core/v2",
+					Type:       "EventFilter",
+					Name:       "is_incident",
+				},
+			},
+			want: false,
+		},
+		{
+			name: "returns true when resource reference is a core/v2.EventFilter and its name is not_flapping",
+			args: args{
+				ref: &corev2.ResourceReference{
+					APIVersion: "core/v2",
+					Type:       "EventFilter",
+					Name:       "not_flapping",
+				},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &NotFlappingAdapter{}
+			if got := i.CanFilter(tt.args.ref); got != tt.want {
+				t.Errorf("NotFlappingAdapter.CanFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotFlappingAdapter_Filter(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		ref   *corev2.ResourceReference
+		event *corev2.Event
+	}
+	tests := []struct {
+		name    string
+		i       *NotFlappingAdapter
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "event is denied when its check is flapping",
+			args: args{
+				ctx: context.Background(),
+				event: func() *corev2.Event {
+					event := corev2.FixtureEvent("default", "default")
+					event.Check.State = corev2.EventFlappingState
+					return event
+				}(),
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "event is allowed when its check is not flapping",
+			args: args{
+				ctx: context.Background(),
+				event: func() *corev2.Event {
+					event := corev2.FixtureEvent("default", "default")
+					return event
+				}(),
+			},
+			want:    false,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &NotFlappingAdapter{}
+			got, err := i.Filter(tt.args.ctx, tt.args.ref, tt.args.event)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NotFlappingAdapter.Filter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NotFlappingAdapter.Filter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// defaultHandlerWorkerIdleTimeout is how long a handler worker waits for an
+// execution before it exits. Workers are started again on the next
+// submission, so that deleted or idle handlers do not hold on to goroutines.
+const defaultHandlerWorkerIdleTimeout = time.Minute
+
+// errHandlerQueueFull is returned when a handler with a concurrency limit
+// already has as many executions waiting as its queue can hold.
+var errHandlerQueueFull = errors.New("handler queue is full")
+
+// handlerQueue runs the executions of a single handler with at most limit
+// workers.
+type handlerQueue struct {
+	limit   int
+	workers int
+	jobs    chan func()
+}
+
+// handlerLimiter bounds the number of concurrent executions per handler. Each
+// handler gets its own workers, so that a slow handler only delays its own
+// executions. Workers exit when their context is done or once they have been
+// idle for idleTimeout. The zero value is ready to use.
+type handlerLimiter struct {
+	mu     sync.Mutex
+	queues map[string]*handlerQueue
+
+	// idleTimeout defaults to defaultHandlerWorkerIdleTimeout.
+	idleTimeout time.Duration
+}
+
+// submit queues fn for execution by the workers of the handler identified by
+// key, without blocking. The workers started for fn stop when ctx is done,
+// discarding any execution still waiting. It returns errHandlerQueueFull when
+// queueSize executions are already waiting.
+func (h *handlerLimiter) submit(ctx context.Context, key string, limit, queueSize int, fn func()) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.queues == nil {
+		h.queues = make(map[string]*handlerQueue)
+	}
+	q, ok := h.queues[key]
+	if ok && q.limit != limit {
+		// The limit of the handler was changed, let the current workers
+		// drain the executions they already have.
+		close(q.jobs)
+		ok = false
+	}
+	if !ok {
+		q = &handlerQueue{limit: limit, jobs: make(chan func(), queueSize)}
+		h.queues[key] = q
+	}
+	select {
+	case q.jobs <- fn:
+	default:
+		return errHandlerQueueFull
+	}
+	if q.workers < q.limit {
+		q.workers++
+		go h.work(ctx, key, q)
+	}
+	return nil
+}
+
+// work runs the executions of q until ctx is done, its jobs channel is closed,
+// or no execution was submitted for the idle timeout. The last worker to exit
+// removes q from the limiter.
+func (h *handlerLimiter) work(ctx context.Context, key string, q *handlerQueue) {
+	idleTimeout := h.idleTimeout
+	if idleTimeout <= 0 {
+		idleTimeout = defaultHandlerWorkerIdleTimeout
+	}
+	timer := time.NewTimer(idleTimeout)
+	defer timer.Stop()
+	for {
+		select {
+		case fn, ok := <-q.jobs:
+			if !ok {
+				h.exit(key, q)
+				return
+			}
+			fn()
+		case <-ctx.Done():
+			h.exit(key, q)
+			return
+		case <-timer.C:
+			h.mu.Lock()
+			if len(q.jobs) == 0 {
+				// Submissions hold the lock, so nothing can be queued
+				// for this worker once it has decided to exit.
+				h.removeWorker(key, q)
+				h.mu.Unlock()
+				return
+			}
+			h.mu.Unlock()
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(idleTimeout)
+	}
+}
+
+// exit unregisters a worker of q.
+func (h *handlerLimiter) exit(key string, q *handlerQueue) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removeWorker(key, q)
+}
+
+// removeWorker unregisters a worker of q, and removes q from the limiter when
+// it has no workers left. It must be called with h.mu held.
+func (h *handlerLimiter) removeWorker(key string, q *handlerQueue) {
+	q.workers--
+	if q.workers == 0 && h.queues[key] == q {
+		delete(h.queues, key)
+	}
+}
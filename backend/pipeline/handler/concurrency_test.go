@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHandlerLimiterLimitsConcurrency(t *testing.T) {
+	var limiter handlerLimiter
+	ctx := context.Background()
+	var running, maxRunning int32
+	var wg sync.WaitGroup
+	started := make(chan struct{}, 6)
+	release := make(chan struct{})
+
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		err := limiter.submit(ctx, "default/slow", 2, 10, func() {
+			defer wg.Done()
+			n := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+					break
+				}
+			}
+			started <- struct{}{}
+			<-release
+			atomic.AddInt32(&running, -1)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Another handler is not held up by the slow one
+	done := make(chan struct{})
+	if err := limiter.submit(ctx, "default/fast", 1, 10, func() { close(done) }); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler was blocked by another handler")
+	}
+
+	<-started
+	<-started
+	close(release)
+	wg.Wait()
+	if got := atomic.LoadInt32(&maxRunning); got != 2 {
+		t.Errorf("max concurrent executions = %d, want 2", got)
+	}
+}
+
+func TestHandlerLimiterQueueFull(t *testing.T) {
+	var limiter handlerLimiter
+	ctx := context.Background()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	block := func() {
+		started <- struct{}{}
+		<-release
+	}
+	if err := limiter.submit(ctx, "default/slow", 1, 1, block); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	if err := limiter.submit(ctx, "default/slow", 1, 1, func() {}); err != nil {
+		t.Fatal(err)
+	}
+	if err := limiter.submit(ctx, "default/slow", 1, 1, func() {}); err != errHandlerQueueFull {
+		t.Fatalf("expected errHandlerQueueFull, got %v", err)
+	}
+}
+
+func TestHandlerLimiterReapsIdleWorkers(t *testing.T) {
+	limiter := handlerLimiter{idleTimeout: 10 * time.Millisecond}
+	done := make(chan struct{})
+	if err := limiter.submit(context.Background(), "default/idle", 2, 10, func() { close(done) }); err != nil {
+		t.Fatal(err)
+	}
+	<-done
+	waitForQueues(t, &limiter, 0)
+
+	// The handler gets new workers on its next execution
+	done = make(chan struct{})
+	if err := limiter.submit(context.Background(), "default/idle", 2, 10, func() { close(done) }); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler was not executed after its workers were reaped")
+	}
+}
+
+func TestHandlerLimiterStopsWithContext(t *testing.T) {
+	var limiter handlerLimiter
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	release := make(chan struct{})
+	if err := limiter.submit(ctx, "default/slow", 1, 10, func() {
+		close(started)
+		<-release
+	}); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	cancel()
+	close(release)
+	waitForQueues(t, &limiter, 0)
+	if err := limiter.submit(ctx, "default/slow", 1, 10, func() {}); err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func waitForQueues(t *testing.T, limiter *handlerLimiter, want int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		limiter.mu.Lock()
+		got := len(limiter.queues)
+		limiter.mu.Unlock()
+		if got == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("handlers with workers = %d, want %d", got, want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -25,6 +25,14 @@ const (
 
 	// LegacyAdapterName is the name of the handler adapter.
 	LegacyAdapterName = "LegacyAdapter"
+
+	// DefaultHandlerQueueSize is the default number of executions that can
+	// wait for a handler with a concurrency limit.
+	DefaultHandlerQueueSize = 100
+
+	// DefaultHandlerRetryQueueSize is the default number of handler retries
+	// that can wait for their backoff.
+	DefaultHandlerRetryQueueSize = 1000
 )
 
 // LegacyAdapter is a handler adapter that supports the legacy core.v2/Handler
@@ -36,6 +44,20 @@ type LegacyAdapter struct {
 	SecretsProviderManager secrets.ProviderManagerer
 	Store                  store.Store
 	StoreTimeout           time.Duration
+
+	// HandlerQueueSize is the number of executions that can wait for a
+	// handler with a concurrency limit, beyond which events are dropped for
+	// that handler. Defaults to DefaultHandlerQueueSize.
+	HandlerQueueSize int
+
+	// HandlerRetryQueueSize is the number of handler retries that can wait
+	// for their backoff, beyond which the events whose handler failed are
+	// routed to their dead-letter handler right away. Defaults to
+	// DefaultHandlerRetryQueueSize.
+	HandlerRetryQueueSize int
+
+	limiter handlerLimiter
+	retries retryQueue
 }
 
 // Name returns the name of the handler adapter.
@@ -73,6 +95,42 @@ func (l *LegacyAdapter) Handle(ctx context.Context, ref *corev2.ResourceReferenc
 		return nil
 	}
 
+	limit, err := corev2.HandlerConcurrency(handler.Annotations)
+	if err != nil {
+		logger.WithFields(fields).WithError(err).Warn("ignoring invalid handler concurrency")
+	}
+	if limit > 0 {
+		key := handler.Namespace + "/" + handler.Name
+		err := l.limiter.submit(ctx, key, limit, l.handlerQueueSize(), func() {
+			_ = l.executeWithRetry(ctx, handler, event, mutatedData, fields)
+		})
+		if err != nil {
+			logger.WithFields(fields).WithError(err).Error("dropping event for handler")
+			return err
+		}
+		return nil
+	}
+
+	return l.executeWithRetry(ctx, handler, event, mutatedData, fields)
+}
+
+func (l *LegacyAdapter) handlerQueueSize() int {
+	if l.HandlerQueueSize <= 0 {
+		return DefaultHandlerQueueSize
+	}
+	return l.HandlerQueueSize
+}
+
+func (l *LegacyAdapter) retryQueueSize() int {
+	if l.HandlerRetryQueueSize <= 0 {
+		return DefaultHandlerRetryQueueSize
+	}
+	return l.HandlerRetryQueueSize
+}
+
+// execute runs the handler for the event, depending on its type. If strict is
+// set, a pipe handler exiting with a non-zero status is a failure.
+func (l *LegacyAdapter) execute(ctx context.Context, handler *corev2.Handler, event *corev2.Event, mutatedData []byte, fields map[string]interface{}, strict bool) error {
 	switch handler.Type {
 	case "pipe":
 		result, err := l.pipeHandler(ctx, handler, event, mutatedData)
@@ -85,6 +143,9 @@ func (l *LegacyAdapter) Handle(ctx context.Context, ref *corev2.ResourceReferenc
 		fields["status"] = result.Status
 		fields["output"] = result.Output
 		logger.WithFields(fields).Info("event pipe handler executed")
+		if strict && result.Status != 0 {
+			return fmt.Errorf("handler exited with status %d", result.Status)
+		}
 	case "tcp", "udp":
 		err := l.socketHandler(ctx, handler, event, mutatedData)
 		if err != nil {
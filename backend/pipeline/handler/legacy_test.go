@@ -596,3 +596,144 @@ func TestLegacyAdapter_socketHandlerUDP(t *testing.T) {
 
 	<-done
 }
+
+func TestLegacyAdapter_executeWithRetry(t *testing.T) {
+	handler := corev2.FixtureHandler("handler1")
+	handler.Annotations = map[string]string{
+		corev2.HandlerRetriesAnnotation:      "2",
+		corev2.HandlerRetryBackoffAnnotation: "1ms",
+		corev2.HandlerDeadLetterAnnotation:   "dead-letters",
+	}
+	deadLetterHandler := corev2.FixtureHandler("dead-letters")
+	deadLetterHandler.Command = "dead-letters-command"
+
+	var failure *corev2.Event
+	stor := &mockstore.MockStore{}
+	stor.On("GetHandlerByName", mock.Anything, "dead-letters").Return(deadLetterHandler, nil)
+	stor.On("UpdateEvent", mock.Anything).Run(func(args mock.Arguments) {
+		failure = args.Get(0).(*corev2.Event)
+	}).Return((*corev2.Event)(nil), (*corev2.Event)(nil), nil)
+
+	// the handler always fails, the dead-letter handler succeeds
+	var commands []string
+	var deadLetter *corev2.Event
+	done := make(chan struct{})
+	ex := &mockexecutor.MockExecutor{}
+	ex.SetRequestFunc(func(_ context.Context, request command.ExecutionRequest) {
+		commands = append(commands, request.Command)
+		if request.Command == deadLetterHandler.Command {
+			deadLetter = &corev2.Event{}
+			require.NoError(t, json.Unmarshal([]byte(request.Input), deadLetter))
+			ex.UnsafeReturn(command.FixtureExecutionResponse(0, ""), nil)
+			close(done)
+			return
+		}
+		ex.UnsafeReturn(command.FixtureExecutionResponse(2, "service unavailable"), nil)
+	})
+
+	l := &LegacyAdapter{Executor: ex, Store: stor, StoreTimeout: time.Second}
+	event := corev2.FixtureEvent("entity1", "check1")
+
+	// only the first execution is run by the caller, the retries are run
+	// asynchronously
+	err := l.executeWithRetry(context.Background(), handler, event, []byte{}, map[string]interface{}{})
+	assert.EqualError(t, err, "handler exited with status 2")
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the event was not routed to the dead-letter handler")
+	}
+
+	assert.Equal(t, []string{"command", "command", "command", "dead-letters-command"}, commands)
+	require.NotNil(t, deadLetter)
+	assert.Equal(t, "handler1", deadLetter.Annotations[corev2.DeadLetterHandlerAnnotation])
+	assert.Equal(t, "handler exited with status 2", deadLetter.Annotations[corev2.DeadLetterErrorAnnotation])
+	assert.Equal(t, "3", deadLetter.Annotations[corev2.DeadLetterAttemptsAnnotation])
+	assert.Empty(t, event.Annotations[corev2.DeadLetterHandlerAnnotation])
+
+	// the failure is recorded as an event of the entity
+	require.NotNil(t, failure)
+	assert.Equal(t, "entity1", failure.Entity.Name)
+	assert.Equal(t, corev2.HandlerFailureCheckPrefix+"handler1", failure.Check.Name)
+	assert.Equal(t, uint32(2), failure.Check.Status)
+	assert.Equal(t, "handler handler1 failed after 3 attempts: handler exited with status 2, for the event of check check1", failure.Check.Output)
+}
+
+func TestLegacyAdapter_executeWithRetryQueueFull(t *testing.T) {
+	handler := corev2.FixtureHandler("handler1")
+	handler.Annotations = map[string]string{
+		corev2.HandlerRetriesAnnotation:      "2",
+		corev2.HandlerRetryBackoffAnnotation: "1h",
+	}
+
+	stor := &mockstore.MockStore{}
+	stor.On("UpdateEvent", mock.Anything).Return((*corev2.Event)(nil), (*corev2.Event)(nil), nil)
+
+	ex := &mockexecutor.MockExecutor{}
+	ex.Return(command.FixtureExecutionResponse(2, "service unavailable"), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	l := &LegacyAdapter{Executor: ex, Store: stor, StoreTimeout: time.Second, HandlerRetryQueueSize: 1}
+	event := corev2.FixtureEvent("entity1", "check1")
+
+	// the first failure waits in the retry queue, the second one is recorded
+	// right away since the queue is full
+	_ = l.executeWithRetry(ctx, handler, event, []byte{}, map[string]interface{}{})
+	stor.AssertNotCalled(t, "UpdateEvent", mock.Anything)
+	_ = l.executeWithRetry(ctx, handler, event, []byte{}, map[string]interface{}{})
+	stor.AssertNumberOfCalls(t, "UpdateEvent", 1)
+}
+
+func TestLegacyAdapter_executeWithRetryResolvesFailure(t *testing.T) {
+	handler := corev2.FixtureHandler("handler1")
+	handler.Annotations = map[string]string{
+		corev2.HandlerRetriesAnnotation: "2",
+	}
+	failureName := corev2.HandlerFailureCheckPrefix + "handler1"
+	failure := corev2.FixtureEvent("entity1", failureName)
+	failure.Check.Status = 2
+
+	tests := []struct {
+		name         string
+		failure      *corev2.Event
+		wantResolved bool
+	}{
+		{
+			name:         "recorded failure",
+			failure:      failure,
+			wantResolved: true,
+		},
+		{
+			name: "no recorded failure",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resolved *corev2.Event
+			stor := &mockstore.MockStore{}
+			stor.On("GetEventByEntityCheck", mock.Anything, "entity1", failureName).Return(tt.failure, nil)
+			stor.On("UpdateEvent", mock.Anything).Run(func(args mock.Arguments) {
+				resolved = args.Get(0).(*corev2.Event)
+			}).Return((*corev2.Event)(nil), (*corev2.Event)(nil), nil)
+
+			ex := &mockexecutor.MockExecutor{}
+			ex.Return(command.FixtureExecutionResponse(0, ""), nil)
+
+			l := &LegacyAdapter{Executor: ex, Store: stor, StoreTimeout: time.Second}
+			event := corev2.FixtureEvent("entity1", "check1")
+			require.NoError(t, l.executeWithRetry(context.Background(), handler, event, []byte{}, map[string]interface{}{}))
+
+			if !tt.wantResolved {
+				stor.AssertNotCalled(t, "UpdateEvent", mock.Anything)
+				return
+			}
+			require.NotNil(t, resolved)
+			assert.Equal(t, "entity1", resolved.Entity.Name)
+			assert.Equal(t, failureName, resolved.Check.Name)
+			assert.Equal(t, uint32(0), resolved.Check.Status)
+			assert.Equal(t, "handler handler1 succeeded, for the event of check check1", resolved.Check.Output)
+		})
+	}
+}
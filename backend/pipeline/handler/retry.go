@@ -0,0 +1,314 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	metricspkg "github.com/sensu/sensu-go/metrics"
+)
+
+const (
+	// DeadLetters is the name of the prometheus counter vec used to track the
+	// events routed to dead-letter handlers, by failed handler.
+	DeadLetters = "sensu_go_handler_dead_letters_total"
+)
+
+var deadLetters = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: DeadLetters,
+		Help: "number of events routed to a dead-letter handler once their handler and its retries failed",
+	},
+	[]string{"namespace", "handler"},
+)
+
+func init() {
+	if err := prometheus.Register(deadLetters); err != nil {
+		panic(metricspkg.FormatRegistrationErr(DeadLetters, err))
+	}
+}
+
+// errRetryQueueFull is returned when as many retries as the retry queue can
+// hold are already waiting for their backoff.
+var errRetryQueueFull = errors.New("handler retry queue is full")
+
+// retryQueue delays the retries of the handlers, without blocking the
+// pipeline workers that scheduled them. The zero value is ready to use.
+type retryQueue struct {
+	mu      sync.Mutex
+	pending int
+}
+
+// schedule runs fn once delay is elapsed, unless ctx is done first. It
+// returns errRetryQueueFull when size retries are already waiting.
+func (q *retryQueue) schedule(ctx context.Context, size int, delay time.Duration, fn func()) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.pending >= size {
+		return errRetryQueueFull
+	}
+	q.pending++
+	go func() {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			q.done()
+		case <-timer.C:
+			q.done()
+			fn()
+		}
+	}()
+	return nil
+}
+
+func (q *retryQueue) done() {
+	q.mu.Lock()
+	q.pending--
+	q.mu.Unlock()
+}
+
+// executeWithRetry runs the handler for the event, and schedules its retries
+// as its retry policy sets. The retries wait for their backoff in the retry
+// queue, so only the first execution is run by the caller, whose error is
+// returned. Once the execution and its retries failed, the failure is
+// recorded and the event is routed to the dead-letter handler of the policy,
+// if any.
+func (l *LegacyAdapter) executeWithRetry(ctx context.Context, handler *corev2.Handler, event *corev2.Event, mutatedData []byte, fields map[string]interface{}) error {
+	policy, err := corev2.HandlerRetry(handler.Annotations)
+	if err != nil {
+		logger.WithFields(fields).WithError(err).Warn("ignoring invalid handler retry policy")
+		policy = corev2.HandlerRetryPolicy{}
+	}
+	if !policy.Enabled() {
+		return l.execute(ctx, handler, event, mutatedData, fields, false)
+	}
+	return l.attempt(ctx, handler, policy, event, mutatedData, fields, 1, policy.Backoff)
+}
+
+// attempt runs the given attempt of the execution of the handler. If it fails,
+// the next attempt is scheduled after backoff, or the failure is recorded once
+// the retries are exhausted.
+func (l *LegacyAdapter) attempt(ctx context.Context, handler *corev2.Handler, policy corev2.HandlerRetryPolicy, event *corev2.Event, mutatedData []byte, fields map[string]interface{}, attempt int, backoff time.Duration) error {
+	// The attempts may run concurrently with the caller, which must not see
+	// the fields they add.
+	fields = copyFields(fields)
+	err := l.execute(ctx, handler, event, mutatedData, fields, true)
+	if err == nil {
+		l.resolveFailure(ctx, handler, event, fields)
+		return nil
+	}
+
+	if attempt <= policy.Retries {
+		logger.WithFields(fields).WithError(err).
+			WithField("attempt", attempt).
+			WithField("backoff", backoff.String()).
+			Warn("handler failed, retrying")
+		retry := func() {
+			l.retry(ctx, handler, policy, event, mutatedData, fields, attempt+1, backoff*2)
+		}
+		qerr := l.retries.schedule(ctx, l.retryQueueSize(), backoff, retry)
+		if qerr == nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			// The backend is stopping, the failure can't be handled
+			return err
+		}
+		logger.WithFields(fields).WithError(qerr).Error("could not schedule the retry of the handler")
+	}
+
+	l.fail(ctx, handler, policy, event, attempt, err, fields)
+	return err
+}
+
+// retry runs the given attempt of the execution of the handler, within its
+// concurrency limit if it has one.
+func (l *LegacyAdapter) retry(ctx context.Context, handler *corev2.Handler, policy corev2.HandlerRetryPolicy, event *corev2.Event, mutatedData []byte, fields map[string]interface{}, attempt int, backoff time.Duration) {
+	run := func() {
+		_ = l.attempt(ctx, handler, policy, event, mutatedData, fields, attempt, backoff)
+	}
+	limit, _ := corev2.HandlerConcurrency(handler.Annotations)
+	if limit <= 0 {
+		run()
+		return
+	}
+	key := handler.Namespace + "/" + handler.Name
+	if err := l.limiter.submit(ctx, key, limit, l.handlerQueueSize(), run); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		l.fail(ctx, handler, policy, event, attempt-1, err, fields)
+	}
+}
+
+// fail records the failure of the handler for the event after the given
+// number of attempts, and routes the event to the dead-letter handler of the
+// policy, if any.
+func (l *LegacyAdapter) fail(ctx context.Context, handler *corev2.Handler, policy corev2.HandlerRetryPolicy, event *corev2.Event, attempts int, cause error, fields map[string]interface{}) {
+	fields = copyFields(fields)
+	fields["attempts"] = attempts
+	logger.WithFields(fields).WithError(cause).Error("handler failed, giving up")
+
+	failed := failedEvent(handler, event, attempts, cause)
+	l.recordFailure(ctx, handler, failed, fields)
+	if policy.DeadLetter != "" {
+		l.deadLetter(ctx, handler, policy.DeadLetter, failed, fields)
+	}
+}
+
+// recordFailure stores the handler failure event of the failed event, so that
+// the failures of the handler can be listed with the events of the entity. The
+// failure event is only stored, it is not handled.
+func (l *LegacyAdapter) recordFailure(ctx context.Context, handler *corev2.Handler, failed *corev2.Event, fields map[string]interface{}) {
+	if failed.Entity == nil {
+		return
+	}
+	tctx, cancel := context.WithTimeout(ctx, l.StoreTimeout)
+	defer cancel()
+	if _, _, err := l.Store.UpdateEvent(tctx, handlerFailureEvent(handler, failed)); err != nil {
+		logger.WithFields(fields).WithError(err).Error("failed to store the handler failure event")
+	}
+}
+
+// resolveFailure stores a resolved handler failure event for the entity of the
+// event once the handler succeeds, if a failure of the handler is recorded for
+// the entity and not resolved yet.
+func (l *LegacyAdapter) resolveFailure(ctx context.Context, handler *corev2.Handler, event *corev2.Event, fields map[string]interface{}) {
+	if event.Entity == nil {
+		return
+	}
+	tctx, cancel := context.WithTimeout(ctx, l.StoreTimeout)
+	defer cancel()
+	failure, err := l.Store.GetEventByEntityCheck(tctx, event.Entity.Name, corev2.HandlerFailureCheckPrefix+handler.Name)
+	if err != nil {
+		logger.WithFields(fields).WithError(err).Error("failed to fetch the handler failure event")
+		return
+	}
+	if failure == nil || !failure.HasCheck() || failure.Check.Status == 0 {
+		return
+	}
+	if _, _, err := l.Store.UpdateEvent(tctx, handlerResolvedEvent(handler, event)); err != nil {
+		logger.WithFields(fields).WithError(err).Error("failed to store the resolved handler failure event")
+	}
+}
+
+// deadLetter routes the failed event to the dead-letter handler named name.
+// The event is passed to the dead-letter handler as JSON, without retries.
+func (l *LegacyAdapter) deadLetter(ctx context.Context, handler *corev2.Handler, name string, failed *corev2.Event, fields map[string]interface{}) {
+	fields = copyFields(fields)
+	fields["dead_letter_handler"] = name
+	logger.WithFields(fields).Info("routing the event to its dead-letter handler")
+	deadLetters.WithLabelValues(handler.Namespace, handler.Name).Inc()
+
+	tctx, cancel := context.WithTimeout(ctx, l.StoreTimeout)
+	deadLetterHandler, err := l.Store.GetHandlerByName(tctx, name)
+	cancel()
+	if err != nil {
+		logger.WithFields(fields).WithError(err).Error("failed to fetch dead-letter handler from store")
+		return
+	}
+	if deadLetterHandler == nil {
+		logger.WithFields(fields).Error("dead-letter handler not found, the event is dropped")
+		return
+	}
+	if deadLetterHandler.Type == corev2.HandlerSetType {
+		logger.WithFields(fields).Error("dead-letter handler is a handler set, the event is dropped")
+		return
+	}
+
+	data, err := json.Marshal(failed)
+	if err != nil {
+		logger.WithFields(fields).WithError(err).Error("failed to marshal the event for the dead-letter handler")
+		return
+	}
+	fields["handler"] = name
+	if err := l.execute(ctx, deadLetterHandler, failed, data, fields, false); err != nil {
+		logger.WithFields(fields).WithError(err).Error("dead-letter handler failed, the event is dropped")
+	}
+}
+
+// failedEvent returns a copy of the event annotated with the failed handler,
+// its error and its number of attempts.
+func failedEvent(handler *corev2.Handler, event *corev2.Event, attempts int, cause error) *corev2.Event {
+	failed := *event
+	failed.ObjectMeta.Annotations = make(map[string]string, len(event.Annotations)+3)
+	for k, v := range event.Annotations {
+		failed.ObjectMeta.Annotations[k] = v
+	}
+	failed.ObjectMeta.Annotations[corev2.DeadLetterHandlerAnnotation] = handler.Name
+	failed.ObjectMeta.Annotations[corev2.DeadLetterErrorAnnotation] = cause.Error()
+	failed.ObjectMeta.Annotations[corev2.DeadLetterAttemptsAnnotation] = strconv.Itoa(attempts)
+	return &failed
+}
+
+// handlerFailureEvent returns the handler failure event of the failed event,
+// a critical event of the entity of the failed event whose check is named
+// after the handler, and whose output is the error of the handler.
+func handlerFailureEvent(handler *corev2.Handler, failed *corev2.Event) *corev2.Event {
+	output := fmt.Sprintf("handler %s failed after %s attempts: %s",
+		handler.Name,
+		failed.Annotations[corev2.DeadLetterAttemptsAnnotation],
+		failed.Annotations[corev2.DeadLetterErrorAnnotation],
+	)
+	if failed.HasCheck() {
+		output = fmt.Sprintf("%s, for the event of check %s", output, failed.Check.Name)
+	}
+	event := handlerEvent(handler, failed.Entity, 2, output)
+	event.Annotations = map[string]string{
+		corev2.DeadLetterHandlerAnnotation:  failed.Annotations[corev2.DeadLetterHandlerAnnotation],
+		corev2.DeadLetterErrorAnnotation:    failed.Annotations[corev2.DeadLetterErrorAnnotation],
+		corev2.DeadLetterAttemptsAnnotation: failed.Annotations[corev2.DeadLetterAttemptsAnnotation],
+	}
+	return event
+}
+
+// handlerResolvedEvent returns the event resolving the handler failure event
+// of the entity of the event, once the handler succeeded for the event.
+func handlerResolvedEvent(handler *corev2.Handler, event *corev2.Event) *corev2.Event {
+	output := fmt.Sprintf("handler %s succeeded", handler.Name)
+	if event.HasCheck() {
+		output = fmt.Sprintf("%s, for the event of check %s", output, event.Check.Name)
+	}
+	return handlerEvent(handler, event.Entity, 0, output)
+}
+
+// handlerEvent returns an event of the entity whose check is named after the
+// handler, with the given status and output.
+func handlerEvent(handler *corev2.Handler, entity *corev2.Entity, status uint32, output string) *corev2.Event {
+	now := time.Now().Unix()
+	return &corev2.Event{
+		ObjectMeta: corev2.ObjectMeta{
+			Namespace: entity.Namespace,
+		},
+		Timestamp: now,
+		Entity:    entity,
+		Check: &corev2.Check{
+			ObjectMeta: corev2.ObjectMeta{
+				Name:      corev2.HandlerFailureCheckPrefix + handler.Name,
+				Namespace: entity.Namespace,
+			},
+			Status:   status,
+			Output:   output,
+			Executed: now,
+			Issued:   now,
+		},
+	}
+}
+
+func copyFields(fields map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		copied[k] = v
+	}
+	return copied
+}
@@ -37,6 +37,11 @@ type LegacyAdapter struct {
 	SecretsProviderManager *secrets.ProviderManager
 	Store                  store.Store
 	StoreTimeout           time.Duration
+
+	// MaxTimeout and TimeoutPolicy configure the pipe mutators, see
+	// PipeAdapter.
+	MaxTimeout    time.Duration
+	TimeoutPolicy string
 }
 
 // Name returns the name of the mutator adapter.
@@ -104,6 +109,8 @@ func (l *LegacyAdapter) Mutate(ctx context.Context, ref *corev2.ResourceReferenc
 			SecretsProviderManager: l.SecretsProviderManager,
 			Store:                  l.Store,
 			StoreTimeout:           l.StoreTimeout,
+			MaxTimeout:             l.MaxTimeout,
+			TimeoutPolicy:          l.TimeoutPolicy,
 		}
 		eventData, err = pipeMutator.run(ctx, mutator, event, assets)
 	} else if mutator.Type == corev2.JavascriptMutator {
@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/asset"
 	"github.com/sensu/sensu-go/backend/secrets"
@@ -19,8 +22,44 @@ import (
 const (
 	// PipeAdapterName is the name of the mutator adapter.
 	PipeAdapterName = "PipeAdapter"
+
+	// MutatorTimeouts is the name of the prometheus counter used to track the
+	// pipe mutator executions that timed out.
+	MutatorTimeouts = "sensu_go_pipeline_mutator_timeouts"
+
+	// TimeoutPolicyFail fails the handling of the events whose mutator timed
+	// out.
+	TimeoutPolicyFail = "fail"
+
+	// TimeoutPolicyUnmutated handles the events whose mutator timed out with
+	// their unmutated JSON data.
+	TimeoutPolicyUnmutated = "unmutated"
+)
+
+var mutatorTimeoutsCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: MutatorTimeouts,
+		Help: "The number of pipe mutator executions that timed out",
+	},
+	[]string{"policy"},
 )
 
+func init() {
+	if err := prometheus.Register(mutatorTimeoutsCounter); err != nil {
+		panic(fmt.Errorf("error registering %s: %s", MutatorTimeouts, err))
+	}
+}
+
+// ValidateTimeoutPolicy returns an error if the policy is not a known mutator
+// timeout policy.
+func ValidateTimeoutPolicy(policy string) error {
+	switch policy {
+	case "", TimeoutPolicyFail, TimeoutPolicyUnmutated:
+		return nil
+	}
+	return fmt.Errorf("invalid mutator timeout policy %q, must be %q or %q", policy, TimeoutPolicyFail, TimeoutPolicyUnmutated)
+}
+
 // PipeAdapter is a mutator adapter which fork/executes a child process for a
 // Sensu mutator command, writes the JSON encoding of the Sensu event to it via
 // STDIN, and captures the command output (STDOUT/ERR) to be used as the mutated
@@ -31,6 +70,14 @@ type PipeAdapter struct {
 	SecretsProviderManager *secrets.ProviderManager
 	Store                  store.Store
 	StoreTimeout           time.Duration
+
+	// MaxTimeout caps the execution time of the mutators, including those
+	// without a timeout. Disabled when zero.
+	MaxTimeout time.Duration
+
+	// TimeoutPolicy is what happens to the events whose mutator timed out,
+	// either TimeoutPolicyFail (the default) or TimeoutPolicyUnmutated.
+	TimeoutPolicy string
 }
 
 // Name returns the name of the mutator adapter.
@@ -78,7 +125,7 @@ func (p *PipeAdapter) run(ctx context.Context, mutator *corev2.Mutator, event *c
 
 	mutatorExec := command.ExecutionRequest{}
 	mutatorExec.Command = mutator.Command
-	mutatorExec.Timeout = int(mutator.Timeout)
+	mutatorExec.Timeout = p.timeout(mutator)
 	mutatorExec.Env = env
 
 	eventData, err := json.Marshal(event)
@@ -100,6 +147,16 @@ func (p *PipeAdapter) run(ctx context.Context, mutator *corev2.Mutator, event *c
 	if err != nil {
 		logger.WithFields(fields).WithError(err).Error("failed to execute event pipe mutator")
 		return nil, err
+	} else if timedOut(result) {
+		fields["timeout"] = mutatorExec.Timeout
+		if p.TimeoutPolicy == TimeoutPolicyUnmutated {
+			mutatorTimeoutsCounter.WithLabelValues(TimeoutPolicyUnmutated).Inc()
+			logger.WithFields(fields).Warn("pipe mutator execution timed out, handling the unmutated event")
+			return eventData, nil
+		}
+		mutatorTimeoutsCounter.WithLabelValues(TimeoutPolicyFail).Inc()
+		logger.WithFields(fields).Error("pipe mutator execution timed out")
+		return nil, errors.New("pipe mutator execution timed out")
 	} else if result.Status != 0 {
 		logger.WithFields(fields).Error("failure in event pipe mutator execution")
 		return nil, errors.New("pipe mutator execution returned non-zero exit status")
@@ -109,3 +166,22 @@ func (p *PipeAdapter) run(ctx context.Context, mutator *corev2.Mutator, event *c
 
 	return []byte(result.Output), nil
 }
+
+// timeout returns the execution timeout of the mutator in seconds, capped by
+// the max timeout of the adapter.
+func (p *PipeAdapter) timeout(mutator *corev2.Mutator) int {
+	timeout := int(mutator.Timeout)
+	if p.MaxTimeout <= 0 {
+		return timeout
+	}
+	// round up so that sub-second max timeouts do not disable the timeout
+	max := int((p.MaxTimeout + time.Second - 1) / time.Second)
+	if timeout == 0 || timeout > max {
+		return max
+	}
+	return timeout
+}
+
+func timedOut(result *command.ExecutionResponse) bool {
+	return result.Status == command.TimeoutExitStatus && strings.HasPrefix(result.Output, command.TimeoutOutput)
+}
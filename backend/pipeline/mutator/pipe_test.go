@@ -2,6 +2,7 @@ package mutator
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -15,6 +16,7 @@ import (
 	"github.com/sensu/sensu-go/backend/secrets"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/command"
+	"github.com/sensu/sensu-go/testing/mockexecutor"
 )
 
 func TestHelperMutatorProcess(t *testing.T) {
@@ -168,3 +170,102 @@ func TestPipeAdapter_run(t *testing.T) {
 		})
 	}
 }
+
+func TestPipeAdapter_timeout(t *testing.T) {
+	tests := []struct {
+		name       string
+		timeout    uint32
+		maxTimeout time.Duration
+		want       int
+	}{
+		{
+			name:    "no max timeout",
+			timeout: 30,
+			want:    30,
+		},
+		{
+			name:       "mutator without timeout",
+			maxTimeout: time.Minute,
+			want:       60,
+		},
+		{
+			name:       "mutator timeout below the max",
+			timeout:    10,
+			maxTimeout: time.Minute,
+			want:       10,
+		},
+		{
+			name:       "mutator timeout above the max",
+			timeout:    120,
+			maxTimeout: time.Minute,
+			want:       60,
+		},
+		{
+			name:       "sub-second max timeout",
+			maxTimeout: 500 * time.Millisecond,
+			want:       1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &PipeAdapter{MaxTimeout: tt.maxTimeout}
+			mutator := corev2.FixtureMutator("mutator")
+			mutator.Timeout = tt.timeout
+			if got := p.timeout(mutator); got != tt.want {
+				t.Errorf("PipeAdapter.timeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPipeAdapter_runTimedOut(t *testing.T) {
+	event := corev2.FixtureEvent("entity", "check")
+	eventData, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		policy  string
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name:    "default policy",
+			wantErr: true,
+		},
+		{
+			name:    "fail policy",
+			policy:  TimeoutPolicyFail,
+			wantErr: true,
+		},
+		{
+			name:   "unmutated policy",
+			policy: TimeoutPolicyUnmutated,
+			want:   eventData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &mockexecutor.MockExecutor{}
+			executor.Return(&command.ExecutionResponse{
+				Output: command.TimeoutOutput,
+				Status: command.TimeoutExitStatus,
+			}, nil)
+			p := &PipeAdapter{
+				Executor:               executor,
+				SecretsProviderManager: secrets.NewProviderManager(nil),
+				MaxTimeout:             time.Second,
+				TimeoutPolicy:          tt.policy,
+			}
+			got, err := p.run(context.Background(), corev2.FixtureMutator("mutator"), event, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PipeAdapter.run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PipeAdapter.run() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
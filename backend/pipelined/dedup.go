@@ -0,0 +1,105 @@
+package pipelined
+
+import (
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// DedupOccurrencesAnnotation is the annotation set on events handled after
+// repeats of the same status were coalesced by the dedup window. Its value is
+// the number of occurrences of the status since it was last handled, including
+// the annotated event.
+const DedupOccurrencesAnnotation = "sensu.io/dedup_occurrences"
+
+// statusRecord tracks the last status handled for a check, when it was handled
+// and how many times it occurred since then.
+type statusRecord struct {
+	status      uint32
+	handled     time.Time
+	occurrences int
+}
+
+// deduplicator coalesces the consecutive repeats of the same status of a check
+// that occur within a window, so that flapping checks do not flood handlers.
+type deduplicator struct {
+	window    time.Duration
+	now       func() time.Time
+	mu        sync.Mutex
+	records   map[string]*statusRecord
+	lastSweep time.Time
+}
+
+func newDeduplicator(window time.Duration) *deduplicator {
+	return &deduplicator{
+		window:  window,
+		now:     time.Now,
+		records: make(map[string]*statusRecord),
+	}
+}
+
+// observe returns the event to handle, or nil if the event repeats the status
+// last handled for its check within the window. A status differing from the
+// last handled one is always handled, so that handlers never miss a change.
+// When repeats were coalesced, the returned event is a copy annotated with the
+// number of occurrences of the status.
+func (d *deduplicator) observe(event *corev2.Event) *corev2.Event {
+	if !event.HasCheck() || event.Entity == nil {
+		return event
+	}
+	status := event.Check.Status
+	key := path.Join(event.Entity.Namespace, event.Entity.Name, event.Check.Name)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	// Sweep once the current status is recorded, so that its record is not
+	// discarded before being used
+	defer d.sweep(now)
+
+	record, ok := d.records[key]
+	if !ok || record.status != status {
+		d.records[key] = &statusRecord{status: status, handled: now, occurrences: 1}
+		return event
+	}
+	record.occurrences++
+	if now.Sub(record.handled) < d.window {
+		return nil
+	}
+
+	// Exclude the occurrence that was handled at the start of the window
+	occurrences := record.occurrences - 1
+	record.handled = now
+	record.occurrences = 1
+	if occurrences == 1 {
+		// No repeat was dropped since the status was last handled
+		return event
+	}
+
+	annotated := *event
+	annotations := make(map[string]string, len(event.Annotations)+1)
+	for k, v := range event.Annotations {
+		annotations[k] = v
+	}
+	annotations[DedupOccurrencesAnnotation] = strconv.Itoa(occurrences)
+	annotated.Annotations = annotations
+	return &annotated
+}
+
+// sweep removes the records of statuses that were last handled more than a
+// window ago, at most once per window. It must be called with the mutex held.
+func (d *deduplicator) sweep(now time.Time) {
+	if now.Sub(d.lastSweep) < d.window {
+		return
+	}
+	for key, record := range d.records {
+		if now.Sub(record.handled) >= d.window {
+			delete(d.records, key)
+		}
+	}
+	d.lastSweep = now
+}
@@ -0,0 +1,79 @@
+package pipelined
+
+import (
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func statusEvent(status uint32) *corev2.Event {
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Check.Status = status
+	return event
+}
+
+func TestDeduplicator(t *testing.T) {
+	now := time.Unix(1000, 0)
+	d := newDeduplicator(time.Minute)
+	d.now = func() time.Time { return now }
+
+	// The first occurrence of a status is handled
+	assert.NotNil(t, d.observe(statusEvent(2)))
+
+	// Repeats of the last handled status within the window are coalesced
+	now = now.Add(10 * time.Second)
+	assert.Nil(t, d.observe(statusEvent(2)))
+	now = now.Add(10 * time.Second)
+	assert.Nil(t, d.observe(statusEvent(2)))
+
+	// Once the window expired, the status is handled and annotated with its
+	// number of occurrences
+	now = now.Add(time.Minute)
+	event := d.observe(statusEvent(2))
+	require.NotNil(t, event)
+	assert.Equal(t, "3", event.Annotations[DedupOccurrencesAnnotation])
+
+	// The next window starts from the handled occurrence
+	now = now.Add(2 * time.Minute)
+	event = d.observe(statusEvent(2))
+	require.NotNil(t, event)
+	assert.NotContains(t, event.Annotations, DedupOccurrencesAnnotation)
+}
+
+func TestDeduplicatorHandlesStatusChanges(t *testing.T) {
+	now := time.Unix(1000, 0)
+	d := newDeduplicator(time.Minute)
+	d.now = func() time.Time { return now }
+
+	// A flapping check has every status change handled, so that handlers
+	// always know its latest status
+	assert.NotNil(t, d.observe(statusEvent(0)))
+	assert.NotNil(t, d.observe(statusEvent(2)))
+	assert.NotNil(t, d.observe(statusEvent(0)))
+	assert.NotNil(t, d.observe(statusEvent(2)))
+	assert.Nil(t, d.observe(statusEvent(2)))
+
+	// Other checks are tracked separately
+	other := statusEvent(2)
+	other.Check.Name = "check2"
+	assert.NotNil(t, d.observe(other))
+}
+
+func TestDeduplicatorDoesNotMutateEvent(t *testing.T) {
+	now := time.Unix(1000, 0)
+	d := newDeduplicator(time.Minute)
+	d.now = func() time.Time { return now }
+
+	assert.NotNil(t, d.observe(statusEvent(1)))
+	assert.Nil(t, d.observe(statusEvent(1)))
+
+	now = now.Add(time.Minute)
+	original := statusEvent(1)
+	event := d.observe(original)
+	require.NotNil(t, event)
+	assert.Equal(t, "2", event.Annotations[DedupOccurrencesAnnotation])
+	assert.NotContains(t, original.Annotations, DedupOccurrencesAnnotation)
+}
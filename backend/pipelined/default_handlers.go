@@ -0,0 +1,61 @@
+package pipelined
+
+import (
+	"strconv"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// withDefaultHandlers returns the event with the default handlers of its
+// namespace merged into the handlers of its check, unless the check opts out
+// with the CheckSkipDefaultHandlersAnnotation annotation. The namespace is
+// looked up in the namespace cache, so that handling an event does not query
+// the store; the event is returned unchanged when it isn't cached.
+func (p *Pipelined) withDefaultHandlers(event *corev2.Event) *corev2.Event {
+	if p.namespaces == nil || !event.HasCheck() || event.Entity == nil || skipsDefaultHandlers(event.Check) {
+		return event
+	}
+	for _, value := range p.namespaces.Get("") {
+		namespace, ok := value.Resource.(*corev2.Namespace)
+		if ok && namespace.Name == event.Entity.Namespace {
+			return mergeDefaultHandlers(event, namespace.DefaultHandlers)
+		}
+	}
+	return event
+}
+
+// skipsDefaultHandlers returns true if check opted out of the default
+// handlers of its namespace.
+func skipsDefaultHandlers(check *corev2.Check) bool {
+	skip, _ := strconv.ParseBool(check.Annotations[corev2.CheckSkipDefaultHandlersAnnotation])
+	return skip
+}
+
+// mergeDefaultHandlers returns a copy of event whose check handlers include
+// the defaults not already referenced by the check, after the check's own
+// handlers. The event is returned as is when there is nothing to merge.
+func mergeDefaultHandlers(event *corev2.Event, defaults []string) *corev2.Event {
+	present := make(map[string]struct{}, len(event.Check.Handlers))
+	for _, handler := range event.Check.Handlers {
+		present[handler] = struct{}{}
+	}
+	var handlers []string
+	for _, handler := range defaults {
+		if _, ok := present[handler]; ok {
+			continue
+		}
+		present[handler] = struct{}{}
+		handlers = append(handlers, handler)
+	}
+	if len(handlers) == 0 {
+		return event
+	}
+
+	// The event is shared with the other subscribers of the bus, so the
+	// handlers are merged into a copy of its check.
+	check := *event.Check
+	check.Handlers = append(append([]string{}, event.Check.Handlers...), handlers...)
+	merged := *event
+	merged.Check = &check
+	return &merged
+}
@@ -0,0 +1,79 @@
+package pipelined
+
+import (
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithDefaultHandlers(t *testing.T) {
+	tests := []struct {
+		name          string
+		checkHandlers []string
+		annotations   map[string]string
+		namespace     *corev2.Namespace
+		want          []string
+	}{
+		{
+			name:          "default handlers are merged after the check handlers",
+			checkHandlers: []string{"slack"},
+			namespace:     &corev2.Namespace{Name: "default", DefaultHandlers: []string{"pagerduty", "archive"}},
+			want:          []string{"slack", "pagerduty", "archive"},
+		},
+		{
+			name:      "default handlers apply to checks without handlers",
+			namespace: &corev2.Namespace{Name: "default", DefaultHandlers: []string{"archive"}},
+			want:      []string{"archive"},
+		},
+		{
+			name:          "check handlers override identical default handlers",
+			checkHandlers: []string{"archive", "slack"},
+			namespace:     &corev2.Namespace{Name: "default", DefaultHandlers: []string{"slack", "archive"}},
+			want:          []string{"archive", "slack"},
+		},
+		{
+			name:          "checks can opt out of the default handlers",
+			checkHandlers: []string{"slack"},
+			annotations:   map[string]string{corev2.CheckSkipDefaultHandlersAnnotation: "true"},
+			want:          []string{"slack"},
+		},
+		{
+			name:          "opt out must be a true boolean",
+			checkHandlers: []string{"slack"},
+			annotations:   map[string]string{corev2.CheckSkipDefaultHandlersAnnotation: "no"},
+			namespace:     &corev2.Namespace{Name: "default", DefaultHandlers: []string{"archive"}},
+			want:          []string{"slack", "archive"},
+		},
+		{
+			name:          "namespace without default handlers",
+			checkHandlers: []string{"slack"},
+			namespace:     &corev2.Namespace{Name: "default"},
+			want:          []string{"slack"},
+		},
+		{
+			name:          "uncached namespaces leave the check handlers unchanged",
+			checkHandlers: []string{"slack"},
+			namespace:     &corev2.Namespace{Name: "other", DefaultHandlers: []string{"archive"}},
+			want:          []string{"slack"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var namespaces []corev2.Resource
+			if tt.namespace != nil {
+				namespaces = append(namespaces, tt.namespace)
+			}
+			p := &Pipelined{namespaces: cache.NewFromResources(namespaces, false)}
+
+			event := corev2.FixtureEvent("entity1", "check1")
+			event.Check.Handlers = tt.checkHandlers
+			event.Check.Annotations = tt.annotations
+
+			got := p.withDefaultHandlers(event)
+			assert.Equal(t, tt.want, got.Check.Handlers)
+			assert.Equal(t, tt.checkHandlers, event.Check.Handlers, "the event was mutated")
+		})
+	}
+}
@@ -13,7 +13,9 @@ import (
 	"github.com/sensu/sensu-go/backend/messaging"
 	"github.com/sensu/sensu-go/backend/pipeline"
 	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/cache"
 	metricspkg "github.com/sensu/sensu-go/metrics"
+	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
 const (
@@ -44,6 +46,8 @@ var (
 // handler configuration determines which Sensu filters and mutator
 // are used.
 type Pipelined struct {
+	ctx          context.Context
+	cancel       context.CancelFunc
 	stopping     chan struct{}
 	running      *atomic.Value
 	wg           *sync.WaitGroup
@@ -55,6 +59,8 @@ type Pipelined struct {
 	store        store.Store
 	storeTimeout time.Duration
 	adapters     []pipeline.Adapter
+	dedup        *deduplicator
+	namespaces   cache.Cache
 }
 
 // Config configures a Pipelined.
@@ -64,6 +70,16 @@ type Config struct {
 	Store        store.Store
 	StoreTimeout time.Duration
 	WorkerCount  int
+
+	// DedupWindow is the window within which the repeats of the status last
+	// handled for a check are coalesced into a single handled event. Disabled
+	// when zero.
+	DedupWindow time.Duration
+
+	// Client is used to watch the namespaces, whose default handlers are
+	// merged into the handlers of their events. Default handlers are not
+	// applied when nil.
+	Client *clientv3.Client
 }
 
 // Option is a functional option used to configure Pipelined.
@@ -91,7 +107,10 @@ func New(c Config, options ...Option) (*Pipelined, error) {
 		c.StoreTimeout = defaultStoreTimeout
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
 	p := &Pipelined{
+		ctx:          ctx,
+		cancel:       cancel,
 		bus:          c.Bus,
 		stopping:     make(chan struct{}, 1),
 		running:      &atomic.Value{},
@@ -102,6 +121,17 @@ func New(c Config, options ...Option) (*Pipelined, error) {
 		store:        c.Store,
 		storeTimeout: c.StoreTimeout,
 	}
+	if c.DedupWindow > 0 {
+		p.dedup = newDeduplicator(c.DedupWindow)
+	}
+	if c.Client != nil {
+		namespaces, err := cache.New(ctx, c.Client, &corev2.Namespace{}, false)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		p.namespaces = namespaces
+	}
 	for _, o := range options {
 		if err := o(p); err != nil {
 			return nil, err
@@ -124,6 +154,16 @@ func (p *Pipelined) Receiver() chan<- interface{} {
 	return p.eventChan
 }
 
+// QueueLen returns the number of events waiting to be handled.
+func (p *Pipelined) QueueLen() int {
+	return len(p.eventChan)
+}
+
+// QueueCap returns the maximum number of events that can wait to be handled.
+func (p *Pipelined) QueueCap() int {
+	return cap(p.eventChan)
+}
+
 // Start pipelined, subscribing to the "event" message bus topic to
 // pass Sensu events to the pipelines for handling (goroutines).
 func (p *Pipelined) Start() error {
@@ -143,6 +183,7 @@ func (p *Pipelined) Stop() error {
 	p.running.Store(false)
 	close(p.stopping)
 	p.wg.Wait()
+	p.cancel()
 	close(p.errChan)
 	err := p.subscription.Cancel()
 	close(p.eventChan)
@@ -177,7 +218,7 @@ func (p *Pipelined) createWorkers(count int, channel chan interface{}) {
 				case <-p.stopping:
 					return
 				case msg := <-channel:
-					if _, err := p.handleMessage(context.Background(), msg); err != nil {
+					if _, err := p.handleMessage(p.ctx, msg); err != nil {
 						if _, ok := err.(*store.ErrInternal); ok {
 							select {
 							case p.errChan <- err:
@@ -220,6 +261,17 @@ func (p *Pipelined) handleMessage(ctx context.Context, msg interface{}) (hadPipe
 	// Add a legacy pipeline "reference" if msg is a
 	// corev2.Event & has handlers.
 	if event, ok := msg.(*corev2.Event); ok {
+		if p.dedup != nil {
+			deduped := p.dedup.observe(event)
+			if deduped == nil {
+				logger.WithFields(fields).Debug("status already handled within the dedup window, skipping event")
+				return false, nil
+			}
+			msg = deduped
+			event = deduped
+		}
+		event = p.withDefaultHandlers(event)
+		msg = event
 		if event.HasHandlers() {
 			pipelineRefs = append(pipelineRefs, pipeline.LegacyPipelineReference())
 		} else {
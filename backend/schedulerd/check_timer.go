@@ -1,11 +1,8 @@
 package schedulerd
 
 import (
-	"crypto/md5"
-	"encoding/binary"
-
 	time "github.com/echlebek/timeproxy"
-	cron "github.com/robfig/cron/v3"
+	"github.com/sensu/sensu-go/util/schedule"
 )
 
 // A CheckTimer handles starting and stopping timers for a given check
@@ -33,10 +30,7 @@ type IntervalTimer struct {
 func NewIntervalTimer(name string, interval uint) *IntervalTimer {
 	// Calculate a check execution splay to ensure
 	// execution is consistent between process restarts.
-	sum := md5.Sum([]byte(name))
-	splay := binary.LittleEndian.Uint64(sum[:])
-
-	timer := &IntervalTimer{splay: splay}
+	timer := &IntervalTimer{splay: schedule.IntervalSplay(name)}
 	timer.SetDuration("", interval)
 	return timer
 }
@@ -79,10 +73,9 @@ func (timerPtr *IntervalTimer) Stop() {
 
 // Calculate the first execution time using splay & interval
 func (timerPtr *IntervalTimer) calcInitialOffset() time.Duration {
-	now := uint64(time.Now().UnixNano())
-	offset := (timerPtr.splay - now) % uint64(timerPtr.interval)
-	logger.WithField("offset", time.Duration(offset)/time.Second).Debug("initial offset for interval timer (in seconds)")
-	return time.Duration(offset) / time.Nanosecond
+	offset := schedule.NextIntervalTime(time.Now(), timerPtr.splay, timerPtr.interval)
+	logger.WithField("offset", offset/time.Second).Debug("initial offset for interval timer (in seconds)")
+	return offset
 }
 
 // A CronTimer handles starting and stopping timers for a given check
@@ -149,12 +142,5 @@ func (timerPtr *CronTimer) Stop() {
 // NextCronTime calculates how much time is between the current time and the
 // time indidcated by the cron string
 func NextCronTime(now time.Time, cronStr string) (time.Duration, error) {
-	schedule, err := cron.ParseStandard(cronStr)
-	if err != nil {
-		return 0, err
-	}
-	nextTime := schedule.Next(now)
-	diff := nextTime.Sub(now)
-
-	return diff, nil
+	return schedule.NextCronTime(now, cronStr)
 }
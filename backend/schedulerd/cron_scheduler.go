@@ -8,6 +8,7 @@ import (
 	"github.com/sensu/sensu-go/backend/secrets"
 	"github.com/sensu/sensu-go/backend/store"
 	cachev2 "github.com/sensu/sensu-go/backend/store/cache/v2"
+	"github.com/sensu/sensu-go/util/schedule"
 	"github.com/sirupsen/logrus"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
@@ -34,7 +35,7 @@ func NewCronScheduler(ctx context.Context, store store.Store, bus messaging.Mess
 		store:         store,
 		bus:           bus,
 		check:         check,
-		lastCronState: check.Cron,
+		lastCronState: schedule.CronSpec(check),
 		interrupt:     make(chan *corev2.CheckConfig),
 		logger: logger.WithFields(logrus.Fields{
 			"name":           check.Name,
@@ -74,7 +75,7 @@ func (s *CronScheduler) Start() {
 func (s *CronScheduler) start() {
 	defer s.stopWg.Done()
 	s.logger.Info("starting new cron scheduler")
-	timer := NewCronTimer(s.check.Name, s.check.Cron)
+	timer := NewCronTimer(s.check.Name, schedule.CronSpec(s.check))
 	executor := NewCheckExecutor(s.bus, s.check.Namespace, s.store, s.entityCache, s.secretsProviderManager)
 	timer.Start()
 
@@ -116,7 +117,7 @@ func (s *CronScheduler) Stop() error {
 func (s *CronScheduler) toggleSchedule() (stateChanged bool) {
 	defer s.setLastState()
 
-	if s.lastCronState != s.check.Cron {
+	if s.lastCronState != schedule.CronSpec(s.check) {
 		s.logger.Info("cron schedule has changed")
 		return true
 	}
@@ -126,11 +127,11 @@ func (s *CronScheduler) toggleSchedule() (stateChanged bool) {
 }
 
 func (s *CronScheduler) setLastState() {
-	s.lastCronState = s.check.Cron
+	s.lastCronState = schedule.CronSpec(s.check)
 }
 
 func (s *CronScheduler) resetTimer(timer *CronTimer) {
-	timer.SetDuration(s.check.Cron, 0)
+	timer.SetDuration(schedule.CronSpec(s.check), 0)
 	timer.Next()
 }
 
@@ -265,10 +265,11 @@ func (a *AdhocRequestExecutor) buildRequest(check *corev2.CheckConfig) (*corev2.
 }
 
 func publishProxyCheckRequests(e Executor, entities []*corev3.EntityConfig, check *corev2.CheckConfig) error {
+	batchSize := proxyBatchSize(check)
 	var splay time.Duration
 	if check.ProxyRequests.Splay {
 		var err error
-		if splay, err = calculateSplayInterval(check, len(entities)); err != nil {
+		if splay, err = calculateSplayInterval(check, proxyBatchCount(len(entities), batchSize)); err != nil {
 			return err
 		}
 	}
@@ -278,8 +279,10 @@ func publishProxyCheckRequests(e Executor, entities []*corev3.EntityConfig, chec
 		"namespace": check.Namespace,
 	}
 
-	for _, entity := range entities {
-		time.Sleep(splay)
+	for i, entity := range entities {
+		if i%batchSize == 0 {
+			time.Sleep(splay)
+		}
 		substitutedCheck, err := substituteProxyEntityTokens(entity, check)
 		if err != nil {
 			logger.WithFields(fields).WithError(err).Errorf("could not substitute tokens for proxy entity %q", entity.Metadata.Name)
@@ -331,10 +334,11 @@ func processRoundRobinCheck(ctx context.Context, executor *CheckExecutor, check
 }
 
 func publishRoundRobinProxyCheckRequests(executor *CheckExecutor, check *corev2.CheckConfig, proxyEntities []*corev3.EntityConfig, agentEntities []string) error {
+	batchSize := proxyBatchSize(check)
 	var splay time.Duration
 	if check.ProxyRequests.Splay {
 		var err error
-		if splay, err = calculateSplayInterval(check, len(proxyEntities)); err != nil {
+		if splay, err = calculateSplayInterval(check, proxyBatchCount(len(proxyEntities), batchSize)); err != nil {
 			return err
 		}
 	}
@@ -344,8 +348,13 @@ func publishRoundRobinProxyCheckRequests(executor *CheckExecutor, check *corev2.
 		"namespace": check.Namespace,
 	}
 
+	now := time.Now()
 	for i, proxyEntity := range proxyEntities {
-		now := time.Now()
+		if i > 0 && i%batchSize == 0 {
+			dreamtime := splay - time.Now().Sub(now)
+			time.Sleep(dreamtime)
+			now = time.Now()
+		}
 		agentEntity := agentEntities[i]
 		substitutedCheck, err := substituteProxyEntityTokens(proxyEntity, check)
 		if err != nil {
@@ -356,8 +365,6 @@ func publishRoundRobinProxyCheckRequests(executor *CheckExecutor, check *corev2.
 			logger.WithFields(fields).WithError(err).Errorf("could not send check request for proxy entity %q", proxyEntity.Metadata.Name)
 			continue
 		}
-		dreamtime := splay - time.Now().Sub(now)
-		time.Sleep(dreamtime)
 	}
 	return nil
 }
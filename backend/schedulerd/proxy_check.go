@@ -12,6 +12,7 @@ import (
 	"github.com/sensu/sensu-go/js"
 	"github.com/sensu/sensu-go/token"
 	"github.com/sensu/sensu-go/types/dynamic"
+	"github.com/sensu/sensu-go/util/schedule"
 )
 
 // matchEntities matches the provided list of entities to the entity attributes
@@ -71,21 +72,36 @@ func substituteProxyEntityTokens(entity *corev3.EntityConfig, check *corev2.Chec
 	return substitutedCheck, nil
 }
 
-// calculateSplayInterval calculates the duration between publishing proxy
-// requests to each individual entity (based on a configurable splay %)
-func calculateSplayInterval(check *corev2.CheckConfig, numEntities int) (time.Duration, error) {
+// proxyBatchSize returns the number of proxy check requests of the check to
+// publish at once.
+func proxyBatchSize(check *corev2.CheckConfig) int {
+	if size := int(check.ProxyRequests.BatchSize); size > 1 {
+		return size
+	}
+	return 1
+}
+
+// proxyBatchCount returns the number of batches needed to publish the proxy
+// check requests of numEntities entities.
+func proxyBatchCount(numEntities, batchSize int) int {
+	return (numEntities + batchSize - 1) / batchSize
+}
+
+// calculateSplayInterval calculates the duration between publishing batches
+// of proxy requests (based on a configurable splay %)
+func calculateSplayInterval(check *corev2.CheckConfig, numBatches int) (time.Duration, error) {
 	next := time.Second * time.Duration(check.Interval)
 	if check.Cron != "" {
-		schedule, err := cron.ParseStandard(check.Cron)
+		sched, err := cron.ParseStandard(schedule.CronSpec(check))
 		if err != nil {
 			return 0, err
 		}
 		now := time.Now()
-		then := schedule.Next(now)
+		then := sched.Next(now)
 		next = then.Sub(now)
 		if next < 5*time.Second {
 			now = time.Now().Add(next + time.Second)
-			then = schedule.Next(now)
+			then = sched.Next(now)
 			next = then.Sub(now)
 		}
 	}
@@ -93,7 +109,7 @@ func calculateSplayInterval(check *corev2.CheckConfig, numEntities int) (time.Du
 	if splayCoverage == 0 {
 		splayCoverage = corev2.DefaultSplayCoverage
 	}
-	timeSlice := splayCoverage / 100.0 / float64(numEntities)
+	timeSlice := splayCoverage / 100.0 / float64(numBatches)
 	splay := time.Duration(float64(next) * timeSlice)
 	return splay, nil
 }
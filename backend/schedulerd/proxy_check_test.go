@@ -140,6 +140,26 @@ func TestSplayCalculation(t *testing.T) {
 	assert.Nil(err)
 }
 
+func TestProxyBatches(t *testing.T) {
+	check := corev2.FixtureCheckConfig("check1")
+	check.ProxyRequests = corev2.FixtureProxyRequests(true)
+
+	assert.Equal(t, 1, proxyBatchSize(check))
+	assert.Equal(t, 7, proxyBatchCount(7, proxyBatchSize(check)))
+
+	check.ProxyRequests.BatchSize = 3
+	assert.Equal(t, 3, proxyBatchSize(check))
+	assert.Equal(t, 3, proxyBatchCount(7, 3))
+	assert.Equal(t, 2, proxyBatchCount(6, 3))
+
+	// 9s * 100% / 3 batches = 3
+	check.Interval = 9
+	check.ProxyRequests.SplayCoverage = 100
+	splay, err := calculateSplayInterval(check, proxyBatchCount(7, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, 3*time.Second, splay)
+}
+
 func TestSubstituteProxyEntityTokens(t *testing.T) {
 	assert := assert.New(t)
 
@@ -11,6 +11,7 @@ import (
 	"github.com/sensu/sensu-go/backend/secrets"
 	"github.com/sensu/sensu-go/backend/store"
 	cachev2 "github.com/sensu/sensu-go/backend/store/cache/v2"
+	"github.com/sensu/sensu-go/util/schedule"
 	"github.com/sirupsen/logrus"
 )
 
@@ -41,7 +42,7 @@ func NewRoundRobinCronScheduler(ctx context.Context, store store.Store, bus mess
 		store:         store,
 		bus:           bus,
 		check:         check,
-		lastCronState: check.Cron,
+		lastCronState: schedule.CronSpec(check),
 		interrupt:     make(chan *corev2.CheckConfig),
 		logger: logger.WithFields(logrus.Fields{
 			"name":           check.Name,
@@ -165,7 +166,7 @@ func (s *RoundRobinCronScheduler) updateRings() {
 			Name:             s.check.Name,
 			Items:            agentEntitiesRequest,
 			IntervalSchedule: int(s.check.Interval),
-			CronSchedule:     s.check.Cron,
+			CronSchedule:     schedule.CronSpec(s.check),
 		}
 		if err := sub.Validate(); err != nil {
 			s.logger.WithField("check", s.check.Name).WithError(err).Error("error scheduling round-robin check")
@@ -189,6 +190,11 @@ func (s *RoundRobinCronScheduler) schedule(executor *CheckExecutor, proxyEntitie
 
 	s.logger.Debug("check is not subdued")
 
+	if s.check.ProxyRequests != nil {
+		weights := agentWeights(s.entityCache.Get(s.check.Namespace), agentEntities)
+		agentEntities = weightAgentEntities(agentEntities, weights, len(proxyEntities))
+	}
+
 	if err := processRoundRobinCheck(s.ctx, executor, s.check, proxyEntities, agentEntities); err != nil {
 		logger.WithError(err).Error("error executing check")
 	}
@@ -198,7 +204,7 @@ func (s *RoundRobinCronScheduler) schedule(executor *CheckExecutor, proxyEntitie
 func (s *RoundRobinCronScheduler) toggleSchedule() (stateChanged bool) {
 	defer s.setLastState()
 
-	if s.lastCronState != s.check.Cron {
+	if s.lastCronState != schedule.CronSpec(s.check) {
 		s.logger.Debug("cron schedule has changed")
 		return true
 	}
@@ -212,7 +218,7 @@ func (s *RoundRobinCronScheduler) toggleSchedule() (stateChanged bool) {
 
 // Update the CronScheduler with the last schedule states
 func (s *RoundRobinCronScheduler) setLastState() {
-	s.lastCronState = s.check.Cron
+	s.lastCronState = schedule.CronSpec(s.check)
 	s.lastScheduler = s.check.Scheduler
 }
 
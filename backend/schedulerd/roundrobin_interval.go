@@ -12,6 +12,7 @@ import (
 	"github.com/sensu/sensu-go/backend/secrets"
 	"github.com/sensu/sensu-go/backend/store"
 	cachev2 "github.com/sensu/sensu-go/backend/store/cache/v2"
+	"github.com/sensu/sensu-go/util/schedule"
 	"github.com/sirupsen/logrus"
 )
 
@@ -105,7 +106,7 @@ func (s *RoundRobinIntervalScheduler) updateRings() {
 			Name:             s.check.Name,
 			Items:            agentEntitiesRequest,
 			IntervalSchedule: int(s.check.Interval),
-			CronSchedule:     s.check.Cron,
+			CronSchedule:     schedule.CronSpec(s.check),
 		}
 		if err := sub.Validate(); err != nil {
 			s.logger.WithError(err).Error("error scheduling round-robin check")
@@ -209,6 +210,11 @@ func (s *RoundRobinIntervalScheduler) schedule(executor *CheckExecutor, proxyEnt
 
 	s.logger.Debug("check is not subdued")
 
+	if s.check.ProxyRequests != nil {
+		weights := agentWeights(s.entityCache.Get(s.check.Namespace), agentEntities)
+		agentEntities = weightAgentEntities(agentEntities, weights, len(proxyEntities))
+	}
+
 	if err := processRoundRobinCheck(s.ctx, executor, s.check, proxyEntities, agentEntities); err != nil {
 		logger.WithError(err).Error("error executing check")
 	}
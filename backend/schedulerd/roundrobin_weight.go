@@ -0,0 +1,82 @@
+package schedulerd
+
+import (
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	cachev2 "github.com/sensu/sensu-go/backend/store/cache/v2"
+)
+
+// agentWeights returns the round-robin weights of the agent entities, found in
+// the cached entities of their namespace. The agents without a valid weight
+// are omitted, and get the default weight of 1.
+func agentWeights(entities []cachev2.Value, agentEntities []string) map[string]int {
+	agents := make(map[string]bool, len(agentEntities))
+	for _, agent := range agentEntities {
+		agents[agent] = true
+	}
+	weights := make(map[string]int)
+	for _, entity := range entities {
+		config, ok := entity.Resource.(*corev3.EntityConfig)
+		if !ok || config.Metadata == nil || !agents[config.Metadata.Name] {
+			continue
+		}
+		weight, err := corev2.RoundRobinWeight(config.Metadata.Annotations)
+		if err != nil {
+			logger.WithError(err).WithField("entity", config.Metadata.Name).Warn("ignoring invalid round-robin weight")
+			continue
+		}
+		weights[config.Metadata.Name] = weight
+	}
+	return weights
+}
+
+// weightAgentEntities returns the agent entities to which the n proxy check
+// requests of a round-robin check are sent. Each distinct agent returned by
+// the ring gets a share of the requests proportional to its weight, and the
+// requests of an agent are interleaved with the requests of the others. The
+// agent entities are returned unchanged when they all have the default weight.
+func weightAgentEntities(agentEntities []string, weights map[string]int, n int) []string {
+	var agents []string
+	seen := make(map[string]bool, len(agentEntities))
+	weighted := false
+	for _, agent := range agentEntities {
+		if seen[agent] {
+			continue
+		}
+		seen[agent] = true
+		agents = append(agents, agent)
+		if weight, ok := weights[agent]; ok && weight != 1 {
+			weighted = true
+		}
+	}
+	if !weighted {
+		return agentEntities
+	}
+
+	// smooth weighted round-robin, as implemented by nginx
+	total := 0
+	current := make([]int, len(agents))
+	for _, agent := range agents {
+		total += agentWeight(weights, agent)
+	}
+	result := make([]string, 0, n)
+	for len(result) < n {
+		best := 0
+		for i, agent := range agents {
+			current[i] += agentWeight(weights, agent)
+			if current[i] > current[best] {
+				best = i
+			}
+		}
+		current[best] -= total
+		result = append(result, agents[best])
+	}
+	return result
+}
+
+func agentWeight(weights map[string]int, agent string) int {
+	if weight, ok := weights[agent]; ok {
+		return weight
+	}
+	return 1
+}
@@ -0,0 +1,75 @@
+package schedulerd
+
+import (
+	"reflect"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	cachev2 "github.com/sensu/sensu-go/backend/store/cache/v2"
+)
+
+func TestWeightAgentEntities(t *testing.T) {
+	tests := []struct {
+		name    string
+		agents  []string
+		weights map[string]int
+		n       int
+		want    []string
+	}{
+		{
+			name:   "default weights",
+			agents: []string{"a", "b", "a", "b"},
+			n:      4,
+			want:   []string{"a", "b", "a", "b"},
+		},
+		{
+			name:    "weights of 1",
+			agents:  []string{"a", "b", "a"},
+			weights: map[string]int{"a": 1, "b": 1},
+			n:       3,
+			want:    []string{"a", "b", "a"},
+		},
+		{
+			name:    "proportional",
+			agents:  []string{"a", "b", "a", "b"},
+			weights: map[string]int{"a": 3},
+			n:       4,
+			want:    []string{"a", "a", "b", "a"},
+		},
+		{
+			name:    "interleaved",
+			agents:  []string{"a", "b", "c", "a", "b", "c", "a"},
+			weights: map[string]int{"a": 5, "b": 1, "c": 1},
+			n:       7,
+			want:    []string{"a", "a", "b", "a", "c", "a", "a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := weightAgentEntities(tt.agents, tt.weights, tt.n)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("weightAgentEntities() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAgentWeights(t *testing.T) {
+	heavy := corev3.FixtureEntityConfig("heavy")
+	heavy.Metadata.Annotations[corev2.RoundRobinWeightAnnotation] = "4"
+	invalid := corev3.FixtureEntityConfig("invalid")
+	invalid.Metadata.Annotations[corev2.RoundRobinWeightAnnotation] = "many"
+	other := corev3.FixtureEntityConfig("other")
+	other.Metadata.Annotations[corev2.RoundRobinWeightAnnotation] = "2"
+	entities := []cachev2.Value{
+		{Resource: heavy},
+		{Resource: invalid},
+		{Resource: other},
+	}
+
+	got := agentWeights(entities, []string{"heavy", "invalid"})
+	if want := map[string]int{"heavy": 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("agentWeights() = %v, want %v", got, want)
+	}
+}
@@ -93,6 +93,32 @@ func (m *ProviderManager) RemoveProvider(name string) error {
 	return nil
 }
 
+// ValidateSecrets verifies that the given Sensu secrets exist, and that the
+// providers they refer to are configured, without retrieving their values.
+// ErrSecretsNotSupported is returned if the secrets cannot be looked up.
+func (m *ProviderManager) ValidateSecrets(ctx context.Context, secrets []*corev2.Secret) error {
+	if len(secrets) == 0 {
+		return nil
+	}
+	if m.Getter == nil {
+		return ErrSecretsNotSupported
+	}
+	providers := m.Providers()
+	for _, secret := range secrets {
+		if secret == nil {
+			continue
+		}
+		providerName, _, err := m.Getter.Get(ctx, secret.Secret)
+		if err != nil {
+			return fmt.Errorf("invalid secret %q of %s: %s", secret.Secret, secret.Name, err)
+		}
+		if _, ok := providers[providerName]; !ok {
+			return fmt.Errorf("invalid secret %q of %s: %s", secret.Secret, secret.Name, ErrProviderNotFound(providerName))
+		}
+	}
+	return nil
+}
+
 // SubSecrets substitutes all secret tokens with the value of the secret.
 func (m *ProviderManager) SubSecrets(ctx context.Context, secrets []*corev2.Secret) ([]string, error) {
 	secretVars := make([]string, 0, len(secrets))
@@ -214,3 +214,36 @@ func TestSubSecrets(t *testing.T) {
 	require.Error(t, err)
 	require.Equal(t, []string{}, secretVars)
 }
+
+func TestValidateSecrets(t *testing.T) {
+	ctx := context.Background()
+
+	mg := &mockGetter{}
+	mg.On("Get", ctx, "sensu-foo").Return("env", "SENSU_FOO", nil)
+	mg.On("Get", ctx, "sensu-err").Return("", "", ErrSecretNotFound("sensu-err"))
+	mg.On("Get", ctx, "sensu-no-provider").Return("foo", "SENSU_NO_PROVIDER", nil)
+
+	pm := NewProviderManager(&mockEventReceiver{})
+
+	// no error without secrets, even if they are not supported
+	require.NoError(t, pm.ValidateSecrets(ctx, nil))
+	require.Equal(t, ErrSecretsNotSupported, pm.ValidateSecrets(ctx, []*corev2.Secret{{Name: "FOO", Secret: "sensu-foo"}}))
+
+	pm.Getter = mg
+	env := &mockProvider{}
+	env.On("GetObjectMeta", mock.Anything).Return(corev2.ObjectMeta{Name: "env"})
+	pm.AddProvider(env)
+
+	// the values of the secrets are not retrieved
+	require.NoError(t, pm.ValidateSecrets(ctx, []*corev2.Secret{{Name: "FOO", Secret: "sensu-foo"}}))
+	env.AssertNotCalled(t, "Get", mock.Anything)
+
+	// secrets must exist
+	require.Error(t, pm.ValidateSecrets(ctx, []*corev2.Secret{
+		{Name: "FOO", Secret: "sensu-foo"},
+		{Name: "ERR", Secret: "sensu-err"},
+	}))
+
+	// providers must be configured
+	require.Error(t, pm.ValidateSecrets(ctx, []*corev2.Secret{{Name: "NO_PROVIDER", Secret: "sensu-no-provider"}}))
+}
@@ -0,0 +1,143 @@
+package seeds
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	storev1 "github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/cli/resource"
+	"github.com/sensu/sensu-go/types"
+)
+
+// devSeedExtensions are the file extensions considered when seeding from a
+// directory.
+var devSeedExtensions = []string{".json", ".yaml", ".yml"}
+
+// SeedDevResources loads the YAML or JSON resources found at path, which can
+// either be a file or a directory, into the store. It is meant to be used in
+// dev mode only, so that demos and local tests start with a reproducible set
+// of resources. Resources without a namespace are created in the default
+// namespace, and the namespaces referenced by resources are created as needed.
+func SeedDevResources(ctx context.Context, store storev1.Store, path string) error {
+	logger := logger.WithField("component", "backend.seeds")
+
+	files, err := devSeedFiles(path)
+	if err != nil {
+		return err
+	}
+
+	var resources []*types.Wrapper
+	for _, file := range files {
+		parsed, err := parseDevSeedFile(file)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, parsed...)
+	}
+	if err := resource.Validate(resources, "default"); err != nil {
+		return err
+	}
+
+	// Namespaces need to exist before anything can be stored in them
+	sort.SliceStable(resources, func(i, j int) bool {
+		_, iok := resources[i].Value.(*corev2.Namespace)
+		_, jok := resources[j].Value.(*corev2.Namespace)
+		return iok && !jok
+	})
+
+	namespaces := map[string]struct{}{}
+	for _, w := range resources {
+		if err := seedDevResource(ctx, store, w.Value, namespaces); err != nil {
+			return fmt.Errorf("could not seed %s %q: %w", w.Type, w.ObjectMeta.Name, err)
+		}
+	}
+
+	logger.WithField("path", path).Infof("seeded %d resources", len(resources))
+	return nil
+}
+
+func seedDevResource(ctx context.Context, store storev1.Store, value interface{}, namespaces map[string]struct{}) error {
+	switch value := value.(type) {
+	case *corev2.Namespace:
+		return ensureNamespace(ctx, store, value.Name, namespaces)
+	case *corev2.Entity:
+		if err := ensureNamespace(ctx, store, value.Namespace, namespaces); err != nil {
+			return err
+		}
+		ctx := storev1.NamespaceContext(ctx, value.Namespace)
+		return store.UpdateEntity(ctx, value)
+	case corev2.Resource:
+		namespace := value.GetObjectMeta().Namespace
+		if namespace != "" {
+			if err := ensureNamespace(ctx, store, namespace, namespaces); err != nil {
+				return err
+			}
+		}
+		ctx := storev1.NamespaceContext(ctx, namespace)
+		return store.CreateOrUpdateResource(ctx, value)
+	default:
+		return fmt.Errorf("unsupported resource type %T", value)
+	}
+}
+
+// ensureNamespace creates the namespace unless it was already seeded.
+func ensureNamespace(ctx context.Context, store storev1.Store, name string, namespaces map[string]struct{}) error {
+	if _, ok := namespaces[name]; ok {
+		return nil
+	}
+	if err := store.CreateNamespace(ctx, &corev2.Namespace{Name: name}); err != nil {
+		if _, ok := err.(*storev1.ErrAlreadyExists); !ok {
+			return err
+		}
+	}
+	namespaces[name] = struct{}{}
+	return nil
+}
+
+// devSeedFiles returns the files to seed from path. When path is a directory,
+// the JSON and YAML files it directly contains are returned in lexical order.
+func devSeedFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		for _, e := range devSeedExtensions {
+			if ext == e {
+				files = append(files, filepath.Join(path, entry.Name()))
+				break
+			}
+		}
+	}
+	return files, nil
+}
+
+func parseDevSeedFile(path string) ([]*types.Wrapper, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	resources, err := resource.Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return resources, nil
+}
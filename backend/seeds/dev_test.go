@@ -0,0 +1,76 @@
+package seeds
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/etcd/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const devSeedYAML = `type: CheckConfig
+api_version: core/v2
+metadata:
+  name: check-cpu
+  namespace: demo
+spec:
+  command: check-cpu.sh
+  interval: 60
+  publish: true
+  subscriptions:
+  - linux
+---
+type: Namespace
+api_version: core/v2
+metadata: {}
+spec:
+  name: demo
+`
+
+const devSeedJSON = `{
+  "type": "Entity",
+  "api_version": "core/v2",
+  "metadata": {"name": "proxy-entity"},
+  "spec": {"entity_class": "proxy"}
+}`
+
+func TestSeedDevResources(t *testing.T) {
+	ctx := context.Background()
+	st, err := testutil.NewStoreInstance()
+	require.NoError(t, err)
+	defer st.Teardown()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "checks.yml"), []byte(devSeedYAML), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "entities.json"), []byte(devSeedJSON), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a resource"), 0644))
+
+	require.NoError(t, SeedDevResources(ctx, st, dir))
+
+	ns, err := st.GetNamespace(ctx, "demo")
+	require.NoError(t, err)
+	assert.NotNil(t, ns)
+
+	check, err := st.GetCheckConfigByName(store.NamespaceContext(ctx, "demo"), "check-cpu")
+	require.NoError(t, err)
+	require.NotNil(t, check)
+	assert.Equal(t, "check-cpu.sh", check.Command)
+
+	entity, err := st.GetEntityByName(store.NamespaceContext(ctx, "default"), "proxy-entity")
+	require.NoError(t, err)
+	require.NotNil(t, entity)
+	assert.Equal(t, corev2.EntityProxyClass, entity.EntityClass)
+
+	// seeding is idempotent
+	require.NoError(t, SeedDevResources(ctx, st, filepath.Join(dir, "checks.yml")))
+}
+
+func TestSeedDevResourcesMissingPath(t *testing.T) {
+	err := SeedDevResources(context.Background(), nil, filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
@@ -148,6 +148,40 @@ func SeedInitialDataWithContext(ctx context.Context, store storev1.Store) (err e
 	return SeedCluster(ctx, store, nil, config)
 }
 
+// EnsureCluster creates the default namespace, the cluster-admin ClusterRole
+// and ClusterRoleBinding, and the admin user, when they are missing from an
+// initialized cluster. Existing resources are left untouched, so that it can
+// be run repeatedly.
+func EnsureCluster(ctx context.Context, store storev1.Store, config Config) error {
+	logger := logger.WithField("component", "backend.seeds")
+
+	steps := []struct {
+		name   string
+		create func() error
+	}{
+		{"default namespace", func() error { return setupDefaultNamespace(ctx, store) }},
+		{"cluster-admin ClusterRole", func() error { return store.CreateClusterRole(ctx, clusterAdminClusterRole()) }},
+		{"cluster-admin ClusterRoleBinding", func() error {
+			return store.CreateClusterRoleBinding(ctx, clusterAdminClusterRoleBinding())
+		}},
+		{"admin user", func() error {
+			return setupAdminUser(ctx, store, config.AdminUsername, config.AdminPassword, config.AdminAPIKey)
+		}},
+	}
+	for _, step := range steps {
+		err := step.create()
+		if _, ok := err.(*storev1.ErrAlreadyExists); ok {
+			logger.Debugf("%s already exists", step.name)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("could not ensure the %s exists: %w", step.name, err)
+		}
+		logger.Infof("created the missing %s", step.name)
+	}
+	return nil
+}
+
 func setupDefaultNamespace(ctx context.Context, store storev1.Store) error {
 	return store.CreateNamespace(
 		ctx,
@@ -156,10 +190,11 @@ func setupDefaultNamespace(ctx context.Context, store storev1.Store) error {
 		})
 }
 
-func setupClusterRoleBindings(ctx context.Context, store storev1.Store) error {
-	// The cluster-admin ClusterRoleBinding grants permission found in the
-	// cluster-admin ClusterRole to any user belonging to the cluster-admins group
-	clusterAdmin := &types.ClusterRoleBinding{
+// clusterAdminClusterRoleBinding returns the cluster-admin ClusterRoleBinding,
+// which grants permission found in the cluster-admin ClusterRole to any user
+// belonging to the cluster-admins group
+func clusterAdminClusterRoleBinding() *types.ClusterRoleBinding {
+	return &types.ClusterRoleBinding{
 		ObjectMeta: corev2.NewObjectMeta("cluster-admin", ""),
 		RoleRef: types.RoleRef{
 			Type: "ClusterRole",
@@ -172,7 +207,10 @@ func setupClusterRoleBindings(ctx context.Context, store storev1.Store) error {
 			},
 		},
 	}
-	if err := store.CreateClusterRoleBinding(ctx, clusterAdmin); err != nil {
+}
+
+func setupClusterRoleBindings(ctx context.Context, store storev1.Store) error {
+	if err := store.CreateClusterRoleBinding(ctx, clusterAdminClusterRoleBinding()); err != nil {
 		return err
 	}
 
@@ -213,13 +251,13 @@ func setupClusterRoleBindings(ctx context.Context, store storev1.Store) error {
 	return store.CreateClusterRoleBinding(ctx, systemUser)
 }
 
-func setupClusterRoles(ctx context.Context, store storev1.Store) error {
-	// The cluster-admin ClusterRole gives access to perform any action on any
-	// resource. When used in a ClusterRoleBinding, it gives full control over
-	// every resource in the cluster and in all namespaces. When used in a
-	// RoleBinding, it gives full control over every resource in the rolebinding's
-	// namespace, including the namespace itself
-	clusterAdmin := &types.ClusterRole{
+// clusterAdminClusterRole returns the cluster-admin ClusterRole, which gives
+// access to perform any action on any resource. When used in a
+// ClusterRoleBinding, it gives full control over every resource in the cluster
+// and in all namespaces. When used in a RoleBinding, it gives full control over
+// every resource in the rolebinding's namespace, including the namespace itself
+func clusterAdminClusterRole() *types.ClusterRole {
+	return &types.ClusterRole{
 		ObjectMeta: corev2.NewObjectMeta("cluster-admin", ""),
 		Rules: []types.Rule{
 			types.Rule{
@@ -228,7 +266,10 @@ func setupClusterRoles(ctx context.Context, store storev1.Store) error {
 			},
 		},
 	}
-	if err := store.CreateClusterRole(ctx, clusterAdmin); err != nil {
+}
+
+func setupClusterRoles(ctx context.Context, store storev1.Store) error {
+	if err := store.CreateClusterRole(ctx, clusterAdminClusterRole()); err != nil {
 		return err
 	}
 
@@ -38,3 +38,34 @@ func TestSeedInitialDataWithContext(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEmpty(t, defaultNamespace, "default namespace should be present after seed process")
 }
+
+func TestEnsureCluster(t *testing.T) {
+	ctx := context.Background()
+	st, serr := testutil.NewStoreInstance()
+	if serr != nil {
+		assert.FailNow(t, serr.Error())
+	}
+	defer st.Teardown()
+
+	config := Config{
+		AdminUsername: "admin",
+		AdminPassword: "P@ssw0rd!",
+	}
+	require.NoError(t, SeedCluster(ctx, st, nil, config))
+	require.Equal(t, ErrAlreadyInitialized, SeedCluster(ctx, st, nil, config))
+
+	require.NoError(t, st.DeleteNamespace(ctx, "default"))
+	require.NoError(t, st.DeleteClusterRoleBinding(ctx, "cluster-admin"))
+
+	// EnsureCluster must be safe to run repeatedly
+	require.NoError(t, EnsureCluster(ctx, st, config))
+	require.NoError(t, EnsureCluster(ctx, st, config))
+
+	defaultNamespace, err := st.GetNamespace(ctx, "default")
+	require.NoError(t, err)
+	assert.NotEmpty(t, defaultNamespace, "default namespace should be recreated")
+
+	binding, err := st.GetClusterRoleBinding(ctx, "cluster-admin")
+	require.NoError(t, err)
+	assert.NotEmpty(t, binding, "cluster-admin binding should be recreated")
+}
@@ -0,0 +1,107 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// stateDirPerm is the permission required on the state directory and its
+// subdirectories: the backend must be able to list, read and write them.
+const stateDirPerm fs.FileMode = 0700
+
+// etcdStateSubdirs are the directories the embedded etcd expects under the
+// state directory.
+var etcdStateSubdirs = []string{
+	"etcd",
+	filepath.Join("etcd", "data"),
+	filepath.Join("etcd", "wal"),
+}
+
+// PrepareStateDir verifies the layout and the permissions of the state
+// directory before the backend starts. It creates the missing directories,
+// grants the owner the permissions it lacks and migrates the known layout
+// changes, logging every action taken. The etcd directories are only prepared
+// when the backend runs an embedded etcd. Errors name the offending path.
+func PrepareStateDir(dir string, embeddedEtcd bool) error {
+	if err := prepareDir(dir); err != nil {
+		return err
+	}
+	if !embeddedEtcd {
+		return nil
+	}
+	for _, subdir := range etcdStateSubdirs {
+		if err := prepareDir(filepath.Join(dir, subdir)); err != nil {
+			return err
+		}
+	}
+	return migrateEtcdWAL(dir)
+}
+
+// prepareDir creates dir if it is missing, and otherwise makes sure that it is
+// a directory owned by the current user with the permissions it requires.
+func prepareDir(dir string) error {
+	info, err := os.Stat(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		if err := os.MkdirAll(dir, stateDirPerm); err != nil {
+			return fmt.Errorf("could not create state directory %q: %s", dir, err)
+		}
+		logger.WithField("path", dir).Info("created missing state directory")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not access state directory %q: %s", dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("state directory %q is not a directory", dir)
+	}
+	if err := checkOwner(dir, info); err != nil {
+		return err
+	}
+	if info.Mode().Perm()&stateDirPerm != stateDirPerm {
+		mode := info.Mode().Perm() | stateDirPerm
+		if err := os.Chmod(dir, mode); err != nil {
+			return fmt.Errorf("state directory %q requires %s permissions, but has %s: %s", dir, stateDirPerm, info.Mode().Perm(), err)
+		}
+		logger.WithField("path", dir).Infof("changed state directory permissions from %s to %s", info.Mode().Perm(), mode)
+	}
+	return nil
+}
+
+// migrateEtcdWAL moves the write-ahead log that etcd keeps under its data
+// directory when no dedicated WAL directory is configured, as in older
+// releases, to the dedicated WAL directory. etcd would otherwise ignore it and
+// start over with an empty log.
+func migrateEtcdWAL(dir string) error {
+	oldWAL := filepath.Join(dir, "etcd", "data", "member", "wal")
+	newWAL := filepath.Join(dir, "etcd", "wal")
+
+	oldEntries, err := os.ReadDir(oldWAL)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && len(oldEntries) == 0) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not read etcd WAL directory %q: %s", oldWAL, err)
+	}
+	newEntries, err := os.ReadDir(newWAL)
+	if err != nil {
+		return fmt.Errorf("could not read etcd WAL directory %q: %s", newWAL, err)
+	}
+	if len(newEntries) > 0 {
+		return fmt.Errorf("found etcd WAL files in both %q and %q, remove the stale one", oldWAL, newWAL)
+	}
+	for _, entry := range oldEntries {
+		from := filepath.Join(oldWAL, entry.Name())
+		to := filepath.Join(newWAL, entry.Name())
+		if err := os.Rename(from, to); err != nil {
+			return fmt.Errorf("could not move etcd WAL file %q to %q: %s", from, to, err)
+		}
+	}
+	if err := os.Remove(oldWAL); err != nil {
+		return fmt.Errorf("could not remove etcd WAL directory %q: %s", oldWAL, err)
+	}
+	logger.WithField("path", newWAL).Infof("migrated etcd WAL from %s", oldWAL)
+	return nil
+}
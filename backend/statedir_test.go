@@ -0,0 +1,77 @@
+//go:build !windows
+// +build !windows
+
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareStateDirCreatesSubdirs(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "state")
+	require.NoError(t, PrepareStateDir(dir, true))
+	for _, subdir := range etcdStateSubdirs {
+		info, err := os.Stat(filepath.Join(dir, subdir))
+		require.NoError(t, err)
+		assert.True(t, info.IsDir())
+	}
+}
+
+func TestPrepareStateDirFixesPermissions(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Chmod(dir, 0500))
+	require.NoError(t, PrepareStateDir(dir, true))
+	info, err := os.Stat(dir)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+}
+
+func TestPrepareStateDirNotADirectory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "etcd"), nil, 0600))
+	err := PrepareStateDir(dir, true)
+	assert.EqualError(t, err, `state directory "`+filepath.Join(dir, "etcd")+`" is not a directory`)
+}
+
+func TestPrepareStateDirMigratesEtcdWAL(t *testing.T) {
+	dir := t.TempDir()
+	oldWAL := filepath.Join(dir, "etcd", "data", "member", "wal")
+	require.NoError(t, os.MkdirAll(oldWAL, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(oldWAL, "0.wal"), []byte("wal"), 0600))
+
+	require.NoError(t, PrepareStateDir(dir, true))
+
+	_, err := os.Stat(oldWAL)
+	assert.True(t, os.IsNotExist(err))
+	b, err := os.ReadFile(filepath.Join(dir, "etcd", "wal", "0.wal"))
+	require.NoError(t, err)
+	assert.Equal(t, "wal", string(b))
+}
+
+func TestPrepareStateDirExternalEtcd(t *testing.T) {
+	dir := t.TempDir()
+	oldWAL := filepath.Join(dir, "etcd", "data", "member", "wal")
+	require.NoError(t, os.MkdirAll(oldWAL, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(oldWAL, "0.wal"), []byte("wal"), 0600))
+
+	require.NoError(t, PrepareStateDir(dir, false))
+
+	_, err := os.Stat(filepath.Join(oldWAL, "0.wal"))
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dir, "etcd", "wal"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestPrepareStateDirConflictingEtcdWAL(t *testing.T) {
+	dir := t.TempDir()
+	for _, wal := range []string{filepath.Join(dir, "etcd", "data", "member", "wal"), filepath.Join(dir, "etcd", "wal")} {
+		require.NoError(t, os.MkdirAll(wal, 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(wal, "0.wal"), nil, 0600))
+	}
+	assert.Error(t, PrepareStateDir(dir, true))
+}
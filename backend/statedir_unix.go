@@ -0,0 +1,25 @@
+//go:build !windows
+// +build !windows
+
+package backend
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+// checkOwner returns an error if dir is not owned by the user running the
+// backend, since it could not fix the permissions of the directory.
+func checkOwner(dir string, info fs.FileInfo) error {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+	uid := os.Geteuid()
+	if int(stat.Uid) != uid {
+		return fmt.Errorf("state directory %q is owned by uid %d, but sensu-backend runs as uid %d: it requires ownership and %s permissions", dir, stat.Uid, uid, stateDirPerm)
+	}
+	return nil
+}
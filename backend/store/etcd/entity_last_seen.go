@@ -0,0 +1,312 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/etcd/kvc"
+	storev2 "github.com/sensu/sensu-go/backend/store/v2"
+	"github.com/sensu/sensu-go/backend/store/v2/etcdstore"
+	"github.com/sensu/sensu-go/backend/store/v2/wrap"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// The stale entities are found with an index of the last seen times of the
+// entities, made of two keys per entity:
+//
+//	/sensu.io/entity_last_seen/<namespace>/<entity> holds the last seen time
+//	/sensu.io/entity_last_seen_index/<namespace>/<last seen>/<entity> is empty
+//
+// The last seen times of the index keys are zero-padded so that the keys sort
+// by time, and the entities last seen before a given time are read with a
+// single range request. The index is only a hint: the entity states are always
+// read to confirm that an entity is stale, and the entries that no longer
+// match their entity state are fixed when they are found.
+const (
+	entityLastSeenPathPrefix      = "entity_last_seen"
+	entityLastSeenIndexPathPrefix = "entity_last_seen_index"
+
+	// entityLastSeenIndexedPathPrefix holds a key for each namespace whose
+	// existing entities were added to the index.
+	entityLastSeenIndexedPathPrefix = "entity_last_seen_indexed"
+
+	// staleEntityPageSize is the number of index entries read at once when
+	// looking for stale entities
+	staleEntityPageSize = 500
+)
+
+var (
+	entityLastSeenKeyBuilder        = store.NewKeyBuilder(entityLastSeenPathPrefix)
+	entityLastSeenIndexKeyBuilder   = store.NewKeyBuilder(entityLastSeenIndexPathPrefix)
+	entityLastSeenIndexedKeyBuilder = store.NewKeyBuilder(entityLastSeenIndexedPathPrefix)
+)
+
+func formatLastSeen(lastSeen int64) string {
+	return fmt.Sprintf("%020d", lastSeen)
+}
+
+func entityLastSeenIndexKey(namespace, lastSeen, name string) string {
+	return entityLastSeenIndexKeyBuilder.WithNamespace(namespace).Build(lastSeen, name)
+}
+
+// UpdateEntityLastSeen sets the last seen time of an entity in the index used
+// to find the stale entities. The namespace is specified as part of the
+// context.
+func (s *Store) UpdateEntityLastSeen(ctx context.Context, name string, lastSeen int64) error {
+	if name == "" {
+		return &store.ErrNotValid{Err: errors.New("must specify name")}
+	}
+	if lastSeen <= 0 {
+		return nil
+	}
+	namespace := corev2.ContextNamespace(ctx)
+	key := entityLastSeenKeyBuilder.WithNamespace(namespace).Build(name)
+	value := formatLastSeen(lastSeen)
+
+	var resp *clientv3.GetResponse
+	err := kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
+		resp, err = s.client.Get(ctx, key, clientv3.WithLimit(1))
+		return kvc.RetryRequest(n, err)
+	})
+	if err != nil {
+		return err
+	}
+
+	cmp := clientv3.Compare(clientv3.CreateRevision(key), "=", 0)
+	ops := []clientv3.Op{
+		clientv3.OpPut(key, value),
+		clientv3.OpPut(entityLastSeenIndexKey(namespace, value, name), ""),
+	}
+	if len(resp.Kvs) > 0 {
+		previous := string(resp.Kvs[0].Value)
+		if previous == value {
+			return nil
+		}
+		cmp = clientv3.Compare(clientv3.ModRevision(key), "=", resp.Kvs[0].ModRevision)
+		ops = append(ops, clientv3.OpDelete(entityLastSeenIndexKey(namespace, previous, name)))
+	}
+
+	// When the comparison fails, the last seen time was concurrently updated
+	// and that update is kept; an outdated entry is fixed by ListStaleEntities.
+	return kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
+		_, err = s.client.Txn(ctx).If(cmp).Then(ops...).Commit()
+		return kvc.RetryRequest(n, err)
+	})
+}
+
+// ListStaleEntities returns the entities of the namespace that were last seen
+// before the given unix timestamp. The candidates are read from the last seen
+// index, then their states and configs are read in transactions of up to
+// maxTxnOps reads.
+func (s *Store) ListStaleEntities(ctx context.Context, lastSeenBefore int64) ([]*corev2.Entity, error) {
+	namespace := corev2.ContextNamespace(ctx)
+	if namespace == "" {
+		return nil, &store.ErrNotValid{Err: errors.New("must specify namespace")}
+	}
+	if err := s.indexEntitiesLastSeen(ctx, namespace); err != nil {
+		return nil, err
+	}
+
+	builder := entityLastSeenIndexKeyBuilder.WithNamespace(namespace)
+	prefix := builder.Build("")
+	end := builder.Build(formatLastSeen(lastSeenBefore))
+	var entities []*corev2.Entity
+	found := make(map[string]bool)
+	for from := prefix; ; {
+		var resp *clientv3.GetResponse
+		err := kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
+			resp, err = s.client.Get(ctx, from,
+				clientv3.WithRange(end),
+				clientv3.WithLimit(staleEntityPageSize),
+				clientv3.WithKeysOnly(),
+			)
+			return kvc.RetryRequest(n, err)
+		})
+		if err != nil {
+			return nil, err
+		}
+		var (
+			names     []string
+			lastSeens []string
+		)
+		for _, kv := range resp.Kvs {
+			parts := strings.SplitN(strings.TrimPrefix(string(kv.Key), prefix), "/", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			lastSeens = append(lastSeens, parts[0])
+			names = append(names, parts[1])
+		}
+		configs, states, err := s.getEntityConfigsAndStates(ctx, namespace, names)
+		if err != nil {
+			return nil, err
+		}
+		for i, name := range names {
+			state := states[i]
+			if state == nil || formatLastSeen(state.LastSeen) != lastSeens[i] {
+				if err := s.fixEntityLastSeen(ctx, name, lastSeens[i], state); err != nil {
+					return nil, err
+				}
+			}
+			// the fixed entry of an entity can be found again later in the
+			// scan
+			if state == nil || configs[i] == nil || found[name] {
+				continue
+			}
+			if state.LastSeen == 0 || state.LastSeen >= lastSeenBefore {
+				continue
+			}
+			found[name] = true
+			entity, err := corev3.V3EntityToV2(configs[i], state)
+			if err != nil {
+				return nil, &store.ErrNotValid{Err: err}
+			}
+			entities = append(entities, entity)
+		}
+		if !resp.More || len(resp.Kvs) == 0 {
+			return entities, nil
+		}
+		from = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+	}
+}
+
+// fixEntityLastSeen removes an index entry that does not match the state of
+// its entity, and indexes the last seen time of the state instead. The namespace
+// is specified as part of the context.
+func (s *Store) fixEntityLastSeen(ctx context.Context, name, lastSeen string, state *corev3.EntityState) error {
+	namespace := corev2.ContextNamespace(ctx)
+	key := entityLastSeenKeyBuilder.WithNamespace(namespace).Build(name)
+	indexKey := entityLastSeenIndexKey(namespace, lastSeen, name)
+	err := kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
+		_, err = s.client.Txn(ctx).If(
+			clientv3.Compare(clientv3.Value(key), "=", lastSeen),
+		).Then(
+			clientv3.OpDelete(indexKey),
+			clientv3.OpDelete(key),
+		).Else(
+			clientv3.OpDelete(indexKey),
+		).Commit()
+		return kvc.RetryRequest(n, err)
+	})
+	if err != nil || state == nil {
+		return err
+	}
+	return s.UpdateEntityLastSeen(ctx, name, state.LastSeen)
+}
+
+// indexEntitiesLastSeen adds the entities of the namespace that are missing
+// from the last seen index, such as the entities last seen before an upgrade.
+// The entity states are only scanned once per namespace.
+func (s *Store) indexEntitiesLastSeen(ctx context.Context, namespace string) error {
+	marker := entityLastSeenIndexedKeyBuilder.Build(namespace)
+	var resp *clientv3.GetResponse
+	err := kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
+		resp, err = s.client.Get(ctx, marker, clientv3.WithCountOnly())
+		return kvc.RetryRequest(n, err)
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Count > 0 {
+		return nil
+	}
+
+	v2store := etcdstore.NewStore(s.client)
+	stateReq := storev2.ResourceRequest{
+		Namespace: namespace,
+		Context:   ctx,
+		StoreName: new(corev3.EntityState).StoreName(),
+	}
+	pred := &store.SelectionPredicate{Limit: staleEntityPageSize}
+	for {
+		list, err := v2store.List(stateReq, pred)
+		if err != nil {
+			return err
+		}
+		states := make([]corev3.EntityState, list.Len())
+		if err := list.UnwrapInto(&states); err != nil {
+			return &store.ErrDecode{Err: err, Key: etcdstore.StoreKey(stateReq)}
+		}
+		for i := range states {
+			if states[i].LastSeen == 0 {
+				continue
+			}
+			if err := s.UpdateEntityLastSeen(ctx, states[i].Metadata.Name, states[i].LastSeen); err != nil {
+				return err
+			}
+		}
+		if pred.Continue == "" {
+			break
+		}
+	}
+
+	return kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
+		_, err = s.client.Put(ctx, marker, "")
+		return kvc.RetryRequest(n, err)
+	})
+}
+
+// getEntityConfigsAndStates returns the configs and the states of the named
+// entities, read in batches of maxTxnOps. The config or the state of an entity
+// that has none is nil.
+func (s *Store) getEntityConfigsAndStates(ctx context.Context, namespace string, names []string) ([]*corev3.EntityConfig, []*corev3.EntityState, error) {
+	configs := make([]*corev3.EntityConfig, len(names))
+	states := make([]*corev3.EntityState, len(names))
+	batchSize := maxTxnOps / 2
+	for start := 0; start < len(names); start += batchSize {
+		end := start + batchSize
+		if end > len(names) {
+			end = len(names)
+		}
+		keys := make([]string, 0, 2*(end-start))
+		ops := make([]clientv3.Op, 0, 2*(end-start))
+		for _, name := range names[start:end] {
+			meta := &corev2.ObjectMeta{Name: name, Namespace: namespace}
+			configKey := etcdstore.StoreKey(storev2.NewResourceRequestFromResource(ctx, &corev3.EntityConfig{Metadata: meta}))
+			stateKey := etcdstore.StoreKey(storev2.NewResourceRequestFromResource(ctx, &corev3.EntityState{Metadata: meta}))
+			keys = append(keys, configKey, stateKey)
+			ops = append(ops,
+				clientv3.OpGet(configKey, clientv3.WithLimit(1)),
+				clientv3.OpGet(stateKey, clientv3.WithLimit(1)),
+			)
+		}
+		var resp *clientv3.TxnResponse
+		err := kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
+			resp, err = s.client.Txn(ctx).Then(ops...).Commit()
+			return kvc.RetryRequest(n, err)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		for i, r := range resp.Responses {
+			kvs := r.GetResponseRange().Kvs
+			if len(kvs) == 0 {
+				continue
+			}
+			var wrapper wrap.Wrapper
+			if err := proto.Unmarshal(kvs[0].Value, &wrapper); err != nil {
+				return nil, nil, &store.ErrDecode{Err: err, Key: keys[i]}
+			}
+			if i%2 == 0 {
+				var config corev3.EntityConfig
+				if err := wrapper.UnwrapInto(&config); err != nil {
+					return nil, nil, &store.ErrDecode{Err: err, Key: keys[i]}
+				}
+				configs[start+i/2] = &config
+			} else {
+				var state corev3.EntityState
+				if err := wrapper.UnwrapInto(&state); err != nil {
+					return nil, nil, &store.ErrDecode{Err: err, Key: keys[i]}
+				}
+				states[start+i/2] = &state
+			}
+		}
+	}
+	return configs, states, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strings"
 
 	"github.com/gogo/protobuf/proto"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
@@ -65,6 +66,7 @@ func (s *Store) DeleteEntity(ctx context.Context, e *corev2.Entity) error {
 	ops := []clientv3.Op{
 		clientv3.OpDelete(stateKey),
 		clientv3.OpDelete(configKey),
+		clientv3.OpDelete(entityLastSeenKeyBuilder.WithResource(e).Build(e.Name)),
 	}
 
 	return kvc.Txn(ctx, s.client, comparator, ops...)
@@ -100,11 +102,23 @@ func (s *Store) DeleteEntityByName(ctx context.Context, name string) error {
 	ops := []clientv3.Op{
 		clientv3.OpDelete(stateKey),
 		clientv3.OpDelete(configKey),
+		clientv3.OpDelete(entityLastSeenKeyBuilder.WithContext(ctx).Build(name)),
 	}
 
 	return kvc.Txn(ctx, s.client, comparator, ops...)
 }
 
+// CountEntities counts the entity configs in the namespace. The
+// SelectionPredicate is not supported.
+func (s *Store) CountEntities(ctx context.Context, _ *store.SelectionPredicate) (int64, error) {
+	key := GetEntityConfigsPath(ctx, "")
+	if !strings.HasSuffix(key, "/") {
+		key += "/"
+	}
+
+	return Count(ctx, s.client, key)
+}
+
 // GetEntityByName gets an Entity by its name.
 func (s *Store) GetEntityByName(ctx context.Context, name string) (*corev2.Entity, error) {
 	if name == "" {
@@ -211,9 +225,23 @@ func (s *Store) GetEntities(ctx context.Context, pred *store.SelectionPredicate)
 			configReq.SortOrder = dir
 		}
 	}
-	stateList, err := v2store.List(stateReq, statePred)
-	if err != nil {
-		return nil, err
+	var fields []string
+	if pred != nil {
+		fields = pred.Fields
+	}
+	// The states hold the system facts of the entities, their largest part;
+	// they are neither read nor decoded when none of their fields is wanted.
+	withState := len(fields) == 0 || projectionNeedsState(fields)
+	var states []corev3.EntityState
+	if withState {
+		stateList, err := v2store.List(stateReq, statePred)
+		if err != nil {
+			return nil, err
+		}
+		states = make([]corev3.EntityState, stateList.Len())
+		if err := stateList.UnwrapInto(&states); err != nil {
+			return nil, &store.ErrDecode{Err: err, Key: etcdstore.StoreKey(stateReq)}
+		}
 	}
 	configList, err := v2store.List(configReq, configPred)
 	if err != nil {
@@ -230,15 +258,64 @@ func (s *Store) GetEntities(ctx context.Context, pred *store.SelectionPredicate)
 		}
 		pred.Continue = cont
 	}
-	states := make([]corev3.EntityState, stateList.Len())
-	if err := stateList.UnwrapInto(&states); err != nil {
-		return nil, &store.ErrDecode{Err: err, Key: etcdstore.StoreKey(stateReq)}
-	}
 	configs := make([]corev3.EntityConfig, configList.Len())
 	if err := configList.UnwrapInto(&configs); err != nil {
 		return nil, &store.ErrDecode{Err: err, Key: etcdstore.StoreKey(configReq)}
 	}
-	return entitiesFromConfigAndState(configs, states)
+	entities, err := entitiesFromConfigAndState(configs, states)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) > 0 {
+		for i := range entities {
+			entities[i] = projectEntity(entities[i], fields)
+		}
+	}
+	return entities, nil
+}
+
+// projectionNeedsState returns true if any of the fields is held by the entity
+// states rather than the entity configs.
+func projectionNeedsState(fields []string) bool {
+	for _, field := range fields {
+		switch field {
+		case "system", "last_seen", "sensu_agent_version":
+			return true
+		}
+	}
+	return false
+}
+
+// projectEntity returns a copy of the entity with only the given fields set.
+// Fields are referred to by their JSON name; the metadata is always kept so
+// that the entity can still be identified.
+func projectEntity(entity *corev2.Entity, fields []string) *corev2.Entity {
+	result := &corev2.Entity{ObjectMeta: entity.ObjectMeta}
+	for _, field := range fields {
+		switch field {
+		case "entity_class":
+			result.EntityClass = entity.EntityClass
+		case "system":
+			result.System = entity.System
+		case "subscriptions":
+			result.Subscriptions = entity.Subscriptions
+		case "last_seen":
+			result.LastSeen = entity.LastSeen
+		case "deregister":
+			result.Deregister = entity.Deregister
+		case "deregistration":
+			result.Deregistration = entity.Deregistration
+		case "user":
+			result.User = entity.User
+		case "redact":
+			result.Redact = entity.Redact
+		case "sensu_agent_version":
+			result.SensuAgentVersion = entity.SensuAgentVersion
+		case "keepalive_handlers":
+			result.KeepaliveHandlers = entity.KeepaliveHandlers
+		}
+	}
+	return result
 }
 
 func entitiesFromConfigAndState(configs []corev3.EntityConfig, states []corev3.EntityState) ([]*corev2.Entity, error) {
@@ -316,5 +393,8 @@ func (s *Store) UpdateEntity(ctx context.Context, e *corev2.Entity) error {
 		clientv3.OpPut(stateKey, string(stateMsg)),
 	}
 
-	return kvc.Txn(ctx, s.client, comparator, ops...)
+	if err := kvc.Txn(ctx, s.client, comparator, ops...); err != nil {
+		return err
+	}
+	return s.UpdateEntityLastSeen(store.NamespaceContext(ctx, namespace), e.Name, e.LastSeen)
 }
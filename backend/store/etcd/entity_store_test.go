@@ -2,6 +2,7 @@ package etcd
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	corev3 "github.com/sensu/sensu-go/api/core/v3"
@@ -9,6 +10,7 @@ import (
 	"github.com/sensu/sensu-go/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
 func TestEntityStorage(t *testing.T) {
@@ -55,6 +57,72 @@ func TestEntityStorage(t *testing.T) {
 	})
 }
 
+func TestListStaleEntities(t *testing.T) {
+	testWithEtcd(t, func(s store.Store) {
+		ctx := context.WithValue(context.Background(), types.NamespaceKey, "default")
+
+		// more stale entities than configs read in a single transaction
+		for i := 0; i < maxTxnOps+6; i++ {
+			entity := types.FixtureEntity(fmt.Sprintf("stale-%d", i))
+			entity.LastSeen = 100
+			require.NoError(t, s.UpdateEntity(ctx, entity))
+		}
+		fresh := types.FixtureEntity("fresh")
+		fresh.LastSeen = 2000
+		require.NoError(t, s.UpdateEntity(ctx, fresh))
+		never := types.FixtureEntity("never")
+		never.LastSeen = 0
+		require.NoError(t, s.UpdateEntity(ctx, never))
+
+		entities, err := s.(store.StaleEntityLister).ListStaleEntities(ctx, 1000)
+		require.NoError(t, err)
+		require.Len(t, entities, maxTxnOps+6)
+		for _, entity := range entities {
+			assert.Equal(t, int64(100), entity.LastSeen)
+			assert.Equal(t, "host", entity.EntityClass)
+		}
+	})
+}
+
+func TestListStaleEntitiesIndex(t *testing.T) {
+	testWithEtcdStore(t, func(s *Store) {
+		ctx := context.WithValue(context.Background(), types.NamespaceKey, "default")
+		for _, name := range []string{"a", "b", "c"} {
+			entity := types.FixtureEntity(name)
+			entity.LastSeen = 100
+			require.NoError(t, s.UpdateEntity(ctx, entity))
+		}
+
+		names := func() []string {
+			entities, err := s.ListStaleEntities(ctx, 1000)
+			require.NoError(t, err)
+			var names []string
+			for _, entity := range entities {
+				names = append(names, entity.Name)
+			}
+			return names
+		}
+
+		// the entities last seen before the index existed are indexed once
+		_, err := s.client.Delete(ctx, "/sensu.io/"+entityLastSeenPathPrefix, clientv3.WithPrefix())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, names())
+
+		seen := types.FixtureEntity("b")
+		seen.LastSeen = 2000
+		require.NoError(t, s.UpdateEntity(ctx, seen))
+		assert.Equal(t, []string{"a", "c"}, names())
+
+		require.NoError(t, s.DeleteEntityByName(ctx, "c"))
+		assert.Equal(t, []string{"a"}, names())
+
+		// an outdated entry is replaced by the last seen time of the state
+		require.NoError(t, s.UpdateEntityLastSeen(ctx, "a", 50))
+		assert.Equal(t, []string{"a"}, names())
+		assert.Equal(t, []string{"a"}, names())
+	})
+}
+
 func TestEntityIteration(t *testing.T) {
 	configs := []corev3.EntityConfig{
 		*corev3.FixtureEntityConfig("a"),
@@ -96,3 +164,19 @@ func TestEntityIterationNoPanicMismatched(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestProjectEntity(t *testing.T) {
+	entity := types.FixtureEntity("entity")
+	entity.Labels = map[string]string{"region": "us-west-2"}
+
+	projected := projectEntity(entity, []string{"entity_class", "subscriptions"})
+	assert.Equal(t, entity.ObjectMeta, projected.ObjectMeta)
+	assert.Equal(t, entity.EntityClass, projected.EntityClass)
+	assert.Equal(t, entity.Subscriptions, projected.Subscriptions)
+	assert.Empty(t, projected.System.Hostname)
+	assert.Empty(t, projected.Deregistration.Handler)
+	assert.Zero(t, projected.LastSeen)
+
+	assert.False(t, projectionNeedsState([]string{"entity_class", "subscriptions"}))
+	assert.True(t, projectionNeedsState([]string{"entity_class", "system"}))
+}
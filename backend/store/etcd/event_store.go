@@ -2,6 +2,7 @@ package etcd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"path"
@@ -14,6 +15,7 @@ import (
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/backend/store/etcd/kvc"
 	"github.com/sensu/sensu-go/backend/store/provider"
+	utilstrings "github.com/sensu/sensu-go/util/strings"
 	clientv3 "go.etcd.io/etcd/client/v3"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
@@ -125,11 +127,17 @@ func (s *Store) GetEvents(ctx context.Context, pred *store.SelectionPredicate) (
 	}
 
 	events := []*corev2.Event{}
+	var last *corev2.Event
 	for _, kv := range resp.Kvs {
 		event := &corev2.Event{}
 		if err := unmarshal(kv.Value, event); err != nil {
 			return nil, &store.ErrDecode{Err: err}
 		}
+		last = event
+
+		if !eventHasSubscription(event, pred.Subscription) {
+			continue
+		}
 
 		if event.Labels == nil {
 			event.Labels = make(map[string]string)
@@ -141,8 +149,10 @@ func (s *Store) GetEvents(ctx context.Context, pred *store.SelectionPredicate) (
 		events = append(events, event)
 	}
 
+	// The page of the events with the subscription can be short, the next
+	// one starts after the last event read.
 	if pred.Limit != 0 && resp.Count > pred.Limit {
-		pred.Continue = ComputeContinueToken(ctx, events[len(events)-1])
+		pred.Continue = ComputeContinueToken(ctx, last)
 	} else {
 		pred.Continue = ""
 	}
@@ -178,11 +188,17 @@ func (s *Store) GetEventsByEntity(ctx context.Context, entityName string, pred *
 	}
 
 	events := []*corev2.Event{}
+	var last *corev2.Event
 	for _, kv := range resp.Kvs {
 		event := &corev2.Event{}
 		if err := unmarshal(kv.Value, event); err != nil {
 			return nil, &store.ErrDecode{Err: err}
 		}
+		last = event
+
+		if !eventHasSubscription(event, pred.Subscription) {
+			continue
+		}
 
 		if event.Labels == nil {
 			event.Labels = make(map[string]string)
@@ -195,8 +211,7 @@ func (s *Store) GetEventsByEntity(ctx context.Context, entityName string, pred *
 	}
 
 	if pred.Limit != 0 && resp.Count > pred.Limit {
-		lastEvent := events[len(events)-1]
-		pred.Continue = lastEvent.Check.Name + "\x00"
+		pred.Continue = last.Check.Name + "\x00"
 	} else {
 		pred.Continue = ""
 	}
@@ -266,7 +281,7 @@ func (s *Store) UpdateEvent(ctx context.Context, event *corev2.Event) (*corev2.E
 		return nil, nil, err
 	}
 
-	if err := updateEventHistory(event, prevEvent); err != nil {
+	if err := updateEventHistory(event, prevEvent, s.maxCheckHistory); err != nil {
 		return nil, nil, &store.ErrNotValid{Err: err}
 	}
 
@@ -308,7 +323,12 @@ func (s *Store) UpdateEvent(ctx context.Context, event *corev2.Event) (*corev2.E
 
 	// update the history
 	// marshal the new event and store it.
-	eventBytes, err := proto.Marshal(persistEvent)
+	var eventBytes []byte
+	if s.eventStorageFormat == EventStorageFormatJSON {
+		eventBytes, err = json.Marshal(persistEvent)
+	} else {
+		eventBytes, err = proto.Marshal(persistEvent)
+	}
 	if err != nil {
 		return nil, nil, &store.ErrEncode{Err: err}
 	}
@@ -346,13 +366,17 @@ func (s *Store) GetProviderInfo() *provider.Info {
 }
 
 // updateCheckHistory takes two events and merges the check result history of
-// the second event into the first event.
-func updateEventHistory(event *corev2.Event, prevEvent *corev2.Event) error {
+// the second event into the first event, retaining at most maxHistory results,
+// or corev2.DefaultMaxCheckHistory if zero.
+func updateEventHistory(event *corev2.Event, prevEvent *corev2.Event, maxHistory int) error {
+	if maxHistory == 0 {
+		maxHistory = corev2.DefaultMaxCheckHistory
+	}
 	if prevEvent != nil {
 		if !prevEvent.HasCheck() {
 			return errors.New("invalid previous event")
 		}
-		event.Check.MergeWith(prevEvent.Check)
+		event.Check.MergeWithMaxHistory(prevEvent.Check, maxHistory)
 	} else {
 		// If there was no previous check, we still need to set State and LastOK.
 		event.Check.State = corev2.EventFailingState
@@ -360,7 +384,7 @@ func updateEventHistory(event *corev2.Event, prevEvent *corev2.Event) error {
 			event.Check.LastOK = event.Check.Executed
 			event.Check.State = corev2.EventPassingState
 		}
-		event.Check.MergeWith(event.Check)
+		event.Check.MergeWithMaxHistory(event.Check, maxHistory)
 	}
 	return nil
 }
@@ -419,6 +443,15 @@ func handleExpireOnResolveEntries(ctx context.Context, event *corev2.Event, st s
 	return nil
 }
 
+// eventHasSubscription returns whether the check of the event has the
+// subscription, or true if the subscription is empty.
+func eventHasSubscription(event *corev2.Event, subscription string) bool {
+	if subscription == "" {
+		return true
+	}
+	return event.HasCheck() && utilstrings.InArray(subscription, event.Check.Subscriptions)
+}
+
 // CountEvents counts events in the namespace. The SelectionPredicate is not
 // supported.
 func (s *Store) CountEvents(ctx context.Context, _ *store.SelectionPredicate) (int64, error) {
@@ -834,3 +834,71 @@ func TestCountEvents(t *testing.T) {
 		}
 	})
 }
+
+func TestGetEventsBySubscription(t *testing.T) {
+	testWithEtcd(t, func(s store.Store) {
+		ctx := store.NamespaceContext(context.Background(), "default")
+		for i := 0; i < 10; i++ {
+			event := corev2.FixtureEvent("foo", fmt.Sprintf("check-%d", i))
+			if i%2 == 1 {
+				event.Check.Subscriptions = []string{"windows"}
+			}
+			if _, _, err := s.UpdateEvent(ctx, event); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		pred := &store.SelectionPredicate{Limit: 3, Subscription: "linux"}
+		var events []*corev2.Event
+		for {
+			page, err := s.GetEvents(ctx, pred)
+			if err != nil {
+				t.Fatal(err)
+			}
+			events = append(events, page...)
+			if pred.Continue == "" {
+				break
+			}
+		}
+		if got, want := len(events), 5; got != want {
+			t.Fatalf("bad number of events: got %d, want %d", got, want)
+		}
+		for _, event := range events {
+			if got, want := event.Check.Subscriptions, []string{"linux"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("bad subscriptions for %s: got %v, want %v", event.Check.Name, got, want)
+			}
+		}
+
+		events, err := s.GetEventsByEntity(ctx, "foo", &store.SelectionPredicate{Subscription: "linux"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := len(events), 5; got != want {
+			t.Errorf("bad number of entity events: got %d, want %d", got, want)
+		}
+	})
+}
+
+func TestEventStorageFormat(t *testing.T) {
+	for _, format := range []string{EventStorageFormatProtobuf, EventStorageFormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			testWithEtcdStore(t, func(s *Store) {
+				require.NoError(t, s.SetEventStorageFormat(format))
+				event := corev2.FixtureEvent("entity1", "check1")
+				ctx := context.WithValue(context.Background(), corev2.NamespaceKey, event.Entity.Namespace)
+				_, _, err := s.UpdateEvent(ctx, event)
+				require.NoError(t, err)
+
+				resp, err := s.client.Get(ctx, getEventPath(event))
+				require.NoError(t, err)
+				require.Len(t, resp.Kvs, 1)
+				isJSON := resp.Kvs[0].Value[0] == '{'
+				assert.Equal(t, format == EventStorageFormatJSON, isJSON)
+
+				stored, err := s.GetEventByEntityCheck(ctx, "entity1", "check1")
+				require.NoError(t, err)
+				assert.Equal(t, event.Check.Output, stored.Check.Output)
+			})
+		})
+	}
+}
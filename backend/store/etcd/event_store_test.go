@@ -28,6 +28,7 @@ func Test_updateEventHistory(t *testing.T) {
 		eventFn     eventFn
 		prevEventFn eventFn
 		wantEventFn eventFn
+		maxHistory  int
 		wantErr     bool
 		errMatch    string
 	}{
@@ -72,6 +73,38 @@ func Test_updateEventHistory(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "history trimmed to the maximum",
+			eventFn: func() *corev2.Event {
+				event := newEventFixture("foo", "bar")
+				event.Check.LowFlapThreshold = 0
+				event.Check.HighFlapThreshold = 0
+				return event
+			},
+			prevEventFn: func() *corev2.Event {
+				event := newEventFixture("foo", "bar")
+				event.Check.State = corev2.EventFailingState
+				event.Check.Executed = 1610056753
+				event.Check.LastOK = 1610056743
+				event.Check.History = []corev2.CheckHistory{
+					{Status: 0, Executed: 1610056733},
+					{Status: 0, Executed: 1610056743},
+					{Status: 1, Executed: 1610056753},
+				}
+				return event
+			},
+			wantEventFn: func() *corev2.Event {
+				event := newEventFixture("foo", "bar")
+				event.Check.State = corev2.EventFailingState
+				event.Check.LastOK = 1610056743
+				event.Check.History = []corev2.CheckHistory{
+					{Status: 1, Executed: 1610056753},
+					{Status: 1, Executed: 1610056763},
+				}
+				return event
+			},
+			maxHistory: 2,
+		},
 		{
 			name: "no previous event exists, check status 1",
 			eventFn: func() *corev2.Event {
@@ -117,7 +150,7 @@ func Test_updateEventHistory(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			event := tt.eventFn()
 			prevEvent := tt.prevEventFn()
-			err := updateEventHistory(event, prevEvent)
+			err := updateEventHistory(event, prevEvent, tt.maxHistory)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("updateEventHistory() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -148,3 +181,12 @@ func TestEventStoreSupportsFilteringUnsupported(t *testing.T) {
 	store := NewStore(nil)
 	assert.Equal(t, false, store.EventStoreSupportsFiltering(context.Background()), "etcd event store not expected to support filtering")
 }
+
+func TestSetEventStorageFormat(t *testing.T) {
+	s := &Store{}
+	assert.NoError(t, s.SetEventStorageFormat(""))
+	assert.Equal(t, EventStorageFormatProtobuf, s.eventStorageFormat)
+	assert.NoError(t, s.SetEventStorageFormat(EventStorageFormatJSON))
+	assert.Equal(t, EventStorageFormatJSON, s.eventStorageFormat)
+	assert.Error(t, s.SetEventStorageFormat("xml"))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"path"
 	"sort"
 	"strings"
 	"sync"
@@ -15,6 +16,10 @@ import (
 	"go.etcd.io/etcd/client/v3"
 )
 
+// healthWriteKey is the key written, then deleted, to verify that the store
+// accepts writes.
+var healthWriteKey = path.Join(EtcdRoot, "health", "write")
+
 func isEmbeddedClient(clientURLs []string) bool {
 	// It is assumed that if any of the client URLs have ':0' as their port,
 	// the member is embedded and the client doesn't need to dial.
@@ -146,3 +151,22 @@ func (s *Store) GetClusterHealth(ctx context.Context, cluster clientv3.Cluster,
 
 	return healthResponse
 }
+
+// CheckWriteHealth verifies that the store accepts writes by putting a small
+// key, then deleting it.
+func (s *Store) CheckWriteHealth(ctx context.Context) error {
+	if val := ctx.Value(store.ContextKeyTimeout); val != nil {
+		if timeout, _ := val.(time.Duration); timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+	}
+	if _, err := s.client.Put(ctx, healthWriteKey, "ok"); err != nil {
+		return &store.ErrInternal{Message: fmt.Sprintf("could not write to the store: %s", err)}
+	}
+	if _, err := s.client.Delete(ctx, healthWriteKey); err != nil {
+		return &store.ErrInternal{Message: fmt.Sprintf("could not delete from the store: %s", err)}
+	}
+	return nil
+}
@@ -24,3 +24,13 @@ func TestGetClusterHealthTimeout(t *testing.T) {
 		assert.NotEmpty(t, result.ClusterHealth[0].Err)
 	})
 }
+
+func TestCheckWriteHealth(t *testing.T) {
+	testWithEtcdClient(t, func(s store.Store, client *clientv3.Client) {
+		assert.NoError(t, s.CheckWriteHealth(context.Background()))
+
+		resp, err := client.Get(context.Background(), healthWriteKey)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), resp.Count)
+	})
+}
@@ -204,24 +204,11 @@ func (s *Store) GetSilencedEntriesByName(ctx context.Context, names ...string) (
 
 // UpdateSilencedEntry updates a Silenced.
 func (s *Store) UpdateSilencedEntry(ctx context.Context, silenced *corev2.Silenced) error {
-	if err := silenced.Validate(); err != nil {
-		return &store.ErrNotValid{Err: err}
-	}
-
-	if silenced.ExpireAt == 0 && silenced.Expire > 0 {
-		start := time.Now()
-		if silenced.Begin > 0 {
-			start = time.Unix(silenced.Begin, 0)
-		}
-		silenced.ExpireAt = start.Add(time.Duration(silenced.Expire) * time.Second).Unix()
-	}
-
-	silencedBytes, err := proto.Marshal(silenced)
+	req, err := silencedPutOp(ctx, silenced)
 	if err != nil {
-		return &store.ErrEncode{Err: err}
+		return err
 	}
 	cmp := clientv3.Compare(clientv3.Version(getNamespacePath(silenced.Namespace)), ">", 0)
-	req := clientv3.OpPut(GetSilencedPath(ctx, silenced.Name), string(silencedBytes))
 	var res *clientv3.TxnResponse
 	err = kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
 		res, err = s.client.Txn(ctx).If(cmp).Then(req).Commit()
@@ -237,6 +224,64 @@ func (s *Store) UpdateSilencedEntry(ctx context.Context, silenced *corev2.Silenc
 	return nil
 }
 
+// UpdateSilencedEntries creates or updates the given entries in a single
+// transaction, so at most maxTxnOps entries can be updated at once.
+func (s *Store) UpdateSilencedEntries(ctx context.Context, entries ...*corev2.Silenced) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	if len(entries) > maxTxnOps {
+		return &store.ErrNotValid{Err: fmt.Errorf("cannot update more than %d silenced entries at once, got %d", maxTxnOps, len(entries))}
+	}
+	namespace := corev2.ContextNamespace(ctx)
+	ops := make([]clientv3.Op, 0, len(entries))
+	for _, silenced := range entries {
+		if silenced.Namespace != namespace {
+			return &store.ErrNotValid{Err: fmt.Errorf("silenced entry %q is not in namespace %q", silenced.Name, namespace)}
+		}
+		op, err := silencedPutOp(ctx, silenced)
+		if err != nil {
+			return err
+		}
+		ops = append(ops, op)
+	}
+	cmp := clientv3.Compare(clientv3.Version(getNamespacePath(namespace)), ">", 0)
+	var res *clientv3.TxnResponse
+	err := kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
+		res, err = s.client.Txn(ctx).If(cmp).Then(ops...).Commit()
+		return kvc.RetryRequest(n, err)
+	})
+	if err != nil {
+		return err
+	}
+	if !res.Succeeded {
+		return &store.ErrNamespaceMissing{Namespace: namespace}
+	}
+	return nil
+}
+
+// silencedPutOp validates the silenced entry, sets its expiration time and
+// returns the operation storing it.
+func silencedPutOp(ctx context.Context, silenced *corev2.Silenced) (clientv3.Op, error) {
+	if err := silenced.Validate(); err != nil {
+		return clientv3.Op{}, &store.ErrNotValid{Err: err}
+	}
+
+	if silenced.ExpireAt == 0 && silenced.Expire > 0 {
+		start := time.Now()
+		if silenced.Begin > 0 {
+			start = time.Unix(silenced.Begin, 0)
+		}
+		silenced.ExpireAt = start.Add(time.Duration(silenced.Expire) * time.Second).Unix()
+	}
+
+	silencedBytes, err := proto.Marshal(silenced)
+	if err != nil {
+		return clientv3.Op{}, &store.ErrEncode{Err: err}
+	}
+	return clientv3.OpPut(GetSilencedPath(ctx, silenced.Name), string(silencedBytes)), nil
+}
+
 // arraySilencedEntries is a helper function to unmarshal serialized entries and
 // return them as an array
 //
@@ -112,6 +112,36 @@ func TestSilencedStorageWithExpire(t *testing.T) {
 	})
 }
 
+func TestSilencedStorageUpdateEntries(t *testing.T) {
+	testWithEtcd(t, func(s store.Store) {
+		ctx := context.WithValue(context.Background(), types.NamespaceKey, "default")
+		updater := s.(store.SilencedBatchUpdater)
+
+		cpu := types.FixtureSilenced("linux:cpu")
+		mem := types.FixtureSilenced("linux:mem")
+		mem.Expire = 15
+		require.NoError(t, updater.UpdateSilencedEntries(ctx, cpu, mem))
+		entries, err := s.GetSilencedEntries(ctx)
+		require.NoError(t, err)
+		assert.Len(t, entries, 2)
+		assert.NotZero(t, mem.ExpireAt)
+
+		// an invalid entry fails the whole update
+		disk := types.FixtureSilenced("linux:disk")
+		invalid := types.FixtureSilenced("linux:net")
+		invalid.Check = "not valid"
+		assert.Error(t, updater.UpdateSilencedEntries(ctx, disk, invalid))
+		entry, err := s.GetSilencedEntryByName(ctx, disk.Name)
+		require.NoError(t, err)
+		assert.Nil(t, entry)
+
+		// the entries must be in the namespace of the context
+		other := types.FixtureSilenced("linux:disk")
+		other.Namespace = "acme"
+		assert.Error(t, updater.UpdateSilencedEntries(ctx, other))
+	})
+}
+
 func TestSilencedStorageWithBegin(t *testing.T) {
 	testWithEtcd(t, func(store store.Store) {
 		silenced := types.FixtureSilenced("subscription:checkname")
@@ -20,12 +20,21 @@ import (
 const (
 	// EtcdRoot is the root of all sensu storage.
 	EtcdRoot = "/sensu.io"
+
+	// EventStorageFormatProtobuf stores events in protobuf binary, the
+	// default.
+	EventStorageFormatProtobuf = "protobuf"
+
+	// EventStorageFormatJSON stores events in JSON.
+	EventStorageFormatJSON = "json"
 )
 
 // Store is an implementation of the sensu-go/backend/store.Store iface.
 type Store struct {
-	client         *clientv3.Client
-	keepalivesPath string
+	client             *clientv3.Client
+	keepalivesPath     string
+	eventStorageFormat string
+	maxCheckHistory    int
 }
 
 // NewStore creates a new Store.
@@ -38,6 +47,32 @@ func NewStore(client *clientv3.Client) *Store {
 	return store
 }
 
+// SetEventStorageFormat sets the format events are written in, either
+// EventStorageFormatProtobuf or EventStorageFormatJSON, protobuf being used
+// when empty. Events are read regardless of the format they were written in.
+func (s *Store) SetEventStorageFormat(format string) error {
+	switch format {
+	case "":
+		s.eventStorageFormat = EventStorageFormatProtobuf
+		return nil
+	case EventStorageFormatProtobuf, EventStorageFormatJSON:
+		s.eventStorageFormat = format
+		return nil
+	default:
+		return fmt.Errorf("invalid event storage format %q, expected %q or %q", format, EventStorageFormatProtobuf, EventStorageFormatJSON)
+	}
+}
+
+// SetMaxCheckHistory sets the number of check results retained in the history
+// of the events, corev2.DefaultMaxCheckHistory being used when zero.
+func (s *Store) SetMaxCheckHistory(max int) error {
+	if max != 0 && max < corev2.MinCheckHistory {
+		return fmt.Errorf("invalid maximum check history %d, must be at least %d", max, corev2.MinCheckHistory)
+	}
+	s.maxCheckHistory = max
+	return nil
+}
+
 // Create the given key with the serialized object.
 func Create(ctx context.Context, client *clientv3.Client, key, namespace string, object interface{}) error {
 	bytes, err := marshal(object)
@@ -0,0 +1,199 @@
+// Package postgres manages the schema of the postgresql stores.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+const (
+	// MigrationsTable is the table recording the migrations applied to the
+	// schema of a store.
+	MigrationsTable = "schema_migrations"
+
+	// migrationLockID is the key of the advisory lock serializing the
+	// migrations of the backends sharing a store.
+	migrationLockID = 0x53454e5355
+)
+
+var (
+	// DriverName is the name of the database/sql driver the postgresql stores
+	// are opened with. The driver must be registered by the binary.
+	DriverName = "pgx"
+
+	// ErrDriverNotRegistered is returned when the stores are opened without
+	// the postgresql driver being registered.
+	ErrDriverNotRegistered = errors.New("the postgresql driver is not registered")
+
+	// StateStoreMigrations are the migrations of the schema of the state
+	// store, ordered by version.
+	StateStoreMigrations = []Migration{}
+
+	// ConfigStoreMigrations are the migrations of the schema of the
+	// configuration store, ordered by version.
+	ConfigStoreMigrations = []Migration{}
+)
+
+// Migration is a change to the schema of a store. Its version is its position
+// in the migrations of the store, starting at 1. It is applied in its own
+// transaction, and recorded in the MigrationsTable table by the same
+// transaction, so that a migration that fails leaves the schema at the version
+// of the previous one.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, tx *sql.Tx) error
+}
+
+// Open opens the postgresql store of the DSN with the DriverName driver.
+func Open(dsn string) (*sql.DB, error) {
+	registered := false
+	for _, name := range sql.Drivers() {
+		if name == DriverName {
+			registered = true
+		}
+	}
+	if !registered {
+		return nil, ErrDriverNotRegistered
+	}
+	return sql.Open(DriverName, dsn)
+}
+
+// SchemaVersion returns the version of the last migration applied to the
+// schema of the store, 0 if none was applied.
+func SchemaVersion(ctx context.Context, db *sql.DB) (int, error) {
+	if err := createMigrationsTable(ctx, db); err != nil {
+		return 0, err
+	}
+	return schemaVersion(ctx, db)
+}
+
+// CheckSchema verifies that the schema of the store is at the version of the
+// last of its migrations.
+func CheckSchema(ctx context.Context, db *sql.DB, migrations []Migration) error {
+	version, err := SchemaVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+	latest := len(migrations)
+	if version > latest {
+		return fmt.Errorf("the schema is at version %d, newer than the latest version %d known to this backend", version, latest)
+	}
+	if version < latest {
+		return fmt.Errorf("the schema is at version %d, older than the latest version %d: its migrations must be applied", version, latest)
+	}
+	return nil
+}
+
+// Migrate applies the migrations of the store up to the version to, or all
+// of them if to is 0, and returns the resulting version of the schema. The
+// migrations that were already applied are skipped. Downgrades are not
+// supported.
+func Migrate(ctx context.Context, db *sql.DB, migrations []Migration, to int) (int, error) {
+	if err := validateMigrations(migrations); err != nil {
+		return 0, err
+	}
+	if to == 0 {
+		to = len(migrations)
+	}
+	if to < 0 || to > len(migrations) {
+		return 0, fmt.Errorf("unknown schema version %d, the latest is %d", to, len(migrations))
+	}
+
+	version, err := SchemaVersion(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+	if version > to {
+		return version, fmt.Errorf("the schema is at version %d, newer than %d: downgrades are not supported", version, to)
+	}
+
+	for _, migration := range migrations[:to] {
+		if migration.Version <= version {
+			continue
+		}
+		if err := applyMigration(ctx, db, migration); err != nil {
+			return version, fmt.Errorf("migration %d (%s) failed, the schema is at version %d: %w", migration.Version, migration.Name, version, err)
+		}
+		version = migration.Version
+	}
+	return version, nil
+}
+
+// validateMigrations verifies that the versions of the migrations are
+// consecutive, starting at 1.
+func validateMigrations(migrations []Migration) error {
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			return fmt.Errorf("migration %q has version %d, expected %d", migration.Name, migration.Version, i+1)
+		}
+		if migration.Up == nil {
+			return fmt.Errorf("migration %d (%s) does nothing", migration.Version, migration.Name)
+		}
+	}
+	return nil
+}
+
+func createMigrationsTable(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+MigrationsTable+` (
+		version    integer PRIMARY KEY,
+		name       text NOT NULL,
+		applied_at timestamptz NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return fmt.Errorf("could not create the %s table: %w", MigrationsTable, err)
+	}
+	return nil
+}
+
+type queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func schemaVersion(ctx context.Context, q queryer) (int, error) {
+	var version int
+	row := q.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM `+MigrationsTable)
+	if err := row.Scan(&version); err != nil {
+		return 0, fmt.Errorf("could not read the schema version: %w", err)
+	}
+	return version, nil
+}
+
+// applyMigration applies and records the migration in a transaction, holding
+// an advisory lock so that the backends sharing the store apply it once. The
+// migration is skipped if another backend applied it in the meantime.
+func applyMigration(ctx context.Context, db *sql.DB, migration Migration) (fErr error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if fErr != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, migrationLockID); err != nil {
+		return err
+	}
+	version, err := schemaVersion(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if version >= migration.Version {
+		return tx.Rollback()
+	}
+	if version != migration.Version-1 {
+		return fmt.Errorf("the schema is at version %d", version)
+	}
+
+	if err := migration.Up(ctx, tx); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO `+MigrationsTable+` (version, name) VALUES ($1, $2)`, migration.Version, migration.Name); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
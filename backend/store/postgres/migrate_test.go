@@ -0,0 +1,316 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore is the state of a fake postgresql store, recording the applied
+// migrations and the statements they executed.
+type fakeStore struct {
+	mu         sync.Mutex
+	versions   map[int]string
+	statements []string
+}
+
+// fakeDriver is a database/sql driver executing the statements of the
+// migrations against a fakeStore.
+type fakeDriver struct {
+	store *fakeStore
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) {
+	return &fakeConn{store: d.store}, nil
+}
+
+type fakeConn struct {
+	store *fakeStore
+	tx    *fakeTx
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: c, query: query}, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.tx = &fakeTx{conn: c, versions: map[int]string{}}
+	return c.tx, nil
+}
+
+type fakeTx struct {
+	conn       *fakeConn
+	versions   map[int]string
+	statements []string
+}
+
+func (tx *fakeTx) Commit() error {
+	store := tx.conn.store
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	for version, name := range tx.versions {
+		store.versions[version] = name
+	}
+	store.statements = append(store.statements, tx.statements...)
+	tx.conn.tx = nil
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.conn.tx = nil
+	return nil
+}
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	tx := s.conn.tx
+	switch {
+	case strings.HasPrefix(s.query, "CREATE TABLE IF NOT EXISTS "+MigrationsTable),
+		strings.HasPrefix(s.query, "SELECT pg_advisory_xact_lock"):
+	case strings.HasPrefix(s.query, "INSERT INTO "+MigrationsTable):
+		if tx == nil {
+			return nil, errors.New("not in a transaction")
+		}
+		tx.versions[int(args[0].(int64))] = args[1].(string)
+	case s.query == "FAIL":
+		return nil, errors.New("syntax error")
+	default:
+		if tx == nil {
+			return nil, errors.New("not in a transaction")
+		}
+		tx.statements = append(tx.statements, s.query)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if !strings.HasPrefix(s.query, "SELECT COALESCE(MAX(version), 0)") {
+		return nil, fmt.Errorf("unexpected query %q", s.query)
+	}
+	store := s.conn.store
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	version := 0
+	for v := range store.versions {
+		if v > version {
+			version = v
+		}
+	}
+	return &fakeRows{values: []driver.Value{int64(version)}}, nil
+}
+
+type fakeRows struct {
+	values []driver.Value
+	done   bool
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"version"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.values)
+	return nil
+}
+
+var fakeDrivers int
+
+func newFakeDB(t *testing.T) (*sql.DB, *fakeStore) {
+	t.Helper()
+	store := &fakeStore{versions: map[int]string{}}
+	fakeDrivers++
+	name := fmt.Sprintf("fakepg-%d", fakeDrivers)
+	sql.Register(name, &fakeDriver{store: store})
+	db, err := sql.Open(name, "")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db, store
+}
+
+func execMigration(version int, name, statement string) Migration {
+	return Migration{
+		Version: version,
+		Name:    name,
+		Up: func(ctx context.Context, tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, statement)
+			return err
+		},
+	}
+}
+
+var testMigrations = []Migration{
+	execMigration(1, "create events", "CREATE TABLE events"),
+	execMigration(2, "create entities", "CREATE TABLE entities"),
+	execMigration(3, "index events", "CREATE INDEX events_entity"),
+}
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name           string
+		applied        []int
+		migrations     []Migration
+		to             int
+		wantVersion    int
+		wantStatements []string
+		wantErr        bool
+	}{
+		{
+			name:           "all migrations are applied in order",
+			migrations:     testMigrations,
+			wantVersion:    3,
+			wantStatements: []string{"CREATE TABLE events", "CREATE TABLE entities", "CREATE INDEX events_entity"},
+		},
+		{
+			name:           "migrations are applied up to the target version",
+			migrations:     testMigrations,
+			to:             2,
+			wantVersion:    2,
+			wantStatements: []string{"CREATE TABLE events", "CREATE TABLE entities"},
+		},
+		{
+			name:           "applied migrations are skipped",
+			applied:        []int{1, 2},
+			migrations:     testMigrations,
+			wantVersion:    3,
+			wantStatements: []string{"CREATE INDEX events_entity"},
+		},
+		{
+			name:        "the schema is up to date",
+			applied:     []int{1, 2, 3},
+			migrations:  testMigrations,
+			to:          3,
+			wantVersion: 3,
+		},
+		{
+			name:        "downgrades are rejected",
+			applied:     []int{1, 2, 3},
+			migrations:  testMigrations,
+			to:          1,
+			wantVersion: 3,
+			wantErr:     true,
+		},
+		{
+			name:       "unknown target versions are rejected",
+			migrations: testMigrations,
+			to:         4,
+			wantErr:    true,
+		},
+		{
+			name: "versions must be consecutive",
+			migrations: []Migration{
+				execMigration(1, "create events", "CREATE TABLE events"),
+				execMigration(3, "index events", "CREATE INDEX events_entity"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "a failed migration leaves the schema at the previous version",
+			migrations: []Migration{
+				execMigration(1, "create events", "CREATE TABLE events"),
+				execMigration(2, "broken", "FAIL"),
+				execMigration(3, "index events", "CREATE INDEX events_entity"),
+			},
+			wantVersion:    1,
+			wantStatements: []string{"CREATE TABLE events"},
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, store := newFakeDB(t)
+			for _, version := range tt.applied {
+				store.versions[version] = testMigrations[version-1].Name
+			}
+
+			version, err := Migrate(context.Background(), db, tt.migrations, tt.to)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantVersion, version)
+			assert.Equal(t, tt.wantStatements, store.statements)
+
+			current, err := SchemaVersion(context.Background(), db)
+			require.NoError(t, err)
+			if tt.wantVersion > 0 {
+				assert.Equal(t, tt.wantVersion, current)
+			}
+		})
+	}
+}
+
+func TestCheckSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		applied []int
+		wantErr bool
+	}{
+		{
+			name:    "up to date",
+			applied: []int{1, 2, 3},
+		},
+		{
+			name:    "pending migrations",
+			applied: []int{1},
+			wantErr: true,
+		},
+		{
+			name:    "newer schema",
+			applied: []int{1, 2, 3, 4},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, store := newFakeDB(t)
+			for _, version := range tt.applied {
+				store.versions[version] = fmt.Sprintf("migration %d", version)
+			}
+			err := CheckSchema(context.Background(), db, testMigrations)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOpenWithoutDriver(t *testing.T) {
+	_, err := Open("host=localhost")
+	assert.ErrorIs(t, err, ErrDriverNotRegistered)
+}
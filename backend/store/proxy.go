@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"crypto/tls"
+	"fmt"
 	"sync"
 	"time"
 
@@ -74,6 +75,39 @@ func (s *StoreProxy) CountEvents(ctx context.Context, pred *SelectionPredicate)
 	return s.do().CountEvents(ctx, pred)
 }
 
+// CountEntities counts the entities in a namespace, if supported by the
+// underlying store. The namespace is specified as part of the context.
+func (s *StoreProxy) CountEntities(ctx context.Context, pred *SelectionPredicate) (int64, error) {
+	impl := s.do()
+	counter, ok := impl.(EntityCounter)
+	if !ok {
+		return 0, fmt.Errorf("%T does not support counting entities", impl)
+	}
+	return counter.CountEntities(ctx, pred)
+}
+
+// ListStaleEntities lists the entities in a namespace that were last seen
+// before the given unix timestamp, if supported by the underlying store. The
+// namespace is specified as part of the context.
+func (s *StoreProxy) ListStaleEntities(ctx context.Context, lastSeenBefore int64) ([]*corev2.Entity, error) {
+	impl := s.do()
+	lister, ok := impl.(StaleEntityLister)
+	if !ok {
+		return nil, fmt.Errorf("%T does not support listing stale entities", impl)
+	}
+	return lister.ListStaleEntities(ctx, lastSeenBefore)
+}
+
+// UpdateEntityLastSeen records the last seen time of an entity, if supported by
+// the underlying store. The namespace is specified as part of the context.
+func (s *StoreProxy) UpdateEntityLastSeen(ctx context.Context, name string, lastSeen int64) error {
+	lister, ok := s.do().(StaleEntityLister)
+	if !ok {
+		return nil
+	}
+	return lister.UpdateEntityLastSeen(ctx, name, lastSeen)
+}
+
 // EventStoreSupportsFiltering signals whether an event store implementation
 // supporting filtering, ordering and offsets. Currently an enterprise postgres store feature.
 func (s *StoreProxy) EventStoreSupportsFiltering(ctx context.Context) bool {
@@ -310,6 +344,11 @@ func (s *StoreProxy) GetClusterHealth(ctx context.Context, cluster clientv3.Clus
 	return s.do().GetClusterHealth(ctx, cluster, etcdClientTLSConfig)
 }
 
+// CheckWriteHealth returns an error if the store does not accept writes.
+func (s *StoreProxy) CheckWriteHealth(ctx context.Context) error {
+	return s.do().CheckWriteHealth(ctx)
+}
+
 // DeleteFailingKeepalive deletes a failing keepalive record for a given entity.
 func (s *StoreProxy) DeleteFailingKeepalive(ctx context.Context, entity *types.Entity) error {
 	return s.do().DeleteFailingKeepalive(ctx, entity)
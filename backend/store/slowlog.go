@@ -0,0 +1,261 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store/provider"
+	"github.com/sensu/sensu-go/types"
+	"github.com/sirupsen/logrus"
+)
+
+var slowLogger = logrus.WithFields(logrus.Fields{
+	"component": "store",
+})
+
+// SlowLogStore is a Store that logs the operations taking longer than a
+// threshold to complete. Only the list and count operations, and the
+// operations on the event and entity hot paths, are timed; the other ones are
+// passed through to the underlying store.
+type SlowLogStore struct {
+	Store
+
+	threshold time.Duration
+}
+
+// NewSlowLogStore returns a SlowLogStore that logs the operations of s taking
+// longer than threshold.
+func NewSlowLogStore(s Store, threshold time.Duration) *SlowLogStore {
+	return &SlowLogStore{Store: s, threshold: threshold}
+}
+
+// LogSlowOperation logs the store operation op, started at start, if it took
+// longer than threshold. The namespace, key and pred identify the resources
+// it accessed, and are omitted from the log when empty.
+func LogSlowOperation(threshold time.Duration, start time.Time, op, namespace, key string, pred *SelectionPredicate) {
+	elapsed := time.Since(start)
+	if elapsed < threshold {
+		return
+	}
+	fields := logrus.Fields{
+		"operation": op,
+		"duration":  elapsed.String(),
+	}
+	if namespace != "" {
+		fields["namespace"] = namespace
+	}
+	if key != "" {
+		fields["key"] = key
+	}
+	if pred != nil {
+		fields["selector"] = predicateString(pred)
+	}
+	slowLogger.WithFields(fields).Warn("slow store operation")
+}
+
+// predicateString describes the non-zero fields of pred.
+func predicateString(pred *SelectionPredicate) string {
+	var parts []string
+	if pred.Limit > 0 {
+		parts = append(parts, fmt.Sprintf("limit=%d", pred.Limit))
+	}
+	if pred.Offset > 0 {
+		parts = append(parts, fmt.Sprintf("offset=%d", pred.Offset))
+	}
+	if pred.Continue != "" {
+		parts = append(parts, fmt.Sprintf("continue=%s", pred.Continue))
+	}
+	if pred.Subcollection != "" {
+		parts = append(parts, fmt.Sprintf("subcollection=%s", pred.Subcollection))
+	}
+	if pred.Subscription != "" {
+		parts = append(parts, fmt.Sprintf("subscription=%s", pred.Subscription))
+	}
+	if pred.Ordering != "" {
+		parts = append(parts, fmt.Sprintf("ordering=%s", pred.Ordering))
+	}
+	if pred.Descending {
+		parts = append(parts, "descending=true")
+	}
+	if len(pred.Fields) > 0 {
+		parts = append(parts, fmt.Sprintf("fields=%s", strings.Join(pred.Fields, ",")))
+	}
+	return strings.Join(parts, " ")
+}
+
+func (s *SlowLogStore) observe(ctx context.Context, op, key string, pred *SelectionPredicate) func() {
+	start := time.Now()
+	return func() {
+		LogSlowOperation(s.threshold, start, op, corev2.ContextNamespace(ctx), key, pred)
+	}
+}
+
+// GetAssets logs the slow calls to the underlying store's GetAssets.
+func (s *SlowLogStore) GetAssets(ctx context.Context, pred *SelectionPredicate) ([]*types.Asset, error) {
+	defer s.observe(ctx, "GetAssets", "", pred)()
+	return s.Store.GetAssets(ctx, pred)
+}
+
+// GetCheckConfigs logs the slow calls to the underlying store's
+// GetCheckConfigs.
+func (s *SlowLogStore) GetCheckConfigs(ctx context.Context, pred *SelectionPredicate) ([]*types.CheckConfig, error) {
+	defer s.observe(ctx, "GetCheckConfigs", "", pred)()
+	return s.Store.GetCheckConfigs(ctx, pred)
+}
+
+// GetHookConfigs logs the slow calls to the underlying store's
+// GetHookConfigs.
+func (s *SlowLogStore) GetHookConfigs(ctx context.Context, pred *SelectionPredicate) ([]*types.HookConfig, error) {
+	defer s.observe(ctx, "GetHookConfigs", "", pred)()
+	return s.Store.GetHookConfigs(ctx, pred)
+}
+
+// GetEntities logs the slow calls to the underlying store's GetEntities.
+func (s *SlowLogStore) GetEntities(ctx context.Context, pred *SelectionPredicate) ([]*types.Entity, error) {
+	defer s.observe(ctx, "GetEntities", "", pred)()
+	return s.Store.GetEntities(ctx, pred)
+}
+
+// GetEntityByName logs the slow calls to the underlying store's
+// GetEntityByName.
+func (s *SlowLogStore) GetEntityByName(ctx context.Context, name string) (*types.Entity, error) {
+	defer s.observe(ctx, "GetEntityByName", name, nil)()
+	return s.Store.GetEntityByName(ctx, name)
+}
+
+// CountEntities logs the slow calls to the underlying store's CountEntities,
+// if supported.
+func (s *SlowLogStore) CountEntities(ctx context.Context, pred *SelectionPredicate) (int64, error) {
+	counter, ok := s.Store.(EntityCounter)
+	if !ok {
+		return 0, fmt.Errorf("%T does not support counting entities", s.Store)
+	}
+	defer s.observe(ctx, "CountEntities", "", pred)()
+	return counter.CountEntities(ctx, pred)
+}
+
+// ListStaleEntities logs the slow calls to the underlying store's
+// ListStaleEntities, if supported.
+func (s *SlowLogStore) ListStaleEntities(ctx context.Context, lastSeenBefore int64) ([]*corev2.Entity, error) {
+	lister, ok := s.Store.(StaleEntityLister)
+	if !ok {
+		return nil, fmt.Errorf("%T does not support listing stale entities", s.Store)
+	}
+	defer s.observe(ctx, "ListStaleEntities", "", nil)()
+	return lister.ListStaleEntities(ctx, lastSeenBefore)
+}
+
+// UpdateEntityLastSeen logs the slow calls to the underlying store's
+// UpdateEntityLastSeen, if supported.
+func (s *SlowLogStore) UpdateEntityLastSeen(ctx context.Context, name string, lastSeen int64) error {
+	lister, ok := s.Store.(StaleEntityLister)
+	if !ok {
+		return nil
+	}
+	defer s.observe(ctx, "UpdateEntityLastSeen", name, nil)()
+	return lister.UpdateEntityLastSeen(ctx, name, lastSeen)
+}
+
+// GetEvents logs the slow calls to the underlying store's GetEvents.
+func (s *SlowLogStore) GetEvents(ctx context.Context, pred *SelectionPredicate) ([]*corev2.Event, error) {
+	defer s.observe(ctx, "GetEvents", "", pred)()
+	return s.Store.GetEvents(ctx, pred)
+}
+
+// GetEventsByEntity logs the slow calls to the underlying store's
+// GetEventsByEntity.
+func (s *SlowLogStore) GetEventsByEntity(ctx context.Context, entity string, pred *SelectionPredicate) ([]*corev2.Event, error) {
+	defer s.observe(ctx, "GetEventsByEntity", entity, pred)()
+	return s.Store.GetEventsByEntity(ctx, entity, pred)
+}
+
+// GetEventByEntityCheck logs the slow calls to the underlying store's
+// GetEventByEntityCheck.
+func (s *SlowLogStore) GetEventByEntityCheck(ctx context.Context, entity, check string) (*types.Event, error) {
+	defer s.observe(ctx, "GetEventByEntityCheck", entity+"/"+check, nil)()
+	return s.Store.GetEventByEntityCheck(ctx, entity, check)
+}
+
+// UpdateEvent logs the slow calls to the underlying store's UpdateEvent.
+func (s *SlowLogStore) UpdateEvent(ctx context.Context, event *corev2.Event) (old, new *corev2.Event, err error) {
+	var key string
+	if event.HasCheck() && event.Entity != nil {
+		key = event.Entity.Name + "/" + event.Check.Name
+	}
+	defer s.observe(ctx, "UpdateEvent", key, nil)()
+	return s.Store.UpdateEvent(ctx, event)
+}
+
+// CountEvents logs the slow calls to the underlying store's CountEvents.
+func (s *SlowLogStore) CountEvents(ctx context.Context, pred *SelectionPredicate) (int64, error) {
+	defer s.observe(ctx, "CountEvents", "", pred)()
+	return s.Store.CountEvents(ctx, pred)
+}
+
+// GetEventFilters logs the slow calls to the underlying store's
+// GetEventFilters.
+func (s *SlowLogStore) GetEventFilters(ctx context.Context, pred *SelectionPredicate) ([]*types.EventFilter, error) {
+	defer s.observe(ctx, "GetEventFilters", "", pred)()
+	return s.Store.GetEventFilters(ctx, pred)
+}
+
+// GetHandlers logs the slow calls to the underlying store's GetHandlers.
+func (s *SlowLogStore) GetHandlers(ctx context.Context, pred *SelectionPredicate) ([]*types.Handler, error) {
+	defer s.observe(ctx, "GetHandlers", "", pred)()
+	return s.Store.GetHandlers(ctx, pred)
+}
+
+// GetMutators logs the slow calls to the underlying store's GetMutators.
+func (s *SlowLogStore) GetMutators(ctx context.Context, pred *SelectionPredicate) ([]*types.Mutator, error) {
+	defer s.observe(ctx, "GetMutators", "", pred)()
+	return s.Store.GetMutators(ctx, pred)
+}
+
+// ListNamespaces logs the slow calls to the underlying store's
+// ListNamespaces.
+func (s *SlowLogStore) ListNamespaces(ctx context.Context, pred *SelectionPredicate) ([]*types.Namespace, error) {
+	defer s.observe(ctx, "ListNamespaces", "", pred)()
+	return s.Store.ListNamespaces(ctx, pred)
+}
+
+// GetResource logs the slow calls to the underlying store's GetResource.
+func (s *SlowLogStore) GetResource(ctx context.Context, name string, resource corev2.Resource) error {
+	defer s.observe(ctx, "GetResource", resource.StorePrefix()+"/"+name, nil)()
+	return s.Store.GetResource(ctx, name, resource)
+}
+
+// ListResources logs the slow calls to the underlying store's ListResources.
+func (s *SlowLogStore) ListResources(ctx context.Context, kind string, resources interface{}, pred *SelectionPredicate) error {
+	defer s.observe(ctx, "ListResources", kind, pred)()
+	return s.Store.ListResources(ctx, kind, resources, pred)
+}
+
+// GetSilencedEntries logs the slow calls to the underlying store's
+// GetSilencedEntries.
+func (s *SlowLogStore) GetSilencedEntries(ctx context.Context) ([]*types.Silenced, error) {
+	defer s.observe(ctx, "GetSilencedEntries", "", nil)()
+	return s.Store.GetSilencedEntries(ctx)
+}
+
+// GetSilencedEntriesBySubscription logs the slow calls to the underlying
+// store's GetSilencedEntriesBySubscription.
+func (s *SlowLogStore) GetSilencedEntriesBySubscription(ctx context.Context, subscriptions ...string) ([]*types.Silenced, error) {
+	defer s.observe(ctx, "GetSilencedEntriesBySubscription", strings.Join(subscriptions, ","), nil)()
+	return s.Store.GetSilencedEntriesBySubscription(ctx, subscriptions...)
+}
+
+// GetProviderInfo returns the info of the underlying store provider.
+func (s *SlowLogStore) GetProviderInfo() *provider.Info {
+	if p, ok := s.Store.(provider.InfoGetter); ok {
+		return p.GetProviderInfo()
+	}
+	return &provider.Info{
+		TypeMeta: corev2.TypeMeta{
+			Type:       "etcd",
+			APIVersion: "core/v2",
+		},
+	}
+}
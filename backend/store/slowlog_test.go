@@ -0,0 +1,49 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSlowLogStore(t *testing.T) {
+	hook := test.NewGlobal()
+	defer hook.Reset()
+
+	s := new(mockstore.MockStore)
+	s.On("GetEvents", mock.Anything, mock.Anything).After(20*time.Millisecond).Return([]*corev2.Event{}, nil)
+	s.On("GetEventByEntityCheck", mock.Anything, "entity1", "check1").Return((*corev2.Event)(nil), nil)
+
+	slow := store.NewSlowLogStore(s, 10*time.Millisecond)
+	ctx := context.WithValue(context.Background(), corev2.NamespaceKey, "acme")
+
+	if _, err := slow.GetEventByEntityCheck(ctx, "entity1", "check1"); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(hook.AllEntries()); got != 0 {
+		t.Fatalf("expected no log entry for a fast operation, got %d", got)
+	}
+
+	if _, err := slow.GetEvents(ctx, &store.SelectionPredicate{Limit: 100}); err != nil {
+		t.Fatal(err)
+	}
+	entry := hook.LastEntry()
+	if entry == nil {
+		t.Fatal("expected a log entry for a slow operation")
+	}
+	if got, want := entry.Data["operation"], "GetEvents"; got != want {
+		t.Errorf("bad operation: got %v, want %v", got, want)
+	}
+	if got, want := entry.Data["namespace"], "acme"; got != want {
+		t.Errorf("bad namespace: got %v, want %v", got, want)
+	}
+	if got, want := entry.Data["selector"], "limit=100"; got != want {
+		t.Errorf("bad selector: got %v, want %v", got, want)
+	}
+}
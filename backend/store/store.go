@@ -109,6 +109,14 @@ type SelectionPredicate struct {
 	Ordering string
 	// Descending indicates the sort direction is in descending order.
 	Descending bool
+	// Fields restricts the fields populated on the returned resources to the
+	// ones named, if supported by the store. Resources are returned in full
+	// when empty.
+	Fields []string
+	// Subscription restricts the events returned to the ones whose check has
+	// the given subscription, if supported by the store. The pages of events
+	// can then be shorter than the Limit. All events are returned when empty.
+	Subscription string
 }
 
 // A WatchEventCheckConfig contains the modified store object and the action
@@ -385,6 +393,27 @@ type EntityStore interface {
 	UpdateEntity(ctx context.Context, entity *types.Entity) error
 }
 
+// EntityCounter is implemented by the entity stores that can count the
+// entities of a namespace without listing them.
+type EntityCounter interface {
+	// CountEntities counts the entities in the ctx's namespace. The
+	// SelectionPredicate is not supported.
+	CountEntities(ctx context.Context, pred *SelectionPredicate) (int64, error)
+}
+
+// StaleEntityLister is implemented by the entity stores that can find the
+// entities that were not seen for a while without listing all of them.
+type StaleEntityLister interface {
+	// ListStaleEntities returns the entities in the ctx's namespace that were
+	// last seen before the given unix timestamp. The entities that were never
+	// seen are not returned.
+	ListStaleEntities(ctx context.Context, lastSeenBefore int64) ([]*types.Entity, error)
+
+	// UpdateEntityLastSeen records the last seen time of the named entity in
+	// the ctx's namespace, for ListStaleEntities to find it.
+	UpdateEntityLastSeen(ctx context.Context, name string, lastSeen int64) error
+}
+
 // EventStore provides methods for managing events
 type EventStore interface {
 	// DeleteEventByEntityCheck deletes an event using the given entity and check,
@@ -457,6 +486,9 @@ type HandlerStore interface {
 // HealthStore provides methods for cluster health
 type HealthStore interface {
 	GetClusterHealth(ctx context.Context, cluster clientv3.Cluster, etcdClientTLSConfig *tls.Config) *types.HealthResponse
+
+	// CheckWriteHealth returns an error if the store does not accept writes.
+	CheckWriteHealth(ctx context.Context) error
 }
 
 // KeepaliveStore provides methods for managing entities keepalives
@@ -610,6 +642,14 @@ type SilencedStore interface {
 	GetSilencedEntriesByName(ctx context.Context, id ...string) ([]*types.Silenced, error)
 }
 
+// SilencedBatchUpdater is implemented by the silenced stores that can create
+// or update several silenced entries at once.
+type SilencedBatchUpdater interface {
+	// UpdateSilencedEntries creates or updates the given entries of the
+	// ctx's namespace, all of them or none.
+	UpdateSilencedEntries(ctx context.Context, entries ...*types.Silenced) error
+}
+
 // TessenConfigStore provides methods for managing the Tessen configuration
 type TessenConfigStore interface {
 	// CreateOrUpdateTessenConfig creates or updates the tessen configuration
@@ -198,6 +198,53 @@ func (s *Store) Patch(req storev2.ResourceRequest, wrapper storev2.Wrapper, patc
 	return s.Update(req, w, comparisons...)
 }
 
+// UpdateIfMatch updates the resource with the wrapped resource, but only if
+// the etag of the stored resource satisfies the conditions. The stored
+// resource must not change between the check and the update, otherwise the
+// update fails with a precondition error as well.
+func (s *Store) UpdateIfMatch(req storev2.ResourceRequest, wrapper storev2.Wrapper, conditions *store.ETagCondition) error {
+	if err := req.Validate(); err != nil {
+		return &store.ErrNotValid{Err: err}
+	}
+
+	key := StoreKey(req)
+
+	resp, err := s.GetWithResponse(req)
+	if err != nil {
+		return err
+	}
+	value := resp.Kvs[0].Value
+
+	var stored wrap.Wrapper
+	if err := proto.Unmarshal(value, &stored); err != nil {
+		return &store.ErrDecode{Key: key, Err: err}
+	}
+	resource, err := stored.Unwrap()
+	if err != nil {
+		return &store.ErrDecode{Key: key, Err: err}
+	}
+	etag, err := store.ETag(resource)
+	if err != nil {
+		return err
+	}
+
+	if conditions != nil {
+		if !store.CheckIfMatch(conditions.IfMatch, etag) {
+			return &store.ErrPreconditionFailed{Key: key}
+		}
+		if !store.CheckIfNoneMatch(conditions.IfNoneMatch, etag) {
+			return &store.ErrPreconditionFailed{Key: key}
+		}
+	}
+
+	comparisons := []kvc.Predicate{
+		kvc.KeyIsFound(key),
+		kvc.KeyHasValue(key, value),
+	}
+
+	return s.Update(req, wrapper, comparisons...)
+}
+
 func (s *Store) UpdateIfExists(req storev2.ResourceRequest, wrapper storev2.Wrapper) error {
 	w, ok := wrapper.(*wrap.Wrapper)
 	if !ok {
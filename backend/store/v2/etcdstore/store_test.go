@@ -104,6 +104,58 @@ func TestUpdateIfExists(t *testing.T) {
 	})
 }
 
+func TestUpdateIfMatch(t *testing.T) {
+	testWithEtcdStore(t, func(s *etcdstore.Store) {
+		// Create a namespace to work within
+		ns := &corev2.Namespace{Name: "default"}
+		ctx := context.Background()
+		req := storev2.NewResourceRequestFromV2Resource(ctx, ns)
+		wrapper, err := wrap.V2Resource(ns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.CreateOrUpdate(req, wrapper); err != nil {
+			t.Fatal(err)
+		}
+		fixture := fixtureTestResource("foo")
+		req = storev2.NewResourceRequestFromResource(ctx, fixture)
+		wrapper, err = wrap.Resource(fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// UpdateIfMatch should fail when the resource does not exist
+		if err := s.UpdateIfMatch(req, wrapper, &store.ETagCondition{IfMatch: "*"}); err == nil {
+			t.Error("expected non-nil error")
+		} else if _, ok := err.(*store.ErrNotFound); !ok {
+			t.Errorf("wrong error: %s", err)
+		}
+		if err := s.CreateOrUpdate(req, wrapper); err != nil {
+			t.Fatal(err)
+		}
+		etag, err := store.ETag(fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// UpdateIfMatch should succeed with the etag of the stored resource
+		fixture.Metadata.Labels["foo"] = "bar"
+		wrapper, err = wrap.Resource(fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.UpdateIfMatch(req, wrapper, &store.ETagCondition{IfMatch: etag}); err != nil {
+			t.Fatal(err)
+		}
+
+		// The etag has changed with the update, so it should now fail
+		if err := s.UpdateIfMatch(req, wrapper, &store.ETagCondition{IfMatch: etag}); err == nil {
+			t.Error("expected non-nil error")
+		} else if _, ok := err.(*store.ErrPreconditionFailed); !ok {
+			t.Errorf("wrong error: %s", err)
+		}
+	})
+}
+
 func TestCreateIfNotExists(t *testing.T) {
 	testWithEtcdStore(t, func(s *etcdstore.Store) {
 		// Create a namespace to work within
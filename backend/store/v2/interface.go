@@ -47,4 +47,9 @@ type Interface interface {
 
 	// Patch patches the resource given in the request
 	Patch(ResourceRequest, Wrapper, patch.Patcher, *store.ETagCondition) error
+
+	// UpdateIfMatch updates the resource with the wrapped resource, but only
+	// if it exists in the store and the etag of the stored resource satisfies
+	// the conditions when it is replaced.
+	UpdateIfMatch(ResourceRequest, Wrapper, *store.ETagCondition) error
 }
@@ -79,3 +79,12 @@ func (p *Proxy) Patch(req ResourceRequest, wrapper Wrapper, patcher patch.Patche
 	defer p.mu.RUnlock()
 	return p.impl.Patch(req, wrapper, patcher, cond)
 }
+
+// UpdateIfMatch updates the resource with the wrapped resource, but only if it
+// exists in the store and the etag of the stored resource satisfies the
+// conditions when it is replaced.
+func (p *Proxy) UpdateIfMatch(req ResourceRequest, wrapper Wrapper, cond *store.ETagCondition) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.impl.UpdateIfMatch(req, wrapper, cond)
+}
@@ -0,0 +1,91 @@
+package v2
+
+import (
+	"time"
+
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/patch"
+)
+
+// SlowLogStore is an Interface that logs the operations taking longer than a
+// threshold to complete.
+type SlowLogStore struct {
+	impl      Interface
+	threshold time.Duration
+}
+
+// NewSlowLogStore returns a SlowLogStore that logs the operations of s taking
+// longer than threshold.
+func NewSlowLogStore(s Interface, threshold time.Duration) *SlowLogStore {
+	return &SlowLogStore{impl: s, threshold: threshold}
+}
+
+func (s *SlowLogStore) observe(op string, req ResourceRequest, pred *store.SelectionPredicate) func() {
+	start := time.Now()
+	return func() {
+		key := req.StoreName
+		if req.Name != "" {
+			key += "/" + req.Name
+		}
+		store.LogSlowOperation(s.threshold, start, op, req.Namespace, key, pred)
+	}
+}
+
+// CreateOrUpdate creates or updates the wrapped resource.
+func (s *SlowLogStore) CreateOrUpdate(req ResourceRequest, wrapper Wrapper) error {
+	defer s.observe("CreateOrUpdate", req, nil)()
+	return s.impl.CreateOrUpdate(req, wrapper)
+}
+
+// UpdateIfExists updates the resource with the wrapped resource, but only
+// if it already exists in the store.
+func (s *SlowLogStore) UpdateIfExists(req ResourceRequest, wrapper Wrapper) error {
+	defer s.observe("UpdateIfExists", req, nil)()
+	return s.impl.UpdateIfExists(req, wrapper)
+}
+
+// CreateIfNotExists writes the wrapped resource to the store, but only if
+// it does not already exist.
+func (s *SlowLogStore) CreateIfNotExists(req ResourceRequest, wrapper Wrapper) error {
+	defer s.observe("CreateIfNotExists", req, nil)()
+	return s.impl.CreateIfNotExists(req, wrapper)
+}
+
+// Get gets a wrapped resource from the store.
+func (s *SlowLogStore) Get(req ResourceRequest) (Wrapper, error) {
+	defer s.observe("Get", req, nil)()
+	return s.impl.Get(req)
+}
+
+// Delete deletes a resource from the store.
+func (s *SlowLogStore) Delete(req ResourceRequest) error {
+	defer s.observe("Delete", req, nil)()
+	return s.impl.Delete(req)
+}
+
+// List lists all resources specified by the resource request, and the
+// selection predicate.
+func (s *SlowLogStore) List(req ResourceRequest, pred *store.SelectionPredicate) (WrapList, error) {
+	defer s.observe("List", req, pred)()
+	return s.impl.List(req, pred)
+}
+
+// Exists returns true if the resource indicated by the request exists
+func (s *SlowLogStore) Exists(req ResourceRequest) (bool, error) {
+	defer s.observe("Exists", req, nil)()
+	return s.impl.Exists(req)
+}
+
+// Patch patches the resource given in the request
+func (s *SlowLogStore) Patch(req ResourceRequest, wrapper Wrapper, patcher patch.Patcher, cond *store.ETagCondition) error {
+	defer s.observe("Patch", req, nil)()
+	return s.impl.Patch(req, wrapper, patcher, cond)
+}
+
+// UpdateIfMatch updates the resource with the wrapped resource, but only if it
+// exists in the store and the etag of the stored resource satisfies the
+// conditions when it is replaced.
+func (s *SlowLogStore) UpdateIfMatch(req ResourceRequest, wrapper Wrapper, cond *store.ETagCondition) error {
+	defer s.observe("UpdateIfMatch", req, nil)()
+	return s.impl.UpdateIfMatch(req, wrapper, cond)
+}
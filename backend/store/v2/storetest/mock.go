@@ -53,3 +53,8 @@ func (s *Store) Patch(req storev2.ResourceRequest, w storev2.Wrapper, patcher pa
 	args := s.Called(req, w, patcher, conditions)
 	return args.Error(0)
 }
+
+func (s *Store) UpdateIfMatch(req storev2.ResourceRequest, w storev2.Wrapper, conditions *store.ETagCondition) error {
+	args := s.Called(req, w, conditions)
+	return args.Error(0)
+}
@@ -19,6 +19,10 @@ var logger *logrus.Entry
 // implemented in Sensu Core.
 var ErrNotImplemented = errors.New("method not implemented")
 
+// ErrPreconditionFailed is returned when a resource is put based on a version
+// of the resource that is no longer the stored one.
+var ErrPreconditionFailed = errors.New("the resource was modified since it was read")
+
 // RestClient wraps resty.Client
 type RestClient struct {
 	resty  *resty.Client
@@ -28,6 +28,10 @@ const (
 	// FormatYAML indicates YAML format for printers. It has the same layout
 	// as wrapped JSON.
 	FormatYAML = "yaml"
+
+	// FormatMarkdown indicates markdown table format for printers. Only
+	// supported by some commands.
+	FormatMarkdown = "markdown"
 )
 
 // Config is an abstract configuration
@@ -2,6 +2,7 @@ package client
 
 import (
 	"encoding/json"
+	"time"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 )
@@ -71,3 +72,21 @@ func (client *RestClient) CreateEntity(entity *corev2.Entity) (err error) {
 
 	return nil
 }
+
+// ListStaleEntities lists the entities of a namespace that were not seen for
+// longer than olderThan
+func (client *RestClient) ListStaleEntities(namespace string, olderThan time.Duration) ([]corev2.Entity, error) {
+	path := EntitiesPath(namespace)
+	res, err := client.R().SetQueryParam("stale", olderThan.String()).Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+
+	var entities []corev2.Entity
+	err = json.Unmarshal(res.Body(), &entities)
+	return entities, err
+}
@@ -1,7 +1,13 @@
 package client
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"time"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
@@ -61,3 +67,67 @@ func (client *RestClient) ResolveEvent(event *corev2.Event) error {
 	event.Timestamp = event.Check.Executed
 	return client.UpdateEvent(event)
 }
+
+// WatchEvents streams to fn the events of the configured namespace as they are
+// processed by the backend, optionally limited to those of an entity and a
+// check. It returns when ctx is done, fn returns an error or the stream ends,
+// the backend bounding the duration of the streams. A stream cut once it was
+// established ends without error, the caller being expected to watch again.
+func (client *RestClient) WatchEvents(ctx context.Context, entity, check string, fn func(*corev2.Event) error) error {
+	params := map[string]string{"watch": "true"}
+	if entity != "" {
+		params["entity"] = entity
+	}
+	if check != "" {
+		params["check"] = check
+	}
+
+	path := EventsPath(client.config.Namespace())
+	res, err := client.R().
+		SetContext(ctx).
+		SetDoNotParseResponse(true).
+		SetHeader("Accept", "text/event-stream").
+		SetQueryParams(params).
+		Get(path)
+	if err != nil {
+		return err
+	}
+	body := res.RawBody()
+	defer body.Close()
+
+	if res.StatusCode() >= 400 {
+		var apiErr APIError
+		b, _ := ioutil.ReadAll(body)
+		if err := json.Unmarshal(b, &apiErr); err != nil {
+			apiErr.Message = fmt.Sprintf("the API returned: %s", res.Status())
+		}
+		return apiErr
+	}
+
+	reader := bufio.NewReader(body)
+	for {
+		// The line is incomplete when an error is returned
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err != io.EOF {
+				logger.WithError(err).Debug("event stream ended")
+			}
+			return nil
+		}
+		data := bytes.TrimPrefix(line, []byte("data: "))
+		if len(data) == len(line) {
+			// Comments and empty lines
+			continue
+		}
+		var event corev2.Event
+		if err := json.Unmarshal(data, &event); err != nil {
+			return err
+		}
+		if err := fn(&event); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli/client/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchEvents(t *testing.T) {
+	testHandler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/core/v2/namespaces/default/events", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("watch"))
+		assert.Equal(t, "foo", r.URL.Query().Get("entity"))
+		assert.Empty(t, r.URL.Query().Get("check"))
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte(":\n\n"))
+		_, _ = w.Write([]byte(`data: {"entity":{"metadata":{"name":"foo"}},"check":{"metadata":{"name":"check-cpu"}}}` + "\n\n"))
+		_, _ = w.Write([]byte(`data: {"entity":{"metadata":{"name":"foo"}},"check":{"metadata":{"name":"check-disk"}}}` + "\n\n"))
+	}
+	server := httptest.NewServer(http.HandlerFunc(testHandler))
+	defer server.Close()
+
+	mockConfig := &config.MockConfig{}
+	client := &RestClient{resty: resty.New(), config: mockConfig}
+	mockConfig.On("APIUrl").Return(server.URL)
+	mockConfig.On("Tokens").Return(&corev2.Tokens{Access: "foo"})
+	mockConfig.On("APIKey").Return("")
+	mockConfig.On("Namespace").Return("default")
+
+	var checks []string
+	err := client.WatchEvents(context.Background(), "foo", "", func(event *corev2.Event) error {
+		checks = append(checks, event.Check.Name)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"check-cpu", "check-disk"}, checks)
+}
+
+func TestWatchEventsError(t *testing.T) {
+	testHandler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"forbidden","code":7}`))
+	}
+	server := httptest.NewServer(http.HandlerFunc(testHandler))
+	defer server.Close()
+
+	mockConfig := &config.MockConfig{}
+	client := &RestClient{resty: resty.New(), config: mockConfig}
+	mockConfig.On("APIUrl").Return(server.URL)
+	mockConfig.On("Tokens").Return(&corev2.Tokens{Access: "foo"})
+	mockConfig.On("APIKey").Return("")
+	mockConfig.On("Namespace").Return("default")
+
+	err := client.WatchEvents(context.Background(), "", "", func(*corev2.Event) error { return nil })
+	require.Error(t, err)
+	assert.Equal(t, "forbidden", err.(APIError).Message)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
 )
 
 // FiltersPath is the api path for filters.
@@ -71,3 +72,26 @@ func (client *RestClient) UpdateFilter(f *corev2.EventFilter) error {
 
 	return err
 }
+
+// TestFilter evaluates an event against a filter on configured Sensu
+// instance, without handling the event.
+func (client *RestClient) TestFilter(name string, event *corev2.Event) (*actions.FilterTestResult, error) {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	path := FiltersPath(client.config.Namespace(), name, "test")
+	res, err := client.R().SetBody(b).Post(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+
+	var result actions.FilterTestResult
+	err = json.Unmarshal(res.Body(), &result)
+	return &result, err
+}
@@ -40,6 +40,21 @@ func (client *RestClient) Get(path string, obj interface{}) error {
 	return nil
 }
 
+// GetWithETag sends a GET request for an object at the given path, and returns
+// the ETag of the response, which identifies the version of the object.
+func (client *RestClient) GetWithETag(path string, obj interface{}) (string, error) {
+	res, err := client.R().SetResult(obj).Get(path)
+	if err != nil {
+		return "", err
+	}
+
+	if res.StatusCode() >= 400 {
+		return "", UnmarshalError(res)
+	}
+
+	return res.Header().Get("ETag"), nil
+}
+
 // List sends a GET request for all objects at the given path.
 // The options parameter allows for enhancing the request with field/label
 // selectors (filtering), pagination, ...
@@ -166,6 +181,14 @@ func (client *RestClient) Put(path string, obj interface{}) error {
 
 // PutResource ...
 func (client *RestClient) PutResource(r types.Wrapper) error {
+	return client.PutResourceIfMatch(r, "")
+}
+
+// PutResourceIfMatch puts a resource according to its URIPath, but only if the
+// stored resource still has the given ETag, as returned by GetWithETag. The
+// condition is ignored if etag is empty, or by the APIs that do not support
+// it.
+func (client *RestClient) PutResourceIfMatch(r types.Wrapper, etag string) error {
 	var path string
 	switch value := r.Value.(type) {
 	case corev2.Resource:
@@ -187,10 +210,17 @@ func (client *RestClient) PutResource(r types.Wrapper) error {
 		return err
 	}
 
-	res, err := client.R().SetBody(bytes).Put(path)
+	req := client.R().SetBody(bytes)
+	if etag != "" {
+		req.SetHeader("If-Match", etag)
+	}
+	res, err := req.Put(path)
 	if err != nil {
 		return fmt.Errorf("PUT %q: %s", path, err)
 	}
+	if res.StatusCode() == http.StatusPreconditionFailed {
+		return fmt.Errorf("PUT %q: %w", path, ErrPreconditionFailed)
+	}
 	if res.StatusCode() >= 400 {
 		return UnmarshalError(res)
 	}
@@ -1,10 +1,13 @@
 package client
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/types"
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
@@ -62,6 +65,9 @@ type GenericClient interface {
 	Delete(path string) error
 	// Get retrieves the key at the given path and stores it into obj
 	Get(path string, obj interface{}) error
+	// GetWithETag retrieves the key at the given path, stores it into obj and
+	// returns its etag
+	GetWithETag(path string, obj interface{}) (string, error)
 	// List retrieves all keys with the given path prefix and stores them into objs
 	List(path string, objs interface{}, options *ListOptions, header *http.Header) error
 	// Post creates the given obj at the specified path
@@ -73,6 +79,9 @@ type GenericClient interface {
 
 	// PutResource puts a resource according to its URIPath.
 	PutResource(types.Wrapper) error
+	// PutResourceIfMatch puts a resource according to its URIPath, if the
+	// stored resource still has the given etag.
+	PutResourceIfMatch(types.Wrapper, string) error
 }
 
 // AuthenticationAPIClient client methods for authenticating
@@ -122,6 +131,7 @@ type EntityAPIClient interface {
 	DeleteEntity(string, string) error
 	FetchEntity(ID string) (*corev2.Entity, error)
 	UpdateEntity(entity *corev2.Entity) error
+	ListStaleEntities(namespace string, olderThan time.Duration) ([]corev2.Entity, error)
 }
 
 // FilterAPIClient client methods for filters
@@ -130,6 +140,7 @@ type FilterAPIClient interface {
 	DeleteFilter(string, string) error
 	FetchFilter(string) (*corev2.EventFilter, error)
 	UpdateFilter(*corev2.EventFilter) error
+	TestFilter(string, *corev2.Event) (*actions.FilterTestResult, error)
 }
 
 // EventAPIClient client methods for events
@@ -140,6 +151,7 @@ type EventAPIClient interface {
 	DeleteEvent(namespace, entity, check string) error
 	UpdateEvent(*corev2.Event) error
 	ResolveEvent(*corev2.Event) error
+	WatchEvents(ctx context.Context, entity, check string, fn func(*corev2.Event) error) error
 }
 
 // HandlerAPIClient client methods for handlers
@@ -176,6 +188,7 @@ type NamespaceAPIClient interface {
 	CreateNamespace(*corev2.Namespace) error
 	UpdateNamespace(*corev2.Namespace) error
 	DeleteNamespace(string) error
+	DeleteNamespaceCascade(string) (map[string]int, error)
 	FetchNamespace(string) (*corev2.Namespace, error)
 }
 
@@ -230,6 +243,10 @@ type SilencedAPIClient interface {
 
 	// UpdateSilenced updates an existing silenced entry.
 	UpdateSilenced(*corev2.Silenced) error
+
+	// CreateSilencedFromSelector creates the silenced entries matching a
+	// selector in the given namespace.
+	CreateSilencedFromSelector(namespace string, selector *corev2.SilencedSelector) ([]corev2.Silenced, error)
 }
 
 // ClusterMemberClient specifies client methods for cluster membership management.
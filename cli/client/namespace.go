@@ -55,6 +55,29 @@ func (client *RestClient) DeleteNamespace(namespace string) error {
 	return client.Delete(NamespacesPath(namespace))
 }
 
+// DeleteNamespaceCascade deletes a namespace along with all of its resources on
+// configured Sensu instance, and returns the number of resources deleted by
+// type.
+func (client *RestClient) DeleteNamespaceCascade(namespace string) (map[string]int, error) {
+	deleted := map[string]int{}
+
+	path := NamespacesPath(namespace)
+	res, err := client.R().SetQueryParam("cascade", "true").Delete(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+
+	if len(res.Body()) == 0 {
+		return deleted, nil
+	}
+	err = json.Unmarshal(res.Body(), &deleted)
+	return deleted, err
+}
+
 // FetchNamespace fetches an namespace by name
 func (client *RestClient) FetchNamespace(namespaceName string) (*corev2.Namespace, error) {
 	var namespace *corev2.Namespace
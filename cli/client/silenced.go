@@ -29,6 +29,29 @@ func (client *RestClient) CreateSilenced(silenced *corev2.Silenced) error {
 	return nil
 }
 
+// CreateSilencedFromSelector creates the silenced entries matching a selector
+// and returns them.
+func (client *RestClient) CreateSilencedFromSelector(namespace string, selector *corev2.SilencedSelector) ([]corev2.Silenced, error) {
+	b, err := json.Marshal(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	path := silencedPath(namespace, "bulk")
+	res, err := client.R().SetBody(b).Post(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+
+	var result []corev2.Silenced
+	err = json.Unmarshal(res.Body(), &result)
+	return result, err
+}
+
 // DeleteSilenced deletes a silenced entry.
 func (client *RestClient) DeleteSilenced(namespace, name string) error {
 	return client.Delete(silencedPath(namespace, name))
@@ -1,6 +1,8 @@
 package testing
 
 import (
+	"time"
+
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 )
 
@@ -27,3 +29,9 @@ func (c *MockClient) CreateEntity(entity *corev2.Entity) error {
 	args := c.Called(entity)
 	return args.Error(0)
 }
+
+// ListStaleEntities for use with mock lib
+func (c *MockClient) ListStaleEntities(namespace string, olderThan time.Duration) ([]corev2.Entity, error) {
+	args := c.Called(namespace, olderThan)
+	return args.Get(0).([]corev2.Entity), args.Error(1)
+}
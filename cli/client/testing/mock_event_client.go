@@ -1,6 +1,8 @@
 package testing
 
 import (
+	"context"
+
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 )
 
@@ -27,3 +29,9 @@ func (c *MockClient) ResolveEvent(event *corev2.Event) error {
 	args := c.Called(event)
 	return args.Error(0)
 }
+
+// WatchEvents for use with mock lib
+func (c *MockClient) WatchEvents(ctx context.Context, entity, check string, fn func(*corev2.Event) error) error {
+	args := c.Called(ctx, entity, check, fn)
+	return args.Error(0)
+}
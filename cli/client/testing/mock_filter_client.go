@@ -2,6 +2,7 @@ package testing
 
 import (
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
 )
 
 // CreateFilter for use with mock lib
@@ -27,3 +28,9 @@ func (c *MockClient) UpdateFilter(filter *corev2.EventFilter) error {
 	args := c.Called(filter)
 	return args.Error(0)
 }
+
+// TestFilter for use with mock lib
+func (c *MockClient) TestFilter(name string, event *corev2.Event) (*actions.FilterTestResult, error) {
+	args := c.Called(name, event)
+	return args.Get(0).(*actions.FilterTestResult), args.Error(1)
+}
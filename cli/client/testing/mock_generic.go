@@ -20,6 +20,12 @@ func (c *MockClient) Get(path string, obj interface{}) error {
 	return args.Error(0)
 }
 
+// GetWithETag ...
+func (c *MockClient) GetWithETag(path string, obj interface{}) (string, error) {
+	args := c.Called(path, obj)
+	return args.String(0), args.Error(1)
+}
+
 // List ...
 func (c *MockClient) List(path string, objs interface{}, options *client.ListOptions, header *http.Header) error {
 	args := c.Called(path, objs, options, header)
@@ -49,3 +55,9 @@ func (c *MockClient) PutResource(r types.Wrapper) error {
 	args := c.Called(r)
 	return args.Error(0)
 }
+
+// PutResourceIfMatch ...
+func (c *MockClient) PutResourceIfMatch(r types.Wrapper, etag string) error {
+	args := c.Called(r, etag)
+	return args.Error(0)
+}
@@ -22,6 +22,12 @@ func (c *MockClient) DeleteNamespace(namespace string) error {
 	return args.Error(0)
 }
 
+// DeleteNamespaceCascade for use with mock lib
+func (c *MockClient) DeleteNamespaceCascade(namespace string) (map[string]int, error) {
+	args := c.Called(namespace)
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
 // FetchNamespace for use with mock lib
 func (c *MockClient) FetchNamespace(namespace string) (*corev2.Namespace, error) {
 	args := c.Called(namespace)
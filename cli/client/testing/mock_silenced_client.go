@@ -15,6 +15,12 @@ func (c *MockClient) CreateSilenced(silenced *types.Silenced) error {
 	return args.Error(0)
 }
 
+// CreateSilencedFromSelector for use with mock lib
+func (c *MockClient) CreateSilencedFromSelector(namespace string, selector *corev2.SilencedSelector) ([]corev2.Silenced, error) {
+	args := c.Called(namespace, selector)
+	return args.Get(0).([]corev2.Silenced), args.Error(1)
+}
+
 // UpdateSilenced for use with mock lib
 func (c *MockClient) UpdateSilenced(silenced *types.Silenced) error {
 	args := c.Called(silenced)
@@ -23,6 +23,8 @@ func HelpCommand(cli *cli.SensuCli) *cobra.Command {
 		ExecuteCommand(cli),
 		ListCommand(cli),
 		InfoCommand(cli),
+		LintCommand(cli),
+		NextRunCommand(cli),
 		UpdateCommand(cli),
 
 		// Remove commands (clear out fields)
@@ -70,6 +70,18 @@ func printToList(v interface{}, writer io.Writer) error {
 				Label: "Cron",
 				Value: r.Cron,
 			},
+			{
+				Label: "Cron Timezone",
+				Value: r.CronTimezone,
+			},
+			{
+				Label: "User",
+				Value: r.User,
+			},
+			{
+				Label: "Group",
+				Value: r.Group,
+			},
 			{
 				Label: "Timeout",
 				Value: strconv.FormatInt(int64(r.Timeout), 10),
@@ -22,6 +22,7 @@ type checkOpts struct {
 	Command              string `survey:"command"`
 	Interval             string `survey:"interval"`
 	Cron                 string `survey:"cron"`
+	CronTimezone         string
 	Subscriptions        string `survey:"subscriptions"`
 	Handlers             string `survey:"handlers"`
 	RuntimeAssets        string `survey:"assets"`
@@ -52,6 +53,7 @@ func (opts *checkOpts) withCheck(check *types.CheckConfig) {
 	opts.Command = check.Command
 	opts.Interval = strconv.Itoa(int(check.Interval))
 	opts.Cron = check.Cron
+	opts.CronTimezone = check.CronTimezone
 	opts.Subscriptions = strings.Join(check.Subscriptions, ",")
 	opts.Handlers = strings.Join(check.Handlers, ",")
 	opts.RuntimeAssets = strings.Join(check.RuntimeAssets, ",")
@@ -70,6 +72,7 @@ func (opts *checkOpts) withFlags(flags *pflag.FlagSet) {
 	opts.Command, _ = flags.GetString("command")
 	opts.Interval, _ = flags.GetString("interval")
 	opts.Cron, _ = flags.GetString("cron")
+	opts.CronTimezone, _ = flags.GetString("cron-timezone")
 	opts.Subscriptions, _ = flags.GetString("subscriptions")
 	opts.Handlers, _ = flags.GetString("handlers")
 	opts.RuntimeAssets, _ = flags.GetString("runtime-assets")
@@ -285,6 +288,7 @@ func (opts *checkOpts) Copy(check *types.CheckConfig) {
 	check.Interval = uint32(interval)
 	check.Command = opts.Command
 	check.Cron = opts.Cron
+	check.CronTimezone = opts.CronTimezone
 	check.Subscriptions = helpers.SafeSplitCSV(opts.Subscriptions)
 	check.Handlers = helpers.SafeSplitCSV(opts.Handlers)
 	check.RuntimeAssets = helpers.SafeSplitCSV(opts.RuntimeAssets)
@@ -0,0 +1,143 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/types"
+	"github.com/spf13/cobra"
+)
+
+// genericNameTokens are the words commonly found in asset and plugin names
+// that are too generic to associate a binary with an asset.
+var genericNameTokens = map[string]struct{}{
+	"sensu":   {},
+	"plugin":  {},
+	"plugins": {},
+	"check":   {},
+	"checks":  {},
+	"metric":  {},
+	"metrics": {},
+	"go":      {},
+	"ruby":    {},
+	"python":  {},
+	"runtime": {},
+}
+
+// LintCommand defines a new command to lint the command of a check
+func LintCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "lint [NAME]",
+		Short:        "warn when the command of a check does not seem to be provided by its runtime assets",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			check, err := cli.Client.FetchCheck(args[0])
+			if err != nil {
+				return err
+			}
+
+			var assets []*types.Asset
+			var warnings []string
+			for _, name := range check.RuntimeAssets {
+				asset, err := cli.Client.FetchAsset(name)
+				if err != nil {
+					warnings = append(warnings, fmt.Sprintf("runtime asset %q could not be fetched: %s", name, err))
+					continue
+				}
+				assets = append(assets, asset)
+			}
+			warnings = append(warnings, lintCheckCommand(check, assets)...)
+
+			printLintWarnings(cmd.OutOrStdout(), check.Name, warnings)
+
+			if strict, _ := cmd.Flags().GetBool("strict"); strict && len(warnings) > 0 {
+				return fmt.Errorf("check %q has %d lint warning(s)", check.Name, len(warnings))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("strict", false, "exit with an error when warnings are found")
+
+	return cmd
+}
+
+// lintCheckCommand returns warnings when the binary invoked by the check, the
+// first element of its argv or else of its command, is not obviously provided
+// by one of the given assets. It is a heuristic based on the names of the
+// binary and of the assets.
+func lintCheckCommand(check *types.CheckConfig, assets []*types.Asset) []string {
+	binary := commandBinary(check.Command)
+	if len(check.Argv) > 0 {
+		binary = check.Argv[0]
+	}
+	if binary == "" {
+		return []string{"the check command is empty"}
+	}
+	if path.IsAbs(binary) {
+		// The binary is explicitly provided by the host
+		return nil
+	}
+	if len(check.RuntimeAssets) == 0 {
+		return []string{fmt.Sprintf("the check has no runtime assets, %q must be installed on the agents", binary)}
+	}
+
+	binaryTokens := nameTokens(binary)
+	for _, asset := range assets {
+		for token := range nameTokens(asset.Name) {
+			if _, ok := binaryTokens[token]; ok {
+				return nil
+			}
+		}
+	}
+	return []string{fmt.Sprintf("%q does not seem to be provided by any of the runtime assets (%s)", binary, strings.Join(check.RuntimeAssets, ", "))}
+}
+
+// commandBinary returns the binary invoked by the command, skipping leading
+// environment variable assignments.
+func commandBinary(command string) string {
+	for _, field := range strings.Fields(command) {
+		if strings.Contains(field, "=") && !strings.HasPrefix(field, "=") {
+			continue
+		}
+		return strings.Trim(field, `"'`)
+	}
+	return ""
+}
+
+// nameTokens splits the base name of a binary or an asset into lowercase
+// words, ignoring its extension, generic words and words shorter than three
+// characters, such as version numbers.
+func nameTokens(name string) map[string]struct{} {
+	name = strings.ToLower(path.Base(name))
+	name = strings.TrimSuffix(name, path.Ext(name))
+	tokens := map[string]struct{}{}
+	for _, token := range strings.FieldsFunc(name, func(r rune) bool {
+		return r == '-' || r == '_' || r == '.'
+	}) {
+		if _, ok := genericNameTokens[token]; ok || len(token) < 3 {
+			continue
+		}
+		tokens[token] = struct{}{}
+	}
+	return tokens
+}
+
+func printLintWarnings(w io.Writer, name string, warnings []string) {
+	if len(warnings) == 0 {
+		fmt.Fprintf(w, "No issues found for check %q\n", name)
+		return
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(w, "warning: %s\n", warning)
+	}
+}
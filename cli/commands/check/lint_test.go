@@ -0,0 +1,139 @@
+package check
+
+import (
+	"errors"
+	"testing"
+
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/sensu/sensu-go/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewCLI()
+	cmd := LintCommand(cli)
+
+	assert.NotNil(cmd, "cmd should be returned")
+	assert.NotNil(cmd.RunE, "cmd should be able to be executed")
+	assert.Regexp("lint", cmd.Use)
+	assert.Regexp("check", cmd.Short)
+}
+
+func TestLintCommandRunMissingArgs(t *testing.T) {
+	cli := test.NewCLI()
+	cmd := LintCommand(cli)
+	out, err := test.RunCmd(cmd, []string{})
+	require.Error(t, err)
+	assert.Contains(t, out, "Usage")
+}
+
+func TestLintCommandRunEClosure(t *testing.T) {
+	check := types.FixtureCheckConfig("check-disk")
+	check.Command = "check-disk-usage.rb -w 80"
+	check.RuntimeAssets = []string{"sensu-plugins-disk-checks", "sensu-ruby-runtime"}
+
+	cli := test.NewCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("FetchCheck", "check-disk").Return(check, nil)
+	client.On("FetchAsset", "sensu-plugins-disk-checks").Return(types.FixtureAsset("sensu-plugins-disk-checks"), nil)
+	client.On("FetchAsset", "sensu-ruby-runtime").Return(types.FixtureAsset("sensu-ruby-runtime"), nil)
+
+	cmd := LintCommand(cli)
+	out, err := test.RunCmd(cmd, []string{"check-disk"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "No issues found")
+}
+
+func TestLintCommandRunEClosureWarnings(t *testing.T) {
+	check := types.FixtureCheckConfig("check-cpu")
+	check.Command = "check-cpu-usage -w 75"
+	check.RuntimeAssets = []string{"sensu-plugins-disk-checks", "missing"}
+
+	cli := test.NewCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("FetchCheck", "check-cpu").Return(check, nil)
+	client.On("FetchAsset", "sensu-plugins-disk-checks").Return(types.FixtureAsset("sensu-plugins-disk-checks"), nil)
+	client.On("FetchAsset", "missing").Return((*types.Asset)(nil), errors.New("not found"))
+
+	cmd := LintCommand(cli)
+	out, err := test.RunCmd(cmd, []string{"check-cpu"})
+	require.NoError(t, err)
+	assert.Contains(t, out, `runtime asset "missing" could not be fetched`)
+	assert.Contains(t, out, `"check-cpu-usage" does not seem to be provided`)
+
+	cmd = LintCommand(cli)
+	require.NoError(t, cmd.Flags().Set("strict", "true"))
+	_, err = test.RunCmd(cmd, []string{"check-cpu"})
+	assert.Error(t, err)
+}
+
+func TestLintCheckCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  string
+		argv     []string
+		assets   []string
+		warnings int
+	}{
+		{
+			name:     "absolute path",
+			command:  "/usr/lib64/nagios/plugins/check_load",
+			warnings: 0,
+		},
+		{
+			name:     "no assets",
+			command:  "check-cpu-usage",
+			warnings: 1,
+		},
+		{
+			name:     "env assignment",
+			command:  "FOO=bar http-check --url localhost",
+			assets:   []string{"sensu/http-checks"},
+			warnings: 0,
+		},
+		{
+			name:     "unrelated asset",
+			command:  "check-memory",
+			assets:   []string{"sensu-plugins-disk-checks"},
+			warnings: 1,
+		},
+		{
+			name:     "argv",
+			argv:     []string{"http-check", "--url", "localhost"},
+			assets:   []string{"sensu/http-checks"},
+			warnings: 0,
+		},
+		{
+			name:     "argv without assets",
+			argv:     []string{"check-cpu-usage"},
+			warnings: 1,
+		},
+		{
+			name:     "argv with absolute path",
+			argv:     []string{"/usr/lib64/nagios/plugins/check_load", "-w", "1"},
+			warnings: 0,
+		},
+		{
+			name:     "empty command",
+			command:  "  ",
+			warnings: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := types.FixtureCheckConfig("check")
+			check.Command = tt.command
+			check.Argv = tt.argv
+			check.RuntimeAssets = tt.assets
+			var assets []*types.Asset
+			for _, name := range tt.assets {
+				assets = append(assets, types.FixtureAsset(name))
+			}
+			assert.Len(t, lintCheckCommand(check, assets), tt.warnings)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/types"
+	"github.com/sensu/sensu-go/util/schedule"
+	"github.com/spf13/cobra"
+)
+
+// NextRunCommand defines a new command to preview the next executions of a
+// check
+func NextRunCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "next-run [NAME]",
+		Short:        "show the next execution times of a check, accounting for its interval or cron and its subdues",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			count, err := cmd.Flags().GetInt("count")
+			if err != nil {
+				return err
+			}
+			if count < 1 {
+				return errors.New("count must be greater than 0")
+			}
+
+			check, err := cli.Client.FetchCheck(args[0])
+			if err != nil {
+				return err
+			}
+
+			times, err := schedule.NextExecutions(check, time.Now(), count)
+			if err != nil {
+				return fmt.Errorf("check %q can't be scheduled: %s", check.Name, err)
+			}
+			printNextRuns(cmd.OutOrStdout(), check, times)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntP("count", "n", 5, "number of execution times to show")
+
+	return cmd
+}
+
+func printNextRuns(w io.Writer, check *types.CheckConfig, times []time.Time) {
+	if !check.Publish {
+		fmt.Fprintf(w, "Check %q is not published, it will not be scheduled until it is.\n", check.Name)
+	}
+	if check.RoundRobin {
+		fmt.Fprintf(w, "Check %q is round robin, its executions are spread over its subscribers and may occur at other times.\n", check.Name)
+	}
+	if len(times) == 0 {
+		fmt.Fprintf(w, "Check %q is subdued for the next %d days.\n", check.Name, schedule.Horizon/(24*time.Hour))
+		return
+	}
+	for _, t := range times {
+		fmt.Fprintln(w, t.Local().Format(time.RFC3339))
+	}
+}
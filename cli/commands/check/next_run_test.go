@@ -0,0 +1,57 @@
+package check
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/sensu/sensu-go/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextRunCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewCLI()
+	cmd := NextRunCommand(cli)
+
+	assert.NotNil(cmd, "cmd should be returned")
+	assert.NotNil(cmd.RunE, "cmd should be able to be executed")
+	assert.Regexp("next-run", cmd.Use)
+	assert.Regexp("execution times", cmd.Short)
+}
+
+func TestNextRunCommandRunEClosure(t *testing.T) {
+	check := types.FixtureCheckConfig("check-cpu")
+	check.Interval = 60
+
+	cli := test.NewCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("FetchCheck", "check-cpu").Return(check, nil)
+
+	cmd := NextRunCommand(cli)
+	require.NoError(t, cmd.Flags().Set("count", "3"))
+	out, err := test.RunCmd(cmd, []string{"check-cpu"})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 3)
+	for _, line := range lines {
+		_, err := time.Parse(time.RFC3339, line)
+		assert.NoError(t, err)
+	}
+}
+
+func TestNextRunCommandRunEWithError(t *testing.T) {
+	cli := test.NewCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("FetchCheck", "check-cpu").Return((*types.CheckConfig)(nil), errors.New("error"))
+
+	cmd := NextRunCommand(cli)
+	_, err := test.RunCmd(cmd, []string{"check-cpu"})
+	assert.Error(t, err)
+}
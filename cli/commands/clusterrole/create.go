@@ -71,6 +71,11 @@ func CreateCommand(cli *cli.SensuCli) *cobra.Command {
 			}
 			rule.ResourceNames = resourceNames
 
+			rule.ResourceNamesRegex, err = cmd.Flags().GetBool("resource-names-regex")
+			if err != nil {
+				return err
+			}
+
 			// Assign the rule to our cluster role and validate it
 			clusterRole.Rules = []types.Rule{rule}
 			if err := clusterRole.Validate(); err != nil {
@@ -104,6 +109,9 @@ func CreateCommand(cli *cli.SensuCli) *cobra.Command {
 	_ = cmd.Flags().StringSliceP("resource-name", "n", []string{},
 		"optional resource names that the rule applies to",
 	)
+	_ = cmd.Flags().Bool("resource-names-regex", false,
+		"match resource names as regular expressions of the whole name",
+	)
 
 	cmd.Flags().MarkDeprecated("resource", "please use resources instead.")
 	cmd.Flags().MarkDeprecated("verb", "please use verbs instead.")
@@ -77,7 +77,11 @@ func printRulesToTable(v interface{}, io io.Writer) error {
 				if !ok {
 					return cli.TypeError
 				}
-				return strings.Join(rule.ResourceNames, ",")
+				names := strings.Join(rule.ResourceNames, ",")
+				if rule.ResourceNamesRegex && names != "" {
+					names += " (regex)"
+				}
+				return names
 			},
 		},
 	})
@@ -0,0 +1,333 @@
+package debug
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/client"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// loadEntityPrefix prefixes the names of the synthetic entities.
+	loadEntityPrefix = "sensuctl-load-"
+
+	// loadCheckName is the name of the check of the synthetic events.
+	loadCheckName = "sensuctl-load"
+
+	// loadPollInterval is the interval at which the stored synthetic events
+	// are polled, until the backend processed all the accepted events.
+	loadPollInterval = 500 * time.Millisecond
+)
+
+// loadClient submits the synthetic events, polls them and deletes them
+// afterward.
+type loadClient interface {
+	UpdateEvent(*corev2.Event) error
+	FetchEvent(entity, check string) (*corev2.Event, error)
+	DeleteEvent(namespace, entity, check string) error
+	DeleteEntity(namespace, name string) error
+}
+
+// loadConfig describes the synthetic load to generate.
+type loadConfig struct {
+	Namespace   string
+	Rate        float64
+	Duration    time.Duration
+	Entities    int
+	Concurrency int
+	WaitTimeout time.Duration
+}
+
+// loadResult is the outcome of the submission of the synthetic events.
+type loadResult struct {
+	Sent       int
+	Accepted   int
+	Rejections map[string]int
+	Latencies  []time.Duration
+	Elapsed    time.Duration
+
+	// AcceptedByEntity is the number of events accepted by synthetic entity.
+	AcceptedByEntity map[string]int
+
+	// Unprocessed is the number of accepted events that the backend did not
+	// store within the wait timeout, because they were dropped or are still
+	// queued.
+	Unprocessed int
+}
+
+// LoadEventsCommand submits synthetic events to the backend
+func LoadEventsCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "load-events",
+		Short: "submit synthetic events to the backend and report its ingestion throughput",
+		Long: "Submit synthetic events to the backend through the events API at a " +
+			"configured rate, and report the achieved throughput, the latency " +
+			"percentiles and the rejected events. The events API accepts an event " +
+			"once it is queued for processing, so the latency measures the " +
+			"submission only, and the events dropped by the backend afterward are " +
+			"reported as unprocessed once the backend is done. The synthetic " +
+			"entities and events are then deleted, even if the command is " +
+			"interrupted.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+			cfg := loadConfig{Namespace: cli.Config.Namespace()}
+			var err error
+			if cfg.Rate, err = cmd.Flags().GetFloat64("rate"); err != nil {
+				return err
+			}
+			if cfg.Duration, err = cmd.Flags().GetDuration("duration"); err != nil {
+				return err
+			}
+			if cfg.Entities, err = cmd.Flags().GetInt("entities"); err != nil {
+				return err
+			}
+			if cfg.Concurrency, err = cmd.Flags().GetInt("concurrency"); err != nil {
+				return err
+			}
+			if cfg.WaitTimeout, err = cmd.Flags().GetDuration("wait-timeout"); err != nil {
+				return err
+			}
+			if cfg.Rate <= 0 || cfg.Duration <= 0 || cfg.Entities <= 0 || cfg.Concurrency <= 0 {
+				return errors.New("--rate, --duration, --entities and --concurrency must be positive")
+			}
+
+			// An interrupt stops the submission, but not the cleanup
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			fmt.Fprintf(cmd.OutOrStdout(), "Submitting %g events/s for %s...\n", cfg.Rate, cfg.Duration)
+			result := loadEvents(ctx, cli.Client, cfg)
+			stop()
+
+			// The synthetic events are deleted once the backend processed them,
+			// so that they are not recreated afterward
+			fmt.Fprintf(cmd.OutOrStdout(), "Waiting for the backend to process the events...\n")
+			result.Unprocessed = waitForLoad(context.Background(), cli.Client, cfg, result.AcceptedByEntity)
+			result.print(cmd.OutOrStdout())
+
+			return cleanupLoad(cli.Client, cfg)
+		},
+	}
+
+	_ = cmd.Flags().Float64("rate", 10, "number of events submitted per second")
+	_ = cmd.Flags().Duration("duration", 10*time.Second, "duration of the submission of events")
+	_ = cmd.Flags().Int("entities", 10, "number of synthetic entities the events are spread over")
+	_ = cmd.Flags().Int("concurrency", 10, "maximum number of events submitted concurrently")
+	_ = cmd.Flags().Duration("wait-timeout", 30*time.Second, "maximum duration to wait for the backend to process the accepted events before deleting them")
+
+	return cmd
+}
+
+// loadEvents submits synthetic events at the configured rate until the
+// configured duration elapsed, and waits for the pending submissions.
+func loadEvents(ctx context.Context, c loadClient, cfg loadConfig) *loadResult {
+	ctx, cancel := context.WithTimeout(ctx, cfg.Duration)
+	defer cancel()
+
+	burst := int(cfg.Rate)
+	if burst < 1 {
+		burst = 1
+	}
+	limiter := rate.NewLimiter(rate.Limit(cfg.Rate), burst)
+	sem := make(chan struct{}, cfg.Concurrency)
+	result := &loadResult{Rejections: map[string]int{}, AcceptedByEntity: map[string]int{}}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; ; i++ {
+		// Wait returns an error once ctx is done, or when the next event could
+		// not be sent before it is
+		if err := limiter.Wait(ctx); err != nil {
+			break
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		event := syntheticEvent(cfg.Namespace, i%cfg.Entities)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			begin := time.Now()
+			err := c.UpdateEvent(event)
+			latency := time.Since(begin)
+
+			mu.Lock()
+			defer mu.Unlock()
+			result.Sent++
+			if err != nil {
+				result.Rejections[err.Error()]++
+				return
+			}
+			result.Accepted++
+			result.AcceptedByEntity[event.Entity.Name]++
+			result.Latencies = append(result.Latencies, latency)
+		}()
+	}
+	wg.Wait()
+	result.Elapsed = time.Since(start)
+
+	return result
+}
+
+// syntheticEvent returns a passing event of the synthetic entity n. Its check
+// has no history, so that the occurrences of the stored event count the
+// events processed by the backend, and it skips the default handlers of the
+// namespace, so that the load does not reach them.
+func syntheticEvent(namespace string, n int) *corev2.Event {
+	now := time.Now().Unix()
+	entity := &corev2.Entity{
+		ObjectMeta:  corev2.NewObjectMeta(fmt.Sprintf("%s%d", loadEntityPrefix, n), namespace),
+		EntityClass: corev2.EntityProxyClass,
+	}
+
+	check := &corev2.Check{
+		ObjectMeta: corev2.ObjectMeta{
+			Name:      loadCheckName,
+			Namespace: namespace,
+			Annotations: map[string]string{
+				corev2.CheckSkipDefaultHandlersAnnotation: "true",
+			},
+		},
+		Interval: 60,
+		Output:   "synthetic event submitted by sensuctl debug load-events",
+		Issued:   now,
+		Executed: now,
+		State:    corev2.EventPassingState,
+	}
+
+	event := corev2.NewEvent(corev2.NewObjectMeta("", namespace))
+	event.Entity = entity
+	event.Check = check
+	event.Timestamp = now
+	return event
+}
+
+// waitForLoad polls the stored synthetic events until the backend processed
+// the events accepted for each entity, as counted by the occurrences of their
+// check, or until the wait timeout of cfg expired. It returns the number of
+// accepted events that were not processed.
+func waitForLoad(ctx context.Context, c loadClient, cfg loadConfig, accepted map[string]int) int {
+	ctx, cancel := context.WithTimeout(ctx, cfg.WaitTimeout)
+	defer cancel()
+
+	pending := make(map[string]int, len(accepted))
+	for name, count := range accepted {
+		pending[name] = count
+	}
+	ticker := time.NewTicker(loadPollInterval)
+	defer ticker.Stop()
+	for {
+		for name := range pending {
+			event, err := c.FetchEvent(name, loadCheckName)
+			if err != nil || event == nil || !event.HasCheck() {
+				continue
+			}
+			if remaining := accepted[name] - int(event.Check.Occurrences); remaining > 0 {
+				pending[name] = remaining
+			} else {
+				delete(pending, name)
+			}
+		}
+		if len(pending) == 0 {
+			return 0
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			unprocessed := 0
+			for _, count := range pending {
+				unprocessed += count
+			}
+			return unprocessed
+		}
+	}
+}
+
+// cleanupLoad deletes the synthetic events and entities.
+func cleanupLoad(c loadClient, cfg loadConfig) error {
+	var errs []error
+	for n := 0; n < cfg.Entities; n++ {
+		name := fmt.Sprintf("%s%d", loadEntityPrefix, n)
+		if err := c.DeleteEvent(cfg.Namespace, name, loadCheckName); err != nil && !isNotFound(err) {
+			errs = append(errs, err)
+		}
+		if err := c.DeleteEntity(cfg.Namespace, name); err != nil && !isNotFound(err) {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("could not delete all synthetic entities and events: %w", errs[0])
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var apiErr client.APIError
+	return errors.As(err, &apiErr) && apiErr.Code == uint32(actions.NotFound)
+}
+
+// print writes a summary of the result to w.
+func (r *loadResult) print(w io.Writer) {
+	fmt.Fprintf(w, "Events sent:     %d\n", r.Sent)
+	fmt.Fprintf(w, "Events accepted: %d\n", r.Accepted)
+	fmt.Fprintf(w, "Events rejected: %d\n", r.Sent-r.Accepted)
+	if r.Unprocessed > 0 {
+		fmt.Fprintf(w, "Events unprocessed: %d (dropped by the backend, or still queued)\n", r.Unprocessed)
+	}
+	if r.Elapsed > 0 {
+		fmt.Fprintf(w, "Throughput:      %.1f events/s\n", float64(r.Accepted)/r.Elapsed.Seconds())
+	}
+	if len(r.Latencies) > 0 {
+		latencies := append([]time.Duration{}, r.Latencies...)
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		fmt.Fprintf(w, "Submit latency:  p50=%s p90=%s p99=%s max=%s\n",
+			percentile(latencies, 0.5), percentile(latencies, 0.9),
+			percentile(latencies, 0.99), latencies[len(latencies)-1])
+	}
+	if len(r.Rejections) > 0 {
+		reasons := make([]string, 0, len(r.Rejections))
+		for reason := range r.Rejections {
+			reasons = append(reasons, reason)
+		}
+		sort.Strings(reasons)
+		fmt.Fprintln(w, "Rejections:")
+		for _, reason := range reasons {
+			fmt.Fprintf(w, "  %d: %s\n", r.Rejections[reason], reason)
+		}
+	}
+}
+
+// percentile returns the p-th percentile, between 0 and 1, of the sorted
+// latencies.
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	i := int(float64(len(latencies))*p+0.5) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(latencies) {
+		i = len(latencies) - 1
+	}
+	return latencies[i].Round(time.Microsecond)
+}
@@ -0,0 +1,103 @@
+package debug
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/cli/client"
+	clientmock "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// processedEvent returns a stored synthetic event whose check occurred
+// occurrences times.
+func processedEvent(entity string, occurrences int64) *corev2.Event {
+	event := corev2.FixtureEvent(entity, loadCheckName)
+	event.Check.Occurrences = occurrences
+	return event
+}
+
+func TestLoadEventsCommand(t *testing.T) {
+	cli := test.NewMockCLI()
+	c := cli.Client.(*clientmock.MockClient)
+	c.On("UpdateEvent", mock.MatchedBy(func(event *corev2.Event) bool {
+		return event.Entity.Name == "sensuctl-load-1"
+	})).Return(client.APIError{Message: "event exceeds the maximum event size"})
+	c.On("UpdateEvent", mock.Anything).Return(nil)
+	c.On("FetchEvent", "sensuctl-load-0", "sensuctl-load").Return(processedEvent("sensuctl-load-0", 1000), nil)
+	c.On("DeleteEvent", "default", mock.Anything, "sensuctl-load").Return(nil)
+	c.On("DeleteEntity", "default", "sensuctl-load-0").Return(nil)
+	c.On("DeleteEntity", "default", "sensuctl-load-1").Return(client.APIError{Code: uint32(actions.NotFound)})
+
+	cmd := LoadEventsCommand(cli)
+	require.NoError(t, cmd.Flags().Set("rate", "100"))
+	require.NoError(t, cmd.Flags().Set("duration", "200ms"))
+	require.NoError(t, cmd.Flags().Set("entities", "2"))
+	out, err := test.RunCmd(cmd, nil)
+	require.NoError(t, err)
+
+	assert.Regexp(t, "Events accepted: [1-9]", out)
+	assert.Regexp(t, "Submit latency: +p50=", out)
+	assert.NotContains(t, out, "Events unprocessed")
+	assert.Regexp(t, "[1-9][0-9]*: event exceeds the maximum event size", out)
+	c.AssertCalled(t, "DeleteEvent", "default", "sensuctl-load-0", "sensuctl-load")
+	c.AssertCalled(t, "DeleteEvent", "default", "sensuctl-load-1", "sensuctl-load")
+	c.AssertCalled(t, "DeleteEntity", "default", "sensuctl-load-0")
+}
+
+func TestLoadEventsCommandCleanupError(t *testing.T) {
+	cli := test.NewMockCLI()
+	c := cli.Client.(*clientmock.MockClient)
+	c.On("UpdateEvent", mock.Anything).Return(nil)
+	c.On("FetchEvent", mock.Anything, "sensuctl-load").Return(processedEvent("sensuctl-load-0", 1000), nil)
+	c.On("DeleteEvent", "default", mock.Anything, "sensuctl-load").Return(nil)
+	c.On("DeleteEntity", "default", mock.Anything).Return(errors.New("forbidden"))
+
+	cmd := LoadEventsCommand(cli)
+	require.NoError(t, cmd.Flags().Set("duration", "10ms"))
+	_, err := test.RunCmd(cmd, nil)
+	assert.Error(t, err)
+}
+
+func TestSyntheticEvent(t *testing.T) {
+	event := syntheticEvent("dev", 3)
+	require.NoError(t, event.Validate())
+	assert.Equal(t, "sensuctl-load-3", event.Entity.Name)
+	assert.Equal(t, "dev", event.Entity.Namespace)
+	assert.Equal(t, "dev", event.Check.Namespace)
+	assert.Equal(t, "true", event.Check.Annotations[corev2.CheckSkipDefaultHandlersAnnotation])
+	assert.Empty(t, event.Check.Handlers)
+	assert.Empty(t, event.Check.History)
+}
+
+func TestWaitForLoad(t *testing.T) {
+	cli := test.NewMockCLI()
+	c := cli.Client.(*clientmock.MockClient)
+	c.On("FetchEvent", "sensuctl-load-0", "sensuctl-load").Return(processedEvent("sensuctl-load-0", 3), nil)
+	c.On("FetchEvent", "sensuctl-load-1", "sensuctl-load").Return(processedEvent("sensuctl-load-1", 1), nil)
+	c.On("FetchEvent", "sensuctl-load-2", "sensuctl-load").Return((*corev2.Event)(nil), client.APIError{Code: uint32(actions.NotFound)})
+
+	cfg := loadConfig{Namespace: "default", WaitTimeout: 10 * time.Millisecond}
+	assert.Equal(t, 0, waitForLoad(context.Background(), c, cfg, map[string]int{"sensuctl-load-0": 3}))
+
+	// The events not stored yet are unprocessed once the timeout expired
+	accepted := map[string]int{"sensuctl-load-0": 3, "sensuctl-load-1": 2, "sensuctl-load-2": 4}
+	assert.Equal(t, 5, waitForLoad(context.Background(), c, cfg, accepted))
+}
+
+func TestPercentile(t *testing.T) {
+	latencies := make([]time.Duration, 100)
+	for i := range latencies {
+		latencies[i] = time.Duration(i+1) * time.Millisecond
+	}
+	assert.Equal(t, 50*time.Millisecond, percentile(latencies, 0.5))
+	assert.Equal(t, 99*time.Millisecond, percentile(latencies, 0.99))
+	assert.Equal(t, time.Millisecond, percentile(latencies[:1], 0.99))
+}
@@ -0,0 +1,181 @@
+package diff
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"reflect"
+
+	"github.com/pmezard/go-difflib/difflib"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/client"
+	"github.com/sensu/sensu-go/cli/client/config"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/cli/resource"
+	"github.com/sensu/sensu-go/types"
+	"github.com/sensu/sensu-go/types/compat"
+	"github.com/spf13/cobra"
+)
+
+// DifferencesError is returned when the resources differ from their server
+// version, so that sensuctl exits with status 1.
+type DifferencesError struct{}
+
+func (DifferencesError) Error() string   { return "resources differ from the server" }
+func (DifferencesError) ExitStatus() int { return 1 }
+
+// DiffCommand shows the differences between resources and their server
+// version.
+func DiffCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff [-f FILE]",
+		Short: "Show the differences between resources from file or STDIN and the server, exits with status 1 when they differ",
+		RunE:  execute(cli),
+	}
+
+	_ = cmd.Flags().StringP("file", "f", "", "File to diff resources from")
+	helpers.AddFormatFlag(cmd.Flags())
+
+	return cmd
+}
+
+func execute(cli *cli.SensuCli) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			_ = cmd.Help()
+			return errors.New("invalid argument(s) received")
+		}
+		fp, err := cmd.Flags().GetString("file")
+		if err != nil {
+			return err
+		}
+
+		in, err := helpers.InputData(fp)
+		if err != nil {
+			return err
+		}
+
+		resources, err := resource.Parse(in)
+		if err != nil {
+			return err
+		}
+		if err := resource.Validate(resources, cli.Config.Namespace()); err != nil {
+			return err
+		}
+
+		format := cli.Config.Format()
+		if flag := helpers.GetChangedStringValueViper("format", cmd.Flags()); flag != "" {
+			format = flag
+		}
+
+		differ, err := DiffResources(cli.Client, resources, format, cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+		if differ {
+			return DifferencesError{}
+		}
+		return nil
+	}
+}
+
+// DiffResources prints a unified diff between the server version of each
+// resource and the resource, and returns whether any of them differ. Resources
+// that do not exist on the server are diffed against nothing.
+func DiffResources(client client.GenericClient, resources []*types.Wrapper, format string, w io.Writer) (bool, error) {
+	differ := false
+	for i, wrapper := range resources {
+		uri := compat.URIPath(wrapper.Value)
+		current, err := fetchResource(client, wrapper.Value)
+		if err != nil {
+			return differ, fmt.Errorf("error fetching resource %d (%s): %s", i, uri, err)
+		}
+
+		var from string
+		if current != nil {
+			if from, err = render(current, format); err != nil {
+				return differ, err
+			}
+		}
+		to, err := render(compat.V2Resource(wrapper.Value), format)
+		if err != nil {
+			return differ, err
+		}
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(from),
+			B:        difflib.SplitLines(to),
+			FromFile: uri + " (server)",
+			ToFile:   uri + " (file)",
+			Context:  3,
+		})
+		if err != nil {
+			return differ, err
+		}
+		if diff != "" {
+			differ = true
+			fmt.Fprint(w, diff)
+		}
+	}
+	return differ, nil
+}
+
+// fetchResource returns the server version of the resource, or nil if it
+// does not exist.
+func fetchResource(c client.GenericClient, value interface{}) (corev2.Resource, error) {
+	// Resources outside core/v2 are stored as wrapped values
+	var response interface{}
+	if types.ApiVersion(reflect.Indirect(reflect.ValueOf(value)).Type().PkgPath()) == path.Join(corev2.APIGroupName, corev2.APIVersion) {
+		response = reflect.New(reflect.Indirect(reflect.ValueOf(value)).Type()).Interface()
+	} else {
+		response = &types.Wrapper{}
+	}
+
+	if err := c.Get(compat.URIPath(value), response); err != nil {
+		var apiErr client.APIError
+		if errors.As(err, &apiErr) && apiErr.Code == uint32(actions.NotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	switch r := response.(type) {
+	case *types.Wrapper:
+		return compat.V2Resource(r.Value), nil
+	case corev2.Resource:
+		return r, nil
+	default:
+		return nil, fmt.Errorf("unexpected response type %T", response)
+	}
+}
+
+// render formats the resource like the other commands, without the metadata
+// managed by sensu so that it does not show up as a difference.
+func render(r corev2.Resource, format string) (string, error) {
+	meta := r.GetObjectMeta()
+	meta.CreatedBy = ""
+	if meta.Labels != nil {
+		labels := make(map[string]string, len(meta.Labels))
+		for k, v := range meta.Labels {
+			if k != corev2.ManagedByLabel {
+				labels[k] = v
+			}
+		}
+		meta.Labels = labels
+	}
+	r.SetObjectMeta(meta)
+
+	buf := new(bytes.Buffer)
+	var err error
+	switch format {
+	case config.FormatJSON, config.FormatWrappedJSON:
+		err = helpers.PrintWrappedJSON(r, buf)
+	default:
+		err = helpers.PrintYAML([]types.Resource{r}, buf)
+	}
+	return buf.String(), err
+}
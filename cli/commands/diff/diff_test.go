@@ -0,0 +1,73 @@
+package diff
+
+import (
+	"bytes"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/cli/client"
+	mockclient "github.com/sensu/sensu-go/cli/client/testing"
+	"github.com/sensu/sensu-go/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffResources(t *testing.T) {
+	server := corev2.FixtureCheckConfig("check")
+	server.Command = "old-command"
+	server.CreatedBy = "admin"
+	server.Labels = map[string]string{corev2.ManagedByLabel: "sensuctl"}
+
+	tests := []struct {
+		name       string
+		command    string
+		getErr     error
+		wantDiffer bool
+		wantOutput []string
+	}{
+		{
+			name:    "identical",
+			command: "old-command",
+		},
+		{
+			name:       "changed",
+			command:    "new-command",
+			wantDiffer: true,
+			wantOutput: []string{"-  command: old-command", "+  command: new-command"},
+		},
+		{
+			name:       "not found",
+			command:    "new-command",
+			getErr:     client.APIError{Code: uint32(actions.NotFound), Message: "not found"},
+			wantDiffer: true,
+			wantOutput: []string{"+  command: new-command"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &mockclient.MockClient{}
+			c.On("Get", server.URIPath(), mock.Anything).Return(tt.getErr).Run(func(args mock.Arguments) {
+				if tt.getErr == nil {
+					*args[1].(*corev2.CheckConfig) = *server
+				}
+			})
+
+			check := corev2.FixtureCheckConfig("check")
+			check.Command = tt.command
+			resources := []*types.Wrapper{{Value: check}}
+
+			out := new(bytes.Buffer)
+			differ, err := DiffResources(c, resources, "yaml", out)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDiffer, differ)
+			for _, line := range tt.wantOutput {
+				assert.Contains(t, out.String(), line)
+			}
+			if !tt.wantDiffer {
+				assert.Empty(t, out.String())
+			}
+		})
+	}
+}
@@ -38,6 +38,10 @@ $ sensuctl dump core/v2.CheckConfig,core/v2.Entity
 
 You can also use the 'all' qualifier to dump all supported resources:
 $ sensuctl dump all
+
+The rbac subcommand dumps the RBAC resources of all namespaces in order, for
+audits:
+$ sensuctl dump rbac
 `
 
 // Command dumps generic Sensu resources to a file or STDOUT.
@@ -60,6 +64,8 @@ func Command(cli *cli.SensuCli) *cobra.Command {
 	_ = cmd.Flags().MarkDeprecated("types", `please use "sensuctl describe-type all" instead`)
 	_ = cmd.Flags().StringP("omit", "o", "", "when using 'sensuctl dump all', omit can be used to exclude types from being dumped")
 
+	cmd.AddCommand(RBACCommand(cli))
+
 	return cmd
 }
 
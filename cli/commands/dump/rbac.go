@@ -0,0 +1,138 @@
+package dump
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/client"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/spf13/cobra"
+)
+
+// RBACCommand dumps the cluster roles, cluster role bindings, and the roles
+// and role bindings of every namespace, as a single YAML document.
+func RBACCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "rbac [-f FILE]",
+		Short:        "Dump the roles and role bindings of all namespaces, and the cluster roles and cluster role bindings, to YAML",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var w io.Writer = cmd.OutOrStdout()
+
+			// if a file is requested, write data to that
+			fp, err := cmd.Flags().GetString("file")
+			if err != nil {
+				return err
+			}
+			if fp != "" {
+				f, err := os.Create(fp)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				w = f
+			}
+
+			return dumpRBAC(cli.Client, w)
+		},
+	}
+
+	_ = cmd.Flags().StringP("file", "f", "", "file to dump resources to")
+
+	return cmd
+}
+
+// dumpRBAC writes the RBAC resources the user can read, ordered by type,
+// namespace and name. The namespaces and types that could not be read are
+// listed as comments at the top of the document.
+func dumpRBAC(c client.APIClient, w io.Writer) error {
+	var resources []corev2.Resource
+	var notes []string
+
+	var clusterRoles []corev2.ClusterRole
+	if ok, err := listRBAC(c, client.ClusterRolesPath(), &clusterRoles); err != nil {
+		return err
+	} else if !ok {
+		notes = append(notes, "could not read cluster roles")
+	}
+	sort.Slice(clusterRoles, func(i, j int) bool { return clusterRoles[i].Name < clusterRoles[j].Name })
+	for i := range clusterRoles {
+		resources = append(resources, &clusterRoles[i])
+	}
+
+	var clusterRoleBindings []corev2.ClusterRoleBinding
+	if ok, err := listRBAC(c, client.ClusterRoleBindingsPath(), &clusterRoleBindings); err != nil {
+		return err
+	} else if !ok {
+		notes = append(notes, "could not read cluster role bindings")
+	}
+	sort.Slice(clusterRoleBindings, func(i, j int) bool { return clusterRoleBindings[i].Name < clusterRoleBindings[j].Name })
+	for i := range clusterRoleBindings {
+		resources = append(resources, &clusterRoleBindings[i])
+	}
+
+	// The namespaces API only returns the namespaces the user has access to
+	var namespaces []corev2.Namespace
+	if ok, err := listRBAC(c, client.NamespacesPath(), &namespaces); err != nil {
+		return err
+	} else if !ok {
+		notes = append(notes, "could not read namespaces")
+	}
+	sort.Slice(namespaces, func(i, j int) bool { return namespaces[i].Name < namespaces[j].Name })
+
+	for _, namespace := range namespaces {
+		var roles []corev2.Role
+		rolesOK, err := listRBAC(c, client.RolesPath(namespace.Name), &roles)
+		if err != nil {
+			return err
+		}
+		var roleBindings []corev2.RoleBinding
+		roleBindingsOK, err := listRBAC(c, client.RoleBindingsPath(namespace.Name), &roleBindings)
+		if err != nil {
+			return err
+		}
+		switch {
+		case !rolesOK && !roleBindingsOK:
+			notes = append(notes, fmt.Sprintf("could not read namespace %s", namespace.Name))
+		case !rolesOK:
+			notes = append(notes, fmt.Sprintf("could not read the roles of namespace %s", namespace.Name))
+		case !roleBindingsOK:
+			notes = append(notes, fmt.Sprintf("could not read the role bindings of namespace %s", namespace.Name))
+		}
+
+		sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+		for i := range roles {
+			resources = append(resources, &roles[i])
+		}
+		sort.Slice(roleBindings, func(i, j int) bool { return roleBindings[i].Name < roleBindings[j].Name })
+		for i := range roleBindings {
+			resources = append(resources, &roleBindings[i])
+		}
+	}
+
+	for _, note := range notes {
+		if _, err := fmt.Fprintf(w, "# %s\n", note); err != nil {
+			return err
+		}
+	}
+	return helpers.PrintYAML(resources, w)
+}
+
+// listRBAC lists the resources at path into objs. It returns false, without
+// an error, when the user is not allowed to list them.
+func listRBAC(c client.APIClient, path string, objs interface{}) (bool, error) {
+	err := c.List(path, objs, &client.ListOptions{ChunkSize: ChunkSize}, nil)
+	if err == nil {
+		return true, nil
+	}
+	if err, ok := err.(client.APIError); ok && actions.ErrCode(err.Code) == actions.PermissionDenied {
+		return false, nil
+	}
+	return false, fmt.Errorf("API error: %s", err)
+}
@@ -0,0 +1,68 @@
+package dump
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/cli/client"
+	clienttest "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpRBAC(t *testing.T) {
+	cli := test.NewCLI()
+	c := cli.Client.(*clienttest.MockClient)
+
+	c.On("List", client.ClusterRolesPath(), mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		roles := args.Get(1).(*[]corev2.ClusterRole)
+		*roles = []corev2.ClusterRole{*corev2.FixtureClusterRole("viewer"), *corev2.FixtureClusterRole("admin")}
+	})
+	c.On("List", client.ClusterRoleBindingsPath(), mock.Anything, mock.Anything, mock.Anything).Return(
+		client.APIError{Code: uint32(actions.PermissionDenied), Message: "forbidden"},
+	)
+	c.On("List", client.NamespacesPath(), mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		namespaces := args.Get(1).(*[]corev2.Namespace)
+		*namespaces = []corev2.Namespace{*corev2.FixtureNamespace("prod"), *corev2.FixtureNamespace("dev")}
+	})
+	c.On("List", client.RolesPath("dev"), mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		roles := args.Get(1).(*[]corev2.Role)
+		*roles = []corev2.Role{*corev2.FixtureRole("dev-role", "dev")}
+	})
+	c.On("List", client.RoleBindingsPath("dev"), mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	c.On("List", client.RolesPath("prod"), mock.Anything, mock.Anything, mock.Anything).Return(
+		client.APIError{Code: uint32(actions.PermissionDenied), Message: "forbidden"},
+	)
+	c.On("List", client.RoleBindingsPath("prod"), mock.Anything, mock.Anything, mock.Anything).Return(
+		client.APIError{Code: uint32(actions.PermissionDenied), Message: "forbidden"},
+	)
+
+	var buf bytes.Buffer
+	require.NoError(t, dumpRBAC(cli.Client, &buf))
+	out := buf.String()
+
+	assert.Contains(t, out, "# could not read cluster role bindings\n")
+	assert.Contains(t, out, "# could not read namespace prod\n")
+
+	// Resources are ordered by type, namespace and name
+	admin := strings.Index(out, "name: admin")
+	viewer := strings.Index(out, "name: viewer")
+	devRole := strings.Index(out, "name: dev-role")
+	assert.True(t, admin >= 0 && admin < viewer && viewer < devRole, out)
+}
+
+func TestDumpRBACError(t *testing.T) {
+	cli := test.NewCLI()
+	c := cli.Client.(*clienttest.MockClient)
+	c.On("List", client.ClusterRolesPath(), mock.Anything, mock.Anything, mock.Anything).Return(
+		client.APIError{Code: uint32(actions.InternalErr), Message: "boom"},
+	)
+
+	var buf bytes.Buffer
+	assert.Error(t, dumpRBAC(cli.Client, &buf))
+}
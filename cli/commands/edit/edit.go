@@ -50,22 +50,24 @@ type namespaceFormat interface {
 }
 
 type client interface {
-	Get(string, interface{}) error
+	GetWithETag(string, interface{}) (string, error)
 }
 
-func dumpResource(client client, cfg namespaceFormat, typeName string, key []string, to io.Writer) error {
+// dumpResource writes the requested resource to the writer, and returns its
+// etag.
+func dumpResource(client client, cfg namespaceFormat, typeName string, key []string, to io.Writer) (string, error) {
 	// Determine the requested resource type. We will use this resource only to
 	// determine it's path in the store
 	requested, err := resource.Resolve(typeName)
 	if err != nil {
-		return fmt.Errorf("invalid resource type: %s", typeName)
+		return "", fmt.Errorf("invalid resource type: %s", typeName)
 	}
 
 	switch r := requested.(type) {
 	case *corev2.Event:
 		// Need an exception for event, because it's a special little type
 		if len(key) != 2 {
-			return errors.New("events need an entity and check component")
+			return "", errors.New("events need an entity and check component")
 		}
 		r.Entity = &corev2.Entity{
 			ObjectMeta: corev2.ObjectMeta{
@@ -83,7 +85,7 @@ func dumpResource(client client, cfg namespaceFormat, typeName string, key []str
 		// Special case here takes care of the check naming boondoggle
 		requested = &corev2.CheckConfig{}
 		if len(key) != 1 {
-			return errors.New("resource name missing")
+			return "", errors.New("resource name missing")
 		}
 		requested.SetObjectMeta(corev2.ObjectMeta{
 			Namespace: cfg.Namespace(),
@@ -91,7 +93,7 @@ func dumpResource(client client, cfg namespaceFormat, typeName string, key []str
 		})
 	default:
 		if len(key) != 1 {
-			return errors.New("resource name missing")
+			return "", errors.New("resource name missing")
 		}
 		requested.SetObjectMeta(corev2.ObjectMeta{
 			Namespace: cfg.Namespace(),
@@ -112,8 +114,9 @@ func dumpResource(client client, cfg namespaceFormat, typeName string, key []str
 		response = &types.Wrapper{}
 	}
 
-	if err := client.Get(requested.URIPath(), &response); err != nil {
-		return err
+	etag, err := client.GetWithETag(requested.URIPath(), &response)
+	if err != nil {
+		return "", err
 	}
 
 	// Retrieve the concrete resource value from the response
@@ -124,16 +127,17 @@ func dumpResource(client client, cfg namespaceFormat, typeName string, key []str
 	case *types.Wrapper:
 		resource = compat.V2Resource(r.Value)
 	default:
-		return fmt.Errorf("unexpected response type %T. Make sure the resource type is valid", response)
+		return "", fmt.Errorf("unexpected response type %T. Make sure the resource type is valid", response)
 	}
 
 	format := cfg.Format()
 	switch format {
 	case "wrapped-json", "json":
-		return helpers.PrintWrappedJSON(resource, to)
+		err = helpers.PrintWrappedJSON(resource, to)
 	default:
-		return helpers.PrintYAML([]types.Resource{resource}, to)
+		err = helpers.PrintYAML([]types.Resource{resource}, to)
 	}
+	return etag, err
 }
 
 func dumpBlank(cfg namespaceFormat, typeName string, to io.Writer) error {
@@ -199,12 +203,14 @@ func Command(cli *cli.SensuCli) *cobra.Command {
 			defer os.Remove(tf.Name())
 			orig := new(bytes.Buffer)
 			writer := io.MultiWriter(orig, tf)
+			var etag string
 			if blank {
 				if err := dumpBlank(cli.Config, args[0], writer); err != nil {
 					return err
 				}
 			} else {
-				if err := dumpResource(cli.Client, cli.Config, args[0], args[1:], writer); err != nil {
+				etag, err = dumpResource(cli.Client, cli.Config, args[0], args[1:], writer)
+				if err != nil {
 					return err
 				}
 			}
@@ -243,9 +249,17 @@ func Command(cli *cli.SensuCli) *cobra.Command {
 			if err := resource.Validate(resources, cli.Config.Namespace()); err != nil {
 				return err
 			}
-			processor := resource.NewPutter()
-			if err := processor.Process(cli.Client, resources); err != nil {
-				return err
+			if etag != "" && len(resources) == 1 && sameResource(orig.Bytes(), resources[0]) {
+				// Only update the resource if it was not modified since it
+				// was dumped, for the APIs that support it
+				if err := cli.Client.PutResourceIfMatch(*resources[0], etag); err != nil {
+					return err
+				}
+			} else {
+				processor := resource.NewPutter()
+				if err := processor.Process(cli.Client, resources); err != nil {
+					return err
+				}
 			}
 			fmt.Fprintf(cmd.OutOrStdout(), "Updated %s\n", compat.URIPath(resources[0].Value))
 			return nil
@@ -258,6 +272,16 @@ func Command(cli *cli.SensuCli) *cobra.Command {
 	return cmd
 }
 
+// sameResource returns whether the edited resource is the one that was dumped,
+// rather than a renamed or different one.
+func sameResource(dumped []byte, edited *types.Wrapper) bool {
+	resources, err := resource.Parse(bytes.NewReader(dumped))
+	if err != nil || len(resources) != 1 {
+		return false
+	}
+	return compat.URIPath(resources[0].Value) == compat.URIPath(edited.Value)
+}
+
 func parseCommand(cmd string) []string {
 	scanner := bufio.NewScanner(strings.NewReader(cmd))
 	scanner.Split(bufio.ScanWords)
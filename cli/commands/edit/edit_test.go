@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/types"
 	"gopkg.in/yaml.v2"
 )
 
@@ -28,9 +30,9 @@ type testClient struct {
 	err error
 }
 
-func (t testClient) Get(_ string, val interface{}) error {
+func (t testClient) GetWithETag(_ string, val interface{}) (string, error) {
 	if t.err != nil {
-		return t.err
+		return "", t.err
 	}
 	switch v := val.(type) {
 	case *corev2.Namespace:
@@ -65,7 +67,7 @@ func (t testClient) Get(_ string, val interface{}) error {
 	case *corev2.Silenced:
 		*v = *(corev2.FixtureSilenced("default"))
 	}
-	return nil
+	return `"etag"`, nil
 }
 
 type um int
@@ -177,7 +179,7 @@ func TestDumpResource(t *testing.T) {
 				}
 				client := testClient{}
 				buf := new(bytes.Buffer)
-				err := dumpResource(client, cfg, test.Type, test.Key, buf)
+				etag, err := dumpResource(client, cfg, test.Type, test.Key, buf)
 				if err != nil && !test.Err {
 					t.Error(err)
 				}
@@ -187,6 +189,9 @@ func TestDumpResource(t *testing.T) {
 				if test.Err {
 					return
 				}
+				if etag != `"etag"` {
+					t.Errorf("etag = %q, want %q", etag, `"etag"`)
+				}
 				var m map[string]interface{}
 				if err := unmarshal(buf.Bytes(), &m); err != nil {
 					t.Fatal(err)
@@ -306,3 +311,18 @@ func TestParseCommand(t *testing.T) {
 		}
 	}
 }
+
+func TestSameResource(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := helpers.PrintYAML([]types.Resource{corev2.FixtureEntity("foo")}, buf); err != nil {
+		t.Fatal(err)
+	}
+	same := types.WrapResource(corev2.FixtureEntity("foo"))
+	if !sameResource(buf.Bytes(), &same) {
+		t.Error("expected the edited entity to be the dumped one")
+	}
+	renamed := types.WrapResource(corev2.FixtureEntity("bar"))
+	if sameResource(buf.Bytes(), &renamed) {
+		t.Error("expected a renamed entity not to be the dumped one")
+	}
+}
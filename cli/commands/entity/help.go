@@ -19,6 +19,7 @@ func HelpCommand(cli *cli.SensuCli) *cobra.Command {
 		CreateCommand(cli),
 		DeleteCommand(cli),
 		ListCommand(cli),
+		StaleCommand(cli),
 		InfoCommand(cli),
 		UpdateCommand(cli),
 	)
@@ -6,6 +6,7 @@ import (
 	"io"
 	"strings"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/cli"
 	"github.com/sensu/sensu-go/cli/commands/helpers"
 	"github.com/sensu/sensu-go/cli/commands/timeutil"
@@ -70,6 +71,10 @@ func printToList(v interface{}, writer io.Writer) error {
 				Label: "Last Seen",
 				Value: timeutil.HumanTimestamp(r.LastSeen),
 			},
+			{
+				Label: "Backend",
+				Value: r.Annotations[corev2.BackendAnnotation],
+			},
 			{
 				Label: "Hostname",
 				Value: r.System.Hostname,
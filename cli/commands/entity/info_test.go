@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	client "github.com/sensu/sensu-go/cli/client/testing"
 	test "github.com/sensu/sensu-go/cli/commands/testing"
 	"github.com/sensu/sensu-go/types"
@@ -55,7 +56,9 @@ func TestInfoCommandRunEClosureWithTable(t *testing.T) {
 
 	cli := test.NewCLI()
 	client := cli.Client.(*client.MockClient)
-	client.On("FetchEntity", "in").Return(types.FixtureEntity("name-one"), nil)
+	entity := types.FixtureEntity("name-one")
+	entity.Annotations = map[string]string{corev2.BackendAnnotation: "backend-2"}
+	client.On("FetchEntity", "in").Return(entity, nil)
 
 	cmd := InfoCommand(cli)
 	require.NoError(t, cmd.Flags().Set("format", "tabular"))
@@ -65,6 +68,7 @@ func TestInfoCommandRunEClosureWithTable(t *testing.T) {
 	assert.NotEmpty(out)
 	assert.Contains(out, "Host")
 	assert.Contains(out, "OS")
+	assert.Contains(out, "backend-2")
 	assert.Nil(err)
 }
 
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/spf13/cobra"
+)
+
+// StaleCommand defines the command listing the entities that were not seen
+// for a while
+func StaleCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "stale",
+		Short:        "list entities that were not seen for longer than a duration",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+			olderThan, err := cmd.Flags().GetDuration("older-than")
+			if err != nil {
+				return err
+			}
+			if olderThan <= 0 {
+				return fmt.Errorf("--older-than must be positive, got %s", olderThan)
+			}
+
+			results, err := cli.Client.ListStaleEntities(cli.Config.Namespace(), olderThan)
+			if err != nil {
+				return err
+			}
+
+			resources := []corev2.Resource{}
+			for i := range results {
+				resources = append(resources, &results[i])
+			}
+			return helpers.Print(cmd, cli.Config.Format(), printToTable, resources, results)
+		},
+	}
+
+	_ = cmd.Flags().Duration("older-than", 0, "list the entities not seen for longer than this duration (e.g. 24h)")
+	helpers.AddFormatFlag(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaleCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("ListStaleEntities", "default", 24*time.Hour).Return([]corev2.Entity{*corev2.FixtureEntity("stale-entity")}, nil)
+
+	cmd := StaleCommand(cli)
+	require.NoError(t, cmd.Flags().Set("older-than", "24h"))
+	out, err := test.RunCmd(cmd, []string{})
+
+	assert.NoError(err)
+	assert.Contains(out, "stale-entity")
+}
+
+func TestStaleCommandMissingDuration(t *testing.T) {
+	cli := test.NewCLI()
+	cmd := StaleCommand(cli)
+	_, err := test.RunCmd(cmd, []string{})
+	assert.Error(t, err)
+}
+
+func TestStaleCommandServerError(t *testing.T) {
+	cli := test.NewCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("ListStaleEntities", "default", time.Hour).Return([]corev2.Entity{}, errors.New("error"))
+
+	cmd := StaleCommand(cli)
+	require.NoError(t, cmd.Flags().Set("older-than", "1h"))
+	_, err := test.RunCmd(cmd, []string{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,94 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/client"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/spf13/cobra"
+)
+
+// tailRetryInterval is the minimum interval between two event streams, so that
+// streams ended right away by the backend are not retried in a tight loop.
+var tailRetryInterval = time.Second
+
+// TailCommand defines new tail events command
+func TailCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "tail",
+		Short:        "stream events as they are processed by the backend",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+			entity, err := cmd.Flags().GetString("entity")
+			if err != nil {
+				return err
+			}
+			check, err := cmd.Flags().GetString("check")
+			if err != nil {
+				return err
+			}
+
+			flag := helpers.GetChangedStringValueViper("format", cmd.Flags())
+			format := cli.Config.Format()
+			w := cmd.OutOrStdout()
+			return tailEvents(context.Background(), cli.Client, entity, check, func(event *corev2.Event) error {
+				return helpers.PrintFormatted(flag, format, event, w, printToLine)
+			})
+		},
+	}
+
+	helpers.AddFormatFlag(cmd.Flags())
+	_ = cmd.Flags().String("entity", "", "only stream the events of this entity")
+	_ = cmd.Flags().String("check", "", "only stream the events of this check")
+
+	return cmd
+}
+
+// tailEvents watches the events until ctx is done or an error occurs, watching
+// again whenever the backend ends the stream.
+func tailEvents(ctx context.Context, c client.EventAPIClient, entity, check string, fn func(*corev2.Event) error) error {
+	for {
+		started := time.Now()
+		if err := c.WatchEvents(ctx, entity, check, fn); err != nil {
+			return err
+		}
+		if wait := tailRetryInterval - time.Since(started); wait > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+	}
+}
+
+// printToLine prints an event on a single line, with the first line of its
+// check output.
+func printToLine(v interface{}, writer io.Writer) error {
+	event, ok := v.(*corev2.Event)
+	if !ok {
+		return fmt.Errorf("%t is not an Event", v)
+	}
+	timestamp := time.Unix(event.Timestamp, 0).Format(time.RFC3339)
+	if event.Check == nil {
+		_, err := fmt.Fprintf(writer, "%s %s (metrics)\n", timestamp, event.Entity.Name)
+		return err
+	}
+	output := strings.TrimSpace(event.Check.Output)
+	if i := strings.IndexByte(output, '\n'); i >= 0 {
+		output = output[:i]
+	}
+	_, err := fmt.Fprintf(writer, "%s %s/%s status=%d %s\n", timestamp, event.Entity.Name, event.Check.Name, event.Check.Status, output)
+	return err
+}
@@ -0,0 +1,46 @@
+package event
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTailCommand(t *testing.T) {
+	tailRetryInterval = 0
+	cli := test.NewMockCLI()
+	config := cli.Config.(*client.MockConfig)
+	config.On("Format").Return("tabular")
+
+	c := cli.Client.(*client.MockClient)
+	emit := func(args mock.Arguments) {
+		fn := args.Get(3).(func(*corev2.Event) error)
+		event := corev2.FixtureEvent("foo", "check-cpu")
+		event.Check.Output = "CPU OK\nload 0.1"
+		_ = fn(event)
+	}
+	// The command watches again when the stream ends
+	c.On("WatchEvents", mock.Anything, "foo", "", mock.Anything).Return(nil).Run(emit).Once()
+	c.On("WatchEvents", mock.Anything, "foo", "", mock.Anything).Return(errors.New("boom")).Run(emit).Once()
+
+	cmd := TailCommand(cli)
+	_ = cmd.Flags().Set("entity", "foo")
+	out, err := test.RunCmd(cmd, []string{})
+	assert.EqualError(t, err, "boom")
+	assert.Equal(t, 2, bytes.Count([]byte(out), []byte("foo/check-cpu status=0 CPU OK\n")), out)
+	c.AssertExpectations(t)
+}
+
+func TestTailCommandArgs(t *testing.T) {
+	cli := test.NewMockCLI()
+	cmd := TailCommand(cli)
+	out, err := test.RunCmd(cmd, []string{"foo"})
+	assert.Error(t, err)
+	assert.Regexp(t, "Usage", out)
+}
@@ -20,6 +20,7 @@ func HelpCommand(cli *cli.SensuCli) *cobra.Command {
 		DeleteCommand(cli),
 		InfoCommand(cli),
 		ListCommand(cli),
+		TestCommand(cli),
 		UpdateCommand(cli),
 
 		// TODO:(echlebek): add these back when the time window facility works
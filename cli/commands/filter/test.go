@@ -0,0 +1,94 @@
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/spf13/cobra"
+)
+
+// TestCommand defines a new command to evaluate an event against a filter
+func TestCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "test [NAME]",
+		Short:        "evaluate an event against a filter, without handling the event",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			file, _ := cmd.Flags().GetString("file")
+			if file == "" {
+				return errors.New("an event must be provided with --file")
+			}
+			b, err := ioutil.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			event, err := decodeEvent(b)
+			if err != nil {
+				return fmt.Errorf("invalid event in %s: %s", file, err)
+			}
+
+			result, err := cli.Client.TestFilter(args[0], event)
+			if err != nil {
+				return err
+			}
+			return printTestResult(cmd.OutOrStdout(), result)
+		},
+	}
+
+	_ = cmd.Flags().StringP("file", "f", "", "file containing the event to evaluate, in JSON")
+
+	return cmd
+}
+
+// decodeEvent decodes an event in JSON, either as is or wrapped, as output
+// by sensuctl event info.
+func decodeEvent(b []byte) (*corev2.Event, error) {
+	var wrapped struct {
+		Type string          `json:"type"`
+		Spec json.RawMessage `json:"spec"`
+	}
+	if err := json.Unmarshal(b, &wrapped); err != nil {
+		return nil, err
+	}
+	if wrapped.Type == "Event" && len(wrapped.Spec) > 0 {
+		b = wrapped.Spec
+	}
+	var event corev2.Event
+	if err := json.Unmarshal(b, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func printTestResult(w io.Writer, result *actions.FilterTestResult) error {
+	verdict := "allowed"
+	if result.Denied {
+		verdict = "denied"
+	}
+	if _, err := fmt.Fprintf(w, "Event %s by filter %s\n", verdict, result.Filter); err != nil {
+		return err
+	}
+	if len(result.Errors) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintln(w, "Errors:"); err != nil {
+		return err
+	}
+	for _, e := range result.Errors {
+		if _, err := fmt.Fprintf(w, "  - %s\n", e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
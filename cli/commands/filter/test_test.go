@@ -0,0 +1,68 @@
+package filter
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestCommandRunEClosure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sensuctl-filter-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name  string
+		event string
+	}{
+		{
+			name:  "raw event",
+			event: `{"entity":{"metadata":{"name":"entity1"}},"check":{"metadata":{"name":"check1"},"status":2}}`,
+		},
+		{
+			name:  "wrapped event",
+			event: `{"type":"Event","api_version":"core/v2","spec":{"entity":{"metadata":{"name":"entity1"}},"check":{"metadata":{"name":"check1"},"status":2}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(dir, "event.json")
+			require.NoError(t, ioutil.WriteFile(file, []byte(tt.event), 0644))
+
+			cli := test.NewCLI()
+			client := cli.Client.(*client.MockClient)
+			client.On("TestFilter", "state_change", mock.MatchedBy(func(event *corev2.Event) bool {
+				return event.Entity.Name == "entity1" && event.Check.Name == "check1" && event.Check.Status == 2
+			})).Return(&actions.FilterTestResult{
+				Filter: "state_change",
+				Denied: true,
+				Errors: []string{`expression "event.check.foo(": SyntaxError`},
+			}, nil)
+
+			cmd := TestCommand(cli)
+			require.NoError(t, cmd.Flags().Set("file", file))
+			out, err := test.RunCmd(cmd, []string{"state_change"})
+
+			assert.NoError(t, err)
+			assert.Contains(t, out, "Event denied by filter state_change")
+			assert.Contains(t, out, "SyntaxError")
+		})
+	}
+}
+
+func TestTestCommandRunMissingFile(t *testing.T) {
+	cli := test.NewCLI()
+	cmd := TestCommand(cli)
+	_, err := test.RunCmd(cmd, []string{"state_change"})
+
+	assert.Error(t, err)
+}
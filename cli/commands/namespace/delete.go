@@ -3,6 +3,8 @@ package namespace
 import (
 	"errors"
 	"fmt"
+	"io"
+	"sort"
 
 	"github.com/sensu/sensu-go/cli"
 	"github.com/sensu/sensu-go/cli/commands/helpers"
@@ -23,14 +25,23 @@ func DeleteCommand(cli *cli.SensuCli) *cobra.Command {
 			}
 
 			namespace := args[0]
+			cascade, _ := cmd.Flags().GetBool("cascade")
 
 			if skipConfirm, _ := cmd.Flags().GetBool("skip-confirm"); !skipConfirm {
-				if confirmed := helpers.ConfirmDeleteResource(namespace, "namespace"); !confirmed {
+				if confirmed := confirmDelete(namespace, cascade); !confirmed {
 					fmt.Fprintln(cmd.OutOrStdout(), "Canceled")
 					return nil
 				}
 			}
 
+			if cascade {
+				deleted, err := cli.Client.DeleteNamespaceCascade(namespace)
+				if err != nil {
+					return err
+				}
+				return printDeleted(cmd.OutOrStdout(), deleted)
+			}
+
 			err := cli.Client.DeleteNamespace(namespace)
 			if err != nil {
 				return err
@@ -42,6 +53,38 @@ func DeleteCommand(cli *cli.SensuCli) *cobra.Command {
 	}
 
 	_ = cmd.Flags().Bool("skip-confirm", false, "skip interactive confirmation prompt")
+	_ = cmd.Flags().Bool("cascade", false, "also delete all the resources contained in the namespace")
 
 	return cmd
 }
+
+func confirmDelete(namespace string, cascade bool) bool {
+	if !cascade {
+		return helpers.ConfirmDeleteResource(namespace, "namespace")
+	}
+	confirm := &helpers.Confirm{
+		Message: fmt.Sprintf("Are you sure you would like to delete namespace '%s' and all the resources it contains?", namespace),
+		Default: false,
+	}
+	ok, _ := confirm.Ask()
+	return ok
+}
+
+// printDeleted prints the number of resources deleted by type, sorted by type.
+func printDeleted(w io.Writer, deleted map[string]int) error {
+	kinds := make([]string, 0, len(deleted))
+	for kind := range deleted {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	if _, err := fmt.Fprintln(w, "Deleted"); err != nil {
+		return err
+	}
+	for _, kind := range kinds {
+		if _, err := fmt.Fprintf(w, "  %s: %d\n", kind, deleted[kind]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -76,3 +76,20 @@ func TestDeleteCommandRunEFailConfirm(t *testing.T) {
 	assert.Contains(out, "Canceled")
 	assert.NoError(err)
 }
+
+func TestDeleteCommandRunEClosureWithCascade(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewMockCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("DeleteNamespaceCascade", "foo").Return(map[string]int{"entities": 2, "checks": 1}, nil)
+
+	cmd := DeleteCommand(cli)
+	require.NoError(t, cmd.Flags().Set("skip-confirm", "t"))
+	require.NoError(t, cmd.Flags().Set("cascade", "t"))
+	out, err := test.RunCmd(cmd, []string{"foo"})
+
+	assert.Nil(err)
+	assert.Equal("Deleted\n  checks: 1\n  entities: 2\n", out)
+	client.AssertNotCalled(t, "DeleteNamespace", "foo")
+}
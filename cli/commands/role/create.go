@@ -55,6 +55,11 @@ func CreateCommand(cli *cli.SensuCli) *cobra.Command {
 			}
 			rule.ResourceNames = resourceNames
 
+			rule.ResourceNamesRegex, err = cmd.Flags().GetBool("resource-names-regex")
+			if err != nil {
+				return err
+			}
+
 			// Assign the rule to our role and validate it
 			role.Rules = []v2.Rule{rule}
 			if err := role.Validate(); err != nil {
@@ -78,6 +83,9 @@ func CreateCommand(cli *cli.SensuCli) *cobra.Command {
 	_ = cmd.Flags().StringSliceP("resource-name", "n", []string{},
 		"optional resource names that the rule applies to",
 	)
+	_ = cmd.Flags().Bool("resource-names-regex", false,
+		"match resource names as regular expressions of the whole name",
+	)
 
 	return cmd
 }
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/client/config"
 	"github.com/sensu/sensu-go/cli/commands/helpers"
 	"github.com/sensu/sensu-go/cli/elements/table"
 	"github.com/sensu/sensu-go/types"
@@ -35,11 +36,22 @@ func InfoCommand(cli *cli.SensuCli) *cobra.Command {
 			// Determine the format to use to output the data
 			flag := helpers.GetChangedStringValueViper("format", cmd.Flags())
 			format := cli.Config.Format()
+			if flag == config.FormatMarkdown || (flag == "" && format == config.FormatMarkdown) {
+				return printRulesToMarkdown(r, cmd.OutOrStdout())
+			}
 			return helpers.PrintFormatted(flag, format, r, cmd.OutOrStdout(), printRulesToTable)
 		},
 	}
 
 	helpers.AddFormatFlag(cmd.Flags())
+	cmd.Flags().Lookup("format").Usage = fmt.Sprintf(
+		`format of data returned ("%s"|"%s"|"%s"|"%s"|"%s")`,
+		config.FormatJSON,
+		config.FormatWrappedJSON,
+		config.FormatTabular,
+		config.FormatYAML,
+		config.FormatMarkdown,
+	)
 
 	return cmd
 }
@@ -49,7 +61,23 @@ func printRulesToTable(v interface{}, io io.Writer) error {
 	if !ok {
 		return fmt.Errorf("%t is not a role", v)
 	}
-	table := table.New([]*table.Column{
+	rulesTable(queryResults).Render(io, queryResults.Rules)
+	return nil
+}
+
+func printRulesToMarkdown(v interface{}, io io.Writer) error {
+	queryResults, ok := v.(*types.Role)
+	if !ok {
+		return fmt.Errorf("%t is not a role", v)
+	}
+	rulesTable(queryResults).RenderMarkdown(io, queryResults.Rules)
+	return nil
+}
+
+// rulesTable returns the table of the rules of the role, shared by the
+// tabular and markdown formats.
+func rulesTable(queryResults *types.Role) *table.Table {
+	return table.New([]*table.Column{
 		{
 			Title:       "Namespace",
 			ColumnStyle: table.PrimaryTextStyle,
@@ -84,11 +112,12 @@ func printRulesToTable(v interface{}, io io.Writer) error {
 				if !ok {
 					return cli.TypeError
 				}
-				return strings.Join(rule.ResourceNames, ",")
+				names := strings.Join(rule.ResourceNames, ",")
+				if rule.ResourceNamesRegex && names != "" {
+					names += " (regex)"
+				}
+				return names
 			},
 		},
 	})
-
-	table.Render(io, queryResults.Rules)
-	return nil
 }
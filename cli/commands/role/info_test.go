@@ -8,6 +8,7 @@ import (
 	test "github.com/sensu/sensu-go/cli/commands/testing"
 	"github.com/sensu/sensu-go/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInfoCommand(t *testing.T) {
@@ -81,3 +82,46 @@ func TestInfoCommandRunEJSON(t *testing.T) {
 	assert.NotEmpty(out)
 	assert.NoError(err)
 }
+
+func TestInfoCommandRunEMarkdown(t *testing.T) {
+	assert := assert.New(t)
+	cli := test.NewMockCLI()
+
+	config := cli.Config.(*client.MockConfig)
+	config.On("Format").Return("tabular")
+
+	role := types.FixtureRole("abc", "default")
+	role.Rules[0].ResourceNames = []string{"foo|bar"}
+	client := cli.Client.(*client.MockClient)
+	client.On("FetchRole", "abc").Return(role, nil)
+
+	cmd := InfoCommand(cli)
+	require.NoError(t, cmd.Flags().Set("format", "markdown"))
+	out, err := test.RunCmd(cmd, []string{"abc"})
+
+	assert.NoError(err)
+	assert.Contains(out, "| Namespace | Verbs | Resources | Resource Names |")
+	assert.Contains(out, "| --- | --- | --- | --- |")
+	assert.Contains(out, "| default |")
+	assert.Contains(out, "foo\\|bar")
+}
+
+func TestInfoCommandRunEResourceNamesRegex(t *testing.T) {
+	assert := assert.New(t)
+	cli := test.NewMockCLI()
+
+	config := cli.Config.(*client.MockConfig)
+	config.On("Format").Return("tabular")
+
+	role := types.FixtureRole("abc", "default")
+	role.Rules[0].ResourceNames = []string{"web-.*"}
+	role.Rules[0].ResourceNamesRegex = true
+	client := cli.Client.(*client.MockClient)
+	client.On("FetchRole", "abc").Return(role, nil)
+
+	cmd := InfoCommand(cli)
+	out, err := test.RunCmd(cmd, []string{"abc"})
+
+	assert.NoError(err)
+	assert.Contains(out, "web-.* (regex)")
+}
@@ -0,0 +1,89 @@
+package silenced
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/commands/timeutil"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// BulkCreateCommand is a command that creates the silenced entries of a
+// selector in one operation
+func BulkCreateCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "bulk-create",
+		Short:        "create silenced entries for subscriptions and checks selected by their labels",
+		SilenceUsage: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			// Mark flags are required for bash-completions
+			_ = cmd.MarkFlagRequired("reason")
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			selector, err := selectorFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			if err := selector.Validate(); err != nil {
+				return err
+			}
+
+			entries, err := cli.Client.CreateSilencedFromSelector(cli.Config.Namespace(), selector)
+			if err != nil {
+				return err
+			}
+			if len(entries) == 0 {
+				_, err := fmt.Fprintln(cmd.OutOrStdout(), "No check matches the selector")
+				return err
+			}
+			for _, entry := range entries {
+				if _, err := fmt.Fprintf(cmd.OutOrStdout(), "Created %s\n", entry.Name); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	_ = cmd.Flags().StringP("reason", "r", "", "reason for the silenced entries")
+	_ = cmd.Flags().BoolP("expire-on-resolve", "x", false, "clear silenced entries on resolution")
+	_ = cmd.Flags().StringP("expire", "e", expireDefault, "expiry in seconds")
+	_ = cmd.Flags().StringSliceP("subscription", "s", nil, "silence subscription, may be repeated")
+	_ = cmd.Flags().StringToString("check-label", nil, "silence the checks with this label, may be repeated (e.g. maintenance=true)")
+	_ = cmd.Flags().StringP("begin", "b", beginDefault, "silence begin in human readable time (Format: Jan 02 2006 3:04PM MST)")
+
+	return cmd
+}
+
+func selectorFromFlags(flags *pflag.FlagSet) (*corev2.SilencedSelector, error) {
+	selector := &corev2.SilencedSelector{}
+	selector.Subscriptions, _ = flags.GetStringSlice("subscription")
+	selector.CheckLabels, _ = flags.GetStringToString("check-label")
+	selector.Reason, _ = flags.GetString("reason")
+	selector.ExpireOnResolve, _ = flags.GetBool("expire-on-resolve")
+
+	expire, _ := flags.GetString("expire")
+	var err error
+	selector.Expire, err = strconv.ParseInt(expire, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	begin, _ := flags.GetString("begin")
+	selector.Begin, err = timeutil.ConvertToUnix(begin)
+	if err != nil {
+		return nil, err
+	}
+	if len(selector.Subscriptions) == 0 && len(selector.CheckLabels) == 0 {
+		return nil, errors.New("must specify --subscription or --check-label")
+	}
+	return selector, nil
+}
@@ -0,0 +1,53 @@
+package silenced
+
+import (
+	"errors"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkCreateCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewMockCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("CreateSilencedFromSelector", "default", mock.MatchedBy(func(s *corev2.SilencedSelector) bool {
+		return len(s.Subscriptions) == 1 && s.Subscriptions[0] == "linux" &&
+			s.CheckLabels["maintenance"] == "true" &&
+			s.Expire == 3600 && s.Reason == "maintenance" && s.Begin > 0
+	})).Return([]corev2.Silenced{*corev2.FixtureSilenced("linux:cpu")}, nil)
+
+	cmd := BulkCreateCommand(cli)
+	require.NoError(t, cmd.Flags().Set("reason", "maintenance"))
+	require.NoError(t, cmd.Flags().Set("expire", "3600"))
+	require.NoError(t, cmd.Flags().Set("subscription", "linux"))
+	require.NoError(t, cmd.Flags().Set("check-label", "maintenance=true"))
+	out, err := test.RunCmd(cmd, []string{})
+	require.NoError(t, err)
+	assert.Equal("Created linux:cpu\n", out)
+}
+
+func TestBulkCreateCommandWithoutSelector(t *testing.T) {
+	cli := test.NewMockCLI()
+	cmd := BulkCreateCommand(cli)
+	require.NoError(t, cmd.Flags().Set("reason", "maintenance"))
+	_, err := test.RunCmd(cmd, []string{})
+	assert.Error(t, err)
+}
+
+func TestBulkCreateCommandWithServerErr(t *testing.T) {
+	cli := test.NewMockCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("CreateSilencedFromSelector", mock.Anything, mock.Anything).Return([]corev2.Silenced(nil), errors.New("error"))
+
+	cmd := BulkCreateCommand(cli)
+	require.NoError(t, cmd.Flags().Set("subscription", "linux"))
+	_, err := test.RunCmd(cmd, []string{})
+	assert.Error(t, err)
+}
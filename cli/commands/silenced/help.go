@@ -23,6 +23,7 @@ func HelpCommand(cli *cli.SensuCli) *cobra.Command {
 	// Add sub-commands
 	cmd.AddCommand(
 		CreateCommand(cli),
+		BulkCreateCommand(cli),
 		DeleteCommand(cli),
 		ListCommand(cli),
 		InfoCommand(cli),
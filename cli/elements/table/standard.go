@@ -1,8 +1,10 @@
 package table
 
 import (
+	"fmt"
 	"io"
 	"reflect"
+	"strings"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/sensu/sensu-go/cli/elements/globals"
@@ -67,9 +69,38 @@ func (t *Table) Render(io io.Writer, results interface{}) {
 	t.writer.Render()
 }
 
-func (t *Table) writeRows(results interface{}) {
+// RenderMarkdown renders table as a markdown table given row values. Column
+// styles are not applied and pipe characters are escaped.
+func (t *Table) RenderMarkdown(io io.Writer, results interface{}) {
+	titles := make([]string, len(t.Columns))
+	separators := make([]string, len(t.Columns))
+	for i, column := range t.Columns {
+		titles[i] = escapeMarkdown(column.Title)
+		separators[i] = "---"
+	}
+	writeMarkdownRow(io, titles)
+	writeMarkdownRow(io, separators)
+
+	for _, row := range rows(results) {
+		cells := make([]string, len(t.Columns))
+		for i, column := range t.Columns {
+			cells[i] = escapeMarkdown(column.CellTransformer(row.Value))
+		}
+		writeMarkdownRow(io, cells)
+	}
+}
+
+func writeMarkdownRow(io io.Writer, cells []string) {
+	fmt.Fprintf(io, "| %s |\n", strings.Join(cells, " | "))
+}
+
+func escapeMarkdown(cell string) string {
+	return strings.ReplaceAll(cell, "|", "\\|")
+}
+
+func rows(results interface{}) []*Row {
 	if reflect.TypeOf(results).Kind() != reflect.Slice {
-		return
+		return nil
 	}
 
 	slice := reflect.ValueOf(results)
@@ -78,8 +109,11 @@ func (t *Table) writeRows(results interface{}) {
 	for i := 0; i < slice.Len(); i++ {
 		rows[i] = &Row{Value: slice.Index(i).Interface()}
 	}
+	return rows
+}
 
-	for _, row := range rows {
+func (t *Table) writeRows(results interface{}) {
+	for _, row := range rows(results) {
 		t.writeRow(row)
 	}
 }
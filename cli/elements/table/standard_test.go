@@ -61,6 +61,34 @@ func TestStandardTable(t *testing.T) {
 	assert.NotContains(row2, PrimaryTextStyle("cell-two"))
 }
 
+func TestMarkdownTable(t *testing.T) {
+	assert := assert.New(t)
+	writer := exWriter{}
+
+	table := New([]*Column{
+		{
+			Title:       "One",
+			ColumnStyle: PrimaryTextStyle,
+			CellTransformer: func(data interface{}) string {
+				return data.(string)
+			},
+		},
+		{
+			Title: "Two",
+			CellTransformer: func(_ interface{}) string {
+				return "cell-two"
+			},
+		},
+	})
+	table.RenderMarkdown(&writer, []string{"cell-one", "cell|one"})
+
+	expected := "| One | Two |\n" +
+		"| --- | --- |\n" +
+		"| cell-one | cell-two |\n" +
+		"| cell\\|one | cell-two |\n"
+	assert.Equal(expected, writer.result)
+}
+
 type exWriter struct {
 	result string
 }
@@ -0,0 +1,182 @@
+package resource
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/types"
+	"github.com/sensu/sensu-go/types/compat"
+)
+
+// Order sorts resources so that the resources referenced by another one, such
+// as the namespace of a check or its handlers, assets and hooks, come before
+// it. Only references between the given resources are considered, and the
+// original order is kept otherwise. An error describing the cycle is returned
+// if resources reference each other.
+func Order(resources []*types.Wrapper) ([]*types.Wrapper, error) {
+	keys := make(map[string]int, len(resources))
+	for i, resource := range resources {
+		if resource.Value != nil {
+			keys[resourceKey(resource)] = i
+		}
+	}
+
+	// dependencies[i] contains the indexes of the resources that resource i
+	// references
+	dependencies := make([][]int, len(resources))
+	for i, resource := range resources {
+		if resource.Value == nil {
+			continue
+		}
+		for _, ref := range references(resource) {
+			if j, ok := keys[ref]; ok && j != i {
+				dependencies[i] = append(dependencies[i], j)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(resources))
+	ordered := make([]*types.Wrapper, 0, len(resources))
+	var path []int
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return cycleError(resources, path, i)
+		}
+		state[i] = visiting
+		path = append(path, i)
+		for _, j := range dependencies[i] {
+			if err := visit(j); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		ordered = append(ordered, resources[i])
+		return nil
+	}
+
+	for i := range resources {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+func cycleError(resources []*types.Wrapper, path []int, i int) error {
+	var cycle []string
+	for k := len(path) - 1; k >= 0; k-- {
+		cycle = append([]string{describe(resources[path[k]])}, cycle...)
+		if path[k] == i {
+			break
+		}
+	}
+	cycle = append(cycle, describe(resources[i]))
+	return fmt.Errorf("resources reference each other, none of them were applied: %s", strings.Join(cycle, " -> "))
+}
+
+func describe(resource *types.Wrapper) string {
+	meta := compat.GetObjectMeta(resource.Value)
+	if meta.Namespace == "" {
+		return fmt.Sprintf("%s %q", kind(resource.Value), meta.Name)
+	}
+	return fmt.Sprintf("%s %q in namespace %q", kind(resource.Value), meta.Name, meta.Namespace)
+}
+
+// kind returns the name of the type of the resource, e.g. CheckConfig.
+func kind(value interface{}) string {
+	t := reflect.TypeOf(value)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	return t.Name()
+}
+
+func referenceKey(kind, namespace, name string) string {
+	return strings.Join([]string{kind, namespace, name}, "/")
+}
+
+func resourceKey(resource *types.Wrapper) string {
+	meta := compat.GetObjectMeta(resource.Value)
+	return referenceKey(kind(resource.Value), meta.Namespace, meta.Name)
+}
+
+// references returns the keys of the resources referenced by the resource.
+func references(resource *types.Wrapper) []string {
+	meta := compat.GetObjectMeta(resource.Value)
+	ns := meta.Namespace
+
+	var refs []string
+	add := func(kind, namespace string, names ...string) {
+		for _, name := range names {
+			if name != "" {
+				refs = append(refs, referenceKey(kind, namespace, name))
+			}
+		}
+	}
+	addReferences := func(references ...*corev2.ResourceReference) {
+		for _, ref := range references {
+			if ref != nil {
+				add(ref.Type, ns, ref.Name)
+			}
+		}
+	}
+
+	if ns != "" {
+		add("Namespace", "", ns)
+	}
+
+	switch value := resource.Value.(type) {
+	case *corev2.CheckConfig:
+		add("Handler", ns, value.Handlers...)
+		add("Handler", ns, value.OutputMetricHandlers...)
+		add("Asset", ns, value.RuntimeAssets...)
+		for _, hooks := range value.CheckHooks {
+			add("HookConfig", ns, hooks.Hooks...)
+		}
+		addReferences(value.Pipelines...)
+	case *corev2.Handler:
+		add("Handler", ns, value.Handlers...)
+		add("EventFilter", ns, value.Filters...)
+		add("Mutator", ns, value.Mutator)
+		add("Asset", ns, value.RuntimeAssets...)
+	case *corev2.EventFilter:
+		add("Asset", ns, value.RuntimeAssets...)
+	case *corev2.Mutator:
+		add("Asset", ns, value.RuntimeAssets...)
+	case *corev2.HookConfig:
+		add("Asset", ns, value.RuntimeAssets...)
+	case *corev2.Pipeline:
+		for _, workflow := range value.Workflows {
+			if workflow == nil {
+				continue
+			}
+			addReferences(workflow.Filters...)
+			addReferences(workflow.Mutator, workflow.Handler)
+		}
+	case *corev2.RoleBinding:
+		if value.RoleRef.Type == "ClusterRole" {
+			add(value.RoleRef.Type, "", value.RoleRef.Name)
+		} else {
+			add(value.RoleRef.Type, ns, value.RoleRef.Name)
+		}
+	case *corev2.ClusterRoleBinding:
+		add(value.RoleRef.Type, "", value.RoleRef.Name)
+	}
+	return refs
+}
@@ -0,0 +1,77 @@
+package resource
+
+import (
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func wrapForOrder(resource corev2.Resource) *types.Wrapper {
+	wrapper := types.WrapResource(resource)
+	return &wrapper
+}
+
+func resourceKeys(resources []*types.Wrapper) []string {
+	var keys []string
+	for _, resource := range resources {
+		keys = append(keys, resourceKey(resource))
+	}
+	return keys
+}
+
+func TestOrder(t *testing.T) {
+	check := corev2.FixtureCheckConfig("check")
+	check.Namespace = "dev"
+	check.Handlers = []string{"slack"}
+	check.RuntimeAssets = []string{"check-plugins"}
+
+	handler := corev2.FixtureHandler("slack")
+	handler.Namespace = "dev"
+	handler.Filters = []string{"state-change"}
+
+	filter := corev2.FixtureEventFilter("state-change")
+	filter.Namespace = "dev"
+
+	asset := corev2.FixtureAsset("check-plugins")
+	asset.Namespace = "dev"
+
+	// references to resources that are not applied are ignored
+	other := corev2.FixtureCheckConfig("other")
+	other.Namespace = "default"
+	other.Handlers = []string{"missing"}
+
+	resources := []*types.Wrapper{
+		wrapForOrder(check),
+		wrapForOrder(handler),
+		wrapForOrder(other),
+		wrapForOrder(filter),
+		wrapForOrder(asset),
+		wrapForOrder(corev2.FixtureNamespace("dev")),
+	}
+	got, err := Order(resources)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Namespace//dev",
+		"EventFilter/dev/state-change",
+		"Handler/dev/slack",
+		"Asset/dev/check-plugins",
+		"CheckConfig/dev/check",
+		"CheckConfig/default/other",
+	}, resourceKeys(got))
+}
+
+func TestOrderCycle(t *testing.T) {
+	a := corev2.FixtureHandler("a")
+	a.Type = corev2.HandlerSetType
+	a.Handlers = []string{"b"}
+	b := corev2.FixtureHandler("b")
+	b.Type = corev2.HandlerSetType
+	b.Handlers = []string{"a"}
+
+	_, err := Order([]*types.Wrapper{wrapForOrder(corev2.FixtureCheckConfig("check")), wrapForOrder(a), wrapForOrder(b)})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `Handler "a" in namespace "default" -> Handler "b" in namespace "default" -> Handler "a" in namespace "default"`)
+}
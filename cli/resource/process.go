@@ -173,8 +173,12 @@ func NewPutter() *Putter {
 	return &Putter{}
 }
 
-// Process puts resources in the API.
+// Process puts resources in the API, after the resources they reference.
 func (p *Putter) Process(client client.GenericClient, resources []*types.Wrapper) error {
+	resources, err := Order(resources)
+	if err != nil {
+		return err
+	}
 	for i, resource := range resources {
 		if err := client.PutResource(*resource); err != nil {
 			return fmt.Errorf(
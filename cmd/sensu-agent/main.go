@@ -2,6 +2,9 @@ package main
 
 import (
 	"context"
+	// embed the timezone database, so that cron timezones can be validated
+	// on hosts without one
+	_ "time/tzdata"
 
 	"github.com/sensu/sensu-go/agent"
 	"github.com/sensu/sensu-go/agent/cmd"
@@ -34,6 +37,7 @@ func main() {
 	addRootPlatformArguments(rootCmd)
 	addStartPlatformArguments(startCmd)
 	rootCmd.AddCommand(startCmd)
+	rootCmd.AddCommand(cmd.InstallAssetCommand())
 
 	cmd.RegisterConfigAliases()
 
@@ -3,7 +3,13 @@ package main
 import (
 	_ "net/http/pprof"
 	"os"
+	// embed the timezone database, so that cron timezones can be validated
+	// on hosts without one
+	_ "time/tzdata"
 
+	// register the pgx database/sql driver, used to migrate the schemas of
+	// the postgresql stores
+	_ "github.com/jackc/pgx/v4/stdlib"
 	"github.com/sensu/sensu-go/backend"
 	"github.com/sensu/sensu-go/backend/cmd"
 	"github.com/sensu/sensu-go/backend/seeds"
@@ -24,6 +30,9 @@ func main() {
 	rootCmd.AddCommand(cmd.StartCommand(backend.Initialize))
 	rootCmd.AddCommand(cmd.VersionCommand())
 	rootCmd.AddCommand(cmd.InitCommand())
+	rootCmd.AddCommand(cmd.EtcdCommand())
+	rootCmd.AddCommand(cmd.GraphQLCommand())
+	rootCmd.AddCommand(cmd.StoreCommand())
 
 	if err := rootCmd.Execute(); err != nil {
 		if err == seeds.ErrAlreadyInitialized {
@@ -2,6 +2,9 @@ package main
 
 import (
 	"os"
+	// embed the timezone database, so that cron timezones can be validated
+	// on hosts without one
+	_ "time/tzdata"
 
 	"github.com/sensu/sensu-go/cli"
 	"github.com/sensu/sensu-go/cli/commands"
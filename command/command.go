@@ -75,6 +75,11 @@ type ExecutionRequest struct {
 	// Command is the command to be executed.
 	Command string
 
+	// Argv is the executable and its arguments, executed directly instead of
+	// Command through a shell when set. The executable is looked up in the
+	// PATH of Env, if any.
+	Argv []string
+
 	// Env ...
 	Env []string
 
@@ -94,6 +99,14 @@ type ExecutionRequest struct {
 
 	// InProgressMu is the mutex for the InProgress map.
 	InProgressMu *sync.Mutex
+
+	// User is the name or ID of the user the command runs as. The command
+	// runs as the current user if empty.
+	User string
+
+	// Group is the name or ID of the group the command runs as. The primary
+	// group of User is used if empty.
+	Group string
 }
 
 // ExecutionResponse provides the response information of an ExecutionRequest.
@@ -137,8 +150,12 @@ func (e *ExecutionRequest) Execute(ctx context.Context, execution ExecutionReque
 	ctx, timeout := context.WithCancel(ctx)
 	defer timeout()
 
-	// Taken from Sensu-Spawn (Sensu 1.x.x).
-	cmd = Command(ctx, execution.Command)
+	if len(execution.Argv) > 0 {
+		cmd = exec.CommandContext(ctx, lookPath(execution.Argv[0], execution.Env), execution.Argv[1:]...)
+	} else {
+		// Taken from Sensu-Spawn (Sensu 1.x.x).
+		cmd = Command(ctx, execution.Command)
+	}
 
 	// Set the ENV for the command if it is set
 	if len(execution.Env) > 0 {
@@ -169,6 +186,11 @@ func (e *ExecutionRequest) Execute(ctx context.Context, execution ExecutionReque
 		timer.Stop()
 		timer = time.NewTimer(time.Duration(execution.Timeout) * time.Second)
 	}
+	// The credential is set once the process group is, which replaces the
+	// process attributes on unix
+	if err := SetCredential(cmd, execution.User, execution.Group); err != nil {
+		return resp, err
+	}
 	if err := cmd.Start(); err != nil {
 		// Something unexpected happened when attempting to
 		// fork/exec, return immediately.
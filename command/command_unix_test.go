@@ -22,3 +22,26 @@ func TestExecuteUnix(t *testing.T) {
 	assert.Equal(t, 2, sleepMultipleExec.Status)
 	assert.NotEqual(t, 0, sleepMultipleExec.Duration)
 }
+
+func TestExecuteArgvUnix(t *testing.T) {
+	// arguments are passed as is, without shell expansion or quoting
+	argv := ExecutionRequest{
+		Argv: []string{"sh", "-c", `printf '%s %s' "$FOO" "$1"`, "sh", "it's $FOO"},
+		Env:  []string{"PATH=/bin:/usr/bin", "FOO=bar"},
+	}
+	argvExec, argvErr := argv.Execute(context.Background(), argv)
+	assert.NoError(t, argvErr)
+	assert.Equal(t, "bar it's $FOO", argvExec.Output)
+	assert.Equal(t, 0, argvExec.Status)
+
+	// the command is ignored
+	argv.Command = "exit 1"
+	argvExec, argvErr = argv.Execute(context.Background(), argv)
+	assert.NoError(t, argvErr)
+	assert.Equal(t, 0, argvExec.Status)
+
+	// executables that cannot be found are errors
+	missing := ExecutionRequest{Argv: []string{"sensu-missing-executable"}}
+	_, missingErr := missing.Execute(context.Background(), missing)
+	assert.Error(t, missingErr)
+}
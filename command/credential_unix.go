@@ -0,0 +1,86 @@
+//go:build !windows
+// +build !windows
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"syscall"
+)
+
+// SetCredential sets the user and group the command process runs as. The
+// user and group can be given by name or by ID, and the primary group of the
+// user is used when the group is empty. Nothing is changed when both are
+// empty.
+func SetCredential(cmd *exec.Cmd, username, group string) error {
+	if username == "" && group == "" {
+		return nil
+	}
+	uid, gid := os.Getuid(), os.Getgid()
+	if username != "" {
+		u, err := lookupUser(username)
+		if err != nil {
+			return err
+		}
+		if uid, err = strconv.Atoi(u.Uid); err != nil {
+			return fmt.Errorf("invalid uid %q for user %q", u.Uid, username)
+		}
+		if gid, err = strconv.Atoi(u.Gid); err != nil {
+			return fmt.Errorf("invalid gid %q for user %q", u.Gid, username)
+		}
+	}
+	if group != "" {
+		g, err := lookupGroup(group)
+		if err != nil {
+			return err
+		}
+		if gid, err = strconv.Atoi(g.Gid); err != nil {
+			return fmt.Errorf("invalid gid %q for group %q", g.Gid, group)
+		}
+	}
+	if uid == os.Geteuid() && gid == os.Getegid() {
+		// the command already runs as this user and group
+		return nil
+	}
+	if os.Geteuid() != 0 {
+		return errors.New("the agent must run as root to execute commands as another user or group")
+	}
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Credential = &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid)}
+	return nil
+}
+
+// lookupUser looks a user up by name, then by ID.
+func lookupUser(name string) (*user.User, error) {
+	u, err := user.Lookup(name)
+	if err == nil {
+		return u, nil
+	}
+	if _, convErr := strconv.Atoi(name); convErr == nil {
+		if u, idErr := user.LookupId(name); idErr == nil {
+			return u, nil
+		}
+	}
+	return nil, fmt.Errorf("user %q does not exist: %s", name, err)
+}
+
+// lookupGroup looks a group up by name, then by ID.
+func lookupGroup(name string) (*user.Group, error) {
+	g, err := user.LookupGroup(name)
+	if err == nil {
+		return g, nil
+	}
+	if _, convErr := strconv.Atoi(name); convErr == nil {
+		if g, idErr := user.LookupGroupId(name); idErr == nil {
+			return g, nil
+		}
+	}
+	return nil, fmt.Errorf("group %q does not exist: %s", name, err)
+}
@@ -0,0 +1,18 @@
+//go:build windows
+// +build windows
+
+package command
+
+import (
+	"errors"
+	"os/exec"
+)
+
+// SetCredential returns an error when a user or group is given, running
+// commands as another user is not supported on Windows.
+func SetCredential(cmd *exec.Cmd, username, group string) error {
+	if username == "" && group == "" {
+		return nil
+	}
+	return errors.New("running commands as a specific user or group is not supported on windows")
+}
@@ -2,6 +2,8 @@ package command
 
 import (
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 )
 
@@ -21,3 +23,27 @@ func FakeCommand(command string, args ...string) ExecutionRequest {
 
 	return execution
 }
+
+// lookPath returns the path of the executable file in the PATH of env, or file
+// itself if it contains a path separator, env has no PATH, or the file could
+// not be found, in which case it is looked up in the PATH of the process.
+func lookPath(file string, env []string) string {
+	if strings.ContainsRune(file, filepath.Separator) || strings.Contains(file, "/") {
+		return file
+	}
+	var path string
+	for _, e := range env {
+		if strings.HasPrefix(e, "PATH=") {
+			path = strings.TrimPrefix(e, "PATH=")
+		}
+	}
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			continue
+		}
+		if p, err := exec.LookPath(filepath.Join(dir, file)); err == nil {
+			return p
+		}
+	}
+	return file
+}
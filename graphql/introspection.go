@@ -0,0 +1,69 @@
+package graphql
+
+import (
+	"errors"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// errIntrospectionDisabled is returned for the queries selecting the
+// introspection fields when introspection is disabled.
+var errIntrospectionDisabled = errors.New("GraphQL introspection is disabled")
+
+// checkIntrospection returns an error if an operation of the document selects
+// the __schema or __type introspection fields, fragments included. The
+// __typename field remains allowed, as clients rely on it to resolve unions
+// and interfaces.
+func checkIntrospection(doc *ast.Document) error {
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok && fragment.Name != nil {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+	visited := map[string]bool{}
+	for _, def := range doc.Definitions {
+		operation, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if selectsIntrospection(operation.SelectionSet, fragments, visited) {
+			return errIntrospectionDisabled
+		}
+	}
+	return nil
+}
+
+func selectsIntrospection(set *ast.SelectionSet, fragments map[string]*ast.FragmentDefinition, visited map[string]bool) bool {
+	if set == nil {
+		return false
+	}
+	for _, selection := range set.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if selection.Name != nil {
+				switch selection.Name.Value {
+				case "__schema", "__type":
+					return true
+				}
+			}
+			if selectsIntrospection(selection.SelectionSet, fragments, visited) {
+				return true
+			}
+		case *ast.InlineFragment:
+			if selectsIntrospection(selection.SelectionSet, fragments, visited) {
+				return true
+			}
+		case *ast.FragmentSpread:
+			if selection.Name == nil || visited[selection.Name.Value] {
+				continue
+			}
+			visited[selection.Name.Value] = true
+			fragment, ok := fragments[selection.Name.Value]
+			if ok && selectsIntrospection(fragment.SelectionSet, fragments, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
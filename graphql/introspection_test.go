@@ -0,0 +1,57 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckIntrospection(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		query   string
+		wantErr bool
+	}{
+		{
+			desc:  "regular query",
+			query: `query { namespace(name: "default") { name __typename } }`,
+		},
+		{
+			desc:    "schema",
+			query:   `query { __schema { types { name } } }`,
+			wantErr: true,
+		},
+		{
+			desc:    "type",
+			query:   `query { __type(name: "Entity") { name } }`,
+			wantErr: true,
+		},
+		{
+			desc: "fragment",
+			query: `
+				query { ...schemaFields }
+				fragment schemaFields on Query { __schema { queryType { name } } }
+			`,
+			wantErr: true,
+		},
+		{
+			desc:    "inline fragment",
+			query:   `query { ... on Query { __type(name: "Entity") { name } } }`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: tc.query})
+			require.NoError(t, err)
+			err = checkIntrospection(doc)
+			if tc.wantErr {
+				assert.Equal(t, errIntrospectionDisabled, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
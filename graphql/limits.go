@@ -0,0 +1,120 @@
+package graphql
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// QueryLimits restricts the cost of the queries executed by the service. A
+// limit of 0 is disabled.
+type QueryLimits struct {
+	// MaxDepth is the maximum nesting of the fields of an operation.
+	MaxDepth int
+
+	// MaxComplexity is the maximum number of fields selected by an operation,
+	// fragments included.
+	MaxComplexity int
+}
+
+// Check returns an error if an operation of the document exceeds the limits.
+func (l QueryLimits) Check(doc *ast.Document) error {
+	if l.MaxDepth <= 0 && l.MaxComplexity <= 0 {
+		return nil
+	}
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok && fragment.Name != nil {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+	for _, def := range doc.Definitions {
+		operation, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		m := queryMeasure{
+			fragments: fragments,
+			visiting:  map[string]bool{},
+			measured:  map[string]fragmentMeasure{},
+		}
+		depth := m.measure(operation.SelectionSet, 0)
+		if l.MaxDepth > 0 && depth > l.MaxDepth {
+			return fmt.Errorf("query depth of %d exceeds the maximum of %d", depth, l.MaxDepth)
+		}
+		if l.MaxComplexity > 0 && m.complexity > l.MaxComplexity {
+			return fmt.Errorf("query complexity of %d exceeds the maximum of %d", m.complexity, l.MaxComplexity)
+		}
+	}
+	return nil
+}
+
+type queryMeasure struct {
+	fragments  map[string]*ast.FragmentDefinition
+	visiting   map[string]bool
+	measured   map[string]fragmentMeasure
+	complexity int
+}
+
+// fragmentMeasure is the depth and complexity of a fragment, relative to the
+// selection set it is spread in. Fragments are measured once per operation,
+// so that nested spreads do not make the measure itself exponential.
+type fragmentMeasure struct {
+	depth      int
+	complexity int
+}
+
+// add adds n to the complexity, which saturates instead of overflowing.
+func (m *queryMeasure) add(n int) {
+	if m.complexity > math.MaxInt-n {
+		m.complexity = math.MaxInt
+		return
+	}
+	m.complexity += n
+}
+
+// measure returns the depth of the selection set, found at the given depth,
+// and adds its fields to the complexity.
+func (m *queryMeasure) measure(set *ast.SelectionSet, depth int) int {
+	if set == nil {
+		return depth
+	}
+	max := depth
+	for _, selection := range set.Selections {
+		d := depth
+		switch selection := selection.(type) {
+		case *ast.Field:
+			m.add(1)
+			d = m.measure(selection.SelectionSet, depth+1)
+		case *ast.InlineFragment:
+			d = m.measure(selection.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			if selection.Name == nil {
+				continue
+			}
+			name := selection.Name.Value
+			if measured, ok := m.measured[name]; ok {
+				m.add(measured.complexity)
+				d = depth + measured.depth
+				break
+			}
+			fragment, ok := m.fragments[name]
+			// fragments that spread themselves are rejected by the validation
+			if !ok || m.visiting[name] {
+				continue
+			}
+			m.visiting[name] = true
+			sub := queryMeasure{fragments: m.fragments, visiting: m.visiting, measured: m.measured}
+			measured := fragmentMeasure{depth: sub.measure(fragment.SelectionSet, 0), complexity: sub.complexity}
+			m.visiting[name] = false
+			m.measured[name] = measured
+			m.add(measured.complexity)
+			d = depth + measured.depth
+		}
+		if d > max {
+			max = d
+		}
+	}
+	return max
+}
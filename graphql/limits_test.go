@@ -0,0 +1,95 @@
+package graphql
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryLimitsCheck(t *testing.T) {
+	const query = `
+		query {
+			namespace(name: "default") {
+				events {
+					nodes {
+						entity { ...entityFields }
+					}
+				}
+			}
+		}
+
+		fragment entityFields on Entity {
+			name
+			... on Entity {
+				events { nodes { id } }
+			}
+		}
+	`
+	// namespace > events > nodes > entity > events > nodes > id
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc    string
+		limits  QueryLimits
+		wantErr string
+	}{
+		{
+			desc:   "no limits",
+			limits: QueryLimits{},
+		},
+		{
+			desc:   "within limits",
+			limits: QueryLimits{MaxDepth: 7, MaxComplexity: 8},
+		},
+		{
+			desc:    "too deep",
+			limits:  QueryLimits{MaxDepth: 6},
+			wantErr: "query depth of 7 exceeds the maximum of 6",
+		},
+		{
+			desc:    "too complex",
+			limits:  QueryLimits{MaxComplexity: 7},
+			wantErr: "query complexity of 8 exceeds the maximum of 7",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.limits.Check(doc)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestQueryLimitsCheckRecursiveFragment(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{Source: `
+		query { ...a }
+		fragment a on Query { viewer { ...a } }
+	`})
+	require.NoError(t, err)
+	assert.NoError(t, QueryLimits{MaxDepth: 1, MaxComplexity: 1}.Check(doc))
+}
+
+func TestQueryLimitsCheckNestedFragments(t *testing.T) {
+	// every fragment spreads the next one twice, the complexity doubles at
+	// every level of nesting
+	source := "query { ...f0 }\n"
+	const levels = 64
+	for i := 0; i < levels; i++ {
+		source += fmt.Sprintf("fragment f%d on Query { id ...f%d ...f%d }\n", i, i+1, i+1)
+	}
+	source += fmt.Sprintf("fragment f%d on Query { id }\n", levels)
+	doc, err := parser.Parse(parser.ParseParams{Source: source})
+	require.NoError(t, err)
+
+	err = QueryLimits{MaxComplexity: 1000}.Check(doc)
+	assert.EqualError(t, err, fmt.Sprintf("query complexity of %d exceeds the maximum of 1000", math.MaxInt))
+}
@@ -0,0 +1,274 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// specifiedScalars are the scalars every GraphQL schema provides, omitted from
+// the printed schema.
+var specifiedScalars = map[string]bool{
+	"Boolean": true,
+	"Float":   true,
+	"ID":      true,
+	"Int":     true,
+	"String":  true,
+}
+
+// PrintSchema returns the schema of the service in the GraphQL schema
+// definition language (SDL), with its types sorted by name.
+func (service *Service) PrintSchema() string {
+	return printSchema(service.schema)
+}
+
+func printSchema(schema graphql.Schema) string {
+	var defs []string
+	if def := printSchemaDefinition(schema); def != "" {
+		defs = append(defs, def)
+	}
+
+	specified := map[string]bool{}
+	for _, directive := range graphql.SpecifiedDirectives {
+		specified[directive.Name] = true
+	}
+	directives := []*graphql.Directive{}
+	for _, directive := range schema.Directives() {
+		if !specified[directive.Name] {
+			directives = append(directives, directive)
+		}
+	}
+	sort.Slice(directives, func(i, j int) bool {
+		return directives[i].Name < directives[j].Name
+	})
+	for _, directive := range directives {
+		defs = append(defs, printDirective(directive))
+	}
+
+	typeMap := schema.TypeMap()
+	names := make([]string, 0, len(typeMap))
+	for name := range typeMap {
+		if strings.HasPrefix(name, "__") || specifiedScalars[name] {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if def := printType(typeMap[name]); def != "" {
+			defs = append(defs, def)
+		}
+	}
+
+	return strings.Join(defs, "\n\n") + "\n"
+}
+
+// printSchemaDefinition returns the schema definition, or an empty string
+// when the root types have their conventional names.
+func printSchemaDefinition(schema graphql.Schema) string {
+	query := schema.QueryType()
+	mutation := schema.MutationType()
+	subscription := schema.SubscriptionType()
+	if (query == nil || query.Name() == "Query") &&
+		(mutation == nil || mutation.Name() == "Mutation") &&
+		(subscription == nil || subscription.Name() == "Subscription") {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("schema {\n")
+	if query != nil {
+		fmt.Fprintf(&b, "  query: %s\n", query.Name())
+	}
+	if mutation != nil {
+		fmt.Fprintf(&b, "  mutation: %s\n", mutation.Name())
+	}
+	if subscription != nil {
+		fmt.Fprintf(&b, "  subscription: %s\n", subscription.Name())
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func printType(t graphql.Type) string {
+	switch t := t.(type) {
+	case *graphql.Scalar:
+		return printDescription(t.Description(), "") + "scalar " + t.Name()
+	case *graphql.Enum:
+		return printEnum(t)
+	case *graphql.InputObject:
+		return printInputObject(t)
+	case *graphql.Object:
+		def := "type " + t.Name()
+		if len(t.Interfaces()) > 0 {
+			names := make([]string, 0, len(t.Interfaces()))
+			for _, iface := range t.Interfaces() {
+				names = append(names, iface.Name())
+			}
+			def += " implements " + strings.Join(names, " & ")
+		}
+		return printDescription(t.Description(), "") + def + printFields(t.Fields())
+	case *graphql.Interface:
+		return printDescription(t.Description(), "") + "interface " + t.Name() + printFields(t.Fields())
+	case *graphql.Union:
+		names := make([]string, 0, len(t.Types()))
+		for _, member := range t.Types() {
+			names = append(names, member.Name())
+		}
+		return printDescription(t.Description(), "") + "union " + t.Name() + " = " + strings.Join(names, " | ")
+	}
+	return ""
+}
+
+func printEnum(t *graphql.Enum) string {
+	values := append([]*graphql.EnumValueDefinition{}, t.Values()...)
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Name < values[j].Name
+	})
+
+	var b strings.Builder
+	b.WriteString(printDescription(t.Description(), ""))
+	fmt.Fprintf(&b, "enum %s {\n", t.Name())
+	for _, value := range values {
+		b.WriteString(printDescription(value.Description, "  "))
+		fmt.Fprintf(&b, "  %s%s\n", value.Name, printDeprecated(value.DeprecationReason))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func printInputObject(t *graphql.InputObject) string {
+	fields := t.Fields()
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(printDescription(t.Description(), ""))
+	fmt.Fprintf(&b, "input %s {\n", t.Name())
+	for _, name := range names {
+		field := fields[name]
+		b.WriteString(printDescription(field.Description(), "  "))
+		fmt.Fprintf(&b, "  %s: %s%s\n", field.Name(), field.Type, printDefaultValue(field.Type, field.DefaultValue))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func printFields(fields graphql.FieldDefinitionMap) string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(" {\n")
+	for _, name := range names {
+		field := fields[name]
+		b.WriteString(printDescription(field.Description, "  "))
+		fmt.Fprintf(&b, "  %s%s: %s%s\n", field.Name, printArgs(field.Args), field.Type, printDeprecated(field.DeprecationReason))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func printArgs(args []*graphql.Argument) string {
+	if len(args) == 0 {
+		return ""
+	}
+	printed := make([]string, 0, len(args))
+	for _, arg := range args {
+		printed = append(printed, fmt.Sprintf("%s: %s%s", arg.Name(), arg.Type, printDefaultValue(arg.Type, arg.DefaultValue)))
+	}
+	return "(" + strings.Join(printed, ", ") + ")"
+}
+
+func printDirective(directive *graphql.Directive) string {
+	return printDescription(directive.Description, "") +
+		"directive @" + directive.Name + printArgs(directive.Args) +
+		" on " + strings.Join(directive.Locations, " | ")
+}
+
+func printDeprecated(reason string) string {
+	if reason == "" {
+		return ""
+	}
+	return " @deprecated(reason: " + printString(reason) + ")"
+}
+
+// printDefaultValue returns the default value of an argument or input field
+// as a GraphQL literal, preceded by " = ", or an empty string if it has none.
+func printDefaultValue(t graphql.Input, value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return " = " + printValue(t, value)
+}
+
+func printValue(t graphql.Type, value interface{}) string {
+	switch t := t.(type) {
+	case *graphql.NonNull:
+		return printValue(t.OfType, value)
+	case *graphql.List:
+		if values, ok := value.([]interface{}); ok {
+			printed := make([]string, 0, len(values))
+			for _, v := range values {
+				printed = append(printed, printValue(t.OfType, v))
+			}
+			return "[" + strings.Join(printed, ", ") + "]"
+		}
+		return printValue(t.OfType, value)
+	case *graphql.Enum:
+		// enum values are referred to by name
+		for _, v := range t.Values() {
+			if v.Value == value || v.Name == value {
+				return v.Name
+			}
+		}
+	}
+	if s, ok := value.(string); ok {
+		return printString(s)
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
+
+// printString returns s as a GraphQL string literal, whose escape sequences
+// are those of JSON.
+func printString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// printDescription returns the description as a block string, indented by
+// indent and followed by a newline, or an empty string if there is none.
+func printDescription(description, indent string) string {
+	if description == "" {
+		return ""
+	}
+	description = strings.ReplaceAll(description, `"""`, `\"""`)
+	lines := strings.Split(description, "\n")
+	if len(lines) == 1 && len(description) < 70 {
+		return indent + `"""` + description + `"""` + "\n"
+	}
+	var b strings.Builder
+	b.WriteString(indent + `"""` + "\n")
+	for _, line := range lines {
+		if line == "" {
+			b.WriteString("\n")
+			continue
+		}
+		b.WriteString(indent + line + "\n")
+	}
+	b.WriteString(indent + `"""` + "\n")
+	return b.String()
+}
@@ -0,0 +1,76 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintSchema(t *testing.T) {
+	order := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Order",
+		Values: graphql.EnumValueConfigMap{
+			"ASC":  &graphql.EnumValueConfig{Value: "asc"},
+			"DESC": &graphql.EnumValueConfig{Value: "desc", DeprecationReason: "use ASC"},
+		},
+	})
+	node := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		},
+	})
+	entity := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Entity",
+		Description: "Entity is a monitored host.",
+		Interfaces:  []*graphql.Interface{node},
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name": &graphql.Field{Type: graphql.String, Description: "The name of the entity."},
+			"subscriptions": &graphql.Field{
+				Type: graphql.NewList(graphql.NewNonNull(graphql.String)),
+				Args: graphql.FieldConfigArgument{
+					"order": &graphql.ArgumentConfig{Type: order, DefaultValue: "asc"},
+				},
+			},
+		},
+	})
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"entity": &graphql.Field{
+				Type: entity,
+				Args: graphql.FieldConfigArgument{
+					"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: query})
+	require.NoError(t, err)
+
+	want := `"""Entity is a monitored host."""
+type Entity implements Node {
+  id: ID!
+  """The name of the entity."""
+  name: String
+  subscriptions(order: Order = ASC): [String!]
+}
+
+interface Node {
+  id: ID!
+}
+
+enum Order {
+  ASC
+  DESC @deprecated(reason: "use ASC")
+}
+
+type Query {
+  entity(name: String!): Entity
+}
+`
+	assert.Equal(t, want, printSchema(schema))
+}
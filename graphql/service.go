@@ -16,6 +16,13 @@ type Service struct {
 	// the default executor is used.
 	Executor func(p graphql.ExecuteParams) *graphql.Result
 
+	// Limits rejects the queries that are too expensive to execute.
+	Limits QueryLimits
+
+	// DisableIntrospection rejects the queries selecting the __schema or
+	// __type introspection fields.
+	DisableIntrospection bool
+
 	schema graphql.Schema
 	types  *typeRegister
 	mware  []Middleware
@@ -202,6 +209,18 @@ func (service *Service) Do(ctx context.Context, p QueryParams) *Result {
 		}
 	}
 
+	// reject introspection queries when introspection is disabled
+	if service.DisableIntrospection {
+		if err := checkIntrospection(AST); err != nil {
+			return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+		}
+	}
+
+	// reject expensive queries before they are executed
+	if err := service.Limits.Check(AST); err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
 	// execute query
 	return service.Executor(graphql.ExecuteParams{
 		Schema:  schema,
@@ -148,8 +148,7 @@ func (p *promSampleInfluxMetric) addLabel(name string, value string) {
 }
 
 func (b *InfluxBridge) logMetrics(families []*dto.MetricFamily) error {
-	now := model.Now() // milliseconds since the epoch, excluding leap seconds
-	samples, err := expfmt.ExtractSamples(&expfmt.DecodeOptions{Timestamp: now}, families...)
+	samples, err := selectSamples(families, b.filter, b.extraLabels)
 	if err != nil {
 		// some metrics might have been successfully extracted, soldier on
 		b.errLogger.WithError(err).Error("error extracting prometheus metric samples")
@@ -158,6 +157,25 @@ func (b *InfluxBridge) logMetrics(families []*dto.MetricFamily) error {
 	encoder := influx.NewEncoder(b.writer)
 	encoder.FailOnFieldErr(true)
 
+	for _, sample := range samples {
+		metric := (*promSampleInfluxMetric)(sample)
+		if _, err := encoder.Encode(metric); err != nil {
+			b.errLogger.WithError(err).Error("error encoding metric")
+		}
+	}
+
+	return err
+}
+
+// selectSamples extracts the samples of the metric families, keeping only the
+// metrics named in filter, if not empty, and adding the extra labels to each
+// sample. Samples that have not been recorded yet are ignored. The samples
+// that could be extracted are returned even if an error occurred.
+func selectSamples(families []*dto.MetricFamily, filter map[string]struct{}, extraLabels map[string]string) (model.Vector, error) {
+	now := model.Now() // milliseconds since the epoch, excluding leap seconds
+	samples, err := expfmt.ExtractSamples(&expfmt.DecodeOptions{Timestamp: now}, families...)
+
+	selected := make(model.Vector, 0, len(samples))
 	for _, sample := range samples {
 		metric := (*promSampleInfluxMetric)(sample)
 
@@ -169,20 +187,18 @@ func (b *InfluxBridge) logMetrics(families []*dto.MetricFamily) error {
 
 		// ignore metrics with names that are not included in the list of
 		// metrics we want to log
-		if len(b.filter) > 0 {
-			if _, ok := b.filter[metric.Name()]; !ok {
+		if len(filter) > 0 {
+			if _, ok := filter[metric.Name()]; !ok {
 				continue
 			}
 		}
 
-		for tagKey, tagValue := range b.extraLabels {
+		for tagKey, tagValue := range extraLabels {
 			metric.addLabel(tagKey, tagValue)
 		}
 
-		if _, err := encoder.Encode(metric); err != nil {
-			b.errLogger.WithError(err).Error("error encoding metric")
-		}
+		selected = append(selected, sample)
 	}
 
-	return err
+	return selected, err
 }
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/sirupsen/logrus"
+)
+
+// JSONHandler serves the current values of the gathered prometheus metrics as
+// JSON, selected the same way as the metrics logged by an InfluxBridge.
+type JSONHandler struct {
+	gatherer    prometheus.Gatherer
+	filter      map[string]struct{}
+	extraLabels map[string]string
+	errLogger   *logrus.Entry
+}
+
+// JSONMetric is a metric sample served by a JSONHandler.
+type JSONMetric struct {
+	Name      string            `json:"name"`
+	Labels    map[string]string `json:"labels"`
+	Value     float64           `json:"value"`
+	Timestamp int64             `json:"timestamp"`
+}
+
+// NewJSONHandler creates a new JSONHandler. Only the Gatherer, Select,
+// ExtraLabels and ErrLogger fields of the configuration are used.
+func NewJSONHandler(cfg *InfluxBridgeConfig) *JSONHandler {
+	h := &JSONHandler{
+		gatherer:    cfg.Gatherer,
+		filter:      make(map[string]struct{}),
+		extraLabels: make(map[string]string),
+		errLogger:   cfg.ErrLogger,
+	}
+	for _, selectedMetric := range cfg.Select {
+		h.filter[selectedMetric] = struct{}{}
+	}
+	for key, value := range cfg.ExtraLabels {
+		h.extraLabels[key] = value
+	}
+	if h.errLogger == nil {
+		h.errLogger = logrus.NewEntry(logrus.StandardLogger())
+	}
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *JSONHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	families, err := h.gatherer.Gather()
+	if err != nil {
+		h.errLogger.WithError(err).Error("error gathering platform metrics")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	samples, err := selectSamples(families, h.filter, h.extraLabels)
+	if err != nil {
+		// some metrics might have been successfully extracted, soldier on
+		h.errLogger.WithError(err).Error("error extracting prometheus metric samples")
+	}
+
+	metrics := make([]JSONMetric, 0, len(samples))
+	for _, sample := range samples {
+		metric := JSONMetric{
+			Name:      string(sample.Metric[model.MetricNameLabel]),
+			Labels:    make(map[string]string, len(sample.Metric)),
+			Value:     float64(sample.Value),
+			Timestamp: int64(sample.Timestamp),
+		}
+		for k, v := range sample.Metric {
+			if k == model.MetricNameLabel {
+				continue
+			}
+			metric.Labels[string(k)] = string(v)
+		}
+		metrics = append(metrics, metric)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(metrics)
+}
@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONHandler(t *testing.T) {
+	handler := NewJSONHandler(&InfluxBridgeConfig{
+		Gatherer:    newTestGatherer(),
+		Select:      []string{"go_goroutines"},
+		ExtraLabels: map[string]string{extraLabelName: extraLabelValue},
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics/platform", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var metrics []JSONMetric
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &metrics))
+	require.Len(t, metrics, 1)
+	assert.Equal(t, "go_goroutines", metrics[0].Name)
+	assert.Equal(t, extraLabelValue, metrics[0].Labels[extraLabelName])
+	assert.NotZero(t, metrics[0].Value)
+	assert.NotZero(t, metrics[0].Timestamp)
+}
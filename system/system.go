@@ -6,12 +6,14 @@ package system
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"io/ioutil"
 	"net"
 	"net/http"
 	"os/exec"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -261,12 +263,14 @@ func dialAzure(ctx context.Context, wg *sync.WaitGroup) <-chan string {
 // names (e.g. eth0), MACs (if available), and addresses.
 func NetworkInfo() (types.Network, error) {
 	interfaces, err := shirounet.Interfaces()
-
-	network := types.Network{}
-
 	if err != nil {
-		return network, err
+		return types.Network{}, err
 	}
+	return networkFromInterfaces(interfaces), nil
+}
+
+func networkFromInterfaces(interfaces []shirounet.InterfaceStat) types.Network {
+	network := types.Network{}
 
 	for _, i := range interfaces {
 		nInterface := types.NetworkInterface{
@@ -275,11 +279,78 @@ func NetworkInfo() (types.Network, error) {
 		}
 
 		for _, address := range i.Addrs {
-			nInterface.Addresses = append(nInterface.Addresses, address.Addr)
+			nInterface.Addresses = append(nInterface.Addresses, normalizeAddress(address.Addr, i.Name))
 		}
 
 		network.Interfaces = append(network.Interfaces, nInterface)
 	}
 
-	return network, nil
+	return network
+}
+
+// normalizeAddress returns the address of an interface in CIDR notation, with
+// IPv4-mapped IPv6 addresses written as IPv4, IPv6 addresses in their
+// canonical form, and the interface name as the zone of IPv6 link-local
+// addresses (e.g. fe80::1%eth0/64), since they are ambiguous without it. The
+// prefix length is recovered when the mask is written in hexadecimal, as for
+// non-canonical masks. Addresses that can't be parsed are returned as is.
+func normalizeAddress(addr, iface string) string {
+	host, prefix := addr, ""
+	if i := strings.LastIndex(addr, "/"); i >= 0 {
+		host, prefix = addr[:i], addr[i+1:]
+	}
+	if i := strings.Index(host, "%"); i >= 0 {
+		host = host[:i]
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return addr
+	}
+
+	if ip4 := ip.To4(); ip4 != nil {
+		if strings.Contains(host, ":") {
+			// IPv4-mapped IPv6 address, whose prefix covers the IPv6 address
+			if ones, err := strconv.Atoi(prefix); err == nil && ones >= 96 {
+				prefix = strconv.Itoa(ones - 96)
+			}
+		}
+		ip = ip4
+	}
+	if prefix != "" {
+		prefix = normalizePrefix(prefix, len(ip)*8)
+	}
+
+	normalized := ip.String()
+	if ip.To4() == nil && ip.IsLinkLocalUnicast() && iface != "" {
+		normalized += "%" + iface
+	}
+	if prefix != "" {
+		normalized += "/" + prefix
+	}
+	return normalized
+}
+
+// normalizePrefix returns the prefix length of a prefix written either as a
+// length or as a hexadecimal mask of bits bits.
+func normalizePrefix(prefix string, bits int) string {
+	if _, err := strconv.Atoi(prefix); err == nil {
+		return prefix
+	}
+	mask, err := hex.DecodeString(strings.Replace(prefix, ":", "", -1))
+	if err != nil {
+		return prefix
+	}
+	if len(mask) == net.IPv6len && bits == 32 {
+		// IPv4 mask in the 16-byte form
+		mask = mask[12:]
+	}
+	if len(mask)*8 != bits {
+		return prefix
+	}
+	ones, size := net.IPMask(mask).Size()
+	if size == 0 {
+		// non-contiguous mask
+		return prefix
+	}
+	return strconv.Itoa(ones)
 }
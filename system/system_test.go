@@ -5,6 +5,7 @@ package system
 import (
 	"testing"
 
+	shirounet "github.com/shirou/gopsutil/v3/net"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -25,3 +26,41 @@ func TestInfo(t *testing.T) {
 	//assert.NotEmpty(t, nInterface.MAC) // can be empty
 	assert.NotEmpty(t, nInterface.Addresses)
 }
+
+func TestNetworkFromInterfaces(t *testing.T) {
+	interfaces := []shirounet.InterfaceStat{
+		{
+			Name:         "eth0",
+			HardwareAddr: "52:54:00:8a:fe:e6",
+			Addrs: shirounet.InterfaceAddrList{
+				{Addr: "10.0.2.15/24"},
+				{Addr: "2001:0db8:0000:0000:0000:0000:0000:0001/64"},
+				{Addr: "fe80::5054:ff:fe8a:fee6/64"},
+				{Addr: "fe80::5054:ff:fe8a:fee7%eth0/64"},
+				{Addr: "::ffff:192.168.1.17/120"},
+				{Addr: "10.0.3.15/ffffff00"},
+				{Addr: "2001:db8::2/ffffffffffffffff0000000000000000"},
+				{Addr: "fe80::1"},
+				{Addr: "not-an-address"},
+			},
+		},
+	}
+
+	network := networkFromInterfaces(interfaces)
+	if !assert.Len(t, network.Interfaces, 1) {
+		return
+	}
+	assert.Equal(t, "eth0", network.Interfaces[0].Name)
+	assert.Equal(t, "52:54:00:8a:fe:e6", network.Interfaces[0].MAC)
+	assert.Equal(t, []string{
+		"10.0.2.15/24",
+		"2001:db8::1/64",
+		"fe80::5054:ff:fe8a:fee6%eth0/64",
+		"fe80::5054:ff:fe8a:fee7%eth0/64",
+		"192.168.1.17/24",
+		"10.0.3.15/24",
+		"2001:db8::2/64",
+		"fe80::1%eth0",
+		"not-an-address",
+	}, network.Interfaces[0].Addresses)
+}
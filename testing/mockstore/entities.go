@@ -36,3 +36,15 @@ func (s *MockStore) UpdateEntity(ctx context.Context, e *types.Entity) error {
 	args := s.Called(ctx, e)
 	return args.Error(0)
 }
+
+// ListStaleEntities ...
+func (s *MockStore) ListStaleEntities(ctx context.Context, lastSeenBefore int64) ([]*types.Entity, error) {
+	args := s.Called(ctx, lastSeenBefore)
+	return args.Get(0).([]*types.Entity), args.Error(1)
+}
+
+// UpdateEntityLastSeen ...
+func (s *MockStore) UpdateEntityLastSeen(ctx context.Context, name string, lastSeen int64) error {
+	args := s.Called(ctx, name, lastSeen)
+	return args.Error(0)
+}
@@ -13,3 +13,9 @@ func (s *MockStore) GetClusterHealth(ctx context.Context, cluster clientv3.Clust
 	args := s.Called(ctx, cluster, etcdClientTLSConfig)
 	return args.Get(0).(*types.HealthResponse)
 }
+
+// CheckWriteHealth ...
+func (s *MockStore) CheckWriteHealth(ctx context.Context) error {
+	args := s.Called(ctx)
+	return args.Error(0)
+}
@@ -48,3 +48,7 @@ func (v *V2MockStore) Exists(req storev2.ResourceRequest) (bool, error) {
 func (v *V2MockStore) Patch(req storev2.ResourceRequest, w storev2.Wrapper, patcher patch.Patcher, cond *store.ETagCondition) error {
 	return v.Called(req, w, patcher, cond).Error(0)
 }
+
+func (v *V2MockStore) UpdateIfMatch(req storev2.ResourceRequest, w storev2.Wrapper, cond *store.ETagCondition) error {
+	return v.Called(req, w, cond).Error(0)
+}
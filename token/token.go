@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -28,6 +29,17 @@ func Substitution(data, input interface{}) ([]byte, error) {
 	return []byte(*rawMessage), nil
 }
 
+// Validate parses the templates of the input, without evaluating them, and
+// returns an error for the first template that could not be parsed, so that
+// invalid tokens can be rejected before they are substituted
+func Validate(input interface{}) error {
+	inputBytes, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("could not marshal the provided template: %s", err)
+	}
+	return validateToken("", (*json.RawMessage)(&inputBytes))
+}
+
 // SubstituteAsset performs token substitution on an asset with the provided
 // entity
 func SubstituteAsset(asset *corev2.Asset, entity *corev2.Entity) error {
@@ -134,6 +146,49 @@ func substituteToken(key string, data interface{}, message *json.RawMessage) (*j
 	}
 }
 
+func validateToken(key string, message *json.RawMessage) error {
+	if message == nil || len(*message) == 0 {
+		return nil
+	}
+	switch (*message)[0] {
+	case '"':
+		var t string
+		if err := json.Unmarshal([]byte(*message), &t); err != nil {
+			return fmt.Errorf("couldn't evaluate template for %s: %s (string)", key, err)
+		}
+		if _, err := template.New(key).Funcs(funcMap()).Parse(t); err != nil {
+			return fmt.Errorf("%s: could not parse the template: %s", key, err)
+		}
+	case '[':
+		var messages []*json.RawMessage
+		if err := json.Unmarshal([]byte(*message), &messages); err != nil {
+			return fmt.Errorf("couldn't evaluate template for %s: %s (array)", key, err)
+		}
+		for _, m := range messages {
+			if err := validateToken(key, m); err != nil {
+				return err
+			}
+		}
+	case '{':
+		var object map[string]*json.RawMessage
+		if err := json.Unmarshal([]byte(*message), &object); err != nil {
+			return fmt.Errorf("couldn't evaluate template for %s: %s (object)", key, err)
+		}
+		keys := make([]string, 0, len(object))
+		for k := range object {
+			keys = append(keys, k)
+		}
+		// report the same template first when several are invalid
+		sort.Strings(keys)
+		for _, k := range keys {
+			if err := validateToken(k, object[k]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func substituteString(key string, data interface{}, message *json.RawMessage) (*json.RawMessage, error) {
 	var t string
 	if err := json.Unmarshal([]byte(*message), &t); err != nil {
@@ -318,3 +318,24 @@ func TestSubstituteHook(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+	check := corev2.FixtureCheckConfig("check")
+	check.Command = `check-http -u {{ .labels.url | default "http://localhost" }}`
+	check.Labels = map[string]string{"target": "{{ .name }}"}
+	assert.NoError(t, Validate(check))
+
+	// Templates are only parsed, the tokens need not be defined
+	check.Command = "check-http -u {{ .labels.undefined }}"
+	assert.NoError(t, Validate(check))
+
+	check.Command = "check-http -u {{ .labels.url"
+	assert.Error(t, Validate(check))
+
+	check.Command = "check-http"
+	check.Labels["target"] = "{{ .name }"
+	assert.Error(t, Validate(check))
+
+	check.Labels["target"] = "{{ unknownFunc .name }}"
+	assert.Error(t, Validate(check))
+}
@@ -1,12 +1,16 @@
 package transport
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -15,27 +19,64 @@ import (
 
 var ErrTooManyRequests = errors.New("too many requests")
 
+// countingConn counts the bytes written to a network connection.
+type countingConn struct {
+	net.Conn
+	written *int64
+}
+
+func (c countingConn) Write(p []byte) (int, error) {
+	n, err := c.Conn.Write(p)
+	atomic.AddInt64(c.written, int64(n))
+	return n, err
+}
+
+// compressionNegotiated returns true if the handshake response accepted
+// per-message compression.
+func compressionNegotiated(responseHeader http.Header) bool {
+	for _, ext := range responseHeader.Values("Sec-Websocket-Extensions") {
+		if strings.Contains(ext, "permessage-deflate") {
+			return true
+		}
+	}
+	return false
+}
+
 // connect establish the connection to a given websocket backend and returns it
-// along with any error encountered
-func connect(wsServerURL string, tlsOpts *types.TLSOptions, requestHeader http.Header, handshakeTimeout int) (*websocket.Conn, http.Header, error) {
+// along with the counter of the bytes written to the network, and any error
+// encountered. Compression is offered to the backend. The connection is dialed
+// with dial, or a net.Dialer if nil.
+func connect(wsServerURL string, tlsOpts *types.TLSOptions, requestHeader http.Header, handshakeTimeout int, dial DialFunc) (*websocket.Conn, *int64, http.Header, error) {
 	// TODO(grep): configurable max sendq depth
 	u, err := url.Parse(wsServerURL)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	if handshakeTimeout < 1 {
 		handshakeTimeout = 15
 	}
+	written := new(int64)
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
 	dialer := websocket.Dialer{
-		HandshakeTimeout: time.Second * time.Duration(handshakeTimeout),
-		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout:  time.Second * time.Duration(handshakeTimeout),
+		Proxy:             http.ProxyFromEnvironment,
+		EnableCompression: true,
+		NetDialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			conn, err := dial(ctx, network, addr)
+			if err != nil {
+				return nil, err
+			}
+			return countingConn{Conn: conn, written: written}, nil
+		},
 	}
 
 	if tlsOpts != nil {
 		dialer.TLSClientConfig, err = tlsOpts.ToClientTLSConfig()
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 	}
 
@@ -48,27 +89,39 @@ func connect(wsServerURL string, tlsOpts *types.TLSOptions, requestHeader http.H
 				if berr == nil {
 					err = fmt.Errorf("%s: %s", err, string(body))
 				}
-				return nil, resp.Header, err
+				return nil, nil, resp.Header, err
 			}
 			if resp.StatusCode == http.StatusTooManyRequests {
-				return nil, nil, ErrTooManyRequests
+				return nil, nil, nil, ErrTooManyRequests
 			}
-			return nil, resp.Header, fmt.Errorf("connection failed with status %d", resp.StatusCode)
+			return nil, nil, resp.Header, fmt.Errorf("connection failed with status %d", resp.StatusCode)
 		}
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	return conn, resp.Header, nil
+	return conn, written, resp.Header, nil
 }
 
 // Connect causes the transport Client to connect to a given websocket server.
 // Transport is a thin wrapper around a websocket connection that makes the
 // connection safe for concurrent use by multiple goroutines.
 func Connect(wsServerURL string, tlsOpts *types.TLSOptions, requestHeader http.Header, handshakeTimeout int) (Transport, http.Header, error) {
-	conn, resp, err := connect(wsServerURL, tlsOpts, requestHeader, handshakeTimeout)
+	return ConnectWithDialer(wsServerURL, tlsOpts, requestHeader, handshakeTimeout, nil)
+}
+
+// ConnectWithDialer is like Connect, but dials the websocket server with dial,
+// e.g. the DialContext method of a RotatingDialer.
+func ConnectWithDialer(wsServerURL string, tlsOpts *types.TLSOptions, requestHeader http.Header, handshakeTimeout int, dial DialFunc) (Transport, http.Header, error) {
+	conn, written, resp, err := connect(wsServerURL, tlsOpts, requestHeader, handshakeTimeout, dial)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return NewTransport(conn), resp, nil
+	t := &WebSocketTransport{
+		Connection: conn,
+	}
+	if compressionNegotiated(resp) {
+		t.written = written
+	}
+	return t, resp, nil
 }
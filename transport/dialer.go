@@ -0,0 +1,128 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sort"
+	"sync"
+)
+
+// DialFunc connects to the address on the named network, like
+// net.Dialer.DialContext.
+type DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// RotatingDialer resolves the host of the addresses it dials on every
+// connection, and dials the IP addresses the host resolves to in turn,
+// starting with the address following the one of its last connection to that
+// host. Successive connections to a host with several A or AAAA records, such
+// as reconnections after a failure, are thus spread over all of its addresses
+// instead of always preferring the first one.
+type RotatingDialer struct {
+	// Dialer dials the resolved IP addresses. A zero net.Dialer is used if
+	// nil.
+	Dialer *net.Dialer
+
+	// Resolver looks up the IP addresses of the hosts. net.DefaultResolver is
+	// used if nil.
+	Resolver *net.Resolver
+
+	// lookup overrides the resolver in tests.
+	lookup func(ctx context.Context, host string) ([]net.IPAddr, error)
+
+	mu   sync.Mutex
+	next map[string]int
+}
+
+// DialContext implements DialFunc.
+func (d *RotatingDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := d.Dialer
+	if dialer == nil {
+		dialer = &net.Dialer{}
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	if net.ParseIP(host) != nil {
+		return dialer.DialContext(ctx, network, addr)
+	}
+
+	addrs, err := d.resolve(ctx, network, host)
+	if err != nil {
+		return nil, err
+	}
+
+	start := d.start(host, len(addrs))
+	var firstErr error
+	for i := range addrs {
+		idx := (start + i) % len(addrs)
+		conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(addrs[idx].String(), port))
+		if err == nil {
+			d.setNext(host, idx+1)
+			return conn, nil
+		}
+		logger.WithError(err).WithField("address", addrs[idx].String()).Debugf("could not connect to an address of %s", host)
+		if firstErr == nil {
+			firstErr = err
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	// Start with the next address on the next attempt, even though none of
+	// them could be reached, in case the failures were transient
+	d.setNext(host, start+1)
+	return nil, firstErr
+}
+
+// resolve returns the IP addresses of host that can be dialed on network,
+// sorted so that their order does not depend on the order of the records in
+// the DNS response.
+func (d *RotatingDialer) resolve(ctx context.Context, network, host string) ([]net.IPAddr, error) {
+	lookup := d.lookup
+	if lookup == nil {
+		resolver := d.Resolver
+		if resolver == nil {
+			resolver = net.DefaultResolver
+		}
+		lookup = resolver.LookupIPAddr
+	}
+	resolved, err := lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make([]net.IPAddr, 0, len(resolved))
+	for _, addr := range resolved {
+		isIPv4 := addr.IP.To4() != nil
+		if (network == "tcp4" && !isIPv4) || (network == "tcp6" && isIPv4) {
+			continue
+		}
+		addrs = append(addrs, addr)
+	}
+	if len(addrs) == 0 {
+		return nil, &net.DNSError{Err: "no suitable address found", Name: host, IsNotFound: true}
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i].IP.To16(), addrs[j].IP.To16()) < 0
+	})
+	return addrs, nil
+}
+
+// start returns the index of the address of host to dial first, among count
+// addresses.
+func (d *RotatingDialer) start(host string, count int) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.next[host] % count
+}
+
+func (d *RotatingDialer) setNext(host string, next int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.next == nil {
+		d.next = make(map[string]int)
+	}
+	d.next[host] = next
+}
@@ -0,0 +1,126 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"sync"
+	"syscall"
+	"testing"
+)
+
+func TestRotatingDialer(t *testing.T) {
+	var mu sync.Mutex
+	var attempts []string
+	refused := errors.New("refused")
+
+	dialer := &RotatingDialer{
+		Dialer: &net.Dialer{
+			// Record the addresses dialed, and refuse them before connecting
+			Control: func(network, address string, c syscall.RawConn) error {
+				mu.Lock()
+				defer mu.Unlock()
+				attempts = append(attempts, address)
+				return refused
+			},
+		},
+		lookup: func(ctx context.Context, host string) ([]net.IPAddr, error) {
+			if host != "backend.example.com" {
+				return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+			}
+			// The order of the records must not matter
+			return []net.IPAddr{
+				{IP: net.ParseIP("10.0.0.3")},
+				{IP: net.ParseIP("10.0.0.1")},
+				{IP: net.ParseIP("10.0.0.2")},
+			}, nil
+		},
+	}
+
+	want := [][]string{
+		{"10.0.0.1:8081", "10.0.0.2:8081", "10.0.0.3:8081"},
+		{"10.0.0.2:8081", "10.0.0.3:8081", "10.0.0.1:8081"},
+		{"10.0.0.3:8081", "10.0.0.1:8081", "10.0.0.2:8081"},
+		{"10.0.0.1:8081", "10.0.0.2:8081", "10.0.0.3:8081"},
+	}
+	for i := range want {
+		attempts = nil
+		if _, err := dialer.DialContext(context.Background(), "tcp", "backend.example.com:8081"); err == nil {
+			t.Fatal("expected an error")
+		}
+		if !reflect.DeepEqual(attempts, want[i]) {
+			t.Errorf("attempt %d dialed %v, want %v", i, attempts, want[i])
+		}
+	}
+
+	// IPv6 addresses are not dialed on tcp4
+	attempts = nil
+	dialer.lookup = func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("::1")}, {IP: net.ParseIP("10.0.0.1")}}, nil
+	}
+	_, _ = dialer.DialContext(context.Background(), "tcp4", "backend.example.com:8081")
+	if want := []string{"10.0.0.1:8081"}; !reflect.DeepEqual(attempts, want) {
+		t.Errorf("tcp4 dialed %v, want %v", attempts, want)
+	}
+
+	// Lookup errors are returned
+	dialer.lookup = func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	if _, err := dialer.DialContext(context.Background(), "tcp", "unknown.example.com:8081"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestRotatingDialerConnects(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+	_, port, _ := net.SplitHostPort(ln.Addr().String())
+
+	var attempts []string
+	dialer := &RotatingDialer{
+		Dialer: &net.Dialer{
+			// Only the listener's address can be reached
+			Control: func(network, address string, c syscall.RawConn) error {
+				attempts = append(attempts, address)
+				if address != ln.Addr().String() {
+					return errors.New("refused")
+				}
+				return nil
+			},
+		},
+		lookup: func(ctx context.Context, host string) ([]net.IPAddr, error) {
+			return []net.IPAddr{{IP: net.ParseIP("127.0.0.1")}, {IP: net.ParseIP("127.0.0.2")}}, nil
+		},
+	}
+
+	// The next connection starts after the address of the last one
+	want := [][]string{
+		{"127.0.0.1:" + port},
+		{"127.0.0.2:" + port, "127.0.0.1:" + port},
+	}
+	for i := range want {
+		attempts = nil
+		conn, err := dialer.DialContext(context.Background(), "tcp", net.JoinHostPort("backend.example.com", port))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = conn.Close()
+		if !reflect.DeepEqual(attempts, want[i]) {
+			t.Errorf("connection %d dialed %v, want %v", i, attempts, want[i])
+		}
+	}
+}
@@ -10,10 +10,21 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 var (
 	sep = []byte("\n")
+
+	// CompressionBytesSaved counts the bytes saved by the compression of the
+	// messages sent: their size, minus the bytes written to the network. It
+	// is registered by the agent.
+	CompressionBytesSaved = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: CompressionBytesSavedName,
+			Help: "The total number of bytes saved by the compression of the messages sent to sensu-backend",
+		},
+	)
 )
 
 const (
@@ -38,6 +49,10 @@ const (
 
 	// HeaderKeySubscriptions is the HTTP request header specifying the Agent Subscriptions
 	HeaderKeySubscriptions = "Sensu-Subscriptions"
+
+	// CompressionBytesSavedName is the name of the counter of the bytes saved
+	// by websocket compression
+	CompressionBytesSavedName = "sensu_go_agent_websocket_compression_bytes_saved"
 )
 
 // A ClosedError is returned when Receive or Send is called on a closed
@@ -129,6 +144,10 @@ type WebSocketTransport struct {
 	closed     atomic.Value
 	readMu     sync.Mutex
 	writeMu    sync.Mutex
+
+	// written counts the bytes written to the network, when the messages are
+	// compressed.
+	written *int64
 }
 
 // NewTransport creates an initialized Transport and return its pointer.
@@ -269,6 +288,10 @@ func (t *WebSocketTransport) Send(m *Message) (err error) {
 	}()
 
 	msg := Encode(m.Type, m.Payload)
+	var before int64
+	if t.written != nil {
+		before = atomic.LoadInt64(t.written)
+	}
 	if err := t.Connection.WriteMessage(websocket.BinaryMessage, msg); err != nil {
 		// If we get _any_ error, let's just considered the connection closed,
 		// because it's _really_ hard to figure out what errors from the
@@ -280,6 +303,11 @@ func (t *WebSocketTransport) Send(m *Message) (err error) {
 		}
 		return ConnectionError{err.Error()}
 	}
+	if t.written != nil {
+		if saved := int64(len(msg)) - (atomic.LoadInt64(t.written) - before); saved > 0 {
+			CompressionBytesSaved.Add(float64(saved))
+		}
+	}
 
 	return nil
 }
@@ -1,6 +1,7 @@
 package transport
 
 import (
+	"bytes"
 	"crypto/rand"
 	"encoding/json"
 	"net/http"
@@ -8,6 +9,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -48,6 +51,37 @@ func TestTransportSendReceive(t *testing.T) {
 	<-done
 }
 
+func TestTransportCompression(t *testing.T) {
+	payload := bytes.Repeat([]byte("cpu.usage 42 1600000000\n"), 1000)
+
+	for _, compression := range []bool{false, true} {
+		done := make(chan struct{})
+		server := &Server{upgrader: &websocket.Upgrader{EnableCompression: compression}}
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			transport, err := server.Serve(w, r)
+			assert.NoError(t, err)
+			msg, err := transport.Receive()
+			assert.NoError(t, err)
+			assert.Equal(t, payload, msg.Payload)
+			close(done)
+		}))
+
+		before := testutil.ToFloat64(CompressionBytesSaved)
+		clientTransport, _, err := Connect(strings.Replace(ts.URL, "http", "ws", 1), nil, nil, 5)
+		require.NoError(t, err)
+		require.NoError(t, clientTransport.Send(&Message{Type: MessageTypeEvent, Payload: payload}))
+		<-done
+		ts.Close()
+
+		saved := testutil.ToFloat64(CompressionBytesSaved) - before
+		if compression {
+			assert.Greater(t, saved, float64(len(payload)/2))
+		} else {
+			assert.Equal(t, float64(0), saved)
+		}
+	}
+}
+
 func TestClosedWebsocket(t *testing.T) {
 	done := make(chan struct{}, 1)
 
@@ -69,6 +69,13 @@ type ExponentialBackoff struct {
 	// this multiplier. If not supplied, it will be set to DefaultMultiplier.
 	Multiplier float64 `json:"multiplier"`
 
+	// Jitter is the fraction of each delay that is randomized, between 0 and
+	// 1. The actual delay is picked uniformly between (1 - Jitter) times and
+	// the full capped delay, so that concurrent clients spread their attempts
+	// even once MaxDelayInterval is reached. If zero, a random delay of up to
+	// the current interval is added to the next interval instead.
+	Jitter float64 `json:"jitter,omitempty"`
+
 	// start contains the starting time of the retry attempts
 	start time.Time
 }
@@ -102,6 +109,11 @@ func (e *ExponentialBackoff) UnmarshalJSON(b []byte) error {
 		}
 		e.MaxDelayInterval = time.Duration(td)
 	}
+	if jitter, ok := blob["jitter"]; ok {
+		if err := json.Unmarshal(*jitter, &e.Jitter); err != nil {
+			return err
+		}
+	}
 	if maxElapsed, ok := blob["max_elapsed_time"]; ok {
 		var td JSONTimeDuration
 		if err := json.Unmarshal(*maxElapsed, &td); err != nil {
@@ -119,6 +131,7 @@ func (e ExponentialBackoff) MarshalJSON() (out []byte, err error) {
 		MaxElapsedTime       JSONTimeDuration `json:"max_elapsed_time,omitempty"`
 		MaxRetryAttempts     int              `json:"max_retry_attempts,omitempty"`
 		Multiplier           float64          `json:"multiplier"`
+		Jitter               float64          `json:"jitter,omitempty"`
 	}
 	eb := ebFacade{
 		InitialDelayInterval: JSONTimeDuration(e.InitialDelayInterval),
@@ -126,6 +139,7 @@ func (e ExponentialBackoff) MarshalJSON() (out []byte, err error) {
 		MaxElapsedTime:       JSONTimeDuration(e.MaxElapsedTime),
 		MaxRetryAttempts:     e.MaxRetryAttempts,
 		Multiplier:           e.Multiplier,
+		Jitter:               e.Jitter,
 	}
 	return json.Marshal(eb)
 }
@@ -165,7 +179,7 @@ func (b *ExponentialBackoff) Retry(fn Func) error {
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
-			case <-time.After(time.Duration(wait)):
+			case <-time.After(b.jittered(wait)):
 			}
 
 			// Exponentially increase that sleep duration
@@ -175,9 +189,11 @@ func (b *ExponentialBackoff) Retry(fn Func) error {
 			}
 			wait = time.Duration(float64(wait) * multiplier)
 
-			// Add a jitter (randomized delay) for the next attempt, to prevent
-			// potential collisions
-			wait = wait + time.Duration(rand.Float64()*float64(wait))
+			if b.Jitter == 0 {
+				// Add a jitter (randomized delay) for the next attempt, to prevent
+				// potential collisions
+				wait = wait + time.Duration(rand.Float64()*float64(wait))
+			}
 		} else {
 			// Save the current time, in order to measure the total execution time
 			b.start = time.Now()
@@ -190,3 +206,16 @@ func (b *ExponentialBackoff) Retry(fn Func) error {
 
 	return ErrMaxRetryAttempts
 }
+
+// jittered returns the amount of time to sleep for the given delay, randomly
+// reduced by up to Jitter times the delay.
+func (b *ExponentialBackoff) jittered(wait time.Duration) time.Duration {
+	jitter := b.Jitter
+	if jitter <= 0 {
+		return wait
+	}
+	if jitter > 1 {
+		jitter = 1
+	}
+	return wait - time.Duration(rand.Float64()*jitter*float64(wait))
+}
@@ -127,3 +127,18 @@ func TestMarshalExponentialBackoff(t *testing.T) {
 		t.Fatal("missing initial_delay_interval")
 	}
 }
+
+func TestExponentialBackoffJitter(t *testing.T) {
+	b := ExponentialBackoff{Jitter: 0.25}
+	for i := 0; i < 100; i++ {
+		wait := b.jittered(time.Second)
+		if wait < 750*time.Millisecond || wait > time.Second {
+			t.Fatalf("jittered delay out of bounds: %s", wait)
+		}
+	}
+
+	b.Jitter = 0
+	if got, want := b.jittered(time.Second), time.Second; got != want {
+		t.Fatalf("bad delay without jitter: got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,88 @@
+// Package schedule computes the execution times of scheduled checks. It is
+// shared by the backend scheduler and the execution preview of sensuctl.
+package schedule
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	cron "github.com/robfig/cron/v3"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// Horizon is how far in the future NextExecutions looks for executions that
+// are not subdued.
+const Horizon = 366 * 24 * time.Hour
+
+// IntervalSplay returns the execution splay of an interval check, derived from
+// its name so that its executions are consistent between process restarts.
+func IntervalSplay(name string) uint64 {
+	sum := md5.Sum([]byte(name))
+	return binary.LittleEndian.Uint64(sum[:])
+}
+
+// NextIntervalTime returns the time between now and the next execution of an
+// interval check with the given splay.
+func NextIntervalTime(now time.Time, splay uint64, interval time.Duration) time.Duration {
+	offset := (splay - uint64(now.UnixNano())) % uint64(interval)
+	return time.Duration(offset)
+}
+
+// CronSpec returns the cron string of the check, prefixed with its cron
+// timezone so that it is evaluated in that time zone, DST transitions
+// included.
+func CronSpec(check *corev2.CheckConfig) string {
+	if check.CronTimezone == "" {
+		return check.Cron
+	}
+	return "CRON_TZ=" + check.CronTimezone + " " + check.Cron
+}
+
+// NextCronTime returns the time between now and the next execution of a cron
+// check.
+func NextCronTime(now time.Time, cronStr string) (time.Duration, error) {
+	schedule, err := cron.ParseStandard(cronStr)
+	if err != nil {
+		return 0, err
+	}
+	return schedule.Next(now).Sub(now), nil
+}
+
+// NextExecutions returns up to n execution times of the check after now,
+// skipping the ones during which the check is subdued. Fewer times are
+// returned if the check is subdued for most of the Horizon.
+func NextExecutions(check *corev2.CheckConfig, now time.Time, n int) ([]time.Time, error) {
+	var next func(time.Time) time.Time
+	if check.Cron != "" {
+		schedule, err := cron.ParseStandard(CronSpec(check))
+		if err != nil {
+			return nil, err
+		}
+		next = schedule.Next
+	} else {
+		if check.Interval == 0 {
+			return nil, errors.New("check has neither an interval nor a cron")
+		}
+		interval := time.Duration(check.Interval) * time.Second
+		splay := IntervalSplay(check.Name)
+		first := true
+		next = func(t time.Time) time.Time {
+			if first {
+				first = false
+				return t.Add(NextIntervalTime(t, splay, interval))
+			}
+			return t.Add(interval)
+		}
+	}
+
+	times := make([]time.Time, 0, n)
+	end := now.Add(Horizon)
+	for t := next(now); len(times) < n && !t.IsZero() && t.Before(end); t = next(t) {
+		if !check.IsSubduedAt(t) {
+			times = append(times, t)
+		}
+	}
+	return times, nil
+}
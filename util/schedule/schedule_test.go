@@ -0,0 +1,95 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextIntervalTime(t *testing.T) {
+	now := time.Now()
+	interval := 10 * time.Second
+	splay := IntervalSplay("check1")
+
+	offset := NextIntervalTime(now, splay, interval)
+	assert.True(t, offset >= 0 && offset < interval)
+
+	// The executions are aligned on the splay, regardless of now
+	later := now.Add(3 * time.Second)
+	assert.Equal(t, now.Add(offset).UnixNano()%int64(interval), later.Add(NextIntervalTime(later, splay, interval)).UnixNano()%int64(interval))
+}
+
+func TestNextExecutionsInterval(t *testing.T) {
+	check := corev2.FixtureCheckConfig("check1")
+	check.Interval = 60
+	now := time.Now()
+
+	times, err := NextExecutions(check, now, 3)
+	require.NoError(t, err)
+	require.Len(t, times, 3)
+	assert.True(t, times[0].Sub(now) < time.Minute)
+	assert.Equal(t, time.Minute, times[1].Sub(times[0]))
+	assert.Equal(t, time.Minute, times[2].Sub(times[1]))
+}
+
+func TestNextExecutionsCron(t *testing.T) {
+	check := corev2.FixtureCheckConfig("check1")
+	check.Cron = "0 * * * *"
+	now := time.Date(2022, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	times, err := NextExecutions(check, now, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		time.Date(2022, 1, 1, 11, 0, 0, 0, time.UTC),
+		time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC),
+	}, times)
+}
+
+func TestNextExecutionsCronTimezone(t *testing.T) {
+	check := corev2.FixtureCheckConfig("check1")
+	check.Cron = "0 9 * * *"
+	check.CronTimezone = "America/New_York"
+	now := time.Date(2022, 3, 12, 0, 0, 0, 0, time.UTC)
+
+	// 9am in New York moves from 14:00 to 13:00 UTC across the DST transition
+	times, err := NextExecutions(check, now, 2)
+	require.NoError(t, err)
+	require.Len(t, times, 2)
+	assert.Equal(t, time.Date(2022, 3, 12, 14, 0, 0, 0, time.UTC), times[0].UTC())
+	assert.Equal(t, time.Date(2022, 3, 13, 13, 0, 0, 0, time.UTC), times[1].UTC())
+}
+
+func TestNextExecutionsSubdued(t *testing.T) {
+	check := corev2.FixtureCheckConfig("check1")
+	check.Cron = "0 * * * *"
+	check.Subdues = []*corev2.TimeWindowRepeated{
+		{
+			Begin:  "2022-01-01T10:45:00Z",
+			End:    "2022-01-01T12:15:00Z",
+			Repeat: []string{corev2.RepeatPeriodDaily},
+		},
+	}
+	now := time.Date(2022, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	times, err := NextExecutions(check, now, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		time.Date(2022, 1, 1, 13, 0, 0, 0, time.UTC),
+		time.Date(2022, 1, 1, 14, 0, 0, 0, time.UTC),
+	}, times)
+}
+
+func TestNextExecutionsInvalid(t *testing.T) {
+	check := corev2.FixtureCheckConfig("check1")
+	check.Interval = 0
+	check.Cron = ""
+	_, err := NextExecutions(check, time.Now(), 1)
+	assert.Error(t, err)
+
+	check.Cron = "invalid"
+	_, err = NextExecutions(check, time.Now(), 1)
+	assert.Error(t, err)
+}